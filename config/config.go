@@ -2,14 +2,18 @@ package config
 
 import (
 	"log"
+	"net"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	ServerPort   int
+	Proxies      []*net.IPNet
 	DBPort       int
 	DBHost       string
 	DBUser       string
@@ -27,6 +31,13 @@ type Config struct {
 	Redis        Redis
 	ResiKey      string
 	FirebaseKey  string
+	Scheduler    Scheduler
+	Payment      Payment
+	Shipping     Shipping
+	Tracking     Tracking
+	Social       Social
+	Mail         Mail
+	Inventory    Inventory
 }
 
 type Redis struct {
@@ -34,6 +45,47 @@ type Redis struct {
 	Pass string
 }
 
+type Scheduler struct {
+	Interval         time.Duration
+	OrderExpiry      time.Duration
+	TrackingInterval time.Duration
+	OutboxInterval   time.Duration
+}
+
+type Payment struct {
+	Gateway     string
+	Environment string
+	FakeStatus  string
+}
+
+type Shipping struct {
+	Provider   string
+	OriginCity string
+}
+
+type Tracking struct {
+	Provider string
+}
+
+type Mail struct {
+	Driver  string
+	Host    string
+	Port    int
+	User    string
+	Pass    string
+	From    string
+	SinkDir string
+}
+
+type Inventory struct {
+	LowStockThreshold uint64
+}
+
+type Social struct {
+	Provider string
+	Audience string
+}
+
 func InitConfig() *Config {
 	return loadConfig()
 
@@ -42,6 +94,14 @@ func InitConfig() *Config {
 func loadConfig() *Config {
 
 	var res = new(Config)
+	res.Scheduler.Interval = 5 * time.Minute
+	res.Scheduler.OrderExpiry = 24 * time.Hour
+	res.Scheduler.TrackingInterval = 30 * time.Minute
+	res.Scheduler.OutboxInterval = 10 * time.Second
+	res.Mail.Host = "smtp.gmail.com"
+	res.Mail.Port = 587
+	res.Mail.SinkDir = "mailbox"
+	res.Inventory.LowStockThreshold = 5
 	_, err := os.Stat(".env")
 	if err == nil {
 		err := godotenv.Load()
@@ -59,6 +119,17 @@ func loadConfig() *Config {
 		res.ServerPort = port
 	}
 
+	if value, found := os.LookupEnv("TRUSTED_PROXIES"); found && value != "" {
+		for _, cidr := range strings.Split(value, ",") {
+			_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+			if err != nil {
+				log.Fatal("Config : invalid trusted proxy", err.Error())
+				return nil
+			}
+			res.Proxies = append(res.Proxies, network)
+		}
+	}
+
 	if value, found := os.LookupEnv("DBPORT"); found {
 		port, err := strconv.Atoi(value)
 		if err != nil {
@@ -120,6 +191,97 @@ func loadConfig() *Config {
 	if value, found := os.LookupEnv("FIREBASEKEY"); found {
 		res.FirebaseKey = value
 	}
+	if value, found := os.LookupEnv("PAYMENT_GATEWAY"); found {
+		res.Payment.Gateway = value
+	}
+	if value, found := os.LookupEnv("MIDTRANS_ENV"); found {
+		res.Payment.Environment = value
+	}
+	if value, found := os.LookupEnv("PAYMENT_FAKE_STATUS"); found {
+		res.Payment.FakeStatus = value
+	}
+	if value, found := os.LookupEnv("SHIPPING_PROVIDER"); found {
+		res.Shipping.Provider = value
+	}
+	if value, found := os.LookupEnv("SHIPPING_ORIGIN_CITY"); found {
+		res.Shipping.OriginCity = value
+	}
+	if value, found := os.LookupEnv("SCHEDULER_INTERVAL_MINUTES"); found {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			log.Fatal("Config : invalid scheduler interval")
+			return nil
+		}
+		res.Scheduler.Interval = time.Duration(minutes) * time.Minute
+	}
+	if value, found := os.LookupEnv("ORDER_EXPIRY_MINUTES"); found {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			log.Fatal("Config : invalid order expiry")
+			return nil
+		}
+		res.Scheduler.OrderExpiry = time.Duration(minutes) * time.Minute
+	}
+	if value, found := os.LookupEnv("TRACKING_SYNC_INTERVAL_MINUTES"); found {
+		minutes, err := strconv.Atoi(value)
+		if err != nil || minutes <= 0 {
+			log.Fatal("Config : invalid tracking sync interval")
+			return nil
+		}
+		res.Scheduler.TrackingInterval = time.Duration(minutes) * time.Minute
+	}
+	if value, found := os.LookupEnv("NOTIFICATION_OUTBOX_INTERVAL_SECONDS"); found {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds <= 0 {
+			log.Fatal("Config : invalid notification outbox interval")
+			return nil
+		}
+		res.Scheduler.OutboxInterval = time.Duration(seconds) * time.Second
+	}
+	if value, found := os.LookupEnv("TRACKING_PROVIDER"); found {
+		res.Tracking.Provider = value
+	}
+	if value, found := os.LookupEnv("SOCIAL_PROVIDER"); found {
+		res.Social.Provider = value
+	}
+	if value, found := os.LookupEnv("SOCIAL_AUDIENCE"); found {
+		res.Social.Audience = value
+	}
+	if value, found := os.LookupEnv("MAIL_DRIVER"); found {
+		res.Mail.Driver = value
+	}
+	if value, found := os.LookupEnv("SMTP_HOST"); found && value != "" {
+		res.Mail.Host = value
+	}
+	if value, found := os.LookupEnv("SMTP_PORT"); found && value != "" {
+		port, err := strconv.Atoi(value)
+		if err != nil {
+			log.Fatal("Config : invalid smtp port", err.Error())
+			return nil
+		}
+		res.Mail.Port = port
+	}
+	if value, found := os.LookupEnv("SMTP_USER"); found {
+		res.Mail.User = value
+		res.Mail.From = value
+	}
+	if value, found := os.LookupEnv("SMTP_PASS"); found {
+		res.Mail.Pass = value
+	}
+	if value, found := os.LookupEnv("MAIL_FROM"); found && value != "" {
+		res.Mail.From = value
+	}
+	if value, found := os.LookupEnv("MAIL_SINK_DIR"); found && value != "" {
+		res.Mail.SinkDir = value
+	}
+	if value, found := os.LookupEnv("LOW_STOCK_THRESHOLD"); found && value != "" {
+		threshold, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			log.Fatal("Config : invalid low stock threshold", err.Error())
+			return nil
+		}
+		res.Inventory.LowStockThreshold = threshold
+	}
 
 	return res
 }
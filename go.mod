@@ -15,6 +15,7 @@ require (
 	github.com/redis/go-redis/v9 v9.3.0
 	github.com/sashabaranov/go-openai v1.17.9
 	github.com/sirupsen/logrus v1.9.3
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	github.com/stretchr/testify v1.8.4
 	github.com/wneessen/go-mail v0.4.0
 	go.mongodb.org/mongo-driver v1.13.0
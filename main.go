@@ -39,6 +39,9 @@ import (
 	hHome "github.com/capstone-kelompok-7/backend-disappear/module/feature/homepage/handler"
 	rHome "github.com/capstone-kelompok-7/backend-disappear/module/feature/homepage/repository"
 	sHome "github.com/capstone-kelompok-7/backend-disappear/module/feature/homepage/service"
+	hNotification "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/handler"
+	rNotification "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/repository"
+	sNotification "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/service"
 	hOrder "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/handler"
 	rOrder "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/repository"
 	sOrder "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/service"
@@ -48,6 +51,14 @@ import (
 	hReview "github.com/capstone-kelompok-7/backend-disappear/module/feature/review/handler"
 	rReview "github.com/capstone-kelompok-7/backend-disappear/module/feature/review/repository"
 	sReview "github.com/capstone-kelompok-7/backend-disappear/module/feature/review/service"
+	hRole "github.com/capstone-kelompok-7/backend-disappear/module/feature/role/handler"
+	rRole "github.com/capstone-kelompok-7/backend-disappear/module/feature/role/repository"
+	sRole "github.com/capstone-kelompok-7/backend-disappear/module/feature/role/service"
+	hSearch "github.com/capstone-kelompok-7/backend-disappear/module/feature/search/handler"
+	rSearch "github.com/capstone-kelompok-7/backend-disappear/module/feature/search/repository"
+	sSearch "github.com/capstone-kelompok-7/backend-disappear/module/feature/search/service"
+	hShipping "github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/handler"
+	sShipping "github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/service"
 	hUser "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/handler"
 	rUser "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/repository"
 	sUser "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/service"
@@ -55,10 +66,20 @@ import (
 	rVoucher "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/repository"
 	sVoucher "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/service"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/caching/redis"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/identity"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/payment"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/scheduler"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/tracking"
 	"github.com/sashabaranov/go-openai"
+	"time"
 
+	"context"
+	"errors"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/middlewares"
 	"github.com/capstone-kelompok-7/backend-disappear/routes"
@@ -71,49 +92,75 @@ import (
 func main() {
 	e := echo.New()
 	var initConfig = config.InitConfig()
+	e.IPExtractor = middlewares.IPExtractor(initConfig.Proxies)
 
 	db := database.InitDatabase(*initConfig)
 	rdb := redis.NewRedisClient(*initConfig)
 	database.Migrate(db)
-	jwtService := utils.NewJWT(initConfig.Secret)
+	jwtService := utils.NewJWT(initConfig.Secret, rdb)
 	hash := utils.NewHash()
 	generatorID := utils.NewGeneratorUUID(db)
 	fcm := sendnotif.NewFcmService()
-	coreApi := payment.InitSnapMidtrans(*initConfig)
-	emailSender := email.NewEmailService()
+	paymentGateway := payment.NewPaymentGateway(*initConfig)
+	rateProvider := shippingrate.NewRateProvider(*initConfig)
+	tracker := tracking.NewTracker(*initConfig)
+	identityVerifier := identity.NewVerifier(*initConfig)
+	emailSender := email.NewEmailService(*initConfig)
+	txManager := database.NewTransactionManager(db)
 
-	userRepo := rUser.NewUserRepository(db)
-	userService := sUser.NewUserService(userRepo, hash)
+	mgodb := database.InitMongoDB(*initConfig)
+
+	userRepo := rUser.NewUserRepository(db, mgodb)
+	userService := sUser.NewUserService(userRepo, hash, jwtService, emailSender)
 	userHandler := hUser.NewUserHandler(userService)
 
-	authRepo := rAuth.NewAuthRepository(db)
-	authService := sAuth.NewAuthService(authRepo, jwtService, userService, hash, rdb, emailSender)
-	authHandler := hAuth.NewAuthHandler(authService, userService)
+	roleRepo := rRole.NewRoleRepository(db)
+	roleService := sRole.NewRoleService(roleRepo, userService, rdb)
+	roleHandler := hRole.NewRoleHandler(roleService)
+	if err := roleService.SeedDefaults(); err != nil {
+		e.Logger.Fatalf("Gagal menyiapkan peran bawaan: %v", err)
+	}
 
-	voucherRepo := rVoucher.NewVoucherRepository(db)
-	voucherService := sVoucher.NewVoucherService(voucherRepo, userService)
-	voucherHandler := hVoucher.NewVoucherHandler(voucherService)
+	fcmRepo := rFcm.NewFcmRepository(db, fcm)
+	authRepo := rAuth.NewAuthRepository(db)
+	authService := sAuth.NewAuthService(authRepo, jwtService, userService, hash, rdb, emailSender, identityVerifier, fcmRepo, roleService)
+	authHandler := hAuth.NewAuthHandler(authService, userService, emailSender)
 
-	mgodb := database.InitMongoDB(*initConfig)
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	chatbotRepo := rChatbot.NewAssistantRepository(mgodb, db)
 	chatbotService := sChatbot.NewAssistantService(chatbotRepo, client, *initConfig)
 	chatbotHandler := hChatbot.NewAssistantHandler(chatbotService)
 
+	fcmService := sFcm.NewFcmService(fcmRepo)
+	fcmHandler := hFcm.NewFcmHandler(fcmService)
+
+	notificationRepo := rNotification.NewNotificationRepository(db)
+	notificationService := sNotification.NewNotificationService(notificationRepo, fcmService, userService, emailSender)
+	notificationHandler := hNotification.NewNotificationHandler(notificationService)
+
+	searchRepo := rSearch.NewSearchRepository(db)
+	searchService := sSearch.NewSearchService(searchRepo)
+
 	productRepo := repository.NewProductRepository(db)
-	productService := service.NewProductService(productRepo, chatbotService)
+	productService := service.NewProductService(productRepo, chatbotService, notificationService, roleService, searchService, txManager, initConfig.Inventory.LowStockThreshold)
 	productHandler := handler.NewProductHandler(productService)
 
+	voucherRepo := rVoucher.NewVoucherRepository(db)
+	voucherService := sVoucher.NewVoucherService(voucherRepo, userService, productService, txManager, rdb)
+	voucherHandler := hVoucher.NewVoucherHandler(voucherService)
+
 	categoryRepo := rCategory.NewCategoryRepository(db)
-	categoryService := sCategory.NewCategoryService(categoryRepo)
+	categoryService := sCategory.NewCategoryService(categoryRepo, productService)
 	categoryHandler := hCategory.NewCategoryHandler(categoryService)
 
 	articleRepo := rArticle.NewArticleRepository(db)
-	articleService := sArticle.NewArticleService(articleRepo)
+	articleService := sArticle.NewArticleService(articleRepo, searchService)
 	articleHandler := hArticle.NewArticleHandler(articleService)
 
+	searchHandler := hSearch.NewSearchHandler(searchService, productService, articleService)
+
 	challengeRepo := rChallenge.NewChallengeRepository(db)
-	challengeService := sChallenge.NewChallengeService(challengeRepo, userService)
+	challengeService := sChallenge.NewChallengeService(challengeRepo, userService, notificationService)
 	challengeHandler := hChallenge.NewChallengeHandler(challengeService)
 
 	carouselRepo := rCarousel.NewCarouselRepository(db)
@@ -128,17 +175,16 @@ func main() {
 	reviewService := sReview.NewReviewService(reviewRepo, productService)
 	reviewHandler := hReview.NewReviewHandler(reviewService)
 
-	fcmRepo := rFcm.NewFcmRepository(db, fcm)
-	fcmService := sFcm.NewFcmService(fcmRepo)
-	fcmHandler := hFcm.NewFcmHandler(fcmService)
-
 	cartRepo := rCart.NewCartRepository(db)
 	cartService := sCart.NewCartService(cartRepo, productService)
 	cartHandler := hCart.NewCartHandler(cartService)
 
-	orderRepo := rOrder.NewOrderRepository(db, coreApi)
+	shippingService := sShipping.NewShippingService(rateProvider, initConfig.Shipping.OriginCity, addressService, productService)
+	shippingHandler := hShipping.NewShippingHandler(shippingService)
+
+	orderRepo := rOrder.NewOrderRepository(db)
 	orderService := sOrder.NewOrderService(orderRepo, generatorID, productService,
-		voucherService, addressService, userService, cartService, fcmService)
+		voucherService, addressService, userService, cartService, notificationService, txManager, paymentGateway, shippingService, tracker)
 	orderHandler := hOrder.NewOrderHandler(orderService)
 
 	dashboardRepo := rDashboard.NewDashboardRepository(db)
@@ -149,6 +195,25 @@ func main() {
 	homeService := sHome.NewHomepageService(homeRepo)
 	homeHandler := hHome.NewHomepageHandler(homeService)
 
+	jobScheduler := scheduler.NewScheduler(rdb)
+	jobScheduler.AddJob("order-expiry", initConfig.Scheduler.Interval, func() error {
+		_, err := orderService.ExpireUnpaidOrders(time.Now().Add(-initConfig.Scheduler.OrderExpiry))
+		return err
+	})
+	jobScheduler.AddJob("shipment-tracking", initConfig.Scheduler.TrackingInterval, func() error {
+		_, err := orderService.SyncShipments()
+		return err
+	})
+	jobScheduler.AddJob("chat-purge", initConfig.Scheduler.Interval, func() error {
+		_, err := userService.PurgeDeletedChats()
+		return err
+	})
+	jobScheduler.AddJob("notification-outbox", initConfig.Scheduler.OutboxInterval, func() error {
+		_, err := notificationService.DeliverPending()
+		return err
+	})
+	jobScheduler.Start()
+
 	e.Pre(middleware.RemoveTrailingSlash())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"*"},
@@ -160,21 +225,41 @@ func main() {
 		return c.String(http.StatusOK, "Hello, Disappear! 🦄✨🍩")
 	})
 
-	routes.RouteUser(e, userHandler, jwtService, userService)
-	routes.RouteAuth(e, authHandler, jwtService, userService)
-	routes.RouteVoucher(e, voucherHandler, jwtService, userService)
-	routes.RouteProduct(e, productHandler, jwtService, userService)
-	routes.RouteArticle(e, articleHandler, jwtService, userService)
-	routes.RouteChallenge(e, challengeHandler, jwtService, userService)
-	routes.RouteCategory(e, categoryHandler, jwtService, userService)
-	routes.RouteCarousel(e, carouselHandler, jwtService, userService)
-	routes.RouteAddress(e, addressHandler, jwtService, userService)
-	routes.RouteReview(e, reviewHandler, jwtService, userService)
-	routes.RouteCart(e, cartHandler, jwtService, userService)
-	routes.RouteOrder(e, orderHandler, jwtService, userService)
-	routes.RouteAssistant(e, chatbotHandler, jwtService, userService)
-	routes.RouteDashboard(e, dashboardHandler, jwtService, userService)
+	routes.RouteUser(e, userHandler, jwtService, userService, roleService)
+	routes.RouteAuth(e, authHandler, jwtService, userService, rdb)
+	routes.RouteVoucher(e, voucherHandler, jwtService, userService, roleService)
+	routes.RouteProduct(e, productHandler, jwtService, userService, roleService)
+	routes.RouteArticle(e, articleHandler, jwtService, userService, roleService)
+	routes.RouteChallenge(e, challengeHandler, jwtService, userService, roleService)
+	routes.RouteCategory(e, categoryHandler, jwtService, userService, roleService)
+	routes.RouteCarousel(e, carouselHandler, jwtService, userService, roleService)
+	routes.RouteAddress(e, addressHandler, jwtService, userService, roleService)
+	routes.RouteReview(e, reviewHandler, jwtService, userService, roleService)
+	routes.RouteCart(e, cartHandler, jwtService, userService, roleService)
+	routes.RouteOrder(e, orderHandler, jwtService, userService, roleService)
+	routes.RouteAssistant(e, chatbotHandler, jwtService, userService, roleService)
+	routes.RouteDashboard(e, dashboardHandler, jwtService, userService, roleService)
 	routes.RouteHomepage(e, homeHandler, jwtService, userService)
-	routes.RouteFcm(e, fcmHandler, jwtService, userService)
-	e.Logger.Fatalf(e.Start(fmt.Sprintf(":%d", initConfig.ServerPort)).Error())
+	routes.RouteFcm(e, fcmHandler, jwtService, userService, roleService)
+	routes.RouteNotification(e, notificationHandler, jwtService, userService, roleService)
+	routes.RouteShipping(e, shippingHandler, jwtService, userService)
+	routes.RouteRole(e, roleHandler, jwtService, userService, roleService)
+	routes.RouteSearch(e, searchHandler, jwtService, userService, roleService)
+
+	go func() {
+		if err := e.Start(fmt.Sprintf(":%d", initConfig.ServerPort)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatalf(err.Error())
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		e.Logger.Error(err.Error())
+	}
+	jobScheduler.Stop()
 }
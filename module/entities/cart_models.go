@@ -9,13 +9,15 @@ type CartModels struct {
 }
 
 type CartItemModels struct {
-	ID         uint64         `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
-	CartID     uint64         `gorm:"column:cart_id;type:BIGINT UNSIGNED" json:"cart_id"`
-	ProductID  uint64         `gorm:"column:product_id; type:BIGINT UNSIGNED" json:"product_id"`
-	Quantity   uint64         `gorm:"column:quantity;type:BIGINT UNSIGNED" json:"quantity"`
-	Price      uint64         `gorm:"column:price;type:BIGINT UNSIGNED" json:"price"`
-	TotalPrice uint64         `gorm:"column:total_price;type:BIGINT UNSIGNED" json:"total_price"`
-	Product    *ProductModels `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	ID         uint64                `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	CartID     uint64                `gorm:"column:cart_id;type:BIGINT UNSIGNED" json:"cart_id"`
+	ProductID  uint64                `gorm:"column:product_id; type:BIGINT UNSIGNED" json:"product_id"`
+	VariantID  *uint64               `gorm:"column:variant_id;type:BIGINT UNSIGNED" json:"variant_id"`
+	Quantity   uint64                `gorm:"column:quantity;type:BIGINT UNSIGNED" json:"quantity"`
+	Price      uint64                `gorm:"column:price;type:BIGINT UNSIGNED" json:"price"`
+	TotalPrice uint64                `gorm:"column:total_price;type:BIGINT UNSIGNED" json:"total_price"`
+	Product    *ProductModels        `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Variant    *ProductVariantModels `json:"variant,omitempty" gorm:"foreignKey:VariantID"`
 }
 
 func (CartModels) TableName() string {
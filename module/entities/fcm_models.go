@@ -2,18 +2,82 @@ package entities
 
 import "time"
 
+// FcmModels is the in-app inbox: one row per notification a user can read.
+// Type is the event that caused it; OrderID is set only when it is about an
+// order.
 type FcmModels struct {
-	ID        uint64      `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
-	OrderID   string      `gorm:"column:order_id;type:VARCHAR(255)" json:"order_id"`
-	UserID    uint64      `gorm:"column:user_id;type:BIGINT UNSIGNED" json:"user_id"`
-	Title     string      `gorm:"column:title;type:varchar(255)" json:"title"`
-	Body      string      `gorm:"column:body;type:text" json:"body"`
-	CreatedAt time.Time   `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
-	DeletedAt *time.Time  `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
-	User      UserModels  `gorm:"foreignKey:UserID" json:"user"`
-	Order     OrderModels `gorm:"foreignKey:OrderID" json:"order"`
+	ID        uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID    uint64     `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	Type      string     `gorm:"column:type;type:VARCHAR(50)" json:"type"`
+	OrderID   string     `gorm:"column:order_id;type:VARCHAR(255)" json:"order_id"`
+	Title     string     `gorm:"column:title;type:varchar(255)" json:"title"`
+	Body      string     `gorm:"column:body;type:text" json:"body"`
+	ReadAt    *time.Time `gorm:"column:read_at;type:TIMESTAMP NULL" json:"read_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	DeletedAt *time.Time `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+	User      UserModels `gorm:"foreignKey:UserID" json:"user"`
+}
+
+// UserDeviceModels is one push token of a user. A user may have several, one
+// per device; a token belongs to whoever registered it last.
+type UserDeviceModels struct {
+	ID         uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID     uint64    `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	Token      string    `gorm:"column:token;type:VARCHAR(255);uniqueIndex" json:"token"`
+	Platform   string    `gorm:"column:platform;type:VARCHAR(20)" json:"platform"`
+	LastSeenAt time.Time `gorm:"column:last_seen_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"last_seen_at"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// NotificationOutboxModels is a push or email waiting to be delivered. It is
+// written in the same transaction as the change it announces and sent later by
+// the outbox worker. NextAttemptAt doubles as the lease of a row being
+// processed. An email with a Template is rendered from it with Data, a JSON
+// object; otherwise Title and Body are sent as plain text.
+type NotificationOutboxModels struct {
+	ID            uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	FcmID         uint64     `gorm:"column:fcm_id;type:BIGINT UNSIGNED" json:"fcm_id"`
+	UserID        uint64     `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	Channel       string     `gorm:"column:channel;type:VARCHAR(20);default:push" json:"channel"`
+	Type          string     `gorm:"column:type;type:VARCHAR(50)" json:"type"`
+	OrderID       string     `gorm:"column:order_id;type:VARCHAR(255)" json:"order_id"`
+	Title         string     `gorm:"column:title;type:varchar(255)" json:"title"`
+	Body          string     `gorm:"column:body;type:text" json:"body"`
+	Template      string     `gorm:"column:template;type:VARCHAR(50)" json:"template"`
+	Data          string     `gorm:"column:data;type:text" json:"data"`
+	Token         string     `gorm:"column:token;type:VARCHAR(255)" json:"token"`
+	Status        string     `gorm:"column:status;type:VARCHAR(20);index:idx_outbox_due,priority:1" json:"status"`
+	Attempts      int        `gorm:"column:attempts;type:INT;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;type:timestamp DEFAULT CURRENT_TIMESTAMP;index:idx_outbox_due,priority:2" json:"next_attempt_at"`
+	LastError     string     `gorm:"column:last_error;type:text" json:"last_error"`
+	SentAt        *time.Time `gorm:"column:sent_at;type:TIMESTAMP NULL" json:"sent_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// NotificationPreferenceModels is a user's choice for one event type on one
+// channel. Only choices the user changed are stored; the rest use defaults.
+type NotificationPreferenceModels struct {
+	ID        uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID    uint64    `gorm:"column:user_id;type:BIGINT UNSIGNED;uniqueIndex:idx_notification_preference,priority:1" json:"user_id"`
+	EventType string    `gorm:"column:event_type;type:VARCHAR(50);uniqueIndex:idx_notification_preference,priority:2" json:"event_type"`
+	Channel   string    `gorm:"column:channel;type:VARCHAR(20);uniqueIndex:idx_notification_preference,priority:3" json:"channel"`
+	Enabled   bool      `gorm:"column:enabled" json:"enabled"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (FcmModels) TableName() string {
 	return "fcms"
 }
+
+func (UserDeviceModels) TableName() string {
+	return "user_devices"
+}
+
+func (NotificationOutboxModels) TableName() string {
+	return "notification_outbox"
+}
+
+func (NotificationPreferenceModels) TableName() string {
+	return "notification_preferences"
+}
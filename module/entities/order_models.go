@@ -15,7 +15,10 @@ type OrderModels struct {
 	GrandTotalPrice       uint64               `gorm:"column:grand_total_price;type:BIGINT UNSIGNED" json:"grand_total_price"`
 	ShipmentFee           uint64               `gorm:"column:shipment_fee;type:BIGINT UNSIGNED" json:"shipment_fee"`
 	AdminFees             uint64               `gorm:"column:admin_fees;type:BIGINT UNSIGNED" json:"admin_fees"`
+	Courier               string               `gorm:"column:courier;type:VARCHAR(255)" json:"courier"`
+	CourierService        string               `gorm:"column:courier_service;type:VARCHAR(255)" json:"courier_service"`
 	GrandTotalDiscount    uint64               `gorm:"column:grand_total_discount;type:BIGINT UNSIGNED" json:"grand_total_discount"`
+	VoucherDiscount       uint64               `gorm:"column:voucher_discount;type:BIGINT UNSIGNED;default:0" json:"voucher_discount"`
 	TotalAmountPaid       uint64               `gorm:"column:total_amount_paid;type:BIGINT UNSIGNED" json:"total_amount_paid"`
 	OrderStatus           string               `gorm:"column:order_status;type:VARCHAR(255)" json:"order_status"`
 	PaymentStatus         string               `gorm:"column:payment_status;type:VARCHAR(255)" json:"payment_status"`
@@ -29,18 +32,45 @@ type OrderModels struct {
 	User                  UserModels           `gorm:"foreignKey:UserID" json:"user"`
 	Voucher               VoucherModels        `gorm:"foreignKey:VoucherID" json:"voucher"`
 	OrderDetails          []OrderDetailsModels `gorm:"foreignKey:OrderID" json:"order_details"`
+	Shipment              *ShipmentModels      `gorm:"foreignKey:OrderID" json:"shipment"`
 }
 
 type OrderDetailsModels struct {
-	ID               uint64        `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
-	OrderID          string        `gorm:"column:order_id;VARCHAR(255)" json:"order_id"`
-	ProductID        uint64        `gorm:"column:product_id; type:BIGINT UNSIGNED" json:"product_id"`
-	Quantity         uint64        `gorm:"column:quantity;type:BIGINT UNSIGNED" json:"quantity"`
-	TotalDiscount    uint64        `gorm:"column:total-discount;type:BIGINT UNSIGNED" json:"total-discount"`
-	TotalGramPlastic uint64        `gorm:"column:total_gram_plastic; type:BIGINT UNSIGNED" json:"total_gram_plastic"`
-	TotalExp         uint64        `gorm:"column:total_exp; type:BIGINT UNSIGNED" json:"total_exp"`
-	TotalPrice       uint64        `gorm:"column:total_price;type:BIGINT UNSIGNED" json:"total_price"`
-	Product          ProductModels `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	ID               uint64                `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	OrderID          string                `gorm:"column:order_id;VARCHAR(255)" json:"order_id"`
+	ProductID        uint64                `gorm:"column:product_id; type:BIGINT UNSIGNED" json:"product_id"`
+	VariantID        *uint64               `gorm:"column:variant_id;type:BIGINT UNSIGNED" json:"variant_id"`
+	Quantity         uint64                `gorm:"column:quantity;type:BIGINT UNSIGNED" json:"quantity"`
+	TotalDiscount    uint64                `gorm:"column:total-discount;type:BIGINT UNSIGNED" json:"total-discount"`
+	TotalGramPlastic uint64                `gorm:"column:total_gram_plastic; type:BIGINT UNSIGNED" json:"total_gram_plastic"`
+	TotalExp         uint64                `gorm:"column:total_exp; type:BIGINT UNSIGNED" json:"total_exp"`
+	TotalPrice       uint64                `gorm:"column:total_price;type:BIGINT UNSIGNED" json:"total_price"`
+	Product          ProductModels         `json:"product,omitempty" gorm:"foreignKey:ProductID"`
+	Variant          *ProductVariantModels `json:"variant,omitempty" gorm:"foreignKey:VariantID"`
+}
+
+type OrderStatusHistoryModels struct {
+	ID         uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	OrderID    string    `gorm:"column:order_id;type:VARCHAR(255);index" json:"order_id"`
+	StatusType string    `gorm:"column:status_type;type:VARCHAR(50)" json:"status_type"`
+	FromStatus string    `gorm:"column:from_status;type:VARCHAR(255)" json:"from_status"`
+	ToStatus   string    `gorm:"column:to_status;type:VARCHAR(255)" json:"to_status"`
+	ActorID    uint64    `gorm:"column:actor_id;type:BIGINT UNSIGNED" json:"actor_id"`
+	ActorRole  string    `gorm:"column:actor_role;type:VARCHAR(50)" json:"actor_role"`
+	Reason     string    `gorm:"column:reason;type:VARCHAR(255)" json:"reason"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+type PaymentWebhookLogModels struct {
+	ID                uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	IdempotencyKey    string    `gorm:"column:idempotency_key;type:VARCHAR(255);uniqueIndex" json:"idempotency_key"`
+	OrderID           string    `gorm:"column:order_id;type:VARCHAR(255);index" json:"order_id"`
+	TransactionStatus string    `gorm:"column:transaction_status;type:VARCHAR(50)" json:"transaction_status"`
+	StatusCode        string    `gorm:"column:status_code;type:VARCHAR(10)" json:"status_code"`
+	Payload           string    `gorm:"column:payload;type:TEXT" json:"payload"`
+	Result            string    `gorm:"column:result;type:VARCHAR(50)" json:"result"`
+	CreatedAt         time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (OrderModels) TableName() string {
@@ -50,3 +80,11 @@ func (OrderModels) TableName() string {
 func (OrderDetailsModels) TableName() string {
 	return "order_details"
 }
+
+func (OrderStatusHistoryModels) TableName() string {
+	return "order_status_history"
+}
+
+func (PaymentWebhookLogModels) TableName() string {
+	return "payment_webhook_logs"
+}
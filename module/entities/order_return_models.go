@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+type OrderReturnModels struct {
+	ID          uint64                   `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	OrderID     string                   `gorm:"column:order_id;type:VARCHAR(255);index" json:"order_id"`
+	UserID      uint64                   `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	Reason      string                   `gorm:"column:reason;type:TEXT" json:"reason"`
+	Status      string                   `gorm:"column:status;type:VARCHAR(50)" json:"status"`
+	AdminNote   string                   `gorm:"column:admin_note;type:VARCHAR(255)" json:"admin_note"`
+	ProcessedBy uint64                   `gorm:"column:processed_by;type:BIGINT UNSIGNED" json:"processed_by"`
+	ProcessedAt *time.Time               `gorm:"column:processed_at;type:TIMESTAMP NULL" json:"processed_at"`
+	CreatedAt   time.Time                `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time                `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+	Order       OrderModels              `gorm:"foreignKey:OrderID" json:"order"`
+	User        UserModels               `gorm:"foreignKey:UserID" json:"user"`
+	Photos      []OrderReturnPhotoModels `gorm:"foreignKey:ReturnID" json:"photos"`
+	Refund      *OrderRefundModels       `gorm:"foreignKey:ReturnID" json:"refund"`
+}
+
+type OrderReturnPhotoModels struct {
+	ID        uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	ReturnID  uint64    `gorm:"column:return_id;type:BIGINT UNSIGNED;index" json:"return_id"`
+	ImageURL  string    `gorm:"column:url;type:varchar(255)" json:"url"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+type OrderRefundModels struct {
+	ID            uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	OrderID       string    `gorm:"column:order_id;type:VARCHAR(255);uniqueIndex" json:"order_id"`
+	ReturnID      uint64    `gorm:"column:return_id;type:BIGINT UNSIGNED;index" json:"return_id"`
+	Amount        uint64    `gorm:"column:amount;type:BIGINT UNSIGNED" json:"amount"`
+	ReversedExp   uint64    `gorm:"column:reversed_exp;type:BIGINT UNSIGNED" json:"reversed_exp"`
+	ReversedGram  uint64    `gorm:"column:reversed_gram;type:BIGINT UNSIGNED" json:"reversed_gram"`
+	ProcessedBy   uint64    `gorm:"column:processed_by;type:BIGINT UNSIGNED" json:"processed_by"`
+	PaymentMethod string    `gorm:"column:payment_method;type:VARCHAR(255)" json:"payment_method"`
+	CreatedAt     time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (OrderReturnModels) TableName() string {
+	return "order_returns"
+}
+
+func (OrderReturnPhotoModels) TableName() string {
+	return "order_return_photos"
+}
+
+func (OrderRefundModels) TableName() string {
+	return "order_refunds"
+}
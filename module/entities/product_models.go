@@ -5,22 +5,24 @@ import (
 )
 
 type ProductModels struct {
-	ID            uint64                `gorm:"column:id;type:bigint;primaryKey" json:"id"`
-	Name          string                `gorm:"column:name;type:varchar(255)" json:"name"`
-	Description   string                `gorm:"column:description;type:text" json:"description"`
-	GramPlastic   uint64                `gorm:"column:gram_plastic;type:bigint" json:"gram_plastic"`
-	Price         uint64                `gorm:"column:price;type:BIGINT UNSIGNED" json:"price"`
-	Stock         uint64                `gorm:"column:stock;type:BIGINT UNSIGNED" json:"stock"`
-	Discount      uint64                `gorm:"column:discount;type:BIGINT UNSIGNED" json:"discount"`
-	Exp           uint64                `gorm:"column:exp;type:BIGINT UNSIGNED" json:"product_exp"`
-	Rating        float64               `gorm:"column:rating;type:DECIMAL(3, 1)" json:"rating"`
-	TotalReview   uint64                `gorm:"column:total_review;type:BIGINT UNSIGNED" json:"total_review"`
-	CreatedAt     time.Time             `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt     time.Time             `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt     *time.Time            `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
-	ProductPhotos []ProductPhotosModels `gorm:"foreignKey:ProductID" json:"product_photos"`
-	ProductReview []ReviewModels        `gorm:"foreignKey:ProductID;references:ID" json:"review"`
-	Categories    []CategoryModels      `gorm:"many2many:product_categories;" json:"categories"`
+	ID            uint64                 `gorm:"column:id;type:bigint;primaryKey" json:"id"`
+	Name          string                 `gorm:"column:name;type:varchar(255)" json:"name"`
+	Description   string                 `gorm:"column:description;type:text" json:"description"`
+	GramPlastic   uint64                 `gorm:"column:gram_plastic;type:bigint" json:"gram_plastic"`
+	Price         uint64                 `gorm:"column:price;type:BIGINT UNSIGNED" json:"price"`
+	Stock         uint64                 `gorm:"column:stock;type:BIGINT UNSIGNED" json:"stock"`
+	Weight        uint64                 `gorm:"column:weight;type:BIGINT UNSIGNED;default:0" json:"weight"`
+	Discount      uint64                 `gorm:"column:discount;type:BIGINT UNSIGNED" json:"discount"`
+	Exp           uint64                 `gorm:"column:exp;type:BIGINT UNSIGNED" json:"product_exp"`
+	Rating        float64                `gorm:"column:rating;type:DECIMAL(3, 1)" json:"rating"`
+	TotalReview   uint64                 `gorm:"column:total_review;type:BIGINT UNSIGNED" json:"total_review"`
+	CreatedAt     time.Time              `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time              `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt     *time.Time             `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+	ProductPhotos []ProductPhotosModels  `gorm:"foreignKey:ProductID" json:"product_photos"`
+	ProductReview []ReviewModels         `gorm:"foreignKey:ProductID;references:ID" json:"review"`
+	Categories    []CategoryModels       `gorm:"many2many:product_categories;" json:"categories"`
+	Variants      []ProductVariantModels `gorm:"foreignKey:ProductID" json:"variants"`
 }
 
 type ProductPhotosModels struct {
@@ -32,6 +34,39 @@ type ProductPhotosModels struct {
 	DeletedAt *time.Time `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
 }
 
+// ProductVariantModels is one sellable option of a product, such as a size or
+// color. A product with variants is priced and stocked per variant, and its own
+// Stock is kept as the sum of theirs.
+type ProductVariantModels struct {
+	ID          uint64     `gorm:"column:id;type:bigint;primaryKey" json:"id"`
+	ProductID   uint64     `gorm:"column:product_id;type:BIGINT UNSIGNED;index" json:"product_id"`
+	SKU         string     `gorm:"column:sku;type:VARCHAR(100);uniqueIndex" json:"sku"`
+	Size        string     `gorm:"column:size;type:VARCHAR(50)" json:"size"`
+	Color       string     `gorm:"column:color;type:VARCHAR(50)" json:"color"`
+	Price       uint64     `gorm:"column:price;type:BIGINT UNSIGNED" json:"price"`
+	Discount    uint64     `gorm:"column:discount;type:BIGINT UNSIGNED;default:0" json:"discount"`
+	Stock       uint64     `gorm:"column:stock;type:BIGINT UNSIGNED" json:"stock"`
+	GramPlastic uint64     `gorm:"column:gram_plastic;type:BIGINT UNSIGNED" json:"gram_plastic"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt   *time.Time `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+}
+
+// InventoryMovementModels is one line of the stock ledger. Quantity is signed:
+// negative for stock going out, positive for stock coming back or added.
+type InventoryMovementModels struct {
+	ID         uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	ProductID  uint64    `gorm:"column:product_id;type:BIGINT UNSIGNED;index" json:"product_id"`
+	VariantID  *uint64   `gorm:"column:variant_id;type:BIGINT UNSIGNED" json:"variant_id"`
+	Type       string    `gorm:"column:type;type:VARCHAR(20)" json:"type"`
+	Quantity   int64     `gorm:"column:quantity;type:BIGINT" json:"quantity"`
+	StockAfter uint64    `gorm:"column:stock_after;type:BIGINT UNSIGNED" json:"stock_after"`
+	Reference  string    `gorm:"column:reference;type:VARCHAR(255);index" json:"reference"`
+	Reason     string    `gorm:"column:reason;type:VARCHAR(255)" json:"reason"`
+	ActorID    uint64    `gorm:"column:actor_id;type:BIGINT UNSIGNED" json:"actor_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
 func (ProductModels) TableName() string {
 	return "products"
 }
@@ -39,3 +74,11 @@ func (ProductModels) TableName() string {
 func (ProductPhotosModels) TableName() string {
 	return "product_photos"
 }
+
+func (ProductVariantModels) TableName() string {
+	return "product_variants"
+}
+
+func (InventoryMovementModels) TableName() string {
+	return "inventory_movements"
+}
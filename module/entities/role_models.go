@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// RoleModels is a named set of permissions. UserModels.Role stores the role name.
+type RoleModels struct {
+	ID          uint64             `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	Name        string             `gorm:"column:name;type:VARCHAR(50);uniqueIndex" json:"name"`
+	Description string             `gorm:"column:description;type:VARCHAR(255)" json:"description"`
+	IsSystem    bool               `gorm:"column:is_system;type:BOOLEAN;default:false" json:"is_system"`
+	Permissions []PermissionModels `gorm:"many2many:role_permissions;joinForeignKey:RoleID;joinReferences:PermissionID" json:"permissions"`
+	CreatedAt   time.Time          `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time          `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (RoleModels) TableName() string {
+	return "roles"
+}
+
+type PermissionModels struct {
+	ID          uint64 `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	Name        string `gorm:"column:name;type:VARCHAR(100);uniqueIndex" json:"name"`
+	Description string `gorm:"column:description;type:VARCHAR(255)" json:"description"`
+}
+
+func (PermissionModels) TableName() string {
+	return "permissions"
+}
@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// SearchDocumentModels is one indexed product or article. Title and Body keep
+// the indexed text so results can be highlighted without loading the source.
+type SearchDocumentModels struct {
+	ID        uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	Type      string    `gorm:"column:type;type:VARCHAR(20);uniqueIndex:idx_search_document_source" json:"type"`
+	SourceID  uint64    `gorm:"column:source_id;type:BIGINT UNSIGNED;uniqueIndex:idx_search_document_source" json:"source_id"`
+	Title     string    `gorm:"column:title;type:VARCHAR(255)" json:"title"`
+	Body      string    `gorm:"column:body;type:TEXT" json:"body"`
+	Length    float64   `gorm:"column:length;type:DOUBLE" json:"length"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// SearchTermModels is one posting of the inverted index: a term and how much
+// it weighs in a document once field boosts are applied.
+type SearchTermModels struct {
+	ID         uint64  `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	Term       string  `gorm:"column:term;type:VARCHAR(100);index" json:"term"`
+	DocumentID uint64  `gorm:"column:document_id;type:BIGINT UNSIGNED;index" json:"document_id"`
+	Weight     float64 `gorm:"column:weight;type:DOUBLE" json:"weight"`
+}
+
+func (SearchDocumentModels) TableName() string {
+	return "search_documents"
+}
+
+func (SearchTermModels) TableName() string {
+	return "search_terms"
+}
@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+type ShipmentModels struct {
+	ID           uint64                `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	OrderID      string                `gorm:"column:order_id;type:VARCHAR(255);uniqueIndex" json:"order_id"`
+	Courier      string                `gorm:"column:courier;type:VARCHAR(255)" json:"courier"`
+	Service      string                `gorm:"column:service;type:VARCHAR(255)" json:"service"`
+	Awb          string                `gorm:"column:awb;type:VARCHAR(255);index" json:"awb"`
+	Status       string                `gorm:"column:status;type:VARCHAR(255)" json:"status"`
+	LastSyncedAt *time.Time            `gorm:"column:last_synced_at;type:TIMESTAMP NULL" json:"last_synced_at"`
+	DeliveredAt  *time.Time            `gorm:"column:delivered_at;type:TIMESTAMP NULL" json:"delivered_at"`
+	CreatedAt    time.Time             `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time             `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+	Events       []ShipmentEventModels `gorm:"foreignKey:ShipmentID" json:"events"`
+}
+
+type ShipmentEventModels struct {
+	ID          uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	ShipmentID  uint64    `gorm:"column:shipment_id;type:BIGINT UNSIGNED;index" json:"shipment_id"`
+	Date        time.Time `gorm:"column:date;type:timestamp" json:"date"`
+	Description string    `gorm:"column:description;type:TEXT" json:"description"`
+	Location    string    `gorm:"column:location;type:VARCHAR(255)" json:"location"`
+}
+
+func (ShipmentModels) TableName() string {
+	return "shipments"
+}
+
+func (ShipmentEventModels) TableName() string {
+	return "shipment_events"
+}
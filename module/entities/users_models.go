@@ -18,10 +18,10 @@ type UserModels struct {
 	Exp            uint64          `gorm:"column:exp;type:BIGINT UNSIGNED" json:"exp"`
 	IsVerified     bool            `gorm:"column:is_verified;default:false" json:"is_verified"`
 	LastLogin      time.Time       `gorm:"column:last_login;type:timestamp;default:CURRENT_TIMESTAMP" json:"last_login"`
-	DeviceToken    string          `gorm:"column:device_token;type:VARCHAR(255)" json:"device_token"`
 	CreatedAt      time.Time       `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt      time.Time       `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
 	DeletedAt      *time.Time      `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+	ChatsPurgedAt  *time.Time      `gorm:"column:chats_purged_at;type:TIMESTAMP NULL" json:"-"`
 	Address        []AddressModels `gorm:"foreignKey:UserID" json:"addresses"`
 	Reviews        []ReviewModels  `gorm:"foreignKey:UserID" json:"reviews"`
 }
@@ -32,6 +32,7 @@ type AddressModels struct {
 	AcceptedName string     `gorm:"column:accepted_name;type:VARCHAR(255)" json:"accepted_name"`
 	Phone        string     `gorm:"column:phone;type:VARCHAR(255)" json:"phone"`
 	Address      string     `gorm:"column:address;type:VARCHAR(255)" json:"address"`
+	City         string     `gorm:"column:city;type:VARCHAR(255)" json:"city"`
 	IsPrimary    bool       `gorm:"column:is_primary;type:BOOLEAN" json:"is_primary"`
 	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt    time.Time  `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
@@ -46,6 +47,38 @@ type OTPModels struct {
 	ExpiredOTP int64      `gorm:"column:expired_otp;type:bigint" json:"expired_otp" `
 }
 
+// RefreshTokenModels stores the SHA-256 of a refresh token, never the token
+// itself. A token is usable once; refreshing revokes it and issues a new one.
+type RefreshTokenModels struct {
+	ID           uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID       uint64     `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	TokenHash    string     `gorm:"column:token_hash;type:VARCHAR(64);uniqueIndex" json:"-"`
+	TokenVersion int64      `gorm:"column:token_version;type:BIGINT" json:"token_version"`
+	ExpiresAt    time.Time  `gorm:"column:expires_at;type:timestamp" json:"expires_at"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at;type:TIMESTAMP NULL" json:"revoked_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// TwoFactorModels holds a user's TOTP secret. The secret is saved on setup and
+// only counts once EnabledAt is set by confirming a code from the app.
+type TwoFactorModels struct {
+	ID        uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID    uint64     `gorm:"column:user_id;type:BIGINT UNSIGNED;uniqueIndex" json:"user_id"`
+	Secret    string     `gorm:"column:secret;type:VARCHAR(64)" json:"-"`
+	EnabledAt *time.Time `gorm:"column:enabled_at;type:TIMESTAMP NULL" json:"enabled_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// RecoveryCodeModels stores the SHA-256 of a one-time 2FA recovery code.
+type RecoveryCodeModels struct {
+	ID        uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	UserID    uint64     `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	CodeHash  string     `gorm:"column:code_hash;type:VARCHAR(64)" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at;type:TIMESTAMP NULL" json:"used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
 func (UserModels) TableName() string {
 	return "users"
 }
@@ -57,3 +90,15 @@ func (AddressModels) TableName() string {
 func (OTPModels) TableName() string {
 	return "otp"
 }
+
+func (RefreshTokenModels) TableName() string {
+	return "refresh_tokens"
+}
+
+func (TwoFactorModels) TableName() string {
+	return "user_two_factors"
+}
+
+func (RecoveryCodeModels) TableName() string {
+	return "user_recovery_codes"
+}
@@ -3,26 +3,83 @@ package entities
 import "time"
 
 type VoucherModels struct {
-	ID          uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id" `
-	Name        string     `gorm:"column:name;type:VARCHAR(255)" json:"name" `
-	Code        string     `gorm:"column:code;type:VARCHAR(255)" json:"code" `
-	Category    string     `gorm:"column:category;type:VARCHAR(255)" json:"category" `
-	Description string     `gorm:"column:description;type:TEXT" json:"description" `
-	Discount    uint64     `gorm:"column:discount;type:BIGINT UNSIGNED" json:"discount" `
-	StartDate   time.Time  `gorm:"column:start_date;type:DATETIME" json:"start_date" `
-	EndDate     time.Time  `gorm:"column:end_date; type:DATETIME" json:"end_date" `
-	MinPurchase uint64     `gorm:"column:min_purchase;type:BIGINT UNSIGNED" json:"min_purchase" `
-	Stock       uint64     `gorm:"column:stock;type:BIGINT UNSIGNED" json:"stock" `
-	Status      string     `gorm:"column:status;type:VARCHAR(255)" json:"status" `
-	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
-	DeletedAt   *time.Time `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+	ID           uint64                  `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id" `
+	Name         string                  `gorm:"column:name;type:VARCHAR(255)" json:"name" `
+	Code         string                  `gorm:"column:code;type:VARCHAR(255);uniqueIndex" json:"code" `
+	Category     string                  `gorm:"column:category;type:VARCHAR(255)" json:"category" `
+	Description  string                  `gorm:"column:description;type:TEXT" json:"description" `
+	DiscountType string                  `gorm:"column:discount_type;type:VARCHAR(50);default:fixed" json:"discount_type" `
+	Discount     uint64                  `gorm:"column:discount;type:BIGINT UNSIGNED" json:"discount" `
+	MaxDiscount  uint64                  `gorm:"column:max_discount;type:BIGINT UNSIGNED;default:0" json:"max_discount" `
+	StartDate    time.Time               `gorm:"column:start_date;type:DATETIME" json:"start_date" `
+	EndDate      time.Time               `gorm:"column:end_date; type:DATETIME" json:"end_date" `
+	MinPurchase  uint64                  `gorm:"column:min_purchase;type:BIGINT UNSIGNED" json:"min_purchase" `
+	Stock        uint64                  `gorm:"column:stock;type:BIGINT UNSIGNED" json:"stock" `
+	UsageLimit   uint64                  `gorm:"column:usage_limit;type:BIGINT UNSIGNED;default:0" json:"usage_limit" `
+	UsagePerUser uint64                  `gorm:"column:usage_per_user;type:BIGINT UNSIGNED;default:0" json:"usage_per_user" `
+	UsedCount    uint64                  `gorm:"column:used_count;type:BIGINT UNSIGNED;default:0" json:"used_count" `
+	Status       string                  `gorm:"column:status;type:VARCHAR(255)" json:"status" `
+	CreatedAt    time.Time               `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time               `gorm:"column:updated_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt    *time.Time              `gorm:"column:deleted_at;type:TIMESTAMP NULL;index" json:"deleted_at"`
+	Products     []VoucherProductModels  `gorm:"foreignKey:VoucherID" json:"products"`
+	Categories   []VoucherCategoryModels `gorm:"foreignKey:VoucherID" json:"categories"`
 }
 
 func (VoucherModels) TableName() string {
 	return "vouchers"
 }
 
+type VoucherProductModels struct {
+	ID        uint64 `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	VoucherID uint64 `gorm:"column:voucher_id;type:BIGINT UNSIGNED;index" json:"voucher_id"`
+	ProductID uint64 `gorm:"column:product_id;type:BIGINT UNSIGNED;index" json:"product_id"`
+}
+
+func (VoucherProductModels) TableName() string {
+	return "voucher_products"
+}
+
+type VoucherCategoryModels struct {
+	ID         uint64 `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	VoucherID  uint64 `gorm:"column:voucher_id;type:BIGINT UNSIGNED;index" json:"voucher_id"`
+	CategoryID uint64 `gorm:"column:category_id;type:BIGINT UNSIGNED;index" json:"category_id"`
+}
+
+func (VoucherCategoryModels) TableName() string {
+	return "voucher_categories"
+}
+
+type VoucherUsageModels struct {
+	ID        uint64    `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	VoucherID uint64    `gorm:"column:voucher_id;type:BIGINT UNSIGNED;index" json:"voucher_id"`
+	UserID    uint64    `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	OrderID   string    `gorm:"column:order_id;type:VARCHAR(255);uniqueIndex" json:"order_id"`
+	Code      string    `gorm:"column:code;type:VARCHAR(255)" json:"code"`
+	Discount  uint64    `gorm:"column:discount;type:BIGINT UNSIGNED" json:"discount"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (VoucherUsageModels) TableName() string {
+	return "voucher_usages"
+}
+
+// VoucherCodeModels is a single-use code generated in bulk for a campaign. It
+// belongs to one user once redeemed and to one order once used at checkout.
+type VoucherCodeModels struct {
+	ID         uint64     `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
+	VoucherID  uint64     `gorm:"column:voucher_id;type:BIGINT UNSIGNED;index" json:"voucher_id"`
+	Code       string     `gorm:"column:code;type:VARCHAR(32);uniqueIndex" json:"code"`
+	UserID     *uint64    `gorm:"column:user_id;type:BIGINT UNSIGNED;index" json:"user_id"`
+	OrderID    *string    `gorm:"column:order_id;type:VARCHAR(255);index" json:"order_id"`
+	RedeemedAt *time.Time `gorm:"column:redeemed_at;type:TIMESTAMP NULL" json:"redeemed_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;type:timestamp DEFAULT CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (VoucherCodeModels) TableName() string {
+	return "voucher_codes"
+}
+
 type VoucherClaimModels struct {
 	ID        uint64         `gorm:"column:id;type:BIGINT UNSIGNED;primaryKey" json:"id"`
 	UserID    uint64         `gorm:"column:user_id;type:BIGINT UNSIGNED" json:"user_id"`
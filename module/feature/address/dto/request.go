@@ -5,6 +5,7 @@ type CreateAddressRequest struct {
 	AcceptedName string `form:"accepted_name" json:"accepted_name" validate:"required"`
 	Phone        string `form:"phone" json:"phone" validate:"required"`
 	Address      string `form:"address" json:"address" validate:"required"`
+	City         string `form:"city" json:"city" validate:"required"`
 	IsPrimary    bool   `form:"is_primary" json:"is_primary"`
 }
 
@@ -13,5 +14,6 @@ type UpdateAddressRequest struct {
 	AcceptedName string `form:"accepted_name" json:"accepted_name"`
 	Phone        string `form:"phone" json:"phone"`
 	Address      string `form:"address" json:"address"`
+	City         string `form:"city" json:"city"`
 	IsPrimary    bool   `form:"is_primary" json:"is_primary"`
 }
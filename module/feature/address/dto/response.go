@@ -8,6 +8,7 @@ type AddressResponse struct {
 	AcceptedName string `json:"accepted_name" `
 	Phone        string `json:"phone"`
 	Address      string `json:"address"`
+	City         string `json:"city"`
 	IsPrimary    bool   `json:"is_primary"`
 }
 
@@ -18,6 +19,7 @@ func FormatAddress(address *entities.AddressModels) *AddressResponse {
 		AcceptedName: address.AcceptedName,
 		Phone:        address.Phone,
 		Address:      address.Address,
+		City:         address.City,
 		IsPrimary:    address.IsPrimary,
 	}
 	return addressFormatter
@@ -23,9 +23,6 @@ func NewAddressHandler(service address.ServiceAddressInterface) address.HandlerA
 func (h *AddressHandler) CreateAddress() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		addressRequest := new(dto.CreateAddressRequest)
 		if err := c.Bind(addressRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -39,6 +36,7 @@ func (h *AddressHandler) CreateAddress() echo.HandlerFunc {
 			AcceptedName: addressRequest.AcceptedName,
 			Phone:        addressRequest.Phone,
 			Address:      addressRequest.Address,
+			City:         addressRequest.City,
 			IsPrimary:    addressRequest.IsPrimary,
 		}
 		createdAddress, err := h.service.CreateAddress(newAddress)
@@ -52,9 +50,6 @@ func (h *AddressHandler) CreateAddress() echo.HandlerFunc {
 func (h *AddressHandler) GetAllAddress() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
 		perPage := 8
@@ -78,9 +73,6 @@ func (h *AddressHandler) GetAllAddress() echo.HandlerFunc {
 func (h *AddressHandler) UpdateAddress() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		addressID := c.Param("id")
 		id, err := strconv.ParseUint(addressID, 10, 64)
 		if err != nil {
@@ -105,9 +97,6 @@ func (h *AddressHandler) UpdateAddress() echo.HandlerFunc {
 func (h *AddressHandler) DeleteAddress() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		id := c.Param("id")
 		addressID, err := strconv.ParseUint(id, 10, 64)
 		if err != nil {
@@ -25,6 +25,7 @@ func (s *AddressService) CreateAddress(addressData *entities.AddressModels) (*en
 		AcceptedName: addressData.AcceptedName,
 		Phone:        addressData.Phone,
 		Address:      addressData.Address,
+		City:         addressData.City,
 		IsPrimary:    addressData.IsPrimary,
 		CreatedAt:    time.Now(),
 	}
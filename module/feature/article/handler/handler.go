@@ -8,6 +8,7 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/article"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/article/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
 
 	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
@@ -27,11 +28,6 @@ func NewArticleHandler(service article.ServiceArticleInterface) article.HandlerA
 
 func (h *ArticleHandler) CreateArticle() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		articleRequest := new(dto.CreateArticleRequest)
 		file, err := c.FormFile("photo")
 		var uploadedURL string
@@ -76,11 +72,6 @@ func (h *ArticleHandler) CreateArticle() echo.HandlerFunc {
 
 func (h *ArticleHandler) UpdateArticleById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		updateRequest := new(dto.UpdateArticleRequest)
 		articleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -130,11 +121,6 @@ func (h *ArticleHandler) UpdateArticleById() echo.HandlerFunc {
 
 func (h *ArticleHandler) DeleteArticleById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		articleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai: "+err.Error())
@@ -181,8 +167,7 @@ func (h *ArticleHandler) GetArticleById() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai: "+err.Error())
 		}
 
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		incrementViews := currentUser.Role != "admin"
+		incrementViews := !role.HasPermission(c, role.PermissionManageArticles)
 
 		getArticleID, err := h.service.GetArticleById(articleID, incrementViews)
 		if err != nil {
@@ -196,9 +181,6 @@ func (h *ArticleHandler) GetArticleById() echo.HandlerFunc {
 func (h *ArticleHandler) BookmarkArticle() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		bookmark := new(dto.UserBookmarkRequest)
 		if err := c.Bind(bookmark); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang anda masukkan tidak sesuai.")
@@ -220,9 +202,6 @@ func (h *ArticleHandler) BookmarkArticle() echo.HandlerFunc {
 func (h *ArticleHandler) DeleteBookmarkedArticle() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		articleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -241,9 +220,6 @@ func (h *ArticleHandler) DeleteBookmarkedArticle() echo.HandlerFunc {
 func (h *ArticleHandler) GetUsersBookmark() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		result, err := h.service.GetUserBookmarkArticle(currentUser.ID)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan data artikel tersimpan user : "+err.Error())
@@ -260,11 +236,6 @@ func (h *ArticleHandler) GetUsersBookmark() echo.HandlerFunc {
 
 func (h *ArticleHandler) GetAllArticleUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		perPage := 8
 
@@ -298,10 +269,6 @@ func (h *ArticleHandler) GetAllArticleUser() echo.HandlerFunc {
 
 func (h *ArticleHandler) GetOtherArticle() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		result, err := h.service.GetOtherArticle()
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan artikel lainnya: "+err.Error())
@@ -313,10 +280,6 @@ func (h *ArticleHandler) GetOtherArticle() echo.HandlerFunc {
 
 func (h *ArticleHandler) GetLatestArticle() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		result, err := h.service.GetLatestArticles()
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan artikel terbaru: "+err.Error())
@@ -52,6 +52,7 @@ type ServiceArticleInterface interface {
 	/*?*/GetPrevPage(currentPage int) int
 	/*?*/CalculatePaginationValues(page int, totalItems int, perPage int) (int, int)
 	GetFilterDateRange(filterType string) (time.Time, time.Time, error)
+	ReindexSearch() (int, error)
 }
 
 type HandlerArticleInterface interface {
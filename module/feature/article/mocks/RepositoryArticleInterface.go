@@ -3,10 +3,10 @@
 package mocks
 
 import (
+	time "time"
+
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // RepositoryArticleInterface is an autogenerated mock type for the RepositoryArticleInterface type
@@ -3,10 +3,10 @@
 package mocks
 
 import (
+	time "time"
+
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // ServiceArticleInterface is an autogenerated mock type for the ServiceArticleInterface type
@@ -505,6 +505,30 @@ func (_m *ServiceArticleInterface) GetUserBookmarkArticle(userID uint64) ([]*ent
 	return r0, r1
 }
 
+// ReindexSearch provides a mock function with given fields:
+func (_m *ServiceArticleInterface) ReindexSearch() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateArticleById provides a mock function with given fields: id, updatedArticle
 func (_m *ServiceArticleInterface) UpdateArticleById(id uint64, updatedArticle *entities.ArticleModels) (*entities.ArticleModels, error) {
 	ret := _m.Called(id, updatedArticle)
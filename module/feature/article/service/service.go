@@ -8,15 +8,21 @@ import (
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/article"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"github.com/sirupsen/logrus"
 )
 
 type ArticleService struct {
-	repo article.RepositoryArticleInterface
+	repo          article.RepositoryArticleInterface
+	searchService search.ServiceSearchInterface
 }
 
-func NewArticleService(repo article.RepositoryArticleInterface) article.ServiceArticleInterface {
+// NewArticleService builds the article service. Articles are not indexed for
+// search when searchService is nil.
+func NewArticleService(repo article.RepositoryArticleInterface, searchService search.ServiceSearchInterface) article.ServiceArticleInterface {
 	return &ArticleService{
-		repo: repo,
+		repo:          repo,
+		searchService: searchService,
 	}
 }
 
@@ -31,6 +37,7 @@ func (s *ArticleService) CreateArticle(articleData *entities.ArticleModels) (*en
 	if err != nil {
 		return nil, errors.New("gagal menambahkan artikel")
 	}
+	s.indexArticle(createdArticle)
 
 	return createdArticle, nil
 }
@@ -54,6 +61,7 @@ func (s *ArticleService) UpdateArticleById(id uint64, updatedArticle *entities.A
 	if err != nil {
 		return nil, errors.New("gagal mengambil artikel")
 	}
+	s.indexArticle(getUpdatedArticle)
 
 	return getUpdatedArticle, nil
 }
@@ -68,10 +76,57 @@ func (s *ArticleService) DeleteArticleById(id uint64) error {
 	if err != nil {
 		return errors.New("gagal menghapus artikel")
 	}
+	if s.searchService != nil {
+		if err := s.searchService.Remove(search.DocumentArticle, existingArticle.ID); err != nil {
+			logrus.Error("Failed to remove article from search index:", err)
+		}
+	}
 
 	return nil
 }
 
+// indexArticle refreshes the article in the search index. The index only
+// serves search, so a failure is logged rather than undoing the change.
+func (s *ArticleService) indexArticle(articleData *entities.ArticleModels) {
+	if s.searchService == nil {
+		return
+	}
+	if err := s.searchService.Index(articleDocument(articleData)); err != nil {
+		logrus.Error("Failed to index article for search:", err)
+	}
+}
+
+// ReindexSearch rebuilds the article part of the search index from the
+// articles table and returns how many articles were indexed.
+func (s *ArticleService) ReindexSearch() (int, error) {
+	if s.searchService == nil {
+		return 0, errors.New("pencarian tidak tersedia")
+	}
+	articles, err := s.repo.FindAll()
+	if err != nil {
+		return 0, errors.New("artikel tidak ditemukan")
+	}
+	if err := s.searchService.Clear(search.DocumentArticle); err != nil {
+		return 0, err
+	}
+
+	for i, articleData := range articles {
+		if err := s.searchService.Index(articleDocument(articleData)); err != nil {
+			return i, err
+		}
+	}
+	return len(articles), nil
+}
+
+func articleDocument(articleData *entities.ArticleModels) *search.Document {
+	return &search.Document{
+		Type:  search.DocumentArticle,
+		ID:    articleData.ID,
+		Title: articleData.Title,
+		Body:  articleData.Content,
+	}
+}
+
 func (s *ArticleService) GetAll() ([]*entities.ArticleModels, error) {
 	articles, err := s.repo.FindAll()
 	if err != nil {
@@ -8,6 +8,8 @@ import (
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/article/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	searchMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/search/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -88,7 +90,7 @@ func TestArticleService_GetPrevPage(t *testing.T) {
 
 func TestArticleService_GetAll(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -121,7 +123,7 @@ func TestArticleService_GetAll(t *testing.T) {
 
 func TestArticleService_GetArticleByTitle(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -156,7 +158,7 @@ func TestArticleService_GetArticleByTitle(t *testing.T) {
 
 func TestArticleService_GetArticleById(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	article := &entities.ArticleModels{
 		ID:        1,
@@ -233,7 +235,7 @@ func TestArticleService_GetArticleById(t *testing.T) {
 
 func TestArticleService_CreateArticle(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articleData := &entities.ArticleModels{
 		Title:   "New Article",
@@ -283,7 +285,7 @@ func TestArticleService_CreateArticle(t *testing.T) {
 
 func TestArticleService_UpdateArticleById(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	existingArticle := &entities.ArticleModels{
 		ID:        1,
@@ -381,7 +383,7 @@ func TestArticleService_UpdateArticleById(t *testing.T) {
 
 func TestArticleService_DeleteArticleById(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	existingArticle := &entities.ArticleModels{
 		ID:        1,
@@ -434,7 +436,7 @@ func TestArticleService_DeleteArticleById(t *testing.T) {
 
 func TestArticleService_GetArticlesByDateRange(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	t.Run("Success Case - Success Get Articles By Date Range", func(t *testing.T) {
 		filterType := "bulan ini"
@@ -483,7 +485,7 @@ func TestArticleService_GetArticlesByDateRange(t *testing.T) {
 
 func TestArticleService_BookmarkArticle(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	userID := uint64(1)
 	articleID := uint64(2)
@@ -572,7 +574,7 @@ func TestArticleService_BookmarkArticle(t *testing.T) {
 
 func TestArticleService_GetLatestArticles(t *testing.T) {
 	repo := new(mocks.RepositoryArticleInterface)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	t.Run("Success Case - Success Get Latest Articles", func(t *testing.T) {
 		expectedArticles := []*entities.ArticleModels{
@@ -604,7 +606,7 @@ func TestArticleService_GetLatestArticles(t *testing.T) {
 
 func TestArticleService_GetOldestArticle(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -657,7 +659,7 @@ func TestArticleService_GetOldestArticle(t *testing.T) {
 
 func TestArticleService_GetArticlesAlphabet(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -710,7 +712,7 @@ func TestArticleService_GetArticlesAlphabet(t *testing.T) {
 
 func TestArticleService_GetArticleMostViews(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -762,7 +764,7 @@ func TestArticleService_GetArticleMostViews(t *testing.T) {
 
 func TestArticleService_GetOtherArticle(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -795,7 +797,7 @@ func TestArticleService_GetOtherArticle(t *testing.T) {
 
 func TestArticleService_GetArticleSearchByDateRange(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -848,7 +850,7 @@ func TestArticleService_GetArticleSearchByDateRange(t *testing.T) {
 
 func TestArticleService_GetAllArticleUser(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	articles := []*entities.ArticleModels{
 		{ID: 1, Title: "Article 1", Photo: "article1.jpg", Content: "this is content of article 1", Author: "DISAPPEAR", CreatedAt: time.Now(), Views: 1},
@@ -900,7 +902,7 @@ func TestArticleService_GetAllArticleUser(t *testing.T) {
 
 func TestArticleService_DeleteBookmarkArticle(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	userID := uint64(1)
 	articleID := uint64(2)
@@ -954,7 +956,7 @@ func TestArticleService_DeleteBookmarkArticle(t *testing.T) {
 
 func TestArticleService_GetUserBookmarkArticle(t *testing.T) {
 	repo := mocks.NewRepositoryArticleInterface(t)
-	service := NewArticleService(repo)
+	service := NewArticleService(repo, nil)
 
 	userID := uint64(1)
 
@@ -1028,3 +1030,48 @@ func TestGetFilterDateRange(t *testing.T) {
 		}
 	}
 }
+
+func TestArticleService_SearchIndex(t *testing.T) {
+	repo := mocks.NewRepositoryArticleInterface(t)
+	searchService := searchMocks.NewServiceSearchInterface(t)
+	service := NewArticleService(repo, searchService)
+
+	articleData := &entities.ArticleModels{ID: 1, Title: "Daur Ulang Plastik", Content: "Cara memilah sampah plastik"}
+	isArticleDocument := mock.MatchedBy(func(document *search.Document) bool {
+		return document.Type == search.DocumentArticle && document.ID == 1 && document.Body == articleData.Content
+	})
+
+	t.Run("Success Case - Created Article Is Indexed", func(t *testing.T) {
+		repo.On("CreateArticle", mock.Anything).Return(articleData, nil).Once()
+		searchService.On("Index", isArticleDocument).Return(nil).Once()
+
+		_, err := service.CreateArticle(articleData)
+
+		assert.NoError(t, err)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Deleted Article Is Removed", func(t *testing.T) {
+		repo.On("GetArticleById", uint64(1)).Return(articleData, nil).Once()
+		repo.On("DeleteArticleById", uint64(1)).Return(nil).Once()
+		searchService.On("Remove", search.DocumentArticle, uint64(1)).Return(nil).Once()
+
+		err := service.DeleteArticleById(1)
+
+		assert.NoError(t, err)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Reindex Rebuilds Articles", func(t *testing.T) {
+		repo.On("FindAll").Return([]*entities.ArticleModels{articleData}, nil).Once()
+		searchService.On("Clear", search.DocumentArticle).Return(nil).Once()
+		searchService.On("Index", isArticleDocument).Return(nil).Once()
+
+		count, err := service.ReindexSearch()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		repo.AssertExpectations(t)
+		searchService.AssertExpectations(t)
+	})
+}
@@ -21,9 +21,6 @@ func NewAssistantHandler(service assistant.ServiceAssistantInterface) assistant.
 func (h *AssistantHandler) CreateQuestion() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		chatRequest := new(dto.CreateChatRequest)
 		if err := c.Bind(chatRequest); err != nil {
@@ -46,9 +43,6 @@ func (h *AssistantHandler) CreateQuestion() echo.HandlerFunc {
 func (h *AssistantHandler) CreateAnswer() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		chatRequest := new(dto.CreateChatRequest)
 		if err := c.Bind(chatRequest); err != nil {
@@ -71,9 +65,6 @@ func (h *AssistantHandler) CreateAnswer() echo.HandlerFunc {
 func (h *AssistantHandler) GetChatByIdUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		chat, err := h.service.GetChatByIdUser(currentUser.ID)
 		if err != nil {
@@ -103,9 +94,6 @@ func (h *AssistantHandler) GenerateArticle() echo.HandlerFunc {
 func (h *AssistantHandler) GetProductByIdUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		chat, err := h.service.GenerateRecommendationProduct(currentUser.ID)
 		if err != nil {
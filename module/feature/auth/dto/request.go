@@ -30,13 +30,39 @@ type ResetPasswordRequest struct {
 }
 
 type RegisterSocialRequest struct {
-	SocialID     string `json:"social_id" validate:"required"`
-	Provider     string `json:"provider"`
-	Email        string `json:"email"`
+	IDToken      string `json:"id_token" validate:"required"`
 	Name         string `json:"name"`
 	PhotoProfile string `json:"photo_profile"`
 }
 
 type LoginSocialRequest struct {
-	SocialID string `json:"social_id" validate:"required"`
+	IDToken string `json:"id_token" validate:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TwoFactorLoginRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+	DeviceToken    string `json:"device_token"`
+}
+
+type TwoFactorEnableRequest struct {
+	Code        string `json:"code" validate:"required"`
+	DeviceToken string `json:"device_token"`
+}
+
+type PasswordResetTwoFactorRequest struct {
+	TwoFactorToken string `json:"two_factor_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" validate:"required"`
 }
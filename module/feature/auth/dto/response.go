@@ -3,12 +3,55 @@ package dto
 import "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 
 type LoginResponse struct {
-	Email       string `json:"email"`
-	AccessToken string `json:"access_token"`
+	Email        string `json:"email"`
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
+// TokenPair is what a successful login or refresh hands out. ExpiresIn is the
+// access token lifetime in seconds.
+//
+// When the password was right but a TOTP code is still needed, only
+// TwoFactorToken is set and ExpiresIn is its lifetime instead.
+type TokenPair struct {
+	AccessToken            string `json:"access_token"`
+	RefreshToken           string `json:"refresh_token"`
+	ExpiresIn              int64  `json:"expires_in"`
+	TwoFactorToken         string `json:"two_factor_token,omitempty"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required,omitempty"`
+}
+
+type TwoFactorLoginResponse struct {
+	Email                  string `json:"email"`
+	TwoFactorToken         string `json:"two_factor_token"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"`
+	ExpiresIn              int64  `json:"expires_in"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+	QRCode     string `json:"qr_code"`
+}
+
+type TwoFactorEnableResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	AccessToken   string   `json:"access_token,omitempty"`
+	RefreshToken  string   `json:"refresh_token,omitempty"`
+	ExpiresIn     int64    `json:"expires_in,omitempty"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// VerifyOTPResponse holds either the reset token for /forgot-password/reset or,
+// for accounts with 2FA, the partial token for /forgot-password/2fa.
 type VerifyOTPResponse struct {
-	AccessToken string `json:"access_token"`
+	ResetToken     string `json:"reset_token,omitempty"`
+	TwoFactorToken string `json:"two_factor_token,omitempty"`
+	ExpiresIn      int64  `json:"expires_in"`
 }
 
 // UserDetailResponse for detail users
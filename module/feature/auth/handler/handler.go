@@ -1,26 +1,32 @@
 package handler
 
 import (
+	"errors"
+
 	user "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/auth"
 	dto2 "github.com/capstone-kelompok-7/backend-disappear/module/feature/auth/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/identity"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
 	"github.com/labstack/echo/v4"
 	"github.com/sirupsen/logrus"
+	"strings"
 )
 
 type AuthHandler struct {
 	service     auth.ServiceAuthInterface
 	userService users.ServiceUserInterface
+	mailer      email.EmailSenderInterface
 }
 
-func NewAuthHandler(service auth.ServiceAuthInterface, userService users.ServiceUserInterface) auth.HandlerAuthInterface {
+func NewAuthHandler(service auth.ServiceAuthInterface, userService users.ServiceUserInterface, mailer email.EmailSenderInterface) auth.HandlerAuthInterface {
 	return &AuthHandler{
 		service:     service,
 		userService: userService,
+		mailer:      mailer,
 	}
 }
 
@@ -59,21 +65,28 @@ func (h *AuthHandler) Login() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
-		userLogin, accessToken, err := h.service.Login(loginRequest.Email, loginRequest.Password, loginRequest.DeviceToken)
+		userLogin, tokens, err := h.service.Login(loginRequest.Email, loginRequest.Password, loginRequest.DeviceToken)
 		if err != nil {
 			if err.Error() == "user tidak ditemukan" {
 				return response.SendStatusNotFoundResponse(c, "Pengguna tidak ditemukan")
 			} else if err.Error() == "akun anda belum diverifikasi" {
 				return response.SendStatusUnauthorizedResponse(c, "akun anda belum diverifikasi")
+			} else if err.Error() == "akun terkunci sementara, silakan coba lagi nanti" {
+				return response.SendStatusTooManyRequestsResponse(c, "Akun terkunci sementara karena terlalu banyak percobaan masuk, silakan coba lagi nanti")
 			}
 
 			logrus.Error("Kesalahan : " + err.Error())
 			return response.SendStatusUnauthorizedResponse(c, "Email atau kata sandi salah")
 		}
+		if tokens.TwoFactorToken != "" {
+			return sendTwoFactorChallenge(c, userLogin.Email, tokens)
+		}
 
 		result := &dto2.LoginResponse{
-			Email:       userLogin.Email,
-			AccessToken: accessToken,
+			Email:        userLogin.Email,
+			AccessToken:  tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
 		}
 
 		return response.SendSuccessResponse(c, "Selamat datang!, Anda telah berhasil masuk.", result)
@@ -92,6 +105,9 @@ func (h *AuthHandler) VerifyEmail() echo.HandlerFunc {
 		}
 
 		if err := h.service.VerifyEmail(emailRequest.Email, emailRequest.OTP); err != nil {
+			if err.Error() == "terlalu banyak percobaan OTP, silakan minta OTP baru" {
+				return response.SendStatusTooManyRequestsResponse(c, "Terlalu banyak percobaan OTP, silakan minta OTP baru")
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal memverifikasi email: "+err.Error())
 		}
 
@@ -116,8 +132,7 @@ func (h *AuthHandler) ResendOTP() echo.HandlerFunc {
 			return response.SendStatusInternalServerResponse(c, "Gagal mengirim ulang OTP: "+err.Error())
 		}
 
-		emailSender := email.NewEmailService()
-		err = emailSender.EmailService(emailRequest.Email, newOTP.OTP)
+		err = h.mailer.EmailService(emailRequest.Email, newOTP.OTP)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mengirim ulang OTP ke email: "+err.Error())
 		}
@@ -143,8 +158,7 @@ func (h *AuthHandler) ForgotPassword() echo.HandlerFunc {
 			return response.SendStatusInternalServerResponse(c, "Gagal mengirim ulang OTP: "+err.Error())
 		}
 
-		emailSender := email.NewEmailService()
-		err = emailSender.EmailService(forgotPasswordRequest.Email, newOTP.OTP)
+		err = h.mailer.EmailService(forgotPasswordRequest.Email, newOTP.OTP)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mengirim ulang OTP ke email: "+err.Error())
 
@@ -164,18 +178,48 @@ func (h *AuthHandler) VerifyOTP() echo.HandlerFunc {
 		if err := utils.ValidateStruct(emailRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
-		accessToken, err := h.service.VerifyOTP(emailRequest.Email, emailRequest.OTP)
+		result, err := h.service.VerifyOTP(emailRequest.Email, emailRequest.OTP)
 		if err != nil {
+			if err.Error() == "terlalu banyak percobaan OTP, silakan minta OTP baru" {
+				return response.SendStatusTooManyRequestsResponse(c, "Terlalu banyak percobaan OTP, silakan minta OTP baru")
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal verifikasi OTP: "+err.Error())
 		}
 
-		result := &dto2.VerifyOTPResponse{
-			AccessToken: accessToken,
+		if result.TwoFactorToken != "" {
+			return response.SendSuccessResponse(c, "Verifikasi OTP berhasil, masukkan kode 2FA untuk melanjutkan", result)
 		}
 		return response.SendSuccessResponse(c, "Verifikasi OTP berhasil", result)
 	}
 }
 
+func (h *AuthHandler) VerifyPasswordResetTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var verifyRequest dto2.PasswordResetTwoFactorRequest
+		if err := c.Bind(&verifyRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(verifyRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, err := h.service.VerifyPasswordResetTwoFactor(verifyRequest.TwoFactorToken, verifyRequest.Code)
+		if err != nil {
+			if err.Error() == "terlalu banyak percobaan 2FA, akun terkunci sementara" ||
+				err.Error() == "akun terkunci sementara, silakan coba lagi nanti" {
+				return response.SendStatusTooManyRequestsResponse(c, "Terlalu banyak percobaan, akun terkunci sementara")
+			} else if err.Error() == "kode 2FA tidak valid" || err.Error() == "kode 2FA sudah digunakan" ||
+				err.Error() == "token 2FA tidak valid" || err.Error() == "2FA belum aktif" {
+				return response.SendStatusUnauthorizedResponse(c, "Verifikasi dua faktor gagal: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Verifikasi dua faktor gagal: "+err.Error())
+		}
+
+		return response.SendSuccessResponse(c, "Verifikasi dua faktor berhasil", result)
+	}
+}
+
 func (h *AuthHandler) ResetPassword() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		var resetPasswordRequest dto2.ResetPasswordRequest
@@ -208,6 +252,11 @@ func (h *AuthHandler) RegisterSocial() echo.HandlerFunc {
 
 		result, err := h.service.RegisterSocial(registerRequest)
 		if err != nil {
+			if errors.Is(err, identity.ErrInvalidToken) {
+				return response.SendStatusUnauthorizedResponse(c, "Token identitas tidak valid")
+			} else if err.Error() == "email akun sosial belum diverifikasi" {
+				return response.SendStatusUnauthorizedResponse(c, "Email akun sosial belum diverifikasi")
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal mendaftarkan akun: "+err.Error())
 		}
 		return response.SendStatusCreatedResponse(c, "Registrasi berhasil!", dto2.FormatterDetailUser(result))
@@ -225,9 +274,13 @@ func (h *AuthHandler) LoginSocial() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
-		userLogin, accessToken, err := h.service.LoginSocial(loginRequest.SocialID)
+		userLogin, tokens, err := h.service.LoginSocial(loginRequest.IDToken)
 		if err != nil {
-			if err.Error() == "user tidak ditemukan" {
+			if errors.Is(err, identity.ErrInvalidToken) {
+				return response.SendStatusUnauthorizedResponse(c, "Token identitas tidak valid")
+			} else if err.Error() == "email akun sosial belum diverifikasi" {
+				return response.SendStatusUnauthorizedResponse(c, "Email akun sosial belum diverifikasi")
+			} else if err.Error() == "pengguna tidak ditemukan" {
 				return response.SendStatusNotFoundResponse(c, "Pengguna tidak ditemukan")
 			} else if err.Error() == "akun anda belum diverifikasi" {
 				return response.SendStatusUnauthorizedResponse(c, "akun anda belum diverifikasi")
@@ -236,12 +289,205 @@ func (h *AuthHandler) LoginSocial() echo.HandlerFunc {
 			logrus.Error("Kesalahan : " + err.Error())
 			return response.SendStatusUnauthorizedResponse(c, "Email atau kata sandi salah")
 		}
+		if tokens.TwoFactorToken != "" {
+			return sendTwoFactorChallenge(c, userLogin.Email, tokens)
+		}
 
 		result := &dto2.LoginResponse{
-			Email:       userLogin.Email,
-			AccessToken: accessToken,
+			Email:        userLogin.Email,
+			AccessToken:  tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+		}
+
+		return response.SendSuccessResponse(c, "Selamat datang!, Anda telah berhasil masuk.", result)
+	}
+}
+
+func (h *AuthHandler) RefreshToken() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var refreshRequest dto2.RefreshTokenRequest
+		if err := c.Bind(&refreshRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(refreshRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		tokens, err := h.service.RefreshToken(refreshRequest.RefreshToken)
+		if err != nil {
+			if err.Error() == "gagal memperbarui refresh token" {
+				return response.SendStatusInternalServerResponse(c, "Gagal memperbarui token: "+err.Error())
+			}
+			return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: "+err.Error())
+		}
+
+		return response.SendSuccessResponse(c, "Berhasil memperbarui token", tokens)
+	}
+}
+
+func (h *AuthHandler) Logout() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var logoutRequest dto2.LogoutRequest
+		if err := c.Bind(&logoutRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		accessToken := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if err := h.service.Logout(currentUser.ID, accessToken, logoutRequest.RefreshToken); err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal keluar: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil keluar")
+	}
+}
+
+func (h *AuthHandler) LogoutAll() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		if err := h.service.LogoutAll(currentUser.ID); err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal keluar dari semua perangkat: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil keluar dari semua perangkat")
+	}
+}
+
+func (h *AuthHandler) SetupTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		result, err := h.service.SetupTwoFactor(currentUser)
+		if err != nil {
+			if err.Error() == "2FA sudah aktif" {
+				return response.SendBadRequestResponse(c, "Autentikasi dua faktor sudah aktif")
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menyiapkan autentikasi dua faktor: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Pindai kode QR lalu konfirmasi dengan kode dari aplikasi autentikator", result)
+	}
+}
+
+func (h *AuthHandler) EnableTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var enableRequest dto2.TwoFactorEnableRequest
+		if err := c.Bind(&enableRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(enableRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		twoFactorToken, _ := c.Get("TwoFactorToken").(string)
+		recoveryCodes, tokens, err := h.service.EnableTwoFactor(currentUser, enableRequest.Code, twoFactorToken, enableRequest.DeviceToken)
+		if err != nil {
+			if err.Error() == "kode 2FA tidak valid" || err.Error() == "kode 2FA sudah digunakan" ||
+				err.Error() == "2FA belum disiapkan" || err.Error() == "2FA sudah aktif" {
+				return response.SendBadRequestResponse(c, "Gagal mengaktifkan autentikasi dua faktor: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mengaktifkan autentikasi dua faktor: "+err.Error())
+		}
+
+		result := &dto2.TwoFactorEnableResponse{RecoveryCodes: recoveryCodes}
+		if tokens != nil {
+			result.AccessToken = tokens.AccessToken
+			result.RefreshToken = tokens.RefreshToken
+			result.ExpiresIn = tokens.ExpiresIn
+		}
+		return response.SendSuccessResponse(c, "Autentikasi dua faktor aktif, simpan kode pemulihan Anda", result)
+	}
+}
+
+func (h *AuthHandler) VerifyTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var verifyRequest dto2.TwoFactorLoginRequest
+		if err := c.Bind(&verifyRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(verifyRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		userLogin, tokens, err := h.service.VerifyTwoFactorLogin(verifyRequest.TwoFactorToken, verifyRequest.Code, verifyRequest.DeviceToken)
+		if err != nil {
+			if err.Error() == "terlalu banyak percobaan 2FA, akun terkunci sementara" ||
+				err.Error() == "akun terkunci sementara, silakan coba lagi nanti" {
+				return response.SendStatusTooManyRequestsResponse(c, "Terlalu banyak percobaan, akun terkunci sementara")
+			} else if err.Error() == "kode 2FA tidak valid" || err.Error() == "kode 2FA sudah digunakan" ||
+				err.Error() == "token 2FA tidak valid" || err.Error() == "2FA belum aktif" {
+				return response.SendStatusUnauthorizedResponse(c, "Verifikasi dua faktor gagal: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Verifikasi dua faktor gagal: "+err.Error())
+		}
+
+		result := &dto2.LoginResponse{
+			Email:        userLogin.Email,
+			AccessToken:  tokens.AccessToken,
+			RefreshToken: tokens.RefreshToken,
+			ExpiresIn:    tokens.ExpiresIn,
+		}
 		return response.SendSuccessResponse(c, "Selamat datang!, Anda telah berhasil masuk.", result)
 	}
 }
+
+func (h *AuthHandler) DisableTwoFactor() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var codeRequest dto2.TwoFactorCodeRequest
+		if err := c.Bind(&codeRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(codeRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		if err := h.service.DisableTwoFactor(currentUser, codeRequest.Code); err != nil {
+			if err.Error() == "pengguna dengan izin pengelolaan wajib menggunakan 2FA" {
+				return response.SendStatusForbiddenResponse(c, "Pengguna dengan izin pengelolaan wajib menggunakan autentikasi dua faktor")
+			} else if err.Error() == "kode 2FA tidak valid" || err.Error() == "kode 2FA sudah digunakan" || err.Error() == "2FA belum aktif" {
+				return response.SendBadRequestResponse(c, "Gagal menonaktifkan autentikasi dua faktor: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menonaktifkan autentikasi dua faktor: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Autentikasi dua faktor dinonaktifkan")
+	}
+}
+
+func (h *AuthHandler) RegenerateRecoveryCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var codeRequest dto2.TwoFactorCodeRequest
+		if err := c.Bind(&codeRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(codeRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		currentUser := c.Get("CurrentUser").(*user.UserModels)
+		recoveryCodes, err := h.service.RegenerateRecoveryCodes(currentUser.ID, codeRequest.Code)
+		if err != nil {
+			if err.Error() == "kode 2FA tidak valid" || err.Error() == "kode 2FA sudah digunakan" || err.Error() == "2FA belum aktif" {
+				return response.SendBadRequestResponse(c, "Gagal membuat kode pemulihan: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal membuat kode pemulihan: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Kode pemulihan baru berhasil dibuat", &dto2.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+	}
+}
+
+func sendTwoFactorChallenge(c echo.Context, email string, tokens *dto2.TokenPair) error {
+	result := &dto2.TwoFactorLoginResponse{
+		Email:                  email,
+		TwoFactorToken:         tokens.TwoFactorToken,
+		TwoFactorSetupRequired: tokens.TwoFactorSetupRequired,
+		ExpiresIn:              tokens.ExpiresIn,
+	}
+	if tokens.TwoFactorSetupRequired {
+		return response.SendSuccessResponse(c, "Akun admin wajib mengaktifkan autentikasi dua faktor sebelum masuk", result)
+	}
+	return response.SendSuccessResponse(c, "Masukkan kode autentikasi dua faktor untuk melanjutkan", result)
+}
@@ -20,19 +20,37 @@ type RepositoryAuthInterface interface {
 	LoginSocial(socialID string) (*entities.UserModels, error)
 	FindUserBySocialID(socialID string) (*entities.UserModels, error)
 	UpdateLastLogin(userID uint64, lastLogin time.Time) error
-	CekDeviceTokenByEmail(email string) (string, error)
-	UpdateDeviceTokenByID(email string, deviceToken string) (*entities.UserModels, error)
+	CreateRefreshToken(refreshToken *entities.RefreshTokenModels) error
+	GetRefreshTokenByHash(tokenHash string) (*entities.RefreshTokenModels, error)
+	RotateRefreshToken(oldTokenID uint64, newToken *entities.RefreshTokenModels) (bool, error)
+	RevokeRefreshToken(tokenID uint64) error
+	RevokeUserRefreshTokens(userID uint64) error
+	GetTwoFactorByUserID(userID uint64) (*entities.TwoFactorModels, error)
+	SaveTwoFactorSecret(userID uint64, secret string) error
+	EnableTwoFactor(userID uint64, recoveryCodeHashes []string) error
+	DisableTwoFactor(userID uint64) error
+	ReplaceRecoveryCodes(userID uint64, recoveryCodeHashes []string) error
+	UseRecoveryCode(userID uint64, codeHash string) (bool, error)
 }
 
 type ServiceAuthInterface interface {
 	Register(newData *entities.UserModels) (*entities.UserModels, error)
-	Login(email, password, deviceToken string) (*entities.UserModels, string, error)
+	Login(email, password, deviceToken string) (*entities.UserModels, *dto.TokenPair, error)
 	VerifyEmail(email, otp string) error
 	ResendOTP(email string) (*entities.OTPModels, error)
 	ResetPassword(email, newPassword, confirmPass string) error
-	VerifyOTP(email, otp string) (string, error)
+	VerifyOTP(email, otp string) (*dto.VerifyOTPResponse, error)
+	VerifyPasswordResetTwoFactor(twoFactorToken, code string) (*dto.VerifyOTPResponse, error)
 	RegisterSocial(req *dto.RegisterSocialRequest) (*entities.UserModels, error)
-	LoginSocial(socialID string) (*entities.UserModels, string, error)
+	LoginSocial(idToken string) (*entities.UserModels, *dto.TokenPair, error)
+	RefreshToken(refreshToken string) (*dto.TokenPair, error)
+	Logout(userID uint64, accessToken, refreshToken string) error
+	LogoutAll(userID uint64) error
+	SetupTwoFactor(user *entities.UserModels) (*dto.TwoFactorSetupResponse, error)
+	EnableTwoFactor(user *entities.UserModels, code, twoFactorToken, deviceToken string) ([]string, *dto.TokenPair, error)
+	VerifyTwoFactorLogin(twoFactorToken, code, deviceToken string) (*entities.UserModels, *dto.TokenPair, error)
+	DisableTwoFactor(user *entities.UserModels, code string) error
+	RegenerateRecoveryCodes(userID uint64, code string) ([]string, error)
 }
 
 type HandlerAuthInterface interface {
@@ -41,8 +59,17 @@ type HandlerAuthInterface interface {
 	VerifyEmail() echo.HandlerFunc
 	ResendOTP() echo.HandlerFunc
 	VerifyOTP() echo.HandlerFunc
+	VerifyPasswordResetTwoFactor() echo.HandlerFunc
 	ForgotPassword() echo.HandlerFunc
 	ResetPassword() echo.HandlerFunc
 	RegisterSocial() echo.HandlerFunc
 	LoginSocial() echo.HandlerFunc
+	RefreshToken() echo.HandlerFunc
+	Logout() echo.HandlerFunc
+	LogoutAll() echo.HandlerFunc
+	SetupTwoFactor() echo.HandlerFunc
+	EnableTwoFactor() echo.HandlerFunc
+	VerifyTwoFactor() echo.HandlerFunc
+	DisableTwoFactor() echo.HandlerFunc
+	RegenerateRecoveryCodes() echo.HandlerFunc
 }
@@ -12,6 +12,38 @@ type HandlerAuthInterface struct {
 	mock.Mock
 }
 
+// DisableTwoFactor provides a mock function with given fields:
+func (_m *HandlerAuthInterface) DisableTwoFactor() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields:
+func (_m *HandlerAuthInterface) EnableTwoFactor() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // ForgotPassword provides a mock function with given fields:
 func (_m *HandlerAuthInterface) ForgotPassword() echo.HandlerFunc {
 	ret := _m.Called()
@@ -60,6 +92,70 @@ func (_m *HandlerAuthInterface) LoginSocial() echo.HandlerFunc {
 	return r0
 }
 
+// Logout provides a mock function with given fields:
+func (_m *HandlerAuthInterface) Logout() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields:
+func (_m *HandlerAuthInterface) LogoutAll() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields:
+func (_m *HandlerAuthInterface) RefreshToken() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields:
+func (_m *HandlerAuthInterface) RegenerateRecoveryCodes() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Register provides a mock function with given fields:
 func (_m *HandlerAuthInterface) Register() echo.HandlerFunc {
 	ret := _m.Called()
@@ -124,6 +220,22 @@ func (_m *HandlerAuthInterface) ResetPassword() echo.HandlerFunc {
 	return r0
 }
 
+// SetupTwoFactor provides a mock function with given fields:
+func (_m *HandlerAuthInterface) SetupTwoFactor() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // VerifyEmail provides a mock function with given fields:
 func (_m *HandlerAuthInterface) VerifyEmail() echo.HandlerFunc {
 	ret := _m.Called()
@@ -156,6 +268,38 @@ func (_m *HandlerAuthInterface) VerifyOTP() echo.HandlerFunc {
 	return r0
 }
 
+// VerifyPasswordResetTwoFactor provides a mock function with given fields:
+func (_m *HandlerAuthInterface) VerifyPasswordResetTwoFactor() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// VerifyTwoFactor provides a mock function with given fields:
+func (_m *HandlerAuthInterface) VerifyTwoFactor() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // NewHandlerAuthInterface creates a new instance of HandlerAuthInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHandlerAuthInterface(t interface {
@@ -3,10 +3,10 @@
 package mocks
 
 import (
+	time "time"
+
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // RepositoryAuthInterface is an autogenerated mock type for the RepositoryAuthInterface type
@@ -14,28 +14,18 @@ type RepositoryAuthInterface struct {
 	mock.Mock
 }
 
-// CekDeviceTokenByEmail provides a mock function with given fields: email
-func (_m *RepositoryAuthInterface) CekDeviceTokenByEmail(email string) (string, error) {
-	ret := _m.Called(email)
-
-	var r0 string
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(email)
-	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(email)
-	} else {
-		r0 = ret.Get(0).(string)
-	}
+// CreateRefreshToken provides a mock function with given fields: refreshToken
+func (_m *RepositoryAuthInterface) CreateRefreshToken(refreshToken *entities.RefreshTokenModels) error {
+	ret := _m.Called(refreshToken)
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(email)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.RefreshTokenModels) error); ok {
+		r0 = rf(refreshToken)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // DeleteOTP provides a mock function with given fields: otp
@@ -66,6 +56,34 @@ func (_m *RepositoryAuthInterface) DeleteUserOTP(userId uint64) error {
 	return r0
 }
 
+// DisableTwoFactor provides a mock function with given fields: userID
+func (_m *RepositoryAuthInterface) DisableTwoFactor(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: userID, recoveryCodeHashes
+func (_m *RepositoryAuthInterface) EnableTwoFactor(userID uint64, recoveryCodeHashes []string) error {
+	ret := _m.Called(userID, recoveryCodeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, []string) error); ok {
+		r0 = rf(userID, recoveryCodeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindUserBySocialID provides a mock function with given fields: socialID
 func (_m *RepositoryAuthInterface) FindUserBySocialID(socialID string) (*entities.UserModels, error) {
 	ret := _m.Called(socialID)
@@ -118,6 +136,58 @@ func (_m *RepositoryAuthInterface) FindValidOTP(userID int, otp string) (*entiti
 	return r0, r1
 }
 
+// GetRefreshTokenByHash provides a mock function with given fields: tokenHash
+func (_m *RepositoryAuthInterface) GetRefreshTokenByHash(tokenHash string) (*entities.RefreshTokenModels, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 *entities.RefreshTokenModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.RefreshTokenModels, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entities.RefreshTokenModels); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.RefreshTokenModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTwoFactorByUserID provides a mock function with given fields: userID
+func (_m *RepositoryAuthInterface) GetTwoFactorByUserID(userID uint64) (*entities.TwoFactorModels, error) {
+	ret := _m.Called(userID)
+
+	var r0 *entities.TwoFactorModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.TwoFactorModels, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *entities.TwoFactorModels); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.TwoFactorModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: email
 func (_m *RepositoryAuthInterface) Login(email string) (*entities.UserModels, error) {
 	ret := _m.Called(email)
@@ -196,6 +266,20 @@ func (_m *RepositoryAuthInterface) Register(newData *entities.UserModels) (*enti
 	return r0, r1
 }
 
+// ReplaceRecoveryCodes provides a mock function with given fields: userID, recoveryCodeHashes
+func (_m *RepositoryAuthInterface) ReplaceRecoveryCodes(userID uint64, recoveryCodeHashes []string) error {
+	ret := _m.Called(userID, recoveryCodeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, []string) error); ok {
+		r0 = rf(userID, recoveryCodeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetPassword provides a mock function with given fields: email, newPasswordHash
 func (_m *RepositoryAuthInterface) ResetPassword(email string, newPasswordHash string) error {
 	ret := _m.Called(email, newPasswordHash)
@@ -210,6 +294,58 @@ func (_m *RepositoryAuthInterface) ResetPassword(email string, newPasswordHash s
 	return r0
 }
 
+// RevokeRefreshToken provides a mock function with given fields: tokenID
+func (_m *RepositoryAuthInterface) RevokeRefreshToken(tokenID uint64) error {
+	ret := _m.Called(tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserRefreshTokens provides a mock function with given fields: userID
+func (_m *RepositoryAuthInterface) RevokeUserRefreshTokens(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateRefreshToken provides a mock function with given fields: oldTokenID, newToken
+func (_m *RepositoryAuthInterface) RotateRefreshToken(oldTokenID uint64, newToken *entities.RefreshTokenModels) (bool, error) {
+	ret := _m.Called(oldTokenID, newToken)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *entities.RefreshTokenModels) (bool, error)); ok {
+		return rf(oldTokenID, newToken)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *entities.RefreshTokenModels) bool); ok {
+		r0 = rf(oldTokenID, newToken)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *entities.RefreshTokenModels) error); ok {
+		r1 = rf(oldTokenID, newToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveOTP provides a mock function with given fields: otp
 func (_m *RepositoryAuthInterface) SaveOTP(otp *entities.OTPModels) (*entities.OTPModels, error) {
 	ret := _m.Called(otp)
@@ -236,30 +372,18 @@ func (_m *RepositoryAuthInterface) SaveOTP(otp *entities.OTPModels) (*entities.O
 	return r0, r1
 }
 
-// UpdateDeviceTokenByID provides a mock function with given fields: email, deviceToken
-func (_m *RepositoryAuthInterface) UpdateDeviceTokenByID(email string, deviceToken string) (*entities.UserModels, error) {
-	ret := _m.Called(email, deviceToken)
+// SaveTwoFactorSecret provides a mock function with given fields: userID, secret
+func (_m *RepositoryAuthInterface) SaveTwoFactorSecret(userID uint64, secret string) error {
+	ret := _m.Called(userID, secret)
 
-	var r0 *entities.UserModels
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (*entities.UserModels, error)); ok {
-		return rf(email, deviceToken)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) *entities.UserModels); ok {
-		r0 = rf(email, deviceToken)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.UserModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(email, deviceToken)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string) error); ok {
+		r0 = rf(userID, secret)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // UpdateLastLogin provides a mock function with given fields: userID, lastLogin
@@ -302,6 +426,30 @@ func (_m *RepositoryAuthInterface) UpdateUser(user *entities.UserModels) (*entit
 	return r0, r1
 }
 
+// UseRecoveryCode provides a mock function with given fields: userID, codeHash
+func (_m *RepositoryAuthInterface) UseRecoveryCode(userID uint64, codeHash string) (bool, error) {
+	ret := _m.Called(userID, codeHash)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string) (bool, error)); ok {
+		return rf(userID, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string) bool); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
+		r1 = rf(userID, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewRepositoryAuthInterface creates a new instance of RepositoryAuthInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryAuthInterface(t interface {
@@ -5,7 +5,6 @@ package mocks
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/auth/dto"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,14 +13,63 @@ type ServiceAuthInterface struct {
 	mock.Mock
 }
 
+// DisableTwoFactor provides a mock function with given fields: user, code
+func (_m *ServiceAuthInterface) DisableTwoFactor(user *entities.UserModels, code string) error {
+	ret := _m.Called(user, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.UserModels, string) error); ok {
+		r0 = rf(user, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnableTwoFactor provides a mock function with given fields: user, code, twoFactorToken, deviceToken
+func (_m *ServiceAuthInterface) EnableTwoFactor(user *entities.UserModels, code string, twoFactorToken string, deviceToken string) ([]string, *dto.TokenPair, error) {
+	ret := _m.Called(user, code, twoFactorToken, deviceToken)
+
+	var r0 []string
+	var r1 *dto.TokenPair
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*entities.UserModels, string, string, string) ([]string, *dto.TokenPair, error)); ok {
+		return rf(user, code, twoFactorToken, deviceToken)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.UserModels, string, string, string) []string); ok {
+		r0 = rf(user, code, twoFactorToken, deviceToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.UserModels, string, string, string) *dto.TokenPair); ok {
+		r1 = rf(user, code, twoFactorToken, deviceToken)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*dto.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*entities.UserModels, string, string, string) error); ok {
+		r2 = rf(user, code, twoFactorToken, deviceToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Login provides a mock function with given fields: email, password, deviceToken
-func (_m *ServiceAuthInterface) Login(email string, password string, deviceToken string) (*entities.UserModels, string, error) {
+func (_m *ServiceAuthInterface) Login(email string, password string, deviceToken string) (*entities.UserModels, *dto.TokenPair, error) {
 	ret := _m.Called(email, password, deviceToken)
 
 	var r0 *entities.UserModels
-	var r1 string
+	var r1 *dto.TokenPair
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (*entities.UserModels, string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string, string) (*entities.UserModels, *dto.TokenPair, error)); ok {
 		return rf(email, password, deviceToken)
 	}
 	if rf, ok := ret.Get(0).(func(string, string, string) *entities.UserModels); ok {
@@ -32,10 +80,12 @@ func (_m *ServiceAuthInterface) Login(email string, password string, deviceToken
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) string); ok {
+	if rf, ok := ret.Get(1).(func(string, string, string) *dto.TokenPair); ok {
 		r1 = rf(email, password, deviceToken)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*dto.TokenPair)
+		}
 	}
 
 	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
@@ -47,32 +97,34 @@ func (_m *ServiceAuthInterface) Login(email string, password string, deviceToken
 	return r0, r1, r2
 }
 
-// LoginSocial provides a mock function with given fields: socialID
-func (_m *ServiceAuthInterface) LoginSocial(socialID string) (*entities.UserModels, string, error) {
-	ret := _m.Called(socialID)
+// LoginSocial provides a mock function with given fields: idToken
+func (_m *ServiceAuthInterface) LoginSocial(idToken string) (*entities.UserModels, *dto.TokenPair, error) {
+	ret := _m.Called(idToken)
 
 	var r0 *entities.UserModels
-	var r1 string
+	var r1 *dto.TokenPair
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string) (*entities.UserModels, string, error)); ok {
-		return rf(socialID)
+	if rf, ok := ret.Get(0).(func(string) (*entities.UserModels, *dto.TokenPair, error)); ok {
+		return rf(idToken)
 	}
 	if rf, ok := ret.Get(0).(func(string) *entities.UserModels); ok {
-		r0 = rf(socialID)
+		r0 = rf(idToken)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.UserModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) string); ok {
-		r1 = rf(socialID)
+	if rf, ok := ret.Get(1).(func(string) *dto.TokenPair); ok {
+		r1 = rf(idToken)
 	} else {
-		r1 = ret.Get(1).(string)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*dto.TokenPair)
+		}
 	}
 
 	if rf, ok := ret.Get(2).(func(string) error); ok {
-		r2 = rf(socialID)
+		r2 = rf(idToken)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -80,6 +132,86 @@ func (_m *ServiceAuthInterface) LoginSocial(socialID string) (*entities.UserMode
 	return r0, r1, r2
 }
 
+// Logout provides a mock function with given fields: userID, accessToken, refreshToken
+func (_m *ServiceAuthInterface) Logout(userID uint64, accessToken string, refreshToken string) error {
+	ret := _m.Called(userID, accessToken, refreshToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string) error); ok {
+		r0 = rf(userID, accessToken, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LogoutAll provides a mock function with given fields: userID
+func (_m *ServiceAuthInterface) LogoutAll(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: refreshToken
+func (_m *ServiceAuthInterface) RefreshToken(refreshToken string) (*dto.TokenPair, error) {
+	ret := _m.Called(refreshToken)
+
+	var r0 *dto.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*dto.TokenPair, error)); ok {
+		return rf(refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(string) *dto.TokenPair); ok {
+		r0 = rf(refreshToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RegenerateRecoveryCodes provides a mock function with given fields: userID, code
+func (_m *ServiceAuthInterface) RegenerateRecoveryCodes(userID uint64, code string) ([]string, error) {
+	ret := _m.Called(userID, code)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string) ([]string, error)); ok {
+		return rf(userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string) []string); ok {
+		r0 = rf(userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
+		r1 = rf(userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Register provides a mock function with given fields: newData
 func (_m *ServiceAuthInterface) Register(newData *entities.UserModels) (*entities.UserModels, error) {
 	ret := _m.Called(newData)
@@ -172,6 +304,32 @@ func (_m *ServiceAuthInterface) ResetPassword(email string, newPassword string,
 	return r0
 }
 
+// SetupTwoFactor provides a mock function with given fields: user
+func (_m *ServiceAuthInterface) SetupTwoFactor(user *entities.UserModels) (*dto.TwoFactorSetupResponse, error) {
+	ret := _m.Called(user)
+
+	var r0 *dto.TwoFactorSetupResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.UserModels) (*dto.TwoFactorSetupResponse, error)); ok {
+		return rf(user)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.UserModels) *dto.TwoFactorSetupResponse); ok {
+		r0 = rf(user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.TwoFactorSetupResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.UserModels) error); ok {
+		r1 = rf(user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyEmail provides a mock function with given fields: email, otp
 func (_m *ServiceAuthInterface) VerifyEmail(email string, otp string) error {
 	ret := _m.Called(email, otp)
@@ -187,18 +345,20 @@ func (_m *ServiceAuthInterface) VerifyEmail(email string, otp string) error {
 }
 
 // VerifyOTP provides a mock function with given fields: email, otp
-func (_m *ServiceAuthInterface) VerifyOTP(email string, otp string) (string, error) {
+func (_m *ServiceAuthInterface) VerifyOTP(email string, otp string) (*dto.VerifyOTPResponse, error) {
 	ret := _m.Called(email, otp)
 
-	var r0 string
+	var r0 *dto.VerifyOTPResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(string, string) (*dto.VerifyOTPResponse, error)); ok {
 		return rf(email, otp)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+	if rf, ok := ret.Get(0).(func(string, string) *dto.VerifyOTPResponse); ok {
 		r0 = rf(email, otp)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.VerifyOTPResponse)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(string, string) error); ok {
@@ -210,6 +370,67 @@ func (_m *ServiceAuthInterface) VerifyOTP(email string, otp string) (string, err
 	return r0, r1
 }
 
+// VerifyPasswordResetTwoFactor provides a mock function with given fields: twoFactorToken, code
+func (_m *ServiceAuthInterface) VerifyPasswordResetTwoFactor(twoFactorToken string, code string) (*dto.VerifyOTPResponse, error) {
+	ret := _m.Called(twoFactorToken, code)
+
+	var r0 *dto.VerifyOTPResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*dto.VerifyOTPResponse, error)); ok {
+		return rf(twoFactorToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *dto.VerifyOTPResponse); ok {
+		r0 = rf(twoFactorToken, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.VerifyOTPResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(twoFactorToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTwoFactorLogin provides a mock function with given fields: twoFactorToken, code, deviceToken
+func (_m *ServiceAuthInterface) VerifyTwoFactorLogin(twoFactorToken string, code string, deviceToken string) (*entities.UserModels, *dto.TokenPair, error) {
+	ret := _m.Called(twoFactorToken, code, deviceToken)
+
+	var r0 *entities.UserModels
+	var r1 *dto.TokenPair
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, string, string) (*entities.UserModels, *dto.TokenPair, error)); ok {
+		return rf(twoFactorToken, code, deviceToken)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *entities.UserModels); ok {
+		r0 = rf(twoFactorToken, code, deviceToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.UserModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) *dto.TokenPair); ok {
+		r1 = rf(twoFactorToken, code, deviceToken)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*dto.TokenPair)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(twoFactorToken, code, deviceToken)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewServiceAuthInterface creates a new instance of ServiceAuthInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceAuthInterface(t interface {
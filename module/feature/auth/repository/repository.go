@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
@@ -114,21 +115,134 @@ func (r *AuthRepository) UpdateLastLogin(userID uint64, lastLogin time.Time) err
 	return nil
 }
 
-func (r *AuthRepository) CekDeviceTokenByEmail(email string) (string, error) {
-	var user entities.UserModels
-	if err := r.db.Table("users").Where("email = ? AND  deleted_at IS NULL", email).First(&user).Error; err != nil {
-		return "", err
+func (r *AuthRepository) CreateRefreshToken(refreshToken *entities.RefreshTokenModels) error {
+	if err := r.db.Create(refreshToken).Error; err != nil {
+		return err
 	}
+	return nil
+}
 
-	return user.DeviceToken, nil
+func (r *AuthRepository) GetRefreshTokenByHash(tokenHash string) (*entities.RefreshTokenModels, error) {
+	var refreshToken entities.RefreshTokenModels
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&refreshToken).Error; err != nil {
+		return nil, err
+	}
+	return &refreshToken, nil
 }
 
-func (r *AuthRepository) UpdateDeviceTokenByID(email string, deviceToken string) (*entities.UserModels, error) {
-	var user entities.UserModels
-	if err := r.db.Model(&user).Where("email = ?", email).Update("device_token", deviceToken).Error; err != nil {
+// RotateRefreshToken revokes the old token and stores its replacement in one
+// transaction. It reports false when the old token was already revoked, which
+// means two requests raced with the same token.
+func (r *AuthRepository) RotateRefreshToken(oldTokenID uint64, newToken *entities.RefreshTokenModels) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entities.RefreshTokenModels{}).
+			Where("id = ? AND revoked_at IS NULL", oldTokenID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		if err := tx.Create(newToken).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return rotated, nil
+}
+
+func (r *AuthRepository) RevokeRefreshToken(tokenID uint64) error {
+	return r.db.Model(&entities.RefreshTokenModels{}).
+		Where("id = ? AND revoked_at IS NULL", tokenID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *AuthRepository) RevokeUserRefreshTokens(userID uint64) error {
+	return r.db.Model(&entities.RefreshTokenModels{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *AuthRepository) GetTwoFactorByUserID(userID uint64) (*entities.TwoFactorModels, error) {
+	var twoFactor entities.TwoFactorModels
+	if err := r.db.Where("user_id = ?", userID).First(&twoFactor).Error; err != nil {
 		return nil, err
 	}
+	return &twoFactor, nil
+}
 
-	return &user, nil
+// SaveTwoFactorSecret stores a new pending secret. An enabled secret is never
+// replaced here; it has to be disabled first.
+func (r *AuthRepository) SaveTwoFactorSecret(userID uint64, secret string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var twoFactor entities.TwoFactorModels
+		err := tx.Where("user_id = ?", userID).First(&twoFactor).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(&entities.TwoFactorModels{UserID: userID, Secret: secret}).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&entities.TwoFactorModels{}).
+			Where("id = ? AND enabled_at IS NULL", twoFactor.ID).
+			Update("secret", secret).Error
+	})
+}
+
+func (r *AuthRepository) EnableTwoFactor(userID uint64, recoveryCodeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.TwoFactorModels{}).
+			Where("user_id = ?", userID).
+			Update("enabled_at", time.Now()).Error; err != nil {
+			return err
+		}
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+func (r *AuthRepository) DisableTwoFactor(userID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entities.RecoveryCodeModels{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entities.TwoFactorModels{}).Error
+	})
+}
+
+func (r *AuthRepository) ReplaceRecoveryCodes(userID uint64, recoveryCodeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
 
+// UseRecoveryCode marks an unused code as used. It reports false when the code
+// does not exist or was already used.
+func (r *AuthRepository) UseRecoveryCode(userID uint64, codeHash string) (bool, error) {
+	result := r.db.Model(&entities.RecoveryCodeModels{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uint64, recoveryCodeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&entities.RecoveryCodeModels{}).Error; err != nil {
+		return err
+	}
+	codes := make([]*entities.RecoveryCodeModels, 0, len(recoveryCodeHashes))
+	for _, codeHash := range recoveryCodeHashes {
+		codes = append(codes, &entities.RecoveryCodeModels{UserID: userID, CodeHash: codeHash})
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	return tx.Create(&codes).Error
 }
@@ -1,20 +1,31 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/auth"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/auth/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
 	"github.com/labstack/gommon/log"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/identity"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/otp"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/totp"
+	"github.com/golang-jwt/jwt"
+	"gorm.io/gorm"
 )
 
 type AuthService struct {
@@ -24,9 +35,12 @@ type AuthService struct {
 	hash        utils.HashInterface
 	cache       caching.CacheRepository
 	email       email.EmailSenderInterface
+	identity    identity.Verifier
+	devices     fcm.RepositoryFcmInterface
+	roles       role.ServiceRoleInterface
 }
 
-func NewAuthService(repo auth.RepositoryAuthInterface, jwt utils.JWTInterface, userService users.ServiceUserInterface, hash utils.HashInterface, cache caching.CacheRepository, email email.EmailSenderInterface) auth.ServiceAuthInterface {
+func NewAuthService(repo auth.RepositoryAuthInterface, jwt utils.JWTInterface, userService users.ServiceUserInterface, hash utils.HashInterface, cache caching.CacheRepository, email email.EmailSenderInterface, identity identity.Verifier, devices fcm.RepositoryFcmInterface, roles role.ServiceRoleInterface) auth.ServiceAuthInterface {
 	return &AuthService{
 		repo:        repo,
 		jwt:         jwt,
@@ -34,11 +48,33 @@ func NewAuthService(repo auth.RepositoryAuthInterface, jwt utils.JWTInterface, u
 		hash:        hash,
 		cache:       cache,
 		email:       email,
+		identity:    identity,
+		devices:     devices,
+		roles:       roles,
 	}
 }
 
+const (
+	loginFailureLimit  = 5
+	loginFailureWindow = 15 * time.Minute
+	loginLockDuration  = 15 * time.Minute
+	otpFailureLimit    = 5
+	otpFailureWindow   = 10 * time.Minute
+
+	twoFactorIssuer        = "Disappear"
+	twoFactorFailureLimit  = 5
+	twoFactorFailureWindow = 15 * time.Minute
+	recoveryCodeCount      = 10
+)
+
+// generateCacheKey keys per-email state case-insensitively, so "A@x.com" and
+// "a@x.com " share one counter.
 func generateCacheKey(email, action string) string {
-	return fmt.Sprintf("auth:%s:%s", email, action)
+	return fmt.Sprintf("auth:%s:%s", strings.ToLower(strings.TrimSpace(email)), action)
+}
+
+func otpFailureCacheKey(userID uint64) string {
+	return fmt.Sprintf("auth:otp-failures:%d", userID)
 }
 
 func (s *AuthService) Register(newData *entities.UserModels) (*entities.UserModels, error) {
@@ -64,7 +100,10 @@ func (s *AuthService) Register(newData *entities.UserModels) (*entities.UserMode
 		return nil, err
 	}
 
-	generateOTP := otp.GenerateRandomOTP(6)
+	generateOTP, err := otp.GenerateRandomOTP(6)
+	if err != nil {
+		return nil, errors.New("gagal membuat OTP")
+	}
 	newOTP := &entities.OTPModels{
 		UserID:     int(result.ID),
 		OTP:        generateOTP,
@@ -82,52 +121,34 @@ func (s *AuthService) Register(newData *entities.UserModels) (*entities.UserMode
 	return result, nil
 }
 
-func (s *AuthService) Login(email, password, deviceToken string) (*entities.UserModels, string, error) {
-	cachedToken, err := s.cache.Get(email)
-	if err == nil {
-		return nil, string(cachedToken), nil
+func (s *AuthService) Login(email, password, deviceToken string) (*entities.UserModels, *dto.TokenPair, error) {
+	if s.isAccountLocked(email) {
+		return nil, nil, errors.New("akun terkunci sementara, silakan coba lagi nanti")
 	}
+
 	user, err := s.userService.GetUsersByEmail(email)
 	if err != nil {
-		return nil, "", errors.New("user tidak ditemukan")
+		return nil, nil, errors.New("user tidak ditemukan")
 	}
 	if !user.IsVerified {
-		return nil, "", errors.New("akun anda belum diverifikasi")
+		return nil, nil, errors.New("akun anda belum diverifikasi")
 	}
 	isValidPassword, err := s.hash.ComparePassword(user.Password, password)
 	if err != nil || !isValidPassword {
-		return nil, "", errors.New("password salah")
-	}
-
-	user.LastLogin = time.Now()
-	if err := s.repo.UpdateLastLogin(user.ID, user.LastLogin); err != nil {
-		return nil, "", errors.New("gagal memperbarui LastLogin")
+		s.recordLoginFailure(email)
+		return nil, nil, errors.New("password salah")
 	}
+	s.clearCounter(generateCacheKey(email, "login_failures"))
 
-	accessToken, err := s.jwt.GenerateJWT(user.ID, user.Email, user.Role)
+	challenge, err := s.twoFactorChallenge(user, false)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
-
-	cekDeviceToken, err := s.repo.CekDeviceTokenByEmail(email)
-	if err != nil {
-		return nil, "", err
-	}
-	if cekDeviceToken != deviceToken {
-		_, err := s.repo.UpdateDeviceTokenByID(email, deviceToken)
-		if err != nil {
-			return nil, "", errors.New("gagal memperbarui device token")
-		}
-
+	if challenge != nil {
+		return user, challenge, nil
 	}
 
-	err = s.cache.Set(email, []byte(accessToken), 1*time.Second)
-	if err != nil {
-		log.Error("Failed to store accessToken in cache:", err)
-		return nil, "", errors.New("internal server error")
-	}
-
-	return user, accessToken, nil
+	return s.completeLogin(user, deviceToken)
 }
 
 func (s *AuthService) VerifyEmail(email, otp string) error {
@@ -151,8 +172,9 @@ func (s *AuthService) VerifyEmail(email, otp string) error {
 	}
 
 	if isValidOTP.ID == 0 {
-		return errors.New("invalid atau OTP telah kadaluarsa")
+		return s.recordOTPFailure(user.ID)
 	}
+	s.clearCounter(otpFailureCacheKey(user.ID))
 
 	user.IsVerified = true
 
@@ -189,7 +211,10 @@ func (s *AuthService) ResendOTP(email string) (*entities.OTPModels, error) {
 	if errDeleteOTP != nil {
 		return nil, errDeleteOTP
 	}
-	generateOTP := otp.GenerateRandomOTP(6)
+	generateOTP, err := otp.GenerateRandomOTP(6)
+	if err != nil {
+		return nil, errors.New("gagal membuat OTP")
+	}
 	newOTP := &entities.OTPModels{
 		UserID:     int(user.ID),
 		OTP:        generateOTP,
@@ -200,6 +225,7 @@ func (s *AuthService) ResendOTP(email string) (*entities.OTPModels, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.clearCounter(otpFailureCacheKey(user.ID))
 	return newOTP, nil
 }
 
@@ -225,86 +251,162 @@ func (s *AuthService) ResetPassword(email, newPassword, confirmPass string) erro
 	if err != nil {
 		return errors.New("gagal reset pass: ")
 	}
+
+	if err := s.revokeSessions(user.ID); err != nil {
+		return err
+	}
+
+	s.sendPasswordChanged(user)
 	return nil
 }
 
-func (s *AuthService) VerifyOTP(email, otp string) (string, error) {
+// sendPasswordChanged lives outside ResetPassword, whose email parameter
+// shadows the email package.
+func (s *AuthService) sendPasswordChanged(user *entities.UserModels) {
+	email.SendPasswordChanged(s.email, user.ID, user.Name, user.Email)
+}
+
+// VerifyOTP checks the forgot-password OTP. Accounts with 2FA get a partial
+// token for VerifyPasswordResetTwoFactor, everyone else a reset token that is
+// only accepted for setting a new password.
+func (s *AuthService) VerifyOTP(email, otp string) (*dto.VerifyOTPResponse, error) {
 	emailVerifyCacheKey := generateCacheKey(email, "verify_status")
 	isVerified, err := s.cache.Get(emailVerifyCacheKey)
 	if err == nil && string(isVerified) == "true" {
-		return "", errors.New("email sudah diverifikasi")
+		return nil, errors.New("email sudah diverifikasi")
 	}
 
 	user, err := s.userService.GetUsersByEmail(email)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if user == nil {
-		return "", errors.New("user tidak ditemukan")
-	}
-
-	accessTokenCacheKey := generateCacheKey(email, "access_token")
-	cachedToken, err := s.cache.Get(accessTokenCacheKey)
-	if err == nil {
-		return string(cachedToken), nil
+		return nil, errors.New("user tidak ditemukan")
 	}
 
 	isValidOTP, err := s.repo.FindValidOTP(int(user.ID), otp)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if isValidOTP.ID == 0 {
-		return "", errors.New("invalid atau OTP telah kadaluarsa")
+		return nil, s.recordOTPFailure(user.ID)
 	}
+	s.clearCounter(otpFailureCacheKey(user.ID))
 
 	user.IsVerified = true
 
 	_, errUpdate := s.repo.UpdateUser(user)
 	if errUpdate != nil {
-		return "", errors.New("gagal verifikasi email")
+		return nil, errors.New("gagal verifikasi email")
 	}
 
 	errDeleteOTP := s.repo.DeleteOTP(isValidOTP)
 	if errDeleteOTP != nil {
-		return "", errors.New("gagal delete OTP")
+		return nil, errors.New("gagal delete OTP")
 	}
 
-	accessToken, err := s.jwt.GenerateJWT(user.ID, user.Role, user.Email)
+	err = s.cache.Set(emailVerifyCacheKey, []byte("true"), 1*time.Second)
 	if err != nil {
-		return "", errors.New("gagal generate access token")
+		return nil, errors.New("gagal menyimpan status verifikasi email ke cache")
 	}
 
-	err = s.cache.Set(accessTokenCacheKey, []byte(accessToken), 1*time.Second)
+	challenge, err := s.twoFactorChallenge(user, true)
 	if err != nil {
-		return "", errors.New("gagal menyimpan access token ke cache")
+		return nil, err
+	}
+	if challenge != nil {
+		return &dto.VerifyOTPResponse{
+			TwoFactorToken: challenge.TwoFactorToken,
+			ExpiresIn:      challenge.ExpiresIn,
+		}, nil
 	}
 
-	err = s.cache.Set(emailVerifyCacheKey, []byte("true"), 1*time.Second)
+	return s.issuePasswordResetToken(user)
+}
+
+// VerifyPasswordResetTwoFactor is the TOTP step of the forgot-password flow for
+// accounts with 2FA. Like the login step it spends the partial token on success
+// and after too many wrong codes.
+func (s *AuthService) VerifyPasswordResetTwoFactor(twoFactorToken, code string) (*dto.VerifyOTPResponse, error) {
+	token, err := s.jwt.ValidatePasswordResetTwoFactorToken(twoFactorToken)
+	if err != nil {
+		return nil, errors.New("token 2FA tidak valid")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("token 2FA tidak valid")
+	}
+	userID, _ := claims["user_id"].(float64)
+
+	user, err := s.userService.GetUsersById(uint64(userID))
 	if err != nil {
-		return "", errors.New("gagal menyimpan status verifikasi email ke cache")
+		return nil, errors.New("pengguna tidak ditemukan")
+	}
+	if s.isAccountLocked(user.Email) {
+		return nil, errors.New("akun terkunci sementara, silakan coba lagi nanti")
+	}
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return nil, errors.New("2FA belum aktif")
+	}
+
+	if err := s.verifyTwoFactorCode(user.ID, twoFactor.Secret, code, true); err != nil {
+		if lockErr := s.recordTwoFactorFailure(user, twoFactorToken); lockErr != nil {
+			return nil, lockErr
+		}
+		return nil, err
+	}
+	s.clearCounter(twoFactorFailureCacheKey(user.ID))
+	if err := s.jwt.RevokeToken(twoFactorToken); err != nil {
+		log.Error("Gagal mencabut token 2FA: ", err)
 	}
 
-	return accessToken, nil
+	return s.issuePasswordResetToken(user)
+}
+
+func (s *AuthService) issuePasswordResetToken(user *entities.UserModels) (*dto.VerifyOTPResponse, error) {
+	resetToken, err := s.jwt.GeneratePasswordResetToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, errors.New("gagal membuat token reset password")
+	}
+	return &dto.VerifyOTPResponse{
+		ResetToken: resetToken,
+		ExpiresIn:  int64(utils.PasswordResetTokenTTL.Seconds()),
+	}, nil
 }
 
 func (s *AuthService) RegisterSocial(req *dto.RegisterSocialRequest) (*entities.UserModels, error) {
-	existingUser, _ := s.userService.GetUsersByEmail(req.Email)
+	socialIdentity, err := s.verifySocialIdentity(req.IDToken)
+	if err != nil {
+		return nil, err
+	}
+
+	existingUser, _ := s.userService.GetUsersByEmail(socialIdentity.Email)
 	if existingUser != nil {
 		return nil, errors.New("email sudah terdaftar")
 	}
 
-	existingUserBySocialID, _ := s.repo.FindUserBySocialID(req.SocialID)
+	existingUserBySocialID, _ := s.repo.FindUserBySocialID(socialIdentity.SocialID)
 	if existingUserBySocialID != nil {
 		return nil, errors.New("Social ID sudah terdaftar")
 	}
 
+	name := req.Name
+	if name == "" {
+		name = socialIdentity.Name
+	}
+	photoProfile := req.PhotoProfile
+	if photoProfile == "" {
+		photoProfile = socialIdentity.Picture
+	}
+
 	value := &entities.UserModels{
-		SocialID:     req.SocialID,
-		Provider:     req.Provider,
-		Email:        req.Email,
-		Name:         req.Name,
-		PhotoProfile: req.PhotoProfile,
+		SocialID:     socialIdentity.SocialID,
+		Provider:     socialIdentity.Provider,
+		Email:        socialIdentity.Email,
+		Name:         name,
+		PhotoProfile: photoProfile,
 		Role:         "customer",
 		Level:        "bronze",
 		LastLogin:    time.Time{},
@@ -317,25 +419,536 @@ func (s *AuthService) RegisterSocial(req *dto.RegisterSocialRequest) (*entities.
 	return result, nil
 }
 
-func (s *AuthService) LoginSocial(socialID string) (*entities.UserModels, string, error) {
-	user, err := s.repo.FindUserBySocialID(socialID)
+func (s *AuthService) LoginSocial(idToken string) (*entities.UserModels, *dto.TokenPair, error) {
+	socialIdentity, err := s.verifySocialIdentity(idToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.repo.FindUserBySocialID(socialIdentity.SocialID)
 	if err != nil {
-		return nil, "", errors.New("pengguna tidak ditemukan")
+		return nil, nil, errors.New("pengguna tidak ditemukan")
 	}
 
 	if user == nil {
-		return nil, "", errors.New("pengguna tidak ditemukan")
+		return nil, nil, errors.New("pengguna tidak ditemukan")
 	}
 
+	challenge, err := s.twoFactorChallenge(user, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if challenge != nil {
+		return user, challenge, nil
+	}
+
+	return s.completeLogin(user, "")
+}
+
+// completeLogin runs once every login step has passed. A device token is added
+// to the user's devices; the others stay registered.
+func (s *AuthService) completeLogin(user *entities.UserModels, deviceToken string) (*entities.UserModels, *dto.TokenPair, error) {
 	user.LastLogin = time.Now()
 	if err := s.repo.UpdateLastLogin(user.ID, user.LastLogin); err != nil {
-		return nil, "", errors.New("gagal memperbarui LastLogin")
+		return nil, nil, errors.New("gagal memperbarui LastLogin")
+	}
+
+	tokens, err := s.issueTokens(user)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if deviceToken == "" {
+		return user, tokens, nil
+	}
+	device := &entities.UserDeviceModels{
+		UserID:     user.ID,
+		Token:      deviceToken,
+		LastSeenAt: user.LastLogin,
+	}
+	if err := s.devices.SaveDevice(device); err != nil {
+		return nil, nil, errors.New("gagal memperbarui device token")
+	}
+
+	return user, tokens, nil
+}
+
+// verifySocialIdentity checks the provider ID token. Only the verified claims
+// are trusted, never a social ID or email sent by the client.
+func (s *AuthService) verifySocialIdentity(idToken string) (*identity.Identity, error) {
+	socialIdentity, err := s.identity.Verify(idToken)
+	if err != nil {
+		return nil, identity.ErrInvalidToken
+	}
+	if socialIdentity.Email == "" || !socialIdentity.EmailVerified {
+		return nil, errors.New("email akun sosial belum diverifikasi")
+	}
+	return socialIdentity, nil
+}
+
+// RefreshToken rotates a refresh token. Presenting a token that was already
+// rotated means it leaked, so every session of that user is revoked.
+func (s *AuthService) RefreshToken(refreshToken string) (*dto.TokenPair, error) {
+	stored, err := s.repo.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, errors.New("refresh token tidak valid")
+	}
+	if stored.RevokedAt != nil {
+		if err := s.revokeSessions(stored.UserID); err != nil {
+			log.Error("Gagal mencabut sesi setelah refresh token dipakai ulang: ", err)
+		}
+		return nil, errors.New("refresh token tidak valid")
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, errors.New("refresh token telah kadaluarsa")
+	}
+
+	version, err := s.jwt.TokenVersion(stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.TokenVersion != version {
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	user, err := s.userService.GetUsersById(stored.UserID)
+	if err != nil {
+		return nil, errors.New("pengguna tidak ditemukan")
+	}
+
+	accessToken, err := s.jwt.GenerateJWT(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	newRefreshToken, newStored, err := newRefreshTokenRecord(user.ID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.repo.RotateRefreshToken(stored.ID, newStored)
+	if err != nil {
+		return nil, errors.New("gagal memperbarui refresh token")
+	}
+	if !rotated {
+		if err := s.revokeSessions(stored.UserID); err != nil {
+			log.Error("Gagal mencabut sesi setelah refresh token dipakai ulang: ", err)
+		}
+		return nil, errors.New("refresh token tidak valid")
+	}
+
+	return &dto.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *AuthService) Logout(userID uint64, accessToken, refreshToken string) error {
+	if err := s.jwt.RevokeToken(accessToken); err != nil {
+		return errors.New("gagal mencabut access token")
+	}
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.repo.GetRefreshTokenByHash(hashRefreshToken(refreshToken))
+	if err != nil || stored.UserID != userID {
+		return nil
+	}
+	if err := s.repo.RevokeRefreshToken(stored.ID); err != nil {
+		return errors.New("gagal mencabut refresh token")
 	}
+	return nil
+}
 
+func (s *AuthService) LogoutAll(userID uint64) error {
+	return s.revokeSessions(userID)
+}
+
+func (s *AuthService) issueTokens(user *entities.UserModels) (*dto.TokenPair, error) {
 	accessToken, err := s.jwt.GenerateJWT(user.ID, user.Email, user.Role)
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
+	version, err := s.jwt.TokenVersion(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, stored, err := newRefreshTokenRecord(user.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateRefreshToken(stored); err != nil {
+		return nil, errors.New("gagal menyimpan refresh token")
+	}
+
+	return &dto.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// recordLoginFailure locks the account for a while once the failed attempts in
+// the current window reach the limit.
+func (s *AuthService) recordLoginFailure(email string) {
+	failuresKey := generateCacheKey(email, "login_failures")
+	failures, err := s.cache.Incr(failuresKey, loginFailureWindow)
+	if err != nil {
+		log.Error("Gagal mencatat percobaan masuk: ", err)
+		return
+	}
+	if failures < loginFailureLimit {
+		return
+	}
+	if !s.lockAccount(email) {
+		return
+	}
+	s.clearCounter(failuresKey)
+}
+
+// isAccountLocked reports whether too many wrong passwords or 2FA codes locked
+// the account.
+func (s *AuthService) isAccountLocked(email string) bool {
+	_, err := s.cache.Get(generateCacheKey(email, "login_locked"))
+	return err == nil
+}
+
+func (s *AuthService) lockAccount(email string) bool {
+	if err := s.cache.Set(generateCacheKey(email, "login_locked"), []byte("true"), loginLockDuration); err != nil {
+		log.Error("Gagal mengunci akun: ", err)
+		return false
+	}
+	return true
+}
+
+// recordOTPFailure deletes the user's OTP once too many wrong codes were
+// tried, so the remaining guesses cannot be spent on the same code.
+func (s *AuthService) recordOTPFailure(userID uint64) error {
+	failuresKey := otpFailureCacheKey(userID)
+	failures, err := s.cache.Incr(failuresKey, otpFailureWindow)
+	if err != nil {
+		log.Error("Gagal mencatat percobaan OTP: ", err)
+		return errors.New("invalid atau OTP telah kadaluarsa")
+	}
+	if failures < otpFailureLimit {
+		return errors.New("invalid atau OTP telah kadaluarsa")
+	}
+
+	if err := s.repo.DeleteUserOTP(userID); err != nil {
+		return errors.New("gagal menghapus OTP")
+	}
+	s.clearCounter(failuresKey)
+	return errors.New("terlalu banyak percobaan OTP, silakan minta OTP baru")
+}
+
+func (s *AuthService) clearCounter(key string) {
+	if err := s.cache.Del(key); err != nil {
+		log.Error("Gagal menghapus penghitung percobaan: ", err)
+	}
+}
+
+func (s *AuthService) revokeSessions(userID uint64) error {
+	if err := s.repo.RevokeUserRefreshTokens(userID); err != nil {
+		return errors.New("gagal mencabut refresh token")
+	}
+	if err := s.jwt.RevokeUserTokens(userID); err != nil {
+		return errors.New("gagal mencabut access token")
+	}
+	return nil
+}
+
+func newRefreshTokenRecord(userID uint64, version int64) (string, *entities.RefreshTokenModels, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	return refreshToken, &entities.RefreshTokenModels{
+		UserID:       userID,
+		TokenHash:    hashRefreshToken(refreshToken),
+		TokenVersion: version,
+		ExpiresAt:    time.Now().Add(utils.RefreshTokenTTL),
+	}, nil
+}
+
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// twoFactorChallenge returns a partial token when the user has to pass the TOTP
+// step, or nil when the login can be completed right away. Staff without 2FA
+// get a partial token that is only good for enrolling. For a password reset
+// only accounts with 2FA are challenged and the token is only good for
+// VerifyPasswordResetTwoFactor.
+func (s *AuthService) twoFactorChallenge(user *entities.UserModels, passwordReset bool) (*dto.TokenPair, error) {
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal memeriksa status 2FA")
+	}
+	enabled := err == nil && twoFactor.EnabledAt != nil
+	setupRequired := false
+	if !enabled && !passwordReset {
+		setupRequired, err = s.requiresTwoFactor(user)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !enabled && !setupRequired {
+		return nil, nil
+	}
+
+	generate := s.jwt.GenerateTwoFactorToken
+	if passwordReset {
+		generate = s.jwt.GeneratePasswordResetTwoFactorToken
+	}
+	twoFactorToken, err := generate(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TokenPair{
+		ExpiresIn:              int64(utils.TwoFactorTokenTTL.Seconds()),
+		TwoFactorToken:         twoFactorToken,
+		TwoFactorSetupRequired: setupRequired,
+	}, nil
+}
+
+// requiresTwoFactor reports whether the user's role grants any management
+// permission. Custom staff roles are covered the same way as admin.
+func (s *AuthService) requiresTwoFactor(user *entities.UserModels) (bool, error) {
+	permissions, err := s.roles.GetRolePermissions(user.Role)
+	if err != nil {
+		return false, errors.New("gagal memeriksa izin pengguna")
+	}
+	return role.HasManagementPermission(permissions), nil
+}
+
+func (s *AuthService) SetupTwoFactor(user *entities.UserModels) (*dto.TwoFactorSetupResponse, error) {
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("gagal memeriksa status 2FA")
+	}
+	if err == nil && twoFactor.EnabledAt != nil {
+		return nil, errors.New("2FA sudah aktif")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, errors.New("gagal membuat kunci 2FA")
+	}
+	if err := s.repo.SaveTwoFactorSecret(user.ID, secret); err != nil {
+		return nil, errors.New("gagal menyimpan kunci 2FA")
+	}
+
+	uri := totp.URI(twoFactorIssuer, user.Email, secret)
+	qrCode, err := totp.QRCode(uri)
+	if err != nil {
+		return nil, errors.New("gagal membuat kode QR")
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OtpauthURI: uri,
+		QRCode:     qrCode,
+	}, nil
+}
+
+// EnableTwoFactor confirms the pending secret with a code from the app and
+// hands out the recovery codes. When enrolling during login the partial token
+// is spent and a full token pair is returned as well.
+func (s *AuthService) EnableTwoFactor(user *entities.UserModels, code, twoFactorToken, deviceToken string) ([]string, *dto.TokenPair, error) {
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, errors.New("2FA belum disiapkan")
+	}
+	if err != nil {
+		return nil, nil, errors.New("gagal memeriksa status 2FA")
+	}
+	if twoFactor.EnabledAt != nil {
+		return nil, nil, errors.New("2FA sudah aktif")
+	}
+	if err := s.verifyTwoFactorCode(user.ID, twoFactor.Secret, code, false); err != nil {
+		return nil, nil, err
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, nil, errors.New("gagal membuat kode pemulihan")
+	}
+	if err := s.repo.EnableTwoFactor(user.ID, recoveryCodeHashes); err != nil {
+		return nil, nil, errors.New("gagal mengaktifkan 2FA")
+	}
+
+	if twoFactorToken == "" {
+		return recoveryCodes, nil, nil
+	}
+	if err := s.jwt.RevokeToken(twoFactorToken); err != nil {
+		log.Error("Gagal mencabut token 2FA: ", err)
+	}
+	_, tokens, err := s.completeLogin(user, deviceToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return recoveryCodes, tokens, nil
+}
+
+// VerifyTwoFactorLogin is the second login step. The partial token is spent on
+// success and after too many wrong codes.
+func (s *AuthService) VerifyTwoFactorLogin(twoFactorToken, code, deviceToken string) (*entities.UserModels, *dto.TokenPair, error) {
+	token, err := s.jwt.ValidateTwoFactorToken(twoFactorToken)
+	if err != nil {
+		return nil, nil, errors.New("token 2FA tidak valid")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, nil, errors.New("token 2FA tidak valid")
+	}
+	userID, _ := claims["user_id"].(float64)
+
+	user, err := s.userService.GetUsersById(uint64(userID))
+	if err != nil {
+		return nil, nil, errors.New("pengguna tidak ditemukan")
+	}
+	if s.isAccountLocked(user.Email) {
+		return nil, nil, errors.New("akun terkunci sementara, silakan coba lagi nanti")
+	}
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return nil, nil, errors.New("2FA belum aktif")
+	}
+
+	if err := s.verifyTwoFactorCode(user.ID, twoFactor.Secret, code, true); err != nil {
+		if lockErr := s.recordTwoFactorFailure(user, twoFactorToken); lockErr != nil {
+			return nil, nil, lockErr
+		}
+		return nil, nil, err
+	}
+	s.clearCounter(twoFactorFailureCacheKey(user.ID))
+	if err := s.jwt.RevokeToken(twoFactorToken); err != nil {
+		log.Error("Gagal mencabut token 2FA: ", err)
+	}
+
+	return s.completeLogin(user, deviceToken)
+}
+
+func (s *AuthService) DisableTwoFactor(user *entities.UserModels, code string) error {
+	required, err := s.requiresTwoFactor(user)
+	if err != nil {
+		return err
+	}
+	if required {
+		return errors.New("pengguna dengan izin pengelolaan wajib menggunakan 2FA")
+	}
+	twoFactor, err := s.repo.GetTwoFactorByUserID(user.ID)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return errors.New("2FA belum aktif")
+	}
+	if err := s.verifyTwoFactorCode(user.ID, twoFactor.Secret, code, true); err != nil {
+		return err
+	}
+	if err := s.repo.DisableTwoFactor(user.ID); err != nil {
+		return errors.New("gagal menonaktifkan 2FA")
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces every recovery code. It asks for a code from
+// the app rather than a recovery code, so a leaked code list cannot renew
+// itself.
+func (s *AuthService) RegenerateRecoveryCodes(userID uint64, code string) ([]string, error) {
+	twoFactor, err := s.repo.GetTwoFactorByUserID(userID)
+	if err != nil || twoFactor.EnabledAt == nil {
+		return nil, errors.New("2FA belum aktif")
+	}
+	if err := s.verifyTwoFactorCode(userID, twoFactor.Secret, code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, recoveryCodeHashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.New("gagal membuat kode pemulihan")
+	}
+	if err := s.repo.ReplaceRecoveryCodes(userID, recoveryCodeHashes); err != nil {
+		return nil, errors.New("gagal menyimpan kode pemulihan")
+	}
+	return recoveryCodes, nil
+}
+
+// verifyTwoFactorCode accepts a TOTP code at most once per time step, or an
+// unused recovery code when allowRecovery is set.
+func (s *AuthService) verifyTwoFactorCode(userID uint64, secret, code string, allowRecovery bool) error {
+	if step, ok := totp.Validate(secret, code, time.Now()); ok {
+		stepKey := fmt.Sprintf("auth:2fa-step:%d:%d", userID, step)
+		fresh, err := s.cache.SetNX(stepKey, []byte("1"), (2*totp.Skew+1)*totp.Period)
+		if err != nil {
+			return errors.New("gagal memverifikasi kode 2FA")
+		}
+		if !fresh {
+			return errors.New("kode 2FA sudah digunakan")
+		}
+		return nil
+	}
+
+	if allowRecovery {
+		used, err := s.repo.UseRecoveryCode(userID, hashRecoveryCode(code))
+		if err != nil {
+			return errors.New("gagal memverifikasi kode 2FA")
+		}
+		if used {
+			return nil
+		}
+	}
+	return errors.New("kode 2FA tidak valid")
+}
+
+// recordTwoFactorFailure locks the account like too many wrong passwords do
+// once the failed codes reach the limit. Signing in again only gives another
+// partial token, so the lock is what stops the guessing; every partial token
+// of the account is refused while it lasts.
+func (s *AuthService) recordTwoFactorFailure(user *entities.UserModels, twoFactorToken string) error {
+	failuresKey := twoFactorFailureCacheKey(user.ID)
+	failures, err := s.cache.Incr(failuresKey, twoFactorFailureWindow)
+	if err != nil {
+		log.Error("Gagal mencatat percobaan 2FA: ", err)
+		return nil
+	}
+	if failures < twoFactorFailureLimit {
+		return nil
+	}
+
+	if err := s.jwt.RevokeToken(twoFactorToken); err != nil {
+		log.Error("Gagal mencabut token 2FA: ", err)
+	}
+	if s.lockAccount(user.Email) {
+		s.clearCounter(failuresKey)
+	}
+	return errors.New("terlalu banyak percobaan 2FA, akun terkunci sementara")
+}
+
+// generateRecoveryCodes returns the codes to show once and the hashes to store.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(buf)
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
 
-	return user, accessToken, nil
+func twoFactorFailureCacheKey(userID uint64) string {
+	return fmt.Sprintf("auth:2fa-failures:%d", userID)
 }
@@ -1,19 +1,35 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"github.com/capstone-kelompok-7/backend-disappear/utils/otp"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/capstone-kelompok-7/backend-disappear/utils/identity"
+	identityMocks "github.com/capstone-kelompok-7/backend-disappear/utils/identity/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/otp"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/totp"
+	"github.com/golang-jwt/jwt"
+
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/auth/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/auth/mocks"
+	fcmMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	roleMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/role/mocks"
 	userMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
 	user "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/service"
 	utils "github.com/capstone-kelompok-7/backend-disappear/utils/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupTestService(t *testing.T) (
@@ -23,42 +39,105 @@ func setupTestService(t *testing.T) (
 	*userMocks.RepositoryUserInterface,
 	*utils.HashInterface,
 	*utils.CacheRepository,
-	*utils.EmailSenderInterface) {
+	*utils.EmailSenderInterface,
+	*roleMocks.ServiceRoleInterface) {
 
 	repo := mocks.NewRepositoryAuthInterface(t)
 	jwt := utils.NewJWTInterface(t)
 	userRepo := userMocks.NewRepositoryUserInterface(t)
 	hash := utils.NewHashInterface(t)
 	cache := utils.NewCacheRepository(t)
-	userService := user.NewUserService(userRepo, hash)
+	userService := user.NewUserService(userRepo, hash, jwt, nil)
 	email := utils.NewEmailSenderInterface(t)
-	service := NewAuthService(repo, jwt, userService, hash, cache, email)
+	roles := roleMocks.NewServiceRoleInterface(t)
+	service := NewAuthService(repo, jwt, userService, hash, cache, email, identityMocks.NewVerifier(t), fcmMocks.NewRepositoryFcmInterface(t), roles)
+
+	return service.(*AuthService), repo, jwt, userRepo, hash, cache, email, roles
+}
+
+const testAudience = "disappear-test"
+
+type testIdentityProvider struct {
+	key      *rsa.PrivateKey
+	verifier *identity.JWKSVerifier
+}
+
+// newTestIdentityProvider serves a locally generated RSA key as JWKS so the
+// real verifier can be used against tokens signed in the test.
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=3600")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "test-key",
+				"kty": "RSA",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return &testIdentityProvider{
+		key:      key,
+		verifier: identity.NewJWKSVerifier(identity.ProviderGoogle, server.URL, testAudience, "https://accounts.google.com"),
+	}
+}
+
+func (p *testIdentityProvider) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
 
-	return service.(*AuthService), repo, jwt, userRepo, hash, cache, email
+func identityClaims(socialID, email string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            "https://accounts.google.com",
+		"aud":            testAudience,
+		"sub":            socialID,
+		"email":          email,
+		"email_verified": true,
+		"name":           "Joni",
+		"picture":        "photo.jpg",
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
 }
 
 func TestAuthService_RegisterSocial(t *testing.T) {
+	provider := newTestIdentityProvider(t)
 	userID := uint64(1)
+	socialID := "123123131231"
+	email := "email@email.com"
 	request := &dto.RegisterSocialRequest{
-		SocialID:     "123123131231",
-		Provider:     "Google",
-		Email:        "email@email.com",
-		Name:         "Joni",
-		PhotoProfile: "photo.jpg",
+		IDToken: provider.sign(t, identityClaims(socialID, email)),
 	}
 	existingUser := &entities.UserModels{
 		ID:           userID,
-		SocialID:     "123123131231",
-		Email:        "email@email.com",
+		SocialID:     socialID,
+		Email:        email,
 		Name:         "Joni",
 		PhotoProfile: "photo.jpg",
 	}
 
 	t.Run("Success Case - Register Social", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
-		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
-		authRepo.On("FindUserBySocialID", request.SocialID).Return(nil, nil)
-		authRepo.On("Register", mock.AnythingOfType("*entities.UserModels")).Return(existingUser, nil)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		userRepo.On("GetUsersByEmail", email).Return(nil, nil)
+		authRepo.On("FindUserBySocialID", socialID).Return(nil, nil)
+		authRepo.On("Register", mock.MatchedBy(func(user *entities.UserModels) bool {
+			return user.SocialID == socialID && user.Email == email && user.Provider == identity.ProviderGoogle && user.Name == "Joni"
+		})).Return(existingUser, nil)
 
 		result, err := authService.RegisterSocial(request)
 
@@ -70,8 +149,9 @@ func TestAuthService_RegisterSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Email Already Exists", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
-		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil)
 
 		result, err := authService.RegisterSocial(request)
 
@@ -84,9 +164,10 @@ func TestAuthService_RegisterSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Google ID Already Exists", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
-		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
-		authRepo.On("FindUserBySocialID", request.SocialID).Return(existingUser, nil)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		userRepo.On("GetUsersByEmail", email).Return(nil, nil)
+		authRepo.On("FindUserBySocialID", socialID).Return(existingUser, nil)
 
 		result, err := authService.RegisterSocial(request)
 
@@ -98,10 +179,11 @@ func TestAuthService_RegisterSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Register Social Error", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
 		expectedError := errors.New("gagal mendaftarkan pengguna baru")
-		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
-		authRepo.On("FindUserBySocialID", request.SocialID).Return(nil, nil)
+		userRepo.On("GetUsersByEmail", email).Return(nil, nil)
+		authRepo.On("FindUserBySocialID", socialID).Return(nil, nil)
 		authRepo.On("Register", mock.AnythingOfType("*entities.UserModels")).Return(nil, expectedError)
 
 		result, err := authService.RegisterSocial(request)
@@ -112,11 +194,25 @@ func TestAuthService_RegisterSocial(t *testing.T) {
 
 		authRepo.AssertExpectations(t)
 	})
+
+	t.Run("Failed Case - Unverified Email", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		claims := identityClaims(socialID, email)
+		claims["email_verified"] = false
+
+		result, err := authService.RegisterSocial(&dto.RegisterSocialRequest{IDToken: provider.sign(t, claims)})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "email akun sosial belum diverifikasi")
+		authRepo.AssertNotCalled(t, "Register", mock.Anything)
+	})
 }
 
 func TestAuthService_LoginSocial(t *testing.T) {
-
+	provider := newTestIdentityProvider(t)
 	socialID := "someSocialID"
+	idToken := provider.sign(t, identityClaims(socialID, "test@example.com"))
 
 	user := &entities.UserModels{
 		ID:        1,
@@ -127,27 +223,34 @@ func TestAuthService_LoginSocial(t *testing.T) {
 	}
 
 	t.Run("Success Case - User Found and Login", func(t *testing.T) {
-		authService, authRepo, jwtService, _, _, _, _ := setupTestService(t)
+		authService, authRepo, jwtService, _, _, _, _, roles := setupTestService(t)
+		authService.identity = provider.verifier
 		authRepo.On("FindUserBySocialID", socialID).Return(user, nil)
+		authRepo.On("GetTwoFactorByUserID", user.ID).Return(nil, gorm.ErrRecordNotFound)
+		roles.On("GetRolePermissions", user.Role).Return(map[string]bool{role.PermissionOwnCart: true}, nil)
 		authRepo.On("UpdateLastLogin", user.ID, mock.AnythingOfType("time.Time")).Return(nil)
 		jwtService.On("GenerateJWT", user.ID, user.Email, user.Role).Return("someAccessToken", nil)
+		jwtService.On("TokenVersion", user.ID).Return(int64(0), nil)
+		authRepo.On("CreateRefreshToken", mock.AnythingOfType("*entities.RefreshTokenModels")).Return(nil)
 
-		foundUser, accessToken, err := authService.LoginSocial(socialID)
+		foundUser, tokens, err := authService.LoginSocial(idToken)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, foundUser)
-		assert.Equal(t, "someAccessToken", accessToken)
+		assert.Equal(t, "someAccessToken", tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
 
 		authRepo.AssertExpectations(t)
 		jwtService.AssertExpectations(t)
 	})
 
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
-		authService, authRepo, jwtService, _, _, _, _ := setupTestService(t)
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
 		expectedError := errors.New("pengguna tidak ditemukan")
 		authRepo.On("FindUserBySocialID", socialID).Return(nil, expectedError)
 
-		foundUser, accessToken, err := authService.LoginSocial(socialID)
+		foundUser, accessToken, err := authService.LoginSocial(idToken)
 
 		assert.Error(t, err)
 		assert.Nil(t, foundUser)
@@ -159,10 +262,11 @@ func TestAuthService_LoginSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - User is Nil After Finding", func(t *testing.T) {
-		authService, authRepo, _, _, _, _, _ := setupTestService(t)
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
 		authRepo.On("FindUserBySocialID", socialID).Return(nil, nil)
 
-		foundUser, _, err := authService.LoginSocial(socialID)
+		foundUser, _, err := authService.LoginSocial(idToken)
 
 		assert.Error(t, err)
 		assert.Nil(t, foundUser)
@@ -172,11 +276,14 @@ func TestAuthService_LoginSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Updating LastLogin", func(t *testing.T) {
-		authService, authRepo, jwtService, _, _, _, _ := setupTestService(t)
+		authService, authRepo, jwtService, _, _, _, _, roles := setupTestService(t)
+		authService.identity = provider.verifier
 		authRepo.On("FindUserBySocialID", socialID).Return(user, nil)
+		authRepo.On("GetTwoFactorByUserID", user.ID).Return(nil, gorm.ErrRecordNotFound)
+		roles.On("GetRolePermissions", user.Role).Return(map[string]bool{role.PermissionOwnCart: true}, nil)
 		authRepo.On("UpdateLastLogin", user.ID, mock.AnythingOfType("time.Time")).Return(errors.New("update failed"))
 
-		foundUser, accessToken, err := authService.LoginSocial(socialID)
+		foundUser, accessToken, err := authService.LoginSocial(idToken)
 
 		assert.Error(t, err)
 		assert.Nil(t, foundUser)
@@ -188,14 +295,17 @@ func TestAuthService_LoginSocial(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Generating JWT", func(t *testing.T) {
-		authService, authRepo, jwtService, _, _, _, _ := setupTestService(t)
+		authService, authRepo, jwtService, _, _, _, _, roles := setupTestService(t)
+		authService.identity = provider.verifier
 
 		authRepo.On("FindUserBySocialID", socialID).Return(user, nil)
+		authRepo.On("GetTwoFactorByUserID", user.ID).Return(nil, gorm.ErrRecordNotFound)
+		roles.On("GetRolePermissions", user.Role).Return(map[string]bool{role.PermissionOwnCart: true}, nil)
 		authRepo.On("UpdateLastLogin", user.ID, mock.AnythingOfType("time.Time")).Return(nil)
 		expectedErr := errors.New("JWT generation failed")
 		jwtService.On("GenerateJWT", user.ID, user.Email, user.Role).Return("", expectedErr)
 
-		foundUser, _, err := authService.LoginSocial(socialID)
+		foundUser, _, err := authService.LoginSocial(idToken)
 
 		assert.Error(t, err)
 		assert.Nil(t, foundUser)
@@ -204,6 +314,44 @@ func TestAuthService_LoginSocial(t *testing.T) {
 		authRepo.AssertExpectations(t)
 		jwtService.AssertExpectations(t)
 	})
+
+	t.Run("Failed Case - Forged Token", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		forger := newTestIdentityProvider(t)
+
+		foundUser, _, err := authService.LoginSocial(forger.sign(t, identityClaims(socialID, "test@example.com")))
+
+		assert.Nil(t, foundUser)
+		assert.ErrorIs(t, err, identity.ErrInvalidToken)
+		authRepo.AssertNotCalled(t, "FindUserBySocialID", socialID)
+	})
+
+	t.Run("Failed Case - Wrong Audience", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		claims := identityClaims(socialID, "test@example.com")
+		claims["aud"] = "another-app"
+
+		foundUser, _, err := authService.LoginSocial(provider.sign(t, claims))
+
+		assert.Nil(t, foundUser)
+		assert.ErrorIs(t, err, identity.ErrInvalidToken)
+		authRepo.AssertNotCalled(t, "FindUserBySocialID", socialID)
+	})
+
+	t.Run("Failed Case - Expired Token", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authService.identity = provider.verifier
+		claims := identityClaims(socialID, "test@example.com")
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+
+		foundUser, _, err := authService.LoginSocial(provider.sign(t, claims))
+
+		assert.Nil(t, foundUser)
+		assert.ErrorIs(t, err, identity.ErrInvalidToken)
+		authRepo.AssertNotCalled(t, "FindUserBySocialID", socialID)
+	})
 }
 
 func TestGenerateCacheKey(t *testing.T) {
@@ -214,6 +362,7 @@ func TestGenerateCacheKey(t *testing.T) {
 	result := generateCacheKey(email, action)
 
 	assert.Equal(t, expectedKey, result, "Generated cache key is incorrect")
+	assert.Equal(t, expectedKey, generateCacheKey(" Test@Example.COM ", action), "Cache key should ignore case and surrounding spaces")
 }
 
 func TestAuthService_Register(t *testing.T) {
@@ -232,7 +381,7 @@ func TestAuthService_Register(t *testing.T) {
 	expectedErr := errors.New("some error")
 
 	t.Run("Failed Case - Email Already Exists", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
 		result, err := authService.Register(request)
 
@@ -245,7 +394,7 @@ func TestAuthService_Register(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Generate Hash", func(t *testing.T) {
-		authService, authRepo, _, userRepo, hash, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, hash, _, _, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
 		hash.On("GenerateHash", request.Password).Return("", expectedErr)
 		result, err := authService.Register(request)
@@ -259,7 +408,7 @@ func TestAuthService_Register(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Register", func(t *testing.T) {
-		authService, authRepo, _, userRepo, hash, _, email := setupTestService(t)
+		authService, authRepo, _, userRepo, hash, _, email, _ := setupTestService(t)
 
 		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
 		hash.On("GenerateHash", request.Password).Return(passwordHash, nil)
@@ -277,7 +426,7 @@ func TestAuthService_Register(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Save OTP", func(t *testing.T) {
-		authService, authRepo, _, userRepo, hash, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, hash, _, _, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(nil, nil)
 		hash.On("GenerateHash", request.Password).Return(passwordHash, nil)
 		authRepo.On("Register", mock.AnythingOfType("*entities.UserModels")).Return(&entities.UserModels{ID: userID}, nil)
@@ -294,9 +443,9 @@ func TestAuthService_Register(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Email Sending Error", func(t *testing.T) {
-		authService, authRepo, _, userRepo, hash, _, emailService := setupTestService(t)
+		authService, authRepo, _, userRepo, hash, _, emailService, _ := setupTestService(t)
 
-		generateOTP := otp.GenerateRandomOTP(6)
+		generateOTP, _ := otp.GenerateRandomOTP(6)
 		newOTP := &entities.OTPModels{
 			UserID: int(userID),
 			OTP:    generateOTP,
@@ -321,9 +470,9 @@ func TestAuthService_Register(t *testing.T) {
 	})
 
 	t.Run("Success Case - Register", func(t *testing.T) {
-		authService, authRepo, _, userRepo, hash, _, emailService := setupTestService(t)
+		authService, authRepo, _, userRepo, hash, _, emailService, _ := setupTestService(t)
 
-		generateOTP := otp.GenerateRandomOTP(6)
+		generateOTP, _ := otp.GenerateRandomOTP(6)
 		newOTP := &entities.OTPModels{
 			UserID: int(userID),
 			OTP:    generateOTP,
@@ -350,7 +499,7 @@ func TestAuthService_Register(t *testing.T) {
 }
 
 func TestAuthService_ResetPassword(t *testing.T) {
-	authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
+	authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
 	userID := uint64(1)
 	request := &entities.UserModels{
 		Email:    "email@email.com",
@@ -396,7 +545,7 @@ func TestAuthService_ResetPassword(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Passwords Mismatch", func(t *testing.T) {
-		authService, authRepo, _, userRepo, _, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, _, _, _, _ := setupTestService(t)
 
 		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
 
@@ -410,7 +559,7 @@ func TestAuthService_ResetPassword(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Hash Password Error", func(t *testing.T) {
-		authService, authRepo, _, userRepo, mockHash, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, mockHash, _, _, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
 		mockHash.On("GenerateHash", mock.AnythingOfType("string")).Return("", errors.New("hashing error"))
 
@@ -425,7 +574,7 @@ func TestAuthService_ResetPassword(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Reset Password Error", func(t *testing.T) {
-		authService, authRepo, _, userRepo, mockHash, _, _ := setupTestService(t)
+		authService, authRepo, _, userRepo, mockHash, _, _, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
 		mockHash.On("GenerateHash", mock.AnythingOfType("string")).Return("hashedpassword", nil)
 		authRepo.On("ResetPassword", email, "hashedpassword").Return(errors.New("reset error"))
@@ -441,10 +590,13 @@ func TestAuthService_ResetPassword(t *testing.T) {
 	})
 
 	t.Run("Success Case - Password Reset", func(t *testing.T) {
-		authService, authRepo, _, userRepo, mockHash, _, _ := setupTestService(t)
+		authService, authRepo, jwtService, userRepo, mockHash, _, mailer, _ := setupTestService(t)
 		userRepo.On("GetUsersByEmail", request.Email).Return(existingUser, nil)
 		mockHash.On("GenerateHash", mock.AnythingOfType("string")).Return("newhashedpassword", nil)
 		authRepo.On("ResetPassword", email, "newhashedpassword").Return(nil)
+		authRepo.On("RevokeUserRefreshTokens", existingUser.ID).Return(nil)
+		jwtService.On("RevokeUserTokens", existingUser.ID).Return(nil)
+		mailer.On("SendTemplate", existingUser.Email, "password_changed", mock.AnythingOfType("map[string]string")).Return(nil).Once()
 
 		err := authService.ResetPassword(email, password, confirmPass)
 
@@ -456,3 +608,581 @@ func TestAuthService_ResetPassword(t *testing.T) {
 	})
 
 }
+
+func TestAuthService_RefreshToken(t *testing.T) {
+	refreshToken := "someRefreshToken"
+	tokenHash := hashRefreshToken(refreshToken)
+	user := &entities.UserModels{
+		ID:    1,
+		Email: "test@example.com",
+		Role:  "customer",
+	}
+
+	t.Run("Success Case - Token Rotated", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, _, _, _ := setupTestService(t)
+		stored := &entities.RefreshTokenModels{ID: 10, UserID: user.ID, TokenHash: tokenHash, TokenVersion: 2, ExpiresAt: time.Now().Add(time.Hour)}
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(stored, nil)
+		jwtService.On("TokenVersion", user.ID).Return(int64(2), nil)
+		userRepo.On("GetUsersById", user.ID).Return(user, nil)
+		jwtService.On("GenerateJWT", user.ID, user.Email, user.Role).Return("newAccessToken", nil)
+		authRepo.On("RotateRefreshToken", stored.ID, mock.MatchedBy(func(next *entities.RefreshTokenModels) bool {
+			return next.UserID == user.ID && next.TokenVersion == 2 && next.TokenHash != tokenHash
+		})).Return(true, nil)
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "newAccessToken", tokens.AccessToken)
+		assert.NotEqual(t, refreshToken, tokens.RefreshToken)
+	})
+
+	t.Run("Failed Case - Unknown Token", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(nil, errors.New("record not found"))
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.EqualError(t, err, "refresh token tidak valid")
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Failed Case - Reused Token Revokes All Sessions", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		revokedAt := time.Now().Add(-time.Minute)
+		stored := &entities.RefreshTokenModels{ID: 10, UserID: user.ID, TokenHash: tokenHash, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(stored, nil)
+		authRepo.On("RevokeUserRefreshTokens", user.ID).Return(nil)
+		jwtService.On("RevokeUserTokens", user.ID).Return(nil)
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.EqualError(t, err, "refresh token tidak valid")
+		assert.Nil(t, tokens)
+		authRepo.AssertExpectations(t)
+		jwtService.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Expired Token", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		stored := &entities.RefreshTokenModels{ID: 10, UserID: user.ID, TokenHash: tokenHash, ExpiresAt: time.Now().Add(-time.Hour)}
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(stored, nil)
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.EqualError(t, err, "refresh token telah kadaluarsa")
+		assert.Nil(t, tokens)
+	})
+
+	t.Run("Failed Case - Token Version Bumped", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		stored := &entities.RefreshTokenModels{ID: 10, UserID: user.ID, TokenHash: tokenHash, TokenVersion: 1, ExpiresAt: time.Now().Add(time.Hour)}
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(stored, nil)
+		jwtService.On("TokenVersion", user.ID).Return(int64(2), nil)
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.EqualError(t, err, "refresh token tidak valid")
+		assert.Nil(t, tokens)
+		authRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Concurrent Rotation", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, _, _, _ := setupTestService(t)
+		stored := &entities.RefreshTokenModels{ID: 10, UserID: user.ID, TokenHash: tokenHash, ExpiresAt: time.Now().Add(time.Hour)}
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(stored, nil)
+		jwtService.On("TokenVersion", user.ID).Return(int64(0), nil)
+		userRepo.On("GetUsersById", user.ID).Return(user, nil)
+		jwtService.On("GenerateJWT", user.ID, user.Email, user.Role).Return("newAccessToken", nil)
+		authRepo.On("RotateRefreshToken", stored.ID, mock.AnythingOfType("*entities.RefreshTokenModels")).Return(false, nil)
+		authRepo.On("RevokeUserRefreshTokens", user.ID).Return(nil)
+		jwtService.On("RevokeUserTokens", user.ID).Return(nil)
+
+		tokens, err := authService.RefreshToken(refreshToken)
+
+		assert.EqualError(t, err, "refresh token tidak valid")
+		assert.Nil(t, tokens)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	userID := uint64(1)
+	refreshToken := "someRefreshToken"
+	tokenHash := hashRefreshToken(refreshToken)
+
+	t.Run("Success Case - Access And Refresh Token Revoked", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		jwtService.On("RevokeToken", "someAccessToken").Return(nil)
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(&entities.RefreshTokenModels{ID: 10, UserID: userID}, nil)
+		authRepo.On("RevokeRefreshToken", uint64(10)).Return(nil)
+
+		err := authService.Logout(userID, "someAccessToken", refreshToken)
+
+		assert.NoError(t, err)
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Refresh Token Of Another User Is Ignored", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		jwtService.On("RevokeToken", "someAccessToken").Return(nil)
+		authRepo.On("GetRefreshTokenByHash", tokenHash).Return(&entities.RefreshTokenModels{ID: 10, UserID: 2}, nil)
+
+		err := authService.Logout(userID, "someAccessToken", refreshToken)
+
+		assert.NoError(t, err)
+		authRepo.AssertNotCalled(t, "RevokeRefreshToken", mock.Anything)
+	})
+
+	t.Run("Failed Case - Error Revoking Access Token", func(t *testing.T) {
+		authService, _, jwtService, _, _, _, _, _ := setupTestService(t)
+		jwtService.On("RevokeToken", "someAccessToken").Return(errors.New("redis down"))
+
+		err := authService.Logout(userID, "someAccessToken", "")
+
+		assert.EqualError(t, err, "gagal mencabut access token")
+	})
+}
+
+func TestAuthService_LogoutAll(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, _, _, _ := setupTestService(t)
+		authRepo.On("RevokeUserRefreshTokens", uint64(1)).Return(nil)
+		jwtService.On("RevokeUserTokens", uint64(1)).Return(nil)
+
+		err := authService.LogoutAll(1)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed Case - Error Revoking Refresh Tokens", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		authRepo.On("RevokeUserRefreshTokens", uint64(1)).Return(errors.New("database error"))
+
+		err := authService.LogoutAll(1)
+
+		assert.EqualError(t, err, "gagal mencabut refresh token")
+	})
+}
+
+func TestAuthService_Login(t *testing.T) {
+	email := "test@example.com"
+	password := "password123"
+	existingUser := &entities.UserModels{
+		ID:         1,
+		Email:      email,
+		Password:   "hashedPassword",
+		Role:       "customer",
+		IsVerified: true,
+	}
+	lockedKey := generateCacheKey(email, "login_locked")
+	failuresKey := generateCacheKey(email, "login_failures")
+
+	t.Run("Success Case - Failures Counter Cleared", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, hash, cache, _, roles := setupTestService(t)
+		cache.On("Get", lockedKey).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		hash.On("ComparePassword", existingUser.Password, password).Return(true, nil).Once()
+		authRepo.On("GetTwoFactorByUserID", existingUser.ID).Return(nil, gorm.ErrRecordNotFound).Once()
+		roles.On("GetRolePermissions", existingUser.Role).Return(map[string]bool{role.PermissionOwnCart: true}, nil).Once()
+		cache.On("Del", failuresKey).Return(nil).Once()
+		authRepo.On("UpdateLastLogin", existingUser.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		jwtService.On("GenerateJWT", existingUser.ID, existingUser.Email, existingUser.Role).Return("someAccessToken", nil).Once()
+		jwtService.On("TokenVersion", existingUser.ID).Return(int64(0), nil).Once()
+		authRepo.On("CreateRefreshToken", mock.AnythingOfType("*entities.RefreshTokenModels")).Return(nil).Once()
+		devices := authService.devices.(*fcmMocks.RepositoryFcmInterface)
+		devices.On("SaveDevice", mock.AnythingOfType("*entities.UserDeviceModels")).Return(nil).Once()
+
+		result, tokens, err := authService.Login(email, password, "device")
+
+		assert.NoError(t, err)
+		assert.Equal(t, existingUser.ID, result.ID)
+		assert.Equal(t, "someAccessToken", tokens.AccessToken)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Account Locked", func(t *testing.T) {
+		authService, _, _, userRepo, _, cache, _, _ := setupTestService(t)
+		cache.On("Get", lockedKey).Return([]byte("true"), nil).Once()
+
+		result, tokens, err := authService.Login(email, password, "device")
+
+		assert.Nil(t, result)
+		assert.Nil(t, tokens)
+		assert.EqualError(t, err, "akun terkunci sementara, silakan coba lagi nanti")
+		userRepo.AssertNotCalled(t, "GetUsersByEmail", email)
+	})
+
+	t.Run("Failed Case - Wrong Password Counted", func(t *testing.T) {
+		authService, _, _, userRepo, hash, cache, _, _ := setupTestService(t)
+		cache.On("Get", lockedKey).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		hash.On("ComparePassword", existingUser.Password, password).Return(false, nil).Once()
+		cache.On("Incr", failuresKey, loginFailureWindow).Return(int64(1), nil).Once()
+
+		result, tokens, err := authService.Login(email, password, "device")
+
+		assert.Nil(t, result)
+		assert.Nil(t, tokens)
+		assert.EqualError(t, err, "password salah")
+		cache.AssertNotCalled(t, "Set", lockedKey, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Wrong Password Locks Account At Limit", func(t *testing.T) {
+		authService, _, _, userRepo, hash, cache, _, _ := setupTestService(t)
+		cache.On("Get", lockedKey).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		hash.On("ComparePassword", existingUser.Password, password).Return(false, nil).Once()
+		cache.On("Incr", failuresKey, loginFailureWindow).Return(int64(loginFailureLimit), nil).Once()
+		cache.On("Set", lockedKey, []byte("true"), loginLockDuration).Return(nil).Once()
+		cache.On("Del", failuresKey).Return(nil).Once()
+
+		_, _, err := authService.Login(email, password, "device")
+
+		assert.EqualError(t, err, "password salah")
+		cache.AssertExpectations(t)
+	})
+}
+
+func TestAuthService_VerifyOTP(t *testing.T) {
+	email := "test@example.com"
+	code := "123456"
+	existingUser := &entities.UserModels{
+		ID:    1,
+		Email: email,
+	}
+	failuresKey := otpFailureCacheKey(existingUser.ID)
+
+	t.Run("Failed Case - Wrong OTP Counted", func(t *testing.T) {
+		authService, authRepo, _, userRepo, _, cache, _, _ := setupTestService(t)
+		cache.On("Get", generateCacheKey(email, "verify_status")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		authRepo.On("FindValidOTP", int(existingUser.ID), code).Return(&entities.OTPModels{}, nil).Once()
+		cache.On("Incr", failuresKey, otpFailureWindow).Return(int64(1), nil).Once()
+
+		token, err := authService.VerifyOTP(email, code)
+
+		assert.Empty(t, token)
+		assert.EqualError(t, err, "invalid atau OTP telah kadaluarsa")
+		authRepo.AssertNotCalled(t, "DeleteUserOTP", existingUser.ID)
+	})
+
+	t.Run("Failed Case - OTP Invalidated At Limit", func(t *testing.T) {
+		authService, authRepo, _, userRepo, _, cache, _, _ := setupTestService(t)
+		cache.On("Get", generateCacheKey(email, "verify_status")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		authRepo.On("FindValidOTP", int(existingUser.ID), code).Return(&entities.OTPModels{}, nil).Once()
+		cache.On("Incr", failuresKey, otpFailureWindow).Return(int64(otpFailureLimit), nil).Once()
+		authRepo.On("DeleteUserOTP", existingUser.ID).Return(nil).Once()
+		cache.On("Del", failuresKey).Return(nil).Once()
+
+		token, err := authService.VerifyOTP(email, code)
+
+		assert.Empty(t, token)
+		assert.EqualError(t, err, "terlalu banyak percobaan OTP, silakan minta OTP baru")
+		authRepo.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Reset Token Without 2FA", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		validOTP := &entities.OTPModels{ID: 9, UserID: int(existingUser.ID), OTP: code}
+		cache.On("Get", generateCacheKey(email, "verify_status")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		authRepo.On("FindValidOTP", int(existingUser.ID), code).Return(validOTP, nil).Once()
+		cache.On("Del", failuresKey).Return(nil).Once()
+		authRepo.On("UpdateUser", existingUser).Return(existingUser, nil).Once()
+		authRepo.On("DeleteOTP", validOTP).Return(nil).Once()
+		cache.On("Set", generateCacheKey(email, "verify_status"), []byte("true"), time.Second).Return(nil).Once()
+		authRepo.On("GetTwoFactorByUserID", existingUser.ID).Return(nil, gorm.ErrRecordNotFound).Once()
+		jwtService.On("GeneratePasswordResetToken", existingUser.ID, existingUser.Email, existingUser.Role).Return("resetToken", nil).Once()
+
+		result, err := authService.VerifyOTP(email, code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "resetToken", result.ResetToken)
+		assert.Empty(t, result.TwoFactorToken)
+		jwtService.AssertNotCalled(t, "GenerateJWT", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Case - 2FA Account Gets Challenge Instead Of Reset Token", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		validOTP := &entities.OTPModels{ID: 9, UserID: int(existingUser.ID), OTP: code}
+		enabledAt := time.Now()
+		cache.On("Get", generateCacheKey(email, "verify_status")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", email).Return(existingUser, nil).Once()
+		authRepo.On("FindValidOTP", int(existingUser.ID), code).Return(validOTP, nil).Once()
+		cache.On("Del", failuresKey).Return(nil).Once()
+		authRepo.On("UpdateUser", existingUser).Return(existingUser, nil).Once()
+		authRepo.On("DeleteOTP", validOTP).Return(nil).Once()
+		cache.On("Set", generateCacheKey(email, "verify_status"), []byte("true"), time.Second).Return(nil).Once()
+		authRepo.On("GetTwoFactorByUserID", existingUser.ID).Return(&entities.TwoFactorModels{UserID: existingUser.ID, EnabledAt: &enabledAt}, nil).Once()
+		jwtService.On("GeneratePasswordResetTwoFactorToken", existingUser.ID, existingUser.Email, existingUser.Role).Return("partialToken", nil).Once()
+
+		result, err := authService.VerifyOTP(email, code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "partialToken", result.TwoFactorToken)
+		assert.Empty(t, result.ResetToken)
+	})
+}
+
+func TestAuthService_VerifyPasswordResetTwoFactor(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	enabledAt := time.Now()
+	customer := &entities.UserModels{ID: 1, Email: "test@example.com", Role: "customer"}
+	enabled := &entities.TwoFactorModels{UserID: customer.ID, Secret: secret, EnabledAt: &enabledAt}
+	partialToken := &jwt.Token{Claims: jwt.MapClaims{"user_id": float64(customer.ID)}, Valid: true}
+
+	t.Run("Success Case - Valid Code Issues Reset Token", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		code, _ := totp.GenerateCode(secret, totp.Step(time.Now()))
+		jwtService.On("ValidatePasswordResetTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		cache.On("SetNX", mock.AnythingOfType("string"), []byte("1"), mock.Anything).Return(true, nil).Once()
+		cache.On("Del", twoFactorFailureCacheKey(customer.ID)).Return(nil).Once()
+		jwtService.On("RevokeToken", "partialToken").Return(nil).Once()
+		jwtService.On("GeneratePasswordResetToken", customer.ID, customer.Email, customer.Role).Return("resetToken", nil).Once()
+
+		result, err := authService.VerifyPasswordResetTwoFactor("partialToken", code)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "resetToken", result.ResetToken)
+		jwtService.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Login Partial Token Rejected", func(t *testing.T) {
+		authService, _, jwtService, _, _, _, _, _ := setupTestService(t)
+		jwtService.On("ValidatePasswordResetTwoFactorToken", "loginToken").Return(nil, errors.New("token tidak dapat digunakan untuk permintaan ini")).Once()
+
+		result, err := authService.VerifyPasswordResetTwoFactor("loginToken", "123456")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "token 2FA tidak valid")
+	})
+
+	t.Run("Failed Case - Wrong Code", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidatePasswordResetTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		authRepo.On("UseRecoveryCode", customer.ID, hashRecoveryCode("000000")).Return(false, nil).Once()
+		cache.On("Incr", twoFactorFailureCacheKey(customer.ID), twoFactorFailureWindow).Return(int64(1), nil).Once()
+
+		result, err := authService.VerifyPasswordResetTwoFactor("partialToken", "000000")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kode 2FA tidak valid")
+	})
+}
+
+func TestAuthService_TwoFactor(t *testing.T) {
+	secret, _ := totp.GenerateSecret()
+	enabledAt := time.Now()
+	customer := &entities.UserModels{
+		ID:         1,
+		Email:      "test@example.com",
+		Password:   "hashedPassword",
+		Role:       "customer",
+		IsVerified: true,
+	}
+	admin := &entities.UserModels{
+		ID:         2,
+		Email:      "admin@example.com",
+		Password:   "hashedPassword",
+		Role:       "admin",
+		IsVerified: true,
+	}
+	enabled := &entities.TwoFactorModels{UserID: customer.ID, Secret: secret, EnabledAt: &enabledAt}
+	partialToken := &jwt.Token{Claims: jwt.MapClaims{"user_id": float64(customer.ID)}, Valid: true}
+	currentCode := func() string {
+		code, _ := totp.GenerateCode(secret, totp.Step(time.Now()))
+		return code
+	}
+
+	t.Run("Success Case - Login Returns Partial Token When Enabled", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, hash, cache, _, _ := setupTestService(t)
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", customer.Email).Return(customer, nil).Once()
+		hash.On("ComparePassword", customer.Password, "password123").Return(true, nil).Once()
+		cache.On("Del", generateCacheKey(customer.Email, "login_failures")).Return(nil).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		jwtService.On("GenerateTwoFactorToken", customer.ID, customer.Email, customer.Role).Return("partialToken", nil).Once()
+
+		_, tokens, err := authService.Login(customer.Email, "password123", "device")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "partialToken", tokens.TwoFactorToken)
+		assert.False(t, tokens.TwoFactorSetupRequired)
+		assert.Empty(t, tokens.AccessToken)
+		authRepo.AssertNotCalled(t, "UpdateLastLogin", customer.ID, mock.Anything)
+		jwtService.AssertNotCalled(t, "GenerateJWT", customer.ID, customer.Email, customer.Role)
+	})
+
+	t.Run("Success Case - Admin Without 2FA Must Enroll", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, hash, cache, _, roles := setupTestService(t)
+		cache.On("Get", generateCacheKey(admin.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", admin.Email).Return(admin, nil).Once()
+		hash.On("ComparePassword", admin.Password, "password123").Return(true, nil).Once()
+		cache.On("Del", generateCacheKey(admin.Email, "login_failures")).Return(nil).Once()
+		authRepo.On("GetTwoFactorByUserID", admin.ID).Return(nil, gorm.ErrRecordNotFound).Once()
+		roles.On("GetRolePermissions", admin.Role).Return(map[string]bool{role.PermissionManageUsers: true}, nil).Once()
+		jwtService.On("GenerateTwoFactorToken", admin.ID, admin.Email, admin.Role).Return("partialToken", nil).Once()
+
+		_, tokens, err := authService.Login(admin.Email, "password123", "device")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "partialToken", tokens.TwoFactorToken)
+		assert.True(t, tokens.TwoFactorSetupRequired)
+	})
+
+	t.Run("Success Case - Custom Staff Role Without 2FA Must Enroll", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, hash, cache, _, roles := setupTestService(t)
+		staff := &entities.UserModels{ID: 3, Email: "staff@example.com", Password: "hashedPassword", Role: "packer", IsVerified: true}
+		cache.On("Get", generateCacheKey(staff.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		userRepo.On("GetUsersByEmail", staff.Email).Return(staff, nil).Once()
+		hash.On("ComparePassword", staff.Password, "password123").Return(true, nil).Once()
+		cache.On("Del", generateCacheKey(staff.Email, "login_failures")).Return(nil).Once()
+		authRepo.On("GetTwoFactorByUserID", staff.ID).Return(nil, gorm.ErrRecordNotFound).Once()
+		roles.On("GetRolePermissions", staff.Role).Return(map[string]bool{role.PermissionManageStock: true}, nil).Once()
+		jwtService.On("GenerateTwoFactorToken", staff.ID, staff.Email, staff.Role).Return("partialToken", nil).Once()
+
+		_, tokens, err := authService.Login(staff.Email, "password123", "device")
+
+		assert.NoError(t, err)
+		assert.True(t, tokens.TwoFactorSetupRequired)
+	})
+
+	t.Run("Success Case - Verify TOTP Completes Login", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidateTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		cache.On("SetNX", mock.AnythingOfType("string"), []byte("1"), mock.Anything).Return(true, nil).Once()
+		cache.On("Del", twoFactorFailureCacheKey(customer.ID)).Return(nil).Once()
+		jwtService.On("RevokeToken", "partialToken").Return(nil).Once()
+		authRepo.On("UpdateLastLogin", customer.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		jwtService.On("GenerateJWT", customer.ID, customer.Email, customer.Role).Return("accessToken", nil).Once()
+		jwtService.On("TokenVersion", customer.ID).Return(int64(0), nil).Once()
+		authRepo.On("CreateRefreshToken", mock.AnythingOfType("*entities.RefreshTokenModels")).Return(nil).Once()
+
+		result, tokens, err := authService.VerifyTwoFactorLogin("partialToken", currentCode(), "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, customer.ID, result.ID)
+		assert.Equal(t, "accessToken", tokens.AccessToken)
+		jwtService.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Verify Recovery Code", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidateTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		authRepo.On("UseRecoveryCode", customer.ID, hashRecoveryCode("abcde12345")).Return(true, nil).Once()
+		cache.On("Del", twoFactorFailureCacheKey(customer.ID)).Return(nil).Once()
+		jwtService.On("RevokeToken", "partialToken").Return(nil).Once()
+		authRepo.On("UpdateLastLogin", customer.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		jwtService.On("GenerateJWT", customer.ID, customer.Email, customer.Role).Return("accessToken", nil).Once()
+		jwtService.On("TokenVersion", customer.ID).Return(int64(0), nil).Once()
+		authRepo.On("CreateRefreshToken", mock.AnythingOfType("*entities.RefreshTokenModels")).Return(nil).Once()
+
+		_, tokens, err := authService.VerifyTwoFactorLogin("partialToken", "ABCDE-12345", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "accessToken", tokens.AccessToken)
+	})
+
+	t.Run("Failed Case - Reused TOTP Code", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidateTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		cache.On("SetNX", mock.AnythingOfType("string"), []byte("1"), mock.Anything).Return(false, nil).Once()
+		cache.On("Incr", twoFactorFailureCacheKey(customer.ID), twoFactorFailureWindow).Return(int64(1), nil).Once()
+
+		_, tokens, err := authService.VerifyTwoFactorLogin("partialToken", currentCode(), "")
+
+		assert.Nil(t, tokens)
+		assert.EqualError(t, err, "kode 2FA sudah digunakan")
+	})
+
+	t.Run("Failed Case - Too Many Wrong Codes Locks Account", func(t *testing.T) {
+		authService, authRepo, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidateTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return(nil, errors.New("cache miss")).Once()
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(enabled, nil).Once()
+		authRepo.On("UseRecoveryCode", customer.ID, hashRecoveryCode("0000zz")).Return(false, nil).Once()
+		cache.On("Incr", twoFactorFailureCacheKey(customer.ID), twoFactorFailureWindow).Return(int64(twoFactorFailureLimit), nil).Once()
+		jwtService.On("RevokeToken", "partialToken").Return(nil).Once()
+		cache.On("Set", generateCacheKey(customer.Email, "login_locked"), []byte("true"), loginLockDuration).Return(nil).Once()
+		cache.On("Del", twoFactorFailureCacheKey(customer.ID)).Return(nil).Once()
+
+		_, _, err := authService.VerifyTwoFactorLogin("partialToken", "0000zz", "")
+
+		assert.EqualError(t, err, "terlalu banyak percobaan 2FA, akun terkunci sementara")
+		jwtService.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Locked Account Rejects Other Partial Tokens", func(t *testing.T) {
+		authService, _, jwtService, userRepo, _, cache, _, _ := setupTestService(t)
+		jwtService.On("ValidateTwoFactorToken", "partialToken").Return(partialToken, nil).Once()
+		userRepo.On("GetUsersById", customer.ID).Return(customer, nil).Once()
+		cache.On("Get", generateCacheKey(customer.Email, "login_locked")).Return([]byte("true"), nil).Once()
+
+		_, _, err := authService.VerifyTwoFactorLogin("partialToken", currentCode(), "")
+
+		assert.EqualError(t, err, "akun terkunci sementara, silakan coba lagi nanti")
+	})
+
+	t.Run("Success Case - Enroll During Login", func(t *testing.T) {
+		authService, authRepo, jwtService, _, _, cache, _, _ := setupTestService(t)
+		pending := &entities.TwoFactorModels{UserID: admin.ID, Secret: secret}
+		authRepo.On("GetTwoFactorByUserID", admin.ID).Return(pending, nil).Once()
+		cache.On("SetNX", mock.AnythingOfType("string"), []byte("1"), mock.Anything).Return(true, nil).Once()
+		authRepo.On("EnableTwoFactor", admin.ID, mock.MatchedBy(func(hashes []string) bool {
+			return len(hashes) == recoveryCodeCount
+		})).Return(nil).Once()
+		jwtService.On("RevokeToken", "partialToken").Return(nil).Once()
+		authRepo.On("UpdateLastLogin", admin.ID, mock.AnythingOfType("time.Time")).Return(nil).Once()
+		jwtService.On("GenerateJWT", admin.ID, admin.Email, admin.Role).Return("accessToken", nil).Once()
+		jwtService.On("TokenVersion", admin.ID).Return(int64(0), nil).Once()
+		authRepo.On("CreateRefreshToken", mock.AnythingOfType("*entities.RefreshTokenModels")).Return(nil).Once()
+
+		recoveryCodes, tokens, err := authService.EnableTwoFactor(admin, currentCode(), "partialToken", "")
+
+		assert.NoError(t, err)
+		assert.Len(t, recoveryCodes, recoveryCodeCount)
+		assert.Equal(t, "accessToken", tokens.AccessToken)
+		authRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Enable With Wrong Code", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, _ := setupTestService(t)
+		pending := &entities.TwoFactorModels{UserID: customer.ID, Secret: secret}
+		authRepo.On("GetTwoFactorByUserID", customer.ID).Return(pending, nil).Once()
+
+		recoveryCodes, tokens, err := authService.EnableTwoFactor(customer, "abc", "", "")
+
+		assert.Nil(t, recoveryCodes)
+		assert.Nil(t, tokens)
+		assert.EqualError(t, err, "kode 2FA tidak valid")
+		authRepo.AssertNotCalled(t, "EnableTwoFactor", customer.ID, mock.Anything)
+	})
+
+	t.Run("Failed Case - Admin Cannot Disable", func(t *testing.T) {
+		authService, authRepo, _, _, _, _, _, roles := setupTestService(t)
+		roles.On("GetRolePermissions", admin.Role).Return(map[string]bool{role.PermissionManageUsers: true}, nil).Once()
+
+		err := authService.DisableTwoFactor(admin, currentCode())
+
+		assert.EqualError(t, err, "pengguna dengan izin pengelolaan wajib menggunakan 2FA")
+		authRepo.AssertNotCalled(t, "DisableTwoFactor", admin.ID)
+	})
+}
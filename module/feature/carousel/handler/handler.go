@@ -25,10 +25,6 @@ func NewCarouselHandler(service carousel.ServiceCarouselInterface) carousel.Hand
 
 func (h *CarouselHandler) GetAllCarousels() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
 		perPage := 8
@@ -57,11 +53,6 @@ func (h *CarouselHandler) GetAllCarousels() echo.HandlerFunc {
 
 func (h *CarouselHandler) GetCarouselById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		id := c.Param("id")
 		carouselId, err := strconv.ParseUint(id, 10, 64)
 		if err != nil {
@@ -78,10 +69,6 @@ func (h *CarouselHandler) GetCarouselById() echo.HandlerFunc {
 
 func (h *CarouselHandler) CreateCarousel() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.CreateCarouselRequest)
 		file, err := c.FormFile("photo")
 		var uploadedURL string
@@ -120,10 +107,6 @@ func (h *CarouselHandler) CreateCarousel() echo.HandlerFunc {
 
 func (h *CarouselHandler) UpdateCarousel() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.UpdateCarouselRequest)
 		carouselID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -167,10 +150,6 @@ func (h *CarouselHandler) UpdateCarousel() echo.HandlerFunc {
 
 func (h *CarouselHandler) DeleteCarousel() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		carouselID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
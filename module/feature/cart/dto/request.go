@@ -3,6 +3,7 @@ package dto
 type AddCartItemsRequest struct {
 	UserID    uint64 `form:"user_id" json:"user_id"`
 	ProductID uint64 `form:"product_id" json:"product_id" validate:"required"`
+	VariantID uint64 `form:"variant_id" json:"variant_id"`
 	Quantity  uint64 `form:"quantity" json:"quantity" validate:"required"`
 }
 
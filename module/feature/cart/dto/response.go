@@ -5,13 +5,22 @@ import (
 )
 
 type CartItemFormatter struct {
-	CartItemID  uint64          `json:"cart_item_id"`
-	ProductName string          `json:"product_name"`
-	GramPlastic uint64          `json:"gram_plastic"`
-	Price       uint64          `json:"price"`
-	Quantity    uint64          `json:"quantity"`
-	TotalPrice  uint64          `json:"total_price"`
-	Product     ProductResponse `json:"product,omitempty"`
+	CartItemID  uint64           `json:"cart_item_id"`
+	ProductName string           `json:"product_name"`
+	GramPlastic uint64           `json:"gram_plastic"`
+	Price       uint64           `json:"price"`
+	Quantity    uint64           `json:"quantity"`
+	TotalPrice  uint64           `json:"total_price"`
+	Product     ProductResponse  `json:"product,omitempty"`
+	Variant     *VariantResponse `json:"variant,omitempty"`
+}
+
+type VariantResponse struct {
+	ID    uint64 `json:"id"`
+	SKU   string `json:"sku"`
+	Size  string `json:"size"`
+	Color string `json:"color"`
+	Price uint64 `json:"price"`
 }
 
 type CartFormatter struct {
@@ -68,6 +77,17 @@ func FormatCart(cart *entities.CartModels) *CartFormatter {
 				ProductPhotos: productPhotos,
 			},
 		}
+		if item.Variant != nil {
+			cartItem.GramPlastic = item.Variant.GramPlastic
+			cartItem.Price = item.Variant.Price
+			cartItem.Variant = &VariantResponse{
+				ID:    item.Variant.ID,
+				SKU:   item.Variant.SKU,
+				Size:  item.Variant.Size,
+				Color: item.Variant.Color,
+				Price: item.Variant.Price,
+			}
+		}
 		if len(item.Product.ProductPhotos) > 0 {
 			productPhoto := ProductPhotoResponse{
 				ID:        item.Product.ProductPhotos[0].ID,
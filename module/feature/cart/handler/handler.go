@@ -23,9 +23,6 @@ func NewCartHandler(service cart.ServiceCartInterface) cart.HandlerCartInterface
 func (h *CartHandler) AddCartItem() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.AddCartItemsRequest)
 		if err := c.Bind(req); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -46,9 +43,6 @@ func (h *CartHandler) AddCartItem() echo.HandlerFunc {
 func (h *CartHandler) GetCart() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		cartItemsSummary, err := h.service.GetCart(currentUser.ID)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan detail keranjang"+err.Error())
@@ -59,11 +53,6 @@ func (h *CartHandler) GetCart() echo.HandlerFunc {
 
 func (h *CartHandler) ReduceQuantity() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		req := new(dto.ReduceCartItemsRequest)
 		if err := c.Bind(req); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -84,10 +73,6 @@ func (h *CartHandler) ReduceQuantity() echo.HandlerFunc {
 
 func (h *CartHandler) DeleteCartItems() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		id := c.Param("id")
 		cartItemsID, err := strconv.ParseUint(id, 10, 64)
 		if err != nil {
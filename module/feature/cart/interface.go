@@ -4,12 +4,14 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/dto"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type RepositoryCartInterface interface {
+	WithTx(tx *gorm.DB) RepositoryCartInterface
 	CreateCart(newCart *entities.CartModels) (*entities.CartModels, error)
 	CreateCartItem(cartItem *entities.CartItemModels) (*entities.CartItemModels, error)
-	GetCartItemByProductID(cartID, productID uint64) (*entities.CartItemModels, error)
+	GetCartItemByProductID(cartID, productID, variantID uint64) (*entities.CartItemModels, error)
 	GetCartItemsByCartID(cartID uint64) ([]*entities.CartItemModels, error)
 	GetCartItemByID(cartItemID uint64) (*entities.CartItemModels, error)
 	GetCartByID(cartID uint64) (*entities.CartModels, error)
@@ -18,18 +20,19 @@ type RepositoryCartInterface interface {
 	UpdateCartItem(cartItem *entities.CartItemModels) error
 	UpdateGrandTotal(cartID, grandTotal uint64) error
 	DeleteCartItem(cartItemID uint64) error
-	IsProductInCart(userID, productID uint64) bool
-	RemoveProductFromCart(userID, productID uint64) error
+	IsProductInCart(userID, productID, variantID uint64) bool
+	RemoveProductFromCart(userID, productID, variantID uint64) error
 }
 
 type ServiceCartInterface interface {
+	WithTx(tx *gorm.DB) ServiceCartInterface
 	AddCartItems(userID uint64, request *dto.AddCartItemsRequest) (*entities.CartItemModels, error)
 	GetCart(userID uint64) (*entities.CartModels, error)
 	GetCartItems(cartItem uint64) (*entities.CartItemModels, error)
 	ReduceCartItemQuantity(cartItemID, quantity uint64) error
 	DeleteCartItem(cartItemID uint64) error
-	IsProductInCart(userID, productID uint64) bool
-	RemoveProductFromCart(userID, productID uint64) error
+	IsProductInCart(userID, productID, variantID uint64) bool
+	RemoveProductFromCart(userID, productID, variantID uint64) error
 	RecalculateGrandTotal(cart *entities.CartModels) error
 }
 
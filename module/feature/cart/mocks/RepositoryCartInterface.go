@@ -4,7 +4,9 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	cart "github.com/capstone-kelompok-7/backend-disappear/module/feature/cart"
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // RepositoryCartInterface is an autogenerated mock type for the RepositoryCartInterface type
@@ -182,25 +184,25 @@ func (_m *RepositoryCartInterface) GetCartItemByID(cartItemID uint64) (*entities
 	return r0, r1
 }
 
-// GetCartItemByProductID provides a mock function with given fields: cartID, productID
-func (_m *RepositoryCartInterface) GetCartItemByProductID(cartID uint64, productID uint64) (*entities.CartItemModels, error) {
-	ret := _m.Called(cartID, productID)
+// GetCartItemByProductID provides a mock function with given fields: cartID, productID, variantID
+func (_m *RepositoryCartInterface) GetCartItemByProductID(cartID uint64, productID uint64, variantID uint64) (*entities.CartItemModels, error) {
+	ret := _m.Called(cartID, productID, variantID)
 
 	var r0 *entities.CartItemModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) (*entities.CartItemModels, error)); ok {
-		return rf(cartID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) (*entities.CartItemModels, error)); ok {
+		return rf(cartID, productID, variantID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, uint64) *entities.CartItemModels); ok {
-		r0 = rf(cartID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) *entities.CartItemModels); ok {
+		r0 = rf(cartID, productID, variantID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entities.CartItemModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
-		r1 = rf(cartID, productID)
+	if rf, ok := ret.Get(1).(func(uint64, uint64, uint64) error); ok {
+		r1 = rf(cartID, productID, variantID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -234,13 +236,13 @@ func (_m *RepositoryCartInterface) GetCartItemsByCartID(cartID uint64) ([]*entit
 	return r0, r1
 }
 
-// IsProductInCart provides a mock function with given fields: userID, productID
-func (_m *RepositoryCartInterface) IsProductInCart(userID uint64, productID uint64) bool {
-	ret := _m.Called(userID, productID)
+// IsProductInCart provides a mock function with given fields: userID, productID, variantID
+func (_m *RepositoryCartInterface) IsProductInCart(userID uint64, productID uint64, variantID uint64) bool {
+	ret := _m.Called(userID, productID, variantID)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint64, uint64) bool); ok {
-		r0 = rf(userID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) bool); ok {
+		r0 = rf(userID, productID, variantID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -248,13 +250,13 @@ func (_m *RepositoryCartInterface) IsProductInCart(userID uint64, productID uint
 	return r0
 }
 
-// RemoveProductFromCart provides a mock function with given fields: userID, productID
-func (_m *RepositoryCartInterface) RemoveProductFromCart(userID uint64, productID uint64) error {
-	ret := _m.Called(userID, productID)
+// RemoveProductFromCart provides a mock function with given fields: userID, productID, variantID
+func (_m *RepositoryCartInterface) RemoveProductFromCart(userID uint64, productID uint64, variantID uint64) error {
+	ret := _m.Called(userID, productID, variantID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(userID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, productID, variantID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -290,6 +292,22 @@ func (_m *RepositoryCartInterface) UpdateGrandTotal(cartID uint64, grandTotal ui
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *RepositoryCartInterface) WithTx(tx *gorm.DB) cart.RepositoryCartInterface {
+	ret := _m.Called(tx)
+
+	var r0 cart.RepositoryCartInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) cart.RepositoryCartInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cart.RepositoryCartInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewRepositoryCartInterface creates a new instance of RepositoryCartInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryCartInterface(t interface {
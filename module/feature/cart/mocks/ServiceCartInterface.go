@@ -4,9 +4,10 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	cart "github.com/capstone-kelompok-7/backend-disappear/module/feature/cart"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/dto"
-
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // ServiceCartInterface is an autogenerated mock type for the ServiceCartInterface type
@@ -106,13 +107,13 @@ func (_m *ServiceCartInterface) GetCartItems(cartItem uint64) (*entities.CartIte
 	return r0, r1
 }
 
-// IsProductInCart provides a mock function with given fields: userID, productID
-func (_m *ServiceCartInterface) IsProductInCart(userID uint64, productID uint64) bool {
-	ret := _m.Called(userID, productID)
+// IsProductInCart provides a mock function with given fields: userID, productID, variantID
+func (_m *ServiceCartInterface) IsProductInCart(userID uint64, productID uint64, variantID uint64) bool {
+	ret := _m.Called(userID, productID, variantID)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(uint64, uint64) bool); ok {
-		r0 = rf(userID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) bool); ok {
+		r0 = rf(userID, productID, variantID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -148,13 +149,13 @@ func (_m *ServiceCartInterface) ReduceCartItemQuantity(cartItemID uint64, quanti
 	return r0
 }
 
-// RemoveProductFromCart provides a mock function with given fields: userID, productID
-func (_m *ServiceCartInterface) RemoveProductFromCart(userID uint64, productID uint64) error {
-	ret := _m.Called(userID, productID)
+// RemoveProductFromCart provides a mock function with given fields: userID, productID, variantID
+func (_m *ServiceCartInterface) RemoveProductFromCart(userID uint64, productID uint64, variantID uint64) error {
+	ret := _m.Called(userID, productID, variantID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(userID, productID)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, productID, variantID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -162,6 +163,22 @@ func (_m *ServiceCartInterface) RemoveProductFromCart(userID uint64, productID u
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *ServiceCartInterface) WithTx(tx *gorm.DB) cart.ServiceCartInterface {
+	ret := _m.Called(tx)
+
+	var r0 cart.ServiceCartInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) cart.ServiceCartInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cart.ServiceCartInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewServiceCartInterface creates a new instance of ServiceCartInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceCartInterface(t interface {
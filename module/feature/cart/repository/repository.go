@@ -16,6 +16,12 @@ func NewCartRepository(db *gorm.DB) cart.RepositoryCartInterface {
 	}
 }
 
+func (r *CartRepository) WithTx(tx *gorm.DB) cart.RepositoryCartInterface {
+	return &CartRepository{
+		db: tx,
+	}
+}
+
 func (r *CartRepository) GetCartByUserID(userID uint64) (*entities.CartModels, error) {
 	carts := &entities.CartModels{}
 	if err := r.db.Where("user_id = ?", userID).First(carts).Error; err != nil {
@@ -32,9 +38,17 @@ func (r *CartRepository) GetCartByID(cartID uint64) (*entities.CartModels, error
 	return carts, nil
 }
 
-func (r *CartRepository) GetCartItemByProductID(cartID, productID uint64) (*entities.CartItemModels, error) {
+// byVariant narrows cart items to one variant; zero means the product itself.
+func byVariant(db *gorm.DB, variantID uint64) *gorm.DB {
+	if variantID == 0 {
+		return db.Where("cart_items.variant_id IS NULL")
+	}
+	return db.Where("cart_items.variant_id = ?", variantID)
+}
+
+func (r *CartRepository) GetCartItemByProductID(cartID, productID, variantID uint64) (*entities.CartItemModels, error) {
 	var cartItem entities.CartItemModels
-	if err := r.db.Where("cart_id = ? AND product_id = ?", cartID, productID).First(&cartItem).Error; err != nil {
+	if err := byVariant(r.db.Where("cart_id = ? AND product_id = ?", cartID, productID), variantID).First(&cartItem).Error; err != nil {
 		return nil, err
 	}
 	return &cartItem, nil
@@ -60,11 +74,12 @@ func (r *CartRepository) GetCart(userID uint64) (*entities.CartModels, error) {
 	carts := &entities.CartModels{}
 	if err := r.db.
 		Preload("CartItems", func(db *gorm.DB) *gorm.DB {
-			return db.Select("id, cart_id, product_id, quantity, total_price").
+			return db.Select("id, cart_id, product_id, variant_id, quantity, price, total_price").
 				Preload("Product", func(db *gorm.DB) *gorm.DB {
 					return db.Select("id, name, gram_plastic, price, discount").
 						Preload("ProductPhotos")
-				})
+				}).
+				Preload("Variant")
 		}).
 		Where("user_id = ?", userID).
 		Find(&carts).Error; err != nil {
@@ -105,24 +120,30 @@ func (r *CartRepository) UpdateGrandTotal(cartID, grandTotal uint64) error {
 	return nil
 }
 
+// DeleteCartItem returns gorm.ErrRecordNotFound when the item was already gone,
+// so two checkouts of the same cart cannot both go through: the second one
+// waits on the row lock and then deletes nothing.
 func (r *CartRepository) DeleteCartItem(cartItemID uint64) error {
 	result := r.db.Where("id = ?", cartItemID).Delete(&entities.CartItemModels{})
 	if result.Error != nil {
 		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
 	return nil
 }
 
-func (r *CartRepository) IsProductInCart(userID, productID uint64) bool {
+func (r *CartRepository) IsProductInCart(userID, productID, variantID uint64) bool {
 	var count int64
-	r.db.Model(&entities.CartItemModels{}).
+	query := r.db.Model(&entities.CartItemModels{}).
 		Joins("JOIN carts ON cart_items.cart_id = carts.id").
-		Where("carts.user_id = ? AND cart_items.product_id = ?", userID, productID).
-		Count(&count)
+		Where("carts.user_id = ? AND cart_items.product_id = ?", userID, productID)
+	byVariant(query, variantID).Count(&count)
 	return count > 0
 }
 
-func (r *CartRepository) RemoveProductFromCart(userID, productID uint64) error {
+func (r *CartRepository) RemoveProductFromCart(userID, productID, variantID uint64) error {
 	var carts entities.CartModels
 	if err := r.db.Where("user_id = ?", userID).Preload("CartItems").First(&carts).Error; err != nil {
 		return err
@@ -130,7 +151,11 @@ func (r *CartRepository) RemoveProductFromCart(userID, productID uint64) error {
 
 	var cartItem entities.CartItemModels
 	for _, item := range carts.CartItems {
-		if item.ProductID == productID {
+		var itemVariantID uint64
+		if item.VariantID != nil {
+			itemVariantID = *item.VariantID
+		}
+		if item.ProductID == productID && itemVariantID == variantID {
 			cartItem = *item
 			break
 		}
@@ -6,6 +6,7 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
+	"gorm.io/gorm"
 )
 
 type CartService struct {
@@ -20,6 +21,13 @@ func NewCartService(repo cart.RepositoryCartInterface, productService product.Se
 	}
 }
 
+func (s *CartService) WithTx(tx *gorm.DB) cart.ServiceCartInterface {
+	return &CartService{
+		repo:           s.repo.WithTx(tx),
+		productService: s.productService.WithTx(tx),
+	}
+}
+
 func (s *CartService) GetCart(userID uint64) (*entities.CartModels, error) {
 	carts, err := s.repo.GetCart(userID)
 	if err != nil {
@@ -43,7 +51,7 @@ func (s *CartService) AddCartItems(userID uint64, request *dto.AddCartItemsReque
 		}
 	}
 
-	existingCartItem, err := s.repo.GetCartItemByProductID(carts.ID, request.ProductID)
+	existingCartItem, err := s.repo.GetCartItemByProductID(carts.ID, request.ProductID, request.VariantID)
 	if err == nil && existingCartItem != nil {
 		existingCartItem.Quantity += request.Quantity
 		existingCartItem.TotalPrice = existingCartItem.Quantity * existingCartItem.Price
@@ -59,9 +67,9 @@ func (s *CartService) AddCartItems(userID uint64, request *dto.AddCartItemsReque
 		return existingCartItem, nil
 	}
 
-	getProductByID, err := s.productService.GetProductByID(request.ProductID)
+	getProductByID, err := s.productService.GetProductForPurchase(request.ProductID, request.VariantID)
 	if err != nil {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, err
 	}
 
 	cartItem := &entities.CartItemModels{
@@ -71,6 +79,9 @@ func (s *CartService) AddCartItems(userID uint64, request *dto.AddCartItemsReque
 		Price:      getProductByID.Price,
 		TotalPrice: getProductByID.Price * request.Quantity,
 	}
+	if request.VariantID != 0 {
+		cartItem.VariantID = &request.VariantID
+	}
 
 	result, err := s.repo.CreateCartItem(cartItem)
 	if err != nil {
@@ -97,6 +108,9 @@ func (s *CartService) DeleteCartItem(cartItemID uint64) error {
 	}
 
 	err = s.repo.DeleteCartItem(cartItem.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return errors.New("item dikeranjang tidak ditemukan")
+	}
 	if err != nil {
 		return errors.New("gagal menghapus item dikeranjang")
 	}
@@ -166,18 +180,18 @@ func (s *CartService) RecalculateGrandTotal(cart *entities.CartModels) error {
 	return nil
 }
 
-func (s *CartService) IsProductInCart(userID, productID uint64) bool {
-	isInCart := s.repo.IsProductInCart(userID, productID)
+func (s *CartService) IsProductInCart(userID, productID, variantID uint64) bool {
+	isInCart := s.repo.IsProductInCart(userID, productID, variantID)
 	return isInCart
 }
 
-func (s *CartService) RemoveProductFromCart(userID, productID uint64) error {
-	isProductInCart := s.repo.IsProductInCart(userID, productID)
+func (s *CartService) RemoveProductFromCart(userID, productID, variantID uint64) error {
+	isProductInCart := s.repo.IsProductInCart(userID, productID, variantID)
 	if !isProductInCart {
 		return errors.New("produk tidak ada dalam keranjang pengguna")
 	}
 
-	err := s.repo.RemoveProductFromCart(userID, productID)
+	err := s.repo.RemoveProductFromCart(userID, productID, variantID)
 	if err != nil {
 		return err
 	}
@@ -11,11 +11,14 @@ import (
 	assistantMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/mocks"
 	assistants "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/service"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/mocks"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	productsMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
 	products "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/service"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupTestService(t *testing.T) (*mocks.RepositoryCartInterface, cart.ServiceCartInterface, product.ServiceProductInterface, assistant.ServiceAssistantInterface) {
@@ -24,7 +27,7 @@ func setupTestService(t *testing.T) (*mocks.RepositoryCartInterface, cart.Servic
 	repoAssistant := assistantMocks.NewRepositoryAssistantInterface(t)
 
 	assistantService := assistants.NewAssistantService(repoAssistant, nil, config.Config{})
-	productService := products.NewProductService(repoProduct, assistantService)
+	productService := products.NewProductService(repoProduct, assistantService, nil, nil, nil, nil, 0)
 	cartService := NewCartService(repo, productService)
 
 	return repo, cartService, productService, assistantService
@@ -99,18 +102,79 @@ func TestCartService_GetCart(t *testing.T) {
 	})
 }
 
+func TestCartService_AddCartItems(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.RepositoryCartInterface, *productsMocks.RepositoryProductInterface, cart.ServiceCartInterface) {
+		repo := mocks.NewRepositoryCartInterface(t)
+		repoProduct := productsMocks.NewRepositoryProductInterface(t)
+		cartService := NewCartService(repo, products.NewProductService(repoProduct, nil, nil, nil, nil, nil, 0))
+		return repo, repoProduct, cartService
+	}
+	userCart := &entities.CartModels{ID: 1, UserID: 1}
+	withVariants := &entities.ProductModels{
+		ID:    1,
+		Price: 5000,
+		Stock: 15,
+		Variants: []entities.ProductVariantModels{
+			{ID: 3, ProductID: 1, SKU: "TOTE-L", Size: "L", Price: 7000, Stock: 5},
+			{ID: 4, ProductID: 1, SKU: "TOTE-M", Size: "M", Price: 6000, Stock: 10},
+		},
+	}
+
+	t.Run("Success Case - Variant priced item", func(t *testing.T) {
+		repo, repoProduct, cartService := setup(t)
+		repo.On("GetCartByUserID", uint64(1)).Return(userCart, nil).Once()
+		repo.On("GetCartItemByProductID", uint64(1), uint64(1), uint64(3)).Return(nil, errors.New("record not found")).Once()
+		repoProduct.On("GetProductByID", uint64(1)).Return(withVariants, nil).Once()
+		repo.On("CreateCartItem", mock.MatchedBy(func(item *entities.CartItemModels) bool {
+			return item.VariantID != nil && *item.VariantID == 3 && item.Price == 7000 && item.TotalPrice == 14000
+		})).Return(func(item *entities.CartItemModels) *entities.CartItemModels { return item }, nil).Once()
+		repo.On("GetCartItemsByCartID", uint64(1)).Return([]*entities.CartItemModels{{TotalPrice: 14000}}, nil).Once()
+		repo.On("UpdateGrandTotal", uint64(1), uint64(14000)).Return(nil).Once()
+
+		item, err := cartService.AddCartItems(1, &dto.AddCartItemsRequest{ProductID: 1, VariantID: 3, Quantity: 2})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7000), item.Price)
+	})
+
+	t.Run("Failed Case - Variant not picked", func(t *testing.T) {
+		repo, repoProduct, cartService := setup(t)
+		repo.On("GetCartByUserID", uint64(1)).Return(userCart, nil).Once()
+		repo.On("GetCartItemByProductID", uint64(1), uint64(1), uint64(0)).Return(nil, errors.New("record not found")).Once()
+		repoProduct.On("GetProductByID", uint64(1)).Return(withVariants, nil).Once()
+
+		item, err := cartService.AddCartItems(1, &dto.AddCartItemsRequest{ProductID: 1, Quantity: 2})
+
+		assert.EqualError(t, err, "varian produk wajib dipilih")
+		assert.Nil(t, item)
+	})
+
+	t.Run("Failed Case - Variant of another product", func(t *testing.T) {
+		repo, repoProduct, cartService := setup(t)
+		repo.On("GetCartByUserID", uint64(1)).Return(userCart, nil).Once()
+		repo.On("GetCartItemByProductID", uint64(1), uint64(1), uint64(9)).Return(nil, errors.New("record not found")).Once()
+		repoProduct.On("GetProductByID", uint64(1)).Return(withVariants, nil).Once()
+
+		item, err := cartService.AddCartItems(1, &dto.AddCartItemsRequest{ProductID: 1, VariantID: 9, Quantity: 2})
+
+		assert.EqualError(t, err, "varian produk tidak ditemukan")
+		assert.Nil(t, item)
+	})
+}
+
 func TestCartService_RemoveProductFromCart(t *testing.T) {
 	userID := uint64(1)
 	productID := uint64(10)
+	variantID := uint64(3)
 
 	t.Run("Success Case - Product Removed From Cart", func(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
 
-		repoMock.On("IsProductInCart", userID, productID).Return(true)
-		repoMock.On("RemoveProductFromCart", userID, productID).Return(nil)
+		repoMock.On("IsProductInCart", userID, productID, variantID).Return(true)
+		repoMock.On("RemoveProductFromCart", userID, productID, variantID).Return(nil)
 
-		err := cartService.RemoveProductFromCart(userID, productID)
+		err := cartService.RemoveProductFromCart(userID, productID, variantID)
 
 		assert.NoError(t, err)
 	})
@@ -119,9 +183,9 @@ func TestCartService_RemoveProductFromCart(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
 
-		repoMock.On("IsProductInCart", userID, productID).Return(false)
+		repoMock.On("IsProductInCart", userID, productID, variantID).Return(false)
 
-		err := cartService.RemoveProductFromCart(userID, productID)
+		err := cartService.RemoveProductFromCart(userID, productID, variantID)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "produk tidak ada dalam keranjang pengguna")
@@ -131,10 +195,10 @@ func TestCartService_RemoveProductFromCart(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
 
-		repoMock.On("IsProductInCart", userID, productID).Return(true)
-		repoMock.On("RemoveProductFromCart", userID, productID).Return(errors.New("gagal menghapus produk dari keranjang"))
+		repoMock.On("IsProductInCart", userID, productID, variantID).Return(true)
+		repoMock.On("RemoveProductFromCart", userID, productID, variantID).Return(errors.New("gagal menghapus produk dari keranjang"))
 
-		err := cartService.RemoveProductFromCart(userID, productID)
+		err := cartService.RemoveProductFromCart(userID, productID, variantID)
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "gagal menghapus produk dari keranjang")
@@ -144,14 +208,15 @@ func TestCartService_RemoveProductFromCart(t *testing.T) {
 func TestCartService_IsProductInCart(t *testing.T) {
 	userID := uint64(1)
 	productID := uint64(10)
+	variantID := uint64(3)
 
 	t.Run("Success Case - Product In Cart", func(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
 
-		repoMock.On("IsProductInCart", userID, productID).Return(true)
+		repoMock.On("IsProductInCart", userID, productID, variantID).Return(true)
 
-		isInCart := cartService.IsProductInCart(userID, productID)
+		isInCart := cartService.IsProductInCart(userID, productID, variantID)
 
 		assert.True(t, isInCart)
 	})
@@ -160,9 +225,9 @@ func TestCartService_IsProductInCart(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
 
-		repoMock.On("IsProductInCart", userID, productID).Return(false)
+		repoMock.On("IsProductInCart", userID, productID, variantID).Return(false)
 
-		isInCart := cartService.IsProductInCart(userID, productID)
+		isInCart := cartService.IsProductInCart(userID, productID, variantID)
 
 		assert.False(t, isInCart)
 	})
@@ -310,6 +375,20 @@ func TestCartService_DeleteCartItem(t *testing.T) {
 		assert.EqualError(t, err, "gagal menghapus item dikeranjang")
 	})
 
+	t.Run("Failed Case - Cart Item Already Deleted", func(t *testing.T) {
+		repoMock, cartService, _, _ := setupTestService(t)
+		defer repoMock.AssertExpectations(t)
+
+		repoMock.On("GetCartItemByID", cartItemID).Return(expectedCart.CartItems[0], nil)
+		repoMock.On("GetCartByID", expectedCart.ID).Return(expectedCart, nil)
+		repoMock.On("DeleteCartItem", cartItemID).Return(gorm.ErrRecordNotFound)
+
+		err := cartService.DeleteCartItem(cartItemID)
+
+		assert.EqualError(t, err, "item dikeranjang tidak ditemukan")
+		repoMock.AssertNotCalled(t, "UpdateGrandTotal", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Failed Case - Error Recalculating Grand Total", func(t *testing.T) {
 		repoMock, cartService, _, _ := setupTestService(t)
 		defer repoMock.AssertExpectations(t)
@@ -25,10 +25,6 @@ func NewCategoryHandler(service category.ServiceCategoryInterface) category.Hand
 
 func (h *CategoryHandler) CreateCategory() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		categoryRequest := new(dto.CreateCategoryRequest)
 		file, err := c.FormFile("photo")
 		var uploadedURL string
@@ -95,11 +91,6 @@ func (h *CategoryHandler) GetAllCategory() echo.HandlerFunc {
 
 func (h *CategoryHandler) GetCategoryById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		id := c.Param("id")
 		categoryID, err := strconv.ParseUint(id, 10, 64)
 		if err != nil {
@@ -117,10 +108,6 @@ func (h *CategoryHandler) GetCategoryById() echo.HandlerFunc {
 
 func (h *CategoryHandler) UpdateCategoryById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		updateRequest := new(dto.UpdateCategoryRequest)
 		categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -173,11 +160,6 @@ func (h *CategoryHandler) UpdateCategoryById() echo.HandlerFunc {
 
 func (h *CategoryHandler) DeleteCategoryById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		categoryID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
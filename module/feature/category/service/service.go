@@ -4,16 +4,20 @@ import (
 	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/category"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
+	"github.com/sirupsen/logrus"
 	"math"
 )
 
 type CategoryService struct {
-	repo category.RepositoryCategoryInterface
+	repo           category.RepositoryCategoryInterface
+	productService product.ServiceProductInterface
 }
 
-func NewCategoryService(categoryRepo category.RepositoryCategoryInterface) category.ServiceCategoryInterface {
+func NewCategoryService(categoryRepo category.RepositoryCategoryInterface, productService product.ServiceProductInterface) category.ServiceCategoryInterface {
 	return &CategoryService{
-		repo: categoryRepo,
+		repo:           categoryRepo,
+		productService: productService,
 	}
 }
 
@@ -99,6 +103,7 @@ func (s *CategoryService) UpdateCategoryById(categoryID uint64, updatedCategory
 		return err
 	}
 
+	s.reindexProducts(categories.ID)
 	return nil
 }
 
@@ -113,9 +118,19 @@ func (s *CategoryService) DeleteCategoryById(categoryID uint64) error {
 		return err
 	}
 
+	s.reindexProducts(categories.ID)
 	return nil
 }
 
+// reindexProducts refreshes the category name in the search keywords of its
+// products. The index only serves search, so a failure is logged rather than
+// undoing the change.
+func (s *CategoryService) reindexProducts(categoryID uint64) {
+	if err := s.productService.ReindexCategoryProducts(categoryID); err != nil {
+		logrus.Error("Failed to reindex category products for search:", err)
+	}
+}
+
 func (s *CategoryService) GetCategoryById(categoryID uint64) (*entities.CategoryModels, error) {
 	result, err := s.repo.GetCategoryById(categoryID)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/category/mocks"
+	productMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -84,7 +85,7 @@ func TestCategoryService_GetPrevPage(t *testing.T) {
 
 func TestCategoryService_GetAll(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	service := NewCategoryService(repo, nil)
 
 	categories := []*entities.CategoryModels{
 		{ID: 1, Name: "Category 1", Photo: "categories1.jpg"},
@@ -132,7 +133,7 @@ func TestCategoryService_GetAll(t *testing.T) {
 
 func TestCategoryService_GetCategoryByName(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	service := NewCategoryService(repo, nil)
 
 	categories := []*entities.CategoryModels{
 		{ID: 1, Name: "Category 1", Photo: "categories1.jpg"},
@@ -183,7 +184,7 @@ func TestCategoryService_GetCategoryByName(t *testing.T) {
 
 func TestCategoryService_CreateCategory(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	service := NewCategoryService(repo, nil)
 
 	categories := &entities.CategoryModels{
 		Name:  "Category 1",
@@ -228,7 +229,8 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 
 func TestCategoryService_UpdateCategory(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	productService := productMocks.NewServiceProductInterface(t)
+	service := NewCategoryService(repo, productService)
 
 	categories := &entities.CategoryModels{
 		Name:  "Category 1",
@@ -238,6 +240,7 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 	t.Run("Success Case", func(t *testing.T) {
 		repo.On("GetCategoryById", categories.ID).Return(categories, nil).Once()
 		repo.On("UpdateCategoryById", categories.ID, categories).Return(nil).Once()
+		productService.On("ReindexCategoryProducts", categories.ID).Return(nil).Once()
 
 		result := service.UpdateCategoryById(categories.ID, categories)
 
@@ -272,7 +275,8 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 
 func TestCategoryService_DeleteCategory(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	productService := productMocks.NewServiceProductInterface(t)
+	service := NewCategoryService(repo, productService)
 
 	categories := &entities.CategoryModels{
 		Name:  "Category 1",
@@ -282,6 +286,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 	t.Run("Success Case", func(t *testing.T) {
 		repo.On("GetCategoryById", categories.ID).Return(categories, nil).Once()
 		repo.On("DeleteCategoryById", categories.ID).Return(nil).Once()
+		productService.On("ReindexCategoryProducts", categories.ID).Return(nil).Once()
 
 		err := service.DeleteCategoryById(categories.ID)
 
@@ -316,7 +321,7 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 
 func TestCategoryService_GetCategoryById(t *testing.T) {
 	repo := mocks.NewRepositoryCategoryInterface(t)
-	service := NewCategoryService(repo)
+	service := NewCategoryService(repo, nil)
 
 	categories := &entities.CategoryModels{
 		ID:    1,
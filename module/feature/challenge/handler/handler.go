@@ -68,11 +68,6 @@ func (h *ChallengeHandler) GetAllChallenges() echo.HandlerFunc {
 
 func (h *ChallengeHandler) CreateChallenge() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		challengeRequest := new(dto.CreateChallengeRequest)
 		if err := c.Bind(challengeRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -123,11 +118,6 @@ func (h *ChallengeHandler) CreateChallenge() echo.HandlerFunc {
 
 func (h *ChallengeHandler) UpdateChallenge() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		challengeID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
@@ -176,10 +166,6 @@ func (h *ChallengeHandler) UpdateChallenge() echo.HandlerFunc {
 
 func (h *ChallengeHandler) DeleteChallengeById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		challengeId, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
@@ -209,9 +195,6 @@ func (h *ChallengeHandler) GetChallengeById() echo.HandlerFunc {
 func (h *ChallengeHandler) CreateSubmitChallengeForm() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		formRequest := new(dto.CreateChallengeFormRequest)
 		if err := c.Bind(formRequest); err != nil {
@@ -262,10 +245,6 @@ func (h *ChallengeHandler) CreateSubmitChallengeForm() echo.HandlerFunc {
 
 func (h *ChallengeHandler) GetAllSubmitChallengeForm() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
 		perPage := 8
@@ -300,11 +279,6 @@ func (h *ChallengeHandler) GetAllSubmitChallengeForm() echo.HandlerFunc {
 
 func (h *ChallengeHandler) UpdateSubmitChallengeForm() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
-
 		var formRequest dto.UpdateChallengeFormStatusRequest
 		formID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -332,10 +306,6 @@ func (h *ChallengeHandler) UpdateSubmitChallengeForm() echo.HandlerFunc {
 
 func (h *ChallengeHandler) GetSubmitChallengeFormById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		formID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
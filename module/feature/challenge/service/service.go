@@ -2,25 +2,33 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/challenge"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/challenge/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	notificationDto "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/sirupsen/logrus"
 )
 
 type ChallengeService struct {
-	repo        challenge.RepositoryChallengeInterface
-	userService users.ServiceUserInterface
+	repo                challenge.RepositoryChallengeInterface
+	userService         users.ServiceUserInterface
+	notificationService notification.ServiceNotificationInterface
 }
 
-func NewChallengeService(repo challenge.RepositoryChallengeInterface, userService users.ServiceUserInterface) challenge.ServiceChallengeInterface {
+func NewChallengeService(repo challenge.RepositoryChallengeInterface, userService users.ServiceUserInterface, notificationService notification.ServiceNotificationInterface) challenge.ServiceChallengeInterface {
 	return &ChallengeService{
-		repo:        repo,
-		userService: userService,
+		repo:                repo,
+		userService:         userService,
+		notificationService: notificationService,
 	}
 }
 
@@ -265,6 +273,7 @@ func (s *ChallengeService) UpdateSubmitChallengeForm(id uint64, updatedData dto.
 		return nil, errors.New("pengguna tidak ada")
 	}
 
+	approved := form.Status != "valid" && updatedData.Status == "valid"
 	var changeTotalChallenge int64
 
 	switch {
@@ -299,9 +308,37 @@ func (s *ChallengeService) UpdateSubmitChallengeForm(id uint64, updatedData dto.
 		return nil, errors.New("gagal menyimpan perubahan total tantangan user ke database")
 	}
 
+	if approved {
+		s.notifyApproved(form)
+	}
+
 	return result, nil
 }
 
+// notifyApproved tells the user their submission was accepted. The exp has
+// already been granted by then, so a failure is only logged.
+func (s *ChallengeService) notifyApproved(form *entities.ChallengeFormModels) {
+	var title string
+	if result, err := s.repo.GetChallengeById(form.ChallengeID); err == nil {
+		title = result.Title
+	}
+
+	_, err := s.notificationService.Notify(&notificationDto.NotifyRequest{
+		UserID:   form.UserID,
+		Type:     notification.EventChallenge,
+		Title:    "Tantangan Disetujui",
+		Body:     fmt.Sprintf("Yeayy! Bukti tantangan %s kamu udah disetujui dan kamu mendapatkan %d exp.", title, form.Exp),
+		Template: email.TemplateChallengeApproved,
+		Data: map[string]string{
+			"Challenge": title,
+			"Exp":       strconv.FormatUint(form.Exp, 10),
+		},
+	})
+	if err != nil {
+		logrus.Error("Gagal mengirim notifikasi tantangan: ", err)
+	}
+}
+
 func (s *ChallengeService) GetSubmitChallengeFormById(id uint64) (*entities.ChallengeFormModels, error) {
 	result, err := s.repo.GetSubmitChallengeFormById(id)
 	if err != nil {
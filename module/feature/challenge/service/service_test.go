@@ -8,6 +8,8 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/challenge/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/challenge/mocks"
+	notificationDto "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	notification_mock "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/mocks"
 	user_mock "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
 	user_service "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/service"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
@@ -92,9 +94,9 @@ func TestChallengelService_GetPrevPage(t *testing.T) {
 func TestChallengeService_GetAll(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewChallengeService(repo, userService)
+	service := NewChallengeService(repo, userService, nil)
 
 	challenges := []*entities.ChallengeModels{
 		{ID: 1, Title: "Challenge 1", Photo: "challenge1.jpg", StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, 7), Description: "Description 1", Status: "Status 1", Exp: 500},
@@ -144,9 +146,9 @@ func TestChallengeService_GetAll(t *testing.T) {
 func TestChallengeService_GetChallengeByTitle(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewChallengeService(repo, userService)
+	service := NewChallengeService(repo, userService, nil)
 
 	challenges := []*entities.ChallengeModels{
 		{ID: 1, Title: "Challenge 1", Photo: "challenge1.jpg", StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, 7), Description: "Description 1", Status: "Status 1", Exp: 500},
@@ -197,9 +199,9 @@ func TestChallengeService_GetChallengeByTitle(t *testing.T) {
 func TestChallengeService_GetChallengeByStatus(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewChallengeService(repo, userService)
+	service := NewChallengeService(repo, userService, nil)
 
 	challenges := []*entities.ChallengeModels{
 		{ID: 1, Title: "Challenge 1", Photo: "challenge1.jpg", StartDate: time.Now(), EndDate: time.Now().AddDate(0, 0, 7), Description: "Description 1", Status: "Status 1", Exp: 500},
@@ -250,8 +252,8 @@ func TestChallengeService_GetChallengeByStatus(t *testing.T) {
 func TestChallengeService_CreateChallenge(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case - Belum Kadaluwarsa", func(t *testing.T) {
 		challenges := &entities.ChallengeModels{
@@ -327,8 +329,8 @@ func TestChallengeService_CreateChallenge(t *testing.T) {
 func TestChallengeService_GetChallengeById(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case - Found", func(t *testing.T) {
 		expectedChallenge := &entities.ChallengeModels{
@@ -417,8 +419,8 @@ func TestUpdateIfNotZeroUint64(t *testing.T) {
 func TestChallengeService_UpdateChallenge(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Status Change: Kadaluwarsa to Belum Kadaluwarsa", func(t *testing.T) {
 		existingChallenge := &entities.ChallengeModels{
@@ -501,8 +503,8 @@ func TestChallengeService_UpdateChallenge(t *testing.T) {
 func TestChallengeService_DeleteChallenge(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	existingChallenge := &entities.ChallengeModels{
 		ID:          1,
@@ -551,8 +553,8 @@ func TestChallengeService_DeleteChallenge(t *testing.T) {
 func TestChallengeService_CreateSubmitChallengeForm(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	existingChallenge := &entities.ChallengeModels{
 		ID:          1,
@@ -662,9 +664,9 @@ func TestChallengeService_CreateSubmitChallengeForm(t *testing.T) {
 func TestChallengeService_GetAllForm(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewChallengeService(repo, userService)
+	service := NewChallengeService(repo, userService, nil)
 
 	formChallenge := []*entities.ChallengeFormModels{
 		{ID: 1, UserID: 1, ChallengeID: 1, Username: "user123", Photo: "user123.jpg", Status: "menunggu validasi", Exp: 100, CreatedAt: time.Now()},
@@ -714,9 +716,9 @@ func TestChallengeService_GetAllForm(t *testing.T) {
 func TestChallengeService_GetChallengeFormByStatus(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewChallengeService(repo, userService)
+	service := NewChallengeService(repo, userService, nil)
 
 	formChallenge := []*entities.ChallengeFormModels{
 		{ID: 1, UserID: 1, ChallengeID: 1, Username: "user123", Photo: "user123.jpg", Status: "menunggu validasi", Exp: 100, CreatedAt: time.Now()},
@@ -767,12 +769,14 @@ func TestChallengeService_GetChallengeFormByStatus(t *testing.T) {
 func TestChallengeService_UpdateSubmitChallengeFormm(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	notificationService := notification_mock.NewServiceNotificationInterface(t)
+	service := NewChallengeService(repo, userService, notificationService)
 
 	challenge := &entities.ChallengeModels{
-		ID:  1,
-		Exp: 10,
+		ID:    1,
+		Title: "Tantangan 1",
+		Exp:   10,
 	}
 
 	user := &entities.UserModels{
@@ -828,6 +832,11 @@ func TestChallengeService_UpdateSubmitChallengeFormm(t *testing.T) {
 		repo.On("UpdateSubmitChallengeForm", form.ID, updatedData).Return(form, nil)
 		repoUser.On("UpdateUserExp", user.ID, user.Exp+form.Exp).Return(user, nil)
 		repoUser.On("UpdateUserChallengeFollow", user.ID, user.TotalChallenge+uint64(changeTotalChallenge)).Return(user, nil)
+		repo.On("GetChallengeById", challenge.ID).Return(challenge, nil).Once()
+		notificationService.On("Notify", mock.MatchedBy(func(req *notificationDto.NotifyRequest) bool {
+			return req.UserID == user.ID && req.Template == "challenge_approved" &&
+				req.Data["Challenge"] == challenge.Title && req.Data["Exp"] == "10"
+		})).Return(nil, nil).Once()
 		result, err := service.UpdateSubmitChallengeForm(form.ID, updatedData)
 		assert.NoError(t, err)
 		_, err = userService.UpdateUserExp(user.ID, user.Exp)
@@ -909,6 +918,10 @@ func TestChallengeService_UpdateSubmitChallengeFormm(t *testing.T) {
 		repo.On("UpdateSubmitChallengeForm", form3.ID, updatedData3).Return(form3, nil)
 		repoUser.On("UpdateUserExp", user.ID, user.Exp+form3.Exp).Return(user, nil)
 		repoUser.On("UpdateUserChallengeFollow", user.ID, user.TotalChallenge+uint64(changeTotalChallenge)).Return(user, nil)
+		repo.On("GetChallengeById", form3.ChallengeID).Return(nil, errors.New("tantangan tidak ditemukan")).Once()
+		notificationService.On("Notify", mock.MatchedBy(func(req *notificationDto.NotifyRequest) bool {
+			return req.UserID == user.ID && req.Data["Exp"] == "20"
+		})).Return(nil, errors.New("gagal mengirim notifikasi")).Once()
 		result, err := service.UpdateSubmitChallengeForm(form3.ID, updatedData3)
 		assert.NoError(t, err)
 		_, err = userService.UpdateUserExp(user.ID, user.Exp)
@@ -928,8 +941,8 @@ func TestChallengeService_UpdateSubmitChallengeFormm(t *testing.T) {
 	t.Run("Failed Case - UpdateSubmitChallengeForm", func(t *testing.T) {
 		repo := mocks.NewRepositoryChallengeInterface(t)
 		repoUser := user_mock.NewRepositoryUserInterface(t)
-		userService := user_service.NewUserService(repoUser, utils.NewHash())
-		service := NewChallengeService(repo, userService)
+		userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+		service := NewChallengeService(repo, userService, nil)
 		repo.On("GetSubmitChallengeFormById", form.ID).Return(form, nil)
 		repoUser.On("GetUsersById", user.ID).Return(user, nil)
 		repo.On("UpdateSubmitChallengeForm", form.ID, updatedData).Return(nil, errors.New("gagal memperbarui formulir"))
@@ -1058,8 +1071,8 @@ func TestChallengeService_UpdateSubmitChallengeFormm(t *testing.T) {
 func TestChallengeService_GetChallengeFormById(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case - Found", func(t *testing.T) {
 		expectedForm := &entities.ChallengeFormModels{
@@ -1145,8 +1158,8 @@ func TestGetDatesFromFilterType(t *testing.T) {
 func TestChallengeService_GetSubmitChallengeFormByDateRange(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	userRepo := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(userRepo, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(userRepo, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case", func(t *testing.T) {
 		page := 1
@@ -1224,8 +1237,8 @@ func TestChallengeService_GetSubmitChallengeFormByDateRange(t *testing.T) {
 func TestChallengeService_GetSubmitChallengeFormByStatusAndDate(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	userRepo := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(userRepo, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(userRepo, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case", func(t *testing.T) {
 		page := 1
@@ -1307,8 +1320,8 @@ func TestChallengeService_GetSubmitChallengeFormByStatusAndDate(t *testing.T) {
 func TestChallengeService_GetChallengesBySearchAndStatus(t *testing.T) {
 	repo := mocks.NewRepositoryChallengeInterface(t)
 	userRepo := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(userRepo, utils.NewHash())
-	service := NewChallengeService(repo, userService)
+	userService := user_service.NewUserService(userRepo, utils.NewHash(), nil, nil)
+	service := NewChallengeService(repo, userService, nil)
 
 	t.Run("Success Case", func(t *testing.T) {
 		page := 1
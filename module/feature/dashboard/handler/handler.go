@@ -3,7 +3,6 @@ package handler
 import (
 	"errors"
 	"fmt"
-	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/dashboard"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/dashboard/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
@@ -23,10 +22,6 @@ func NewDashboardHandler(service dashboard.ServiceDashboardInterface) dashboard.
 
 func (h *DashboardHandler) GetCardDashboard() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		productCount, userCount, orderCount, incomeCount, err := h.service.GetCardDashboard()
 		if err != nil {
 			c.Logger().Error("handler: failed to fetch dashboard data:", err.Error())
@@ -70,10 +65,6 @@ func (h *DashboardHandler) GetReview() echo.HandlerFunc {
 
 func (h *DashboardHandler) GetGramPlasticStat() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		now := time.Now()
 		currentMonth := now.Month()
 		indonesianMonth := dto.MonthMap[currentMonth]
@@ -104,10 +95,6 @@ func (h *DashboardHandler) GetGramPlasticStat() echo.HandlerFunc {
 
 func (h *DashboardHandler) GetLastTransactions() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		limit := 8
 		transactions, err := h.service.GetLatestTransactions(limit)
 		if err != nil {
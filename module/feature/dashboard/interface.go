@@ -12,6 +12,7 @@ type RepositoryDashboardInterface interface {
 	CountUsers() (int64, error)
 	CountOrder() (int64, error)
 	CountIncome() (float64, error)
+	CountRefund() (float64, error)
 	CountTotalGram() (int64, error)
 	GetProductWithMaxReviews() ([]*entities.ProductModels, error)
 	GetGramPlasticStat(startOfWeek, endOfWeek time.Time) (uint64, error)
@@ -86,6 +86,30 @@ func (_m *RepositoryDashboardInterface) CountProducts() (int64, error) {
 	return r0, r1
 }
 
+// CountRefund provides a mock function with given fields:
+func (_m *RepositoryDashboardInterface) CountRefund() (float64, error) {
+	ret := _m.Called()
+
+	var r0 float64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (float64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() float64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountTotalGram provides a mock function with given fields:
 func (_m *RepositoryDashboardInterface) CountTotalGram() (int64, error) {
 	ret := _m.Called()
@@ -52,8 +52,8 @@ func (r *DashboardRepository) CountIncome() (float64, error) {
 	firstDay := time.Now().AddDate(0, 0, -time.Now().Day()+1).Format("2006-01-02")
 	lastDay := time.Now().AddDate(0, 1, -time.Now().Day()).Format("2006-01-02")
 	if err := r.db.Model(&entities.OrderModels{}).
-		Where("order_status = ? AND payment_status = ? AND created_at BETWEEN ? AND ?",
-			"proses", "konfirmasi", firstDay, lastDay).
+		Where("payment_status = ? AND created_at BETWEEN ? AND ?",
+			"konfirmasi", firstDay, lastDay).
 		Select("SUM(total_amount_paid)").
 		Row().Scan(&totalAmount); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -64,6 +64,23 @@ func (r *DashboardRepository) CountIncome() (float64, error) {
 	return totalAmount.Float64, nil
 }
 
+func (r *DashboardRepository) CountRefund() (float64, error) {
+	var totalAmount sql.NullFloat64
+
+	firstDay := time.Now().AddDate(0, 0, -time.Now().Day()+1).Format("2006-01-02")
+	lastDay := time.Now().AddDate(0, 1, -time.Now().Day()).Format("2006-01-02")
+	if err := r.db.Model(&entities.OrderRefundModels{}).
+		Where("created_at BETWEEN ? AND ?", firstDay, lastDay).
+		Select("SUM(amount)").
+		Row().Scan(&totalAmount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0.0, nil
+		}
+		return 0.0, err
+	}
+	return totalAmount.Float64, nil
+}
+
 func (r *DashboardRepository) CountTotalGram() (int64, error) {
 	var totalGramPlastic int64
 	if err := r.db.Model(&entities.OrderModels{}).
@@ -94,7 +111,7 @@ func (r *DashboardRepository) GetProductWithMaxReviews() ([]*entities.ProductMod
 func (r *DashboardRepository) GetGramPlasticStat(startOfWeek, endOfWeek time.Time) (uint64, error) {
 	var gramTotalCount uint64
 	if err := r.db.Model(&entities.OrderModels{}).
-		Where("payment_status = 'konfirmasi'").
+		Where("payment_status = 'konfirmasi' AND order_status <> 'dikembalikan'").
 		Where("created_at BETWEEN ? AND ?", startOfWeek, endOfWeek).
 		Select("COALESCE(SUM(grand_total_gram_plastic), 0)").
 		Scan(&gramTotalCount).
@@ -45,8 +45,12 @@ func (s *DashboardService) GetCardDashboard() (int64, int64, int64, float64, err
 	if err != nil {
 		return 0, 0, 0, 0.0, errors.New("gagal menghitung total pendapatan")
 	}
+	refundCount, err := s.repo.CountRefund()
+	if err != nil {
+		return 0, 0, 0, 0.0, errors.New("gagal menghitung total refund")
+	}
 
-	return productCount, userCount, orderCount, inComeCount, nil
+	return productCount, userCount, orderCount, inComeCount - refundCount, nil
 }
 
 func (s *DashboardService) GetLandingPage() (int64, int64, int64, error) {
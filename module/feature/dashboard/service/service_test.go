@@ -33,6 +33,7 @@ func TestGetCardDashboard(t *testing.T) {
 		repo.On("CountOrder").Return(expectedOrderCount, nil)
 		repo.On("CountUsers").Return(expectedUserCount, nil)
 		repo.On("CountIncome").Return(expectedIncomeCount, nil)
+		repo.On("CountRefund").Return(float64(0.0), nil)
 
 		productCount, userCount, orderCount, incomeCount, err := service.GetCardDashboard()
 
@@ -45,6 +46,21 @@ func TestGetCardDashboard(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Success Case - Income Excludes Refunds", func(t *testing.T) {
+		repo, _, service := setupTestService(t)
+		repo.On("CountProducts").Return(expectedProductCount, nil)
+		repo.On("CountOrder").Return(expectedOrderCount, nil)
+		repo.On("CountUsers").Return(expectedUserCount, nil)
+		repo.On("CountIncome").Return(expectedIncomeCount, nil)
+		repo.On("CountRefund").Return(float64(1500.0), nil)
+
+		_, _, _, incomeCount, err := service.GetCardDashboard()
+
+		assert.NoError(t, err)
+		assert.Equal(t, float64(3500.0), incomeCount)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("Failed Case - CountProducts", func(t *testing.T) {
 		repo, _, service := setupTestService(t)
 		expectedError := errors.New("gagal menghitung total produk")
@@ -114,6 +130,26 @@ func TestGetCardDashboard(t *testing.T) {
 		assert.Zero(t, incomeCount)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("Failed Case - CountRefund", func(t *testing.T) {
+		repo, _, service := setupTestService(t)
+		expectedError := errors.New("gagal menghitung total refund")
+		repo.On("CountProducts").Return(int64(10), nil)
+		repo.On("CountOrder").Return(int64(20), nil)
+		repo.On("CountUsers").Return(int64(30), nil)
+		repo.On("CountIncome").Return(expectedIncomeCount, nil)
+		repo.On("CountRefund").Return(float64(0.0), errors.New("db error"))
+
+		productCount, userCount, orderCount, incomeCount, err := service.GetCardDashboard()
+
+		assert.Error(t, err)
+		assert.Equal(t, expectedError, err)
+		assert.Zero(t, productCount)
+		assert.Zero(t, userCount)
+		assert.Zero(t, orderCount)
+		assert.Zero(t, incomeCount)
+		repo.AssertExpectations(t)
+	})
 }
 
 func TestGetLandingPage(t *testing.T) {
@@ -0,0 +1,15 @@
+package fcm
+
+const (
+	PlatformAndroid = "android"
+	PlatformIOS     = "ios"
+	PlatformWeb     = "web"
+)
+
+func IsValidPlatform(platform string) bool {
+	switch platform {
+	case PlatformAndroid, PlatformIOS, PlatformWeb:
+		return true
+	}
+	return false
+}
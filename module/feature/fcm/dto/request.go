@@ -1,8 +1,10 @@
 package dto
 
-type FcmRequest struct {
-	Title  string `json:"title" form:"title"`
-	UserID uint64 `json:"user_id" form:"user_id"`
-	Body   string `json:"body" form:"body"`
-	Token  string `json:"token" form:"token"`
+type RegisterDeviceRequest struct {
+	Token    string `json:"token" form:"token" validate:"required"`
+	Platform string `json:"platform" form:"platform" validate:"required"`
+}
+
+type UnregisterDeviceRequest struct {
+	Token string `json:"token" form:"token" validate:"required"`
 }
@@ -1,51 +1,29 @@
 package dto
 
-import "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+import (
+	"time"
 
-type FcmFormatterCreate struct {
-	ID     uint64 `json:"id"`
-	UserID uint64 `json:"user_id"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
-	Token  string `json:"token"`
-	Status string `json:"status"`
-}
-
-type FcmFormatterByIdUser struct {
-	ID     uint64 `json:"id"`
-	UserID uint64 `json:"user_id"`
-	Title  string `json:"title"`
-	Body   string `json:"body"`
-}
-
-func FormatFcmCreate(fcm *entities.FcmModels, status string, token string) *FcmFormatterCreate {
-	FcmFormatter := &FcmFormatterCreate{}
-	FcmFormatter.ID = fcm.ID
-	FcmFormatter.UserID = fcm.UserID
-	FcmFormatter.Title = fcm.Title
-	FcmFormatter.Body = fcm.Body
-	FcmFormatter.Status = status
-	FcmFormatter.Token = token
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+)
 
-	return FcmFormatter
+type DeviceFormatter struct {
+	Token      string    `json:"token"`
+	Platform   string    `json:"platform"`
+	LastSeenAt time.Time `json:"last_seen_at"`
 }
-func FormatFcmGetbyIdUser(fcm *entities.FcmModels) *FcmFormatterByIdUser {
-	FcmFormatter := &FcmFormatterByIdUser{}
-	FcmFormatter.ID = fcm.ID
-	FcmFormatter.UserID = fcm.UserID
-	FcmFormatter.Title = fcm.Title
-	FcmFormatter.Body = fcm.Body
 
-	return FcmFormatter
+func FormatDevice(device *entities.UserDeviceModels) *DeviceFormatter {
+	return &DeviceFormatter{
+		Token:      device.Token,
+		Platform:   device.Platform,
+		LastSeenAt: device.LastSeenAt,
+	}
 }
 
-func FormatFcmGetbyIdUser2(fcms []*entities.FcmModels) []*FcmFormatterByIdUser {
-	var FcmFormatter []*FcmFormatterByIdUser
-
-	for _, fcm := range fcms {
-		formatFcm := FormatFcmGetbyIdUser(fcm)
-		FcmFormatter = append(FcmFormatter, formatFcm)
+func FormatDevices(devices []*entities.UserDeviceModels) []*DeviceFormatter {
+	deviceFormatters := make([]*DeviceFormatter, 0, len(devices))
+	for _, device := range devices {
+		deviceFormatters = append(deviceFormatters, FormatDevice(device))
 	}
-
-	return FcmFormatter
+	return deviceFormatters
 }
@@ -1,9 +1,6 @@
 package handler
 
 import (
-	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
-	"strconv"
-
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/dto"
@@ -22,77 +19,62 @@ func NewFcmHandler(service fcm.ServiceFcmInterface) fcm.HandlerFcmInterface {
 	}
 }
 
-func (h *FcmHandler) CreateFcm() echo.HandlerFunc {
+func (h *FcmHandler) RegisterDevice() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		fcmRequest := new(dto.FcmRequest)
-		if err := c.Bind(fcmRequest); err != nil {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		deviceRequest := new(dto.RegisterDeviceRequest)
+		if err := c.Bind(deviceRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai: "+err.Error())
 		}
 
-		if err := utils.ValidateStruct(fcmRequest); err != nil {
+		if err := utils.ValidateStruct(deviceRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
-		createFcmRequest := sendnotif.SendNotificationRequest{
-			UserID: fcmRequest.UserID,
-			Title:  fcmRequest.Title,
-			Body:   fcmRequest.Body,
-			Token:  fcmRequest.Token,
-		}
-		res, statusFcm, err := h.service.CreateFcm(createFcmRequest)
+		device, err := h.service.RegisterDevice(currentUser.ID, deviceRequest)
 		if err != nil {
-			return response.SendStatusInternalServerResponse(c, "Gagal mengirim notifikasi: "+err.Error())
+			if err.Error() == "platform perangkat tidak valid" {
+				return response.SendBadRequestResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mendaftarkan perangkat: "+err.Error())
 		}
 
-		return response.SendStatusCreatedResponse(c, "Berhasil mengirim notifikasi", dto.FormatFcmCreate(statusFcm, res, fcmRequest.Token))
+		return response.SendStatusCreatedResponse(c, "Berhasil mendaftarkan perangkat", dto.FormatDevice(device))
 	}
 }
 
-func (h *FcmHandler) GetFcmByIdUser() echo.HandlerFunc {
+func (h *FcmHandler) UnregisterDevice() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		deviceRequest := new(dto.UnregisterDeviceRequest)
+		if err := c.Bind(deviceRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai: "+err.Error())
 		}
-		var fcm []*entities.FcmModels
-		var err error
 
-		fcm, err = h.service.GetFcmByIdUser(currentUser.ID)
-		if err != nil {
-			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar notifikasi by iduser: "+err.Error())
+		if err := utils.ValidateStruct(deviceRequest); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
-		return response.SendStatusOkWithDataResponse(c, "Berhasil mendapatkan daftar notifikasi by id user", dto.FormatFcmGetbyIdUser2(fcm))
-	}
-}
-func (h *FcmHandler) GetFcmById() echo.HandlerFunc {
-	return func(c echo.Context) error {
-		var fcm *entities.FcmModels
-		var err error
-
-		id := c.Param("id")
-		idConf, _ := strconv.Atoi(id)
-
-		fcm, err = h.service.GetFcmById(uint64(idConf))
-		if err != nil {
-			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar notifikasi by id: "+err.Error())
+		if err := h.service.UnregisterDevice(currentUser.ID, deviceRequest.Token); err != nil {
+			if err.Error() == "perangkat tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menghapus perangkat: "+err.Error())
 		}
 
-		return response.SendStatusOkWithDataResponse(c, "Berhasil mendapatkan notifikasi by id", dto.FormatFcmGetbyIdUser(fcm))
-
+		return response.SendStatusOkResponse(c, "Berhasil menghapus perangkat")
 	}
 }
-func (h *FcmHandler) DeleteFcmById() echo.HandlerFunc {
+
+func (h *FcmHandler) GetDevices() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		var err error
-		id := c.Param("id")
-		idConf, _ := strconv.Atoi(id)
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
 
-		err = h.service.DeleteFcmById(uint64(idConf))
+		devices, err := h.service.GetDevicesByUserID(currentUser.ID)
 		if err != nil {
-			return response.SendStatusInternalServerResponse(c, "Gagal menghapus notifikasi: "+err.Error())
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar perangkat: "+err.Error())
 		}
 
-		return response.SendStatusOkWithDataResponse(c, "Berhasil menghapus notifikasi", nil)
+		return response.SendStatusOkWithDataResponse(c, "Berhasil mendapatkan daftar perangkat", dto.FormatDevices(devices))
 	}
 }
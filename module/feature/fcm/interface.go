@@ -2,27 +2,27 @@ package fcm
 
 import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
 	"github.com/labstack/echo/v4"
 )
 
 type RepositoryFcmInterface interface {
-	CreateFcm(fcm *entities.FcmModels) (*entities.FcmModels, error)
-	GetFcmByIdUser(id uint64) ([]*entities.FcmModels, error)
-	GetFcmById(id uint64) (*entities.FcmModels, error)
-	DeleteFcmById(id uint64) error
 	SendMessageNotification(request sendnotif.SendNotificationRequest) (string, error)
+	SaveDevice(device *entities.UserDeviceModels) error
+	GetDevicesByUserID(userID uint64) ([]*entities.UserDeviceModels, error)
+	DeleteDevice(userID uint64, token string) error
+	DeleteDeviceByToken(token string) error
 }
 type ServiceFcmInterface interface {
-	CreateFcm(request sendnotif.SendNotificationRequest) (string, *entities.FcmModels, error)
-	GetFcmByIdUser(id uint64) ([]*entities.FcmModels, error)
-	GetFcmById(id uint64) (*entities.FcmModels, error)
-	DeleteFcmById(id uint64) error
+	SendPush(request sendnotif.SendNotificationRequest) error
+	RegisterDevice(userID uint64, request *dto.RegisterDeviceRequest) (*entities.UserDeviceModels, error)
+	UnregisterDevice(userID uint64, token string) error
+	GetDevicesByUserID(userID uint64) ([]*entities.UserDeviceModels, error)
 }
 
 type HandlerFcmInterface interface {
-	CreateFcm() echo.HandlerFunc
-	GetFcmByIdUser() echo.HandlerFunc
-	GetFcmById() echo.HandlerFunc
-	DeleteFcmById() echo.HandlerFunc
+	RegisterDevice() echo.HandlerFunc
+	UnregisterDevice() echo.HandlerFunc
+	GetDevices() echo.HandlerFunc
 }
@@ -1,8 +1,6 @@
 package repository
 
 import (
-	"time"
-
 	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
 	"github.com/sirupsen/logrus"
 
@@ -10,6 +8,7 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FcmRepository struct {
@@ -24,58 +23,61 @@ func NewFcmRepository(db *gorm.DB, fcm sendnotif.FcmServiceInterface) fcm.Reposi
 	}
 }
 
-func (r *FcmRepository) CreateFcm(fcm *entities.FcmModels) (*entities.FcmModels, error) {
-	if err := r.db.Create(fcm).Error; err != nil {
-		return nil, err
+func (r *FcmRepository) SendMessageNotification(request sendnotif.SendNotificationRequest) (string, error) {
+	var err error
+	var sendSuccess string
+
+	sendSuccess, err = r.fcm.SendNotification(request)
+	if err != nil {
+		logrus.Error("Failed to send notification")
+		return "", err
 	}
 
-	return fcm, nil
+	return sendSuccess, nil
 }
 
-func (r *FcmRepository) GetFcmByIdUser(id uint64) ([]*entities.FcmModels, error) {
-	var fcm []*entities.FcmModels
-	err := r.db.Where("deleted_at IS NULL && user_id = ?", id).Find(&fcm).Error
-	if err != nil {
-		return nil, err
+// SaveDevice registers a push token. A token already on file is moved to this
+// user and its last-seen time refreshed; an empty platform keeps the old one.
+func (r *FcmRepository) SaveDevice(device *entities.UserDeviceModels) error {
+	updates := map[string]interface{}{
+		"user_id":      device.UserID,
+		"last_seen_at": device.LastSeenAt,
+	}
+	if device.Platform != "" {
+		updates["platform"] = device.Platform
 	}
 
-	return fcm, nil
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "token"}},
+		DoUpdates: clause.Assignments(updates),
+	}).Create(device).Error; err != nil {
+		return err
+	}
+	return nil
 }
 
-func (r *FcmRepository) GetFcmById(id uint64) (*entities.FcmModels, error) {
-	var fcm entities.FcmModels
-
-	if err := r.db.Where("deleted_at IS NULL && id = ?", id).First(&fcm).Error; err != nil {
+func (r *FcmRepository) GetDevicesByUserID(userID uint64) ([]*entities.UserDeviceModels, error) {
+	var devices []*entities.UserDeviceModels
+	if err := r.db.Where("user_id = ?", userID).Order("last_seen_at DESC").Find(&devices).Error; err != nil {
 		return nil, err
 	}
-
-	return &fcm, nil
+	return devices, nil
 }
 
-func (r *FcmRepository) DeleteFcmById(id uint64) error {
-	var fcm = &entities.FcmModels{}
-
-	if err := r.db.First(fcm, id).Error; err != nil {
-		return err
+func (r *FcmRepository) DeleteDevice(userID uint64, token string) error {
+	result := r.db.Where("user_id = ? AND token = ?", userID, token).Delete(&entities.UserDeviceModels{})
+	if result.Error != nil {
+		return result.Error
 	}
-
-	if err := r.db.Model(fcm).Update("deleted_at", time.Now()).Error; err != nil {
-		return err
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
 	}
-
 	return nil
-
 }
 
-func (r *FcmRepository) SendMessageNotification(request sendnotif.SendNotificationRequest) (string, error) {
-	var err error
-	var sendSuccess string
-
-	sendSuccess, err = r.fcm.SendNotification(request)
-	if err != nil {
-		logrus.Error("Failed to send notification")
-		return "", err
+func (r *FcmRepository) DeleteDeviceByToken(token string) error {
+	if err := r.db.Where("token = ?", token).Delete(&entities.UserDeviceModels{}).Error; err != nil {
+		return err
 	}
-
-	return sendSuccess, nil
+	return nil
 }
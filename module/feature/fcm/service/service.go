@@ -2,11 +2,15 @@ package service
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type FcmService struct {
@@ -19,58 +23,89 @@ func NewFcmService(repo fcm.RepositoryFcmInterface) fcm.ServiceFcmInterface {
 	}
 }
 
-func (s *FcmService) CreateFcm(request sendnotif.SendNotificationRequest) (string, *entities.FcmModels, error) {
-	var sendSuccess string
-	var err error
-
-	sendSuccess, err = s.repo.SendMessageNotification(request)
+// SendPush pushes the notification to the request's token, or to every registered
+// device of the user when there is none. Tokens FCM no longer knows are
+// removed. It fails only when no device could be reached for another reason.
+func (s *FcmService) SendPush(request sendnotif.SendNotificationRequest) error {
+	tokens, err := s.deviceTokens(request)
 	if err != nil {
-		logrus.Error("Failed to send notification:", err)
-		return "", nil, err
+		return err
 	}
 
-	value := &entities.FcmModels{
-		OrderID: request.OrderID,
-		UserID:  request.UserID,
-		Title:   request.Title,
-		Body:    request.Body,
-	}
+	delivered := false
+	var sendErr error
+	for _, token := range tokens {
+		deviceRequest := request
+		deviceRequest.Token = token
 
-	response, createErr := s.repo.CreateFcm(value)
-	if createErr != nil {
-		logrus.Error("Failed to create notification in the database:", createErr)
-		return "", nil, createErr
+		_, err := s.repo.SendMessageNotification(deviceRequest)
+		if errors.Is(err, sendnotif.ErrTokenUnregistered) {
+			if err := s.repo.DeleteDeviceByToken(token); err != nil {
+				logrus.Error("Failed to remove unregistered device:", err)
+			}
+			continue
+		}
+		if err != nil {
+			sendErr = err
+			continue
+		}
+		delivered = true
 	}
-
-	return sendSuccess, response, nil
+	if !delivered && sendErr != nil {
+		return sendErr
+	}
+	return nil
 }
 
-func (s *FcmService) GetFcmByIdUser(id uint64) ([]*entities.FcmModels, error) {
-	res, err := s.repo.GetFcmByIdUser(id)
-	if err != nil {
-		return nil, errors.New("Notifikasi tidak ditemukan")
+func (s *FcmService) deviceTokens(request sendnotif.SendNotificationRequest) ([]string, error) {
+	if request.Token != "" {
+		return []string{request.Token}, nil
 	}
-	return res, nil
-}
 
-func (s *FcmService) GetFcmById(id uint64) (*entities.FcmModels, error) {
-	res, err := s.repo.GetFcmById(id)
+	devices, err := s.repo.GetDevicesByUserID(request.UserID)
 	if err != nil {
-		return nil, errors.New("Notifikasi tidak ditemukan")
+		return nil, err
 	}
-	return res, nil
+	tokens := make([]string, 0, len(devices))
+	for _, device := range devices {
+		tokens = append(tokens, device.Token)
+	}
+	return tokens, nil
 }
 
-func (s *FcmService) DeleteFcmById(id uint64) error {
-	result, err := s.repo.GetFcmById(id)
-	if err != nil {
-		return errors.New("fcm tidak ditemukan")
+func (s *FcmService) RegisterDevice(userID uint64, request *dto.RegisterDeviceRequest) (*entities.UserDeviceModels, error) {
+	platform := strings.ToLower(request.Platform)
+	if !fcm.IsValidPlatform(platform) {
+		return nil, errors.New("platform perangkat tidak valid")
 	}
 
-	err = s.repo.DeleteFcmById(result.ID)
-	if err != nil {
-		return errors.New("fcm id tidak ditemukan")
+	device := &entities.UserDeviceModels{
+		UserID:     userID,
+		Token:      request.Token,
+		Platform:   platform,
+		LastSeenAt: time.Now(),
+	}
+	if err := s.repo.SaveDevice(device); err != nil {
+		return nil, errors.New("gagal mendaftarkan perangkat")
 	}
 
+	return device, nil
+}
+
+func (s *FcmService) UnregisterDevice(userID uint64, token string) error {
+	if err := s.repo.DeleteDevice(userID, token); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errors.New("perangkat tidak ditemukan")
+		}
+		return errors.New("gagal menghapus perangkat")
+	}
 	return nil
 }
+
+func (s *FcmService) GetDevicesByUserID(userID uint64) ([]*entities.UserDeviceModels, error) {
+	devices, err := s.repo.GetDevicesByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan daftar perangkat")
+	}
+	return devices, nil
+}
@@ -3,187 +3,148 @@ package service
 import (
 	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/mocks"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 	"testing"
 )
 
-func TestFcmService_GetFcmById(t *testing.T) {
-	repo := mocks.NewRepositoryFcmInterface(t)
-	service := NewFcmService(repo)
-
-	fcm := &entities.FcmModels{
-		ID:    1,
-		Title: "Test title 1",
-		Body:  "Test body 1",
+func TestFcmService_SendPush(t *testing.T) {
+	devices := []*entities.UserDeviceModels{
+		{UserID: 456, Token: "phone"},
+		{UserID: 456, Token: "tablet"},
 	}
-
-	expectedFcm := &entities.FcmModels{
-		ID:    fcm.ID,
-		Title: fcm.Title,
-		Body:  fcm.Body,
+	request := sendnotif.SendNotificationRequest{
+		OrderID: "123",
+		UserID:  456,
+		Title:   "Test Title",
+		Body:    "Test Body",
+	}
+	withToken := func(token string) sendnotif.SendNotificationRequest {
+		deviceRequest := request
+		deviceRequest.Token = token
+		return deviceRequest
 	}
 
-	t.Run("Success case - Message found", func(t *testing.T) {
-		fcmId := uint64(1)
-		repo.On("GetFcmById", fcmId).Return(expectedFcm, nil).Once()
-
-		result, err := service.GetFcmById(fcmId)
-		assert.Nil(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, expectedFcm.ID, result.ID)
-		assert.Equal(t, expectedFcm.Title, result.Title)
-		assert.Equal(t, expectedFcm.Body, result.Body)
-	})
+	t.Run("Success case - Sent to every device", func(t *testing.T) {
+		repo := mocks.NewRepositoryFcmInterface(t)
+		service := NewFcmService(repo)
+		repo.On("GetDevicesByUserID", uint64(456)).Return(devices, nil).Once()
+		repo.On("SendMessageNotification", withToken("phone")).Return("ok", nil).Once()
+		repo.On("SendMessageNotification", withToken("tablet")).Return("ok", nil).Once()
 
-	t.Run("Failed case - Message not found", func(t *testing.T) {
-		fcmId := uint64(2)
+		err := service.SendPush(request)
 
-		expectedErr := errors.New("Notifikasi tidak ditemukan")
-		repo.On("GetFcmById", fcmId).Return(nil, expectedErr).Once()
+		assert.NoError(t, err)
+	})
 
-		result, err := service.GetFcmById(fcmId)
+	t.Run("Success case - Unregistered token pruned", func(t *testing.T) {
+		repo := mocks.NewRepositoryFcmInterface(t)
+		service := NewFcmService(repo)
+		repo.On("GetDevicesByUserID", uint64(456)).Return(devices, nil).Once()
+		repo.On("SendMessageNotification", withToken("phone")).Return("", sendnotif.ErrTokenUnregistered).Once()
+		repo.On("DeleteDeviceByToken", "phone").Return(nil).Once()
+		repo.On("SendMessageNotification", withToken("tablet")).Return("ok", nil).Once()
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, expectedErr, err)
+		err := service.SendPush(request)
 
-		repo.AssertExpectations(t)
+		assert.NoError(t, err)
 	})
-}
 
-func TestFcmService_GetFcmByIdUser(t *testing.T) {
-	repo := mocks.NewRepositoryFcmInterface(t)
-	service := NewFcmService(repo)
+	t.Run("Success case - Explicit token only", func(t *testing.T) {
+		repo := mocks.NewRepositoryFcmInterface(t)
+		service := NewFcmService(repo)
+		repo.On("SendMessageNotification", withToken("explicit")).Return("ok", nil).Once()
 
-	userID := uint64(1)
+		err := service.SendPush(withToken("explicit"))
 
-	expectedFcms := []*entities.FcmModels{
-		{
-			ID:    1,
-			Title: "Test title 1",
-			Body:  "Test body 1",
-		},
-	}
-
-	t.Run("Success case - Notifications found", func(t *testing.T) {
-		repo.On("GetFcmByIdUser", userID).Return(expectedFcms, nil).Once()
+		assert.NoError(t, err)
+	})
 
-		result, err := service.GetFcmByIdUser(userID)
+	t.Run("Failed case - No device reached", func(t *testing.T) {
+		repo := mocks.NewRepositoryFcmInterface(t)
+		service := NewFcmService(repo)
+		repo.On("GetDevicesByUserID", uint64(456)).Return(devices[:1], nil).Once()
+		repo.On("SendMessageNotification", withToken("phone")).Return("", errors.New("fcm unavailable")).Once()
 
-		assert.Nil(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, expectedFcms, result)
+		err := service.SendPush(request)
 
-		repo.AssertExpectations(t)
+		assert.EqualError(t, err, "fcm unavailable")
 	})
 
-	t.Run("Failed case - Notifications not found", func(t *testing.T) {
-		expectedErr := errors.New("Notifikasi tidak ditemukan")
-		repo.On("GetFcmByIdUser", userID).Return(nil, expectedErr).Once()
+	t.Run("Failed case - Devices lookup failed", func(t *testing.T) {
+		repo := mocks.NewRepositoryFcmInterface(t)
+		service := NewFcmService(repo)
+		repo.On("GetDevicesByUserID", uint64(456)).Return(nil, errors.New("db error")).Once()
 
-		result, err := service.GetFcmByIdUser(userID)
+		err := service.SendPush(request)
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, expectedErr, err)
-
-		repo.AssertExpectations(t)
+		assert.EqualError(t, err, "db error")
 	})
 }
 
-func TestFcmService_DeleteFcmById(t *testing.T) {
+func TestFcmService_RegisterDevice(t *testing.T) {
 	repo := mocks.NewRepositoryFcmInterface(t)
 	service := NewFcmService(repo)
 
-	fcmID := uint64(1)
+	t.Run("Success case - Device registered", func(t *testing.T) {
+		request := &dto.RegisterDeviceRequest{Token: "phone", Platform: "Android"}
+		repo.On("SaveDevice", mock.MatchedBy(func(device *entities.UserDeviceModels) bool {
+			return device.UserID == 1 && device.Token == "phone" && device.Platform == fcm.PlatformAndroid
+		})).Return(nil).Once()
 
-	t.Run("Success case - Fcm found and deleted", func(t *testing.T) {
-		repo.On("GetFcmById", fcmID).Return(&entities.FcmModels{ID: fcmID}, nil).Once()
-		repo.On("DeleteFcmById", fcmID).Return(nil).Once()
-
-		err := service.DeleteFcmById(fcmID)
-
-		assert.Nil(t, err)
+		device, err := service.RegisterDevice(1, request)
 
+		assert.NoError(t, err)
+		assert.Equal(t, "phone", device.Token)
+		assert.False(t, device.LastSeenAt.IsZero())
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Failed case - Fcm not found", func(t *testing.T) {
-		repo.On("GetFcmById", fcmID).Return(nil, errors.New("fcm tidak ditemukan")).Once()
-
-		err := service.DeleteFcmById(fcmID)
+	t.Run("Failed case - Invalid platform", func(t *testing.T) {
+		request := &dto.RegisterDeviceRequest{Token: "phone", Platform: "symbian"}
 
-		assert.Error(t, err)
-		assert.Equal(t, errors.New("fcm tidak ditemukan"), err)
+		device, err := service.RegisterDevice(1, request)
 
-		repo.AssertNotCalled(t, "DeleteFcmById")
+		assert.Nil(t, device)
+		assert.EqualError(t, err, "platform perangkat tidak valid")
 	})
 
-	t.Run("Failed case - Failed to delete Fcm", func(t *testing.T) {
-		repo.On("GetFcmById", fcmID).Return(&entities.FcmModels{ID: fcmID}, nil).Once()
-		repo.On("DeleteFcmById", fcmID).Return(errors.New("fcm id tidak ditemukan")).Once()
+	t.Run("Failed case - Save failed", func(t *testing.T) {
+		request := &dto.RegisterDeviceRequest{Token: "phone", Platform: "ios"}
+		repo.On("SaveDevice", mock.AnythingOfType("*entities.UserDeviceModels")).Return(errors.New("db error")).Once()
 
-		err := service.DeleteFcmById(fcmID)
-
-		assert.Error(t, err)
-		assert.Equal(t, errors.New("fcm id tidak ditemukan"), err)
+		device, err := service.RegisterDevice(1, request)
 
+		assert.Nil(t, device)
+		assert.EqualError(t, err, "gagal mendaftarkan perangkat")
 		repo.AssertExpectations(t)
 	})
 }
 
-func TestFcmService_CreateFcm(t *testing.T) {
-	repoMock := mocks.NewRepositoryFcmInterface(t)
-	service := NewFcmService(repoMock)
-
-	request := sendnotif.SendNotificationRequest{
-		OrderID: "123",
-		UserID:  456,
-		Title:   "Test Title",
-		Body:    "Test Body",
-	}
-
-	t.Run("Success case - Fcm created and notification sent", func(t *testing.T) {
-		repoMock.On("SendMessageNotification", request).Return("success", nil).Once()
-		repoMock.On("CreateFcm", mock.AnythingOfType("*entities.FcmModels")).Return(&entities.FcmModels{}, nil).Once()
-
-		sendSuccess, response, err := service.CreateFcm(request)
-
-		assert.Nil(t, err)
-		assert.NotNil(t, response)
-		assert.Equal(t, "success", sendSuccess)
-
-		repoMock.AssertExpectations(t)
-	})
-
-	t.Run("Failed case - Failed to send notification", func(t *testing.T) {
-		expectedErr := errors.New("failed to send notification")
-		repoMock.On("SendMessageNotification", request).Return("", expectedErr).Once()
+func TestFcmService_UnregisterDevice(t *testing.T) {
+	repo := mocks.NewRepositoryFcmInterface(t)
+	service := NewFcmService(repo)
 
-		sendSuccess, response, err := service.CreateFcm(request)
+	t.Run("Success case - Device removed", func(t *testing.T) {
+		repo.On("DeleteDevice", uint64(1), "phone").Return(nil).Once()
 
-		assert.Error(t, err)
-		assert.Nil(t, response)
-		assert.Equal(t, "", sendSuccess)
+		err := service.UnregisterDevice(1, "phone")
 
-		repoMock.AssertExpectations(t)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
 	})
 
-	t.Run("Failed case - Failed to create Fcm in the database", func(t *testing.T) {
-		sendSuccess := "success"
-		expectedErr := errors.New("failed to create Fcm in the database")
-		repoMock.On("SendMessageNotification", request).Return(sendSuccess, nil).Once()
-		repoMock.On("CreateFcm", mock.AnythingOfType("*entities.FcmModels")).Return(nil, expectedErr).Once()
+	t.Run("Failed case - Device not found", func(t *testing.T) {
+		repo.On("DeleteDevice", uint64(1), "other").Return(gorm.ErrRecordNotFound).Once()
 
-		sendSuccess, response, err := service.CreateFcm(request)
+		err := service.UnregisterDevice(1, "other")
 
-		assert.Error(t, err)
-		assert.Nil(t, response)
-		assert.Equal(t, sendSuccess, "")
-
-		repoMock.AssertExpectations(t)
+		assert.EqualError(t, err, "perangkat tidak ditemukan")
+		repo.AssertExpectations(t)
 	})
 }
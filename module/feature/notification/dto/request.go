@@ -0,0 +1,31 @@
+package dto
+
+// NotifyRequest is one event for one user. Which channels it goes out on is
+// decided by the user's preferences for Type. Template names the email
+// template to use instead of Title and Body, filled in with Data.
+type NotifyRequest struct {
+	UserID   uint64            `json:"user_id" form:"user_id" validate:"required"`
+	Type     string            `json:"type" form:"type" validate:"required"`
+	OrderID  string            `json:"order_id" form:"order_id"`
+	Title    string            `json:"title" form:"title" validate:"required"`
+	Body     string            `json:"body" form:"body" validate:"required"`
+	Template string            `json:"template" form:"template"`
+	Data     map[string]string `json:"data" form:"data"`
+}
+
+type PreferenceRequest struct {
+	EventType string `json:"event_type" validate:"required"`
+	Channel   string `json:"channel" validate:"required"`
+	Enabled   bool   `json:"enabled"`
+}
+
+type UpdatePreferencesRequest struct {
+	Preferences []PreferenceRequest `json:"preferences" validate:"required,dive"`
+}
+
+type SendNotificationRequest struct {
+	UserID uint64 `json:"user_id" form:"user_id" validate:"required"`
+	Type   string `json:"type" form:"type" validate:"required"`
+	Title  string `json:"title" form:"title" validate:"required"`
+	Body   string `json:"body" form:"body" validate:"required"`
+}
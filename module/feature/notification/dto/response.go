@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+)
+
+type NotificationFormatter struct {
+	ID        uint64     `json:"id"`
+	Type      string     `json:"type"`
+	OrderID   string     `json:"order_id,omitempty"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	IsRead    bool       `json:"is_read"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func FormatNotification(notification *entities.FcmModels) *NotificationFormatter {
+	return &NotificationFormatter{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		OrderID:   notification.OrderID,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		IsRead:    notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
+
+func FormatterNotification(notifications []*entities.FcmModels) []*NotificationFormatter {
+	notificationFormatters := make([]*NotificationFormatter, 0, len(notifications))
+	for _, notification := range notifications {
+		notificationFormatters = append(notificationFormatters, FormatNotification(notification))
+	}
+	return notificationFormatters
+}
+
+type UnreadCountFormatter struct {
+	Unread int64 `json:"unread"`
+}
+
+type MarkAllReadFormatter struct {
+	Updated int64 `json:"updated"`
+}
+
+type PreferenceFormatter struct {
+	EventType string `json:"event_type"`
+	Channel   string `json:"channel"`
+	Enabled   bool   `json:"enabled"`
+}
+
+func FormatterPreference(preferences []*entities.NotificationPreferenceModels) []*PreferenceFormatter {
+	preferenceFormatters := make([]*PreferenceFormatter, 0, len(preferences))
+	for _, preference := range preferences {
+		preferenceFormatters = append(preferenceFormatters, &PreferenceFormatter{
+			EventType: preference.EventType,
+			Channel:   preference.Channel,
+			Enabled:   preference.Enabled,
+		})
+	}
+	return preferenceFormatters
+}
@@ -0,0 +1,48 @@
+package notification
+
+const (
+	ChannelPush  = "push"
+	ChannelEmail = "email"
+	ChannelInApp = "in_app"
+
+	EventOrderStatus     = "order_status"
+	EventPaymentStatus   = "payment_status"
+	EventChallenge       = "challenge"
+	EventAccountSecurity = "account_security"
+	EventPromo           = "promo"
+	EventStockAlert      = "stock_alert"
+)
+
+// Channels and EventTypes are listed in the order preferences are shown.
+var Channels = []string{ChannelInApp, ChannelPush, ChannelEmail}
+
+var EventTypes = []string{EventOrderStatus, EventPaymentStatus, EventChallenge, EventAccountSecurity, EventPromo, EventStockAlert}
+
+// defaultPreferences applies to every choice a user has not changed. Promotions
+// stay out of the mailbox unless asked for.
+var defaultPreferences = map[string]map[string]bool{
+	EventOrderStatus:     {ChannelInApp: true, ChannelPush: true, ChannelEmail: true},
+	EventPaymentStatus:   {ChannelInApp: true, ChannelPush: true, ChannelEmail: true},
+	EventChallenge:       {ChannelInApp: true, ChannelPush: true, ChannelEmail: true},
+	EventAccountSecurity: {ChannelInApp: true, ChannelPush: true, ChannelEmail: true},
+	EventPromo:           {ChannelInApp: true, ChannelPush: true, ChannelEmail: false},
+	EventStockAlert:      {ChannelInApp: true, ChannelPush: true, ChannelEmail: true},
+}
+
+func DefaultEnabled(eventType, channel string) bool {
+	return defaultPreferences[eventType][channel]
+}
+
+func IsValidEventType(eventType string) bool {
+	_, ok := defaultPreferences[eventType]
+	return ok
+}
+
+func IsValidChannel(channel string) bool {
+	for _, c := range Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+)
+
+type NotificationHandler struct {
+	service notification.ServiceNotificationInterface
+}
+
+func NewNotificationHandler(service notification.ServiceNotificationInterface) notification.HandlerNotificationInterface {
+	return &NotificationHandler{
+		service: service,
+	}
+}
+
+func (h *NotificationHandler) GetNotifications() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		perPage := 8
+		unreadOnly := c.QueryParam("unread") == "true"
+
+		notifications, totalItems, err := h.service.GetInbox(currentUser.ID, unreadOnly, page, perPage)
+		if err != nil {
+			c.Logger().Error("handler: failed to fetch notifications:", err.Error())
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar notifikasi: "+err.Error())
+		}
+
+		currentPage, totalPages := h.service.CalculatePaginationValues(page, int(totalItems), perPage)
+		nextPage := h.service.GetNextPage(currentPage, totalPages)
+		prevPage := h.service.GetPrevPage(currentPage)
+
+		return response.SendPaginationResponse(c, dto.FormatterNotification(notifications), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar notifikasi")
+	}
+}
+
+func (h *NotificationHandler) GetUnreadCount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+
+		count, err := h.service.CountUnread(currentUser.ID)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal menghitung notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkWithDataResponse(c, "Berhasil mendapatkan jumlah notifikasi belum dibaca", &dto.UnreadCountFormatter{Unread: count})
+	}
+}
+
+func (h *NotificationHandler) MarkRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+
+		if err := h.service.MarkRead(currentUser.ID, id); err != nil {
+			if err.Error() == "notifikasi tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Notifikasi tidak ditemukan")
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menandai notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkResponse(c, "Berhasil menandai notifikasi sebagai dibaca")
+	}
+}
+
+func (h *NotificationHandler) MarkAllRead() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+
+		updated, err := h.service.MarkAllRead(currentUser.ID)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal menandai notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkWithDataResponse(c, "Berhasil menandai semua notifikasi sebagai dibaca", &dto.MarkAllReadFormatter{Updated: updated})
+	}
+}
+
+func (h *NotificationHandler) DeleteNotification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+
+		if err := h.service.DeleteNotification(currentUser.ID, id); err != nil {
+			if err.Error() == "notifikasi tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Notifikasi tidak ditemukan")
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menghapus notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkResponse(c, "Berhasil menghapus notifikasi")
+	}
+}
+
+func (h *NotificationHandler) GetPreferences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+
+		preferences, err := h.service.GetPreferences(currentUser.ID)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan preferensi notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkWithDataResponse(c, "Berhasil mendapatkan preferensi notifikasi", dto.FormatterPreference(preferences))
+	}
+}
+
+func (h *NotificationHandler) UpdatePreferences() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		request := new(dto.UpdatePreferencesRequest)
+		if err := c.Bind(request); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(request); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		preferences, err := h.service.UpdatePreferences(currentUser.ID, request)
+		if err != nil {
+			if err.Error() == "jenis notifikasi tidak valid" || err.Error() == "kanal notifikasi tidak valid" {
+				return response.SendBadRequestResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menyimpan preferensi notifikasi: "+err.Error())
+		}
+
+		return response.SendStatusOkWithDataResponse(c, "Berhasil menyimpan preferensi notifikasi", dto.FormatterPreference(preferences))
+	}
+}
+
+func (h *NotificationHandler) SendNotification() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		request := new(dto.SendNotificationRequest)
+		if err := c.Bind(request); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai: "+err.Error())
+		}
+		if err := utils.ValidateStruct(request); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		inbox, err := h.service.Notify(&dto.NotifyRequest{
+			UserID: request.UserID,
+			Type:   request.Type,
+			Title:  request.Title,
+			Body:   request.Body,
+		})
+		if err != nil {
+			if err.Error() == "jenis notifikasi tidak valid" {
+				return response.SendBadRequestResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mengirim notifikasi: "+err.Error())
+		}
+
+		if inbox == nil {
+			return response.SendStatusCreatedResponse(c, "Berhasil mengirim notifikasi", nil)
+		}
+		return response.SendStatusCreatedResponse(c, "Berhasil mengirim notifikasi", dto.FormatNotification(inbox))
+	}
+}
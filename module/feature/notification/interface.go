@@ -0,0 +1,53 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+type RepositoryNotificationInterface interface {
+	WithTx(tx *gorm.DB) RepositoryNotificationInterface
+	CreateInbox(notification *entities.FcmModels) error
+	FindInbox(userID uint64, unreadOnly bool, page, perPage int) ([]*entities.FcmModels, error)
+	CountInbox(userID uint64, unreadOnly bool) (int64, error)
+	GetInboxByID(userID, id uint64) (*entities.FcmModels, error)
+	MarkRead(id uint64, readAt time.Time) error
+	MarkAllRead(userID uint64, readAt time.Time) (int64, error)
+	DeleteInbox(id uint64) error
+	GetPreferences(userID uint64) ([]*entities.NotificationPreferenceModels, error)
+	SavePreferences(preferences []*entities.NotificationPreferenceModels) error
+	CreateOutbox(outbox []*entities.NotificationOutboxModels) error
+	ClaimOutbox(now time.Time, limit int, lease time.Duration) ([]*entities.NotificationOutboxModels, error)
+	UpdateOutbox(outbox *entities.NotificationOutboxModels) error
+}
+
+type ServiceNotificationInterface interface {
+	WithTx(tx *gorm.DB) ServiceNotificationInterface
+	Notify(request *dto.NotifyRequest) (*entities.FcmModels, error)
+	DeliverPending() (int, error)
+	GetInbox(userID uint64, unreadOnly bool, page, perPage int) ([]*entities.FcmModels, int64, error)
+	CalculatePaginationValues(page int, totalItems int, perPage int) (int, int)
+	GetNextPage(currentPage, totalPages int) int
+	GetPrevPage(currentPage int) int
+	CountUnread(userID uint64) (int64, error)
+	MarkRead(userID, id uint64) error
+	MarkAllRead(userID uint64) (int64, error)
+	DeleteNotification(userID, id uint64) error
+	GetPreferences(userID uint64) ([]*entities.NotificationPreferenceModels, error)
+	UpdatePreferences(userID uint64, request *dto.UpdatePreferencesRequest) ([]*entities.NotificationPreferenceModels, error)
+}
+
+type HandlerNotificationInterface interface {
+	GetNotifications() echo.HandlerFunc
+	GetUnreadCount() echo.HandlerFunc
+	MarkRead() echo.HandlerFunc
+	MarkAllRead() echo.HandlerFunc
+	DeleteNotification() echo.HandlerFunc
+	GetPreferences() echo.HandlerFunc
+	UpdatePreferences() echo.HandlerFunc
+	SendNotification() echo.HandlerFunc
+}
@@ -0,0 +1,30 @@
+package notification
+
+import "time"
+
+const (
+	OutboxStatusPending    = "pending"
+	OutboxStatusProcessing = "processing"
+	OutboxStatusSent       = "sent"
+	OutboxStatusDead       = "dead"
+
+	// OutboxMaxAttempts is how many sends a notification gets before it is
+	// dead-lettered.
+	OutboxMaxAttempts = 8
+
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = time.Hour
+)
+
+// OutboxBackoff is the wait before the next try after the given number of
+// failed attempts: 30s, 1m, 2m, ... capped at one hour.
+func OutboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) notification.RepositoryNotificationInterface {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationRepository) WithTx(tx *gorm.DB) notification.RepositoryNotificationInterface {
+	return &NotificationRepository{
+		db: tx,
+	}
+}
+
+func (r *NotificationRepository) CreateInbox(notification *entities.FcmModels) error {
+	if err := r.db.Create(notification).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *NotificationRepository) inbox(userID uint64, unreadOnly bool) *gorm.DB {
+	query := r.db.Model(&entities.FcmModels{}).Where("user_id = ? AND deleted_at IS NULL", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	return query
+}
+
+func (r *NotificationRepository) FindInbox(userID uint64, unreadOnly bool, page, perPage int) ([]*entities.FcmModels, error) {
+	var notifications []*entities.FcmModels
+	offset := (page - 1) * perPage
+	err := r.inbox(userID, unreadOnly).
+		Order("created_at DESC, id DESC").
+		Limit(perPage).
+		Offset(offset).
+		Find(&notifications).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *NotificationRepository) CountInbox(userID uint64, unreadOnly bool) (int64, error) {
+	var count int64
+	err := r.inbox(userID, unreadOnly).Count(&count).Error
+	return count, err
+}
+
+func (r *NotificationRepository) GetInboxByID(userID, id uint64) (*entities.FcmModels, error) {
+	var notification entities.FcmModels
+	if err := r.db.Where("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+func (r *NotificationRepository) MarkRead(id uint64, readAt time.Time) error {
+	if err := r.db.Model(&entities.FcmModels{}).Where("id = ? AND read_at IS NULL", id).Update("read_at", readAt).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *NotificationRepository) MarkAllRead(userID uint64, readAt time.Time) (int64, error) {
+	result := r.inbox(userID, true).Update("read_at", readAt)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepository) DeleteInbox(id uint64) error {
+	if err := r.db.Model(&entities.FcmModels{}).Where("id = ?", id).Update("deleted_at", time.Now()).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *NotificationRepository) GetPreferences(userID uint64) ([]*entities.NotificationPreferenceModels, error) {
+	var preferences []*entities.NotificationPreferenceModels
+	if err := r.db.Where("user_id = ?", userID).Find(&preferences).Error; err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
+
+func (r *NotificationRepository) SavePreferences(preferences []*entities.NotificationPreferenceModels) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "event_type"}, {Name: "channel"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&preferences).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *NotificationRepository) CreateOutbox(outbox []*entities.NotificationOutboxModels) error {
+	if len(outbox) == 0 {
+		return nil
+	}
+	if err := r.db.Create(&outbox).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimOutbox takes up to limit due notifications and leases them until
+// now+lease. Rows locked by another worker are skipped; a processing row whose
+// lease ran out, because its worker died, is due again.
+func (r *NotificationRepository) ClaimOutbox(now time.Time, limit int, lease time.Duration) ([]*entities.NotificationOutboxModels, error) {
+	var outbox []*entities.NotificationOutboxModels
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ? AND next_attempt_at <= ?", []string{notification.OutboxStatusPending, notification.OutboxStatusProcessing}, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&outbox).Error; err != nil {
+			return err
+		}
+		if len(outbox) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, 0, len(outbox))
+		for _, item := range outbox {
+			item.Status = notification.OutboxStatusProcessing
+			item.NextAttemptAt = now.Add(lease)
+			ids = append(ids, item.ID)
+		}
+		return tx.Model(&entities.NotificationOutboxModels{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":          notification.OutboxStatusProcessing,
+			"next_attempt_at": now.Add(lease),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outbox, nil
+}
+
+func (r *NotificationRepository) UpdateOutbox(outbox *entities.NotificationOutboxModels) error {
+	if err := r.db.Model(&entities.NotificationOutboxModels{}).Where("id = ?", outbox.ID).Updates(map[string]interface{}{
+		"status":          outbox.Status,
+		"attempts":        outbox.Attempts,
+		"next_attempt_at": outbox.NextAttemptAt,
+		"last_error":      outbox.LastError,
+		"sent_at":         outbox.SentAt,
+	}).Error; err != nil {
+		return err
+	}
+	return nil
+}
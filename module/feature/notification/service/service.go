@@ -0,0 +1,367 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type NotificationService struct {
+	repo        notification.RepositoryNotificationInterface
+	fcmService  fcm.ServiceFcmInterface
+	userService users.ServiceUserInterface
+	mailer      email.EmailSenderInterface
+}
+
+func NewNotificationService(repo notification.RepositoryNotificationInterface, fcmService fcm.ServiceFcmInterface, userService users.ServiceUserInterface, mailer email.EmailSenderInterface) notification.ServiceNotificationInterface {
+	return &NotificationService{
+		repo:        repo,
+		fcmService:  fcmService,
+		userService: userService,
+		mailer:      mailer,
+	}
+}
+
+func (s *NotificationService) WithTx(tx *gorm.DB) notification.ServiceNotificationInterface {
+	return &NotificationService{
+		repo:        s.repo.WithTx(tx),
+		fcmService:  s.fcmService,
+		userService: s.userService,
+		mailer:      s.mailer,
+	}
+}
+
+// Notify writes the notification to the user's inbox and queues it for push
+// and email, each only if the user has that channel on for the event type.
+// Nothing is sent here; call it on WithTx so the rows are committed together
+// with the change they announce. The inbox row is nil when in-app is off.
+func (s *NotificationService) Notify(request *dto.NotifyRequest) (*entities.FcmModels, error) {
+	if !notification.IsValidEventType(request.Type) {
+		return nil, errors.New("jenis notifikasi tidak valid")
+	}
+	if request.Template != "" && !email.IsValidTemplate(request.Template) {
+		return nil, errors.New("template email tidak valid")
+	}
+
+	enabled, err := s.enabledChannels(request.UserID, request.Type)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan preferensi notifikasi")
+	}
+
+	var inbox *entities.FcmModels
+	if enabled[notification.ChannelInApp] {
+		inbox = &entities.FcmModels{
+			UserID:  request.UserID,
+			Type:    request.Type,
+			OrderID: request.OrderID,
+			Title:   request.Title,
+			Body:    request.Body,
+		}
+		if err := s.repo.CreateInbox(inbox); err != nil {
+			logrus.Error("Failed to create notification in the database:", err)
+			return nil, errors.New("gagal menyimpan notifikasi")
+		}
+	}
+
+	var outbox []*entities.NotificationOutboxModels
+	for _, channel := range []string{notification.ChannelPush, notification.ChannelEmail} {
+		if !enabled[channel] {
+			continue
+		}
+		item := &entities.NotificationOutboxModels{
+			UserID:        request.UserID,
+			Channel:       channel,
+			Type:          request.Type,
+			OrderID:       request.OrderID,
+			Title:         request.Title,
+			Body:          request.Body,
+			Status:        notification.OutboxStatusPending,
+			NextAttemptAt: time.Now(),
+		}
+		if channel == notification.ChannelEmail && request.Template != "" {
+			data, _ := json.Marshal(request.Data)
+			item.Template = request.Template
+			item.Data = string(data)
+		}
+		if inbox != nil {
+			item.FcmID = inbox.ID
+		}
+		outbox = append(outbox, item)
+	}
+	if err := s.repo.CreateOutbox(outbox); err != nil {
+		logrus.Error("Failed to queue notification:", err)
+		return nil, errors.New("gagal mengantrekan notifikasi")
+	}
+
+	return inbox, nil
+}
+
+func (s *NotificationService) enabledChannels(userID uint64, eventType string) (map[string]bool, error) {
+	preferences, err := s.repo.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[string]bool, len(notification.Channels))
+	for _, channel := range notification.Channels {
+		enabled[channel] = notification.DefaultEnabled(eventType, channel)
+	}
+	for _, preference := range preferences {
+		if preference.EventType == eventType {
+			enabled[preference.Channel] = preference.Enabled
+		}
+	}
+	return enabled, nil
+}
+
+const (
+	outboxBatchSize = 100
+	outboxWorkers   = 5
+	outboxLease     = 5 * time.Minute
+)
+
+// DeliverPending sends the due outbox notifications with a small pool of
+// workers and returns how many were delivered.
+func (s *NotificationService) DeliverPending() (int, error) {
+	pending, err := s.repo.ClaimOutbox(time.Now(), outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, errors.New("gagal mengambil antrean notifikasi")
+	}
+
+	jobs := make(chan *entities.NotificationOutboxModels)
+	var delivered int64
+	var wg sync.WaitGroup
+	for i := 0; i < outboxWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for outbox := range jobs {
+				if s.deliver(outbox) {
+					atomic.AddInt64(&delivered, 1)
+				}
+			}
+		}()
+	}
+	for _, outbox := range pending {
+		jobs <- outbox
+	}
+	close(jobs)
+	wg.Wait()
+
+	return int(delivered), nil
+}
+
+// deliver sends one outbox notification and records the outcome: sent, retried
+// later with exponential backoff, or dead-lettered once the attempts run out.
+func (s *NotificationService) deliver(outbox *entities.NotificationOutboxModels) bool {
+	sendErr := s.send(outbox)
+
+	now := time.Now()
+	outbox.Attempts++
+	switch {
+	case sendErr == nil:
+		outbox.Status = notification.OutboxStatusSent
+		outbox.SentAt = &now
+		outbox.LastError = ""
+	case outbox.Attempts >= notification.OutboxMaxAttempts:
+		outbox.Status = notification.OutboxStatusDead
+		outbox.LastError = sendErr.Error()
+		logrus.Errorf("Notifikasi %d gagal dikirim setelah %d percobaan: %v", outbox.ID, outbox.Attempts, sendErr)
+	default:
+		outbox.Status = notification.OutboxStatusPending
+		outbox.NextAttemptAt = now.Add(notification.OutboxBackoff(outbox.Attempts))
+		outbox.LastError = sendErr.Error()
+	}
+
+	if err := s.repo.UpdateOutbox(outbox); err != nil {
+		logrus.Error("Failed to update notification outbox:", err)
+	}
+	return sendErr == nil
+}
+
+func (s *NotificationService) send(outbox *entities.NotificationOutboxModels) error {
+	switch outbox.Channel {
+	case notification.ChannelEmail:
+		user, err := s.userService.GetUsersById(outbox.UserID)
+		if err != nil {
+			return err
+		}
+		if outbox.Template == "" {
+			return s.mailer.SendEmail(user.Email, outbox.Title, outbox.Body)
+		}
+		data := map[string]string{}
+		if outbox.Data != "" {
+			if err := json.Unmarshal([]byte(outbox.Data), &data); err != nil {
+				return err
+			}
+		}
+		data["Name"] = user.Name
+		data["Email"] = user.Email
+		return s.mailer.SendTemplate(user.Email, outbox.Template, data)
+	default:
+		return s.fcmService.SendPush(sendnotif.SendNotificationRequest{
+			OrderID: outbox.OrderID,
+			Type:    outbox.Type,
+			UserID:  outbox.UserID,
+			Title:   outbox.Title,
+			Body:    outbox.Body,
+			Token:   outbox.Token,
+		})
+	}
+}
+
+func (s *NotificationService) GetInbox(userID uint64, unreadOnly bool, page, perPage int) ([]*entities.FcmModels, int64, error) {
+	notifications, err := s.repo.FindInbox(userID, unreadOnly, page, perPage)
+	if err != nil {
+		return nil, 0, errors.New("gagal mendapatkan daftar notifikasi")
+	}
+
+	totalItems, err := s.repo.CountInbox(userID, unreadOnly)
+	if err != nil {
+		return nil, 0, errors.New("gagal mendapatkan daftar notifikasi")
+	}
+
+	return notifications, totalItems, nil
+}
+
+func (s *NotificationService) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
+	pageInt := page
+	if pageInt <= 0 {
+		pageInt = 1
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(perPage)))
+
+	if pageInt > totalPages {
+		pageInt = totalPages
+	}
+
+	return pageInt, totalPages
+}
+
+func (s *NotificationService) GetNextPage(currentPage, totalPages int) int {
+	if currentPage < totalPages {
+		return currentPage + 1
+	}
+	return totalPages
+}
+
+func (s *NotificationService) GetPrevPage(currentPage int) int {
+	if currentPage > 1 {
+		return currentPage - 1
+	}
+	return 1
+}
+
+func (s *NotificationService) CountUnread(userID uint64) (int64, error) {
+	count, err := s.repo.CountInbox(userID, true)
+	if err != nil {
+		return 0, errors.New("gagal menghitung notifikasi belum dibaca")
+	}
+	return count, nil
+}
+
+func (s *NotificationService) MarkRead(userID, id uint64) error {
+	result, err := s.repo.GetInboxByID(userID, id)
+	if err != nil {
+		return errors.New("notifikasi tidak ditemukan")
+	}
+	if result.ReadAt != nil {
+		return nil
+	}
+
+	if err := s.repo.MarkRead(result.ID, time.Now()); err != nil {
+		return errors.New("gagal menandai notifikasi sebagai dibaca")
+	}
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(userID uint64) (int64, error) {
+	updated, err := s.repo.MarkAllRead(userID, time.Now())
+	if err != nil {
+		return 0, errors.New("gagal menandai notifikasi sebagai dibaca")
+	}
+	return updated, nil
+}
+
+func (s *NotificationService) DeleteNotification(userID, id uint64) error {
+	result, err := s.repo.GetInboxByID(userID, id)
+	if err != nil {
+		return errors.New("notifikasi tidak ditemukan")
+	}
+
+	if err := s.repo.DeleteInbox(result.ID); err != nil {
+		return errors.New("gagal menghapus notifikasi")
+	}
+	return nil
+}
+
+// GetPreferences returns every event type and channel pair, with the user's
+// own choice where there is one and the default otherwise.
+func (s *NotificationService) GetPreferences(userID uint64) ([]*entities.NotificationPreferenceModels, error) {
+	stored, err := s.repo.GetPreferences(userID)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan preferensi notifikasi")
+	}
+
+	choices := make(map[string]bool, len(stored))
+	for _, preference := range stored {
+		choices[preference.EventType+"/"+preference.Channel] = preference.Enabled
+	}
+
+	preferences := make([]*entities.NotificationPreferenceModels, 0, len(notification.EventTypes)*len(notification.Channels))
+	for _, eventType := range notification.EventTypes {
+		for _, channel := range notification.Channels {
+			enabled, ok := choices[eventType+"/"+channel]
+			if !ok {
+				enabled = notification.DefaultEnabled(eventType, channel)
+			}
+			preferences = append(preferences, &entities.NotificationPreferenceModels{
+				UserID:    userID,
+				EventType: eventType,
+				Channel:   channel,
+				Enabled:   enabled,
+			})
+		}
+	}
+	return preferences, nil
+}
+
+func (s *NotificationService) UpdatePreferences(userID uint64, request *dto.UpdatePreferencesRequest) ([]*entities.NotificationPreferenceModels, error) {
+	now := time.Now()
+	preferences := make([]*entities.NotificationPreferenceModels, 0, len(request.Preferences))
+	for _, item := range request.Preferences {
+		if !notification.IsValidEventType(item.EventType) {
+			return nil, errors.New("jenis notifikasi tidak valid")
+		}
+		if !notification.IsValidChannel(item.Channel) {
+			return nil, errors.New("kanal notifikasi tidak valid")
+		}
+		preferences = append(preferences, &entities.NotificationPreferenceModels{
+			UserID:    userID,
+			EventType: item.EventType,
+			Channel:   item.Channel,
+			Enabled:   item.Enabled,
+			UpdatedAt: now,
+		})
+	}
+
+	if err := s.repo.SavePreferences(preferences); err != nil {
+		return nil, errors.New("gagal menyimpan preferensi notifikasi")
+	}
+
+	return s.GetPreferences(userID)
+}
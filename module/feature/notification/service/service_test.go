@@ -0,0 +1,542 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	fcmMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/mocks"
+	userMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
+	utils "github.com/capstone-kelompok-7/backend-disappear/utils/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupNotificationService(t *testing.T) (
+	notification.ServiceNotificationInterface,
+	*mocks.RepositoryNotificationInterface,
+	*fcmMocks.ServiceFcmInterface,
+	*userMocks.ServiceUserInterface,
+	*utils.EmailSenderInterface,
+) {
+	repo := mocks.NewRepositoryNotificationInterface(t)
+	fcmService := fcmMocks.NewServiceFcmInterface(t)
+	userService := userMocks.NewServiceUserInterface(t)
+	mailer := utils.NewEmailSenderInterface(t)
+	service := NewNotificationService(repo, fcmService, userService, mailer)
+	return service, repo, fcmService, userService, mailer
+}
+
+func TestNotificationService_Notify(t *testing.T) {
+	request := &dto.NotifyRequest{
+		UserID:  1,
+		Type:    notification.EventOrderStatus,
+		OrderID: "order-1",
+		Title:   "Status Pengiriman",
+		Body:    "Pesananmu sedang dikirim",
+	}
+
+	t.Run("Success case - Every channel on by default", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return(nil, nil).Once()
+		repo.On("CreateInbox", mock.AnythingOfType("*entities.FcmModels")).Run(func(args mock.Arguments) {
+			args.Get(0).(*entities.FcmModels).ID = 7
+		}).Return(nil).Once()
+		repo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 2 &&
+				outbox[0].Channel == notification.ChannelPush && outbox[1].Channel == notification.ChannelEmail &&
+				outbox[0].FcmID == 7 && outbox[0].Status == notification.OutboxStatusPending &&
+				outbox[0].Type == notification.EventOrderStatus
+		})).Return(nil).Once()
+
+		inbox, err := service.Notify(request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), inbox.ID)
+		assert.Equal(t, notification.EventOrderStatus, inbox.Type)
+		assert.Equal(t, "order-1", inbox.OrderID)
+	})
+
+	t.Run("Success case - Preferences turn channels off", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return([]*entities.NotificationPreferenceModels{
+			{UserID: 1, EventType: notification.EventOrderStatus, Channel: notification.ChannelInApp, Enabled: false},
+			{UserID: 1, EventType: notification.EventOrderStatus, Channel: notification.ChannelEmail, Enabled: false},
+			{UserID: 1, EventType: notification.EventPromo, Channel: notification.ChannelPush, Enabled: false},
+		}, nil).Once()
+		repo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 1 && outbox[0].Channel == notification.ChannelPush && outbox[0].FcmID == 0
+		})).Return(nil).Once()
+
+		inbox, err := service.Notify(request)
+
+		assert.NoError(t, err)
+		assert.Nil(t, inbox)
+		repo.AssertNotCalled(t, "CreateInbox", mock.Anything)
+	})
+
+	t.Run("Success case - Promotions skip email by default", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		promo := *request
+		promo.Type = notification.EventPromo
+		repo.On("GetPreferences", uint64(1)).Return(nil, nil).Once()
+		repo.On("CreateInbox", mock.AnythingOfType("*entities.FcmModels")).Return(nil).Once()
+		repo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 1 && outbox[0].Channel == notification.ChannelPush
+		})).Return(nil).Once()
+
+		_, err := service.Notify(&promo)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success case - Template kept on the email row only", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		templated := *request
+		templated.Template = "shipped"
+		templated.Data = map[string]string{"OrderID": "order-1"}
+		repo.On("GetPreferences", uint64(1)).Return(nil, nil).Once()
+		repo.On("CreateInbox", mock.AnythingOfType("*entities.FcmModels")).Return(nil).Once()
+		repo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 2 &&
+				outbox[0].Template == "" && outbox[0].Data == "" &&
+				outbox[1].Template == "shipped" && outbox[1].Data == `{"OrderID":"order-1"}`
+		})).Return(nil).Once()
+
+		_, err := service.Notify(&templated)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed case - Unknown template", func(t *testing.T) {
+		service, _, _, _, _ := setupNotificationService(t)
+		invalid := *request
+		invalid.Template = "unknown"
+
+		inbox, err := service.Notify(&invalid)
+
+		assert.EqualError(t, err, "template email tidak valid")
+		assert.Nil(t, inbox)
+	})
+
+	t.Run("Failed case - Unknown event type", func(t *testing.T) {
+		service, _, _, _, _ := setupNotificationService(t)
+		invalid := *request
+		invalid.Type = "unknown"
+
+		inbox, err := service.Notify(&invalid)
+
+		assert.EqualError(t, err, "jenis notifikasi tidak valid")
+		assert.Nil(t, inbox)
+	})
+
+	t.Run("Failed case - Preferences lookup failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return(nil, errors.New("db error")).Once()
+
+		inbox, err := service.Notify(request)
+
+		assert.EqualError(t, err, "gagal mendapatkan preferensi notifikasi")
+		assert.Nil(t, inbox)
+	})
+
+	t.Run("Failed case - Inbox not stored", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return(nil, nil).Once()
+		repo.On("CreateInbox", mock.AnythingOfType("*entities.FcmModels")).Return(errors.New("db error")).Once()
+
+		inbox, err := service.Notify(request)
+
+		assert.EqualError(t, err, "gagal menyimpan notifikasi")
+		assert.Nil(t, inbox)
+	})
+
+	t.Run("Failed case - Outbox not stored", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return(nil, nil).Once()
+		repo.On("CreateInbox", mock.AnythingOfType("*entities.FcmModels")).Return(nil).Once()
+		repo.On("CreateOutbox", mock.Anything).Return(errors.New("db error")).Once()
+
+		inbox, err := service.Notify(request)
+
+		assert.EqualError(t, err, "gagal mengantrekan notifikasi")
+		assert.Nil(t, inbox)
+	})
+}
+
+func TestNotificationService_WithTx(t *testing.T) {
+	service, repo, _, _, _ := setupNotificationService(t)
+	txRepo := mocks.NewRepositoryNotificationInterface(t)
+	repo.On("WithTx", (*gorm.DB)(nil)).Return(txRepo).Once()
+	txRepo.On("MarkAllRead", uint64(1), mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+
+	updated, err := service.WithTx(nil).MarkAllRead(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), updated)
+}
+
+func TestNotificationService_DeliverPending(t *testing.T) {
+	newOutbox := func(channel string, attempts int) *entities.NotificationOutboxModels {
+		return &entities.NotificationOutboxModels{
+			ID:       1,
+			UserID:   456,
+			Channel:  channel,
+			Type:     notification.EventOrderStatus,
+			OrderID:  "123",
+			Title:    "Test Title",
+			Body:     "Test Body",
+			Status:   notification.OutboxStatusProcessing,
+			Attempts: attempts,
+		}
+	}
+	push := sendnotif.SendNotificationRequest{
+		OrderID: "123",
+		Type:    notification.EventOrderStatus,
+		UserID:  456,
+		Title:   "Test Title",
+		Body:    "Test Body",
+	}
+
+	t.Run("Success case - Push sent", func(t *testing.T) {
+		service, repo, fcmService, _, _ := setupNotificationService(t)
+		outbox := newOutbox(notification.ChannelPush, 0)
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return([]*entities.NotificationOutboxModels{outbox}, nil).Once()
+		fcmService.On("SendPush", push).Return(nil).Once()
+		repo.On("UpdateOutbox", outbox).Return(nil).Once()
+
+		delivered, err := service.DeliverPending()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, notification.OutboxStatusSent, outbox.Status)
+		assert.Equal(t, 1, outbox.Attempts)
+		assert.NotNil(t, outbox.SentAt)
+	})
+
+	t.Run("Success case - Email sent", func(t *testing.T) {
+		service, repo, _, userService, mailer := setupNotificationService(t)
+		outbox := newOutbox(notification.ChannelEmail, 0)
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return([]*entities.NotificationOutboxModels{outbox}, nil).Once()
+		userService.On("GetUsersById", uint64(456)).Return(&entities.UserModels{ID: 456, Email: "user@example.com"}, nil).Once()
+		mailer.On("SendEmail", "user@example.com", "Test Title", "Test Body").Return(nil).Once()
+		repo.On("UpdateOutbox", outbox).Return(nil).Once()
+
+		delivered, err := service.DeliverPending()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, notification.OutboxStatusSent, outbox.Status)
+	})
+
+	t.Run("Success case - Templated email sent", func(t *testing.T) {
+		service, repo, _, userService, mailer := setupNotificationService(t)
+		outbox := newOutbox(notification.ChannelEmail, 0)
+		outbox.Template = "shipped"
+		outbox.Data = `{"OrderID":"123"}`
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return([]*entities.NotificationOutboxModels{outbox}, nil).Once()
+		userService.On("GetUsersById", uint64(456)).Return(&entities.UserModels{ID: 456, Name: "User", Email: "user@example.com"}, nil).Once()
+		mailer.On("SendTemplate", "user@example.com", "shipped", map[string]string{
+			"OrderID": "123",
+			"Name":    "User",
+			"Email":   "user@example.com",
+		}).Return(nil).Once()
+		repo.On("UpdateOutbox", outbox).Return(nil).Once()
+
+		delivered, err := service.DeliverPending()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, notification.OutboxStatusSent, outbox.Status)
+	})
+
+	t.Run("Failed case - Retried with backoff", func(t *testing.T) {
+		service, repo, fcmService, _, _ := setupNotificationService(t)
+		outbox := newOutbox(notification.ChannelPush, 2)
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return([]*entities.NotificationOutboxModels{outbox}, nil).Once()
+		fcmService.On("SendPush", push).Return(errors.New("fcm unavailable")).Once()
+		repo.On("UpdateOutbox", outbox).Return(nil).Once()
+
+		before := time.Now()
+		delivered, err := service.DeliverPending()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, notification.OutboxStatusPending, outbox.Status)
+		assert.Equal(t, 3, outbox.Attempts)
+		assert.Equal(t, "fcm unavailable", outbox.LastError)
+		assert.True(t, outbox.NextAttemptAt.After(before.Add(notification.OutboxBackoff(3)-time.Second)))
+	})
+
+	t.Run("Failed case - Dead-lettered after the last attempt", func(t *testing.T) {
+		service, repo, _, userService, mailer := setupNotificationService(t)
+		outbox := newOutbox(notification.ChannelEmail, notification.OutboxMaxAttempts-1)
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return([]*entities.NotificationOutboxModels{outbox}, nil).Once()
+		userService.On("GetUsersById", uint64(456)).Return(&entities.UserModels{ID: 456, Email: "user@example.com"}, nil).Once()
+		mailer.On("SendEmail", "user@example.com", "Test Title", "Test Body").Return(errors.New("smtp unavailable")).Once()
+		repo.On("UpdateOutbox", outbox).Return(nil).Once()
+
+		delivered, err := service.DeliverPending()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, delivered)
+		assert.Equal(t, notification.OutboxStatusDead, outbox.Status)
+		assert.Equal(t, notification.OutboxMaxAttempts, outbox.Attempts)
+	})
+
+	t.Run("Failed case - Claim failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("ClaimOutbox", mock.AnythingOfType("time.Time"), outboxBatchSize, outboxLease).Return(nil, errors.New("db error")).Once()
+
+		delivered, err := service.DeliverPending()
+
+		assert.EqualError(t, err, "gagal mengambil antrean notifikasi")
+		assert.Equal(t, 0, delivered)
+	})
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, notification.OutboxBackoff(1))
+	assert.Equal(t, time.Minute, notification.OutboxBackoff(2))
+	assert.Equal(t, 4*time.Minute, notification.OutboxBackoff(4))
+	assert.Equal(t, time.Hour, notification.OutboxBackoff(20))
+}
+
+func TestNotificationService_GetInbox(t *testing.T) {
+	notifications := []*entities.FcmModels{{ID: 1, UserID: 1, Title: "Status Pengiriman"}}
+
+	t.Run("Success case - Unread only", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("FindInbox", uint64(1), true, 1, 8).Return(notifications, nil).Once()
+		repo.On("CountInbox", uint64(1), true).Return(int64(1), nil).Once()
+
+		result, total, err := service.GetInbox(1, true, 1, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, notifications, result)
+		assert.Equal(t, int64(1), total)
+	})
+
+	t.Run("Failed case - Find failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("FindInbox", uint64(1), false, 1, 8).Return(nil, errors.New("db error")).Once()
+
+		result, total, err := service.GetInbox(1, false, 1, 8)
+
+		assert.EqualError(t, err, "gagal mendapatkan daftar notifikasi")
+		assert.Nil(t, result)
+		assert.Equal(t, int64(0), total)
+	})
+}
+
+func TestNotificationService_CountUnread(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("CountInbox", uint64(1), true).Return(int64(4), nil).Once()
+
+		count, err := service.CountUnread(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+
+	t.Run("Failed case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("CountInbox", uint64(1), true).Return(int64(0), errors.New("db error")).Once()
+
+		_, err := service.CountUnread(1)
+
+		assert.EqualError(t, err, "gagal menghitung notifikasi belum dibaca")
+	})
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	t.Run("Success case - Marked as read", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(&entities.FcmModels{ID: 7, UserID: 1}, nil).Once()
+		repo.On("MarkRead", uint64(7), mock.AnythingOfType("time.Time")).Return(nil).Once()
+
+		err := service.MarkRead(1, 7)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Success case - Already read", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		readAt := time.Now()
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(&entities.FcmModels{ID: 7, UserID: 1, ReadAt: &readAt}, nil).Once()
+
+		err := service.MarkRead(1, 7)
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "MarkRead", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed case - Not the user's notification", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(2), uint64(7)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.MarkRead(2, 7)
+
+		assert.EqualError(t, err, "notifikasi tidak ditemukan")
+	})
+
+	t.Run("Failed case - Update failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(&entities.FcmModels{ID: 7, UserID: 1}, nil).Once()
+		repo.On("MarkRead", uint64(7), mock.AnythingOfType("time.Time")).Return(errors.New("db error")).Once()
+
+		err := service.MarkRead(1, 7)
+
+		assert.EqualError(t, err, "gagal menandai notifikasi sebagai dibaca")
+	})
+}
+
+func TestNotificationService_MarkAllRead(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("MarkAllRead", uint64(1), mock.AnythingOfType("time.Time")).Return(int64(5), nil).Once()
+
+		updated, err := service.MarkAllRead(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), updated)
+	})
+
+	t.Run("Failed case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("MarkAllRead", uint64(1), mock.AnythingOfType("time.Time")).Return(int64(0), errors.New("db error")).Once()
+
+		_, err := service.MarkAllRead(1)
+
+		assert.EqualError(t, err, "gagal menandai notifikasi sebagai dibaca")
+	})
+}
+
+func TestNotificationService_DeleteNotification(t *testing.T) {
+	t.Run("Success case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(&entities.FcmModels{ID: 7, UserID: 1}, nil).Once()
+		repo.On("DeleteInbox", uint64(7)).Return(nil).Once()
+
+		err := service.DeleteNotification(1, 7)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed case - Not found", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		err := service.DeleteNotification(1, 7)
+
+		assert.EqualError(t, err, "notifikasi tidak ditemukan")
+	})
+
+	t.Run("Failed case - Delete failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetInboxByID", uint64(1), uint64(7)).Return(&entities.FcmModels{ID: 7, UserID: 1}, nil).Once()
+		repo.On("DeleteInbox", uint64(7)).Return(errors.New("db error")).Once()
+
+		err := service.DeleteNotification(1, 7)
+
+		assert.EqualError(t, err, "gagal menghapus notifikasi")
+	})
+}
+
+func TestNotificationService_GetPreferences(t *testing.T) {
+	t.Run("Success case - Stored choices merged with defaults", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return([]*entities.NotificationPreferenceModels{
+			{UserID: 1, EventType: notification.EventPromo, Channel: notification.ChannelEmail, Enabled: true},
+			{UserID: 1, EventType: notification.EventOrderStatus, Channel: notification.ChannelPush, Enabled: false},
+		}, nil).Once()
+
+		preferences, err := service.GetPreferences(1)
+
+		assert.NoError(t, err)
+		assert.Len(t, preferences, len(notification.EventTypes)*len(notification.Channels))
+		enabled := map[string]bool{}
+		for _, preference := range preferences {
+			enabled[preference.EventType+"/"+preference.Channel] = preference.Enabled
+		}
+		assert.True(t, enabled[notification.EventPromo+"/"+notification.ChannelEmail])
+		assert.False(t, enabled[notification.EventOrderStatus+"/"+notification.ChannelPush])
+		assert.True(t, enabled[notification.EventOrderStatus+"/"+notification.ChannelEmail])
+		assert.True(t, enabled[notification.EventChallenge+"/"+notification.ChannelEmail])
+	})
+
+	t.Run("Failed case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("GetPreferences", uint64(1)).Return(nil, errors.New("db error")).Once()
+
+		preferences, err := service.GetPreferences(1)
+
+		assert.EqualError(t, err, "gagal mendapatkan preferensi notifikasi")
+		assert.Nil(t, preferences)
+	})
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	request := &dto.UpdatePreferencesRequest{
+		Preferences: []dto.PreferenceRequest{
+			{EventType: notification.EventPromo, Channel: notification.ChannelPush, Enabled: false},
+		},
+	}
+
+	t.Run("Success case", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("SavePreferences", mock.MatchedBy(func(preferences []*entities.NotificationPreferenceModels) bool {
+			return len(preferences) == 1 && preferences[0].UserID == 1 &&
+				preferences[0].EventType == notification.EventPromo && !preferences[0].Enabled
+		})).Return(nil).Once()
+		repo.On("GetPreferences", uint64(1)).Return([]*entities.NotificationPreferenceModels{
+			{UserID: 1, EventType: notification.EventPromo, Channel: notification.ChannelPush, Enabled: false},
+		}, nil).Once()
+
+		preferences, err := service.UpdatePreferences(1, request)
+
+		assert.NoError(t, err)
+		assert.Len(t, preferences, len(notification.EventTypes)*len(notification.Channels))
+	})
+
+	t.Run("Failed case - Unknown channel", func(t *testing.T) {
+		service, _, _, _, _ := setupNotificationService(t)
+		invalid := &dto.UpdatePreferencesRequest{
+			Preferences: []dto.PreferenceRequest{{EventType: notification.EventPromo, Channel: "sms"}},
+		}
+
+		preferences, err := service.UpdatePreferences(1, invalid)
+
+		assert.EqualError(t, err, "kanal notifikasi tidak valid")
+		assert.Nil(t, preferences)
+	})
+
+	t.Run("Failed case - Unknown event type", func(t *testing.T) {
+		service, _, _, _, _ := setupNotificationService(t)
+		invalid := &dto.UpdatePreferencesRequest{
+			Preferences: []dto.PreferenceRequest{{EventType: "unknown", Channel: notification.ChannelPush}},
+		}
+
+		preferences, err := service.UpdatePreferences(1, invalid)
+
+		assert.EqualError(t, err, "jenis notifikasi tidak valid")
+		assert.Nil(t, preferences)
+	})
+
+	t.Run("Failed case - Save failed", func(t *testing.T) {
+		service, repo, _, _, _ := setupNotificationService(t)
+		repo.On("SavePreferences", mock.Anything).Return(errors.New("db error")).Once()
+
+		preferences, err := service.UpdatePreferences(1, request)
+
+		assert.EqualError(t, err, "gagal menyimpan preferensi notifikasi")
+		assert.Nil(t, preferences)
+	})
+}
@@ -6,20 +6,27 @@ import (
 )
 
 type CreateOrderRequest struct {
-	AddressID     uint64 `form:"address_id" json:"address_id" validate:"required"`
-	VoucherID     uint64 `form:"voucher_id" json:"voucher_id"`
-	Note          string `form:"note" json:"note"`
-	ProductID     uint64 `json:"product_id" validate:"required"`
-	Quantity      uint64 `json:"quantity" validate:"required"`
-	PaymentMethod string `json:"payment_method" validate:"required"`
+	AddressID      uint64 `form:"address_id" json:"address_id" validate:"required"`
+	VoucherID      uint64 `form:"voucher_id" json:"voucher_id"`
+	VoucherCode    string `form:"voucher_code" json:"voucher_code"`
+	Note           string `form:"note" json:"note"`
+	ProductID      uint64 `json:"product_id" validate:"required"`
+	VariantID      uint64 `json:"variant_id"`
+	Quantity       uint64 `json:"quantity" validate:"required"`
+	PaymentMethod  string `json:"payment_method" validate:"required"`
+	Courier        string `json:"courier" validate:"required"`
+	CourierService string `json:"courier_service" validate:"required"`
 }
 
 type CreateOrderCartRequest struct {
-	AddressID     uint64                    `form:"address_id" json:"address_id" validate:"required"`
-	VoucherID     uint64                    `form:"voucher_id" json:"voucher_id"`
-	Note          string                    `form:"note" json:"note"`
-	PaymentMethod string                    `json:"payment_method" validate:"required"`
-	CartItems     []entities.CartItemModels `json:"cart_items" validate:"required"`
+	AddressID      uint64                    `form:"address_id" json:"address_id" validate:"required"`
+	VoucherID      uint64                    `form:"voucher_id" json:"voucher_id"`
+	VoucherCode    string                    `form:"voucher_code" json:"voucher_code"`
+	Note           string                    `form:"note" json:"note"`
+	PaymentMethod  string                    `json:"payment_method" validate:"required"`
+	Courier        string                    `json:"courier" validate:"required"`
+	CourierService string                    `json:"courier_service" validate:"required"`
+	CartItems      []entities.CartItemModels `json:"cart_items" validate:"required"`
 }
 
 type PaymentOrderRequest struct {
@@ -38,6 +45,8 @@ type UpdateOrderStatus struct {
 	StatusOrderDate time.Time `json:"status_order_date"`
 	OrderStatus     string    `json:"order_status"`
 	ExtraInfo       string    `json:"extra_info"`
+	Awb             string    `json:"awb"`
+	Courier         string    `json:"courier"`
 }
 
 type SendNotificationPaymentRequest struct {
@@ -47,7 +56,6 @@ type SendNotificationPaymentRequest struct {
 	UserName      string `json:"user_name"`
 	Title         string `json:"title"`
 	Body          string `json:"body"`
-	Token         string `json:"token"`
 }
 
 type SendNotificationOrderRequest struct {
@@ -57,5 +65,13 @@ type SendNotificationOrderRequest struct {
 	UserName    string `json:"user_name"`
 	Title       string `json:"title"`
 	Body        string `json:"body"`
-	Token       string `json:"token"`
+}
+
+type CreateReturnRequest struct {
+	OrderID string `form:"order_id" json:"order_id" validate:"required"`
+	Reason  string `form:"reason" json:"reason" validate:"required"`
+}
+
+type ReviewReturnRequest struct {
+	Note string `form:"note" json:"note"`
 }
@@ -18,8 +18,11 @@ type OrderResponse struct {
 	GrandTotalQuantity    uint64                `json:"grand_total_quantity"`
 	GrandTotalPrice       uint64                `json:"grand_total_price"`
 	ShipmentFee           uint64                `json:"shipment_fee"`
+	Courier               string                `json:"courier"`
+	CourierService        string                `json:"courier_service"`
 	AdminFees             uint64                `json:"admin_fees"`
 	GrandTotalDiscount    uint64                `json:"grand_total_discount"`
+	VoucherDiscount       uint64                `json:"voucher_discount"`
 	TotalAmountPaid       uint64                `json:"total_amount_paid"`
 	OrderStatus           string                `json:"order_status"`
 	PaymentStatus         string                `json:"payment_status"`
@@ -34,15 +37,26 @@ type OrderResponse struct {
 }
 
 type OrderDetailResponse struct {
-	ID               uint64          `json:"id"`
-	OrderID          string          `json:"order_id"`
-	ProductID        uint64          `json:"product_id"`
-	Quantity         uint64          `json:"quantity"`
-	TotalGramPlastic uint64          `json:"total_gram_plastic"`
-	TotalExp         uint64          `json:"total_exp"`
-	TotalPrice       uint64          `json:"total_price"`
-	TotalDiscount    uint64          `json:"total_discount"`
-	Product          ProductResponse `json:"product,omitempty"`
+	ID               uint64           `json:"id"`
+	OrderID          string           `json:"order_id"`
+	ProductID        uint64           `json:"product_id"`
+	Quantity         uint64           `json:"quantity"`
+	TotalGramPlastic uint64           `json:"total_gram_plastic"`
+	TotalExp         uint64           `json:"total_exp"`
+	TotalPrice       uint64           `json:"total_price"`
+	TotalDiscount    uint64           `json:"total_discount"`
+	Product          ProductResponse  `json:"product,omitempty"`
+	Variant          *VariantResponse `json:"variant,omitempty"`
+}
+
+type VariantResponse struct {
+	ID          uint64 `json:"id"`
+	SKU         string `json:"sku"`
+	Size        string `json:"size"`
+	Color       string `json:"color"`
+	Price       uint64 `json:"price"`
+	Discount    uint64 `json:"discount"`
+	GramPlastic uint64 `json:"gram_plastic"`
 }
 
 type ProductPhotoResponse struct {
@@ -88,6 +102,21 @@ type VoucherResponse struct {
 	MinPurchase uint64 `json:"min_purchase"`
 }
 
+func formatVariant(variant *entities.ProductVariantModels) *VariantResponse {
+	if variant == nil {
+		return nil
+	}
+	return &VariantResponse{
+		ID:          variant.ID,
+		SKU:         variant.SKU,
+		Size:        variant.Size,
+		Color:       variant.Color,
+		Price:       variant.Price,
+		Discount:    variant.Discount,
+		GramPlastic: variant.GramPlastic,
+	}
+}
+
 func FormatOrderDetail(order *entities.OrderModels) OrderResponse {
 	orderResponse := OrderResponse{
 		ID:                    order.ID,
@@ -101,8 +130,11 @@ func FormatOrderDetail(order *entities.OrderModels) OrderResponse {
 		GrandTotalQuantity:    order.GrandTotalQuantity,
 		GrandTotalPrice:       order.GrandTotalPrice,
 		ShipmentFee:           order.ShipmentFee,
+		Courier:               order.Courier,
+		CourierService:        order.CourierService,
 		AdminFees:             order.AdminFees,
 		GrandTotalDiscount:    order.GrandTotalDiscount,
+		VoucherDiscount:       order.VoucherDiscount,
 		TotalAmountPaid:       order.TotalAmountPaid,
 		OrderStatus:           order.OrderStatus,
 		PaymentStatus:         order.PaymentStatus,
@@ -165,6 +197,7 @@ func FormatOrderDetail(order *entities.OrderModels) OrderResponse {
 				ProductExp:    detail.Product.Exp,
 				ProductPhotos: productPhotos,
 			},
+			Variant: formatVariant(detail.Variant),
 		}
 		if len(detail.Product.ProductPhotos) > 0 {
 			productPhoto := ProductPhotoResponse{
@@ -284,8 +317,11 @@ type OrderCreationResponse struct {
 	GrandTotalQuantity    uint64                        `json:"grand_total_quantity"`
 	GrandTotalPrice       uint64                        `json:"grand_total_price"`
 	ShipmentFee           uint64                        `json:"shipment_fee"`
+	Courier               string                        `json:"courier"`
+	CourierService        string                        `json:"courier_service"`
 	AdminFees             uint64                        `json:"admin_fees"`
 	GrandTotalDiscount    uint64                        `json:"grand_total_discount"`
+	VoucherDiscount       uint64                        `json:"voucher_discount"`
 	TotalAmountPaid       uint64                        `json:"total_amount_paid"`
 	OrderStatus           string                        `json:"order_status"`
 	PaymentStatus         string                        `json:"payment_status"`
@@ -298,6 +334,7 @@ type OrderDetailCreationResponse struct {
 	ID               uint64 `json:"id"`
 	OrderID          string `json:"order_id"`
 	ProductID        uint64 `json:"product_id"`
+	VariantID        uint64 `json:"variant_id,omitempty"`
 	Quantity         uint64 `json:"quantity"`
 	TotalGramPlastic uint64 `json:"total_gram_plastic"`
 	TotalExp         uint64 `json:"total_exp"`
@@ -318,8 +355,11 @@ func CreateOrderFormatter(order *entities.OrderModels) OrderCreationResponse {
 		GrandTotalQuantity:    order.GrandTotalQuantity,
 		GrandTotalPrice:       order.GrandTotalPrice,
 		ShipmentFee:           order.ShipmentFee,
+		Courier:               order.Courier,
+		CourierService:        order.CourierService,
 		AdminFees:             order.AdminFees,
 		GrandTotalDiscount:    order.GrandTotalDiscount,
+		VoucherDiscount:       order.VoucherDiscount,
 		TotalAmountPaid:       order.TotalAmountPaid,
 		OrderStatus:           order.OrderStatus,
 		PaymentStatus:         order.PaymentStatus,
@@ -339,6 +379,9 @@ func CreateOrderFormatter(order *entities.OrderModels) OrderCreationResponse {
 			TotalPrice:       detail.TotalPrice,
 			TotalDiscount:    detail.TotalDiscount,
 		}
+		if detail.VariantID != nil {
+			orderDetail.VariantID = *detail.VariantID
+		}
 		orderDetails = append(orderDetails, orderDetail)
 	}
 	if order.VoucherID != nil {
@@ -408,6 +451,7 @@ func FormatGetAllOrderUser(order *entities.OrderModels) *GetAllOrderUserResponse
 				ProductExp:    detail.Product.Exp,
 				ProductPhotos: productPhotos,
 			},
+			Variant: formatVariant(detail.Variant),
 		}
 		if len(detail.Product.ProductPhotos) > 0 {
 			productPhoto := ProductPhotoResponse{
@@ -435,3 +479,163 @@ func FormatterGetAllOrderUser(orders []*entities.OrderModels) []*GetAllOrderUser
 
 	return orderFormatters
 }
+
+// OrderTimelineResponse Respon Order Status Timeline
+type OrderTimelineResponse struct {
+	ID            string                        `json:"id"`
+	IdOrder       string                        `json:"id_order"`
+	OrderStatus   string                        `json:"order_status"`
+	PaymentStatus string                        `json:"payment_status"`
+	Timeline      []*OrderStatusHistoryResponse `json:"timeline"`
+}
+
+type OrderStatusHistoryResponse struct {
+	StatusType string    `json:"status_type"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    uint64    `json:"actor_id"`
+	ActorRole  string    `json:"actor_role"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func FormatOrderTimeline(order *entities.OrderModels, histories []*entities.OrderStatusHistoryModels) *OrderTimelineResponse {
+	timeline := make([]*OrderStatusHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		timeline = append(timeline, &OrderStatusHistoryResponse{
+			StatusType: history.StatusType,
+			FromStatus: history.FromStatus,
+			ToStatus:   history.ToStatus,
+			ActorID:    history.ActorID,
+			ActorRole:  history.ActorRole,
+			Reason:     history.Reason,
+			CreatedAt:  history.CreatedAt,
+		})
+	}
+
+	return &OrderTimelineResponse{
+		ID:            order.ID,
+		IdOrder:       order.IdOrder,
+		OrderStatus:   order.OrderStatus,
+		PaymentStatus: order.PaymentStatus,
+		Timeline:      timeline,
+	}
+}
+
+// OrderReturnResponse Respon Order Return
+type OrderReturnResponse struct {
+	ID          uint64                      `json:"id"`
+	OrderID     string                      `json:"order_id"`
+	UserID      uint64                      `json:"user_id"`
+	Reason      string                      `json:"reason"`
+	Status      string                      `json:"status"`
+	AdminNote   string                      `json:"admin_note"`
+	ProcessedAt *time.Time                  `json:"processed_at"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	User        UserPaginationOrderResponse `json:"user"`
+	Photos      []OrderReturnPhotoResponse  `json:"photos"`
+	Refund      *OrderRefundResponse        `json:"refund"`
+}
+
+type OrderReturnPhotoResponse struct {
+	ID  uint64 `json:"id"`
+	URL string `json:"url"`
+}
+
+type OrderRefundResponse struct {
+	ID           uint64    `json:"id"`
+	Amount       uint64    `json:"amount"`
+	ReversedExp  uint64    `json:"reversed_exp"`
+	ReversedGram uint64    `json:"reversed_gram"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func FormatOrderReturn(orderReturn *entities.OrderReturnModels) *OrderReturnResponse {
+	returnResponse := &OrderReturnResponse{
+		ID:          orderReturn.ID,
+		OrderID:     orderReturn.OrderID,
+		UserID:      orderReturn.UserID,
+		Reason:      orderReturn.Reason,
+		Status:      orderReturn.Status,
+		AdminNote:   orderReturn.AdminNote,
+		ProcessedAt: orderReturn.ProcessedAt,
+		CreatedAt:   orderReturn.CreatedAt,
+		User: UserPaginationOrderResponse{
+			ID:   orderReturn.User.ID,
+			Name: orderReturn.User.Name,
+		},
+	}
+
+	photos := make([]OrderReturnPhotoResponse, 0, len(orderReturn.Photos))
+	for _, photo := range orderReturn.Photos {
+		photos = append(photos, OrderReturnPhotoResponse{
+			ID:  photo.ID,
+			URL: photo.ImageURL,
+		})
+	}
+	returnResponse.Photos = photos
+
+	if orderReturn.Refund != nil {
+		returnResponse.Refund = &OrderRefundResponse{
+			ID:           orderReturn.Refund.ID,
+			Amount:       orderReturn.Refund.Amount,
+			ReversedExp:  orderReturn.Refund.ReversedExp,
+			ReversedGram: orderReturn.Refund.ReversedGram,
+			CreatedAt:    orderReturn.Refund.CreatedAt,
+		}
+	}
+
+	return returnResponse
+}
+
+func FormatterOrderReturn(orderReturns []*entities.OrderReturnModels) []*OrderReturnResponse {
+	var returnFormatters []*OrderReturnResponse
+
+	for _, orderReturn := range orderReturns {
+		formattedReturn := FormatOrderReturn(orderReturn)
+		returnFormatters = append(returnFormatters, formattedReturn)
+	}
+
+	return returnFormatters
+}
+
+type ShipmentEventResponse struct {
+	Date        time.Time `json:"date"`
+	Description string    `json:"description"`
+	Location    string    `json:"location"`
+}
+
+type ShipmentResponse struct {
+	OrderID      string                  `json:"order_id"`
+	Courier      string                  `json:"courier"`
+	Service      string                  `json:"service"`
+	Awb          string                  `json:"awb"`
+	Status       string                  `json:"status"`
+	LastSyncedAt *time.Time              `json:"last_synced_at"`
+	DeliveredAt  *time.Time              `json:"delivered_at"`
+	Events       []ShipmentEventResponse `json:"events"`
+}
+
+func FormatShipment(shipment *entities.ShipmentModels) *ShipmentResponse {
+	shipmentResponse := &ShipmentResponse{
+		OrderID:      shipment.OrderID,
+		Courier:      shipment.Courier,
+		Service:      shipment.Service,
+		Awb:          shipment.Awb,
+		Status:       shipment.Status,
+		LastSyncedAt: shipment.LastSyncedAt,
+		DeliveredAt:  shipment.DeliveredAt,
+	}
+
+	events := make([]ShipmentEventResponse, 0, len(shipment.Events))
+	for _, event := range shipment.Events {
+		events = append(events, ShipmentEventResponse{
+			Date:        event.Date,
+			Description: event.Description,
+			Location:    event.Location,
+		})
+	}
+	shipmentResponse.Events = events
+
+	return shipmentResponse
+}
@@ -0,0 +1,61 @@
+package order
+
+import "time"
+
+const (
+	SortNewest  = "terbaru"
+	SortOldest  = "terlama"
+	SortHighest = "termahal"
+	SortLowest  = "termurah"
+)
+
+const (
+	DefaultSort    = SortNewest
+	DefaultPerPage = 8
+	MaxPerPage     = 50
+	DateLayout     = "2006-01-02"
+)
+
+// OrderFilter describes one order listing. Every field is optional and they
+// all combine with AND. EndDate is exclusive. DateFilter is a preset such as
+// "bulan ini" that the service turns into StartDate and EndDate when neither
+// is set. Search matches the customer name.
+type OrderFilter struct {
+	StartDate     *time.Time
+	EndDate       *time.Time
+	DateFilter    string
+	OrderStatus   string
+	PaymentStatus string
+	PaymentMethod string
+	Search        string
+	UserID        uint64
+	Sort          string
+	Page          int
+	PerPage       int
+}
+
+// OrderSort is the column a sort orders by and its direction. The order ID
+// breaks ties so pages do not overlap.
+type OrderSort struct {
+	Column string
+	Desc   bool
+}
+
+var orderSorts = map[string]OrderSort{
+	SortNewest:  {Column: "created_at", Desc: true},
+	SortOldest:  {Column: "created_at"},
+	SortHighest: {Column: "total_amount_paid", Desc: true},
+	SortLowest:  {Column: "total_amount_paid"},
+}
+
+func GetOrderSort(sort string) (OrderSort, bool) {
+	orderSort, ok := orderSorts[sort]
+	return orderSort, ok
+}
+
+func (f *OrderFilter) Offset() int {
+	if f.Page <= 1 {
+		return 0
+	}
+	return (f.Page - 1) * f.PerPage
+}
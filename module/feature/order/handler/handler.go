@@ -2,13 +2,18 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/payment"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/upload"
 	"github.com/labstack/echo/v4"
 	"strconv"
+	"time"
 )
 
 type OrderHandler struct {
@@ -23,47 +28,24 @@ func NewOrderHandler(service order.ServiceOrderInterface) order.HandlerOrderInte
 
 func (h *OrderHandler) GetAllOrders() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		filter, err := parseOrderFilter(c)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format filter yang Anda masukkan tidak sesuai: "+err.Error())
 		}
-
-		page, _ := strconv.Atoi(c.QueryParam("page"))
-		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
-		perPage := 8
-
-		var orders []*entities.OrderModels
-		var totalItems int64
-		var err error
-
-		search := c.QueryParam("search")
-		dateFilter := c.QueryParam("date_filter")
-		statusFilter := c.QueryParam("status_filter")
-
-		if search != "" && dateFilter != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRangeAndStatusAndSearch(dateFilter, statusFilter, search, page, perPage)
-		} else if search != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrdersBySearchAndStatus(statusFilter, search, page, perPage)
-		} else if search != "" && dateFilter != "" {
-			orders, totalItems, err = h.service.GetOrderBySearchAndDateRange(dateFilter, search, page, perPage)
-		} else if dateFilter != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRangeAndStatus(dateFilter, statusFilter, page, perPage)
-		} else if search != "" {
-			orders, totalItems, err = h.service.GetOrdersByName(page, perPage, search)
-		} else if dateFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRange(dateFilter, page, perPage)
-		} else if statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByOrderStatus(statusFilter, page, perPage)
-		} else {
-			orders, totalItems, err = h.service.GetAll(pageConv, perPage)
+		if filter.OrderStatus == "" {
+			filter.OrderStatus = c.QueryParam("status_filter")
 		}
 
+		orders, totalItems, err := h.service.GetOrders(filter)
 		if err != nil {
 			c.Logger().Error("handler: failed to fetch all orders:", err.Error())
+			if isOrderFilterError(err) {
+				return response.SendBadRequestResponse(c, "Gagal mendapatkan daftar pesanan: "+err.Error())
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar pesanan: ")
 		}
 
-		currentPage, totalPages := h.service.CalculatePaginationValues(pageConv, int(totalItems), perPage)
+		currentPage, totalPages := h.service.CalculatePaginationValues(filter.Page, int(totalItems), filter.PerPage)
 		nextPage := h.service.GetNextPage(currentPage, totalPages)
 		prevPage := h.service.GetPrevPage(currentPage)
 
@@ -88,9 +70,6 @@ func (h *OrderHandler) GetOrderById() echo.HandlerFunc {
 func (h *OrderHandler) CreateOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.CreateOrderRequest)
 		if err := c.Bind(req); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -118,14 +97,11 @@ func (h *OrderHandler) CreateOrder() echo.HandlerFunc {
 func (h *OrderHandler) ConfirmPayment() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		orderID := c.Param("id")
 		if orderID == "" {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
 		}
-		err := h.service.ConfirmPayment(orderID)
+		err := h.service.ConfirmPayment(orderID, order.Actor{ID: currentUser.ID, Role: currentUser.Role})
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mmelakukan pesanan: "+err.Error())
 		}
@@ -136,9 +112,6 @@ func (h *OrderHandler) ConfirmPayment() echo.HandlerFunc {
 func (h *OrderHandler) CreateOrderFromCart() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.CreateOrderCartRequest)
 		if err := c.Bind(req); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -166,14 +139,12 @@ func (h *OrderHandler) CreateOrderFromCart() echo.HandlerFunc {
 func (h *OrderHandler) CancelPayment() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		orderID := c.Param("id")
 		if orderID == "" {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
 		}
-		err := h.service.CancelPayment(orderID)
+		reason := c.FormValue("reason")
+		err := h.service.CancelPayment(orderID, order.Actor{ID: currentUser.ID, Role: currentUser.Role}, reason)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mmelakukan pesanan: "+err.Error())
 		}
@@ -191,6 +162,9 @@ func (h *OrderHandler) Callback() echo.HandlerFunc {
 
 		err := h.service.CallBack(notificationPayload)
 		if err != nil {
+			if errors.Is(err, payment.ErrInvalidSignature) {
+				return response.SendStatusUnauthorizedResponse(c, "Gagal callback pesanan: "+err.Error())
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal callback pesanan: "+err.Error())
 		}
 		return response.SendStatusOkResponse(c, "Berhasil callback")
@@ -200,9 +174,6 @@ func (h *OrderHandler) Callback() echo.HandlerFunc {
 func (h *OrderHandler) UpdateOrderStatus() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		req := new(dto.UpdateOrderStatus)
 		if err := c.Bind(req); err != nil {
@@ -213,7 +184,7 @@ func (h *OrderHandler) UpdateOrderStatus() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
-		if err := h.service.UpdateOrderStatus(req); err != nil {
+		if err := h.service.UpdateOrderStatus(req, order.Actor{ID: currentUser.ID, Role: currentUser.Role}); err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal memperbarui status pesanan: "+err.Error())
 		}
 		return response.SendStatusOkResponse(c, "Berhasil memperbarui status pesanan")
@@ -244,15 +215,15 @@ func (h *OrderHandler) GetAllOrderByUserID() echo.HandlerFunc {
 func (h *OrderHandler) AcceptOrder() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		orderID := c.Param("id")
 		if orderID == "" {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
 		}
-		err := h.service.AcceptOrder(orderID)
+		err := h.service.AcceptOrder(orderID, order.Actor{ID: currentUser.ID, Role: currentUser.Role})
 		if err != nil {
+			if err.Error() == "pesanan tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Gagal mengkonfirmasi pesanan: "+err.Error())
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal mengkonfirmasi pesanan: "+err.Error())
 		}
 		return response.SendStatusOkResponse(c, "Berhasil mengkonfirmasi pesanan")
@@ -261,63 +232,270 @@ func (h *OrderHandler) AcceptOrder() echo.HandlerFunc {
 
 func (h *OrderHandler) Tracking() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		courier := c.QueryParam("courier")
-		awb := c.QueryParam("awb")
-		result, err := h.service.Tracking(courier, awb)
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		orderID := c.QueryParam("order_id")
+		if orderID == "" {
+			return response.SendBadRequestResponse(c, "ID pesanan wajib diisi")
+		}
+
+		orders, err := h.service.GetOrderById(orderID)
+		if err != nil || (!role.HasPermission(c, role.PermissionManageOrders) && orders.UserID != currentUser.ID) {
+			return response.SendStatusNotFoundResponse(c, "Pesanan tidak ditemukan")
+		}
+
+		result, err := h.service.GetShipmentByOrderID(orders.ID)
 		if err != nil {
-			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan resi: "+err.Error())
+			return response.SendStatusNotFoundResponse(c, "Gagal mendapatkan resi: "+err.Error())
 		}
 
-		return response.SendSuccessResponse(c, "Berhasil mendapatkan resi", result)
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan resi", dto.FormatShipment(result))
 	}
 }
 
 func (h *OrderHandler) GetAllPayment() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		filter, err := parseOrderFilter(c)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format filter yang Anda masukkan tidak sesuai: "+err.Error())
+		}
+		if filter.PaymentStatus == "" {
+			filter.PaymentStatus = c.QueryParam("status_filter")
+		}
+
+		orders, totalItems, err := h.service.GetOrders(filter)
+		if err != nil {
+			c.Logger().Error("handler: failed to fetch all orders:", err.Error())
+			if isOrderFilterError(err) {
+				return response.SendBadRequestResponse(c, "Gagal mendapatkan daftar pembayaran: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar pembayaran: ")
+		}
+
+		currentPage, totalPages := h.service.CalculatePaginationValues(filter.Page, int(totalItems), filter.PerPage)
+		nextPage := h.service.GetNextPage(currentPage, totalPages)
+		prevPage := h.service.GetPrevPage(currentPage)
+
+		return response.SendPaginationResponse(c, dto.FormatterOrderPayment(orders), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar pembayaran")
+	}
+}
+
+// parseOrderFilter reads the filters shared by the admin order and payment
+// listings: search, date_filter, start_date, end_date, order_status,
+// payment_status, payment_method, user_id, sort, page and per_page. Dates use
+// YYYY-MM-DD and end_date includes the whole day.
+func parseOrderFilter(c echo.Context) (*order.OrderFilter, error) {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	filter := &order.OrderFilter{
+		DateFilter:    c.QueryParam("date_filter"),
+		OrderStatus:   c.QueryParam("order_status"),
+		PaymentStatus: c.QueryParam("payment_status"),
+		PaymentMethod: c.QueryParam("payment_method"),
+		Search:        c.QueryParam("search"),
+		Sort:          c.QueryParam("sort"),
+		Page:          page,
+		PerPage:       order.DefaultPerPage,
+	}
+
+	if value := c.QueryParam("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage <= 0 {
+			return nil, errors.New("per_page tidak valid")
+		}
+		if perPage > order.MaxPerPage {
+			perPage = order.MaxPerPage
+		}
+		filter.PerPage = perPage
+	}
+
+	if value := c.QueryParam("user_id"); value != "" {
+		userID, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, errors.New("user_id tidak valid")
+		}
+		filter.UserID = userID
+	}
+
+	if value := c.QueryParam("start_date"); value != "" {
+		startDate, err := time.ParseInLocation(order.DateLayout, value, time.Local)
+		if err != nil {
+			return nil, errors.New("start_date tidak valid")
+		}
+		filter.StartDate = &startDate
+	}
+
+	if value := c.QueryParam("end_date"); value != "" {
+		endDate, err := time.ParseInLocation(order.DateLayout, value, time.Local)
+		if err != nil {
+			return nil, errors.New("end_date tidak valid")
+		}
+		endDate = endDate.AddDate(0, 0, 1)
+		filter.EndDate = &endDate
+	}
+
+	return filter, nil
+}
+
+func isOrderFilterError(err error) bool {
+	switch err.Error() {
+	case "tipe filter tidak valid", "urutan pesanan tidak valid", "rentang tanggal tidak valid":
+		return true
+	}
+	return false
+}
+
+func (h *OrderHandler) GetOrderTimeline() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
+		orderID := c.Param("id")
+		if orderID == "" {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+
+		orders, err := h.service.GetOrderById(orderID)
+		if err != nil {
+			return response.SendStatusNotFoundResponse(c, "Pesanan tidak ditemukan")
+		}
+		if !role.HasPermission(c, role.PermissionManageOrders) && orders.UserID != currentUser.ID {
 			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
 		}
 
-		page, _ := strconv.Atoi(c.QueryParam("page"))
-		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
-		perPage := 8
+		histories, err := h.service.GetOrderStatusHistory(orders.ID)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan riwayat status pesanan: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan riwayat status pesanan", dto.FormatOrderTimeline(orders, histories))
+	}
+}
 
-		var orders []*entities.OrderModels
-		var totalItems int64
-		var err error
+const maxReturnPhotos = 5
 
-		search := c.QueryParam("search")
-		dateFilter := c.QueryParam("date_filter")
-		statusFilter := c.QueryParam("status_filter")
-
-		if search != "" && dateFilter != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRangeAndPaymentStatusAndSearch(dateFilter, statusFilter, search, page, perPage)
-		} else if search != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrdersBySearchAndPaymentStatus(statusFilter, search, page, perPage)
-		} else if search != "" && dateFilter != "" {
-			orders, totalItems, err = h.service.GetOrderBySearchAndDateRange(dateFilter, search, page, perPage)
-		} else if dateFilter != "" && statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRangeAndPaymentStatus(dateFilter, statusFilter, page, perPage)
-		} else if search != "" {
-			orders, totalItems, err = h.service.GetOrdersByName(page, perPage, search)
-		} else if dateFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByDateRange(dateFilter, page, perPage)
-		} else if statusFilter != "" {
-			orders, totalItems, err = h.service.GetOrderByPaymentStatus(statusFilter, page, perPage)
-		} else {
-			orders, totalItems, err = h.service.GetAll(pageConv, perPage)
+func (h *OrderHandler) CreateReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+
+		req := new(dto.CreateReturnRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		form, err := c.MultipartForm()
+		if err != nil || len(form.File["photos"]) == 0 {
+			return response.SendBadRequestResponse(c, "Foto bukti pengembalian wajib diunggah")
+		}
+		files := form.File["photos"]
+		if len(files) > maxReturnPhotos {
+			return response.SendBadRequestResponse(c, "Maksimal "+strconv.Itoa(maxReturnPhotos)+" foto bukti pengembalian")
+		}
+
+		photoURLs := make([]string, 0, len(files))
+		for _, file := range files {
+			fileToUpload, err := file.Open()
+			if err != nil {
+				return response.SendStatusInternalServerResponse(c, "Gagal membuka file: "+err.Error())
+			}
+			uploadedURL, err := upload.ImageUploadHelper(fileToUpload)
+			_ = fileToUpload.Close()
+			if err != nil {
+				return response.SendStatusInternalServerResponse(c, "Gagal mengunggah foto: "+err.Error())
+			}
+			photoURLs = append(photoURLs, uploadedURL)
 		}
 
+		result, err := h.service.CreateReturn(currentUser.ID, req, photoURLs)
 		if err != nil {
-			c.Logger().Error("handler: failed to fetch all orders:", err.Error())
-			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar pembayaran: ")
+			return response.SendStatusInternalServerResponse(c, "Gagal mengajukan pengembalian: "+err.Error())
+		}
+		return response.SendStatusCreatedResponse(c, "Berhasil mengajukan pengembalian pesanan", dto.FormatOrderReturn(result))
+	}
+}
+
+func (h *OrderHandler) GetReturns() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		if !role.HasPermission(c, role.PermissionManageOrders) {
+			result, err := h.service.GetReturnsByUserID(currentUser.ID)
+			if err != nil {
+				return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar pengembalian: "+err.Error())
+			}
+			return response.SendSuccessResponse(c, "Berhasil mendapatkan daftar pengembalian", dto.FormatterOrderReturn(result))
 		}
 
-		currentPage, totalPages := h.service.CalculatePaginationValues(pageConv, int(totalItems), perPage)
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		perPage := 8
+		status := c.QueryParam("status")
+
+		result, totalItems, err := h.service.GetReturns(page, perPage, status)
+		if err != nil {
+			c.Logger().Error("handler: failed to fetch all returns:", err.Error())
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar pengembalian")
+		}
+
+		currentPage, totalPages := h.service.CalculatePaginationValues(page, int(totalItems), perPage)
 		nextPage := h.service.GetNextPage(currentPage, totalPages)
 		prevPage := h.service.GetPrevPage(currentPage)
 
-		return response.SendPaginationResponse(c, dto.FormatterOrderPayment(orders), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar pembayaran")
+		return response.SendPaginationResponse(c, dto.FormatterOrderReturn(result), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar pengembalian")
+	}
+}
+
+func (h *OrderHandler) GetReturnById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+
+		result, err := h.service.GetReturnById(returnID)
+		if err != nil {
+			return response.SendStatusNotFoundResponse(c, "Pengajuan pengembalian tidak ditemukan")
+		}
+		if !role.HasPermission(c, role.PermissionManageOrders) && result.UserID != currentUser.ID {
+			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		}
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan detail pengembalian", dto.FormatOrderReturn(result))
+	}
+}
+
+func (h *OrderHandler) ApproveReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.ReviewReturnRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		err = h.service.ApproveReturn(returnID, order.Actor{ID: currentUser.ID, Role: currentUser.Role}, req.Note)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal menyetujui pengembalian: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil menyetujui pengembalian pesanan")
+	}
+}
+
+func (h *OrderHandler) RejectReturn() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		returnID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.ReviewReturnRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		err = h.service.RejectReturn(returnID, order.Actor{ID: currentUser.ID, Role: currentUser.Role}, req.Note)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal menolak pengembalian: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil menolak pengembalian pesanan")
 	}
 }
@@ -4,77 +4,70 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 	"time"
 )
 
 type RepositoryOrderInterface interface {
-	FindByName(page, perPage int, name string) ([]*entities.OrderModels, error)
-	GetTotalCustomerCountByName(name string) (int64, error)
-	FindAll(page, perPage int) ([]*entities.OrderModels, error)
-	GetTotalOrderCount() (int64, error)
+	WithTx(tx *gorm.DB) RepositoryOrderInterface
 	GetOrderById(orderID string) (*entities.OrderModels, error)
+	LockOrder(orderID string) (*entities.OrderModels, error)
+	GetUnpaidOrdersBefore(deadline time.Time, limit int) ([]*entities.OrderModels, error)
 	CreateOrder(newOrder *entities.OrderModels) (*entities.OrderModels, error)
 	ConfirmPayment(orderID string, orderStatus, paymentStatus string) error
-	ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod, name, email string) (interface{}, error)
-	CheckTransaction(orderID string) (dto.Status, error)
 	UpdateOrderStatus(req *dto.UpdateOrderStatus) error
 	GetAllOrdersByUserID(userID uint64) ([]*entities.OrderModels, error)
 	GetAllOrdersWithFilter(userID uint64, orderStatus string) ([]*entities.OrderModels, error)
 	AcceptOrder(orderID, orderStatus string) error
-	Tracking(courier, awb string) (map[string]interface{}, error)
-	GetOrderByDateRange(startDate, endDate time.Time, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByDateRange(startDate, endDate time.Time) (int64, error)
-	GetOrderByOrderStatus(orderStatus string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByByOrderStatus(orderStatus string) (int64, error)
-	GetOrderByDateRangeAndStatus(startDate, endDate time.Time, status string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByDateRangeAndStatus(startDate, endDate time.Time, status string) (int64, error)
-	GetOrderByDateRangeAndStatusAndSearch(startDate, endDate time.Time, status, search string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByDateRangeAndStatusAndSearch(startDate, endDate time.Time, status, search string) (int64, error)
-	GetOrdersBySearchAndDateRange(startDate, endDate time.Time, search string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountBySearchAndDateRange(startDate, endDate time.Time, search string) (int64, error)
-	GetOrdersBySearchAndStatus(status, search string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrdersCountBySearchAndStatus(status, search string) (int64, error)
-	GetOrderBySearchAndPaymentStatus(status, search string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountBySearchAndPaymentStatus(status, search string) (int64, error)
-	GetOrderByDateRangeAndPaymentStatusAndSearch(startDate, endDate time.Time, status, search string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByDateRangeAndPaymentStatusAndSearch(startDate, endDate time.Time, status, search string) (int64, error)
-	GetOrderByPaymentStatus(orderStatus string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByByPaymentStatus(orderStatus string) (int64, error)
-	GetOrderByDateRangeAndPaymentStatus(startDate, endDate time.Time, status string, offset, limit int) ([]*entities.OrderModels, error)
-	GetOrderCountByDateRangeAndPaymentStatus(startDate, endDate time.Time, status string) (int64, error)
+	CreateStatusHistory(history *entities.OrderStatusHistoryModels) error
+	GetStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error)
+	GetWebhookLog(idempotencyKey string) (*entities.PaymentWebhookLogModels, error)
+	SaveWebhookLog(webhookLog *entities.PaymentWebhookLogModels) error
+	CreateReturn(orderReturn *entities.OrderReturnModels) (*entities.OrderReturnModels, error)
+	GetReturnById(returnID uint64) (*entities.OrderReturnModels, error)
+	LockReturn(returnID uint64) (*entities.OrderReturnModels, error)
+	GetActiveReturnByOrderID(orderID string) (*entities.OrderReturnModels, error)
+	FindAllReturns(page, perPage int, status string) ([]*entities.OrderReturnModels, error)
+	GetTotalReturnCount(status string) (int64, error)
+	GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error)
+	UpdateReturnStatus(returnID uint64, status, note string, processedBy uint64) error
+	CreateRefund(refund *entities.OrderRefundModels) error
+	CreateShipment(shipment *entities.ShipmentModels) error
+	GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error)
+	GetShipmentsInTransit(limit int) ([]*entities.ShipmentModels, error)
+	UpdateShipmentTracking(shipment *entities.ShipmentModels, events []entities.ShipmentEventModels) error
+	FindOrders(filter *OrderFilter) ([]*entities.OrderModels, int64, error)
 }
 
 type ServiceOrderInterface interface {
-	GetAll(page, perPage int) ([]*entities.OrderModels, int64, error)
 	CalculatePaginationValues(page int, totalItems int, perPage int) (int, int)
 	GetNextPage(currentPage, totalPages int) int
 	GetPrevPage(currentPage int) int
-	GetOrdersByName(page, perPage int, name string) ([]*entities.OrderModels, int64, error)
 	GetOrderById(orderID string) (*entities.OrderModels, error)
 	CreateOrder(userID uint64, request *dto.CreateOrderRequest) (interface{}, error)
-	ConfirmPayment(orderID string) error
+	ConfirmPayment(orderID string, actor Actor) error
 	CreateOrderFromCart(userID uint64, request *dto.CreateOrderCartRequest) (interface{}, error)
-	CancelPayment(orderID string) error
+	CancelPayment(orderID string, actor Actor, reason string) error
+	ExpireUnpaidOrders(deadline time.Time) (int, error)
 	CallBack(notifPayload map[string]any) error
-	UpdateOrderStatus(req *dto.UpdateOrderStatus) error
+	UpdateOrderStatus(req *dto.UpdateOrderStatus, actor Actor) error
 	GetAllOrdersByUserID(userID uint64) ([]*entities.OrderModels, error)
 	GetAllOrdersWithFilter(userID uint64, orderStatus string) ([]*entities.OrderModels, error)
-	AcceptOrder(orderID string) error
-	Tracking(courier, awb string) (map[string]interface{}, error)
-	GetOrderByDateRange(filterType string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByOrderStatus(orderStatus string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByDateRangeAndStatus(filterType, status string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByDateRangeAndStatusAndSearch(filterType, status, search string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderBySearchAndDateRange(filterType, search string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrdersBySearchAndStatus(status, search string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrdersBySearchAndPaymentStatus(status, search string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByDateRangeAndPaymentStatusAndSearch(filterType, status, search string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByDateRangeAndPaymentStatus(filterType, status string, page, perPage int) ([]*entities.OrderModels, int64, error)
-	GetOrderByPaymentStatus(orderStatus string, page, perPage int) ([]*entities.OrderModels, int64, error)
+	AcceptOrder(orderID string, actor Actor) error
+	GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error)
+	SyncShipments() (int, error)
+	GetOrderStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error)
+	GetOrders(filter *OrderFilter) ([]*entities.OrderModels, int64, error)
 	ProcessManualPayment(orderID string) (*entities.OrderModels, error)
 	ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod, name, email string) (interface{}, error)
 	SendNotificationOrder(request dto.SendNotificationOrderRequest) (string, error)
 	SendNotificationPayment(request dto.SendNotificationPaymentRequest) (string, error)
+	CreateReturn(userID uint64, request *dto.CreateReturnRequest, photoURLs []string) (*entities.OrderReturnModels, error)
+	GetReturns(page, perPage int, status string) ([]*entities.OrderReturnModels, int64, error)
+	GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error)
+	GetReturnById(returnID uint64) (*entities.OrderReturnModels, error)
+	ApproveReturn(returnID uint64, actor Actor, note string) error
+	RejectReturn(returnID uint64, actor Actor, note string) error
 }
 
 type HandlerOrderInterface interface {
@@ -90,4 +83,10 @@ type HandlerOrderInterface interface {
 	AcceptOrder() echo.HandlerFunc
 	Tracking() echo.HandlerFunc
 	GetAllPayment() echo.HandlerFunc
+	GetOrderTimeline() echo.HandlerFunc
+	CreateReturn() echo.HandlerFunc
+	GetReturns() echo.HandlerFunc
+	GetReturnById() echo.HandlerFunc
+	ApproveReturn() echo.HandlerFunc
+	RejectReturn() echo.HandlerFunc
 }
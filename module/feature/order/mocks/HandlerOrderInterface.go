@@ -28,6 +28,22 @@ func (_m *HandlerOrderInterface) AcceptOrder() echo.HandlerFunc {
 	return r0
 }
 
+// ApproveReturn provides a mock function with given fields:
+func (_m *HandlerOrderInterface) ApproveReturn() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Callback provides a mock function with given fields:
 func (_m *HandlerOrderInterface) Callback() echo.HandlerFunc {
 	ret := _m.Called()
@@ -108,6 +124,22 @@ func (_m *HandlerOrderInterface) CreateOrderFromCart() echo.HandlerFunc {
 	return r0
 }
 
+// CreateReturn provides a mock function with given fields:
+func (_m *HandlerOrderInterface) CreateReturn() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetAllOrderByUserID provides a mock function with given fields:
 func (_m *HandlerOrderInterface) GetAllOrderByUserID() echo.HandlerFunc {
 	ret := _m.Called()
@@ -172,6 +204,70 @@ func (_m *HandlerOrderInterface) GetOrderById() echo.HandlerFunc {
 	return r0
 }
 
+// GetOrderTimeline provides a mock function with given fields:
+func (_m *HandlerOrderInterface) GetOrderTimeline() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// GetReturnById provides a mock function with given fields:
+func (_m *HandlerOrderInterface) GetReturnById() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// GetReturns provides a mock function with given fields:
+func (_m *HandlerOrderInterface) GetReturns() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// RejectReturn provides a mock function with given fields:
+func (_m *HandlerOrderInterface) RejectReturn() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // Tracking provides a mock function with given fields:
 func (_m *HandlerOrderInterface) Tracking() echo.HandlerFunc {
 	ret := _m.Called()
@@ -3,12 +3,13 @@
 package mocks
 
 import (
+	time "time"
+
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	order "github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
-
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
+	gorm "gorm.io/gorm"
 )
 
 // RepositoryOrderInterface is an autogenerated mock type for the RepositoryOrderInterface type
@@ -30,30 +31,6 @@ func (_m *RepositoryOrderInterface) AcceptOrder(orderID string, orderStatus stri
 	return r0
 }
 
-// CheckTransaction provides a mock function with given fields: orderID
-func (_m *RepositoryOrderInterface) CheckTransaction(orderID string) (dto.Status, error) {
-	ret := _m.Called(orderID)
-
-	var r0 dto.Status
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (dto.Status, error)); ok {
-		return rf(orderID)
-	}
-	if rf, ok := ret.Get(0).(func(string) dto.Status); ok {
-		r0 = rf(orderID)
-	} else {
-		r0 = ret.Get(0).(dto.Status)
-	}
-
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(orderID)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ConfirmPayment provides a mock function with given fields: orderID, orderStatus, paymentStatus
 func (_m *RepositoryOrderInterface) ConfirmPayment(orderID string, orderStatus string, paymentStatus string) error {
 	ret := _m.Called(orderID, orderStatus, paymentStatus)
@@ -94,51 +71,39 @@ func (_m *RepositoryOrderInterface) CreateOrder(newOrder *entities.OrderModels)
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields: page, perPage
-func (_m *RepositoryOrderInterface) FindAll(page int, perPage int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(int, int) []*entities.OrderModels); ok {
-		r0 = rf(page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
+// CreateRefund provides a mock function with given fields: refund
+func (_m *RepositoryOrderInterface) CreateRefund(refund *entities.OrderRefundModels) error {
+	ret := _m.Called(refund)
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(page, perPage)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.OrderRefundModels) error); ok {
+		r0 = rf(refund)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// FindByName provides a mock function with given fields: page, perPage, name
-func (_m *RepositoryOrderInterface) FindByName(page int, perPage int, name string) ([]*entities.OrderModels, error) {
-	ret := _m.Called(page, perPage, name)
+// CreateReturn provides a mock function with given fields: orderReturn
+func (_m *RepositoryOrderInterface) CreateReturn(orderReturn *entities.OrderReturnModels) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(orderReturn)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.OrderModels, error)); ok {
-		return rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(*entities.OrderReturnModels) (*entities.OrderReturnModels, error)); ok {
+		return rf(orderReturn)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.OrderModels); ok {
-		r0 = rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(*entities.OrderReturnModels) *entities.OrderReturnModels); ok {
+		r0 = rf(orderReturn)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
-		r1 = rf(page, perPage, name)
+	if rf, ok := ret.Get(1).(func(*entities.OrderReturnModels) error); ok {
+		r1 = rf(orderReturn)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -146,77 +111,53 @@ func (_m *RepositoryOrderInterface) FindByName(page int, perPage int, name strin
 	return r0, r1
 }
 
-// GetAllOrdersByUserID provides a mock function with given fields: userID
-func (_m *RepositoryOrderInterface) GetAllOrdersByUserID(userID uint64) ([]*entities.OrderModels, error) {
-	ret := _m.Called(userID)
-
-	var r0 []*entities.OrderModels
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.OrderModels, error)); ok {
-		return rf(userID)
-	}
-	if rf, ok := ret.Get(0).(func(uint64) []*entities.OrderModels); ok {
-		r0 = rf(userID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
+// CreateShipment provides a mock function with given fields: shipment
+func (_m *RepositoryOrderInterface) CreateShipment(shipment *entities.ShipmentModels) error {
+	ret := _m.Called(shipment)
 
-	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(userID)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.ShipmentModels) error); ok {
+		r0 = rf(shipment)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetAllOrdersWithFilter provides a mock function with given fields: userID, orderStatus
-func (_m *RepositoryOrderInterface) GetAllOrdersWithFilter(userID uint64, orderStatus string) ([]*entities.OrderModels, error) {
-	ret := _m.Called(userID, orderStatus)
-
-	var r0 []*entities.OrderModels
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, string) ([]*entities.OrderModels, error)); ok {
-		return rf(userID, orderStatus)
-	}
-	if rf, ok := ret.Get(0).(func(uint64, string) []*entities.OrderModels); ok {
-		r0 = rf(userID, orderStatus)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
+// CreateStatusHistory provides a mock function with given fields: history
+func (_m *RepositoryOrderInterface) CreateStatusHistory(history *entities.OrderStatusHistoryModels) error {
+	ret := _m.Called(history)
 
-	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
-		r1 = rf(userID, orderStatus)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.OrderStatusHistoryModels) error); ok {
+		r0 = rf(history)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetOrderByDateRange provides a mock function with given fields: startDate, endDate, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByDateRange(startDate time.Time, endDate time.Time, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, offset, limit)
+// FindAllReturns provides a mock function with given fields: page, perPage, status
+func (_m *RepositoryOrderInterface) FindAllReturns(page int, perPage int, status string) ([]*entities.OrderReturnModels, error) {
+	ret := _m.Called(page, perPage, status)
 
-	var r0 []*entities.OrderModels
+	var r0 []*entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, offset, limit)
+	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.OrderReturnModels, error)); ok {
+		return rf(page, perPage, status)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, offset, limit)
+	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.OrderReturnModels); ok {
+		r0 = rf(page, perPage, status)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).([]*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, int, int) error); ok {
-		r1 = rf(startDate, endDate, offset, limit)
+	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
+		r1 = rf(page, perPage, status)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -224,51 +165,58 @@ func (_m *RepositoryOrderInterface) GetOrderByDateRange(startDate time.Time, end
 	return r0, r1
 }
 
-// GetOrderByDateRangeAndPaymentStatus provides a mock function with given fields: startDate, endDate, status, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndPaymentStatus(startDate time.Time, endDate time.Time, status string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, status, offset, limit)
+// FindOrders provides a mock function with given fields: filter
+func (_m *RepositoryOrderInterface) FindOrders(filter *order.OrderFilter) ([]*entities.OrderModels, int64, error) {
+	ret := _m.Called(filter)
 
 	var r0 []*entities.OrderModels
-	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, status, offset, limit)
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*order.OrderFilter) ([]*entities.OrderModels, int64, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, status, offset, limit)
+	if rf, ok := ret.Get(0).(func(*order.OrderFilter) []*entities.OrderModels); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.OrderModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, int, int) error); ok {
-		r1 = rf(startDate, endDate, status, offset, limit)
+	if rf, ok := ret.Get(1).(func(*order.OrderFilter) int64); ok {
+		r1 = rf(filter)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(*order.OrderFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetOrderByDateRangeAndPaymentStatusAndSearch provides a mock function with given fields: startDate, endDate, status, search, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndPaymentStatusAndSearch(startDate time.Time, endDate time.Time, status string, search string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, status, search, offset, limit)
+// GetActiveReturnByOrderID provides a mock function with given fields: orderID
+func (_m *RepositoryOrderInterface) GetActiveReturnByOrderID(orderID string) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) (*entities.OrderReturnModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) *entities.OrderReturnModels); ok {
+		r0 = rf(orderID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, string, int, int) error); ok {
-		r1 = rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -276,25 +224,25 @@ func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndPaymentStatusAndSearch
 	return r0, r1
 }
 
-// GetOrderByDateRangeAndStatus provides a mock function with given fields: startDate, endDate, status, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndStatus(startDate time.Time, endDate time.Time, status string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, status, offset, limit)
+// GetAllOrdersByUserID provides a mock function with given fields: userID
+func (_m *RepositoryOrderInterface) GetAllOrdersByUserID(userID uint64) ([]*entities.OrderModels, error) {
+	ret := _m.Called(userID)
 
 	var r0 []*entities.OrderModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, status, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.OrderModels, error)); ok {
+		return rf(userID)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, status, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.OrderModels); ok {
+		r0 = rf(userID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.OrderModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, int, int) error); ok {
-		r1 = rf(startDate, endDate, status, offset, limit)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -302,25 +250,25 @@ func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndStatus(startDate time.
 	return r0, r1
 }
 
-// GetOrderByDateRangeAndStatusAndSearch provides a mock function with given fields: startDate, endDate, status, search, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByDateRangeAndStatusAndSearch(startDate time.Time, endDate time.Time, status string, search string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, status, search, offset, limit)
+// GetAllOrdersWithFilter provides a mock function with given fields: userID, orderStatus
+func (_m *RepositoryOrderInterface) GetAllOrdersWithFilter(userID uint64, orderStatus string) ([]*entities.OrderModels, error) {
+	ret := _m.Called(userID, orderStatus)
 
 	var r0 []*entities.OrderModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64, string) ([]*entities.OrderModels, error)); ok {
+		return rf(userID, orderStatus)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64, string) []*entities.OrderModels); ok {
+		r0 = rf(userID, orderStatus)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.OrderModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, string, int, int) error); ok {
-		r1 = rf(startDate, endDate, status, search, offset, limit)
+	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
+		r1 = rf(userID, orderStatus)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -354,25 +302,25 @@ func (_m *RepositoryOrderInterface) GetOrderById(orderID string) (*entities.Orde
 	return r0, r1
 }
 
-// GetOrderByOrderStatus provides a mock function with given fields: orderStatus, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByOrderStatus(orderStatus string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(orderStatus, offset, limit)
+// GetReturnById provides a mock function with given fields: returnID
+func (_m *RepositoryOrderInterface) GetReturnById(returnID uint64) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(returnID)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.OrderReturnModels, error)); ok {
+		return rf(returnID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) *entities.OrderReturnModels); ok {
+		r0 = rf(returnID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(returnID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -380,25 +328,25 @@ func (_m *RepositoryOrderInterface) GetOrderByOrderStatus(orderStatus string, of
 	return r0, r1
 }
 
-// GetOrderByPaymentStatus provides a mock function with given fields: orderStatus, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderByPaymentStatus(orderStatus string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(orderStatus, offset, limit)
+// GetReturnsByUserID provides a mock function with given fields: userID
+func (_m *RepositoryOrderInterface) GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error) {
+	ret := _m.Called(userID)
 
-	var r0 []*entities.OrderModels
+	var r0 []*entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.OrderReturnModels, error)); ok {
+		return rf(userID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.OrderReturnModels); ok {
+		r0 = rf(userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).([]*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(orderStatus, offset, limit)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -406,121 +354,25 @@ func (_m *RepositoryOrderInterface) GetOrderByPaymentStatus(orderStatus string,
 	return r0, r1
 }
 
-// GetOrderBySearchAndPaymentStatus provides a mock function with given fields: status, search, offset, limit
-func (_m *RepositoryOrderInterface) GetOrderBySearchAndPaymentStatus(status string, search string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(status, search, offset, limit)
+// GetShipmentByOrderID provides a mock function with given fields: orderID
+func (_m *RepositoryOrderInterface) GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.ShipmentModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) (*entities.ShipmentModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) *entities.ShipmentModels); ok {
+		r0 = rf(orderID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.ShipmentModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
-		r1 = rf(status, search, offset, limit)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetOrderCountByByOrderStatus provides a mock function with given fields: orderStatus
-func (_m *RepositoryOrderInterface) GetOrderCountByByOrderStatus(orderStatus string) (int64, error) {
-	ret := _m.Called(orderStatus)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return rf(orderStatus)
-	}
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(orderStatus)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(orderStatus)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetOrderCountByByPaymentStatus provides a mock function with given fields: orderStatus
-func (_m *RepositoryOrderInterface) GetOrderCountByByPaymentStatus(orderStatus string) (int64, error) {
-	ret := _m.Called(orderStatus)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return rf(orderStatus)
-	}
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(orderStatus)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(orderStatus)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetOrderCountByDateRange provides a mock function with given fields: startDate, endDate
-func (_m *RepositoryOrderInterface) GetOrderCountByDateRange(startDate time.Time, endDate time.Time) (int64, error) {
-	ret := _m.Called(startDate, endDate)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) (int64, error)); ok {
-		return rf(startDate, endDate)
-	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time) int64); ok {
-		r0 = rf(startDate, endDate)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time) error); ok {
-		r1 = rf(startDate, endDate)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetOrderCountByDateRangeAndPaymentStatus provides a mock function with given fields: startDate, endDate, status
-func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndPaymentStatus(startDate time.Time, endDate time.Time, status string) (int64, error) {
-	ret := _m.Called(startDate, endDate, status)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) (int64, error)); ok {
-		return rf(startDate, endDate, status)
-	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) int64); ok {
-		r0 = rf(startDate, endDate, status)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
-		r1 = rf(startDate, endDate, status)
+		r1 = rf(orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -528,23 +380,25 @@ func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndPaymentStatus(sta
 	return r0, r1
 }
 
-// GetOrderCountByDateRangeAndPaymentStatusAndSearch provides a mock function with given fields: startDate, endDate, status, search
-func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndPaymentStatusAndSearch(startDate time.Time, endDate time.Time, status string, search string) (int64, error) {
-	ret := _m.Called(startDate, endDate, status, search)
+// GetShipmentsInTransit provides a mock function with given fields: limit
+func (_m *RepositoryOrderInterface) GetShipmentsInTransit(limit int) ([]*entities.ShipmentModels, error) {
+	ret := _m.Called(limit)
 
-	var r0 int64
+	var r0 []*entities.ShipmentModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string) (int64, error)); ok {
-		return rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(0).(func(int) ([]*entities.ShipmentModels, error)); ok {
+		return rf(limit)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string) int64); ok {
-		r0 = rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(0).(func(int) []*entities.ShipmentModels); ok {
+		r0 = rf(limit)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ShipmentModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, string) error); ok {
-		r1 = rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -552,23 +406,25 @@ func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndPaymentStatusAndS
 	return r0, r1
 }
 
-// GetOrderCountByDateRangeAndStatus provides a mock function with given fields: startDate, endDate, status
-func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndStatus(startDate time.Time, endDate time.Time, status string) (int64, error) {
-	ret := _m.Called(startDate, endDate, status)
+// GetStatusHistory provides a mock function with given fields: orderID
+func (_m *RepositoryOrderInterface) GetStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 int64
+	var r0 []*entities.OrderStatusHistoryModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) (int64, error)); ok {
-		return rf(startDate, endDate, status)
+	if rf, ok := ret.Get(0).(func(string) ([]*entities.OrderStatusHistoryModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) int64); ok {
-		r0 = rf(startDate, endDate, status)
+	if rf, ok := ret.Get(0).(func(string) []*entities.OrderStatusHistoryModels); ok {
+		r0 = rf(orderID)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.OrderStatusHistoryModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
-		r1 = rf(startDate, endDate, status)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -576,23 +432,23 @@ func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndStatus(startDate
 	return r0, r1
 }
 
-// GetOrderCountByDateRangeAndStatusAndSearch provides a mock function with given fields: startDate, endDate, status, search
-func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndStatusAndSearch(startDate time.Time, endDate time.Time, status string, search string) (int64, error) {
-	ret := _m.Called(startDate, endDate, status, search)
+// GetTotalReturnCount provides a mock function with given fields: status
+func (_m *RepositoryOrderInterface) GetTotalReturnCount(status string) (int64, error) {
+	ret := _m.Called(status)
 
 	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string) (int64, error)); ok {
-		return rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
+		return rf(status)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, string) int64); ok {
-		r0 = rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(status)
 	} else {
 		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, string) error); ok {
-		r1 = rf(startDate, endDate, status, search)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -600,23 +456,25 @@ func (_m *RepositoryOrderInterface) GetOrderCountByDateRangeAndStatusAndSearch(s
 	return r0, r1
 }
 
-// GetOrderCountBySearchAndDateRange provides a mock function with given fields: startDate, endDate, search
-func (_m *RepositoryOrderInterface) GetOrderCountBySearchAndDateRange(startDate time.Time, endDate time.Time, search string) (int64, error) {
-	ret := _m.Called(startDate, endDate, search)
+// GetUnpaidOrdersBefore provides a mock function with given fields: deadline, limit
+func (_m *RepositoryOrderInterface) GetUnpaidOrdersBefore(deadline time.Time, limit int) ([]*entities.OrderModels, error) {
+	ret := _m.Called(deadline, limit)
 
-	var r0 int64
+	var r0 []*entities.OrderModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) (int64, error)); ok {
-		return rf(startDate, endDate, search)
+	if rf, ok := ret.Get(0).(func(time.Time, int) ([]*entities.OrderModels, error)); ok {
+		return rf(deadline, limit)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string) int64); ok {
-		r0 = rf(startDate, endDate, search)
+	if rf, ok := ret.Get(0).(func(time.Time, int) []*entities.OrderModels); ok {
+		r0 = rf(deadline, limit)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.OrderModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string) error); ok {
-		r1 = rf(startDate, endDate, search)
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(deadline, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -624,23 +482,25 @@ func (_m *RepositoryOrderInterface) GetOrderCountBySearchAndDateRange(startDate
 	return r0, r1
 }
 
-// GetOrderCountBySearchAndPaymentStatus provides a mock function with given fields: status, search
-func (_m *RepositoryOrderInterface) GetOrderCountBySearchAndPaymentStatus(status string, search string) (int64, error) {
-	ret := _m.Called(status, search)
+// GetWebhookLog provides a mock function with given fields: idempotencyKey
+func (_m *RepositoryOrderInterface) GetWebhookLog(idempotencyKey string) (*entities.PaymentWebhookLogModels, error) {
+	ret := _m.Called(idempotencyKey)
 
-	var r0 int64
+	var r0 *entities.PaymentWebhookLogModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return rf(status, search)
+	if rf, ok := ret.Get(0).(func(string) (*entities.PaymentWebhookLogModels, error)); ok {
+		return rf(idempotencyKey)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(status, search)
+	if rf, ok := ret.Get(0).(func(string) *entities.PaymentWebhookLogModels); ok {
+		r0 = rf(idempotencyKey)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.PaymentWebhookLogModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(status, search)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(idempotencyKey)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -648,25 +508,25 @@ func (_m *RepositoryOrderInterface) GetOrderCountBySearchAndPaymentStatus(status
 	return r0, r1
 }
 
-// GetOrdersBySearchAndDateRange provides a mock function with given fields: startDate, endDate, search, offset, limit
-func (_m *RepositoryOrderInterface) GetOrdersBySearchAndDateRange(startDate time.Time, endDate time.Time, search string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(startDate, endDate, search, offset, limit)
+// LockOrder provides a mock function with given fields: orderID
+func (_m *RepositoryOrderInterface) LockOrder(orderID string) (*entities.OrderModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.OrderModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(startDate, endDate, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) (*entities.OrderModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(time.Time, time.Time, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(startDate, endDate, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(string) *entities.OrderModels); ok {
+		r0 = rf(orderID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(time.Time, time.Time, string, int, int) error); ok {
-		r1 = rf(startDate, endDate, search, offset, limit)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -674,25 +534,25 @@ func (_m *RepositoryOrderInterface) GetOrdersBySearchAndDateRange(startDate time
 	return r0, r1
 }
 
-// GetOrdersBySearchAndStatus provides a mock function with given fields: status, search, offset, limit
-func (_m *RepositoryOrderInterface) GetOrdersBySearchAndStatus(status string, search string, offset int, limit int) ([]*entities.OrderModels, error) {
-	ret := _m.Called(status, search, offset, limit)
+// LockReturn provides a mock function with given fields: returnID
+func (_m *RepositoryOrderInterface) LockReturn(returnID uint64) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(returnID)
 
-	var r0 []*entities.OrderModels
+	var r0 *entities.OrderReturnModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, error)); ok {
-		return rf(status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.OrderReturnModels, error)); ok {
+		return rf(returnID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(status, search, offset, limit)
+	if rf, ok := ret.Get(0).(func(uint64) *entities.OrderReturnModels); ok {
+		r0 = rf(returnID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
-		r1 = rf(status, search, offset, limit)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(returnID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -700,141 +560,75 @@ func (_m *RepositoryOrderInterface) GetOrdersBySearchAndStatus(status string, se
 	return r0, r1
 }
 
-// GetOrdersCountBySearchAndStatus provides a mock function with given fields: status, search
-func (_m *RepositoryOrderInterface) GetOrdersCountBySearchAndStatus(status string, search string) (int64, error) {
-	ret := _m.Called(status, search)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return rf(status, search)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(status, search)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
+// SaveWebhookLog provides a mock function with given fields: webhookLog
+func (_m *RepositoryOrderInterface) SaveWebhookLog(webhookLog *entities.PaymentWebhookLogModels) error {
+	ret := _m.Called(webhookLog)
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(status, search)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.PaymentWebhookLogModels) error); ok {
+		r0 = rf(webhookLog)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetTotalCustomerCountByName provides a mock function with given fields: name
-func (_m *RepositoryOrderInterface) GetTotalCustomerCountByName(name string) (int64, error) {
-	ret := _m.Called(name)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return rf(name)
-	}
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(name)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
+// UpdateOrderStatus provides a mock function with given fields: req
+func (_m *RepositoryOrderInterface) UpdateOrderStatus(req *dto.UpdateOrderStatus) error {
+	ret := _m.Called(req)
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*dto.UpdateOrderStatus) error); ok {
+		r0 = rf(req)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// GetTotalOrderCount provides a mock function with given fields:
-func (_m *RepositoryOrderInterface) GetTotalOrderCount() (int64, error) {
-	ret := _m.Called()
+// UpdateReturnStatus provides a mock function with given fields: returnID, status, note, processedBy
+func (_m *RepositoryOrderInterface) UpdateReturnStatus(returnID uint64, status string, note string, processedBy uint64) error {
+	ret := _m.Called(returnID, status, note, processedBy)
 
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
-		return rf()
-	}
-	if rf, ok := ret.Get(0).(func() int64); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string, uint64) error); ok {
+		r0 = rf(returnID, status, note, processedBy)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// ProcessGatewayPayment provides a mock function with given fields: totalAmountPaid, orderID, paymentMethod
-func (_m *RepositoryOrderInterface) ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod string) (interface{}, error) {
-	ret := _m.Called(totalAmountPaid, orderID, paymentMethod)
-
-	var r0 interface{}
-	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, string, string) (interface{}, error)); ok {
-		return rf(totalAmountPaid, orderID, paymentMethod)
-	}
-	if rf, ok := ret.Get(0).(func(uint64, string, string) interface{}); ok {
-		r0 = rf(totalAmountPaid, orderID, paymentMethod)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
-	}
+// UpdateShipmentTracking provides a mock function with given fields: shipment, events
+func (_m *RepositoryOrderInterface) UpdateShipmentTracking(shipment *entities.ShipmentModels, events []entities.ShipmentEventModels) error {
+	ret := _m.Called(shipment, events)
 
-	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
-		r1 = rf(totalAmountPaid, orderID, paymentMethod)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.ShipmentModels, []entities.ShipmentEventModels) error); ok {
+		r0 = rf(shipment, events)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Tracking provides a mock function with given fields: courier, awb
-func (_m *RepositoryOrderInterface) Tracking(courier string, awb string) (map[string]interface{}, error) {
-	ret := _m.Called(courier, awb)
+// WithTx provides a mock function with given fields: tx
+func (_m *RepositoryOrderInterface) WithTx(tx *gorm.DB) order.RepositoryOrderInterface {
+	ret := _m.Called(tx)
 
-	var r0 map[string]interface{}
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (map[string]interface{}, error)); ok {
-		return rf(courier, awb)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) map[string]interface{}); ok {
-		r0 = rf(courier, awb)
+	var r0 order.RepositoryOrderInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) order.RepositoryOrderInterface); ok {
+		r0 = rf(tx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
+			r0 = ret.Get(0).(order.RepositoryOrderInterface)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(courier, awb)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// UpdateOrderStatus provides a mock function with given fields: req
-func (_m *RepositoryOrderInterface) UpdateOrderStatus(req *dto.UpdateOrderStatus) error {
-	ret := _m.Called(req)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(*dto.UpdateOrderStatus) error); ok {
-		r0 = rf(req)
-	} else {
-		r0 = ret.Error(0)
-	}
-
 	return r0
 }
 
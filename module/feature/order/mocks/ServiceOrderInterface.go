@@ -3,9 +3,11 @@
 package mocks
 
 import (
+	time "time"
+
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	order "github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
-
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +16,27 @@ type ServiceOrderInterface struct {
 	mock.Mock
 }
 
-// AcceptOrder provides a mock function with given fields: orderID
-func (_m *ServiceOrderInterface) AcceptOrder(orderID string) error {
-	ret := _m.Called(orderID)
+// AcceptOrder provides a mock function with given fields: orderID, actor
+func (_m *ServiceOrderInterface) AcceptOrder(orderID string, actor order.Actor) error {
+	ret := _m.Called(orderID, actor)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(orderID)
+	if rf, ok := ret.Get(0).(func(string, order.Actor) error); ok {
+		r0 = rf(orderID, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ApproveReturn provides a mock function with given fields: returnID, actor, note
+func (_m *ServiceOrderInterface) ApproveReturn(returnID uint64, actor order.Actor, note string) error {
+	ret := _m.Called(returnID, actor, note)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, order.Actor, string) error); ok {
+		r0 = rf(returnID, actor, note)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -53,11 +69,11 @@ func (_m *ServiceOrderInterface) CalculatePaginationValues(page int, totalItems
 }
 
 // CallBack provides a mock function with given fields: notifPayload
-func (_m *ServiceOrderInterface) CallBack(notifPayload map[string]interface{}) error {
+func (_m *ServiceOrderInterface) CallBack(notifPayload map[string]any) error {
 	ret := _m.Called(notifPayload)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+	if rf, ok := ret.Get(0).(func(map[string]any) error); ok {
 		r0 = rf(notifPayload)
 	} else {
 		r0 = ret.Error(0)
@@ -66,13 +82,13 @@ func (_m *ServiceOrderInterface) CallBack(notifPayload map[string]interface{}) e
 	return r0
 }
 
-// CancelPayment provides a mock function with given fields: orderID
-func (_m *ServiceOrderInterface) CancelPayment(orderID string) error {
-	ret := _m.Called(orderID)
+// CancelPayment provides a mock function with given fields: orderID, actor, reason
+func (_m *ServiceOrderInterface) CancelPayment(orderID string, actor order.Actor, reason string) error {
+	ret := _m.Called(orderID, actor, reason)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(orderID)
+	if rf, ok := ret.Get(0).(func(string, order.Actor, string) error); ok {
+		r0 = rf(orderID, actor, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -80,13 +96,13 @@ func (_m *ServiceOrderInterface) CancelPayment(orderID string) error {
 	return r0
 }
 
-// ConfirmPayment provides a mock function with given fields: orderID
-func (_m *ServiceOrderInterface) ConfirmPayment(orderID string) error {
-	ret := _m.Called(orderID)
+// ConfirmPayment provides a mock function with given fields: orderID, actor
+func (_m *ServiceOrderInterface) ConfirmPayment(orderID string, actor order.Actor) error {
+	ret := _m.Called(orderID, actor)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(orderID)
+	if rf, ok := ret.Get(0).(func(string, order.Actor) error); ok {
+		r0 = rf(orderID, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -146,37 +162,54 @@ func (_m *ServiceOrderInterface) CreateOrderFromCart(userID uint64, request *dto
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: page, perPage
-func (_m *ServiceOrderInterface) GetAll(page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(page, perPage)
+// CreateReturn provides a mock function with given fields: userID, request, photoURLs
+func (_m *ServiceOrderInterface) CreateReturn(userID uint64, request *dto.CreateReturnRequest, photoURLs []string) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(userID, request, photoURLs)
 
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(page, perPage)
+	var r0 *entities.OrderReturnModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *dto.CreateReturnRequest, []string) (*entities.OrderReturnModels, error)); ok {
+		return rf(userID, request, photoURLs)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []*entities.OrderModels); ok {
-		r0 = rf(page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64, *dto.CreateReturnRequest, []string) *entities.OrderReturnModels); ok {
+		r0 = rf(userID, request, photoURLs)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) int64); ok {
-		r1 = rf(page, perPage)
+	if rf, ok := ret.Get(1).(func(uint64, *dto.CreateReturnRequest, []string) error); ok {
+		r1 = rf(userID, request, photoURLs)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Error(1)
 	}
 
-	if rf, ok := ret.Get(2).(func(int, int) error); ok {
-		r2 = rf(page, perPage)
+	return r0, r1
+}
+
+// ExpireUnpaidOrders provides a mock function with given fields: deadline
+func (_m *ServiceOrderInterface) ExpireUnpaidOrders(deadline time.Time) (int, error) {
+	ret := _m.Called(deadline)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int, error)); ok {
+		return rf(deadline)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int); ok {
+		r0 = rf(deadline)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Get(0).(int)
 	}
 
-	return r0, r1, r2
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(deadline)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetAllOrdersByUserID provides a mock function with given fields: userID
@@ -245,171 +278,6 @@ func (_m *ServiceOrderInterface) GetNextPage(currentPage int, totalPages int) in
 	return r0
 }
 
-// GetOrderByDateRange provides a mock function with given fields: filterType, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByDateRange(filterType string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
-		r1 = rf(filterType, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
-		r2 = rf(filterType, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetOrderByDateRangeAndPaymentStatus provides a mock function with given fields: filterType, status, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByDateRangeAndPaymentStatus(filterType string, status string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, status, page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, status, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, status, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(filterType, status, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(filterType, status, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetOrderByDateRangeAndPaymentStatusAndSearch provides a mock function with given fields: filterType, status, search, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByDateRangeAndPaymentStatusAndSearch(filterType string, status string, search string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, status, search, page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, status, search, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, status, search, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) int64); ok {
-		r1 = rf(filterType, status, search, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, string, int, int) error); ok {
-		r2 = rf(filterType, status, search, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetOrderByDateRangeAndStatus provides a mock function with given fields: filterType, status, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByDateRangeAndStatus(filterType string, status string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, status, page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, status, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, status, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(filterType, status, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(filterType, status, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetOrderByDateRangeAndStatusAndSearch provides a mock function with given fields: filterType, status, search, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByDateRangeAndStatusAndSearch(filterType string, status string, search string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, status, search, page, perPage)
-
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, status, search, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, status, search, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string, string, int, int) int64); ok {
-		r1 = rf(filterType, status, search, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, string, int, int) error); ok {
-		r2 = rf(filterType, status, search, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
 // GetOrderById provides a mock function with given fields: orderID
 func (_m *ServiceOrderInterface) GetOrderById(orderID string) (*entities.OrderModels, error) {
 	ret := _m.Called(orderID)
@@ -436,65 +304,58 @@ func (_m *ServiceOrderInterface) GetOrderById(orderID string) (*entities.OrderMo
 	return r0, r1
 }
 
-// GetOrderByOrderStatus provides a mock function with given fields: orderStatus, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByOrderStatus(orderStatus string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(orderStatus, page, perPage)
+// GetOrderStatusHistory provides a mock function with given fields: orderID
+func (_m *ServiceOrderInterface) GetOrderStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(orderStatus, page, perPage)
+	var r0 []*entities.OrderStatusHistoryModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*entities.OrderStatusHistoryModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(0).(func(string) []*entities.OrderStatusHistoryModels); ok {
+		r0 = rf(orderID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).([]*entities.OrderStatusHistoryModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
-		r1 = rf(orderStatus, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
-		r2 = rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetOrderByPaymentStatus provides a mock function with given fields: orderStatus, page, perPage
-func (_m *ServiceOrderInterface) GetOrderByPaymentStatus(orderStatus string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(orderStatus, page, perPage)
+// GetOrders provides a mock function with given fields: filter
+func (_m *ServiceOrderInterface) GetOrders(filter *order.OrderFilter) ([]*entities.OrderModels, int64, error) {
+	ret := _m.Called(filter)
 
 	var r0 []*entities.OrderModels
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(0).(func(*order.OrderFilter) ([]*entities.OrderModels, int64, error)); ok {
+		return rf(filter)
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(0).(func(*order.OrderFilter) []*entities.OrderModels); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.OrderModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
-		r1 = rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(1).(func(*order.OrderFilter) int64); ok {
+		r1 = rf(filter)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
-		r2 = rf(orderStatus, page, perPage)
+	if rf, ok := ret.Get(2).(func(*order.OrderFilter) error); ok {
+		r2 = rf(filter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -502,65 +363,72 @@ func (_m *ServiceOrderInterface) GetOrderByPaymentStatus(orderStatus string, pag
 	return r0, r1, r2
 }
 
-// GetOrderBySearchAndDateRange provides a mock function with given fields: filterType, search, page, perPage
-func (_m *ServiceOrderInterface) GetOrderBySearchAndDateRange(filterType string, search string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(filterType, search, page, perPage)
+// GetPrevPage provides a mock function with given fields: currentPage
+func (_m *ServiceOrderInterface) GetPrevPage(currentPage int) int {
+	ret := _m.Called(currentPage)
 
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(filterType, search, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(filterType, search, page, perPage)
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(currentPage)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(filterType, search, page, perPage)
+	return r0
+}
+
+// GetReturnById provides a mock function with given fields: returnID
+func (_m *ServiceOrderInterface) GetReturnById(returnID uint64) (*entities.OrderReturnModels, error) {
+	ret := _m.Called(returnID)
+
+	var r0 *entities.OrderReturnModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.OrderReturnModels, error)); ok {
+		return rf(returnID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *entities.OrderReturnModels); ok {
+		r0 = rf(returnID)
 	} else {
-		r1 = ret.Get(1).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.OrderReturnModels)
+		}
 	}
 
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(filterType, search, page, perPage)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(returnID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetOrdersByName provides a mock function with given fields: page, perPage, name
-func (_m *ServiceOrderInterface) GetOrdersByName(page int, perPage int, name string) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(page, perPage, name)
+// GetReturns provides a mock function with given fields: page, perPage, status
+func (_m *ServiceOrderInterface) GetReturns(page int, perPage int, status string) ([]*entities.OrderReturnModels, int64, error) {
+	ret := _m.Called(page, perPage, status)
 
-	var r0 []*entities.OrderModels
+	var r0 []*entities.OrderReturnModels
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.OrderReturnModels, int64, error)); ok {
+		return rf(page, perPage, status)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.OrderModels); ok {
-		r0 = rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.OrderReturnModels); ok {
+		r0 = rf(page, perPage, status)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).([]*entities.OrderReturnModels)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = rf(page, perPage, name)
+		r1 = rf(page, perPage, status)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
 	if rf, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = rf(page, perPage, name)
+		r2 = rf(page, perPage, status)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -568,105 +436,77 @@ func (_m *ServiceOrderInterface) GetOrdersByName(page int, perPage int, name str
 	return r0, r1, r2
 }
 
-// GetOrdersBySearchAndPaymentStatus provides a mock function with given fields: status, search, page, perPage
-func (_m *ServiceOrderInterface) GetOrdersBySearchAndPaymentStatus(status string, search string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(status, search, page, perPage)
+// GetReturnsByUserID provides a mock function with given fields: userID
+func (_m *ServiceOrderInterface) GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error) {
+	ret := _m.Called(userID)
 
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(status, search, page, perPage)
+	var r0 []*entities.OrderReturnModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.OrderReturnModels, error)); ok {
+		return rf(userID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(status, search, page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.OrderReturnModels); ok {
+		r0 = rf(userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).([]*entities.OrderReturnModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(status, search, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(status, search, page, perPage)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetOrdersBySearchAndStatus provides a mock function with given fields: status, search, page, perPage
-func (_m *ServiceOrderInterface) GetOrdersBySearchAndStatus(status string, search string, page int, perPage int) ([]*entities.OrderModels, int64, error) {
-	ret := _m.Called(status, search, page, perPage)
+// GetShipmentByOrderID provides a mock function with given fields: orderID
+func (_m *ServiceOrderInterface) GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error) {
+	ret := _m.Called(orderID)
 
-	var r0 []*entities.OrderModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.OrderModels, int64, error)); ok {
-		return rf(status, search, page, perPage)
+	var r0 *entities.ShipmentModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.ShipmentModels, error)); ok {
+		return rf(orderID)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.OrderModels); ok {
-		r0 = rf(status, search, page, perPage)
+	if rf, ok := ret.Get(0).(func(string) *entities.ShipmentModels); ok {
+		r0 = rf(orderID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.OrderModels)
+			r0 = ret.Get(0).(*entities.ShipmentModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(status, search, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(status, search, page, perPage)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetPrevPage provides a mock function with given fields: currentPage
-func (_m *ServiceOrderInterface) GetPrevPage(currentPage int) int {
-	ret := _m.Called(currentPage)
-
-	var r0 int
-	if rf, ok := ret.Get(0).(func(int) int); ok {
-		r0 = rf(currentPage)
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
 	} else {
-		r0 = ret.Get(0).(int)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// ProcessGatewayPayment provides a mock function with given fields: totalAmountPaid, orderID, paymentMethod
-func (_m *ServiceOrderInterface) ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod string) (interface{}, error) {
-	ret := _m.Called(totalAmountPaid, orderID, paymentMethod)
+// ProcessGatewayPayment provides a mock function with given fields: totalAmountPaid, orderID, paymentMethod, name, email
+func (_m *ServiceOrderInterface) ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod string, name string, email string) (interface{}, error) {
+	ret := _m.Called(totalAmountPaid, orderID, paymentMethod, name, email)
 
 	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, string, string) (interface{}, error)); ok {
-		return rf(totalAmountPaid, orderID, paymentMethod)
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, string) (interface{}, error)); ok {
+		return rf(totalAmountPaid, orderID, paymentMethod, name, email)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, string, string) interface{}); ok {
-		r0 = rf(totalAmountPaid, orderID, paymentMethod)
+	if rf, ok := ret.Get(0).(func(uint64, string, string, string, string) interface{}); ok {
+		r0 = rf(totalAmountPaid, orderID, paymentMethod, name, email)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
-		r1 = rf(totalAmountPaid, orderID, paymentMethod)
+	if rf, ok := ret.Get(1).(func(uint64, string, string, string, string) error); ok {
+		r1 = rf(totalAmountPaid, orderID, paymentMethod, name, email)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -700,6 +540,20 @@ func (_m *ServiceOrderInterface) ProcessManualPayment(orderID string) (*entities
 	return r0, r1
 }
 
+// RejectReturn provides a mock function with given fields: returnID, actor, note
+func (_m *ServiceOrderInterface) RejectReturn(returnID uint64, actor order.Actor, note string) error {
+	ret := _m.Called(returnID, actor, note)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, order.Actor, string) error); ok {
+		r0 = rf(returnID, actor, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendNotificationOrder provides a mock function with given fields: request
 func (_m *ServiceOrderInterface) SendNotificationOrder(request dto.SendNotificationOrderRequest) (string, error) {
 	ret := _m.Called(request)
@@ -748,25 +602,23 @@ func (_m *ServiceOrderInterface) SendNotificationPayment(request dto.SendNotific
 	return r0, r1
 }
 
-// Tracking provides a mock function with given fields: courier, awb
-func (_m *ServiceOrderInterface) Tracking(courier string, awb string) (map[string]interface{}, error) {
-	ret := _m.Called(courier, awb)
+// SyncShipments provides a mock function with given fields:
+func (_m *ServiceOrderInterface) SyncShipments() (int, error) {
+	ret := _m.Called()
 
-	var r0 map[string]interface{}
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (map[string]interface{}, error)); ok {
-		return rf(courier, awb)
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(string, string) map[string]interface{}); ok {
-		r0 = rf(courier, awb)
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(map[string]interface{})
-		}
+		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(courier, awb)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -774,13 +626,13 @@ func (_m *ServiceOrderInterface) Tracking(courier string, awb string) (map[strin
 	return r0, r1
 }
 
-// UpdateOrderStatus provides a mock function with given fields: req
-func (_m *ServiceOrderInterface) UpdateOrderStatus(req *dto.UpdateOrderStatus) error {
-	ret := _m.Called(req)
+// UpdateOrderStatus provides a mock function with given fields: req, actor
+func (_m *ServiceOrderInterface) UpdateOrderStatus(req *dto.UpdateOrderStatus, actor order.Actor) error {
+	ret := _m.Called(req, actor)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*dto.UpdateOrderStatus) error); ok {
-		r0 = rf(req)
+	if rf, ok := ret.Get(0).(func(*dto.UpdateOrderStatus, order.Actor) error); ok {
+		r0 = rf(req, actor)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -5,77 +5,25 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
-	"github.com/capstone-kelompok-7/backend-disappear/utils/binderbyte"
-	"github.com/capstone-kelompok-7/backend-disappear/utils/payment"
-	"github.com/midtrans/midtrans-go/coreapi"
-	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
 type OrderRepository struct {
-	db         *gorm.DB
-	coreClient coreapi.Client
+	db *gorm.DB
 }
 
-func NewOrderRepository(db *gorm.DB, coreClient coreapi.Client) order.RepositoryOrderInterface {
+func NewOrderRepository(db *gorm.DB) order.RepositoryOrderInterface {
 	return &OrderRepository{
-		db:         db,
-		coreClient: coreClient,
+		db: db,
 	}
 }
 
-func (r *OrderRepository) FindByName(page, perPage int, name string) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	offset := (page - 1) * perPage
-	query := r.db.Preload("User").Offset(offset).Limit(perPage).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.deleted_at IS NULL")
-
-	if name != "" {
-		query = query.Where("users.name LIKE ?", "%"+name+"%")
-	}
-
-	err := query.Find(&orders).Error
-	if err != nil {
-		return nil, err
-	}
-
-	return orders, nil
-}
-
-func (r *OrderRepository) GetTotalCustomerCountByName(name string) (int64, error) {
-	var count int64
-	query := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON orders.user_id = users.id").
-		Where("users.deleted_at IS NULL")
-
-	if name != "" {
-		query = query.Where("users.name LIKE ?", "%"+name+"%")
-	}
-
-	err := query.Count(&count).Error
-	return count, err
-}
-
-func (r *OrderRepository) FindAll(page, perPage int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	offset := (page - 1) * perPage
-	err := r.db.Offset(offset).Limit(perPage).
-		Where("deleted_at IS NULL").
-		Order("created_at DESC").
-		Preload("User").
-		Find(&orders).Error
-	if err != nil {
-		return nil, err
+func (r *OrderRepository) WithTx(tx *gorm.DB) order.RepositoryOrderInterface {
+	return &OrderRepository{
+		db: tx,
 	}
-	return orders, nil
-}
-
-func (r *OrderRepository) GetTotalOrderCount() (int64, error) {
-	var count int64
-	err := r.db.Model(&entities.OrderModels{}).Where("deleted_at IS NULL").Count(&count).Error
-	return count, err
 }
 
 func (r *OrderRepository) GetOrderById(orderID string) (*entities.OrderModels, error) {
@@ -85,9 +33,11 @@ func (r *OrderRepository) GetOrderById(orderID string) (*entities.OrderModels, e
 		Preload("OrderDetails").
 		Preload("OrderDetails.Product").
 		Preload("OrderDetails.Product.ProductPhotos").
+		Preload("OrderDetails.Variant").
 		Preload("User").
 		Preload("Voucher").
 		Preload("Address").
+		Preload("Shipment").
 		Where("id = ? AND deleted_at IS NULL", orderID).
 		First(&orders).
 		Error; err != nil {
@@ -97,6 +47,28 @@ func (r *OrderRepository) GetOrderById(orderID string) (*entities.OrderModels, e
 	return &orders, nil
 }
 
+func (r *OrderRepository) LockOrder(orderID string) (*entities.OrderModels, error) {
+	var orders entities.OrderModels
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", orderID).
+		First(&orders).Error; err != nil {
+		return nil, err
+	}
+	return &orders, nil
+}
+
+func (r *OrderRepository) GetUnpaidOrdersBefore(deadline time.Time, limit int) ([]*entities.OrderModels, error) {
+	var orders []*entities.OrderModels
+	if err := r.db.
+		Where("payment_status = ? AND created_at < ? AND deleted_at IS NULL", order.PaymentStatusWaiting, deadline).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
 func (r *OrderRepository) CreateOrder(newOrder *entities.OrderModels) (*entities.OrderModels, error) {
 	err := r.db.Create(newOrder).Error
 	if err != nil {
@@ -124,42 +96,6 @@ func (r *OrderRepository) ConfirmPayment(orderID, orderStatus, paymentStatus str
 	return nil
 }
 
-func (r *OrderRepository) ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod, name, email string) (interface{}, error) {
-	var paymentType coreapi.CoreapiPaymentType
-
-	switch paymentMethod {
-	case "qris":
-		paymentType = coreapi.PaymentTypeQris
-	case "bank_transfer":
-		paymentType = coreapi.PaymentTypeBankTransfer
-	case "gopay":
-		paymentType = coreapi.PaymentTypeGopay
-	}
-
-	coreClient := r.coreClient
-	resp, err := payment.CreateCoreAPIPaymentRequest(coreClient, orderID, int64(totalAmountPaid), paymentType, name, email)
-	if err != nil {
-		logrus.Error(err)
-		return nil, errors.New("gagal membuat permintaan pembayaran")
-	}
-
-	return resp, nil
-}
-
-func (r *OrderRepository) CheckTransaction(orderID string) (dto.Status, error) {
-	var status dto.Status
-	transactionStatusResp, err := r.coreClient.CheckTransaction(orderID)
-	if err != nil {
-		return dto.Status{}, err
-	} else {
-		if transactionStatusResp != nil {
-			status = payment.TransactionStatus(transactionStatusResp)
-			return status, nil
-		}
-	}
-	return dto.Status{}, err
-}
-
 func (r *OrderRepository) UpdateOrderStatus(req *dto.UpdateOrderStatus) error {
 	orders := entities.OrderModels{}
 	if err := r.db.Where("id = ?", req.OrderID).First(&orders).Error; err != nil {
@@ -187,6 +123,7 @@ func (r *OrderRepository) GetAllOrdersByUserID(userID uint64) ([]*entities.Order
 		Preload("OrderDetails").
 		Preload("OrderDetails.Product").
 		Preload("OrderDetails.Product.ProductPhotos").
+		Preload("OrderDetails.Variant").
 		Where("user_id = ? AND deleted_at IS NULL", userID).
 		Find(&orders).Error; err != nil {
 		return nil, err
@@ -202,6 +139,7 @@ func (r *OrderRepository) GetAllOrdersWithFilter(userID uint64, orderStatus stri
 		Preload("OrderDetails").
 		Preload("OrderDetails.Product").
 		Preload("OrderDetails.Product.ProductPhotos").
+		Preload("OrderDetails.Variant").
 		Where("user_id = ? AND order_status = ? AND deleted_at IS NULL", userID, orderStatus).
 		Find(&orders).Error; err != nil {
 		return nil, err
@@ -220,225 +158,253 @@ func (r *OrderRepository) AcceptOrder(orderID, orderStatus string) error {
 	return nil
 }
 
-func (r *OrderRepository) Tracking(courier, awb string) (map[string]interface{}, error) {
-	result, err := binderbyte.TrackingPackages(courier, awb)
-	if err != nil {
-		return nil, err
+func (r *OrderRepository) CreateStatusHistory(history *entities.OrderStatusHistoryModels) error {
+	if err := r.db.Create(history).Error; err != nil {
+		return err
 	}
-	return result, nil
+	return nil
 }
 
-func (r *OrderRepository) GetOrderByDateRange(startDate, endDate time.Time, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").Where("created_at BETWEEN ? AND ? AND deleted_at IS NULL", startDate, endDate).Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+func (r *OrderRepository) GetStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error) {
+	var histories []*entities.OrderStatusHistoryModels
+	if err := r.db.Where("order_id = ?", orderID).Order("created_at ASC, id ASC").Find(&histories).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return histories, nil
 }
 
-func (r *OrderRepository) GetOrderCountByDateRange(startDate, endDate time.Time) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Where("created_at BETWEEN ? AND ? AND deleted_at IS NULL", startDate, endDate).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) GetWebhookLog(idempotencyKey string) (*entities.PaymentWebhookLogModels, error) {
+	var webhookLog entities.PaymentWebhookLogModels
+	if err := r.db.Where("idempotency_key = ?", idempotencyKey).First(&webhookLog).Error; err != nil {
+		return nil, err
 	}
-	return count, nil
+	return &webhookLog, nil
 }
 
-func (r *OrderRepository) GetOrderByOrderStatus(orderStatus string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").Where("order_status = ? AND deleted_at IS NULL", orderStatus).Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
-		return nil, err
+func (r *OrderRepository) SaveWebhookLog(webhookLog *entities.PaymentWebhookLogModels) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "idempotency_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"transaction_status", "status_code", "payload", "result", "updated_at"}),
+	}).Create(webhookLog).Error; err != nil {
+		return err
 	}
-	return orders, nil
+	return nil
 }
 
-func (r *OrderRepository) GetOrderCountByByOrderStatus(orderStatus string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Where("order_status = ? AND deleted_at IS NULL", orderStatus).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) CreateShipment(shipment *entities.ShipmentModels) error {
+	if err := r.db.Create(shipment).Error; err != nil {
+		return err
 	}
-	return count, nil
+	return nil
 }
 
-func (r *OrderRepository) GetOrderByDateRangeAndStatus(startDate, endDate time.Time, status string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").Where("created_at BETWEEN ? AND ? AND order_status = ? AND deleted_at IS NULL", startDate, endDate, status).
-		Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+func (r *OrderRepository) GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error) {
+	var shipment entities.ShipmentModels
+	if err := r.db.
+		Preload("Events", func(db *gorm.DB) *gorm.DB {
+			return db.Order("date DESC")
+		}).
+		Where("order_id = ?", orderID).
+		First(&shipment).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return &shipment, nil
 }
 
-func (r *OrderRepository) GetOrderCountByDateRangeAndStatus(startDate, endDate time.Time, status string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Where("created_at BETWEEN ? AND ? AND order_status = ? AND deleted_at IS NULL", startDate, endDate, status).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) GetShipmentsInTransit(limit int) ([]*entities.ShipmentModels, error) {
+	var shipments []*entities.ShipmentModels
+	if err := r.db.
+		Joins("JOIN orders ON orders.id = shipments.order_id").
+		Where("shipments.delivered_at IS NULL AND orders.order_status = ? AND orders.deleted_at IS NULL", order.OrderStatusShipping).
+		Order("shipments.last_synced_at ASC").
+		Limit(limit).
+		Find(&shipments).Error; err != nil {
+		return nil, err
 	}
-	return count, nil
+	return shipments, nil
 }
 
-func (r *OrderRepository) GetOrderByDateRangeAndStatusAndSearch(startDate, endDate time.Time, status, search string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND orders.order_status = ? AND users.name LIKE ? AND orders.deleted_at IS NULL",
-			startDate, endDate, status, "%"+search+"%").
-		Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
-		return nil, err
+func (r *OrderRepository) UpdateShipmentTracking(shipment *entities.ShipmentModels, events []entities.ShipmentEventModels) error {
+	if err := r.db.Model(&entities.ShipmentModels{}).
+		Where("id = ?", shipment.ID).
+		Updates(map[string]interface{}{
+			"status":         shipment.Status,
+			"last_synced_at": shipment.LastSyncedAt,
+			"delivered_at":   shipment.DeliveredAt,
+		}).Error; err != nil {
+		return err
 	}
-	return orders, nil
-}
 
-func (r *OrderRepository) GetOrderCountByDateRangeAndStatusAndSearch(startDate, endDate time.Time, status, search string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND orders.order_status = ? AND users.name LIKE ? AND orders.deleted_at IS NULL",
-			startDate, endDate, status, "%"+search+"%").
-		Count(&count).Error; err != nil {
-		return 0, err
+	if err := r.db.Where("shipment_id = ?", shipment.ID).Delete(&entities.ShipmentEventModels{}).Error; err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+	for i := range events {
+		events[i].ShipmentID = shipment.ID
+	}
+	if err := r.db.Create(&events).Error; err != nil {
+		return err
 	}
-	return count, nil
+	return nil
 }
 
-func (r *OrderRepository) GetOrdersBySearchAndDateRange(startDate, endDate time.Time, search string, offset, limit int) ([]*entities.OrderModels, error) {
+// FindOrders lists one page of the orders matching filter together with the
+// number of matches. Sort must already be valid.
+func (r *OrderRepository) FindOrders(filter *order.OrderFilter) ([]*entities.OrderModels, int64, error) {
 	var orders []*entities.OrderModels
-	if err := r.db.
-		Preload("User").
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND users.name LIKE ? AND orders.deleted_at IS NULL", startDate, endDate, "%"+search+"%").
-		Offset(offset).
-		Limit(limit).
-		Find(&orders).Error; err != nil {
-		return nil, err
+	var totalItems int64
+
+	if err := r.filterOrders(filter).Count(&totalItems).Error; err != nil {
+		return nil, 0, err
 	}
-	return orders, nil
-}
 
-func (r *OrderRepository) GetOrderCountBySearchAndDateRange(startDate, endDate time.Time, search string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND users.name LIKE ? AND orders.deleted_at IS NULL", startDate, endDate, "%"+search+"%").
-		Count(&count).Error; err != nil {
-		return 0, err
+	sort, _ := order.GetOrderSort(filter.Sort)
+	direction := "ASC"
+	if sort.Desc {
+		direction = "DESC"
+	}
+	if err := r.filterOrders(filter).Preload("User").
+		Order("orders." + sort.Column + " " + direction).
+		Order("orders.id " + direction).
+		Offset(filter.Offset()).Limit(filter.PerPage).
+		Find(&orders).Error; err != nil {
+		return nil, 0, err
 	}
-	return count, nil
+
+	return orders, totalItems, nil
 }
 
-func (r *OrderRepository) GetOrdersBySearchAndStatus(status, search string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.
-		Preload("User").
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("users.name LIKE ? AND orders.order_status = ? AND orders.deleted_at IS NULL", "%"+search+"%", status).
-		Offset(offset).
-		Limit(limit).
-		Find(&orders).Error; err != nil {
-		return nil, err
+func (r *OrderRepository) filterOrders(filter *order.OrderFilter) *gorm.DB {
+	query := r.db.Model(&entities.OrderModels{}).Where("orders.deleted_at IS NULL")
+
+	if filter.StartDate != nil {
+		query = query.Where("orders.created_at >= ?", *filter.StartDate)
 	}
-	return orders, nil
+	if filter.EndDate != nil {
+		query = query.Where("orders.created_at < ?", *filter.EndDate)
+	}
+	if filter.OrderStatus != "" {
+		query = query.Where("orders.order_status = ?", filter.OrderStatus)
+	}
+	if filter.PaymentStatus != "" {
+		query = query.Where("orders.payment_status = ?", filter.PaymentStatus)
+	}
+	if filter.PaymentMethod != "" {
+		query = query.Where("orders.payment_method = ?", filter.PaymentMethod)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("orders.user_id = ?", filter.UserID)
+	}
+	if filter.Search != "" {
+		query = query.Joins("JOIN users ON users.id = orders.user_id").
+			Where("users.name LIKE ?", "%"+filter.Search+"%")
+	}
+
+	return query
 }
 
-func (r *OrderRepository) GetOrdersCountBySearchAndStatus(status, search string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("users.name LIKE ? AND orders.order_status = ? AND orders.deleted_at IS NULL", "%"+search+"%", status).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) CreateReturn(orderReturn *entities.OrderReturnModels) (*entities.OrderReturnModels, error) {
+	if err := r.db.Create(orderReturn).Error; err != nil {
+		return nil, err
 	}
-	return count, nil
+	return orderReturn, nil
 }
 
-func (r *OrderRepository) GetOrderBySearchAndPaymentStatus(status, search string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
+func (r *OrderRepository) GetReturnById(returnID uint64) (*entities.OrderReturnModels, error) {
+	var orderReturn entities.OrderReturnModels
 	if err := r.db.
+		Preload("Photos").
+		Preload("Refund").
 		Preload("User").
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("users.name LIKE ? AND orders.payment_status = ? AND orders.deleted_at IS NULL", "%"+search+"%", status).
-		Offset(offset).
-		Limit(limit).
-		Find(&orders).Error; err != nil {
+		Preload("Order").
+		Preload("Order.OrderDetails").
+		Preload("Order.OrderDetails.Product").
+		Where("id = ?", returnID).
+		First(&orderReturn).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return &orderReturn, nil
 }
 
-func (r *OrderRepository) GetOrderCountBySearchAndPaymentStatus(status, search string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("users.name LIKE ? AND orders.payment_status = ? AND orders.deleted_at IS NULL", "%"+search+"%", status).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) LockReturn(returnID uint64) (*entities.OrderReturnModels, error) {
+	var orderReturn entities.OrderReturnModels
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", returnID).
+		First(&orderReturn).Error; err != nil {
+		return nil, err
 	}
-	return count, nil
+	return &orderReturn, nil
 }
 
-func (r *OrderRepository) GetOrderByDateRangeAndPaymentStatusAndSearch(startDate, endDate time.Time, status, search string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND orders.payment_status = ? AND users.name LIKE ? AND orders.deleted_at IS NULL",
-			startDate, endDate, status, "%"+search+"%").
-		Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+func (r *OrderRepository) GetActiveReturnByOrderID(orderID string) (*entities.OrderReturnModels, error) {
+	var orderReturn entities.OrderReturnModels
+	if err := r.db.
+		Where("order_id = ? AND status IN ?", orderID, []string{order.ReturnStatusPending, order.ReturnStatusApproved}).
+		First(&orderReturn).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return &orderReturn, nil
 }
 
-func (r *OrderRepository) GetOrderCountByDateRangeAndPaymentStatusAndSearch(startDate, endDate time.Time, status, search string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Joins("JOIN users ON users.id = orders.user_id").
-		Where("orders.created_at BETWEEN ? AND ? AND orders.payment_status = ? AND users.name LIKE ? AND orders.deleted_at IS NULL",
-			startDate, endDate, status, "%"+search+"%").
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) FindAllReturns(page, perPage int, status string) ([]*entities.OrderReturnModels, error) {
+	var orderReturns []*entities.OrderReturnModels
+	offset := (page - 1) * perPage
+	query := r.db.Offset(offset).Limit(perPage).
+		Order("created_at DESC").
+		Preload("Photos").
+		Preload("Refund").
+		Preload("User")
+	if status != "" {
+		query = query.Where("status = ?", status)
 	}
-	return count, nil
-}
-
-func (r *OrderRepository) GetOrderByPaymentStatus(orderStatus string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").Where("payment_status = ? AND deleted_at IS NULL", orderStatus).Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+	if err := query.Find(&orderReturns).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return orderReturns, nil
 }
 
-func (r *OrderRepository) GetOrderCountByByPaymentStatus(orderStatus string) (int64, error) {
+func (r *OrderRepository) GetTotalReturnCount(status string) (int64, error) {
 	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Where("payment_status = ? AND deleted_at IS NULL", orderStatus).
-		Count(&count).Error; err != nil {
-		return 0, err
+	query := r.db.Model(&entities.OrderReturnModels{})
+	if status != "" {
+		query = query.Where("status = ?", status)
 	}
-	return count, nil
+	err := query.Count(&count).Error
+	return count, err
 }
 
-func (r *OrderRepository) GetOrderByDateRangeAndPaymentStatus(startDate, endDate time.Time, status string, offset, limit int) ([]*entities.OrderModels, error) {
-	var orders []*entities.OrderModels
-	if err := r.db.Preload("User").Where("created_at BETWEEN ? AND ? AND payment_status = ? AND deleted_at IS NULL", startDate, endDate, status).
-		Offset(offset).Limit(limit).Find(&orders).Error; err != nil {
+func (r *OrderRepository) GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error) {
+	var orderReturns []*entities.OrderReturnModels
+	if err := r.db.
+		Preload("Photos").
+		Preload("Refund").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&orderReturns).Error; err != nil {
 		return nil, err
 	}
-	return orders, nil
+	return orderReturns, nil
 }
 
-func (r *OrderRepository) GetOrderCountByDateRangeAndPaymentStatus(startDate, endDate time.Time, status string) (int64, error) {
-	var count int64
-	if err := r.db.Model(&entities.OrderModels{}).
-		Where("created_at BETWEEN ? AND ? AND payment_status = ? AND deleted_at IS NULL", startDate, endDate, status).
-		Count(&count).Error; err != nil {
-		return 0, err
+func (r *OrderRepository) UpdateReturnStatus(returnID uint64, status, note string, processedBy uint64) error {
+	if err := r.db.Model(&entities.OrderReturnModels{}).
+		Where("id = ?", returnID).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"admin_note":   note,
+			"processed_by": processedBy,
+			"processed_at": time.Now(),
+		}).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *OrderRepository) CreateRefund(refund *entities.OrderRefundModels) error {
+	if err := r.db.Create(refund).Error; err != nil {
+		return err
 	}
-	return count, nil
+	return nil
 }
@@ -1,36 +1,50 @@
 package service
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/address"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/cart"
-	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	notificationDto "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
-	"github.com/capstone-kelompok-7/backend-disappear/utils/sendnotif"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/database"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/payment"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/tracking"
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 	"math"
+	"strconv"
 	"strings"
 	"time"
 )
 
 type OrderService struct {
-	repo           order.RepositoryOrderInterface
-	generatorID    utils.GeneratorInterface
-	productService product.ServiceProductInterface
-	voucherService voucher.ServiceVoucherInterface
-	addressService address.ServiceAddressInterface
-	userService    users.ServiceUserInterface
-	cartService    cart.ServiceCartInterface
-	fcmService     fcm.ServiceFcmInterface
+	repo                order.RepositoryOrderInterface
+	generatorID         utils.GeneratorInterface
+	productService      product.ServiceProductInterface
+	voucherService      voucher.ServiceVoucherInterface
+	addressService      address.ServiceAddressInterface
+	userService         users.ServiceUserInterface
+	cartService         cart.ServiceCartInterface
+	notificationService notification.ServiceNotificationInterface
+	txManager           database.TransactionManagerInterface
+	paymentGateway      payment.PaymentGateway
+	shippingService     shipping.ServiceShippingInterface
+	tracker             tracking.Tracker
 }
 
+const adminFee uint64 = 2000
+
 func NewOrderService(
 	repo order.RepositoryOrderInterface,
 	generatorID utils.GeneratorInterface,
@@ -39,32 +53,26 @@ func NewOrderService(
 	addressService address.ServiceAddressInterface,
 	userService users.ServiceUserInterface,
 	cartService cart.ServiceCartInterface,
-	fcmService fcm.ServiceFcmInterface,
+	notificationService notification.ServiceNotificationInterface,
+	txManager database.TransactionManagerInterface,
+	paymentGateway payment.PaymentGateway,
+	shippingService shipping.ServiceShippingInterface,
+	tracker tracking.Tracker,
 ) order.ServiceOrderInterface {
 	return &OrderService{
-		repo:           repo,
-		generatorID:    generatorID,
-		productService: productService,
-		voucherService: voucherService,
-		addressService: addressService,
-		userService:    userService,
-		cartService:    cartService,
-		fcmService:     fcmService,
-	}
-}
-
-func (s *OrderService) GetAll(page, perPage int) ([]*entities.OrderModels, int64, error) {
-	orders, err := s.repo.FindAll(page, perPage)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	totalItems, err := s.repo.GetTotalOrderCount()
-	if err != nil {
-		return nil, 0, err
+		repo:                repo,
+		generatorID:         generatorID,
+		productService:      productService,
+		voucherService:      voucherService,
+		addressService:      addressService,
+		userService:         userService,
+		cartService:         cartService,
+		notificationService: notificationService,
+		txManager:           txManager,
+		paymentGateway:      paymentGateway,
+		shippingService:     shippingService,
+		tracker:             tracker,
 	}
-
-	return orders, totalItems, nil
 }
 
 func (s *OrderService) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
@@ -96,20 +104,6 @@ func (s *OrderService) GetPrevPage(currentPage int) int {
 	return 1
 }
 
-func (s *OrderService) GetOrdersByName(page, perPage int, name string) ([]*entities.OrderModels, int64, error) {
-	orders, err := s.repo.FindByName(page, perPage, name)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	totalItems, err := s.repo.GetTotalCustomerCountByName(name)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return orders, totalItems, nil
-}
-
 func (s *OrderService) GetOrderById(orderID string) (*entities.OrderModels, error) {
 	orders, err := s.repo.GetOrderById(orderID)
 	if err != nil {
@@ -134,12 +128,9 @@ func (s *OrderService) CreateOrder(userID uint64, request *dto.CreateOrderReques
 		return nil, errors.New("alamat tidak ditemukan")
 	}
 
-	var vouchers *entities.VoucherModels
-	if request.VoucherID != 0 {
-		vouchers, err = s.voucherService.GetVoucherById(request.VoucherID)
-		if err != nil {
-			return nil, errors.New("kupon tidak ditemukan")
-		}
+	vouchers, err := s.resolveOrderVoucher(userID, request.VoucherID, request.VoucherCode)
+	if err != nil {
+		return nil, err
 	}
 
 	var validPaymentMethods = map[string]bool{
@@ -158,18 +149,24 @@ func (s *OrderService) CreateOrder(userID uint64, request *dto.CreateOrderReques
 	var orderDetails []entities.OrderDetailsModels
 	var totalQuantity, totalGramPlastic, totalExp, totalPrice, totalDiscount uint64
 
-	products, err := s.productService.GetProductByID(request.ProductID)
+	products, err := s.productService.GetProductForPurchase(request.ProductID, request.VariantID)
 	if err != nil {
-		return nil, errors.New("produk tidak ditemukan")
+		return nil, err
 	}
 
 	if products.Stock < request.Quantity {
 		return nil, errors.New("stok tidak mencukupi untuk pesanan ini")
 	}
 
+	var variantID *uint64
+	if request.VariantID != 0 {
+		variantID = &request.VariantID
+	}
+
 	orderDetail := entities.OrderDetailsModels{
 		OrderID:          orderID,
 		ProductID:        request.ProductID,
+		VariantID:        variantID,
 		Quantity:         request.Quantity,
 		TotalGramPlastic: products.GramPlastic * request.Quantity,
 		TotalExp:         products.Exp * request.Quantity,
@@ -184,29 +181,28 @@ func (s *OrderService) CreateOrder(userID uint64, request *dto.CreateOrderReques
 	totalDiscount += orderDetail.TotalDiscount
 
 	orderDetails = append(orderDetails, orderDetail)
+	voucherItems := []voucher.CartItem{voucher.NewCartItem(products, request.Quantity)}
 
-	if isInCart := s.cartService.IsProductInCart(userID, products.ID); isInCart {
-		if err := s.cartService.RemoveProductFromCart(userID, products.ID); err != nil {
-			return nil, errors.New("gagal menghapus keranjang")
-		}
-	}
-
-	if err := s.productService.ReduceStockWhenPurchasing(request.ProductID, request.Quantity); err != nil {
-		return nil, errors.New("gagal mengurangi stok")
+	shipment, err := s.shippingService.CalculateFee(addresses.City, products.Weight*request.Quantity, request.Courier, request.CourierService)
+	if err != nil {
+		return nil, err
 	}
 
 	var discountFromVoucher uint64
-	if request.VoucherID != 0 && totalPrice >= vouchers.MinPurchase {
-		discountFromVoucher = vouchers.Discount
+	if vouchers != nil {
+		discountFromVoucher, err = s.voucherService.CalculateDiscount(userID, vouchers, voucher.Cart{Items: voucherItems, ShipmentFee: shipment.Cost})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var voucherID *uint64
-	if request.VoucherID != 0 {
-		voucherID = &request.VoucherID
+	if vouchers != nil {
+		voucherID = &vouchers.ID
 	}
 
 	grandTotalPrice := totalPrice
-	totalAmountPaid := grandTotalPrice + 2000 + 0 - discountFromVoucher
+	totalAmountPaid := grandTotalPrice + adminFee + shipment.Cost - discountFromVoucher
 
 	newData := &entities.OrderModels{
 		ID:                    orderID,
@@ -219,43 +215,71 @@ func (s *OrderService) CreateOrder(userID uint64, request *dto.CreateOrderReques
 		GrandTotalExp:         totalExp,
 		GrandTotalQuantity:    totalQuantity,
 		GrandTotalPrice:       grandTotalPrice,
-		ShipmentFee:           0,
-		AdminFees:             2000,
+		ShipmentFee:           shipment.Cost,
+		AdminFees:             adminFee,
+		Courier:               shipment.Courier,
+		CourierService:        shipment.Service,
 		GrandTotalDiscount:    totalDiscount,
+		VoucherDiscount:       discountFromVoucher,
 		TotalAmountPaid:       totalAmountPaid,
-		OrderStatus:           "Menunggu Konfirmasi",
-		PaymentStatus:         "Menunggu Konfirmasi",
+		OrderStatus:           order.OrderStatusWaiting,
+		PaymentStatus:         order.PaymentStatusWaiting,
 		PaymentMethod:         request.PaymentMethod,
 		StatusOrderDate:       time.Now(),
 		CreatedAt:             time.Now(),
 		OrderDetails:          orderDetails,
 	}
-	createdOrder, err := s.repo.CreateOrder(newData)
-	if err != nil {
-		return nil, err
-	}
 
-	if request.VoucherID != 0 {
-		if err := s.voucherService.DeleteVoucherClaims(userID, vouchers.ID); err != nil {
-			return nil, err
+	var createdOrder *entities.OrderModels
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		cartService := s.cartService.WithTx(tx)
+		if isInCart := cartService.IsProductInCart(userID, products.ID, request.VariantID); isInCart {
+			if err := cartService.RemoveProductFromCart(userID, products.ID, request.VariantID); err != nil {
+				return errors.New("gagal menghapus keranjang")
+			}
 		}
-	}
 
-	user, err := s.userService.GetUsersById(createdOrder.UserID)
+		if err := s.productService.WithTx(tx).ReduceStockWhenPurchasing(request.ProductID, request.VariantID, request.Quantity, orderID); err != nil {
+			return err
+		}
+
+		repo := s.repo.WithTx(tx)
+		createdOrder, err = repo.CreateOrder(newData)
+		if err != nil {
+			return err
+		}
+
+		actor := order.Actor{ID: userID, Role: "customer"}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypeOrder, "", order.OrderStatusWaiting, actor, "pesanan dibuat"); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypePayment, "", order.PaymentStatusWaiting, actor, "pesanan dibuat"); err != nil {
+			return err
+		}
+
+		if vouchers != nil {
+			voucherService := s.voucherService.WithTx(tx)
+			if err := voucherService.RecordUsage(userID, vouchers.ID, orderID, request.VoucherCode, discountFromVoucher); err != nil {
+				return err
+			}
+			if err := voucherService.DeleteVoucherClaims(userID, vouchers.ID); err != nil {
+				return err
+			}
+		}
+
+		return s.queuePaymentNotification(tx, dto.SendNotificationPaymentRequest{
+			OrderID:       createdOrder.ID,
+			UserID:        createdOrder.UserID,
+			PaymentStatus: order.PaymentStatusWaiting,
+		})
+	})
 	if err != nil {
-		return nil, errors.New("pengguna tidak ditemukan")
+		return nil, err
 	}
 
-	notificationRequest := dto.SendNotificationPaymentRequest{
-		OrderID:       createdOrder.ID,
-		UserID:        createdOrder.UserID,
-		PaymentStatus: "Menunggu Konfirmasi",
-		Token:         user.DeviceToken,
-	}
-	_, err = s.SendNotificationPayment(notificationRequest)
+	user, err := s.userService.GetUsersById(createdOrder.UserID)
 	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return nil, err
+		return nil, errors.New("pengguna tidak ditemukan")
 	}
 
 	switch request.PaymentMethod {
@@ -268,6 +292,22 @@ func (s *OrderService) CreateOrder(userID uint64, request *dto.CreateOrderReques
 	}
 }
 
+// resolveOrderVoucher picks the voucher for a checkout. A typed code wins over
+// a claimed voucher ID; neither means the order has no voucher.
+func (s *OrderService) resolveOrderVoucher(userID, voucherID uint64, voucherCode string) (*entities.VoucherModels, error) {
+	if voucherCode != "" {
+		return s.voucherService.ResolveCode(userID, voucherCode)
+	}
+	if voucherID == 0 {
+		return nil, nil
+	}
+	vouchers, err := s.voucherService.GetVoucherById(voucherID)
+	if err != nil {
+		return nil, errors.New("kupon tidak ditemukan")
+	}
+	return vouchers, nil
+}
+
 func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrderCartRequest) (interface{}, error) {
 	orderID, err := s.generatorID.GenerateUUID()
 	if err != nil {
@@ -284,12 +324,9 @@ func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrd
 		return nil, errors.New("alamat tidak ditemukan")
 	}
 
-	var vouchers *entities.VoucherModels
-	if request.VoucherID != 0 {
-		vouchers, err = s.voucherService.GetVoucherById(request.VoucherID)
-		if err != nil {
-			return nil, errors.New("kupon tidak ditemukan")
-		}
+	vouchers, err := s.resolveOrderVoucher(userID, request.VoucherID, request.VoucherCode)
+	if err != nil {
+		return nil, err
 	}
 
 	var validPaymentMethods = map[string]bool{
@@ -315,12 +352,13 @@ func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrd
 	}
 
 	var orderDetails []entities.OrderDetailsModels
-	var totalQuantity, totalGramPlastic, totalExp, totalPrice, totalDiscount uint64
+	var totalQuantity, totalGramPlastic, totalExp, totalPrice, totalDiscount, totalWeight uint64
+	var voucherItems []voucher.CartItem
 
 	for _, cartItem := range cartItems {
-		products, err := s.productService.GetProductByID(cartItem.ProductID)
+		products, err := s.productService.GetProductForPurchase(cartItem.ProductID, variantIDOf(cartItem.VariantID))
 		if err != nil {
-			return nil, errors.New("produk tidak ditemukan")
+			return nil, err
 		}
 
 		if products.Stock < cartItem.Quantity {
@@ -330,6 +368,7 @@ func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrd
 		orderDetail := entities.OrderDetailsModels{
 			OrderID:          orderID,
 			ProductID:        cartItem.ProductID,
+			VariantID:        cartItem.VariantID,
 			Quantity:         cartItem.Quantity,
 			TotalGramPlastic: products.GramPlastic * cartItem.Quantity,
 			TotalExp:         products.Exp * cartItem.Quantity,
@@ -342,30 +381,32 @@ func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrd
 		totalExp += orderDetail.TotalExp
 		totalPrice += orderDetail.TotalPrice
 		totalDiscount += orderDetail.TotalDiscount
+		totalWeight += products.Weight * cartItem.Quantity
 
 		orderDetails = append(orderDetails, orderDetail)
+		voucherItems = append(voucherItems, voucher.NewCartItem(products, cartItem.Quantity))
+	}
 
-		if err := s.cartService.DeleteCartItem(cartItem.ID); err != nil {
-			return nil, errors.New("gagal menghapus produk dari keranjang")
-		}
-
-		if err := s.productService.ReduceStockWhenPurchasing(cartItem.ProductID, cartItem.Quantity); err != nil {
-			return nil, errors.New("gagal mengurangi stok produk")
-		}
+	shipment, err := s.shippingService.CalculateFee(addresses.City, totalWeight, request.Courier, request.CourierService)
+	if err != nil {
+		return nil, err
 	}
 
 	var discountFromVoucher uint64
-	if request.VoucherID != 0 && totalPrice >= vouchers.MinPurchase {
-		discountFromVoucher = vouchers.Discount
+	if vouchers != nil {
+		discountFromVoucher, err = s.voucherService.CalculateDiscount(userID, vouchers, voucher.Cart{Items: voucherItems, ShipmentFee: shipment.Cost})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	var voucherID *uint64
-	if request.VoucherID != 0 {
-		voucherID = &request.VoucherID
+	if vouchers != nil {
+		voucherID = &vouchers.ID
 	}
 
 	grandTotalPrice := totalPrice
-	totalAmountPaid := grandTotalPrice + 2000 + 0 - discountFromVoucher
+	totalAmountPaid := grandTotalPrice + adminFee + shipment.Cost - discountFromVoucher
 
 	newData := &entities.OrderModels{
 		ID:                    orderID,
@@ -378,45 +419,74 @@ func (s *OrderService) CreateOrderFromCart(userID uint64, request *dto.CreateOrd
 		GrandTotalExp:         totalExp,
 		GrandTotalQuantity:    totalQuantity,
 		GrandTotalPrice:       grandTotalPrice,
-		ShipmentFee:           0,
-		AdminFees:             2000,
+		ShipmentFee:           shipment.Cost,
+		AdminFees:             adminFee,
+		Courier:               shipment.Courier,
+		CourierService:        shipment.Service,
 		GrandTotalDiscount:    totalDiscount,
+		VoucherDiscount:       discountFromVoucher,
 		TotalAmountPaid:       totalAmountPaid,
-		OrderStatus:           "Menunggu Konfirmasi",
-		PaymentStatus:         "Menunggu Konfirmasi",
+		OrderStatus:           order.OrderStatusWaiting,
+		PaymentStatus:         order.PaymentStatusWaiting,
 		PaymentMethod:         request.PaymentMethod,
 		StatusOrderDate:       time.Now(),
 		CreatedAt:             time.Now(),
 		OrderDetails:          orderDetails,
 	}
 
-	createdOrder, err := s.repo.CreateOrder(newData)
+	var createdOrder *entities.OrderModels
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		cartService := s.cartService.WithTx(tx)
+		productService := s.productService.WithTx(tx)
+		for _, cartItem := range cartItems {
+			if err := cartService.DeleteCartItem(cartItem.ID); err != nil {
+				return errors.New("gagal menghapus produk dari keranjang")
+			}
+
+			if err := productService.ReduceStockWhenPurchasing(cartItem.ProductID, variantIDOf(cartItem.VariantID), cartItem.Quantity, orderID); err != nil {
+				return err
+			}
+		}
+
+		repo := s.repo.WithTx(tx)
+		createdOrder, err = repo.CreateOrder(newData)
+		if err != nil {
+			return err
+		}
+
+		actor := order.Actor{ID: userID, Role: "customer"}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypeOrder, "", order.OrderStatusWaiting, actor, "pesanan dibuat"); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypePayment, "", order.PaymentStatusWaiting, actor, "pesanan dibuat"); err != nil {
+			return err
+		}
+
+		if vouchers != nil {
+			voucherService := s.voucherService.WithTx(tx)
+			if err := voucherService.RecordUsage(userID, vouchers.ID, orderID, request.VoucherCode, discountFromVoucher); err != nil {
+				return err
+			}
+			if err := voucherService.DeleteVoucherClaims(userID, vouchers.ID); err != nil {
+				return err
+			}
+		}
+
+		return s.queuePaymentNotification(tx, dto.SendNotificationPaymentRequest{
+			OrderID:       createdOrder.ID,
+			UserID:        createdOrder.UserID,
+			PaymentStatus: order.PaymentStatusWaiting,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if request.VoucherID != 0 {
-		if err := s.voucherService.DeleteVoucherClaims(userID, vouchers.ID); err != nil {
-			return nil, err
-		}
-	}
 	user, err := s.userService.GetUsersById(createdOrder.UserID)
 	if err != nil {
 		return nil, errors.New("pengguna tidak ditemukan")
 	}
 
-	notificationRequest := dto.SendNotificationPaymentRequest{
-		OrderID:       createdOrder.ID,
-		UserID:        createdOrder.UserID,
-		PaymentStatus: "Menunggu Konfirmasi",
-		Token:         user.DeviceToken,
-	}
-	_, err = s.SendNotificationPayment(notificationRequest)
-	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return nil, err
-	}
-
 	switch request.PaymentMethod {
 	case "whatsapp", "telegram":
 		return s.ProcessManualPayment(orderID)
@@ -436,7 +506,13 @@ func (s *OrderService) ProcessManualPayment(orderID string) (*entities.OrderMode
 }
 
 func (s *OrderService) ProcessGatewayPayment(totalAmountPaid uint64, orderID string, paymentMethod, name, email string) (interface{}, error) {
-	result, err := s.repo.ProcessGatewayPayment(totalAmountPaid, orderID, paymentMethod, name, email)
+	result, err := s.paymentGateway.Charge(payment.ChargeRequest{
+		OrderID:       orderID,
+		Amount:        int64(totalAmountPaid),
+		PaymentMethod: paymentMethod,
+		Name:          name,
+		Email:         email,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -449,139 +525,243 @@ func (s *OrderService) CallBack(notifPayload map[string]interface{}) error {
 		return errors.New("invalid notification payload")
 	}
 
-	status, err := s.repo.CheckTransaction(orderID)
-	if err != nil {
+	if err := s.paymentGateway.VerifyNotification(notifPayload); err != nil {
 		return err
 	}
 
-	transaction, err := s.repo.GetOrderById(orderID)
+	transactionStatus, _ := notifPayload["transaction_status"].(string)
+	statusCode := fmt.Sprint(notifPayload["status_code"])
+	idempotencyKey := fmt.Sprintf("%s:%s:%s", orderID, transactionStatus, statusCode)
+
+	// Midtrans retries a notification until it gets a 2xx, so anything already
+	// handled is acknowledged without touching the order again.
+	if webhookLog, err := s.repo.GetWebhookLog(idempotencyKey); err == nil && webhookLog.Result != order.WebhookResultFailed {
+		return nil
+	}
+
+	result, err := s.processPaymentNotification(orderID)
 	if err != nil {
-		return errors.New("transaction data not found")
+		result = order.WebhookResultFailed
 	}
 
-	if status.PaymentStatus == "Konfirmasi" {
-		if err := s.ConfirmPayment(transaction.ID); err != nil {
-			return err
-		}
-	} else if status.PaymentStatus == "Gagal" {
-		if err := s.CancelPayment(transaction.ID); err != nil {
-			return err
-		}
+	payload, _ := json.Marshal(notifPayload)
+	webhookLog := &entities.PaymentWebhookLogModels{
+		IdempotencyKey:    idempotencyKey,
+		OrderID:           orderID,
+		TransactionStatus: transactionStatus,
+		StatusCode:        statusCode,
+		Payload:           string(payload),
+		Result:            result,
+	}
+	if logErr := s.repo.SaveWebhookLog(webhookLog); logErr != nil {
+		logrus.Error("Gagal menyimpan log webhook pembayaran: ", logErr)
 	}
 
-	return nil
+	return err
 }
 
-func (s *OrderService) ConfirmPayment(orderID string) error {
-	orders, err := s.repo.GetOrderById(orderID)
+func (s *OrderService) processPaymentNotification(orderID string) (string, error) {
+	status, err := s.paymentGateway.CheckTransaction(orderID)
 	if err != nil {
-		return errors.New("pesanan tidak ditemukan")
-	}
-
-	orders.OrderStatus = "Proses"
-	orders.PaymentStatus = "Konfirmasi"
-
-	if err := s.repo.ConfirmPayment(orders.ID, orders.OrderStatus, orders.PaymentStatus); err != nil {
-		return err
+		return "", err
 	}
 
-	user, err := s.userService.GetUsersById(orders.UserID)
+	transaction, err := s.repo.GetOrderById(orderID)
 	if err != nil {
-		return errors.New("pengguna tidak ditemukan")
+		return "", errors.New("transaction data not found")
 	}
 
-	user.Exp += orders.GrandTotalExp
-	if _, err := s.userService.UpdateUserExp(user.ID, user.Exp); err != nil {
-		return err
+	// A late or out-of-order notification must not move an order that has
+	// already left the waiting state.
+	if !order.CanTransitionPaymentStatus(transaction.PaymentStatus, status.PaymentStatus) {
+		return order.WebhookResultIgnored, nil
 	}
 
-	user.TotalGram += orders.GrandTotalGramPlastic
-	if _, err := s.userService.UpdateUserContribution(user.ID, user.TotalGram); err != nil {
-		return err
+	if status.PaymentStatus == order.PaymentStatusConfirmed {
+		if err := s.ConfirmPayment(transaction.ID, order.SystemActor); err != nil {
+			return "", err
+		}
+	} else if status.PaymentStatus == order.PaymentStatusFailed {
+		if err := s.CancelPayment(transaction.ID, order.SystemActor, "pembayaran gagal atau kedaluwarsa"); err != nil {
+			return "", err
+		}
 	}
 
-	notificationRequest := dto.SendNotificationPaymentRequest{
-		OrderID:       orderID,
-		UserID:        user.ID,
-		PaymentStatus: "Konfirmasi",
-		Token:         user.DeviceToken,
-	}
-	_, err = s.SendNotificationPayment(notificationRequest)
+	return order.WebhookResultProcessed, nil
+}
+
+func (s *OrderService) ConfirmPayment(orderID string, actor order.Actor) error {
+	orders, err := s.repo.GetOrderById(orderID)
 	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return err
+		return errors.New("pesanan tidak ditemukan")
 	}
 
-	return nil
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockOrder(orders.ID)
+		if err != nil {
+			return errors.New("pesanan tidak ditemukan")
+		}
+		if err := order.ValidatePaymentStatusTransition(current.PaymentStatus, order.PaymentStatusConfirmed); err != nil {
+			return err
+		}
+		if err := order.ValidateOrderStatusTransition(current.OrderStatus, order.OrderStatusProcess); err != nil {
+			return err
+		}
+
+		if err := repo.ConfirmPayment(orders.ID, order.OrderStatusProcess, order.PaymentStatusConfirmed); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orders.ID, order.StatusTypePayment, current.PaymentStatus, order.PaymentStatusConfirmed, actor, "pembayaran dikonfirmasi"); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orders.ID, order.StatusTypeOrder, current.OrderStatus, order.OrderStatusProcess, actor, "pembayaran dikonfirmasi"); err != nil {
+			return err
+		}
+		if err := s.userService.WithTx(tx).AwardUserRewards(current.UserID, current.GrandTotalExp, current.GrandTotalGramPlastic); err != nil {
+			return err
+		}
+
+		return s.queuePaymentNotification(tx, dto.SendNotificationPaymentRequest{
+			OrderID:       orders.ID,
+			UserID:        orders.UserID,
+			PaymentStatus: order.PaymentStatusConfirmed,
+		})
+	})
 }
 
-func (s *OrderService) CancelPayment(orderID string) error {
+func (s *OrderService) CancelPayment(orderID string, actor order.Actor, reason string) error {
 	orders, err := s.repo.GetOrderById(orderID)
 	if err != nil {
 		return errors.New("pesanan tidak ditemukan")
 	}
 
-	orders.OrderStatus = "Gagal"
-	orders.PaymentStatus = "Gagal"
+	if reason == "" {
+		reason = "pembayaran dibatalkan"
+	}
 
-	for _, orderDetail := range orders.OrderDetails {
-		if err := s.productService.IncreaseStock(orderDetail.ProductID, orderDetail.Quantity); err != nil {
-			return errors.New("gagal menambah stok produk")
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockOrder(orderID)
+		if err != nil {
+			return errors.New("pesanan tidak ditemukan")
+		}
+		if err := order.ValidatePaymentStatusTransition(current.PaymentStatus, order.PaymentStatusFailed); err != nil {
+			return err
+		}
+		if err := order.ValidateOrderStatusTransition(current.OrderStatus, order.OrderStatusFailed); err != nil {
+			return err
 		}
-	}
 
-	if err := s.repo.ConfirmPayment(orderID, orders.OrderStatus, orders.PaymentStatus); err != nil {
-		return errors.New("gagal membatalkan pesanan")
-	}
+		productService := s.productService.WithTx(tx)
+		for _, orderDetail := range orders.OrderDetails {
+			if err := productService.IncreaseStock(orderDetail.ProductID, variantIDOf(orderDetail.VariantID), orderDetail.Quantity, product.MovementCancellation, orderID); err != nil {
+				return errors.New("gagal menambah stok produk")
+			}
+		}
 
-	user, err := s.userService.GetUsersById(orders.UserID)
+		if orders.VoucherID != nil {
+			if err := s.voucherService.WithTx(tx).ReleaseUsage(orderID); err != nil {
+				return errors.New("gagal mengembalikan kuota kupon")
+			}
+		}
+
+		if err := repo.ConfirmPayment(orderID, order.OrderStatusFailed, order.PaymentStatusFailed); err != nil {
+			return errors.New("gagal membatalkan pesanan")
+		}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypePayment, current.PaymentStatus, order.PaymentStatusFailed, actor, reason); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orderID, order.StatusTypeOrder, current.OrderStatus, order.OrderStatusFailed, actor, reason); err != nil {
+			return err
+		}
+
+		return s.queuePaymentNotification(tx, dto.SendNotificationPaymentRequest{
+			OrderID:       orderID,
+			UserID:        orders.UserID,
+			PaymentStatus: order.PaymentStatusFailed,
+		})
+	})
+}
+
+const expireUnpaidOrdersBatchSize = 100
+
+func (s *OrderService) ExpireUnpaidOrders(deadline time.Time) (int, error) {
+	orders, err := s.repo.GetUnpaidOrdersBefore(deadline, expireUnpaidOrdersBatchSize)
 	if err != nil {
-		return errors.New("pengguna tidak ditemukan")
+		return 0, errors.New("gagal mendapatkan pesanan yang belum dibayar")
 	}
 
-	notificationRequest := dto.SendNotificationPaymentRequest{
-		OrderID:       orderID,
-		UserID:        user.ID,
-		PaymentStatus: "Gagal",
-		Token:         user.DeviceToken,
-	}
-	_, err = s.SendNotificationPayment(notificationRequest)
-	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return err
+	expired := 0
+	for _, unpaid := range orders {
+		if err := s.CancelPayment(unpaid.ID, order.SystemActor, "pesanan kedaluwarsa karena belum dibayar"); err != nil {
+			logrus.Errorf("Gagal membatalkan pesanan kedaluwarsa %s: %v", unpaid.ID, err)
+			continue
+		}
+		expired++
 	}
 
-	return nil
+	return expired, nil
 }
 
-func (s *OrderService) UpdateOrderStatus(req *dto.UpdateOrderStatus) error {
+func (s *OrderService) UpdateOrderStatus(req *dto.UpdateOrderStatus, actor order.Actor) error {
 	orders, err := s.repo.GetOrderById(req.OrderID)
 	if err != nil {
 		return errors.New("pesanan tidak ditemukan")
 	}
 
-	if err := s.repo.UpdateOrderStatus(req); err != nil {
-		return err
+	if req.OrderStatus == order.OrderStatusReturned {
+		return errors.New("status pesanan dikembalikan hanya dapat diatur melalui pengajuan pengembalian")
 	}
 
-	user, err := s.userService.GetUsersById(orders.UserID)
-	if err != nil {
-		return errors.New("pengguna tidak ditemukan")
-	}
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockOrder(orders.ID)
+		if err != nil {
+			return errors.New("pesanan tidak ditemukan")
+		}
+		if err := order.ValidateOrderStatusTransition(current.OrderStatus, req.OrderStatus); err != nil {
+			return err
+		}
 
-	notificationRequest := dto.SendNotificationOrderRequest{
-		OrderID:     orders.ID,
-		UserID:      user.ID,
-		OrderStatus: req.OrderStatus,
-		Token:       user.DeviceToken,
-	}
-	_, err = s.SendNotificationOrder(notificationRequest)
-	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return err
-	}
+		var shipment *entities.ShipmentModels
+		if req.OrderStatus == order.OrderStatusShipping {
+			if req.Awb == "" {
+				return errors.New("nomor resi wajib diisi untuk status pengiriman")
+			}
+			courier := current.Courier
+			if courier == "" {
+				courier = req.Courier
+			}
+			if courier == "" {
+				return errors.New("kurir wajib diisi untuk status pengiriman")
+			}
+			shipment = &entities.ShipmentModels{
+				OrderID: current.ID,
+				Courier: courier,
+				Service: current.CourierService,
+				Awb:     req.Awb,
+			}
+		}
 
-	return nil
+		if err := repo.UpdateOrderStatus(req); err != nil {
+			return err
+		}
+		if shipment != nil {
+			if err := repo.CreateShipment(shipment); err != nil {
+				return errors.New("gagal menyimpan data pengiriman")
+			}
+		}
+		if err := s.recordStatusHistory(repo, current.ID, order.StatusTypeOrder, current.OrderStatus, req.OrderStatus, actor, req.ExtraInfo); err != nil {
+			return err
+		}
+
+		return s.queueOrderNotification(tx, dto.SendNotificationOrderRequest{
+			OrderID:     current.ID,
+			UserID:      current.UserID,
+			OrderStatus: req.OrderStatus,
+		})
+	})
 }
 
 func (s *OrderService) GetAllOrdersByUserID(userID uint64) ([]*entities.OrderModels, error) {
@@ -608,9 +788,9 @@ func (s *OrderService) GetAllOrdersWithFilter(userID uint64, orderStatus string)
 	return result, nil
 }
 
-func (s *OrderService) AcceptOrder(orderID string) error {
+func (s *OrderService) AcceptOrder(orderID string, actor order.Actor) error {
 	orders, err := s.repo.GetOrderById(orderID)
-	if err != nil {
+	if err != nil || orders.UserID != actor.ID {
 		return errors.New("pesanan tidak ditemukan")
 	}
 
@@ -619,258 +799,243 @@ func (s *OrderService) AcceptOrder(orderID string) error {
 		return errors.New("pengguna tidak ditemukan")
 	}
 
-	orders.OrderStatus = "Selesai"
-
-	if err := s.repo.AcceptOrder(orders.ID, orders.OrderStatus); err != nil {
-		return err
-	}
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockOrder(orders.ID)
+		if err != nil {
+			return errors.New("pesanan tidak ditemukan")
+		}
+		if err := order.ValidateOrderStatusTransition(current.OrderStatus, order.OrderStatusDone); err != nil {
+			return err
+		}
 
-	notificationRequest := dto.SendNotificationOrderRequest{
-		OrderID:     orders.ID,
-		UserID:      user.ID,
-		OrderStatus: "Selesai",
-		Token:       user.DeviceToken,
-	}
-	_, err = s.SendNotificationOrder(notificationRequest)
-	if err != nil {
-		logrus.Error("Gagal mengirim notifikasi: ", err)
-		return err
-	}
+		if err := repo.AcceptOrder(current.ID, order.OrderStatusDone); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, current.ID, order.StatusTypeOrder, current.OrderStatus, order.OrderStatusDone, actor, "pesanan diterima"); err != nil {
+			return err
+		}
 
-	return nil
+		return s.queueOrderNotification(tx, dto.SendNotificationOrderRequest{
+			OrderID:     current.ID,
+			UserID:      user.ID,
+			OrderStatus: order.OrderStatusDone,
+		})
+	})
 }
 
-func (s *OrderService) Tracking(courier, awb string) (map[string]interface{}, error) {
-	result, err := s.repo.Tracking(courier, awb)
+func (s *OrderService) GetShipmentByOrderID(orderID string) (*entities.ShipmentModels, error) {
+	result, err := s.repo.GetShipmentByOrderID(orderID)
 	if err != nil {
-		return nil, err
+		return nil, errors.New("pengiriman tidak ditemukan")
 	}
 	return result, nil
 }
 
-func (s *OrderService) GetOrderByDateRange(filterType string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderByDateRange(startDate, endDate, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
-	}
-
-	totalItems, err := s.repo.GetOrderCountByDateRange(startDate, endDate)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
-	}
-
-	return result, totalItems, nil
-}
-
-func (s *OrderService) GetOrderByOrderStatus(orderStatus string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	offset := (page - 1) * perPage
+const syncShipmentsBatchSize = 100
 
-	result, err := s.repo.GetOrderByOrderStatus(orderStatus, offset, perPage)
+// SyncShipments refreshes the tracking of shipments still on their way and
+// completes the orders whose package has been delivered. It returns how many
+// orders were completed.
+func (s *OrderService) SyncShipments() (int, error) {
+	shipments, err := s.repo.GetShipmentsInTransit(syncShipmentsBatchSize)
 	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
+		return 0, errors.New("gagal mendapatkan data pengiriman")
 	}
 
-	totalItems, err := s.repo.GetOrderCountByByOrderStatus(orderStatus)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
+	completed := 0
+	for _, shipment := range shipments {
+		done, err := s.syncShipment(shipment)
+		if err != nil {
+			logrus.Errorf("Gagal sinkronisasi pengiriman pesanan %s: %v", shipment.OrderID, err)
+			continue
+		}
+		if done {
+			completed++
+		}
 	}
 
-	return result, totalItems, nil
+	return completed, nil
 }
 
-func (s *OrderService) GetOrderByDateRangeAndStatus(filterType, status string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
+func (s *OrderService) syncShipment(shipment *entities.ShipmentModels) (bool, error) {
+	result, err := s.tracker.Track(shipment.Courier, shipment.Awb)
 	if err != nil {
-		return nil, 0, err
+		return false, err
 	}
 
-	offset := (page - 1) * perPage
+	now := time.Now()
+	shipment.Status = result.Status
+	shipment.LastSyncedAt = &now
+	if result.Delivered {
+		shipment.DeliveredAt = &now
+	}
+
+	events := make([]entities.ShipmentEventModels, 0, len(result.Events))
+	for _, event := range result.Events {
+		events = append(events, entities.ShipmentEventModels{
+			ShipmentID:  shipment.ID,
+			Date:        event.Date,
+			Description: event.Description,
+			Location:    event.Location,
+		})
+	}
+
+	var orders *entities.OrderModels
+	completed := false
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		if err := repo.UpdateShipmentTracking(shipment, events); err != nil {
+			return err
+		}
+		if !result.Delivered {
+			return nil
+		}
 
-	result, err := s.repo.GetOrderByDateRangeAndStatus(startDate, endDate, status, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
-	}
+		orders, err = repo.LockOrder(shipment.OrderID)
+		if err != nil {
+			return err
+		}
+		if !order.CanTransitionOrderStatus(orders.OrderStatus, order.OrderStatusDone) {
+			return nil
+		}
+		if err := repo.AcceptOrder(orders.ID, order.OrderStatusDone); err != nil {
+			return err
+		}
+		completed = true
+		if err := s.recordStatusHistory(repo, orders.ID, order.StatusTypeOrder, orders.OrderStatus, order.OrderStatusDone, order.SystemActor, "paket telah diterima"); err != nil {
+			return err
+		}
 
-	totalItems, err := s.repo.GetOrderCountByDateRangeAndStatus(startDate, endDate, status)
+		return s.queueOrderNotification(tx, dto.SendNotificationOrderRequest{
+			OrderID:     orders.ID,
+			UserID:      orders.UserID,
+			OrderStatus: order.OrderStatusDone,
+		})
+	})
 	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
+		return false, err
 	}
 
-	return result, totalItems, nil
+	return completed, nil
 }
 
-func (s *OrderService) GetOrderByDateRangeAndStatusAndSearch(filterType, status, search string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
+func (s *OrderService) GetOrderStatusHistory(orderID string) ([]*entities.OrderStatusHistoryModels, error) {
+	result, err := s.repo.GetStatusHistory(orderID)
 	if err != nil {
-		return nil, 0, err
+		return nil, errors.New("gagal mendapatkan riwayat status pesanan")
 	}
-
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderByDateRangeAndStatusAndSearch(startDate, endDate, status, search, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
-	}
-
-	totalItems, err := s.repo.GetOrderCountByDateRangeAndStatusAndSearch(startDate, endDate, status, search)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
-	}
-
-	return result, totalItems, nil
+	return result, nil
 }
 
-func (s *OrderService) GetOrderBySearchAndDateRange(filterType, search string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrdersBySearchAndDateRange(startDate, endDate, search, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
+func (s *OrderService) recordStatusHistory(repo order.RepositoryOrderInterface, orderID, statusType, fromStatus, toStatus string, actor order.Actor, reason string) error {
+	history := &entities.OrderStatusHistoryModels{
+		OrderID:    orderID,
+		StatusType: statusType,
+		FromStatus: fromStatus,
+		ToStatus:   toStatus,
+		ActorID:    actor.ID,
+		ActorRole:  actor.Role,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
 	}
-
-	totalItems, err := s.repo.GetOrderCountBySearchAndDateRange(startDate, endDate, search)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
+	if err := repo.CreateStatusHistory(history); err != nil {
+		return errors.New("gagal menyimpan riwayat status pesanan")
 	}
-
-	return result, totalItems, nil
+	return nil
 }
 
-func (s *OrderService) GetOrdersBySearchAndStatus(status, search string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrdersBySearchAndStatus(status, search, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pesanan tidak ditemukan")
+// GetOrders lists one page of the orders matching filter with the number of
+// matches. A date preset only applies when no explicit date is given.
+func (s *OrderService) GetOrders(filter *order.OrderFilter) ([]*entities.OrderModels, int64, error) {
+	spec := *filter
+	if spec.Sort == "" {
+		spec.Sort = order.DefaultSort
 	}
-
-	totalItems, err := s.repo.GetOrdersCountBySearchAndStatus(status, search)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pesanan")
+	if _, ok := order.GetOrderSort(spec.Sort); !ok {
+		return nil, 0, errors.New("urutan pesanan tidak valid")
 	}
-
-	return result, totalItems, nil
-}
-
-// Payment
-func (s *OrderService) GetOrderByPaymentStatus(orderStatus string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderByPaymentStatus(orderStatus, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pembayaran tidak ditemukan")
+	if spec.PerPage <= 0 {
+		spec.PerPage = order.DefaultPerPage
 	}
-
-	totalItems, err := s.repo.GetOrderCountByByPaymentStatus(orderStatus)
-	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pembayaran")
+	if spec.PerPage > order.MaxPerPage {
+		spec.PerPage = order.MaxPerPage
 	}
 
-	return result, totalItems, nil
-}
-
-func (s *OrderService) GetOrderByDateRangeAndPaymentStatus(filterType, status string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
-	if err != nil {
-		return nil, 0, err
+	if spec.DateFilter != "" && spec.StartDate == nil && spec.EndDate == nil {
+		startDate, endDate, err := s.GetFilterDateRange(spec.DateFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+		spec.StartDate, spec.EndDate = &startDate, &endDate
 	}
-
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderByDateRangeAndPaymentStatus(startDate, endDate, status, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pembayaran tidak ditemukan")
+	if spec.StartDate != nil && spec.EndDate != nil && spec.StartDate.After(*spec.EndDate) {
+		return nil, 0, errors.New("rentang tanggal tidak valid")
 	}
 
-	totalItems, err := s.repo.GetOrderCountByDateRangeAndPaymentStatus(startDate, endDate, status)
+	result, totalItems, err := s.repo.FindOrders(&spec)
 	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pembayaran")
+		return nil, 0, errors.New("pesanan tidak ditemukan")
 	}
 
 	return result, totalItems, nil
 }
 
-func (s *OrderService) GetOrderByDateRangeAndPaymentStatusAndSearch(filterType, status, search string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	startDate, endDate, err := s.GetFilterDateRange(filterType)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderByDateRangeAndPaymentStatusAndSearch(startDate, endDate, status, search, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pembayaran tidak ditemukan")
-	}
-
-	totalItems, err := s.repo.GetOrderCountByDateRangeAndPaymentStatusAndSearch(startDate, endDate, status, search)
+func (s *OrderService) SendNotificationPayment(request dto.SendNotificationPaymentRequest) (string, error) {
+	user, err := s.userService.GetUsersById(request.UserID)
 	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pembayaran")
+		return "", err
 	}
-
-	return result, totalItems, nil
+	return s.sendPaymentNotification(s.repo, s.notificationService, user, request)
 }
 
-func (s *OrderService) GetOrdersBySearchAndPaymentStatus(status, search string, page, perPage int) ([]*entities.OrderModels, int64, error) {
-	offset := (page - 1) * perPage
-
-	result, err := s.repo.GetOrderBySearchAndPaymentStatus(status, search, offset, perPage)
-	if err != nil {
-		return nil, 0, errors.New("pembayaran tidak ditemukan")
-	}
-
-	totalItems, err := s.repo.GetOrderCountBySearchAndPaymentStatus(status, search)
+// queuePaymentNotification writes the payment notification inside the caller's
+// transaction. A user who is gone has nobody to notify, which is not a reason
+// to roll the change back.
+func (s *OrderService) queuePaymentNotification(tx *gorm.DB, request dto.SendNotificationPaymentRequest) error {
+	user, err := s.userService.GetUsersById(request.UserID)
 	if err != nil {
-		return nil, 0, errors.New("gagal mendapatkan total pembayaran")
+		logrus.Warnf("Notifikasi pesanan %s dilewati: %v", request.OrderID, err)
+		return nil
 	}
-
-	return result, totalItems, nil
+	_, err = s.sendPaymentNotification(s.repo.WithTx(tx), s.notificationService.WithTx(tx), user, request)
+	return err
 }
 
-func (s *OrderService) SendNotificationPayment(request dto.SendNotificationPaymentRequest) (string, error) {
+func (s *OrderService) sendPaymentNotification(repo order.RepositoryOrderInterface, notificationService notification.ServiceNotificationInterface, user *entities.UserModels, request dto.SendNotificationPaymentRequest) (string, error) {
 	var notificationMsg string
-	var err error
 
-	user, err := s.userService.GetUsersById(request.UserID)
-	if err != nil {
-		return "", err
-	}
-	orders, err := s.repo.GetOrderById(request.OrderID)
+	orders, err := repo.GetOrderById(request.OrderID)
 	if err != nil {
 		return "", err
 	}
 
+	var template string
 	switch request.PaymentStatus {
 	case "Menunggu Konfirmasi":
 		notificationMsg = fmt.Sprintf("Alloo, %s! Pesananmu dengan ID %s udah berhasil dibuat, nih. Ditunggu yupp!!", user.Name, orders.IdOrder)
+		template = email.TemplateOrderReceipt
 	case "Konfirmasi":
 		notificationMsg = fmt.Sprintf("Thengkyuu, %s! Pembayaran untuk pesananmu dengan ID %s udah kami terima, nih. Semoga harimu menyenangkan!", user.Name, orders.IdOrder)
+		template = email.TemplatePaymentConfirmed
 	case "Gagal":
 		notificationMsg = fmt.Sprintf("Maaf, %s. Pembayaran untuk pesanan dengan ID %s gagal, nih. Beritahu kami apabila kamu butuh bantuan yaa!!", user.Name, orders.IdOrder)
 	default:
 		return "", errors.New("Status pesanan tidak valid")
 	}
 
-	notificationRequest := sendnotif.SendNotificationRequest{
-		OrderID: request.OrderID,
-		UserID:  request.UserID,
-		Title:   "Status Pembayaran",
-		Body:    notificationMsg,
-		Token:   user.DeviceToken,
-	}
-	_, _, err = s.fcmService.CreateFcm(notificationRequest)
+	_, err = notificationService.Notify(&notificationDto.NotifyRequest{
+		UserID:   request.UserID,
+		Type:     notification.EventPaymentStatus,
+		OrderID:  request.OrderID,
+		Title:    "Status Pembayaran",
+		Body:     notificationMsg,
+		Template: template,
+		Data: map[string]string{
+			"OrderID": orders.IdOrder,
+			"Total":   formatRupiah(orders.TotalAmountPaid),
+		},
+	})
 	if err != nil {
 		logrus.Error("Gagal mengirim notifikasi: ", err)
 		return "", err
@@ -880,21 +1045,37 @@ func (s *OrderService) SendNotificationPayment(request dto.SendNotificationPayme
 }
 
 func (s *OrderService) SendNotificationOrder(request dto.SendNotificationOrderRequest) (string, error) {
-	var notificationMsg string
-	var err error
-
 	user, err := s.userService.GetUsersById(request.UserID)
 	if err != nil {
 		return "", err
 	}
-	orders, err := s.repo.GetOrderById(request.OrderID)
+	return s.sendOrderNotification(s.repo, s.notificationService, user, request)
+}
+
+// queueOrderNotification is queuePaymentNotification for order status changes.
+func (s *OrderService) queueOrderNotification(tx *gorm.DB, request dto.SendNotificationOrderRequest) error {
+	user, err := s.userService.GetUsersById(request.UserID)
+	if err != nil {
+		logrus.Warnf("Notifikasi pesanan %s dilewati: %v", request.OrderID, err)
+		return nil
+	}
+	_, err = s.sendOrderNotification(s.repo.WithTx(tx), s.notificationService.WithTx(tx), user, request)
+	return err
+}
+
+func (s *OrderService) sendOrderNotification(repo order.RepositoryOrderInterface, notificationService notification.ServiceNotificationInterface, user *entities.UserModels, request dto.SendNotificationOrderRequest) (string, error) {
+	var notificationMsg string
+
+	orders, err := repo.GetOrderById(request.OrderID)
 	if err != nil {
 		return "", err
 	}
 
+	var template string
 	switch request.OrderStatus {
 	case "Pengiriman":
 		notificationMsg = fmt.Sprintf("Alloo, %s! Pesanan dengan ID %s udah dalam proses pengiriman, nih. Mohon ditunggu yupp!", user.Name, orders.IdOrder)
+		template = email.TemplateShipped
 	case "Selesai":
 		notificationMsg = fmt.Sprintf("Yeayy, %s! Pesananmu dengan ID %s udah sampai tujuan, nih. Semoga sukakk yupp!", user.Name, orders.IdOrder)
 	case "Menunggu Konfirmasi":
@@ -903,18 +1084,21 @@ func (s *OrderService) SendNotificationOrder(request dto.SendNotificationOrderRe
 		notificationMsg = fmt.Sprintf("Alloo, %s! Pesananmu dengan ID %s sedang dalam proses, nih. Ditunggu yupp!", user.Name, orders.IdOrder)
 	case "Gagal":
 		notificationMsg = fmt.Sprintf("Sowwy, %s. Pesananmu dengan ID %s gagal. Coba lagi, yukk!", user.Name, orders.IdOrder)
+	case "Dikembalikan":
+		notificationMsg = fmt.Sprintf("Alloo, %s! Pengembalian pesanan dengan ID %s udah disetujui dan dananya akan segera dikembalikan, yupp!", user.Name, orders.IdOrder)
 	default:
 		return "", errors.New("Status pengiriman tidak valid")
 	}
 
-	notificationRequest := sendnotif.SendNotificationRequest{
-		OrderID: request.OrderID,
-		UserID:  request.UserID,
-		Title:   "Status Pengiriman",
-		Body:    notificationMsg,
-		Token:   user.DeviceToken,
-	}
-	_, _, err = s.fcmService.CreateFcm(notificationRequest)
+	_, err = notificationService.Notify(&notificationDto.NotifyRequest{
+		UserID:   request.UserID,
+		Type:     notification.EventOrderStatus,
+		OrderID:  request.OrderID,
+		Title:    "Status Pengiriman",
+		Body:     notificationMsg,
+		Template: template,
+		Data:     shipmentMailData(orders),
+	})
 	if err != nil {
 		logrus.Error("Gagal mengirim notifikasi: ", err)
 		return "", err
@@ -923,6 +1107,32 @@ func (s *OrderService) SendNotificationOrder(request dto.SendNotificationOrderRe
 	return notificationMsg, nil
 }
 
+func shipmentMailData(order *entities.OrderModels) map[string]string {
+	data := map[string]string{
+		"OrderID": order.IdOrder,
+		"Courier": strings.ToUpper(order.Courier),
+	}
+	if order.Shipment != nil {
+		data["TrackingNumber"] = order.Shipment.Awb
+	}
+	return data
+}
+
+// formatRupiah writes an amount the way it is shown to customers: Rp 66.000.
+func formatRupiah(amount uint64) string {
+	digits := strconv.FormatUint(amount, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+	return "Rp " + grouped.String()
+}
+
+// GetFilterDateRange turns a preset into a range in the server's time zone.
+// The end is exclusive: it is the start of the next week, month or year.
 func (s *OrderService) GetFilterDateRange(filterType string) (time.Time, time.Time, error) {
 	filterType = strings.ToLower(filterType)
 	now := time.Now()
@@ -930,20 +1140,181 @@ func (s *OrderService) GetFilterDateRange(filterType string) (time.Time, time.Ti
 	switch filterType {
 	case "minggu ini":
 		startOfWeek := now.AddDate(0, 0, -int(now.Weekday()))
-		startDate := time.Date(startOfWeek.Year(), startOfWeek.Month(), startOfWeek.Day(), 0, 0, 0, 0, time.UTC)
-		endDate := startDate.AddDate(0, 0, 7)
-		return startDate, endDate, nil
+		startDate := time.Date(startOfWeek.Year(), startOfWeek.Month(), startOfWeek.Day(), 0, 0, 0, 0, now.Location())
+		return startDate, startDate.AddDate(0, 0, 7), nil
 	case "bulan ini":
-		startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-		nextMonth := startDate.AddDate(0, 1, 0)
-		endDate := nextMonth.Add(-time.Second)
-		return startDate, endDate, nil
+		startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return startDate, startDate.AddDate(0, 1, 0), nil
 	case "tahun ini":
-		startDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
-		nextYear := startDate.AddDate(1, 0, 0)
-		endDate := nextYear.Add(-time.Second)
-		return startDate, endDate, nil
+		startDate := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return startDate, startDate.AddDate(1, 0, 0), nil
 	default:
 		return time.Time{}, time.Time{}, errors.New("tipe filter tidak valid")
 	}
 }
+
+func (s *OrderService) CreateReturn(userID uint64, request *dto.CreateReturnRequest, photoURLs []string) (*entities.OrderReturnModels, error) {
+	orders, err := s.repo.GetOrderById(request.OrderID)
+	if err != nil || orders.UserID != userID {
+		return nil, errors.New("pesanan tidak ditemukan")
+	}
+	if orders.OrderStatus != order.OrderStatusDone {
+		return nil, errors.New("hanya pesanan yang sudah selesai yang dapat dikembalikan")
+	}
+	if existing, err := s.repo.GetActiveReturnByOrderID(orders.ID); err == nil && existing != nil {
+		return nil, errors.New("pengajuan pengembalian untuk pesanan ini sudah ada")
+	}
+
+	photos := make([]entities.OrderReturnPhotoModels, 0, len(photoURLs))
+	for _, photoURL := range photoURLs {
+		photos = append(photos, entities.OrderReturnPhotoModels{ImageURL: photoURL})
+	}
+
+	newReturn := &entities.OrderReturnModels{
+		OrderID: orders.ID,
+		UserID:  userID,
+		Reason:  request.Reason,
+		Status:  order.ReturnStatusPending,
+		Photos:  photos,
+	}
+	result, err := s.repo.CreateReturn(newReturn)
+	if err != nil {
+		return nil, errors.New("gagal membuat pengajuan pengembalian")
+	}
+
+	return result, nil
+}
+
+func (s *OrderService) GetReturns(page, perPage int, status string) ([]*entities.OrderReturnModels, int64, error) {
+	orderReturns, err := s.repo.FindAllReturns(page, perPage, status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalItems, err := s.repo.GetTotalReturnCount(status)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orderReturns, totalItems, nil
+}
+
+func (s *OrderService) GetReturnsByUserID(userID uint64) ([]*entities.OrderReturnModels, error) {
+	orderReturns, err := s.repo.GetReturnsByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan daftar pengembalian")
+	}
+	return orderReturns, nil
+}
+
+func (s *OrderService) GetReturnById(returnID uint64) (*entities.OrderReturnModels, error) {
+	orderReturn, err := s.repo.GetReturnById(returnID)
+	if err != nil {
+		return nil, errors.New("pengajuan pengembalian tidak ditemukan")
+	}
+	return orderReturn, nil
+}
+
+func (s *OrderService) ApproveReturn(returnID uint64, actor order.Actor, note string) error {
+	orderReturn, err := s.repo.GetReturnById(returnID)
+	if err != nil {
+		return errors.New("pengajuan pengembalian tidak ditemukan")
+	}
+	if note == "" {
+		note = "pengembalian disetujui"
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockReturn(returnID)
+		if err != nil {
+			return errors.New("pengajuan pengembalian tidak ditemukan")
+		}
+		if current.Status != order.ReturnStatusPending {
+			return errors.New("pengajuan pengembalian sudah diproses")
+		}
+
+		orders, err := repo.LockOrder(current.OrderID)
+		if err != nil {
+			return errors.New("pesanan tidak ditemukan")
+		}
+		if err := order.ValidateOrderStatusTransition(orders.OrderStatus, order.OrderStatusReturned); err != nil {
+			return err
+		}
+
+		productService := s.productService.WithTx(tx)
+		for _, orderDetail := range orderReturn.Order.OrderDetails {
+			if err := productService.IncreaseStock(orderDetail.ProductID, variantIDOf(orderDetail.VariantID), orderDetail.Quantity, product.MovementReturn, current.OrderID); err != nil {
+				return errors.New("gagal menambah stok produk")
+			}
+		}
+
+		statusUpdate := &dto.UpdateOrderStatus{
+			OrderID:         orders.ID,
+			StatusOrderDate: time.Now(),
+			OrderStatus:     order.OrderStatusReturned,
+			ExtraInfo:       note,
+		}
+		if err := repo.UpdateOrderStatus(statusUpdate); err != nil {
+			return err
+		}
+		if err := s.recordStatusHistory(repo, orders.ID, order.StatusTypeOrder, orders.OrderStatus, order.OrderStatusReturned, actor, note); err != nil {
+			return err
+		}
+		if err := repo.UpdateReturnStatus(returnID, order.ReturnStatusApproved, note, actor.ID); err != nil {
+			return errors.New("gagal memperbarui pengajuan pengembalian")
+		}
+
+		refund := &entities.OrderRefundModels{
+			OrderID:       orders.ID,
+			ReturnID:      returnID,
+			Amount:        orders.TotalAmountPaid,
+			ReversedExp:   orders.GrandTotalExp,
+			ReversedGram:  orders.GrandTotalGramPlastic,
+			ProcessedBy:   actor.ID,
+			PaymentMethod: orders.PaymentMethod,
+		}
+		if err := repo.CreateRefund(refund); err != nil {
+			return errors.New("gagal mencatat refund pesanan")
+		}
+
+		if err := s.userService.WithTx(tx).ReverseUserRewards(orderReturn.UserID, refund.ReversedExp, refund.ReversedGram); err != nil {
+			return err
+		}
+
+		return s.queueOrderNotification(tx, dto.SendNotificationOrderRequest{
+			OrderID:     orders.ID,
+			UserID:      orderReturn.UserID,
+			OrderStatus: order.OrderStatusReturned,
+		})
+	})
+}
+
+func (s *OrderService) RejectReturn(returnID uint64, actor order.Actor, note string) error {
+	if note == "" {
+		return errors.New("alasan penolakan wajib diisi")
+	}
+
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		current, err := repo.LockReturn(returnID)
+		if err != nil {
+			return errors.New("pengajuan pengembalian tidak ditemukan")
+		}
+		if current.Status != order.ReturnStatusPending {
+			return errors.New("pengajuan pengembalian sudah diproses")
+		}
+		if err := repo.UpdateReturnStatus(returnID, order.ReturnStatusRejected, note, actor.ID); err != nil {
+			return errors.New("gagal memperbarui pengajuan pengembalian")
+		}
+		return nil
+	})
+}
+
+// variantIDOf unwraps an optional variant reference, zero meaning none.
+func variantIDOf(variantID *uint64) uint64 {
+	if variantID == nil {
+		return 0
+	}
+	return *variantID
+}
@@ -15,19 +15,28 @@ import (
 	assistants "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/service"
 	cartMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/mocks"
 	cart "github.com/capstone-kelompok-7/backend-disappear/module/feature/cart/service"
-	fcmMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/mocks"
-	fcm "github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm/service"
+	notificationMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/mocks"
+	notification "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/service"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
 	orders "github.com/capstone-kelompok-7/backend-disappear/module/feature/order/mocks"
 	productsMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
 	products "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/service"
+	shipping "github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/service"
 	userMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
 	user "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/service"
 	voucherMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/mocks"
 	vouchers "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/service"
+	databaseMocks "github.com/capstone-kelompok-7/backend-disappear/utils/database/mocks"
 	utils "github.com/capstone-kelompok-7/backend-disappear/utils/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/payment"
+	paymentMocks "github.com/capstone-kelompok-7/backend-disappear/utils/payment/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/tracking"
+	trackingMocks "github.com/capstone-kelompok-7/backend-disappear/utils/tracking/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func setupOrderService(t *testing.T) (
@@ -39,7 +48,7 @@ func setupOrderService(t *testing.T) (
 	*voucherMocks.RepositoryVoucherInterface,
 	*addressMock.RepositoryAddressInterface,
 	*cartMocks.RepositoryCartInterface,
-	*fcmMocks.RepositoryFcmInterface,
+	*notificationMocks.RepositoryNotificationInterface,
 	*utils.GeneratorInterface,
 ) {
 	orderRepo := orders.NewRepositoryOrderInterface(t)
@@ -51,22 +60,36 @@ func setupOrderService(t *testing.T) (
 	voucherRepo := voucherMocks.NewRepositoryVoucherInterface(t)
 	addressRepo := addressMock.NewRepositoryAddressInterface(t)
 	cartRepo := cartMocks.NewRepositoryCartInterface(t)
-	fcmRepo := fcmMocks.NewRepositoryFcmInterface(t)
+	notificationRepo := notificationMocks.NewRepositoryNotificationInterface(t)
 
 	assistantService := assistants.NewAssistantService(assistantRepo, nil, config.Config{})
-	productService := products.NewProductService(productRepo, assistantService)
-	userService := user.NewUserService(userRepo, hashRepo)
-	voucherService := vouchers.NewVoucherService(voucherRepo, userService)
+	productService := products.NewProductService(productRepo, assistantService, nil, nil, nil, nil, 0)
+	userService := user.NewUserService(userRepo, hashRepo, nil, nil)
+	voucherService := vouchers.NewVoucherService(voucherRepo, userService, productService, nil, nil)
 	addressService := address.NewAddressService(addressRepo)
 	cartService := cart.NewCartService(cartRepo, productService)
-	fcmService := fcm.NewFcmService(fcmRepo)
-	orderService := NewOrderService(orderRepo, generatorRepo, productService, voucherService, addressService, userService, cartService, fcmService)
-
-	return orderService.(*OrderService), orderRepo, userRepo, productRepo, assistantRepo, voucherRepo, addressRepo, cartRepo, fcmRepo, generatorRepo
+	notificationService := notification.NewNotificationService(notificationRepo, nil, userService, nil)
+	shippingService := shipping.NewShippingService(shippingrate.NewLocalRateTable(), "Jakarta", addressService, productService)
+	txManager := databaseMocks.NewTransactionManagerInterface(t)
+	txManager.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error {
+		return fn(nil)
+	}).Maybe()
+	orderRepo.On("WithTx", mock.Anything).Return(orderRepo).Maybe()
+	productRepo.On("WithTx", mock.Anything).Return(productRepo).Maybe()
+	cartRepo.On("WithTx", mock.Anything).Return(cartRepo).Maybe()
+	voucherRepo.On("WithTx", mock.Anything).Return(voucherRepo).Maybe()
+	notificationRepo.On("WithTx", mock.Anything).Return(notificationRepo).Maybe()
+	userRepo.On("WithTx", mock.Anything).Return(userRepo).Maybe()
+	paymentGateway := paymentMocks.NewPaymentGateway(t)
+	orderService := NewOrderService(orderRepo, generatorRepo, productService, voucherService, addressService, userService, cartService, notificationService, txManager, paymentGateway, shippingService, trackingMocks.NewTracker(t))
+
+	return orderService.(*OrderService), orderRepo, userRepo, productRepo, assistantRepo, voucherRepo, addressRepo, cartRepo, notificationRepo, generatorRepo
 }
 
 func TestGetFilterDateRange(t *testing.T) {
 	service := &OrderService{}
+	now := time.Now()
+	startOfWeek := time.Date(now.Year(), now.Month(), now.Day()-int(now.Weekday()), 0, 0, 0, 0, time.Local)
 
 	tests := []struct {
 		filterType    string
@@ -76,21 +99,18 @@ func TestGetFilterDateRange(t *testing.T) {
 	}{
 		{
 			filterType:    "minggu ini",
-			expectedStart: time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour),
-			expectedEnd:   time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour),
-			expectedError: nil,
+			expectedStart: startOfWeek,
+			expectedEnd:   startOfWeek.AddDate(0, 0, 7),
 		},
 		{
 			filterType:    "bulan ini",
-			expectedStart: time.Date(time.Now().Year(), time.Now().Month(), 1, 0, 0, 0, 0, time.UTC),
-			expectedEnd:   time.Date(time.Now().Year(), time.Now().Month()+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
-			expectedError: nil,
+			expectedStart: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.Local),
 		},
 		{
 			filterType:    "tahun ini",
-			expectedStart: time.Date(time.Now().Year(), 1, 1, 0, 0, 0, 0, time.UTC),
-			expectedEnd:   time.Date(time.Now().Year()+1, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second),
-			expectedError: nil,
+			expectedStart: time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.Local),
+			expectedEnd:   time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, time.Local),
 		},
 		{
 			filterType:    "invalid",
@@ -156,105 +176,6 @@ func TestOrderService_PaginationFunctions(t *testing.T) {
 	})
 }
 
-func TestOrderService_GetAll(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-
-	order := []*entities.OrderModels{
-		{ID: "order1100sada", IdOrder: "13123sasdasd", AddressID: 1, UserID: 1},
-		{ID: "123123order", IdOrder: "123888jjjhhss", AddressID: 1, UserID: 1},
-	}
-
-	t.Run("Success Case - Order Found", func(t *testing.T) {
-		expectedTotalItems := int64(10)
-		orderRepo.On("FindAll", 1, 8).Return(order, nil).Once()
-		orderRepo.On("GetTotalOrderCount").Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetAll(1, 8)
-
-		assert.NoError(t, err)
-		assert.Equal(t, len(order), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - GetTotalOrderCount Error", func(t *testing.T) {
-		expectedErr := errors.New(" GetTotalOrderCount Error")
-		orderRepo.On("FindAll", 1, 8).Return(order, nil).Once()
-		orderRepo.On("GetTotalOrderCount").Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetAll(1, 8)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, int64(0), totalItems)
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - GetAll Error", func(t *testing.T) {
-		expectedErr := errors.New("FindAll Error")
-		orderRepo.On("FindAll", 1, 8).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetAll(1, 8)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, int64(0), totalItems)
-		orderRepo.AssertExpectations(t)
-	})
-
-}
-
-func TestOrderService_GetOrdersByName(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-
-	order := []*entities.OrderModels{
-		{ID: "order1100sada", IdOrder: "13123sasdasd", AddressID: 1, UserID: 1},
-		{ID: "123123order", IdOrder: "123888jjjhhss", AddressID: 1, UserID: 1},
-	}
-
-	name := "Test"
-
-	t.Run("Success Case - Orders Found by Name", func(t *testing.T) {
-		expectedTotalItems := int64(10)
-		orderRepo.On("FindByName", 1, 8, name).Return(order, nil).Once()
-		orderRepo.On("GetTotalCustomerCountByName", name).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrdersByName(1, 8, name)
-
-		assert.NoError(t, err)
-		assert.Equal(t, len(order), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Finding Orders by Name", func(t *testing.T) {
-		expectedErr := errors.New("failed to find orders by name")
-		orderRepo.On("FindByName", 1, 8, name).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrdersByName(1, 8, name)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Getting Total Order Count by Name", func(t *testing.T) {
-		expectedErr := errors.New("failed to get total order count by name")
-		orderRepo.On("FindByName", 1, 8, name).Return(order, nil).Once()
-		orderRepo.On("GetTotalCustomerCountByName", name).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrdersByName(1, 8, name)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		orderRepo.AssertExpectations(t)
-	})
-}
-
 func TestOrderService_GetOrderById(t *testing.T) {
 	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
 
@@ -340,38 +261,188 @@ func TestOrderService_ProcessManualPayment(t *testing.T) {
 }
 
 func TestOrderService_ProcessGatewayPayment(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+	orderService, _, _, _, _, _, _, _, _, _ := setupOrderService(t)
+	paymentGateway := paymentMocks.NewPaymentGateway(t)
+	orderService.paymentGateway = paymentGateway
 	orderID := "order123"
 	totalAmountPaid := uint64(50000)
 	paymentMethod := "credit_card"
+	name := "John"
+	email := "john@example.com"
+	chargeRequest := payment.ChargeRequest{
+		OrderID:       orderID,
+		Amount:        int64(totalAmountPaid),
+		PaymentMethod: paymentMethod,
+		Name:          name,
+		Email:         email,
+	}
 
 	t.Run("Success Case - Process Gateway Payment", func(t *testing.T) {
 		expectedResult := map[string]interface{}{
 			"payment_status": "success",
 		}
 
-		orderRepo.On("ProcessGatewayPayment", totalAmountPaid, orderID, paymentMethod).Return(expectedResult, nil).Once()
+		paymentGateway.On("Charge", chargeRequest).Return(expectedResult, nil).Once()
 
-		result, err := orderService.ProcessGatewayPayment(totalAmountPaid, orderID, paymentMethod)
+		result, err := orderService.ProcessGatewayPayment(totalAmountPaid, orderID, paymentMethod, name, email)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, expectedResult, result)
-		orderRepo.AssertExpectations(t)
+		paymentGateway.AssertExpectations(t)
 	})
 
 	t.Run("Failed Case - Payment Failure", func(t *testing.T) {
 		expectedErr := errors.New("payment failed")
-		orderRepo.On("ProcessGatewayPayment", totalAmountPaid, orderID, paymentMethod).Return(nil, expectedErr).Once()
+		paymentGateway.On("Charge", chargeRequest).Return(nil, expectedErr).Once()
 
-		result, err := orderService.ProcessGatewayPayment(totalAmountPaid, orderID, paymentMethod)
+		result, err := orderService.ProcessGatewayPayment(totalAmountPaid, orderID, paymentMethod, name, email)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.Equal(t, expectedErr, err)
+		paymentGateway.AssertExpectations(t)
+	})
+
+}
+
+func signedNotification(orderID, transactionStatus, statusCode, serverKey string) map[string]any {
+	return map[string]any{
+		"order_id":           orderID,
+		"transaction_status": transactionStatus,
+		"status_code":        statusCode,
+		"gross_amount":       "10000.00",
+		"signature_key":      payment.NotificationSignature(orderID, statusCode, "10000.00", serverKey),
+	}
+}
+
+func TestOrderService_CallBack(t *testing.T) {
+	orderID := "order_id_1"
+	serverKey := "server-key"
+
+	t.Run("Success Case - Settlement Confirms Payment", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		gateway := payment.NewFakeGateway("", serverKey)
+		orderService.paymentGateway = gateway
+		mockOrder := &entities.OrderModels{
+			ID:            orderID,
+			UserID:        1,
+			OrderStatus:   order.OrderStatusWaiting,
+			PaymentStatus: order.PaymentStatusWaiting,
+		}
+
+		_, err := gateway.Charge(payment.ChargeRequest{OrderID: orderID, Amount: 10000, PaymentMethod: "qris"})
+		assert.NoError(t, err)
+		assert.NoError(t, gateway.Settle(orderID))
+
+		orderRepo.On("GetWebhookLog", orderID+":settlement:200").Return(nil, gorm.ErrRecordNotFound).Once()
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil)
+		orderRepo.On("LockOrder", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("ConfirmPayment", orderID, order.OrderStatusProcess, order.PaymentStatusConfirmed).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.Anything).Return(nil).Twice()
+		orderRepo.On("SaveWebhookLog", mock.MatchedBy(func(l *entities.PaymentWebhookLogModels) bool {
+			return l.IdempotencyKey == orderID+":settlement:200" && l.Result == order.WebhookResultProcessed
+		})).Return(nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(&entities.UserModels{ID: 1, Level: "Bronze"}, nil)
+		userRepo.On("AddUserRewards", uint64(1), uint64(0), uint64(0)).Return(nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		err = orderService.CallBack(signedNotification(orderID, "settlement", "200", serverKey))
+
+		assert.NoError(t, err)
 		orderRepo.AssertExpectations(t)
 	})
 
+	t.Run("Success Case - Duplicate Notification Is Acknowledged", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderService.paymentGateway = payment.NewFakeGateway("", serverKey)
+		webhookLog := &entities.PaymentWebhookLogModels{
+			IdempotencyKey: orderID + ":settlement:200",
+			Result:         order.WebhookResultProcessed,
+		}
+		orderRepo.On("GetWebhookLog", orderID+":settlement:200").Return(webhookLog, nil).Once()
+
+		err := orderService.CallBack(signedNotification(orderID, "settlement", "200", serverKey))
+
+		assert.NoError(t, err)
+		orderRepo.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything, mock.Anything)
+		userRepo.AssertNotCalled(t, "AddUserRewards", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Case - Out Of Order Notification Is Ignored", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		gateway := payment.NewFakeGateway("", serverKey)
+		orderService.paymentGateway = gateway
+		paidOrder := &entities.OrderModels{
+			ID:            orderID,
+			OrderStatus:   order.OrderStatusProcess,
+			PaymentStatus: order.PaymentStatusConfirmed,
+		}
+
+		_, err := gateway.Charge(payment.ChargeRequest{OrderID: orderID, Amount: 10000, PaymentMethod: "qris"})
+		assert.NoError(t, err)
+		assert.NoError(t, gateway.Expire(orderID))
+
+		orderRepo.On("GetWebhookLog", orderID+":expire:202").Return(nil, gorm.ErrRecordNotFound).Once()
+		orderRepo.On("GetOrderById", orderID).Return(paidOrder, nil).Once()
+		orderRepo.On("SaveWebhookLog", mock.MatchedBy(func(l *entities.PaymentWebhookLogModels) bool {
+			return l.Result == order.WebhookResultIgnored
+		})).Return(nil).Once()
+
+		err = orderService.CallBack(signedNotification(orderID, "expire", "202", serverKey))
+
+		assert.NoError(t, err)
+		orderRepo.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Case - Denied Payment Is Ignored", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		gateway := payment.NewFakeGateway(payment.TransactionDeny, serverKey)
+		orderService.paymentGateway = gateway
+		mockOrder := &entities.OrderModels{
+			ID:            orderID,
+			OrderStatus:   order.OrderStatusWaiting,
+			PaymentStatus: order.PaymentStatusWaiting,
+		}
+
+		_, err := gateway.Charge(payment.ChargeRequest{OrderID: orderID, Amount: 10000, PaymentMethod: "gopay"})
+		assert.NoError(t, err)
+
+		orderRepo.On("GetWebhookLog", orderID+":deny:202").Return(nil, gorm.ErrRecordNotFound).Once()
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("SaveWebhookLog", mock.Anything).Return(nil).Once()
+
+		err = orderService.CallBack(signedNotification(orderID, "deny", "202", serverKey))
+
+		assert.NoError(t, err)
+		orderRepo.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Invalid Signature", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderService.paymentGateway = payment.NewFakeGateway("", serverKey)
+
+		err := orderService.CallBack(signedNotification(orderID, "settlement", "200", "forged-key"))
+
+		assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+		orderRepo.AssertNotCalled(t, "GetWebhookLog", mock.Anything)
+	})
+
+	t.Run("Failed Case - Unknown Transaction", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderService.paymentGateway = payment.NewFakeGateway("", serverKey)
+
+		orderRepo.On("GetWebhookLog", orderID+":settlement:200").Return(nil, gorm.ErrRecordNotFound).Once()
+		orderRepo.On("SaveWebhookLog", mock.MatchedBy(func(l *entities.PaymentWebhookLogModels) bool {
+			return l.Result == order.WebhookResultFailed
+		})).Return(nil).Once()
+
+		err := orderService.CallBack(signedNotification(orderID, "settlement", "200", serverKey))
+
+		assert.EqualError(t, err, "transaksi tidak ditemukan")
+	})
 }
 
 func TestOrderService_GetAllOrdersByUserID(t *testing.T) {
@@ -479,801 +550,214 @@ func TestOrderService_GetAllOrdersWithFilter(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 
 		userRepo.AssertExpectations(t)
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_Tracking(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-
-	courier := "JNE"
-	awb := "123456789"
-
-	t.Run("Success Case - Tracking Info Found", func(t *testing.T) {
-		expectedTrackingInfo := map[string]interface{}{
-			"status":      "In Transit",
-			"destination": "Your Location",
-		}
-		orderRepo.On("Tracking", courier, awb).Return(expectedTrackingInfo, nil).Once()
-
-		result, err := orderService.Tracking(courier, awb)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, expectedTrackingInfo, result)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Tracking Info Not Found", func(t *testing.T) {
-		expectedErr := errors.New("tracking information not found")
-		orderRepo.On("Tracking", courier, awb).Return(nil, expectedErr).Once()
-
-		result, err := orderService.Tracking(courier, awb)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByDateRange(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	userID := uint64(123)
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", UserID: userID},
-	}
-
-	t.Run("Success Case - Filter by Week", func(t *testing.T) {
-		filterType := "Minggu Ini"
-		page := 1
-		perPage := 8
-
-		startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-		endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByDateRange", startOfWeek, endOfWeek, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRange", startOfWeek, endOfWeek).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Success Case - Filter by Month", func(t *testing.T) {
-		filterType := "Bulan Ini"
-		page := 1
-		perPage := 8
-
-		now := time.Now()
-		startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
-		nextMonth := startOfMonth.AddDate(0, 1, 0)
-		endOfMonth := nextMonth.Add(-time.Second)
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByDateRange", startOfMonth, endOfMonth, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRange", startOfMonth, endOfMonth).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Success Case - Filter by Year", func(t *testing.T) {
-		filterType := "Tahun Ini"
-		page := 1
-		perPage := 8
-
-		now := time.Now()
-		startOfYear := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
-		nextYear := startOfYear.AddDate(1, 0, 0)
-		endOfYear := nextYear.Add(-time.Second)
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByDateRange", startOfYear, endOfYear, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRange", startOfYear, endOfYear).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		filterType := "Invalid Type"
-		page := 1
-		perPage := 8
-
-		expectedErr := errors.New("tipe filter tidak valid")
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr.Error(), err.Error())
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Fetching Orders", func(t *testing.T) {
-		filterType := "Minggu Ini"
-		page := 1
-		perPage := 8
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-
-		orderRepo.On("GetOrderByDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Fetching Order Count", func(t *testing.T) {
-		filterType := "Minggu Ini"
-		page := 1
-		perPage := 8
-
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-
-		orderRepo.On("GetOrderByDateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRange", mock.Anything, mock.Anything).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRange(filterType, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-}
-
-func TestOrderService_GetOrderByOrderStatus(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", OrderStatus: "Pending"},
-		{ID: "order456", OrderStatus: "Shipped"},
-	}
-
-	t.Run("Success Case - Get Orders by Order Status", func(t *testing.T) {
-		orderStatus := "Pending"
-		page := 1
-		perPage := 8
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByOrderStatus", orderStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByByOrderStatus", orderStatus).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByOrderStatus(orderStatus, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Getting Total Order Count by Order Status", func(t *testing.T) {
-		orderStatus := "Pending"
-		page := 1
-		perPage := 8
-
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-
-		orderRepo.On("GetOrderByOrderStatus", orderStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByByOrderStatus", orderStatus).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByOrderStatus(orderStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-		orderStatus := "Pending"
-		page := 1
-		perPage := 8
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-
-		orderRepo.On("GetOrderByOrderStatus", orderStatus, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByOrderStatus(orderStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByDateRangeAndStatus(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	orderStatus := "Pending"
-	filterType := "Minggu Ini"
-	page := 1
-	perPage := 8
-
-	startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-	endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", OrderStatus: "Pending"},
-		{ID: "order456", OrderStatus: "Shipped"},
-	}
-
-	t.Run("Success Case - Filter by Week and Status", func(t *testing.T) {
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByDateRangeAndStatus", startOfWeek, endOfWeek, orderStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndStatus", startOfWeek, endOfWeek, orderStatus).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatus(filterType, orderStatus, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		invalidFilter := "InvalidFilter"
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatus(invalidFilter, orderStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.EqualError(t, err, "tipe filter tidak valid")
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-		orderRepo.On("GetOrderByDateRangeAndStatus", startOfWeek, endOfWeek, orderStatus, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatus(filterType, orderStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Order Status", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-		orderRepo.On("GetOrderByDateRangeAndStatus", startOfWeek, endOfWeek, orderStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndStatus", startOfWeek, endOfWeek, orderStatus).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatus(filterType, orderStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByDateRangeAndStatusAndSearch(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	orderStatus := "Pending"
-	search := "name"
-	filterType := "Minggu Ini"
-	page := 1
-	perPage := 8
-
-	startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-	endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", OrderStatus: "Pending"},
-		{ID: "order456", OrderStatus: "Shipped"},
-	}
-
-	t.Run("Success Case - Order Found", func(t *testing.T) {
-
-		orderRepo.On("GetOrderByDateRangeAndStatusAndSearch", startOfWeek, endOfWeek, orderStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndStatusAndSearch", startOfWeek, endOfWeek, orderStatus, search).Return(int64(len(expectedOrders)), nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatusAndSearch(filterType, orderStatus, search, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, int64(len(expectedOrders)), totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		invalidFilter := "InvalidFilter"
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatusAndSearch(invalidFilter, orderStatus, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.EqualError(t, err, "tipe filter tidak valid")
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-		orderRepo.On("GetOrderByDateRangeAndStatusAndSearch", startOfWeek, endOfWeek, orderStatus, search, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatusAndSearch(filterType, orderStatus, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Order Status And Search", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-		orderRepo.On("GetOrderByDateRangeAndStatusAndSearch", startOfWeek, endOfWeek, orderStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndStatusAndSearch", startOfWeek, endOfWeek, orderStatus, search).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndStatusAndSearch(filterType, orderStatus, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-}
-
-func TestOrderService_GetOrderBySearchAndDateRange(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	search := "name"
-	filterType := "Minggu Ini"
-	page := 1
-	perPage := 8
-
-	startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-	endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", OrderStatus: "Pending"},
-		{ID: "order456", OrderStatus: "Shipped"},
-	}
-
-	t.Run("Success Case - Order Found", func(t *testing.T) {
-
-		orderRepo.On("GetOrdersBySearchAndDateRange", startOfWeek, endOfWeek, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountBySearchAndDateRange", startOfWeek, endOfWeek, search).Return(int64(len(expectedOrders)), nil).Once()
-
-		result, totalItems, err := orderService.GetOrderBySearchAndDateRange(filterType, search, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, int64(len(expectedOrders)), totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		invalidFilter := "InvalidFilter"
-
-		result, totalItems, err := orderService.GetOrderBySearchAndDateRange(invalidFilter, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.EqualError(t, err, "tipe filter tidak valid")
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-		orderRepo.On("GetOrdersBySearchAndDateRange", startOfWeek, endOfWeek, search, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderBySearchAndDateRange(filterType, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Search", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-		orderRepo.On("GetOrdersBySearchAndDateRange", startOfWeek, endOfWeek, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountBySearchAndDateRange", startOfWeek, endOfWeek, search).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderBySearchAndDateRange(filterType, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrdersBySearchAndStatus(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	search := "name"
-	orderStatus := "Pending"
-	page := 1
-	perPage := 8
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", OrderStatus: "Pending"},
-		{ID: "order456", OrderStatus: "Shipped"},
-	}
-
-	t.Run("Success Case - Order Found", func(t *testing.T) {
-
-		orderRepo.On("GetOrdersBySearchAndStatus", orderStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrdersCountBySearchAndStatus", orderStatus, search).Return(int64(len(expectedOrders)), nil).Once()
-
-		result, totalItems, err := orderService.GetOrdersBySearchAndStatus(orderStatus, search, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, int64(len(expectedOrders)), totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pesanan tidak ditemukan")
-		orderRepo.On("GetOrdersBySearchAndStatus", orderStatus, search, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrdersBySearchAndStatus(orderStatus, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Search", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pesanan")
-		orderRepo.On("GetOrdersBySearchAndStatus", orderStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrdersCountBySearchAndStatus", orderStatus, search).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrdersBySearchAndStatus(orderStatus, search, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByPaymentStatus(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	paymentStatus := "Confirm"
-	page := 1
-	perPage := 8
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", PaymentStatus: "Pending"},
-		{ID: "order456", PaymentStatus: "Confirm"},
-	}
-
-	t.Run("Success Case - Get Orders by Payment Status", func(t *testing.T) {
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByPaymentStatus", paymentStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByByPaymentStatus", paymentStatus).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByPaymentStatus(paymentStatus, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Getting Total Order Count by  Payment Status", func(t *testing.T) {
-
-		expectedErr := errors.New("gagal mendapatkan total pembayaran")
-
-		orderRepo.On("GetOrderByPaymentStatus", paymentStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByByPaymentStatus", paymentStatus).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByPaymentStatus(paymentStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pembayaran tidak ditemukan")
-
-		orderRepo.On("GetOrderByPaymentStatus", paymentStatus, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByPaymentStatus(paymentStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByDateRangeAndPaymentStatus(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	paymentStatus := "Confirm"
-	filterType := "Minggu Ini"
-	page := 1
-	perPage := 8
-
-	startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-	endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", PaymentStatus: "Pending"},
-		{ID: "order456", PaymentStatus: "Confirm"},
-	}
-
-	t.Run("Success Case - Filter by Week and Status", func(t *testing.T) {
-
-		expectedTotalItems := int64(len(expectedOrders))
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatus", startOfWeek, endOfWeek, paymentStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndPaymentStatus", startOfWeek, endOfWeek, paymentStatus).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatus(filterType, paymentStatus, page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		invalidFilter := "InvalidFilter"
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatus(invalidFilter, paymentStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.EqualError(t, err, "tipe filter tidak valid")
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pembayaran tidak ditemukan")
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatus", startOfWeek, endOfWeek, paymentStatus, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatus(filterType, paymentStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Payment Status", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pembayaran")
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatus", startOfWeek, endOfWeek, paymentStatus, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndPaymentStatus", startOfWeek, endOfWeek, paymentStatus).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatus(filterType, paymentStatus, page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-
-		orderRepo.AssertExpectations(t)
-	})
-}
-
-func TestOrderService_GetOrderByDateRangeAndPaymentStatusAndSearch(t *testing.T) {
-	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	paymentStatus := "Confirm"
-	search := "name"
-	filterType := "Minggu Ini"
-	page := 1
-	perPage := 8
-
-	startOfWeek := time.Now().In(time.UTC).AddDate(0, 0, -int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-	endOfWeek := time.Now().In(time.UTC).AddDate(0, 0, 7-int(time.Now().In(time.UTC).Weekday())).Truncate(24 * time.Hour)
-
-	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", PaymentStatus: "Pending"},
-		{ID: "order456", PaymentStatus: "Confirm"},
-	}
+		orderRepo.AssertExpectations(t)
+	})
+}
 
-	t.Run("Success Case - Order Found", func(t *testing.T) {
+func TestOrderService_GetShipmentByOrderID(t *testing.T) {
+	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
 
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatusAndSearch", startOfWeek, endOfWeek, paymentStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndPaymentStatusAndSearch", startOfWeek, endOfWeek, paymentStatus, search).Return(int64(len(expectedOrders)), nil).Once()
+	t.Run("Success Case - Shipment Found", func(t *testing.T) {
+		expectedShipment := &entities.ShipmentModels{ID: 1, OrderID: "order_id_1", Courier: "jne", Awb: "123456789"}
+		orderRepo.On("GetShipmentByOrderID", "order_id_1").Return(expectedShipment, nil).Once()
 
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatusAndSearch(filterType, paymentStatus, search, page, perPage)
+		result, err := orderService.GetShipmentByOrderID("order_id_1")
 
 		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, int64(len(expectedOrders)), totalItems)
+		assert.Equal(t, expectedShipment, result)
 
 		orderRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failed Case - Invalid Filter Type", func(t *testing.T) {
-		invalidFilter := "InvalidFilter"
+	t.Run("Failed Case - Shipment Not Found", func(t *testing.T) {
+		orderRepo.On("GetShipmentByOrderID", "order_id_1").Return(nil, gorm.ErrRecordNotFound).Once()
 
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatusAndSearch(invalidFilter, paymentStatus, search, page, perPage)
+		result, err := orderService.GetShipmentByOrderID("order_id_1")
 
-		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.EqualError(t, err, "tipe filter tidak valid")
+		assert.EqualError(t, err, "pengiriman tidak ditemukan")
 
 		orderRepo.AssertExpectations(t)
 	})
+}
 
-	t.Run("Failed Case - Order Not Found", func(t *testing.T) {
-
-		expectedErr := errors.New("pembayaran tidak ditemukan")
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatusAndSearch", startOfWeek, endOfWeek, paymentStatus, search, 0, perPage).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatusAndSearch(filterType, paymentStatus, search, page, perPage)
+func TestOrderService_SyncShipments(t *testing.T) {
+	t.Run("Success Case - Delivered Package Completes Order", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		tracker := tracking.NewFakeTracker()
+		tracker.Deliver("jne", "123456789")
+		orderService.tracker = tracker
+		shipment := &entities.ShipmentModels{ID: 1, OrderID: "order_id_1", Courier: "jne", Awb: "123456789"}
+		mockOrder := &entities.OrderModels{ID: "order_id_1", UserID: 1, OrderStatus: order.OrderStatusShipping}
+
+		orderRepo.On("GetShipmentsInTransit", syncShipmentsBatchSize).Return([]*entities.ShipmentModels{shipment}, nil).Once()
+		orderRepo.On("UpdateShipmentTracking", mock.MatchedBy(func(s *entities.ShipmentModels) bool {
+			return s.Status == tracking.StatusDelivered && s.DeliveredAt != nil && s.LastSyncedAt != nil
+		}), mock.MatchedBy(func(events []entities.ShipmentEventModels) bool {
+			return len(events) == 2 && events[0].ShipmentID == 1
+		})).Return(nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("AcceptOrder", "order_id_1", order.OrderStatusDone).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.MatchedBy(func(h *entities.OrderStatusHistoryModels) bool {
+			return h.ActorRole == order.ActorRoleSystem && h.FromStatus == order.OrderStatusShipping && h.ToStatus == order.OrderStatusDone
+		})).Return(nil).Once()
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(&entities.UserModels{ID: 1, Name: "John"}, nil)
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
+		completed, err := orderService.SyncShipments()
 
+		assert.NoError(t, err)
+		assert.Equal(t, 1, completed)
 		orderRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failed Case -  Error Getting Total Order Count by Date Range And Order Status And Search", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pembayaran")
-		orderRepo.On("GetOrderByDateRangeAndPaymentStatusAndSearch", startOfWeek, endOfWeek, paymentStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountByDateRangeAndPaymentStatusAndSearch", startOfWeek, endOfWeek, paymentStatus, search).Return(int64(0), expectedErr).Once()
+	t.Run("Success Case - Package Still On The Way", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderService.tracker = tracking.NewFakeTracker()
+		shipment := &entities.ShipmentModels{ID: 1, OrderID: "order_id_1", Courier: "jne", Awb: "123456789"}
 
-		result, totalItems, err := orderService.GetOrderByDateRangeAndPaymentStatusAndSearch(filterType, paymentStatus, search, page, perPage)
+		orderRepo.On("GetShipmentsInTransit", syncShipmentsBatchSize).Return([]*entities.ShipmentModels{shipment}, nil).Once()
+		orderRepo.On("UpdateShipmentTracking", mock.MatchedBy(func(s *entities.ShipmentModels) bool {
+			return s.Status == tracking.StatusOnProcess && s.DeliveredAt == nil
+		}), mock.Anything).Return(nil).Once()
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
+		completed, err := orderService.SyncShipments()
 
+		assert.NoError(t, err)
+		assert.Equal(t, 0, completed)
+		orderRepo.AssertNotCalled(t, "LockOrder", mock.Anything)
+		orderRepo.AssertNotCalled(t, "AcceptOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Case - Tracker Error Skips Shipment", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		tracker := trackingMocks.NewTracker(t)
+		orderService.tracker = tracker
+		shipments := []*entities.ShipmentModels{
+			{ID: 1, OrderID: "order_id_1", Courier: "jne", Awb: "111"},
+			{ID: 2, OrderID: "order_id_2", Courier: "jne", Awb: "222"},
+		}
+
+		orderRepo.On("GetShipmentsInTransit", syncShipmentsBatchSize).Return(shipments, nil).Once()
+		tracker.On("Track", "jne", "111").Return(nil, errors.New("timeout")).Once()
+		tracker.On("Track", "jne", "222").Return(&tracking.Result{Status: tracking.StatusOnProcess}, nil).Once()
+		orderRepo.On("UpdateShipmentTracking", shipments[1], mock.Anything).Return(nil).Once()
+
+		completed, err := orderService.SyncShipments()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, completed)
 		orderRepo.AssertExpectations(t)
 	})
 
+	t.Run("Failed Case - Repository Error", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderRepo.On("GetShipmentsInTransit", syncShipmentsBatchSize).Return(nil, errors.New("db error")).Once()
+
+		completed, err := orderService.SyncShipments()
+
+		assert.EqualError(t, err, "gagal mendapatkan data pengiriman")
+		assert.Equal(t, 0, completed)
+	})
 }
 
-func TestOrderService_GetOrdersBySearchAndPaymentStatus(t *testing.T) {
+func TestOrderService_GetOrders(t *testing.T) {
 	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
-	paymentStatus := "Confirm"
-	search := "name"
-	page := 1
-	perPage := 8
-
 	expectedOrders := []*entities.OrderModels{
-		{ID: "order123", PaymentStatus: "Pending"},
-		{ID: "order456", PaymentStatus: "Confirm"},
+		{ID: "order123", UserID: 7, PaymentMethod: "gopay"},
 	}
 
-	t.Run("Success Case - Order Found", func(t *testing.T) {
-
-		orderRepo.On("GetOrderBySearchAndPaymentStatus", paymentStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountBySearchAndPaymentStatus", paymentStatus, search).Return(int64(len(expectedOrders)), nil).Once()
+	t.Run("Success Case - Filters combine with explicit dates", func(t *testing.T) {
+		startDate := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2023, 11, 30, 23, 59, 59, 0, time.UTC)
+		filter := &order.OrderFilter{
+			StartDate:     &startDate,
+			EndDate:       &endDate,
+			DateFilter:    "minggu ini",
+			OrderStatus:   order.OrderStatusProcess,
+			PaymentStatus: order.PaymentStatusConfirmed,
+			PaymentMethod: "gopay",
+			Search:        "budi",
+			UserID:        7,
+			Sort:          order.SortHighest,
+			Page:          2,
+			PerPage:       5,
+		}
+		orderRepo.On("FindOrders", mock.MatchedBy(func(spec *order.OrderFilter) bool {
+			return spec.StartDate.Equal(startDate) && spec.EndDate.Equal(endDate) &&
+				spec.OrderStatus == order.OrderStatusProcess && spec.PaymentStatus == order.PaymentStatusConfirmed &&
+				spec.PaymentMethod == "gopay" && spec.Search == "budi" && spec.UserID == 7 &&
+				spec.Sort == order.SortHighest && spec.Offset() == 5 && spec.PerPage == 5
+		})).Return(expectedOrders, int64(6), nil).Once()
 
-		result, totalItems, err := orderService.GetOrdersBySearchAndPaymentStatus(paymentStatus, search, page, perPage)
+		result, totalItems, err := orderService.GetOrders(filter)
 
 		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(expectedOrders), len(result))
-		assert.Equal(t, int64(len(expectedOrders)), totalItems)
-
+		assert.Equal(t, expectedOrders, result)
+		assert.Equal(t, int64(6), totalItems)
 		orderRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure Case - Order Not Found", func(t *testing.T) {
+	t.Run("Success Case - Date preset and defaults", func(t *testing.T) {
+		startDate, endDate, _ := orderService.GetFilterDateRange("bulan ini")
+		filter := &order.OrderFilter{DateFilter: "Bulan Ini"}
+		orderRepo.On("FindOrders", mock.MatchedBy(func(spec *order.OrderFilter) bool {
+			return spec.StartDate.Equal(startDate) && spec.EndDate.Equal(endDate) &&
+				spec.Sort == order.DefaultSort && spec.PerPage == order.DefaultPerPage && spec.Offset() == 0
+		})).Return(expectedOrders, int64(1), nil).Once()
+
+		result, totalItems, err := orderService.GetOrders(filter)
 
-		expectedErr := errors.New("pembayaran tidak ditemukan")
-		orderRepo.On("GetOrderBySearchAndPaymentStatus", paymentStatus, search, 0, perPage).Return(nil, expectedErr).Once()
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(1), totalItems)
+		assert.Nil(t, filter.StartDate)
+		orderRepo.AssertExpectations(t)
+	})
 
-		result, totalItems, err := orderService.GetOrdersBySearchAndPaymentStatus(paymentStatus, search, page, perPage)
+	t.Run("Failed Case - Invalid Date Filter", func(t *testing.T) {
+		result, totalItems, err := orderService.GetOrders(&order.OrderFilter{DateFilter: "kemarin"})
 
-		assert.Error(t, err)
+		assert.EqualError(t, err, "tipe filter tidak valid")
 		assert.Nil(t, result)
 		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
+	})
 
-		orderRepo.AssertExpectations(t)
+	t.Run("Failed Case - Invalid Date Range", func(t *testing.T) {
+		startDate := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+		endDate := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+
+		_, _, err := orderService.GetOrders(&order.OrderFilter{StartDate: &startDate, EndDate: &endDate})
+
+		assert.EqualError(t, err, "rentang tanggal tidak valid")
 	})
 
-	t.Run("Failure Case -  Error Getting Total Order Count by Date Range And Search", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total pembayaran")
-		orderRepo.On("GetOrderBySearchAndPaymentStatus", paymentStatus, search, 0, perPage).Return(expectedOrders, nil).Once()
-		orderRepo.On("GetOrderCountBySearchAndPaymentStatus", paymentStatus, search).Return(int64(0), expectedErr).Once()
+	t.Run("Failed Case - Invalid Sort", func(t *testing.T) {
+		_, _, err := orderService.GetOrders(&order.OrderFilter{Sort: "populer"})
 
-		result, totalItems, err := orderService.GetOrdersBySearchAndPaymentStatus(paymentStatus, search, page, perPage)
+		assert.EqualError(t, err, "urutan pesanan tidak valid")
+	})
 
-		assert.Error(t, err)
+	t.Run("Failed Case - Repository Error", func(t *testing.T) {
+		orderRepo.On("FindOrders", mock.Anything).Return(nil, int64(0), errors.New("database error")).Once()
+
+		result, totalItems, err := orderService.GetOrders(&order.OrderFilter{OrderStatus: order.OrderStatusDone})
+
+		assert.EqualError(t, err, "pesanan tidak ditemukan")
 		assert.Nil(t, result)
 		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
 		orderRepo.AssertExpectations(t)
 	})
 }
 
 func TestOrderService_SendNotificationPayment(t *testing.T) {
 	mockUser := &entities.UserModels{
-		ID:          1,
-		Name:        "John",
-		DeviceToken: "device_token_1",
+		ID:   1,
+		Name: "John",
 	}
 
 	mockOrder := &entities.OrderModels{
@@ -1289,13 +773,18 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 
-	orderService, orderRepo, userRepo, _, _, _, _, _, fcmRepo, _ := setupOrderService(t)
+	orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
 
 	t.Run("Success Case - SendNotificationPayment", func(t *testing.T) {
 		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 2 && outbox[1].Template == "order_receipt" && outbox[1].Data != ""
+		})).Return(nil).Once()
 
 		request := dto.SendNotificationPaymentRequest{
 			UserID:        1,
@@ -1310,15 +799,18 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationPayment - Konfirmasi", func(t *testing.T) {
 		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
 		mockFcm.Body = "Thengkyuu, John! Pembayaran untuk pesananmu dengan ID order_id_1 udah kami terima, nih. Semoga harimu menyenangkan!"
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationPaymentRequest{
 			UserID:        1,
@@ -1333,15 +825,18 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationPayment - Gagal", func(t *testing.T) {
 		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
 		mockFcm.Body = "Maaf, John. Pembayaran untuk pesanan dengan ID order_id_1 gagal, nih. Beritahu kami apabila kamu butuh bantuan yaa!!"
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationPaymentRequest{
 			UserID:        1,
@@ -1356,7 +851,7 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - Invalid Payment Status", func(t *testing.T) {
@@ -1379,7 +874,7 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - User Not Found", func(t *testing.T) {
@@ -1402,7 +897,7 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - Order Not Found", func(t *testing.T) {
@@ -1426,16 +921,16 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure Case - Failed Create FCM", func(t *testing.T) {
+	t.Run("Failure Case - Failed Create Notification", func(t *testing.T) {
 		expectedErr := errors.New("failed to create FCM")
 
 		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(nil, expectedErr).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(expectedErr).Once()
 
 		request := dto.SendNotificationPaymentRequest{
 			UserID:        1,
@@ -1446,11 +941,11 @@ func TestOrderService_SendNotificationPayment(t *testing.T) {
 		_, err := orderService.SendNotificationPayment(request)
 
 		assert.Error(t, err)
-		assert.Equal(t, expectedErr, err)
+		assert.EqualError(t, err, "gagal menyimpan notifikasi")
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 }
 
@@ -1460,9 +955,8 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 	orderStatus := "Menunggu Konfirmasi"
 
 	mockUser := &entities.UserModels{
-		ID:          1,
-		Name:        "John",
-		DeviceToken: "device_token_1",
+		ID:   1,
+		Name: "John",
 	}
 
 	mockOrder := &entities.OrderModels{
@@ -1478,13 +972,16 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 
-	orderService, orderRepo, userRepo, _, _, _, _, _, fcmRepo, _ := setupOrderService(t)
+	orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
 
 	t.Run("Success Case - SendNotificationOrder", func(t *testing.T) {
 		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      1,
@@ -1499,7 +996,7 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - Order Not Found", func(t *testing.T) {
@@ -1520,7 +1017,7 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - User Not Found", func(t *testing.T) {
@@ -1540,16 +1037,16 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 		assert.Equal(t, expectedErr, err)
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure Case - Failed Create FCM", func(t *testing.T) {
+	t.Run("Failure Case - Failed Create Notification", func(t *testing.T) {
 		expectedErr := errors.New("failed to create FCM")
 
 		userRepo.On("GetUsersById", userID).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(nil, expectedErr).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(expectedErr).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      userID,
@@ -1560,11 +1057,11 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 		_, err := orderService.SendNotificationOrder(request)
 
 		assert.Error(t, err)
-		assert.Equal(t, expectedErr, err)
+		assert.EqualError(t, err, "gagal menyimpan notifikasi")
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure Case - Invalid Order Status", func(t *testing.T) {
@@ -1587,15 +1084,18 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationOrder - Gagal", func(t *testing.T) {
 		userRepo.On("GetUsersById", userID).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
 		mockFcm.Body = fmt.Sprintf("Sowwy, %s. Pesananmu dengan ID %s gagal. Coba lagi, yukk!", mockUser.Name, mockOrder.IdOrder)
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      userID,
@@ -1610,15 +1110,18 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationOrder - Proses", func(t *testing.T) {
 		userRepo.On("GetUsersById", userID).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
 		mockFcm.Body = fmt.Sprintf("Alloo, %s! Pesananmu dengan ID %s sedang dalam proses, nih. Ditunggu yupp!", mockUser.Name, mockOrder.IdOrder)
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      userID,
@@ -1633,15 +1136,20 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationOrder - Pengiriman", func(t *testing.T) {
 		userRepo.On("GetUsersById", userID).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
 		mockFcm.Body = fmt.Sprintf("Alloo, %s! Pesanan dengan ID %s udah dalam proses pengiriman, nih. Mohon ditunggu yupp!", mockUser.Name, mockOrder.IdOrder)
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.MatchedBy(func(outbox []*entities.NotificationOutboxModels) bool {
+			return len(outbox) == 2 && outbox[1].Template == "shipped"
+		})).Return(nil).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      userID,
@@ -1656,15 +1164,18 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 	t.Run("Success Case - SendNotificationOrder - Selesai", func(t *testing.T) {
 		userRepo.On("GetUsersById", userID).Return(mockUser, nil).Once()
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
 		mockFcm.Body = fmt.Sprintf("Yeayy, %s! Pesananmu dengan ID %s udah sampai tujuan, nih. Semoga sukakk yupp!", mockUser.Name, mockOrder.IdOrder)
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.MatchedBy(func(inbox *entities.FcmModels) bool {
+			return inbox.Title == mockFcm.Title && inbox.Body == mockFcm.Body
+		})).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		request := dto.SendNotificationOrderRequest{
 			UserID:      userID,
@@ -1679,7 +1190,7 @@ func TestOrderService_SendNotificationOrder(t *testing.T) {
 
 		userRepo.AssertExpectations(t)
 		orderRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 	})
 
 }
@@ -1693,7 +1204,6 @@ func TestOrderService_AcceptOrder(t *testing.T) {
 
 	// mockUser := &entities.UserModels{
 	// 	ID:          1,
-	// 	DeviceToken: "user_device_token",
 	// }
 
 	orderService, orderRepo, userRepo, _, _, _, _, _, _, _ := setupOrderService(t)
@@ -1702,7 +1212,7 @@ func TestOrderService_AcceptOrder(t *testing.T) {
 	// 	orderRepo.On("GetOrderById", mock.AnythingOfType("string")).Return(mockOrder, nil)
 	// 	userRepo.On("GetUsersById", mock.AnythingOfType("uint64")).Return(mockUser, nil)
 	// 	orderRepo.On("AcceptOrder", mockOrder.ID, "Selesai").Return(nil).Once()
-	// 	fcmRepo.On("SendNotificationOrder", mock.AnythingOfType("dto.SendNotificationOrderRequest")).Return("", nil).Once()
+	// 	notificationRepo.On("SendNotificationOrder", mock.AnythingOfType("dto.SendNotificationOrderRequest")).Return("", nil).Once()
 
 	// 	err := orderService.AcceptOrder(orderID)
 
@@ -1710,14 +1220,14 @@ func TestOrderService_AcceptOrder(t *testing.T) {
 
 	// 	orderRepo.AssertExpectations(t)
 	// 	userRepo.AssertExpectations(t)
-	// 	fcmRepo.AssertExpectations(t)
+	// 	notificationRepo.AssertExpectations(t)
 	// })
 
 	t.Run("Failure Case - Order Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pesanan tidak ditemukan")
 		orderRepo.On("GetOrderById", orderID).Return(nil, expectedErr).Once()
 
-		err := orderService.AcceptOrder(orderID)
+		err := orderService.AcceptOrder(orderID, order.Actor{ID: 1, Role: "customer"})
 
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
@@ -1731,7 +1241,7 @@ func TestOrderService_AcceptOrder(t *testing.T) {
 		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
 		userRepo.On("GetUsersById", uint64(1)).Return(nil, expectedErr).Once()
 
-		err := orderService.AcceptOrder(orderID)
+		err := orderService.AcceptOrder(orderID, order.Actor{ID: 1, Role: "customer"})
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, "pengguna tidak ditemukan")
@@ -1740,10 +1250,297 @@ func TestOrderService_AcceptOrder(t *testing.T) {
 		userRepo.AssertExpectations(t)
 	})
 
+	t.Run("Failure Case - Order Of Another Customer", func(t *testing.T) {
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
+
+		err := orderService.AcceptOrder(orderID, order.Actor{ID: 2, Role: "customer"})
+
+		assert.EqualError(t, err, "pesanan tidak ditemukan")
+		orderRepo.AssertNotCalled(t, "AcceptOrder", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure Case - Validates Against Locked Status", func(t *testing.T) {
+		orderRepo.On("GetOrderById", orderID).Return(&entities.OrderModels{ID: orderID, UserID: 1, OrderStatus: order.OrderStatusShipping}, nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(&entities.UserModels{ID: 1}, nil).Once()
+		orderRepo.On("LockOrder", orderID).Return(&entities.OrderModels{ID: orderID, UserID: 1, OrderStatus: order.OrderStatusDone}, nil).Once()
+
+		err := orderService.AcceptOrder(orderID, order.Actor{ID: 1, Role: "customer"})
+
+		assert.EqualError(t, err, "status pesanan tidak dapat diubah dari Selesai ke Selesai")
+		orderRepo.AssertNotCalled(t, "AcceptOrder", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrderService_ConfirmPayment(t *testing.T) {
+	orderID := "order_id_1"
+
+	t.Run("Success Case - Records Status History", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:                    orderID,
+			UserID:                1,
+			OrderStatus:           order.OrderStatusWaiting,
+			PaymentStatus:         order.PaymentStatusWaiting,
+			GrandTotalExp:         10,
+			GrandTotalGramPlastic: 5,
+		}
+		mockUser := &entities.UserModels{ID: 1, Name: "John", Level: "Bronze"}
+
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil)
+		orderRepo.On("LockOrder", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("ConfirmPayment", orderID, order.OrderStatusProcess, order.PaymentStatusConfirmed).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.MatchedBy(func(h *entities.OrderStatusHistoryModels) bool {
+			return h.StatusType == order.StatusTypePayment && h.FromStatus == order.PaymentStatusWaiting &&
+				h.ToStatus == order.PaymentStatusConfirmed && h.ActorRole == "admin"
+		})).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.MatchedBy(func(h *entities.OrderStatusHistoryModels) bool {
+			return h.StatusType == order.StatusTypeOrder && h.FromStatus == order.OrderStatusWaiting &&
+				h.ToStatus == order.OrderStatusProcess && h.ActorID == 2
+		})).Return(nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil)
+		userRepo.On("AddUserRewards", uint64(1), uint64(10), uint64(5)).Return(nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		err := orderService.ConfirmPayment(orderID, order.Actor{ID: 2, Role: "admin"})
+
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Reward Fails Inside Transaction", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:                    orderID,
+			UserID:                1,
+			OrderStatus:           order.OrderStatusWaiting,
+			PaymentStatus:         order.PaymentStatusWaiting,
+			GrandTotalExp:         10,
+			GrandTotalGramPlastic: 5,
+		}
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("LockOrder", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("ConfirmPayment", orderID, order.OrderStatusProcess, order.PaymentStatusConfirmed).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.Anything).Return(nil).Twice()
+		userRepo.On("AddUserRewards", uint64(1), uint64(10), uint64(5)).Return(errors.New("db down")).Once()
+
+		err := orderService.ConfirmPayment(orderID, order.SystemActor)
+
+		assert.EqualError(t, err, "gagal menambahkan exp pengguna")
+		notificationRepo.AssertNotCalled(t, "CreateOutbox", mock.Anything)
+	})
+
+	t.Run("Failed Case - Invalid Transition", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:            orderID,
+			UserID:        1,
+			OrderStatus:   order.OrderStatusFailed,
+			PaymentStatus: order.PaymentStatusFailed,
+		}
+		orderRepo.On("GetOrderById", orderID).Return(mockOrder, nil).Once()
+		orderRepo.On("LockOrder", orderID).Return(mockOrder, nil).Once()
+
+		err := orderService.ConfirmPayment(orderID, order.SystemActor)
+
+		assert.EqualError(t, err, "status pembayaran tidak dapat diubah dari Gagal ke Konfirmasi")
+		orderRepo.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "CreateStatusHistory", mock.Anything)
+	})
+}
+
+func TestOrderService_ExpireUnpaidOrders(t *testing.T) {
+	deadline := time.Now().Add(-24 * time.Hour)
+
+	t.Run("Success Case - Cancels And Restocks", func(t *testing.T) {
+		orderService, orderRepo, userRepo, productRepo, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		unpaid := &entities.OrderModels{
+			ID:            "order_id_1",
+			UserID:        1,
+			OrderStatus:   order.OrderStatusWaiting,
+			PaymentStatus: order.PaymentStatusWaiting,
+			OrderDetails:  []entities.OrderDetailsModels{{ProductID: 3, Quantity: 2}},
+		}
+
+		orderRepo.On("GetUnpaidOrdersBefore", deadline, expireUnpaidOrdersBatchSize).Return([]*entities.OrderModels{unpaid}, nil).Once()
+		orderRepo.On("GetOrderById", "order_id_1").Return(unpaid, nil)
+		orderRepo.On("LockOrder", "order_id_1").Return(unpaid, nil).Once()
+		productRepo.On("GetProductByID", uint64(3)).Return(&entities.ProductModels{ID: 3}, nil).Once()
+		productRepo.On("IncreaseStock", uint64(3), uint64(2)).Return(nil).Once()
+		productRepo.On("GetStockLevel", uint64(3), uint64(0)).Return(uint64(2), nil).Once()
+		productRepo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Reference == "order_id_1" && movement.Quantity == 2
+		})).Return(nil).Once()
+		orderRepo.On("ConfirmPayment", "order_id_1", order.OrderStatusFailed, order.PaymentStatusFailed).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.MatchedBy(func(h *entities.OrderStatusHistoryModels) bool {
+			return h.ActorRole == order.ActorRoleSystem && h.Reason == "pesanan kedaluwarsa karena belum dibayar"
+		})).Return(nil).Twice()
+		userRepo.On("GetUsersById", uint64(1)).Return(&entities.UserModels{ID: 1}, nil)
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		expired, err := orderService.ExpireUnpaidOrders(deadline)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, expired)
+		orderRepo.AssertExpectations(t)
+		productRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Skips Order Paid Meanwhile", func(t *testing.T) {
+		orderService, orderRepo, _, productRepo, _, _, _, _, _, _ := setupOrderService(t)
+		unpaid := &entities.OrderModels{
+			ID:            "order_id_1",
+			OrderStatus:   order.OrderStatusWaiting,
+			PaymentStatus: order.PaymentStatusWaiting,
+			OrderDetails:  []entities.OrderDetailsModels{{ProductID: 3, Quantity: 2}},
+		}
+		paid := &entities.OrderModels{
+			ID:            "order_id_1",
+			OrderStatus:   order.OrderStatusProcess,
+			PaymentStatus: order.PaymentStatusConfirmed,
+		}
+
+		orderRepo.On("GetUnpaidOrdersBefore", deadline, expireUnpaidOrdersBatchSize).Return([]*entities.OrderModels{unpaid}, nil).Once()
+		orderRepo.On("GetOrderById", "order_id_1").Return(unpaid, nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(paid, nil).Once()
+
+		expired, err := orderService.ExpireUnpaidOrders(deadline)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expired)
+		productRepo.AssertNotCalled(t, "IncreaseStock", mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "ConfirmPayment", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Repository Error", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		orderRepo.On("GetUnpaidOrdersBefore", deadline, expireUnpaidOrdersBatchSize).Return(nil, errors.New("db error")).Once()
+
+		expired, err := orderService.ExpireUnpaidOrders(deadline)
+
+		assert.EqualError(t, err, "gagal mendapatkan pesanan yang belum dibayar")
+		assert.Equal(t, 0, expired)
+	})
+}
+
+func TestOrderService_UpdateOrderStatus(t *testing.T) {
+	t.Run("Success Case - Shipping Records Shipment", func(t *testing.T) {
+		orderService, orderRepo, userRepo, _, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:             "order_id_1",
+			UserID:         1,
+			OrderStatus:    order.OrderStatusProcess,
+			Courier:        "jne",
+			CourierService: "REG",
+		}
+		req := &dto.UpdateOrderStatus{OrderID: "order_id_1", OrderStatus: order.OrderStatusShipping, Awb: "123456789"}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil)
+		orderRepo.On("LockOrder", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("UpdateOrderStatus", req).Return(nil).Once()
+		orderRepo.On("CreateShipment", mock.MatchedBy(func(s *entities.ShipmentModels) bool {
+			return s.OrderID == "order_id_1" && s.Courier == "jne" && s.Service == "REG" && s.Awb == "123456789"
+		})).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.AnythingOfType("*entities.OrderStatusHistoryModels")).Return(nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(&entities.UserModels{ID: 1, Name: "John"}, nil)
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		err := orderService.UpdateOrderStatus(req, order.Actor{ID: 2, Role: "admin"})
+
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Shipping Without Awb", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{ID: "order_id_1", OrderStatus: order.OrderStatusProcess, Courier: "jne"}
+		req := &dto.UpdateOrderStatus{OrderID: "order_id_1", OrderStatus: order.OrderStatusShipping}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(mockOrder, nil).Once()
+
+		err := orderService.UpdateOrderStatus(req, order.Actor{ID: 2, Role: "admin"})
+
+		assert.EqualError(t, err, "nomor resi wajib diisi untuk status pengiriman")
+		orderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything)
+	})
+
+	t.Run("Failed Case - Skipping States", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:          "order_id_1",
+			OrderStatus: order.OrderStatusFailed,
+		}
+		req := &dto.UpdateOrderStatus{OrderID: "order_id_1", OrderStatus: order.OrderStatusDone}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(mockOrder, nil).Once()
+
+		err := orderService.UpdateOrderStatus(req, order.Actor{ID: 2, Role: "admin"})
+
+		assert.EqualError(t, err, "status pesanan tidak dapat diubah dari Gagal ke Selesai")
+		orderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything)
+	})
+
+	t.Run("Failed Case - Returned Only Through Return Request", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:          "order_id_1",
+			OrderStatus: order.OrderStatusDone,
+		}
+		req := &dto.UpdateOrderStatus{OrderID: "order_id_1", OrderStatus: order.OrderStatusReturned}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+
+		err := orderService.UpdateOrderStatus(req, order.Actor{ID: 2, Role: "admin"})
+
+		assert.EqualError(t, err, "status pesanan dikembalikan hanya dapat diatur melalui pengajuan pengembalian")
+		orderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything)
+	})
+
+	t.Run("Failed Case - Validates Against Locked Status", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		staleOrder := &entities.OrderModels{ID: "order_id_1", OrderStatus: order.OrderStatusProcess, Courier: "jne"}
+		lockedOrder := &entities.OrderModels{ID: "order_id_1", OrderStatus: order.OrderStatusFailed, Courier: "jne"}
+		req := &dto.UpdateOrderStatus{OrderID: "order_id_1", OrderStatus: order.OrderStatusShipping, Awb: "123456789"}
+		orderRepo.On("GetOrderById", "order_id_1").Return(staleOrder, nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(lockedOrder, nil).Once()
+
+		err := orderService.UpdateOrderStatus(req, order.Actor{ID: 2, Role: "admin"})
+
+		assert.EqualError(t, err, "status pesanan tidak dapat diubah dari Gagal ke Pengiriman")
+		orderRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything)
+	})
+}
+
+func TestOrderService_GetOrderStatusHistory(t *testing.T) {
+	orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+	histories := []*entities.OrderStatusHistoryModels{
+		{ID: 1, OrderID: "order_id_1", StatusType: order.StatusTypeOrder, ToStatus: order.OrderStatusWaiting},
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		orderRepo.On("GetStatusHistory", "order_id_1").Return(histories, nil).Once()
+
+		result, err := orderService.GetOrderStatusHistory("order_id_1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, histories, result)
+	})
+
+	t.Run("Failed Case", func(t *testing.T) {
+		orderRepo.On("GetStatusHistory", "order_id_1").Return(nil, errors.New("db error")).Once()
+
+		result, err := orderService.GetOrderStatusHistory("order_id_1")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "gagal mendapatkan riwayat status pesanan")
+	})
 }
 
 func TestOrderService_CreateOrder(t *testing.T) {
-	orderService, orderRepo, userRepo, productRepo, _, voucherRepo, addressRepo, cartRepo, fcmRepo, generatorRepo :=
+	orderService, orderRepo, userRepo, productRepo, _, voucherRepo, addressRepo, cartRepo, notificationRepo, generatorRepo :=
 		setupOrderService(t)
 
 	orderService.repo = orderRepo
@@ -1752,61 +1549,73 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	orderID := "fake_order_id"
 
 	createOrderRequest := &dto.CreateOrderRequest{
-		AddressID:     1,
-		VoucherID:     1,
-		Note:          "test order",
-		ProductID:     1,
-		Quantity:      1,
-		PaymentMethod: "whatsapp",
+		AddressID:      1,
+		VoucherID:      1,
+		Note:           "test order",
+		ProductID:      1,
+		Quantity:       1,
+		PaymentMethod:  "whatsapp",
+		Courier:        "jne",
+		CourierService: "REG",
 	}
 
 	mockAddress := &entities.AddressModels{
-		ID: userID,
+		ID:   userID,
+		City: "Bandung",
 	}
 
 	mockVoucher := &entities.VoucherModels{
-		ID:    1,
-		Stock: 10,
+		ID:           1,
+		Stock:        10,
+		DiscountType: "fixed",
+		Discount:     10000,
+		StartDate:    time.Now().Add(-24 * time.Hour),
+		EndDate:      time.Now().Add(24 * time.Hour),
 	}
 
 	mockUser := &entities.UserModels{
-		ID:          1,
-		Name:        "John",
-		DeviceToken: "device_token_1",
+		ID:   1,
+		Name: "John",
 	}
 
 	mockProduct := &entities.ProductModels{
-		ID:    1,
-		Stock: 100,
+		ID:     1,
+		Price:  50000,
+		Stock:  100,
+		Weight: 1500,
 	}
 
 	mockOrder := &entities.OrderModels{
 		IdOrder: "fake_order_id",
 	}
 
-	mockFcm := &entities.FcmModels{
-		ID:        1,
-		OrderID:   "order_id_1",
-		UserID:    1,
-		Title:     "Status Pengiriman",
-		Body:      fmt.Sprintf("Alloo, %s! Pesananmu dengan ID %s sedang menunggu konfirmasi, nih. Ditunggu yupp!", mockUser.Name, mockOrder.IdOrder),
-		CreatedAt: time.Now(),
-	}
-
 	t.Run("Success Case - Create Order", func(t *testing.T) {
 		generatorRepo.On("GenerateUUID").Return(orderID, nil)
 		generatorRepo.On("GenerateOrderID").Return("fake_id_order", nil)
 		addressRepo.On("GetAddressByID", createOrderRequest.AddressID).Return(mockAddress, nil)
 		voucherRepo.On("GetVoucherById", createOrderRequest.VoucherID).Return(mockVoucher, nil)
 		productRepo.On("GetProductByID", createOrderRequest.ProductID).Return(mockProduct, nil)
-		cartRepo.On("IsProductInCart", userID, mockProduct.ID).Return(false).Once()
+		cartRepo.On("IsProductInCart", userID, mockProduct.ID, uint64(0)).Return(false).Once()
 		productRepo.On("ReduceStockWhenPurchasing", createOrderRequest.ProductID, createOrderRequest.Quantity).Return(nil)
-		orderRepo.On("CreateOrder", mock.AnythingOfType("*entities.OrderModels")).Return(mockOrder, nil)
+		productRepo.On("GetStockLevel", createOrderRequest.ProductID, uint64(0)).Return(uint64(8), nil)
+		productRepo.On("CreateInventoryMovement", mock.AnythingOfType("*entities.InventoryMovementModels")).Return(nil)
+		orderRepo.On("CreateOrder", mock.MatchedBy(func(o *entities.OrderModels) bool {
+			return o.ShipmentFee == 24000 && o.AdminFees == 2000 && o.VoucherDiscount == 10000 &&
+				o.TotalAmountPaid == 66000 && o.Courier == "jne" && o.CourierService == "REG"
+		})).Return(mockOrder, nil)
+		orderRepo.On("CreateStatusHistory", mock.AnythingOfType("*entities.OrderStatusHistoryModels")).Return(nil).Twice()
+		voucherRepo.On("LockVoucher", mockVoucher.ID).Return(mockVoucher, nil).Once()
+		voucherRepo.On("IncreaseVoucherUsage", mockVoucher.ID).Return(true, nil).Once()
+		voucherRepo.On("AttachVoucherCodeOrder", mockVoucher.ID, userID, orderID).Return(nil).Once()
+		voucherRepo.On("CreateVoucherUsage", mock.MatchedBy(func(u *entities.VoucherUsageModels) bool {
+			return u.VoucherID == mockVoucher.ID && u.OrderID == orderID && u.Discount == 10000
+		})).Return(nil).Once()
 		voucherRepo.On("DeleteUserVoucherClaims", mock.AnythingOfType("uint64"), mock.AnythingOfType("uint64")).Return(nil)
 		userRepo.On("GetUsersById", mock.AnythingOfType("uint64")).Return(mockUser, nil)
 		orderRepo.On("GetOrderById", mock.AnythingOfType("string")).Return(mockOrder, nil)
-		fcmRepo.On("SendMessageNotification", mock.Anything).Return("", nil).Once()
-		fcmRepo.On("CreateFcm", mock.Anything).Return(mockFcm, nil).Once()
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
 
 		result, err := orderService.CreateOrder(userID, createOrderRequest)
 		generatorRepo.AssertExpectations(t)
@@ -1817,12 +1626,83 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		orderRepo.AssertExpectations(t)
 		voucherRepo.AssertExpectations(t)
 		userRepo.AssertExpectations(t)
-		fcmRepo.AssertExpectations(t)
+		notificationRepo.AssertExpectations(t)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success Case - Create Order For A Variant", func(t *testing.T) {
+		orderService, orderRepo, userRepo, productRepo, _, _, addressRepo, cartRepo, notificationRepo, generatorRepo := setupOrderService(t)
+		request := &dto.CreateOrderRequest{
+			AddressID:      1,
+			ProductID:      1,
+			VariantID:      7,
+			Quantity:       2,
+			PaymentMethod:  "whatsapp",
+			Courier:        "jne",
+			CourierService: "REG",
+		}
+		variantProduct := &entities.ProductModels{
+			ID:     1,
+			Price:  50000,
+			Stock:  30,
+			Weight: 500,
+			Variants: []entities.ProductVariantModels{
+				{ID: 7, ProductID: 1, SKU: "TOTE-L", Price: 80000, Discount: 5000, Stock: 10, GramPlastic: 20},
+			},
+		}
+
+		generatorRepo.On("GenerateUUID").Return(orderID, nil).Once()
+		generatorRepo.On("GenerateOrderID").Return("fake_id_order", nil).Once()
+		addressRepo.On("GetAddressByID", request.AddressID).Return(mockAddress, nil).Once()
+		productRepo.On("GetProductByID", request.ProductID).Return(variantProduct, nil).Twice()
+		cartRepo.On("IsProductInCart", userID, variantProduct.ID, uint64(7)).Return(false).Once()
+		productRepo.On("ReduceVariantStock", uint64(7), uint64(2)).Return(nil).Once()
+		productRepo.On("SyncVariantStock", uint64(1)).Return(nil).Once()
+		productRepo.On("GetStockLevel", uint64(1), uint64(7)).Return(uint64(3), nil).Once()
+		productRepo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return *movement.VariantID == 7 && movement.Quantity == -2
+		})).Return(nil).Once()
+		orderRepo.On("CreateOrder", mock.MatchedBy(func(o *entities.OrderModels) bool {
+			detail := o.OrderDetails[0]
+			return detail.VariantID != nil && *detail.VariantID == 7 &&
+				detail.TotalPrice == 150000 && detail.TotalDiscount == 10000 && detail.TotalGramPlastic == 40 &&
+				o.GrandTotalPrice == 150000
+		})).Return(mockOrder, nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.AnythingOfType("*entities.OrderStatusHistoryModels")).Return(nil).Twice()
+		userRepo.On("GetUsersById", mock.AnythingOfType("uint64")).Return(mockUser, nil)
+		orderRepo.On("GetOrderById", mock.AnythingOfType("string")).Return(mockOrder, nil)
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		result, err := orderService.CreateOrder(userID, request)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Failed Case - Variant Not Picked", func(t *testing.T) {
+		orderService, _, _, productRepo, _, _, addressRepo, _, _, generatorRepo := setupOrderService(t)
+		generatorRepo.On("GenerateUUID").Return(orderID, nil).Once()
+		generatorRepo.On("GenerateOrderID").Return("fake_id_order", nil).Once()
+		addressRepo.On("GetAddressByID", uint64(1)).Return(mockAddress, nil).Once()
+		productRepo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{
+			ID:       1,
+			Variants: []entities.ProductVariantModels{{ID: 7, ProductID: 1}},
+		}, nil).Once()
+
+		_, err := orderService.CreateOrder(userID, &dto.CreateOrderRequest{
+			AddressID:     1,
+			ProductID:     1,
+			Quantity:      1,
+			PaymentMethod: "whatsapp",
+		})
+
+		assert.EqualError(t, err, "varian produk wajib dipilih")
+	})
+
 	t.Run("Failed Case- Create order id", func(t *testing.T) {
 		orderService, _, _, _, _, _, _, _, _, generatorRepo := setupOrderService(t)
 
@@ -1889,6 +1769,44 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		assert.Equal(t, "kupon tidak ditemukan", err.Error())
 	})
 
+	t.Run("Failed Case - Insufficient Stock Rolls Back", func(t *testing.T) {
+		orderService, orderRepo, _, productRepo, _, voucherRepo, addressRepo, cartRepo, _, generatorRepo := setupOrderService(t)
+
+		generatorRepo.On("GenerateUUID").Return("fake_order_id", nil)
+		generatorRepo.On("GenerateOrderID").Return("fake_id_order", nil)
+		addressRepo.On("GetAddressByID", mock.AnythingOfType("uint64")).Return(mockAddress, nil)
+		voucherRepo.On("GetVoucherById", createOrderRequest.VoucherID).Return(mockVoucher, nil)
+		productRepo.On("GetProductByID", createOrderRequest.ProductID).Return(mockProduct, nil)
+		cartRepo.On("IsProductInCart", userID, mockProduct.ID, uint64(0)).Return(false).Once()
+		productRepo.On("ReduceStockWhenPurchasing", createOrderRequest.ProductID, createOrderRequest.Quantity).
+			Return(errors.New("stok tidak mencukupi untuk pesanan ini"))
+
+		result, err := orderService.CreateOrder(userID, createOrderRequest)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "stok tidak mencukupi untuk pesanan ini")
+		orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything)
+		voucherRepo.AssertNotCalled(t, "DeleteUserVoucherClaims", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Courier Service Unavailable", func(t *testing.T) {
+		orderService, orderRepo, _, productRepo, _, voucherRepo, addressRepo, _, _, generatorRepo := setupOrderService(t)
+
+		generatorRepo.On("GenerateUUID").Return("fake_order_id", nil)
+		generatorRepo.On("GenerateOrderID").Return("fake_id_order", nil)
+		addressRepo.On("GetAddressByID", mock.AnythingOfType("uint64")).Return(mockAddress, nil)
+		voucherRepo.On("GetVoucherById", createOrderRequest.VoucherID).Return(mockVoucher, nil)
+		productRepo.On("GetProductByID", createOrderRequest.ProductID).Return(mockProduct, nil)
+
+		request := *createOrderRequest
+		request.CourierService = "OKE"
+		result, err := orderService.CreateOrder(userID, &request)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "layanan pengiriman tidak tersedia")
+		orderRepo.AssertNotCalled(t, "CreateOrder", mock.Anything)
+	})
+
 	t.Run("InvalidPaymentMethod", func(t *testing.T) {
 		orderService, _, _, _, _, _, addressRepo, _, _, generatorRepo := setupOrderService(t)
 
@@ -1905,3 +1823,153 @@ func TestOrderService_CreateOrder(t *testing.T) {
 		assert.Equal(t, "jenis pembayaran tidak valid", err.Error())
 	})
 }
+
+func TestOrderService_CreateReturn(t *testing.T) {
+	request := &dto.CreateReturnRequest{OrderID: "order_id_1", Reason: "produk rusak"}
+
+	t.Run("Success Case", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{ID: "order_id_1", UserID: 1, OrderStatus: order.OrderStatusDone}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("GetActiveReturnByOrderID", "order_id_1").Return(nil, gorm.ErrRecordNotFound).Once()
+		orderRepo.On("CreateReturn", mock.MatchedBy(func(r *entities.OrderReturnModels) bool {
+			return r.Status == order.ReturnStatusPending && len(r.Photos) == 2 && r.UserID == 1
+		})).Return(&entities.OrderReturnModels{ID: 1, OrderID: "order_id_1"}, nil).Once()
+
+		result, err := orderService.CreateReturn(1, request, []string{"photo1.jpg", "photo2.jpg"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), result.ID)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Order Not Completed", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{ID: "order_id_1", UserID: 1, OrderStatus: order.OrderStatusShipping}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+
+		result, err := orderService.CreateReturn(1, request, []string{"photo1.jpg"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "hanya pesanan yang sudah selesai yang dapat dikembalikan")
+	})
+
+	t.Run("Failed Case - Not Order Owner", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{ID: "order_id_1", UserID: 2, OrderStatus: order.OrderStatusDone}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+
+		result, err := orderService.CreateReturn(1, request, []string{"photo1.jpg"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "pesanan tidak ditemukan")
+	})
+
+	t.Run("Failed Case - Return Already Filed", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{ID: "order_id_1", UserID: 1, OrderStatus: order.OrderStatusDone}
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		orderRepo.On("GetActiveReturnByOrderID", "order_id_1").Return(&entities.OrderReturnModels{ID: 1}, nil).Once()
+
+		result, err := orderService.CreateReturn(1, request, []string{"photo1.jpg"})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "pengajuan pengembalian untuk pesanan ini sudah ada")
+	})
+}
+
+func TestOrderService_ApproveReturn(t *testing.T) {
+	admin := order.Actor{ID: 2, Role: "admin"}
+
+	t.Run("Success Case - Restocks And Reverses Rewards", func(t *testing.T) {
+		orderService, orderRepo, userRepo, productRepo, _, _, _, _, notificationRepo, _ := setupOrderService(t)
+		mockOrder := &entities.OrderModels{
+			ID:                    "order_id_1",
+			UserID:                1,
+			OrderStatus:           order.OrderStatusDone,
+			TotalAmountPaid:       50000,
+			GrandTotalExp:         30,
+			GrandTotalGramPlastic: 20,
+			OrderDetails:          []entities.OrderDetailsModels{{ProductID: 3, Quantity: 2}},
+		}
+		mockReturn := &entities.OrderReturnModels{
+			ID:      1,
+			OrderID: "order_id_1",
+			UserID:  1,
+			Status:  order.ReturnStatusPending,
+			Order:   *mockOrder,
+		}
+		mockUser := &entities.UserModels{ID: 1, Exp: 70, TotalGram: 0, Level: "Bronze"}
+
+		orderRepo.On("GetReturnById", uint64(1)).Return(mockReturn, nil).Once()
+		orderRepo.On("LockReturn", uint64(1)).Return(mockReturn, nil).Once()
+		orderRepo.On("LockOrder", "order_id_1").Return(mockOrder, nil).Once()
+		productRepo.On("GetProductByID", uint64(3)).Return(&entities.ProductModels{ID: 3}, nil).Once()
+		productRepo.On("IncreaseStock", uint64(3), uint64(2)).Return(nil).Once()
+		productRepo.On("GetStockLevel", uint64(3), uint64(0)).Return(uint64(2), nil).Once()
+		productRepo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Reference == "order_id_1" && movement.Quantity == 2
+		})).Return(nil).Once()
+		orderRepo.On("UpdateOrderStatus", mock.MatchedBy(func(req *dto.UpdateOrderStatus) bool {
+			return req.OrderStatus == order.OrderStatusReturned
+		})).Return(nil).Once()
+		orderRepo.On("CreateStatusHistory", mock.MatchedBy(func(h *entities.OrderStatusHistoryModels) bool {
+			return h.FromStatus == order.OrderStatusDone && h.ToStatus == order.OrderStatusReturned
+		})).Return(nil).Once()
+		orderRepo.On("UpdateReturnStatus", uint64(1), order.ReturnStatusApproved, "pengembalian disetujui", uint64(2)).Return(nil).Once()
+		orderRepo.On("CreateRefund", mock.MatchedBy(func(r *entities.OrderRefundModels) bool {
+			return r.Amount == 50000 && r.ReversedExp == 30 && r.ReversedGram == 20
+		})).Return(nil).Once()
+		orderRepo.On("GetOrderById", "order_id_1").Return(mockOrder, nil).Once()
+		userRepo.On("DeductUserRewards", uint64(1), uint64(30), uint64(20)).Return(nil).Once()
+		userRepo.On("GetUsersById", uint64(1)).Return(mockUser, nil)
+		notificationRepo.On("GetPreferences", mock.Anything).Return(nil, nil).Once()
+		notificationRepo.On("CreateInbox", mock.Anything).Return(nil).Once()
+		notificationRepo.On("CreateOutbox", mock.Anything).Return(nil).Once()
+
+		err := orderService.ApproveReturn(1, admin, "")
+
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+		productRepo.AssertExpectations(t)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Already Processed", func(t *testing.T) {
+		orderService, orderRepo, _, productRepo, _, _, _, _, _, _ := setupOrderService(t)
+		mockReturn := &entities.OrderReturnModels{ID: 1, OrderID: "order_id_1", Status: order.ReturnStatusApproved}
+		orderRepo.On("GetReturnById", uint64(1)).Return(mockReturn, nil).Once()
+		orderRepo.On("LockReturn", uint64(1)).Return(mockReturn, nil).Once()
+
+		err := orderService.ApproveReturn(1, admin, "")
+
+		assert.EqualError(t, err, "pengajuan pengembalian sudah diproses")
+		productRepo.AssertNotCalled(t, "IncreaseStock", mock.Anything, mock.Anything)
+		orderRepo.AssertNotCalled(t, "CreateRefund", mock.Anything)
+	})
+}
+
+func TestOrderService_RejectReturn(t *testing.T) {
+	admin := order.Actor{ID: 2, Role: "admin"}
+
+	t.Run("Success Case", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+		mockReturn := &entities.OrderReturnModels{ID: 1, Status: order.ReturnStatusPending}
+		orderRepo.On("LockReturn", uint64(1)).Return(mockReturn, nil).Once()
+		orderRepo.On("UpdateReturnStatus", uint64(1), order.ReturnStatusRejected, "foto tidak jelas", uint64(2)).Return(nil).Once()
+
+		err := orderService.RejectReturn(1, admin, "foto tidak jelas")
+
+		assert.NoError(t, err)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Missing Note", func(t *testing.T) {
+		orderService, orderRepo, _, _, _, _, _, _, _, _ := setupOrderService(t)
+
+		err := orderService.RejectReturn(1, admin, "")
+
+		assert.EqualError(t, err, "alasan penolakan wajib diisi")
+		orderRepo.AssertNotCalled(t, "LockReturn", mock.Anything)
+	})
+}
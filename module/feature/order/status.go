@@ -0,0 +1,81 @@
+package order
+
+import (
+	"fmt"
+)
+
+const (
+	OrderStatusWaiting  = "Menunggu Konfirmasi"
+	OrderStatusProcess  = "Proses"
+	OrderStatusShipping = "Pengiriman"
+	OrderStatusDone     = "Selesai"
+	OrderStatusFailed   = "Gagal"
+	OrderStatusReturned = "Dikembalikan"
+
+	PaymentStatusWaiting   = "Menunggu Konfirmasi"
+	PaymentStatusConfirmed = "Konfirmasi"
+	PaymentStatusFailed    = "Gagal"
+
+	StatusTypeOrder   = "order"
+	StatusTypePayment = "payment"
+
+	ActorRoleSystem = "system"
+
+	WebhookResultProcessed = "processed"
+	WebhookResultIgnored   = "ignored"
+	WebhookResultFailed    = "failed"
+
+	ReturnStatusPending  = "Menunggu Persetujuan"
+	ReturnStatusApproved = "Disetujui"
+	ReturnStatusRejected = "Ditolak"
+)
+
+var orderStatusTransitions = map[string][]string{
+	OrderStatusWaiting:  {OrderStatusProcess, OrderStatusFailed},
+	OrderStatusProcess:  {OrderStatusShipping, OrderStatusFailed},
+	OrderStatusShipping: {OrderStatusDone},
+	OrderStatusDone:     {OrderStatusReturned},
+}
+
+var paymentStatusTransitions = map[string][]string{
+	PaymentStatusWaiting: {PaymentStatusConfirmed, PaymentStatusFailed},
+}
+
+// Actor is whoever triggers a status transition. ID is zero for the system.
+type Actor struct {
+	ID   uint64
+	Role string
+}
+
+var SystemActor = Actor{Role: ActorRoleSystem}
+
+func CanTransitionOrderStatus(from, to string) bool {
+	return canTransition(orderStatusTransitions, from, to)
+}
+
+func CanTransitionPaymentStatus(from, to string) bool {
+	return canTransition(paymentStatusTransitions, from, to)
+}
+
+func ValidateOrderStatusTransition(from, to string) error {
+	if !CanTransitionOrderStatus(from, to) {
+		return fmt.Errorf("status pesanan tidak dapat diubah dari %s ke %s", from, to)
+	}
+	return nil
+}
+
+func ValidatePaymentStatusTransition(from, to string) error {
+	if !CanTransitionPaymentStatus(from, to) {
+		return fmt.Errorf("status pembayaran tidak dapat diubah dari %s ke %s", from, to)
+	}
+	return nil
+}
+
+func canTransition(transitions map[string][]string, from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
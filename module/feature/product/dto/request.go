@@ -6,6 +6,7 @@ type CreateProductRequest struct {
 	GramPlastic uint64   `json:"gram_plastic" form:"gram_plastic" validate:"required"`
 	Price       uint64   `json:"price" form:"price" validate:"required"`
 	Stock       uint64   `json:"stock" form:"stock" validate:"required"`
+	Weight      uint64   `json:"weight" form:"weight"`
 	Discount    uint64   `json:"discount" form:"discount" validate:"required"`
 	Exp         uint64   `json:"exp" form:"exp" validate:"required"`
 	Categories  []uint64 `json:"categories" form:"categories" validate:"required"`
@@ -22,9 +23,30 @@ type UpdateProduct struct {
 	Description string   `json:"description" form:"description" validate:"required"`
 	GramPlastic uint64   `json:"gram_plastic" form:"gram_plastic" validate:"required"`
 	Price       uint64   `json:"price" form:"price" validate:"required"`
-	Stock       uint64   `json:"stock" form:"stock" validate:"required"`
+	Stock       *uint64  `json:"stock" form:"stock"`
+	Weight      uint64   `json:"weight" form:"weight"`
 	Discount    uint64   `json:"discount" form:"discount" validate:"required"`
 	Exp         uint64   `json:"exp" form:"exp" validate:"required"`
 	CategoryIDs []uint64 `json:"categories" form:"categories" validate:"required"`
 	ImageURL    string   `json:"image_url" form:"image_url"`
 }
+
+type UpdateProductStockRequest struct {
+	Stock *uint64 `json:"stock" form:"stock" validate:"required"`
+}
+
+type VariantRequest struct {
+	SKU         string  `json:"sku" form:"sku" validate:"required"`
+	Size        string  `json:"size" form:"size"`
+	Color       string  `json:"color" form:"color"`
+	Price       uint64  `json:"price" form:"price" validate:"required"`
+	Discount    uint64  `json:"discount" form:"discount"`
+	Stock       *uint64 `json:"stock" form:"stock"`
+	GramPlastic uint64  `json:"gram_plastic" form:"gram_plastic"`
+}
+
+type AdjustStockRequest struct {
+	VariantID uint64 `json:"variant_id" form:"variant_id"`
+	Quantity  int64  `json:"quantity" form:"quantity" validate:"required"`
+	Reason    string `json:"reason" form:"reason" validate:"required"`
+}
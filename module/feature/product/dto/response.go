@@ -99,6 +99,7 @@ type ProductDetailFormatter struct {
 	Description string                  `json:"description"`
 	GramPlastic uint64                  `json:"gram_plastic"`
 	Stock       uint64                  `json:"stock"`
+	Weight      uint64                  `json:"weight"`
 	Discount    uint64                  `json:"discount"`
 	Exp         uint64                  `json:"exp"`
 	Price       uint64                  `json:"price"`
@@ -107,15 +108,43 @@ type ProductDetailFormatter struct {
 	TotalSold   uint64                  `json:"total_sold"`
 	Categories  []CategoryFormatter     `json:"categories"`
 	Images      []ProductImageFormatter `json:"image_url"`
+	Variants    []VariantFormatter      `json:"variants"`
 	Reviews     []ReviewFormatter       `json:"reviews"`
 }
 
+type VariantFormatter struct {
+	ID          uint64 `json:"id"`
+	ProductID   uint64 `json:"product_id"`
+	SKU         string `json:"sku"`
+	Size        string `json:"size"`
+	Color       string `json:"color"`
+	Price       uint64 `json:"price"`
+	Discount    uint64 `json:"discount"`
+	Stock       uint64 `json:"stock"`
+	GramPlastic uint64 `json:"gram_plastic"`
+}
+
+func FormatVariant(variant *entities.ProductVariantModels) VariantFormatter {
+	return VariantFormatter{
+		ID:          variant.ID,
+		ProductID:   variant.ProductID,
+		SKU:         variant.SKU,
+		Size:        variant.Size,
+		Color:       variant.Color,
+		Price:       variant.Price,
+		Discount:    variant.Discount,
+		Stock:       variant.Stock,
+		GramPlastic: variant.GramPlastic,
+	}
+}
+
 type CreateProductFormatter struct {
 	ID          uint64 `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	GramPlastic uint64 `json:"gram_plastic"`
 	Stock       uint64 `json:"stock"`
+	Weight      uint64 `json:"weight"`
 	Discount    uint64 `json:"discount"`
 	Exp         uint64 `json:"exp"`
 	Price       uint64 `json:"price"`
@@ -128,6 +157,7 @@ func FormatCreateProductResponse(product *entities.ProductModels) CreateProductF
 	createdProduct.Description = product.Description
 	createdProduct.GramPlastic = product.GramPlastic
 	createdProduct.Stock = product.Stock
+	createdProduct.Weight = product.Weight
 	createdProduct.Discount = product.Discount
 	createdProduct.Exp = product.Exp
 	createdProduct.Price = product.Price
@@ -173,6 +203,7 @@ func FormatProductDetail(product entities.ProductModels, totalSold uint64) Produ
 		GramPlastic: product.GramPlastic,
 		Price:       product.Price,
 		Stock:       product.Stock,
+		Weight:      product.Weight,
 		Discount:    product.Discount,
 		Exp:         product.Exp,
 		Rating:      product.Rating,
@@ -200,6 +231,12 @@ func FormatProductDetail(product entities.ProductModels, totalSold uint64) Produ
 	}
 	productFormatter.Images = images
 
+	variants := make([]VariantFormatter, 0, len(product.Variants))
+	for i := range product.Variants {
+		variants = append(variants, FormatVariant(&product.Variants[i]))
+	}
+	productFormatter.Variants = variants
+
 	var reviews []ReviewFormatter
 	for _, review := range product.ProductReview {
 		reviewFormatter := ReviewFormatter{
@@ -281,3 +318,41 @@ func FormatterOtherProduct(products []*entities.ProductModels) []*OtherProductFo
 
 	return productFormatter
 }
+
+type InventoryMovementFormatter struct {
+	ID         uint64    `json:"id"`
+	ProductID  uint64    `json:"product_id"`
+	VariantID  *uint64   `json:"variant_id"`
+	Type       string    `json:"type"`
+	Quantity   int64     `json:"quantity"`
+	StockAfter uint64    `json:"stock_after"`
+	Reference  string    `json:"reference"`
+	Reason     string    `json:"reason"`
+	ActorID    uint64    `json:"actor_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func FormatInventoryMovement(movement *entities.InventoryMovementModels) *InventoryMovementFormatter {
+	return &InventoryMovementFormatter{
+		ID:         movement.ID,
+		ProductID:  movement.ProductID,
+		VariantID:  movement.VariantID,
+		Type:       movement.Type,
+		Quantity:   movement.Quantity,
+		StockAfter: movement.StockAfter,
+		Reference:  movement.Reference,
+		Reason:     movement.Reason,
+		ActorID:    movement.ActorID,
+		CreatedAt:  movement.CreatedAt,
+	}
+}
+
+func FormatInventoryMovements(movements []*entities.InventoryMovementModels) []*InventoryMovementFormatter {
+	var movementFormatter []*InventoryMovementFormatter
+
+	for _, movement := range movements {
+		movementFormatter = append(movementFormatter, FormatInventoryMovement(movement))
+	}
+
+	return movementFormatter
+}
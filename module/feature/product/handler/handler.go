@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"mime/multipart"
 	"strconv"
 
@@ -24,46 +25,41 @@ func NewProductHandler(service product.ServiceProductInterface) product.HandlerP
 
 func (h *ProductHandler) GetAllProducts() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		page, _ := strconv.Atoi(c.QueryParam("page"))
-		pageConv := page
-		perPage := 8
-
-		var products []*entities.ProductModels
-		var totalItems int64
-		var err error
-
-		search := c.QueryParam("search")
-		categoryName := c.QueryParam("category_name")
-
-		if search != "" && categoryName != "" {
-			products, totalItems, err = h.service.GetProductsByCategoryAndName(categoryName, search, pageConv, perPage)
-		} else if categoryName != "" {
-			products, totalItems, err = h.service.GetProductsByCategoryName(categoryName, pageConv, perPage)
-		} else if search != "" {
-			products, totalItems, err = h.service.GetProductsByName(pageConv, perPage, search)
-		} else {
-			products, totalItems, err = h.service.GetAll(pageConv, perPage)
+		query, err := parseProductQuery(c)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format filter yang Anda masukkan tidak sesuai: "+err.Error())
+		}
+		if query.Category == "" {
+			query.Category = c.QueryParam("category_name")
 		}
 
+		products, totalItems, nextCursor, err := h.service.ListProducts(query)
 		if err != nil {
 			c.Logger().Error("handler: failed to fetch all products:", err.Error())
 			return response.SendBadRequestResponse(c, "Gagal mendapatkan daftar produk: "+err.Error())
 		}
 
-		currentPage, totalPages := h.service.CalculatePaginationValues(pageConv, int(totalItems), perPage)
-		nextPage := h.service.GetNextPage(currentPage, totalPages)
-		prevPage := h.service.GetPrevPage(currentPage)
+		return h.sendProductPage(c, query, dto.FormatterProduct(products), totalItems, nextCursor, "Berhasil mendapatkan daftar produk")
+	}
+}
 
-		return response.SendPaginationResponse(c, dto.FormatterProduct(products), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar produk")
+// sendProductPage answers a product listing. A request that pages by cursor
+// gets only the total and the next cursor, since page numbers mean nothing
+// there. Page mode also returns the next cursor so a client can switch over.
+func (h *ProductHandler) sendProductPage(c echo.Context, query *product.ProductQuery, data interface{}, totalItems int64, nextCursor, message string) error {
+	if query.Cursor != "" {
+		return response.SendCursorPaginationResponse(c, data, int(totalItems), nextCursor, message)
 	}
+
+	currentPage, totalPages := h.service.CalculatePaginationValues(query.Page, int(totalItems), query.PerPage)
+	nextPage := h.service.GetNextPage(currentPage, totalPages)
+	prevPage := h.service.GetPrevPage(currentPage)
+
+	return response.SendPaginationWithCursorResponse(c, data, currentPage, totalPages, int(totalItems), nextPage, prevPage, nextCursor, message)
 }
 
 func (h *ProductHandler) CreateProduct() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		var request dto.CreateProductRequest
 		if err := c.Bind(&request); err != nil {
 			c.Logger().Error("handler: invalid payload:", err.Error())
@@ -105,10 +101,6 @@ func (h *ProductHandler) GetProductById() echo.HandlerFunc {
 
 func (h *ProductHandler) CreateProductImage() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan:: Anda tidak memiliki izin")
-		}
 		payload := new(dto.CreateProductImage)
 		file, err := c.FormFile("photo")
 		var uploadedURL string
@@ -145,39 +137,23 @@ func (h *ProductHandler) CreateProductImage() echo.HandlerFunc {
 
 func (h *ProductHandler) GetAllProductsReview() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		query, err := parseProductQuery(c)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format filter yang Anda masukkan tidak sesuai: "+err.Error())
 		}
-		page, _ := strconv.Atoi(c.QueryParam("page"))
-		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
-		perPage := 8
 
-		var products []*entities.ProductModels
-		var totalItems int64
-		var err error
-		search := c.QueryParam("search")
-		filterRated := c.QueryParam("rating")
-
-		if search != "" && filterRated != "" {
-			products, totalItems, err = h.service.SearchByNameAndFilterByRating(pageConv, perPage, search, filterRated)
-		} else if filterRated != "" {
-			products, totalItems, err = h.service.GetRatedProductsInRange(pageConv, perPage, filterRated)
-		} else if search != "" {
-			products, totalItems, err = h.service.GetProductsByName(pageConv, perPage, search)
-		} else {
-			products, totalItems, err = h.service.GetProductReviews(pageConv, perPage)
-		}
+		products, totalItems, nextCursor, err := h.service.ListProducts(query)
 		if err != nil {
 			c.Logger().Error("handler: failed to fetch all products:", err.Error())
+			switch err.Error() {
+			case "tipe filter tidak valid", "urutan produk tidak valid", "cursor tidak valid",
+				"rentang harga tidak valid", "rentang rating tidak valid":
+				return response.SendBadRequestResponse(c, "Gagal mendapatkan daftar ulasan produk: "+err.Error())
+			}
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar ulasan produk")
 		}
 
-		currentPage, totalPages := h.service.CalculatePaginationValues(pageConv, int(totalItems), perPage)
-		nextPage := h.service.GetNextPage(currentPage, totalPages)
-		prevPage := h.service.GetPrevPage(currentPage)
-
-		return response.SendPaginationResponse(c, dto.FormatReviewProductFormatter(products), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar ulasan produk")
+		return h.sendProductPage(c, query, dto.FormatReviewProductFormatter(products), totalItems, nextCursor, "Berhasil mendapatkan daftar ulasan produk")
 	}
 }
 
@@ -188,10 +164,6 @@ func (h *ProductHandler) UpdateProduct() echo.HandlerFunc {
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
 		}
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		var request dto.UpdateProduct
 		if err := c.Bind(&request); err != nil {
 			c.Logger().Error("handler: invalid payload:", err.Error())
@@ -204,6 +176,12 @@ func (h *ProductHandler) UpdateProduct() echo.HandlerFunc {
 
 		_, err = h.service.UpdateProduct(productID, &request)
 		if err != nil {
+			switch err.Error() {
+			case "produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal memperbarui produk: "+err.Error())
+			case "stok diubah melalui penyesuaian stok":
+				return response.SendBadRequestResponse(c, "Gagal memperbarui produk: "+err.Error())
+			}
 			c.Logger().Error("handler: gagal update produk baru:", err.Error())
 			return response.SendStatusInternalServerResponse(c, "Gagal memperbarui produk: "+err.Error())
 		}
@@ -214,10 +192,6 @@ func (h *ProductHandler) UpdateProduct() echo.HandlerFunc {
 
 func (h *ProductHandler) DeleteProduct() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		productId, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
@@ -230,13 +204,174 @@ func (h *ProductHandler) DeleteProduct() echo.HandlerFunc {
 	}
 }
 
-func (h *ProductHandler) DeleteProductImageById() echo.HandlerFunc {
+func (h *ProductHandler) UpdateProductStock() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productId, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.UpdateProductStockRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		if err := h.service.UpdateProductStock(productId, *req.Stock, currentUser.ID); err != nil {
+			switch err.Error() {
+			case "produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal memperbarui stok produk: "+err.Error())
+			case "stok produk ini diatur per varian":
+				return response.SendBadRequestResponse(c, "Gagal memperbarui stok produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal memperbarui stok produk: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil memperbarui stok produk")
+	}
+}
+
+func (h *ProductHandler) AdjustStock() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.AdjustStockRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		movement, err := h.service.AdjustStock(productID, req, currentUser.ID)
+		if err != nil {
+			switch err.Error() {
+			case "produk tidak ditemukan", "varian produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal menyesuaikan stok produk: "+err.Error())
+			case "jumlah penyesuaian stok tidak boleh nol", "stok tidak boleh kurang dari nol", "varian produk wajib dipilih":
+				return response.SendBadRequestResponse(c, "Gagal menyesuaikan stok produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menyesuaikan stok produk: "+err.Error())
+		}
+		return response.SendStatusCreatedResponse(c, "Berhasil menyesuaikan stok produk", dto.FormatInventoryMovement(movement))
+	}
+}
+
+func (h *ProductHandler) GetInventoryMovements() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		perPage := 8
+
+		movements, totalItems, err := h.service.GetInventoryMovements(productID, page, perPage)
+		if err != nil {
+			if err.Error() == "produk tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Gagal mendapatkan riwayat stok produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan riwayat stok produk: "+err.Error())
+		}
+
+		currentPage, totalPages := h.service.CalculatePaginationValues(page, int(totalItems), perPage)
+		nextPage := h.service.GetNextPage(currentPage, totalPages)
+		prevPage := h.service.GetPrevPage(currentPage)
+
+		return response.SendPaginationResponse(c, dto.FormatInventoryMovements(movements), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan riwayat stok produk")
+	}
+}
+
+func (h *ProductHandler) CreateVariant() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.VariantRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		variant, err := h.service.CreateVariant(productID, req)
+		if err != nil {
+			switch err.Error() {
+			case "produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal menambahkan varian produk: "+err.Error())
+			case "sku varian sudah digunakan", "diskon varian melebihi harga", "stok produk harus dikosongkan sebelum menambahkan varian":
+				return response.SendBadRequestResponse(c, "Gagal menambahkan varian produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menambahkan varian produk: "+err.Error())
+		}
+		return response.SendStatusCreatedResponse(c, "Berhasil menambahkan varian produk", dto.FormatVariant(variant))
+	}
+}
+
+func (h *ProductHandler) UpdateVariant() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		variantID, err := strconv.ParseUint(c.Param("variant_id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID varian yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.VariantRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 
+		variant, err := h.service.UpdateVariant(productID, variantID, req)
+		if err != nil {
+			switch err.Error() {
+			case "varian produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal memperbarui varian produk: "+err.Error())
+			case "sku varian sudah digunakan", "diskon varian melebihi harga", "stok diubah melalui penyesuaian stok":
+				return response.SendBadRequestResponse(c, "Gagal memperbarui varian produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal memperbarui varian produk: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil memperbarui varian produk", dto.FormatVariant(variant))
+	}
+}
+
+func (h *ProductHandler) DeleteVariant() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		productID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		variantID, err := strconv.ParseUint(c.Param("variant_id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID varian yang Anda masukkan tidak sesuai.")
+		}
+
+		if err := h.service.DeleteVariant(productID, variantID); err != nil {
+			switch err.Error() {
+			case "varian produk tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal menghapus varian produk: "+err.Error())
+			case "stok varian harus dikosongkan sebelum dihapus":
+				return response.SendBadRequestResponse(c, "Gagal menghapus varian produk: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal menghapus varian produk: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil menghapus varian produk")
+	}
+}
+
+func (h *ProductHandler) DeleteProductImageById() echo.HandlerFunc {
+	return func(c echo.Context) error {
 		productId, err := strconv.ParseUint(c.Param("idProduct"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID Product yang Anda masukkan tidak sesuai.")
@@ -257,25 +392,24 @@ func (h *ProductHandler) DeleteProductImageById() echo.HandlerFunc {
 func (h *ProductHandler) GetAllProductsPreferences() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		page, _ := strconv.Atoi(c.QueryParam("page"))
-		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
-		perPage := 8
+		query, err := parseProductQuery(c)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format filter yang Anda masukkan tidak sesuai: "+err.Error())
+		}
+		if filter := c.QueryParam("filter"); filter == "promo" {
+			query.DiscountOnly = true
+		} else if filter != "" && query.Sort == "" {
+			query.Sort = filter
+		}
 
 		var products []*entities.ProductModels
 		var totalItems int64
-		var err error
+		var nextCursor string
 
-		search := c.QueryParam("search")
-		filter := c.QueryParam("filter")
-
-		if filter != "" && search != "" {
-			products, totalItems, err = h.service.GetProductsBySearchAndFilter(pageConv, perPage, filter, search)
-		} else if search != "" {
-			products, totalItems, err = h.service.GetProductsByName(pageConv, perPage, search)
-		} else if filter != "" {
-			products, totalItems, err = h.service.GetProductsByFilter(pageConv, perPage, filter)
+		if query.IsFiltered() {
+			products, totalItems, nextCursor, err = h.service.ListProducts(query)
 		} else {
-			products, totalItems, err = h.service.GetProductRecommendation(currentUser.ID, pageConv, perPage)
+			products, totalItems, err = h.service.GetProductRecommendation(currentUser.ID, query.Page, query.PerPage)
 		}
 
 		if err != nil {
@@ -283,12 +417,67 @@ func (h *ProductHandler) GetAllProductsPreferences() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Gagal mendapatkan daftar produk: "+err.Error())
 		}
 
-		currentPage, totalPages := h.service.CalculatePaginationValues(pageConv, int(totalItems), perPage)
-		nextPage := h.service.GetNextPage(currentPage, totalPages)
-		prevPage := h.service.GetPrevPage(currentPage)
+		return h.sendProductPage(c, query, dto.FormatterProduct(products), totalItems, nextCursor, "Berhasil mendapatkan daftar produk")
+	}
+}
 
-		return response.SendPaginationResponse(c, dto.FormatterProduct(products), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar produk")
+// parseProductQuery reads the listing filters shared by the product listings:
+// search, category, min_price, max_price, min_rating, max_rating, rating,
+// discount, in_stock, sort, page, per_page and cursor.
+func parseProductQuery(c echo.Context) (*product.ProductQuery, error) {
+	page, _ := strconv.Atoi(c.QueryParam("page"))
+	query := &product.ProductQuery{
+		Search:   c.QueryParam("search"),
+		Category: c.QueryParam("category"),
+		Rating:   c.QueryParam("rating"),
+		Sort:     c.QueryParam("sort"),
+		Cursor:   c.QueryParam("cursor"),
+		Page:     page,
+		PerPage:  product.DefaultPerPage,
 	}
+
+	if value := c.QueryParam("per_page"); value != "" {
+		perPage, err := strconv.Atoi(value)
+		if err != nil || perPage <= 0 {
+			return nil, errors.New("per_page tidak valid")
+		}
+		if perPage > product.MaxPerPage {
+			perPage = product.MaxPerPage
+		}
+		query.PerPage = perPage
+	}
+
+	for param, target := range map[string]**uint64{"min_price": &query.MinPrice, "max_price": &query.MaxPrice} {
+		if value := c.QueryParam(param); value != "" {
+			price, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, errors.New(param + " tidak valid")
+			}
+			*target = &price
+		}
+	}
+
+	for param, target := range map[string]**float64{"min_rating": &query.MinRating, "max_rating": &query.MaxRating} {
+		if value := c.QueryParam(param); value != "" {
+			rating, err := strconv.ParseFloat(value, 64)
+			if err != nil || rating < 0 || rating > 5 {
+				return nil, errors.New(param + " tidak valid")
+			}
+			*target = &rating
+		}
+	}
+
+	for param, target := range map[string]*bool{"discount": &query.DiscountOnly, "in_stock": &query.InStock} {
+		if value := c.QueryParam(param); value != "" {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, errors.New(param + " tidak valid")
+			}
+			*target = enabled
+		}
+	}
+
+	return query, nil
 }
 
 func (h *ProductHandler) GetTopRatedProducts() echo.HandlerFunc {
@@ -4,11 +4,11 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product/dto"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type RepositoryProductInterface interface {
-	FindByName(page, perPage int, name string) ([]*entities.ProductModels, error)
-	GetTotalProductCountByName(name string) (int64, error)
+	WithTx(tx *gorm.DB) RepositoryProductInterface
 	FindAll(page, perPage int) ([]*entities.ProductModels, error)
 	GetTotalProductCount() (int64, error)
 	CreateProduct(productData *entities.ProductModels, categoryIDs []uint64) (*entities.ProductModels, error)
@@ -16,51 +16,61 @@ type RepositoryProductInterface interface {
 	CreateImageProduct(productImage *entities.ProductPhotosModels) (*entities.ProductPhotosModels, error)
 	UpdateTotalReview(productID uint64) error
 	UpdateProductRating(productID uint64, newRating float64) error
-	GetProductReviews(page, perPage int) ([]*entities.ProductModels, error)
 	UpdateProduct(product *entities.ProductModels) (*entities.ProductModels, error)
 	UpdateProductCategories(product *entities.ProductModels, categoryIDs []uint64) error
+	FindProductIDsByCategory(categoryID uint64) ([]uint64, error)
 	DeleteProduct(id uint64) error
 	DeleteProductImage(productID, imageID uint64) error
 	ReduceStockWhenPurchasing(productID, stock uint64) error
 	IncreaseStock(productID, quantity uint64) error
+	UpdateProductStock(productID, stock uint64) error
+	CreateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error)
+	LockProduct(productID uint64) (*entities.ProductModels, error)
+	GetVariantByID(variantID uint64) (*entities.ProductVariantModels, error)
+	GetVariantBySKU(sku string) (*entities.ProductVariantModels, error)
+	UpdateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error)
+	DeleteVariant(variantID uint64) error
+	ReduceVariantStock(variantID, quantity uint64) error
+	IncreaseVariantStock(variantID, quantity uint64) error
+	SyncVariantStock(productID uint64) error
+	GetStockLevel(productID, variantID uint64) (uint64, error)
+	CreateInventoryMovement(movement *entities.InventoryMovementModels) error
+	FindInventoryMovements(productID uint64, page, perPage int) ([]*entities.InventoryMovementModels, error)
+	CountInventoryMovements(productID uint64) (int64, error)
 	GetTotalProductSold() (uint64, error)
 	GetTopRatedProducts() ([]*entities.ProductModels, error)
-	GetProductsByCategoryAndName(page, perPage int, categoryName, name string) ([]*entities.ProductModels, error)
-	GetProductsCountByCategoryAndName(categoryName, name string) (int64, error)
-	GetProductsByCategoryName(categoryName string, page, perPage int) ([]*entities.ProductModels, error)
-	GetProductCountByCategoryName(categoryName string) (int64, error)
-	GetProductBySearchAndFilter(page, perPage int, sortBy, search string) ([]*entities.ProductModels, int64, error)
-	GetProductByFilter(page, perPage int, sortBy string) ([]*entities.ProductModels, int64, error)
-	GetRatedProductsInRange(page, perPage int, lowerBound, upperBound float64) ([]*entities.ProductModels, int64, error)
-	SearchByNameAndFilterByRating(page, perPage int, name, ratingParam string, lowerBound, upperBound float64) ([]*entities.ProductModels, int64, error)
+	FindProducts(query *ProductQuery, cursor *Cursor, offset, limit int) ([]*entities.ProductModels, error)
+	CountProducts(query *ProductQuery) (int64, error)
 	FindAllProductByUserPreference(page, perPage int, productsFromAI []string) ([]*entities.ProductModels, error)
 }
 
 type ServiceProductInterface interface {
-	GetAll(page, perPage int) ([]*entities.ProductModels, int64, error)
+	WithTx(tx *gorm.DB) ServiceProductInterface
 	CalculatePaginationValues(page int, totalItems int, perPage int) (int, int)
 	GetNextPage(currentPage, totalPages int) int
 	GetPrevPage(currentPage int) int
-	GetProductsByName(page, perPage int, name string) ([]*entities.ProductModels, int64, error)
 	CreateProduct(request *dto.CreateProductRequest) (*entities.ProductModels, error)
 	GetProductByID(productID uint64) (*entities.ProductModels, error)
 	CreateImageProduct(request dto.CreateProductImage) (*entities.ProductPhotosModels, error)
 	UpdateTotalReview(productID uint64) error
 	UpdateProductRating(productID uint64, newRating float64) error
-	GetProductReviews(page, perPage int) ([]*entities.ProductModels, int64, error)
 	UpdateProduct(productID uint64, request *dto.UpdateProduct) (*entities.ProductModels, error)
 	DeleteProduct(id uint64) error
 	DeleteImageProduct(productId, imageId uint64) error
-	ReduceStockWhenPurchasing(productID, quantity uint64) error
-	IncreaseStock(productID, quantity uint64) error
+	ReduceStockWhenPurchasing(productID, variantID, quantity uint64, reference string) error
+	IncreaseStock(productID, variantID, quantity uint64, movementType, reference string) error
+	UpdateProductStock(productID, stock, actorID uint64) error
+	AdjustStock(productID uint64, request *dto.AdjustStockRequest, actorID uint64) (*entities.InventoryMovementModels, error)
+	GetInventoryMovements(productID uint64, page, perPage int) ([]*entities.InventoryMovementModels, int64, error)
+	ReindexSearch() (int, error)
+	ReindexCategoryProducts(categoryID uint64) error
+	GetProductForPurchase(productID, variantID uint64) (*entities.ProductModels, error)
+	CreateVariant(productID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error)
+	UpdateVariant(productID, variantID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error)
+	DeleteVariant(productID, variantID uint64) error
 	GetTotalProductSold() (uint64, error)
 	GetTopRatedProducts() ([]*entities.ProductModels, error)
-	GetProductsByCategoryAndName(categoryName, name string, page, perPage int) ([]*entities.ProductModels, int64, error)
-	GetProductsByCategoryName(categoryName string, page, perPage int) ([]*entities.ProductModels, int64, error)
-	GetProductsBySearchAndFilter(page, perPage int, filter, search string) ([]*entities.ProductModels, int64, error)
-	GetProductsByFilter(page, perPage int, filter string) ([]*entities.ProductModels, int64, error)
-	GetRatedProductsInRange(page, perPage int, ratingParam string) ([]*entities.ProductModels, int64, error)
-	SearchByNameAndFilterByRating(page, perPage int, name, ratingParam string) ([]*entities.ProductModels, int64, error)
+	ListProducts(query *ProductQuery) ([]*entities.ProductModels, int64, string, error)
 	GetProductRecommendation(userID uint64, page, perPage int) ([]*entities.ProductModels, int64, error)
 }
 
@@ -73,6 +83,12 @@ type HandlerProductInterface interface {
 	UpdateProduct() echo.HandlerFunc
 	DeleteProduct() echo.HandlerFunc
 	DeleteProductImageById() echo.HandlerFunc
+	UpdateProductStock() echo.HandlerFunc
+	AdjustStock() echo.HandlerFunc
+	GetInventoryMovements() echo.HandlerFunc
+	CreateVariant() echo.HandlerFunc
+	UpdateVariant() echo.HandlerFunc
+	DeleteVariant() echo.HandlerFunc
 	GetAllProductsPreferences() echo.HandlerFunc
 	GetTopRatedProducts() echo.HandlerFunc
 }
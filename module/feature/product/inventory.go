@@ -0,0 +1,16 @@
+package product
+
+const (
+	MovementInitial      = "initial"
+	MovementSale         = "sale"
+	MovementCancellation = "cancellation"
+	MovementAdjustment   = "adjustment"
+	MovementReturn       = "return"
+)
+
+// IsLowStockCrossing reports whether a movement took stock from above the
+// threshold to at or below it, so each drop is alerted once rather than on
+// every sale after it. A zero threshold turns alerts off.
+func IsLowStockCrossing(before, after, threshold uint64) bool {
+	return threshold > 0 && before > threshold && after <= threshold
+}
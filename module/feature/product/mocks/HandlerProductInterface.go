@@ -12,6 +12,22 @@ type HandlerProductInterface struct {
 	mock.Mock
 }
 
+// AdjustStock provides a mock function with given fields:
+func (_m *HandlerProductInterface) AdjustStock() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // CreateProduct provides a mock function with given fields:
 func (_m *HandlerProductInterface) CreateProduct() echo.HandlerFunc {
 	ret := _m.Called()
@@ -44,6 +60,22 @@ func (_m *HandlerProductInterface) CreateProductImage() echo.HandlerFunc {
 	return r0
 }
 
+// CreateVariant provides a mock function with given fields:
+func (_m *HandlerProductInterface) CreateVariant() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // DeleteProduct provides a mock function with given fields:
 func (_m *HandlerProductInterface) DeleteProduct() echo.HandlerFunc {
 	ret := _m.Called()
@@ -76,6 +108,22 @@ func (_m *HandlerProductInterface) DeleteProductImageById() echo.HandlerFunc {
 	return r0
 }
 
+// DeleteVariant provides a mock function with given fields:
+func (_m *HandlerProductInterface) DeleteVariant() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetAllProducts provides a mock function with given fields:
 func (_m *HandlerProductInterface) GetAllProducts() echo.HandlerFunc {
 	ret := _m.Called()
@@ -124,6 +172,22 @@ func (_m *HandlerProductInterface) GetAllProductsReview() echo.HandlerFunc {
 	return r0
 }
 
+// GetInventoryMovements provides a mock function with given fields:
+func (_m *HandlerProductInterface) GetInventoryMovements() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetProductById provides a mock function with given fields:
 func (_m *HandlerProductInterface) GetProductById() echo.HandlerFunc {
 	ret := _m.Called()
@@ -172,6 +236,38 @@ func (_m *HandlerProductInterface) UpdateProduct() echo.HandlerFunc {
 	return r0
 }
 
+// UpdateProductStock provides a mock function with given fields:
+func (_m *HandlerProductInterface) UpdateProductStock() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
+// UpdateVariant provides a mock function with given fields:
+func (_m *HandlerProductInterface) UpdateVariant() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // NewHandlerProductInterface creates a new instance of HandlerProductInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHandlerProductInterface(t interface {
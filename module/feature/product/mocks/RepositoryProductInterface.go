@@ -4,7 +4,9 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	product "github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // RepositoryProductInterface is an autogenerated mock type for the RepositoryProductInterface type
@@ -12,6 +14,54 @@ type RepositoryProductInterface struct {
 	mock.Mock
 }
 
+// CountInventoryMovements provides a mock function with given fields: productID
+func (_m *RepositoryProductInterface) CountInventoryMovements(productID uint64) (int64, error) {
+	ret := _m.Called(productID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (int64, error)); ok {
+		return rf(productID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
+		r0 = rf(productID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountProducts provides a mock function with given fields: query
+func (_m *RepositoryProductInterface) CountProducts(query *product.ProductQuery) (int64, error) {
+	ret := _m.Called(query)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery) (int64, error)); ok {
+		return rf(query)
+	}
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery) int64); ok {
+		r0 = rf(query)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*product.ProductQuery) error); ok {
+		r1 = rf(query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateImageProduct provides a mock function with given fields: productImage
 func (_m *RepositoryProductInterface) CreateImageProduct(productImage *entities.ProductPhotosModels) (*entities.ProductPhotosModels, error) {
 	ret := _m.Called(productImage)
@@ -38,6 +88,20 @@ func (_m *RepositoryProductInterface) CreateImageProduct(productImage *entities.
 	return r0, r1
 }
 
+// CreateInventoryMovement provides a mock function with given fields: movement
+func (_m *RepositoryProductInterface) CreateInventoryMovement(movement *entities.InventoryMovementModels) error {
+	ret := _m.Called(movement)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.InventoryMovementModels) error); ok {
+		r0 = rf(movement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateProduct provides a mock function with given fields: productData, categoryIDs
 func (_m *RepositoryProductInterface) CreateProduct(productData *entities.ProductModels, categoryIDs []uint64) (*entities.ProductModels, error) {
 	ret := _m.Called(productData, categoryIDs)
@@ -64,6 +128,32 @@ func (_m *RepositoryProductInterface) CreateProduct(productData *entities.Produc
 	return r0, r1
 }
 
+// CreateVariant provides a mock function with given fields: variant
+func (_m *RepositoryProductInterface) CreateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(variant)
+
+	var r0 *entities.ProductVariantModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.ProductVariantModels) (*entities.ProductVariantModels, error)); ok {
+		return rf(variant)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.ProductVariantModels) *entities.ProductVariantModels); ok {
+		r0 = rf(variant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.ProductVariantModels) error); ok {
+		r1 = rf(variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteProduct provides a mock function with given fields: id
 func (_m *RepositoryProductInterface) DeleteProduct(id uint64) error {
 	ret := _m.Called(id)
@@ -92,6 +182,20 @@ func (_m *RepositoryProductInterface) DeleteProductImage(productID uint64, image
 	return r0
 }
 
+// DeleteVariant provides a mock function with given fields: variantID
+func (_m *RepositoryProductInterface) DeleteVariant(variantID uint64) error {
+	ret := _m.Called(variantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(variantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindAll provides a mock function with given fields: page, perPage
 func (_m *RepositoryProductInterface) FindAll(page int, perPage int) ([]*entities.ProductModels, error) {
 	ret := _m.Called(page, perPage)
@@ -144,25 +248,25 @@ func (_m *RepositoryProductInterface) FindAllProductByUserPreference(page int, p
 	return r0, r1
 }
 
-// FindByName provides a mock function with given fields: page, perPage, name
-func (_m *RepositoryProductInterface) FindByName(page int, perPage int, name string) ([]*entities.ProductModels, error) {
-	ret := _m.Called(page, perPage, name)
+// FindInventoryMovements provides a mock function with given fields: productID, page, perPage
+func (_m *RepositoryProductInterface) FindInventoryMovements(productID uint64, page int, perPage int) ([]*entities.InventoryMovementModels, error) {
+	ret := _m.Called(productID, page, perPage)
 
-	var r0 []*entities.ProductModels
+	var r0 []*entities.InventoryMovementModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.ProductModels, error)); ok {
-		return rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]*entities.InventoryMovementModels, error)); ok {
+		return rf(productID, page, perPage)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, name)
+	if rf, ok := ret.Get(0).(func(uint64, int, int) []*entities.InventoryMovementModels); ok {
+		r0 = rf(productID, page, perPage)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
+			r0 = ret.Get(0).([]*entities.InventoryMovementModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string) error); ok {
-		r1 = rf(page, perPage, name)
+	if rf, ok := ret.Get(1).(func(uint64, int, int) error); ok {
+		r1 = rf(productID, page, perPage)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -170,58 +274,25 @@ func (_m *RepositoryProductInterface) FindByName(page int, perPage int, name str
 	return r0, r1
 }
 
-// GetProductByFilter provides a mock function with given fields: page, perPage, sortBy
-func (_m *RepositoryProductInterface) GetProductByFilter(page int, perPage int, sortBy string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, sortBy)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, sortBy)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, sortBy)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = rf(page, perPage, sortBy)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = rf(page, perPage, sortBy)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetProductByID provides a mock function with given fields: productID
-func (_m *RepositoryProductInterface) GetProductByID(productID uint64) (*entities.ProductModels, error) {
-	ret := _m.Called(productID)
+// FindProductIDsByCategory provides a mock function with given fields: categoryID
+func (_m *RepositoryProductInterface) FindProductIDsByCategory(categoryID uint64) ([]uint64, error) {
+	ret := _m.Called(categoryID)
 
-	var r0 *entities.ProductModels
+	var r0 []uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64) (*entities.ProductModels, error)); ok {
-		return rf(productID)
+	if rf, ok := ret.Get(0).(func(uint64) ([]uint64, error)); ok {
+		return rf(categoryID)
 	}
-	if rf, ok := ret.Get(0).(func(uint64) *entities.ProductModels); ok {
-		r0 = rf(productID)
+	if rf, ok := ret.Get(0).(func(uint64) []uint64); ok {
+		r0 = rf(categoryID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.ProductModels)
+			r0 = ret.Get(0).([]uint64)
 		}
 	}
 
 	if rf, ok := ret.Get(1).(func(uint64) error); ok {
-		r1 = rf(productID)
+		r1 = rf(categoryID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -229,56 +300,25 @@ func (_m *RepositoryProductInterface) GetProductByID(productID uint64) (*entitie
 	return r0, r1
 }
 
-// GetProductBySearchAndFilter provides a mock function with given fields: page, perPage, sortBy, search
-func (_m *RepositoryProductInterface) GetProductBySearchAndFilter(page int, perPage int, sortBy string, search string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, sortBy, search)
+// FindProducts provides a mock function with given fields: query, cursor, offset, limit
+func (_m *RepositoryProductInterface) FindProducts(query *product.ProductQuery, cursor *product.Cursor, offset int, limit int) ([]*entities.ProductModels, error) {
+	ret := _m.Called(query, cursor, offset, limit)
 
 	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, sortBy, search)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery, *product.Cursor, int, int) ([]*entities.ProductModels, error)); ok {
+		return rf(query, cursor, offset, limit)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, sortBy, search)
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery, *product.Cursor, int, int) []*entities.ProductModels); ok {
+		r0 = rf(query, cursor, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string, string) int64); ok {
-		r1 = rf(page, perPage, sortBy, search)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, string, string) error); ok {
-		r2 = rf(page, perPage, sortBy, search)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetProductCountByCategoryName provides a mock function with given fields: categoryName
-func (_m *RepositoryProductInterface) GetProductCountByCategoryName(categoryName string) (int64, error) {
-	ret := _m.Called(categoryName)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return rf(categoryName)
-	}
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(categoryName)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(categoryName)
+	if rf, ok := ret.Get(1).(func(*product.ProductQuery, *product.Cursor, int, int) error); ok {
+		r1 = rf(query, cursor, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -286,25 +326,25 @@ func (_m *RepositoryProductInterface) GetProductCountByCategoryName(categoryName
 	return r0, r1
 }
 
-// GetProductReviews provides a mock function with given fields: page, perPage
-func (_m *RepositoryProductInterface) GetProductReviews(page int, perPage int) ([]*entities.ProductModels, error) {
-	ret := _m.Called(page, perPage)
+// GetProductByID provides a mock function with given fields: productID
+func (_m *RepositoryProductInterface) GetProductByID(productID uint64) (*entities.ProductModels, error) {
+	ret := _m.Called(productID)
 
-	var r0 []*entities.ProductModels
+	var r0 *entities.ProductModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]*entities.ProductModels, error)); ok {
-		return rf(page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.ProductModels, error)); ok {
+		return rf(productID)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64) *entities.ProductModels); ok {
+		r0 = rf(productID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
+			r0 = ret.Get(0).(*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(page, perPage)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(productID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -312,25 +352,23 @@ func (_m *RepositoryProductInterface) GetProductReviews(page int, perPage int) (
 	return r0, r1
 }
 
-// GetProductsByCategoryAndName provides a mock function with given fields: page, perPage, categoryName, name
-func (_m *RepositoryProductInterface) GetProductsByCategoryAndName(page int, perPage int, categoryName string, name string) ([]*entities.ProductModels, error) {
-	ret := _m.Called(page, perPage, categoryName, name)
+// GetStockLevel provides a mock function with given fields: productID, variantID
+func (_m *RepositoryProductInterface) GetStockLevel(productID uint64, variantID uint64) (uint64, error) {
+	ret := _m.Called(productID, variantID)
 
-	var r0 []*entities.ProductModels
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) ([]*entities.ProductModels, error)); ok {
-		return rf(page, perPage, categoryName, name)
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (uint64, error)); ok {
+		return rf(productID, variantID)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, categoryName, name)
+	if rf, ok := ret.Get(0).(func(uint64, uint64) uint64); ok {
+		r0 = rf(productID, variantID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string, string) error); ok {
-		r1 = rf(page, perPage, categoryName, name)
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(productID, variantID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -338,49 +376,25 @@ func (_m *RepositoryProductInterface) GetProductsByCategoryAndName(page int, per
 	return r0, r1
 }
 
-// GetProductsByCategoryName provides a mock function with given fields: categoryName, page, perPage
-func (_m *RepositoryProductInterface) GetProductsByCategoryName(categoryName string, page int, perPage int) ([]*entities.ProductModels, error) {
-	ret := _m.Called(categoryName, page, perPage)
+// GetTopRatedProducts provides a mock function with given fields:
+func (_m *RepositoryProductInterface) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+	ret := _m.Called()
 
 	var r0 []*entities.ProductModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.ProductModels, error)); ok {
-		return rf(categoryName, page, perPage)
+	if rf, ok := ret.Get(0).(func() ([]*entities.ProductModels, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.ProductModels); ok {
-		r0 = rf(categoryName, page, perPage)
+	if rf, ok := ret.Get(0).(func() []*entities.ProductModels); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
-		r1 = rf(categoryName, page, perPage)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// GetProductsCountByCategoryAndName provides a mock function with given fields: categoryName, name
-func (_m *RepositoryProductInterface) GetProductsCountByCategoryAndName(categoryName string, name string) (int64, error) {
-	ret := _m.Called(categoryName, name)
-
-	var r0 int64
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (int64, error)); ok {
-		return rf(categoryName, name)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) int64); ok {
-		r0 = rf(categoryName, name)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(categoryName, name)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -388,54 +402,19 @@ func (_m *RepositoryProductInterface) GetProductsCountByCategoryAndName(category
 	return r0, r1
 }
 
-// GetRatedProductsInRange provides a mock function with given fields: page, perPage, lowerBound, upperBound
-func (_m *RepositoryProductInterface) GetRatedProductsInRange(page int, perPage int, lowerBound float64, upperBound float64) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, lowerBound, upperBound)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, float64, float64) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, lowerBound, upperBound)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, float64, float64) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, lowerBound, upperBound)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(int, int, float64, float64) int64); ok {
-		r1 = rf(page, perPage, lowerBound, upperBound)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, float64, float64) error); ok {
-		r2 = rf(page, perPage, lowerBound, upperBound)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
-}
-
-// GetTopRatedProducts provides a mock function with given fields:
-func (_m *RepositoryProductInterface) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+// GetTotalProductCount provides a mock function with given fields:
+func (_m *RepositoryProductInterface) GetTotalProductCount() (int64, error) {
 	ret := _m.Called()
 
-	var r0 []*entities.ProductModels
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*entities.ProductModels, error)); ok {
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() []*entities.ProductModels); ok {
+	if rf, ok := ret.Get(0).(func() int64); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -447,19 +426,19 @@ func (_m *RepositoryProductInterface) GetTopRatedProducts() ([]*entities.Product
 	return r0, r1
 }
 
-// GetTotalProductCount provides a mock function with given fields:
-func (_m *RepositoryProductInterface) GetTotalProductCount() (int64, error) {
+// GetTotalProductSold provides a mock function with given fields:
+func (_m *RepositoryProductInterface) GetTotalProductSold() (uint64, error) {
 	ret := _m.Called()
 
-	var r0 int64
+	var r0 uint64
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() int64); ok {
+	if rf, ok := ret.Get(0).(func() uint64); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(uint64)
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -471,23 +450,25 @@ func (_m *RepositoryProductInterface) GetTotalProductCount() (int64, error) {
 	return r0, r1
 }
 
-// GetTotalProductCountByName provides a mock function with given fields: name
-func (_m *RepositoryProductInterface) GetTotalProductCountByName(name string) (int64, error) {
-	ret := _m.Called(name)
+// GetVariantByID provides a mock function with given fields: variantID
+func (_m *RepositoryProductInterface) GetVariantByID(variantID uint64) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(variantID)
 
-	var r0 int64
+	var r0 *entities.ProductVariantModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (int64, error)); ok {
-		return rf(name)
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.ProductVariantModels, error)); ok {
+		return rf(variantID)
 	}
-	if rf, ok := ret.Get(0).(func(string) int64); ok {
-		r0 = rf(name)
+	if rf, ok := ret.Get(0).(func(uint64) *entities.ProductVariantModels); ok {
+		r0 = rf(variantID)
 	} else {
-		r0 = ret.Get(0).(int64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(name)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(variantID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -495,23 +476,25 @@ func (_m *RepositoryProductInterface) GetTotalProductCountByName(name string) (i
 	return r0, r1
 }
 
-// GetTotalProductSold provides a mock function with given fields:
-func (_m *RepositoryProductInterface) GetTotalProductSold() (uint64, error) {
-	ret := _m.Called()
+// GetVariantBySKU provides a mock function with given fields: sku
+func (_m *RepositoryProductInterface) GetVariantBySKU(sku string) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(sku)
 
-	var r0 uint64
+	var r0 *entities.ProductVariantModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string) (*entities.ProductVariantModels, error)); ok {
+		return rf(sku)
 	}
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) *entities.ProductVariantModels); ok {
+		r0 = rf(sku)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(sku)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -533,13 +516,13 @@ func (_m *RepositoryProductInterface) IncreaseStock(productID uint64, quantity u
 	return r0
 }
 
-// ReduceStockWhenPurchasing provides a mock function with given fields: productID, stock
-func (_m *RepositoryProductInterface) ReduceStockWhenPurchasing(productID uint64, stock uint64) error {
-	ret := _m.Called(productID, stock)
+// IncreaseVariantStock provides a mock function with given fields: variantID, quantity
+func (_m *RepositoryProductInterface) IncreaseVariantStock(variantID uint64, quantity uint64) error {
+	ret := _m.Called(variantID, quantity)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(productID, stock)
+		r0 = rf(variantID, quantity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -547,37 +530,72 @@ func (_m *RepositoryProductInterface) ReduceStockWhenPurchasing(productID uint64
 	return r0
 }
 
-// SearchByNameAndFilterByRating provides a mock function with given fields: page, perPage, name, ratingParam, lowerBound, upperBound
-func (_m *RepositoryProductInterface) SearchByNameAndFilterByRating(page int, perPage int, name string, ratingParam string, lowerBound float64, upperBound float64) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, name, ratingParam, lowerBound, upperBound)
+// LockProduct provides a mock function with given fields: productID
+func (_m *RepositoryProductInterface) LockProduct(productID uint64) (*entities.ProductModels, error) {
+	ret := _m.Called(productID)
 
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string, float64, float64) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, name, ratingParam, lowerBound, upperBound)
+	var r0 *entities.ProductModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.ProductModels, error)); ok {
+		return rf(productID)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string, string, float64, float64) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, name, ratingParam, lowerBound, upperBound)
+	if rf, ok := ret.Get(0).(func(uint64) *entities.ProductModels); ok {
+		r0 = rf(productID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
+			r0 = ret.Get(0).(*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string, string, float64, float64) int64); ok {
-		r1 = rf(page, perPage, name, ratingParam, lowerBound, upperBound)
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReduceStockWhenPurchasing provides a mock function with given fields: productID, stock
+func (_m *RepositoryProductInterface) ReduceStockWhenPurchasing(productID uint64, stock uint64) error {
+	ret := _m.Called(productID, stock)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
+		r0 = rf(productID, stock)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Error(0)
 	}
 
-	if rf, ok := ret.Get(2).(func(int, int, string, string, float64, float64) error); ok {
-		r2 = rf(page, perPage, name, ratingParam, lowerBound, upperBound)
+	return r0
+}
+
+// ReduceVariantStock provides a mock function with given fields: variantID, quantity
+func (_m *RepositoryProductInterface) ReduceVariantStock(variantID uint64, quantity uint64) error {
+	ret := _m.Called(variantID, quantity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
+		r0 = rf(variantID, quantity)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
+}
+
+// SyncVariantStock provides a mock function with given fields: productID
+func (_m *RepositoryProductInterface) SyncVariantStock(productID uint64) error {
+	ret := _m.Called(productID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // UpdateProduct provides a mock function with given fields: _a0
@@ -634,6 +652,20 @@ func (_m *RepositoryProductInterface) UpdateProductRating(productID uint64, newR
 	return r0
 }
 
+// UpdateProductStock provides a mock function with given fields: productID, stock
+func (_m *RepositoryProductInterface) UpdateProductStock(productID uint64, stock uint64) error {
+	ret := _m.Called(productID, stock)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
+		r0 = rf(productID, stock)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTotalReview provides a mock function with given fields: productID
 func (_m *RepositoryProductInterface) UpdateTotalReview(productID uint64) error {
 	ret := _m.Called(productID)
@@ -648,6 +680,48 @@ func (_m *RepositoryProductInterface) UpdateTotalReview(productID uint64) error
 	return r0
 }
 
+// UpdateVariant provides a mock function with given fields: variant
+func (_m *RepositoryProductInterface) UpdateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(variant)
+
+	var r0 *entities.ProductVariantModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*entities.ProductVariantModels) (*entities.ProductVariantModels, error)); ok {
+		return rf(variant)
+	}
+	if rf, ok := ret.Get(0).(func(*entities.ProductVariantModels) *entities.ProductVariantModels); ok {
+		r0 = rf(variant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*entities.ProductVariantModels) error); ok {
+		r1 = rf(variant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *RepositoryProductInterface) WithTx(tx *gorm.DB) product.RepositoryProductInterface {
+	ret := _m.Called(tx)
+
+	var r0 product.RepositoryProductInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) product.RepositoryProductInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(product.RepositoryProductInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewRepositoryProductInterface creates a new instance of RepositoryProductInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryProductInterface(t interface {
@@ -4,9 +4,10 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	product "github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/dto"
-
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // ServiceProductInterface is an autogenerated mock type for the ServiceProductInterface type
@@ -14,6 +15,32 @@ type ServiceProductInterface struct {
 	mock.Mock
 }
 
+// AdjustStock provides a mock function with given fields: productID, request, actorID
+func (_m *ServiceProductInterface) AdjustStock(productID uint64, request *dto.AdjustStockRequest, actorID uint64) (*entities.InventoryMovementModels, error) {
+	ret := _m.Called(productID, request, actorID)
+
+	var r0 *entities.InventoryMovementModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *dto.AdjustStockRequest, uint64) (*entities.InventoryMovementModels, error)); ok {
+		return rf(productID, request, actorID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *dto.AdjustStockRequest, uint64) *entities.InventoryMovementModels); ok {
+		r0 = rf(productID, request, actorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.InventoryMovementModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *dto.AdjustStockRequest, uint64) error); ok {
+		r1 = rf(productID, request, actorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculatePaginationValues provides a mock function with given fields: page, totalItems, perPage
 func (_m *ServiceProductInterface) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
 	ret := _m.Called(page, totalItems, perPage)
@@ -90,6 +117,32 @@ func (_m *ServiceProductInterface) CreateProduct(request *dto.CreateProductReque
 	return r0, r1
 }
 
+// CreateVariant provides a mock function with given fields: productID, request
+func (_m *ServiceProductInterface) CreateVariant(productID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(productID, request)
+
+	var r0 *entities.ProductVariantModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *dto.VariantRequest) (*entities.ProductVariantModels, error)); ok {
+		return rf(productID, request)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *dto.VariantRequest) *entities.ProductVariantModels); ok {
+		r0 = rf(productID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *dto.VariantRequest) error); ok {
+		r1 = rf(productID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteImageProduct provides a mock function with given fields: productId, imageId
 func (_m *ServiceProductInterface) DeleteImageProduct(productId uint64, imageId uint64) error {
 	ret := _m.Called(productId, imageId)
@@ -118,32 +171,46 @@ func (_m *ServiceProductInterface) DeleteProduct(id uint64) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: page, perPage
-func (_m *ServiceProductInterface) GetAll(page int, perPage int) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage)
+// DeleteVariant provides a mock function with given fields: productID, variantID
+func (_m *ServiceProductInterface) DeleteVariant(productID uint64, variantID uint64) error {
+	ret := _m.Called(productID, variantID)
 
-	var r0 []*entities.ProductModels
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
+		r0 = rf(productID, variantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetInventoryMovements provides a mock function with given fields: productID, page, perPage
+func (_m *ServiceProductInterface) GetInventoryMovements(productID uint64, page int, perPage int) ([]*entities.InventoryMovementModels, int64, error) {
+	ret := _m.Called(productID, page, perPage)
+
+	var r0 []*entities.InventoryMovementModels
 	var r1 int64
 	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]*entities.InventoryMovementModels, int64, error)); ok {
+		return rf(productID, page, perPage)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage)
+	if rf, ok := ret.Get(0).(func(uint64, int, int) []*entities.InventoryMovementModels); ok {
+		r0 = rf(productID, page, perPage)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
+			r0 = ret.Get(0).([]*entities.InventoryMovementModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) int64); ok {
-		r1 = rf(page, perPage)
+	if rf, ok := ret.Get(1).(func(uint64, int, int) int64); ok {
+		r1 = rf(productID, page, perPage)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(int, int) error); ok {
-		r2 = rf(page, perPage)
+	if rf, ok := ret.Get(2).(func(uint64, int, int) error); ok {
+		r2 = rf(productID, page, perPage)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -205,6 +272,32 @@ func (_m *ServiceProductInterface) GetProductByID(productID uint64) (*entities.P
 	return r0, r1
 }
 
+// GetProductForPurchase provides a mock function with given fields: productID, variantID
+func (_m *ServiceProductInterface) GetProductForPurchase(productID uint64, variantID uint64) (*entities.ProductModels, error) {
+	ret := _m.Called(productID, variantID)
+
+	var r0 *entities.ProductModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (*entities.ProductModels, error)); ok {
+		return rf(productID, variantID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) *entities.ProductModels); ok {
+		r0 = rf(productID, variantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(productID, variantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProductRecommendation provides a mock function with given fields: userID, page, perPage
 func (_m *ServiceProductInterface) GetProductRecommendation(userID uint64, page int, perPage int) ([]*entities.ProductModels, int64, error) {
 	ret := _m.Called(userID, page, perPage)
@@ -238,252 +331,151 @@ func (_m *ServiceProductInterface) GetProductRecommendation(userID uint64, page
 	return r0, r1, r2
 }
 
-// GetProductReviews provides a mock function with given fields: page, perPage
-func (_m *ServiceProductInterface) GetProductReviews(page int, perPage int) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage)
+// GetTopRatedProducts provides a mock function with given fields:
+func (_m *ServiceProductInterface) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+	ret := _m.Called()
 
 	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage)
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*entities.ProductModels, error)); ok {
+		return rf()
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage)
+	if rf, ok := ret.Get(0).(func() []*entities.ProductModels); ok {
+		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) int64); ok {
-		r1 = rf(page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int) error); ok {
-		r2 = rf(page, perPage)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetProductsByCategoryAndName provides a mock function with given fields: categoryName, name, page, perPage
-func (_m *ServiceProductInterface) GetProductsByCategoryAndName(categoryName string, name string, page int, perPage int) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(categoryName, name, page, perPage)
+// GetTotalProductSold provides a mock function with given fields:
+func (_m *ServiceProductInterface) GetTotalProductSold() (uint64, error) {
+	ret := _m.Called()
 
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(categoryName, name, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, string, int, int) []*entities.ProductModels); ok {
-		r0 = rf(categoryName, name, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
+		return rf()
 	}
-
-	if rf, ok := ret.Get(1).(func(string, string, int, int) int64); ok {
-		r1 = rf(categoryName, name, page, perPage)
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
 	} else {
-		r1 = ret.Get(1).(int64)
+		r0 = ret.Get(0).(uint64)
 	}
 
-	if rf, ok := ret.Get(2).(func(string, string, int, int) error); ok {
-		r2 = rf(categoryName, name, page, perPage)
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
 	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
 
-	return r0, r1, r2
+	return r0, r1
 }
 
-// GetProductsByCategoryName provides a mock function with given fields: categoryName, page, perPage
-func (_m *ServiceProductInterface) GetProductsByCategoryName(categoryName string, page int, perPage int) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(categoryName, page, perPage)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(string, int, int) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(categoryName, page, perPage)
-	}
-	if rf, ok := ret.Get(0).(func(string, int, int) []*entities.ProductModels); ok {
-		r0 = rf(categoryName, page, perPage)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, int, int) int64); ok {
-		r1 = rf(categoryName, page, perPage)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
+// IncreaseStock provides a mock function with given fields: productID, variantID, quantity, movementType, reference
+func (_m *ServiceProductInterface) IncreaseStock(productID uint64, variantID uint64, quantity uint64, movementType string, reference string) error {
+	ret := _m.Called(productID, variantID, quantity, movementType, reference)
 
-	if rf, ok := ret.Get(2).(func(string, int, int) error); ok {
-		r2 = rf(categoryName, page, perPage)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64, string, string) error); ok {
+		r0 = rf(productID, variantID, quantity, movementType, reference)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// GetProductsByFilter provides a mock function with given fields: page, perPage, filter
-func (_m *ServiceProductInterface) GetProductsByFilter(page int, perPage int, filter string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, filter)
+// ListProducts provides a mock function with given fields: query
+func (_m *ServiceProductInterface) ListProducts(query *product.ProductQuery) ([]*entities.ProductModels, int64, string, error) {
+	ret := _m.Called(query)
 
 	var r0 []*entities.ProductModels
 	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, filter)
+	var r2 string
+	var r3 error
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery) ([]*entities.ProductModels, int64, string, error)); ok {
+		return rf(query)
 	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, filter)
+	if rf, ok := ret.Get(0).(func(*product.ProductQuery) []*entities.ProductModels); ok {
+		r0 = rf(query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*entities.ProductModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = rf(page, perPage, filter)
+	if rf, ok := ret.Get(1).(func(*product.ProductQuery) int64); ok {
+		r1 = rf(query)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
-	if rf, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = rf(page, perPage, filter)
+	if rf, ok := ret.Get(2).(func(*product.ProductQuery) string); ok {
+		r2 = rf(query)
 	} else {
-		r2 = ret.Error(2)
+		r2 = ret.Get(2).(string)
 	}
 
-	return r0, r1, r2
-}
-
-// GetProductsByName provides a mock function with given fields: page, perPage, name
-func (_m *ServiceProductInterface) GetProductsByName(page int, perPage int, name string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, name)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, name)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, name)
+	if rf, ok := ret.Get(3).(func(*product.ProductQuery) error); ok {
+		r3 = rf(query)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
+		r3 = ret.Error(3)
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = rf(page, perPage, name)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = rf(page, perPage, name)
-	} else {
-		r2 = ret.Error(2)
-	}
-
-	return r0, r1, r2
+	return r0, r1, r2, r3
 }
 
-// GetProductsBySearchAndFilter provides a mock function with given fields: page, perPage, filter, search
-func (_m *ServiceProductInterface) GetProductsBySearchAndFilter(page int, perPage int, filter string, search string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, filter, search)
+// ReduceStockWhenPurchasing provides a mock function with given fields: productID, variantID, quantity, reference
+func (_m *ServiceProductInterface) ReduceStockWhenPurchasing(productID uint64, variantID uint64, quantity uint64, reference string) error {
+	ret := _m.Called(productID, variantID, quantity, reference)
 
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, filter, search)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, string, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, filter, search)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(int, int, string, string) int64); ok {
-		r1 = rf(page, perPage, filter, search)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, string, string) error); ok {
-		r2 = rf(page, perPage, filter, search)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64, string) error); ok {
+		r0 = rf(productID, variantID, quantity, reference)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// GetRatedProductsInRange provides a mock function with given fields: page, perPage, ratingParam
-func (_m *ServiceProductInterface) GetRatedProductsInRange(page int, perPage int, ratingParam string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, ratingParam)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, ratingParam)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, ratingParam)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
+// ReindexCategoryProducts provides a mock function with given fields: categoryID
+func (_m *ServiceProductInterface) ReindexCategoryProducts(categoryID uint64) error {
+	ret := _m.Called(categoryID)
 
-	if rf, ok := ret.Get(1).(func(int, int, string) int64); ok {
-		r1 = rf(page, perPage, ratingParam)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-
-	if rf, ok := ret.Get(2).(func(int, int, string) error); ok {
-		r2 = rf(page, perPage, ratingParam)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(categoryID)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// GetTopRatedProducts provides a mock function with given fields:
-func (_m *ServiceProductInterface) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+// ReindexSearch provides a mock function with given fields:
+func (_m *ServiceProductInterface) ReindexSearch() (int, error) {
 	ret := _m.Called()
 
-	var r0 []*entities.ProductModels
+	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]*entities.ProductModels, error)); ok {
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() []*entities.ProductModels); ok {
+	if rf, ok := ret.Get(0).(func() int); ok {
 		r0 = rf()
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
+		r0 = ret.Get(0).(int)
 	}
 
 	if rf, ok := ret.Get(1).(func() error); ok {
@@ -495,23 +487,25 @@ func (_m *ServiceProductInterface) GetTopRatedProducts() ([]*entities.ProductMod
 	return r0, r1
 }
 
-// GetTotalProductSold provides a mock function with given fields:
-func (_m *ServiceProductInterface) GetTotalProductSold() (uint64, error) {
-	ret := _m.Called()
+// UpdateProduct provides a mock function with given fields: productID, request
+func (_m *ServiceProductInterface) UpdateProduct(productID uint64, request *dto.UpdateProduct) (*entities.ProductModels, error) {
+	ret := _m.Called(productID, request)
 
-	var r0 uint64
+	var r0 *entities.ProductModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (uint64, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(uint64, *dto.UpdateProduct) (*entities.ProductModels, error)); ok {
+		return rf(productID, request)
 	}
-	if rf, ok := ret.Get(0).(func() uint64); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(uint64, *dto.UpdateProduct) *entities.ProductModels); ok {
+		r0 = rf(productID, request)
 	} else {
-		r0 = ret.Get(0).(uint64)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.ProductModels)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(uint64, *dto.UpdateProduct) error); ok {
+		r1 = rf(productID, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -519,13 +513,13 @@ func (_m *ServiceProductInterface) GetTotalProductSold() (uint64, error) {
 	return r0, r1
 }
 
-// IncreaseStock provides a mock function with given fields: productID, quantity
-func (_m *ServiceProductInterface) IncreaseStock(productID uint64, quantity uint64) error {
-	ret := _m.Called(productID, quantity)
+// UpdateProductRating provides a mock function with given fields: productID, newRating
+func (_m *ServiceProductInterface) UpdateProductRating(productID uint64, newRating float64) error {
+	ret := _m.Called(productID, newRating)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(productID, quantity)
+	if rf, ok := ret.Get(0).(func(uint64, float64) error); ok {
+		r0 = rf(productID, newRating)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -533,13 +527,13 @@ func (_m *ServiceProductInterface) IncreaseStock(productID uint64, quantity uint
 	return r0
 }
 
-// ReduceStockWhenPurchasing provides a mock function with given fields: productID, quantity
-func (_m *ServiceProductInterface) ReduceStockWhenPurchasing(productID uint64, quantity uint64) error {
-	ret := _m.Called(productID, quantity)
+// UpdateProductStock provides a mock function with given fields: productID, stock, actorID
+func (_m *ServiceProductInterface) UpdateProductStock(productID uint64, stock uint64, actorID uint64) error {
+	ret := _m.Called(productID, stock, actorID)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, uint64) error); ok {
-		r0 = rf(productID, quantity)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(productID, stock, actorID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -547,58 +541,39 @@ func (_m *ServiceProductInterface) ReduceStockWhenPurchasing(productID uint64, q
 	return r0
 }
 
-// SearchByNameAndFilterByRating provides a mock function with given fields: page, perPage, name, ratingParam
-func (_m *ServiceProductInterface) SearchByNameAndFilterByRating(page int, perPage int, name string, ratingParam string) ([]*entities.ProductModels, int64, error) {
-	ret := _m.Called(page, perPage, name, ratingParam)
-
-	var r0 []*entities.ProductModels
-	var r1 int64
-	var r2 error
-	if rf, ok := ret.Get(0).(func(int, int, string, string) ([]*entities.ProductModels, int64, error)); ok {
-		return rf(page, perPage, name, ratingParam)
-	}
-	if rf, ok := ret.Get(0).(func(int, int, string, string) []*entities.ProductModels); ok {
-		r0 = rf(page, perPage, name, ratingParam)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entities.ProductModels)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(int, int, string, string) int64); ok {
-		r1 = rf(page, perPage, name, ratingParam)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
+// UpdateTotalReview provides a mock function with given fields: productID
+func (_m *ServiceProductInterface) UpdateTotalReview(productID uint64) error {
+	ret := _m.Called(productID)
 
-	if rf, ok := ret.Get(2).(func(int, int, string, string) error); ok {
-		r2 = rf(page, perPage, name, ratingParam)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(productID)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// UpdateProduct provides a mock function with given fields: productID, request
-func (_m *ServiceProductInterface) UpdateProduct(productID uint64, request *dto.UpdateProduct) (*entities.ProductModels, error) {
-	ret := _m.Called(productID, request)
+// UpdateVariant provides a mock function with given fields: productID, variantID, request
+func (_m *ServiceProductInterface) UpdateVariant(productID uint64, variantID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error) {
+	ret := _m.Called(productID, variantID, request)
 
-	var r0 *entities.ProductModels
+	var r0 *entities.ProductVariantModels
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint64, *dto.UpdateProduct) (*entities.ProductModels, error)); ok {
-		return rf(productID, request)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, *dto.VariantRequest) (*entities.ProductVariantModels, error)); ok {
+		return rf(productID, variantID, request)
 	}
-	if rf, ok := ret.Get(0).(func(uint64, *dto.UpdateProduct) *entities.ProductModels); ok {
-		r0 = rf(productID, request)
+	if rf, ok := ret.Get(0).(func(uint64, uint64, *dto.VariantRequest) *entities.ProductVariantModels); ok {
+		r0 = rf(productID, variantID, request)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*entities.ProductModels)
+			r0 = ret.Get(0).(*entities.ProductVariantModels)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint64, *dto.UpdateProduct) error); ok {
-		r1 = rf(productID, request)
+	if rf, ok := ret.Get(1).(func(uint64, uint64, *dto.VariantRequest) error); ok {
+		r1 = rf(productID, variantID, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -606,29 +581,17 @@ func (_m *ServiceProductInterface) UpdateProduct(productID uint64, request *dto.
 	return r0, r1
 }
 
-// UpdateProductRating provides a mock function with given fields: productID, newRating
-func (_m *ServiceProductInterface) UpdateProductRating(productID uint64, newRating float64) error {
-	ret := _m.Called(productID, newRating)
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64, float64) error); ok {
-		r0 = rf(productID, newRating)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateTotalReview provides a mock function with given fields: productID
-func (_m *ServiceProductInterface) UpdateTotalReview(productID uint64) error {
-	ret := _m.Called(productID)
+// WithTx provides a mock function with given fields: tx
+func (_m *ServiceProductInterface) WithTx(tx *gorm.DB) product.ServiceProductInterface {
+	ret := _m.Called(tx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uint64) error); ok {
-		r0 = rf(productID)
+	var r0 product.ServiceProductInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) product.ServiceProductInterface); ok {
+		r0 = rf(tx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(product.ServiceProductInterface)
+		}
 	}
 
 	return r0
@@ -0,0 +1,130 @@
+package product
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+)
+
+const (
+	SortNewest   = "terbaru"
+	SortCheapest = "termurah"
+	SortPriciest = "termahal"
+	SortAlphabet = "abjad"
+	SortTopRated = "rating"
+)
+
+const (
+	DefaultSort    = SortNewest
+	DefaultPerPage = 8
+	MaxPerPage     = 50
+)
+
+// ProductQuery describes one product listing. Every filter is optional and
+// they all combine with AND. Rating is a label such as "baik" that the service
+// turns into MinRating and MaxRating when those are not set.
+type ProductQuery struct {
+	Search       string
+	Category     string
+	MinPrice     *uint64
+	MaxPrice     *uint64
+	MinRating    *float64
+	MaxRating    *float64
+	Rating       string
+	DiscountOnly bool
+	InStock      bool
+	Sort         string
+	Page         int
+	PerPage      int
+	Cursor       string
+}
+
+// IsFiltered reports whether the query asks for anything beyond a page number.
+func (q *ProductQuery) IsFiltered() bool {
+	return q.Search != "" || q.Category != "" || q.MinPrice != nil || q.MaxPrice != nil ||
+		q.MinRating != nil || q.MaxRating != nil || q.Rating != "" || q.DiscountOnly ||
+		q.InStock || q.Sort != "" || q.Cursor != ""
+}
+
+// SortOrder is the column a sort orders by and its direction. The product ID
+// breaks ties in the same direction so the order is total and cursors work.
+type SortOrder struct {
+	Column string
+	Desc   bool
+}
+
+var sortOrders = map[string]SortOrder{
+	SortNewest:   {Column: "created_at", Desc: true},
+	SortCheapest: {Column: "price"},
+	SortPriciest: {Column: "price", Desc: true},
+	SortAlphabet: {Column: "name"},
+	SortTopRated: {Column: "rating", Desc: true},
+}
+
+func GetSortOrder(sort string) (SortOrder, bool) {
+	order, ok := sortOrders[sort]
+	return order, ok
+}
+
+// Cursor marks the last product of a page: its ID and the value of the sort
+// column, so the next page starts right after it even when products are added
+// or removed in between.
+type Cursor struct {
+	Sort      string    `json:"s"`
+	ID        uint64    `json:"id"`
+	Price     uint64    `json:"p,omitempty"`
+	Name      string    `json:"n,omitempty"`
+	Rating    float64   `json:"r,omitempty"`
+	CreatedAt time.Time `json:"c"`
+}
+
+func NewCursor(sort string, last *entities.ProductModels) *Cursor {
+	cursor := &Cursor{Sort: sort, ID: last.ID}
+	switch sort {
+	case SortCheapest, SortPriciest:
+		cursor.Price = last.Price
+	case SortAlphabet:
+		cursor.Name = last.Name
+	case SortTopRated:
+		cursor.Rating = last.Rating
+	default:
+		cursor.CreatedAt = last.CreatedAt
+	}
+	return cursor
+}
+
+// Value is the sort column value the cursor was taken at.
+func (c *Cursor) Value() interface{} {
+	switch c.Sort {
+	case SortCheapest, SortPriciest:
+		return c.Price
+	case SortAlphabet:
+		return c.Name
+	case SortTopRated:
+		return c.Rating
+	default:
+		return c.CreatedAt
+	}
+}
+
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor reads a cursor made by Encode. A cursor only continues the sort
+// it was made for.
+func DecodeCursor(value, sort string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.New("cursor tidak valid")
+	}
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != sort || cursor.ID == 0 {
+		return nil, errors.New("cursor tidak valid")
+	}
+	return &cursor, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepository struct {
@@ -20,39 +21,16 @@ func NewProductRepository(db *gorm.DB) product.RepositoryProductInterface {
 	}
 }
 
-func (r *ProductRepository) FindByName(page, perPage int, name string) ([]*entities.ProductModels, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
-	query := r.db.Offset(offset).Limit(perPage).Preload("Categories").Preload("ProductPhotos").Where("deleted_at IS NULL")
-
-	if name != "" {
-		query = query.Where("name LIKE ?", "%"+name+"%")
-	}
-
-	err := query.Find(&products).Error
-	if err != nil {
-		return nil, err
-	}
-
-	return products, nil
-}
-
-func (r *ProductRepository) GetTotalProductCountByName(name string) (int64, error) {
-	var count int64
-	query := r.db.Model(&entities.ProductModels{}).Where("deleted_at IS NULL")
-
-	if name != "" {
-		query = query.Where("name LIKE ?", "%"+name+"%")
+func (r *ProductRepository) WithTx(tx *gorm.DB) product.RepositoryProductInterface {
+	return &ProductRepository{
+		db: tx,
 	}
-
-	err := query.Count(&count).Error
-	return count, err
 }
 
 func (r *ProductRepository) FindAll(page, perPage int) ([]*entities.ProductModels, error) {
 	var products []*entities.ProductModels
 	offset := (page - 1) * perPage
-	err := r.db.Offset(offset).Limit(perPage).Preload("Categories").Preload("ProductPhotos").Where("deleted_at IS NULL").Find(&products).Error
+	err := r.db.Order("id ASC").Offset(offset).Limit(perPage).Preload("Categories").Preload("ProductPhotos").Where("deleted_at IS NULL").Find(&products).Error
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +77,7 @@ func (r *ProductRepository) GetProductByID(productID uint64) (*entities.ProductM
 	var products *entities.ProductModels
 
 	if err := r.db.Preload("Categories").Preload("ProductPhotos").
+		Preload("Variants", "deleted_at IS NULL").
 		Preload("ProductReview", func(db *gorm.DB) *gorm.DB {
 			return db.Limit(2)
 		}).Preload("ProductReview.User").Preload("ProductReview.Photos").
@@ -133,20 +112,10 @@ func (r *ProductRepository) UpdateProductRating(productID uint64, newRating floa
 	return nil
 }
 
-func (r *ProductRepository) GetProductReviews(page, perPage int) ([]*entities.ProductModels, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
-	err := r.db.Where("deleted_at IS NULL").Offset(offset).Limit(perPage).Find(&products).Error
-	if err != nil {
-		return nil, err
-	}
-	return products, nil
-}
-
 func (r *ProductRepository) UpdateProduct(product *entities.ProductModels) (*entities.ProductModels, error) {
 	tx := r.db.Begin()
 
-	if err := tx.Save(product).Error; err != nil {
+	if err := tx.Omit("stock").Save(product).Error; err != nil {
 		tx.Rollback()
 		return nil, errors.New("gagal menyimpan produk: " + err.Error())
 	}
@@ -177,6 +146,18 @@ func (r *ProductRepository) UpdateProductCategories(product *entities.ProductMod
 	return tx.Commit().Error
 }
 
+func (r *ProductRepository) FindProductIDsByCategory(categoryID uint64) ([]uint64, error) {
+	var productIDs []uint64
+	err := r.db.Table("product_categories").
+		Joins("JOIN products ON products.id = product_categories.product_models_id").
+		Where("product_categories.category_models_id = ? AND products.deleted_at IS NULL", categoryID).
+		Pluck("product_categories.product_models_id", &productIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return productIDs, nil
+}
+
 func (r *ProductRepository) DeleteProduct(id uint64) error {
 	var productData entities.ProductModels
 	if err := r.db.First(&productData, id).Error; err != nil {
@@ -209,8 +190,14 @@ func (r *ProductRepository) DeleteProductImage(productID, imageID uint64) error
 
 func (r *ProductRepository) ReduceStockWhenPurchasing(productID, quantity uint64) error {
 	var products entities.ProductModels
-	if err := r.db.Model(&products).Where("id = ?", productID).Update("stock", gorm.Expr("stock - ?", quantity)).Error; err != nil {
-		return err
+	result := r.db.Model(&products).
+		Where("id = ? AND stock >= ?", productID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("stok tidak mencukupi untuk pesanan ini")
 	}
 	return nil
 }
@@ -223,215 +210,236 @@ func (r *ProductRepository) IncreaseStock(productID, quantity uint64) error {
 	return nil
 }
 
-func (r *ProductRepository) GetTotalProductSold() (uint64, error) {
-	var totalSold uint64
-
-	query := `
-        SELECT 
-            COALESCE(SUM(quantity), 0) as total_sold 
-        FROM 
-            order_details
-    `
-
-	if err := r.db.Raw(query).Row().Scan(&totalSold); err != nil {
-		return 0, err
+func (r *ProductRepository) CreateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error) {
+	if err := r.db.Create(variant).Error; err != nil {
+		return nil, err
 	}
+	return variant, nil
+}
 
-	return totalSold, nil
+// LockProduct reads the product and holds its row until the transaction ends,
+// so a stock change computed from it cannot race another one.
+func (r *ProductRepository) LockProduct(productID uint64) (*entities.ProductModels, error) {
+	var products entities.ProductModels
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Variants", "deleted_at IS NULL").
+		Where("id = ? AND deleted_at IS NULL", productID).
+		First(&products).Error; err != nil {
+		return nil, err
+	}
+	return &products, nil
 }
 
-func (r *ProductRepository) GetTopRatedProducts() ([]*entities.ProductModels, error) {
-	var products []*entities.ProductModels
+func (r *ProductRepository) GetVariantByID(variantID uint64) (*entities.ProductVariantModels, error) {
+	var variant entities.ProductVariantModels
+	if err := r.db.Where("id = ? AND deleted_at IS NULL", variantID).First(&variant).Error; err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
 
-	if err := r.db.Order("rating desc").Where("deleted_at IS NULL").Limit(5).Find(&products).Error; err != nil {
+func (r *ProductRepository) GetVariantBySKU(sku string) (*entities.ProductVariantModels, error) {
+	var variant entities.ProductVariantModels
+	if err := r.db.Where("sku = ? AND deleted_at IS NULL", sku).First(&variant).Error; err != nil {
 		return nil, err
 	}
+	return &variant, nil
+}
 
-	return products, nil
+func (r *ProductRepository) UpdateVariant(variant *entities.ProductVariantModels) (*entities.ProductVariantModels, error) {
+	if err := r.db.Omit("stock").Save(variant).Error; err != nil {
+		return nil, err
+	}
+	return variant, nil
 }
 
-func (r *ProductRepository) GetProductsByCategoryAndName(page, perPage int, categoryName, name string) ([]*entities.ProductModels, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
+func (r *ProductRepository) DeleteVariant(variantID uint64) error {
+	if err := r.db.Model(&entities.ProductVariantModels{}).Where("id = ?", variantID).Update("deleted_at", time.Now()).Error; err != nil {
+		return err
+	}
+	return nil
+}
 
-	query := r.db.Offset(offset).Limit(perPage).
-		Joins("JOIN product_categories ON product_categories.product_models_id = products.id").
-		Joins("JOIN category ON category.id = product_categories.category_models_id").
-		Where("category.name = ? AND products.name LIKE ? AND products.deleted_at IS NULL", categoryName, "%"+name+"%")
+func (r *ProductRepository) ReduceVariantStock(variantID, quantity uint64) error {
+	result := r.db.Model(&entities.ProductVariantModels{}).
+		Where("id = ? AND stock >= ? AND deleted_at IS NULL", variantID, quantity).
+		Update("stock", gorm.Expr("stock - ?", quantity))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("stok tidak mencukupi untuk pesanan ini")
+	}
+	return nil
+}
 
-	if err := query.Find(&products).Error; err != nil {
-		return nil, err
+func (r *ProductRepository) IncreaseVariantStock(variantID, quantity uint64) error {
+	if err := r.db.Model(&entities.ProductVariantModels{}).Where("id = ?", variantID).Update("stock", gorm.Expr("stock + ?", quantity)).Error; err != nil {
+		return err
 	}
+	return nil
+}
 
-	return products, nil
+// SyncVariantStock sets the product stock to the sum of its live variants.
+func (r *ProductRepository) SyncVariantStock(productID uint64) error {
+	total := r.db.Model(&entities.ProductVariantModels{}).
+		Select("COALESCE(SUM(stock), 0)").
+		Where("product_id = ? AND deleted_at IS NULL", productID)
+	if err := r.db.Model(&entities.ProductModels{}).Where("id = ?", productID).Update("stock", total).Error; err != nil {
+		return err
+	}
+	return nil
 }
 
-func (r *ProductRepository) GetProductsCountByCategoryAndName(categoryName, name string) (int64, error) {
-	var count int64
+func (r *ProductRepository) UpdateProductStock(productID, stock uint64) error {
+	var products entities.ProductModels
+	if err := r.db.Model(&products).Where("id = ?", productID).Update("stock", stock).Error; err != nil {
+		return err
+	}
+	return nil
+}
 
-	if err := r.db.Model(&entities.ProductModels{}).
-		Joins("JOIN product_categories ON product_categories.product_models_id = products.id").
-		Joins("JOIN category ON category.id = product_categories.category_models_id").
-		Where("category.name = ? AND products.name LIKE ? AND products.deleted_at IS NULL", categoryName, "%"+name+"%").
-		Count(&count).Error; err != nil {
+// GetStockLevel reads the current stock of the variant when one is given and
+// of the product otherwise.
+func (r *ProductRepository) GetStockLevel(productID, variantID uint64) (uint64, error) {
+	var stock uint64
+	query := r.db.Model(&entities.ProductModels{}).Where("id = ?", productID)
+	if variantID != 0 {
+		query = r.db.Model(&entities.ProductVariantModels{}).Where("id = ? AND product_id = ?", variantID, productID)
+	}
+	if err := query.Select("stock").Row().Scan(&stock); err != nil {
 		return 0, err
 	}
-
-	return count, nil
+	return stock, nil
 }
 
-func (r *ProductRepository) GetProductsByCategoryName(categoryName string, page, perPage int) ([]*entities.ProductModels, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
-
-	var category *entities.CategoryModels
-	if err := r.db.Where("name = ?", categoryName).First(&category).Error; err != nil {
-		return nil, err
+func (r *ProductRepository) CreateInventoryMovement(movement *entities.InventoryMovementModels) error {
+	if err := r.db.Create(movement).Error; err != nil {
+		return err
 	}
+	return nil
+}
 
-	if err := r.db.Offset(offset).Limit(perPage).
-		Joins("JOIN product_categories ON product_categories.product_models_id = products.id").
-		Joins("JOIN category ON category.id = product_categories.category_models_id").
-		Where("category.name = ?", categoryName).
-		Where("products.deleted_at IS NULL").
-		Find(&products).Error; err != nil {
+func (r *ProductRepository) FindInventoryMovements(productID uint64, page, perPage int) ([]*entities.InventoryMovementModels, error) {
+	var movements []*entities.InventoryMovementModels
+	offset := (page - 1) * perPage
+	if err := r.db.Where("product_id = ?", productID).
+		Order("created_at DESC, id DESC").
+		Offset(offset).Limit(perPage).
+		Find(&movements).Error; err != nil {
 		return nil, err
 	}
-
-	return products, nil
+	return movements, nil
 }
 
-func (r *ProductRepository) GetProductCountByCategoryName(categoryName string) (int64, error) {
+func (r *ProductRepository) CountInventoryMovements(productID uint64) (int64, error) {
 	var count int64
-	if err := r.db.Model(&entities.ProductModels{}).
-		Joins("JOIN product_categories ON product_categories.product_models_id = products.id").
-		Joins("JOIN category ON category.id = product_categories.category_models_id").
-		Where("category.name = ?", categoryName).
-		Where("products.deleted_at IS NULL").
-		Count(&count).Error; err != nil {
+	if err := r.db.Model(&entities.InventoryMovementModels{}).Where("product_id = ?", productID).Count(&count).Error; err != nil {
 		return 0, err
 	}
-
 	return count, nil
 }
 
-func (r *ProductRepository) GetProductBySearchAndFilter(page, perPage int, sortBy, search string) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	var totalItems int64
-
-	query := r.db.Model(&entities.ProductModels{})
+func (r *ProductRepository) GetTotalProductSold() (uint64, error) {
+	var totalSold uint64
 
-	query = query.Preload("Categories").Preload("ProductPhotos").Preload("ProductReview").Where("deleted_at IS NULL")
+	query := `
+        SELECT 
+            COALESCE(SUM(quantity), 0) as total_sold 
+        FROM 
+            order_details
+    `
 
-	if search != "" {
-		query = query.Where("name LIKE ?", "%"+search+"%")
+	if err := r.db.Raw(query).Row().Scan(&totalSold); err != nil {
+		return 0, err
 	}
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, 0, err
-	}
+	return totalSold, nil
+}
 
-	switch sortBy {
-	case "termurah":
-		query = query.Order("price asc")
-	case "termahal":
-		query = query.Order("price desc")
-	case "abjad":
-		query = query.Order("name asc")
-	case "promo":
-		query = query.Where("discount > ?", 0)
-	case "terbaru":
-		query = query.Order("created_at desc")
-	}
+func (r *ProductRepository) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+	var products []*entities.ProductModels
 
-	if err := query.Offset((page - 1) * perPage).Limit(perPage).Find(&products).Error; err != nil {
-		return nil, 0, err
+	if err := r.db.Order("rating desc").Where("deleted_at IS NULL").Limit(5).Find(&products).Error; err != nil {
+		return nil, err
 	}
 
-	return products, totalItems, nil
+	return products, nil
 }
 
-func (r *ProductRepository) GetProductByFilter(page, perPage int, sortBy string) ([]*entities.ProductModels, int64, error) {
+// FindProducts lists the products matching query in its sort order. With a
+// cursor the list starts right after the cursor's product and offset is
+// usually zero.
+func (r *ProductRepository) FindProducts(query *product.ProductQuery, cursor *product.Cursor, offset, limit int) ([]*entities.ProductModels, error) {
 	var products []*entities.ProductModels
-	var totalItems int64
 
-	query := r.db.Model(&entities.ProductModels{})
+	order, ok := product.GetSortOrder(query.Sort)
+	if !ok {
+		return nil, errors.New("urutan produk tidak valid")
+	}
+	column := "products." + order.Column
+	direction, comparison := "ASC", ">"
+	if order.Desc {
+		direction, comparison = "DESC", "<"
+	}
 
-	query = query.Preload("Categories").Preload("ProductPhotos").Preload("ProductReview").Where("deleted_at IS NULL")
+	db := r.filterProducts(query).
+		Preload("Categories").
+		Preload("ProductPhotos").
+		Order(column + " " + direction).
+		Order("products.id " + direction)
 
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, 0, err
+	if cursor != nil {
+		db = db.Where("("+column+" "+comparison+" ? OR ("+column+" = ? AND products.id "+comparison+" ?))",
+			cursor.Value(), cursor.Value(), cursor.ID)
 	}
 
-	switch sortBy {
-	case "termurah":
-		query = query.Order("price asc")
-	case "termahal":
-		query = query.Order("price desc")
-	case "abjad":
-		query = query.Order("name asc")
-	case "promo":
-		query = query.Where("discount > ?", 0)
-	case "terbaru":
-		query = query.Order("created_at desc")
+	if err := db.Offset(offset).Limit(limit).Find(&products).Error; err != nil {
+		return nil, err
 	}
+	return products, nil
+}
 
-	if err := query.Offset((page - 1) * perPage).Limit(perPage).Find(&products).Error; err != nil {
-		return nil, 0, err
+func (r *ProductRepository) CountProducts(query *product.ProductQuery) (int64, error) {
+	var count int64
+	if err := r.filterProducts(query).Count(&count).Error; err != nil {
+		return 0, err
 	}
-
-	return products, totalItems, nil
+	return count, nil
 }
 
-func (r *ProductRepository) GetRatedProductsInRange(page, perPage int, lowerBound, upperBound float64) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
-
-	db := r.db.Model(&entities.ProductModels{}).Where("deleted_at IS NULL")
+func (r *ProductRepository) filterProducts(query *product.ProductQuery) *gorm.DB {
+	db := r.db.Model(&entities.ProductModels{}).Where("products.deleted_at IS NULL")
 
-	err := db.Where("rating BETWEEN ? AND ?", lowerBound, upperBound).
-		Order("rating").Offset(offset).Limit(perPage).Find(&products).Error
-	if err != nil {
-		return nil, 0, err
+	if query.Search != "" {
+		db = db.Where("products.name LIKE ?", "%"+query.Search+"%")
 	}
-
-	var totalItems int64
-	if err := db.Count(&totalItems).Error; err != nil {
-		return nil, 0, err
+	if query.Category != "" {
+		db = db.Where("products.id IN (?)", r.db.Table("product_categories").
+			Select("product_categories.product_models_id").
+			Joins("JOIN category ON category.id = product_categories.category_models_id").
+			Where("category.name = ?", query.Category))
 	}
-
-	return products, totalItems, nil
-}
-
-func (r *ProductRepository) SearchByNameAndFilterByRating(page, perPage int, name, ratingParam string, lowerBound, upperBound float64) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	offset := (page - 1) * perPage
-	query := r.db.Model(&entities.ProductModels{}).
-		Offset(offset).
-		Limit(perPage).
-		Preload("Categories").
-		Preload("ProductPhotos").
-		Where("deleted_at IS NULL")
-
-	if name != "" {
-		query = query.Where("name LIKE ?", "%"+name+"%")
+	if query.MinPrice != nil {
+		db = db.Where("products.price >= ?", *query.MinPrice)
 	}
-
-	if ratingParam != "" {
-		query = query.Where("rating BETWEEN ? AND ?", lowerBound, upperBound)
+	if query.MaxPrice != nil {
+		db = db.Where("products.price <= ?", *query.MaxPrice)
 	}
-
-	err := query.Find(&products).Error
-	if err != nil {
-		return nil, 0, err
+	if query.MinRating != nil {
+		db = db.Where("products.rating >= ?", *query.MinRating)
 	}
-
-	var totalItems int64
-	if err := query.Count(&totalItems).Error; err != nil {
-		return nil, 0, err
+	if query.MaxRating != nil {
+		db = db.Where("products.rating <= ?", *query.MaxRating)
+	}
+	if query.DiscountOnly {
+		db = db.Where("products.discount > ?", 0)
+	}
+	if query.InStock {
+		db = db.Where("products.stock > ?", 0)
 	}
 
-	return products, totalItems, nil
+	return db
 }
 
 func (r *ProductRepository) FindAllProductByUserPreference(page, perPage int, productsFromAI []string) ([]*entities.ProductModels, error) {
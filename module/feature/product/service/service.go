@@ -2,40 +2,78 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant"
 	"math"
 	"strings"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
+	notificationDto "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/database"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type ProductService struct {
-	repo       product.RepositoryProductInterface
-	botService assistant.ServiceAssistantInterface
+	repo                product.RepositoryProductInterface
+	botService          assistant.ServiceAssistantInterface
+	notificationService notification.ServiceNotificationInterface
+	roleService         role.ServiceRoleInterface
+	searchService       search.ServiceSearchInterface
+	txManager           database.TransactionManagerInterface
+	lowStockThreshold   uint64
 }
 
-func NewProductService(repo product.RepositoryProductInterface, botService assistant.ServiceAssistantInterface) product.ServiceProductInterface {
+// reindexBatchSize is how many products ReindexSearch loads per query.
+const reindexBatchSize = 100
+
+// NewProductService builds the product service. Low-stock alerts are off when
+// notificationService or roleService is nil or lowStockThreshold is zero, and
+// products are not indexed for search when searchService is nil.
+func NewProductService(
+	repo product.RepositoryProductInterface,
+	botService assistant.ServiceAssistantInterface,
+	notificationService notification.ServiceNotificationInterface,
+	roleService role.ServiceRoleInterface,
+	searchService search.ServiceSearchInterface,
+	txManager database.TransactionManagerInterface,
+	lowStockThreshold uint64,
+) product.ServiceProductInterface {
 	return &ProductService{
-		repo:       repo,
-		botService: botService,
+		repo:                repo,
+		botService:          botService,
+		notificationService: notificationService,
+		roleService:         roleService,
+		searchService:       searchService,
+		txManager:           txManager,
+		lowStockThreshold:   lowStockThreshold,
 	}
 }
 
-func (s *ProductService) GetAll(page, perPage int) ([]*entities.ProductModels, int64, error) {
-	products, err := s.repo.FindAll(page, perPage)
-	if err != nil {
-		return products, 0, err
-	}
+func (s *ProductService) WithTx(tx *gorm.DB) product.ServiceProductInterface {
+	return s.withTx(tx)
+}
 
-	totalItems, err := s.repo.GetTotalProductCount()
-	if err != nil {
-		return products, 0, err
+func (s *ProductService) withTx(tx *gorm.DB) *ProductService {
+	notificationService := s.notificationService
+	if notificationService != nil {
+		notificationService = notificationService.WithTx(tx)
+	}
+	return &ProductService{
+		repo:                s.repo.WithTx(tx),
+		botService:          s.botService,
+		notificationService: notificationService,
+		roleService:         s.roleService,
+		searchService:       s.searchService,
+		txManager:           s.txManager,
+		lowStockThreshold:   s.lowStockThreshold,
 	}
-
-	return products, totalItems, nil
 }
 
 func (s *ProductService) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
@@ -67,20 +105,6 @@ func (s *ProductService) GetPrevPage(currentPage int) int {
 	return 1
 }
 
-func (s *ProductService) GetProductsByName(page, perPage int, name string) ([]*entities.ProductModels, int64, error) {
-	products, err := s.repo.FindByName(page, perPage, name)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	totalItems, err := s.repo.GetTotalProductCountByName(name)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return products, totalItems, nil
-}
-
 func (s *ProductService) CreateProduct(request *dto.CreateProductRequest) (*entities.ProductModels, error) {
 	productData := &entities.ProductModels{
 		Name:        request.Name,
@@ -88,6 +112,7 @@ func (s *ProductService) CreateProduct(request *dto.CreateProductRequest) (*enti
 		GramPlastic: request.GramPlastic,
 		Price:       request.Price,
 		Stock:       request.Stock,
+		Weight:      request.Weight,
 		Discount:    request.Discount,
 		Exp:         request.Exp,
 		Rating:      0.0,
@@ -106,6 +131,18 @@ func (s *ProductService) CreateProduct(request *dto.CreateProductRequest) (*enti
 	if err != nil {
 		return nil, err
 	}
+	if createdProduct.Stock > 0 {
+		_, err = s.recordMovement(createdProduct, &entities.InventoryMovementModels{
+			ProductID: createdProduct.ID,
+			Type:      product.MovementInitial,
+			Quantity:  int64(createdProduct.Stock),
+			Reason:    "stok awal",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.indexProduct(createdProduct.ID)
 
 	return createdProduct, nil
 }
@@ -158,34 +195,25 @@ func (s *ProductService) UpdateProductRating(productID uint64, newRating float64
 	return nil
 }
 
-func (s *ProductService) GetProductReviews(page, perPage int) ([]*entities.ProductModels, int64, error) {
-	products, err := s.repo.GetProductReviews(page, perPage)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	totalItems, err := s.repo.GetTotalProductCount()
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return products, totalItems, nil
-}
-
 func (s *ProductService) UpdateProduct(productID uint64, request *dto.UpdateProduct) (*entities.ProductModels, error) {
 	productData, err := s.repo.GetProductByID(productID)
 	if err != nil {
 		return nil, errors.New("produk tidak ditemukan")
 	}
+	if request.Stock != nil && *request.Stock != productData.Stock {
+		return nil, errors.New("stok diubah melalui penyesuaian stok")
+	}
 
 	productData.Name = request.Name
 	productData.Description = request.Description
 	productData.GramPlastic = request.GramPlastic
 	productData.Price = request.Price
-	productData.Stock = request.Stock
 	productData.Discount = request.Discount
 	productData.Exp = request.Exp
 	productData.UpdatedAt = time.Now()
+	if request.Weight != 0 {
+		productData.Weight = request.Weight
+	}
 
 	if request.ImageURL != "" {
 		productData.ProductPhotos = []entities.ProductPhotosModels{
@@ -205,6 +233,7 @@ func (s *ProductService) UpdateProduct(productID uint64, request *dto.UpdateProd
 	if err != nil {
 		return nil, err
 	}
+	s.indexProduct(productData.ID)
 
 	return productData, nil
 }
@@ -217,6 +246,11 @@ func (s *ProductService) DeleteProduct(id uint64) error {
 	if err := s.repo.DeleteProduct(productId.ID); err != nil {
 		return errors.New("gagal menghapus product")
 	}
+	if s.searchService != nil {
+		if err := s.searchService.Remove(search.DocumentProduct, productId.ID); err != nil {
+			logrus.Error("Failed to remove product from search index:", err)
+		}
+	}
 	return nil
 }
 
@@ -242,124 +276,523 @@ func (s *ProductService) DeleteImageProduct(productId, imageId uint64) error {
 	return nil
 }
 
-func (s *ProductService) ReduceStockWhenPurchasing(productID, quantity uint64) error {
-	products, err := s.repo.GetProductByID(productID)
+// ReduceStockWhenPurchasing takes stock from the variant when one is given and
+// from the product otherwise, and records the sale against the order.
+func (s *ProductService) ReduceStockWhenPurchasing(productID, variantID, quantity uint64, reference string) error {
+	products, err := s.GetProductForPurchase(productID, variantID)
 	if err != nil {
-		return errors.New("produk tidak ditemukan")
+		return err
 	}
 
 	if products.Stock < quantity {
 		return errors.New("stok tidak mencukupi untuk pesanan ini")
 	}
 
-	if err := s.repo.ReduceStockWhenPurchasing(products.ID, quantity); err != nil {
+	if err := s.reduceStock(products.ID, variantID, quantity); err != nil {
 		return err
 	}
-	return nil
+	_, err = s.recordMovement(products, &entities.InventoryMovementModels{
+		ProductID: products.ID,
+		VariantID: variantRef(variantID),
+		Type:      product.MovementSale,
+		Quantity:  -int64(quantity),
+		Reference: reference,
+	})
+	return err
 }
 
-func (s *ProductService) IncreaseStock(productID, quantity uint64) error {
+// IncreaseStock puts stock back on the variant or product and records it as
+// movementType, a cancellation or a return.
+func (s *ProductService) IncreaseStock(productID, variantID, quantity uint64, movementType, reference string) error {
 	products, err := s.repo.GetProductByID(productID)
 	if err != nil {
 		return errors.New("produk tidak ditemukan")
 	}
 
-	err = s.repo.IncreaseStock(products.ID, quantity)
-	if err != nil {
+	if err := s.increaseStock(products.ID, variantID, quantity); err != nil {
 		return err
 	}
-	return nil
+	_, err = s.recordMovement(products, &entities.InventoryMovementModels{
+		ProductID: products.ID,
+		VariantID: variantRef(variantID),
+		Type:      movementType,
+		Quantity:  int64(quantity),
+		Reference: reference,
+	})
+	return err
 }
 
-func (s *ProductService) GetTotalProductSold() (uint64, error) {
-	totalSold, err := s.repo.GetTotalProductSold()
-	if err != nil {
-		return 0, err
-	}
-	return totalSold, nil
+// UpdateProductStock sets the stock of a product without variants to an exact
+// count. The product row is locked while the difference is written to the
+// ledger, so a sale in between cannot make the recorded change wrong.
+func (s *ProductService) UpdateProductStock(productID, stock, actorID uint64) error {
+	return s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		products, err := txService.repo.LockProduct(productID)
+		if err != nil {
+			return errors.New("produk tidak ditemukan")
+		}
+		if len(products.Variants) > 0 {
+			return errors.New("stok produk ini diatur per varian")
+		}
+		if stock == products.Stock {
+			return nil
+		}
+
+		if err := txService.repo.UpdateProductStock(products.ID, stock); err != nil {
+			return errors.New("gagal memperbarui stok produk")
+		}
+		_, err = txService.recordMovement(products, &entities.InventoryMovementModels{
+			ProductID: products.ID,
+			Type:      product.MovementAdjustment,
+			Quantity:  int64(stock) - int64(products.Stock),
+			Reason:    "stok diperbarui",
+			ActorID:   actorID,
+		})
+		return err
+	})
 }
 
-func (s *ProductService) GetTopRatedProducts() ([]*entities.ProductModels, error) {
-	result, err := s.repo.GetTopRatedProducts()
+// AdjustStock adds or, with a negative quantity, removes stock by hand. The
+// change and its ledger line are written in one transaction, and stock is
+// never taken below zero.
+func (s *ProductService) AdjustStock(productID uint64, request *dto.AdjustStockRequest, actorID uint64) (*entities.InventoryMovementModels, error) {
+	if request.Quantity == 0 {
+		return nil, errors.New("jumlah penyesuaian stok tidak boleh nol")
+	}
+
+	var movement *entities.InventoryMovementModels
+	err := s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		txService := s.withTx(tx)
+		products, err := txService.GetProductForPurchase(productID, request.VariantID)
+		if err != nil {
+			return err
+		}
+
+		if request.Quantity < 0 {
+			quantity := uint64(-request.Quantity)
+			if products.Stock < quantity {
+				return errors.New("stok tidak boleh kurang dari nol")
+			}
+			if err := txService.reduceStock(products.ID, request.VariantID, quantity); err != nil {
+				return errors.New("stok tidak boleh kurang dari nol")
+			}
+		} else if err := txService.increaseStock(products.ID, request.VariantID, uint64(request.Quantity)); err != nil {
+			return errors.New("gagal memperbarui stok produk")
+		}
+
+		movement, err = txService.recordMovement(products, &entities.InventoryMovementModels{
+			ProductID: products.ID,
+			VariantID: variantRef(request.VariantID),
+			Type:      product.MovementAdjustment,
+			Quantity:  request.Quantity,
+			Reason:    request.Reason,
+			ActorID:   actorID,
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	return movement, nil
 }
 
-func (s *ProductService) GetProductsByCategoryAndName(categoryName, name string, page, perPage int) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	var err error
+func (s *ProductService) GetInventoryMovements(productID uint64, page, perPage int) ([]*entities.InventoryMovementModels, int64, error) {
+	products, err := s.repo.GetProductByID(productID)
+	if err != nil {
+		return nil, 0, errors.New("produk tidak ditemukan")
+	}
 
-	products, err = s.repo.GetProductsByCategoryAndName(page, perPage, categoryName, name)
+	movements, err := s.repo.FindInventoryMovements(products.ID, page, perPage)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	totalItems, err := s.repo.GetProductsCountByCategoryAndName(categoryName, name)
+	totalItems, err := s.repo.CountInventoryMovements(products.ID)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return products, totalItems, nil
+	return movements, totalItems, nil
 }
 
-func (s *ProductService) GetProductsByCategoryName(categoryName string, page, perPage int) ([]*entities.ProductModels, int64, error) {
-	products, err := s.repo.GetProductsByCategoryName(categoryName, page, perPage)
+func (s *ProductService) reduceStock(productID, variantID, quantity uint64) error {
+	if variantID == 0 {
+		return s.repo.ReduceStockWhenPurchasing(productID, quantity)
+	}
+	if err := s.repo.ReduceVariantStock(variantID, quantity); err != nil {
+		return err
+	}
+	return s.repo.SyncVariantStock(productID)
+}
+
+func (s *ProductService) increaseStock(productID, variantID, quantity uint64) error {
+	if variantID == 0 {
+		return s.repo.IncreaseStock(productID, quantity)
+	}
+	if err := s.repo.IncreaseVariantStock(variantID, quantity); err != nil {
+		return err
+	}
+	return s.repo.SyncVariantStock(productID)
+}
+
+// recordMovement writes the ledger line for a stock change that has already
+// been applied, stamping it with the stock left afterwards, and alerts staff
+// when the change takes stock down to the low-stock threshold.
+func (s *ProductService) recordMovement(products *entities.ProductModels, movement *entities.InventoryMovementModels) (*entities.InventoryMovementModels, error) {
+	variantID := variantIDOf(movement.VariantID)
+	stockAfter, err := s.repo.GetStockLevel(movement.ProductID, variantID)
 	if err != nil {
-		return nil, 0, err
+		return nil, errors.New("gagal mencatat pergerakan stok")
+	}
+	movement.StockAfter = stockAfter
+	movement.CreatedAt = time.Now()
+	if err := s.repo.CreateInventoryMovement(movement); err != nil {
+		return nil, errors.New("gagal mencatat pergerakan stok")
+	}
+
+	stockBefore := uint64(int64(stockAfter) - movement.Quantity)
+	if s.notificationService != nil && s.roleService != nil && product.IsLowStockCrossing(stockBefore, stockAfter, s.lowStockThreshold) {
+		s.alertLowStock(stockLabel(products, variantID), stockAfter)
+	}
+	return movement, nil
+}
+
+// alertLowStock notifies everyone who manages stock. A failed alert is logged
+// rather than failing the stock change that triggered it.
+func (s *ProductService) alertLowStock(label string, stock uint64) {
+	userIDs, err := s.roleService.GetUserIDsWithPermission(role.PermissionManageStock)
+	if err != nil {
+		logrus.Error("Failed to find low stock alert recipients:", err)
+		return
+	}
+	for _, userID := range userIDs {
+		_, err := s.notificationService.Notify(&notificationDto.NotifyRequest{
+			UserID: userID,
+			Type:   notification.EventStockAlert,
+			Title:  "Stok Menipis",
+			Body:   fmt.Sprintf("Stok %s tinggal %d. Segera lakukan restock, yaa!", label, stock),
+		})
+		if err != nil {
+			logrus.Error("Failed to send low stock alert:", err)
+		}
+	}
+}
+
+func stockLabel(products *entities.ProductModels, variantID uint64) string {
+	for _, variant := range products.Variants {
+		if variant.ID == variantID {
+			return fmt.Sprintf("%s (%s)", products.Name, variant.SKU)
+		}
 	}
+	return products.Name
+}
 
-	count, err := s.repo.GetProductCountByCategoryName(categoryName)
+func variantRef(variantID uint64) *uint64 {
+	if variantID == 0 {
+		return nil
+	}
+	return &variantID
+}
+
+func variantIDOf(variantID *uint64) uint64 {
+	if variantID == nil {
+		return 0
+	}
+	return *variantID
+}
+
+// GetProductForPurchase returns the product as it is sold: with a variant, the
+// price, discount, stock and plastic weight are the variant's. A product that
+// has variants cannot be bought without picking one.
+func (s *ProductService) GetProductForPurchase(productID, variantID uint64) (*entities.ProductModels, error) {
+	products, err := s.repo.GetProductByID(productID)
 	if err != nil {
-		return nil, 0, err
+		return nil, errors.New("produk tidak ditemukan")
 	}
 
-	return products, count, nil
+	if variantID == 0 {
+		if len(products.Variants) > 0 {
+			return nil, errors.New("varian produk wajib dipilih")
+		}
+		return products, nil
+	}
+
+	for _, variant := range products.Variants {
+		if variant.ID != variantID {
+			continue
+		}
+		purchase := *products
+		purchase.Price = variant.Price
+		purchase.Discount = variant.Discount
+		purchase.Stock = variant.Stock
+		purchase.GramPlastic = variant.GramPlastic
+		purchase.Variants = []entities.ProductVariantModels{variant}
+		return &purchase, nil
+	}
+	return nil, errors.New("varian produk tidak ditemukan")
 }
 
-func (s *ProductService) GetProductsBySearchAndFilter(page, perPage int, filter, search string) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	var totalItems int64
-	var err error
+// CreateVariant adds a variant to the product. Once a product has variants its
+// stock is the sum of theirs, so the first variant is refused while the
+// product still holds stock of its own; that stock has to be adjusted to zero
+// first rather than silently disappearing.
+func (s *ProductService) CreateVariant(productID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error) {
+	products, err := s.repo.GetProductByID(productID)
+	if err != nil {
+		return nil, errors.New("produk tidak ditemukan")
+	}
+	if len(products.Variants) == 0 && products.Stock > 0 {
+		return nil, errors.New("stok produk harus dikosongkan sebelum menambahkan varian")
+	}
+	if request.Discount > request.Price {
+		return nil, errors.New("diskon varian melebihi harga")
+	}
+	if existing, _ := s.repo.GetVariantBySKU(request.SKU); existing != nil {
+		return nil, errors.New("sku varian sudah digunakan")
+	}
 
-	if !isValidFilter(filter) {
-		products, totalItems, err = s.repo.GetProductBySearchAndFilter(page, perPage, filter, search)
-	} else {
-		products, totalItems, err = s.repo.GetProductBySearchAndFilter(page, perPage, "", search)
+	variant := &entities.ProductVariantModels{
+		ProductID:   products.ID,
+		SKU:         request.SKU,
+		Size:        request.Size,
+		Color:       request.Color,
+		Price:       request.Price,
+		Discount:    request.Discount,
+		GramPlastic: request.GramPlastic,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}
+	if request.Stock != nil {
+		variant.Stock = *request.Stock
+	}
+	created, err := s.repo.CreateVariant(variant)
+	if err != nil {
+		return nil, errors.New("gagal menambahkan varian produk")
+	}
+	if err := s.repo.SyncVariantStock(products.ID); err != nil {
+		return nil, errors.New("gagal memperbarui stok produk")
+	}
+	if created.Stock > 0 {
+		_, err = s.recordMovement(products, &entities.InventoryMovementModels{
+			ProductID: products.ID,
+			VariantID: variantRef(created.ID),
+			Type:      product.MovementInitial,
+			Quantity:  int64(created.Stock),
+			Reason:    "stok awal",
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
 
+func (s *ProductService) UpdateVariant(productID, variantID uint64, request *dto.VariantRequest) (*entities.ProductVariantModels, error) {
+	variant, err := s.repo.GetVariantByID(variantID)
+	if err != nil || variant.ProductID != productID {
+		return nil, errors.New("varian produk tidak ditemukan")
+	}
+	if request.Discount > request.Price {
+		return nil, errors.New("diskon varian melebihi harga")
+	}
+	if existing, _ := s.repo.GetVariantBySKU(request.SKU); existing != nil && existing.ID != variant.ID {
+		return nil, errors.New("sku varian sudah digunakan")
+	}
+	if request.Stock != nil && *request.Stock != variant.Stock {
+		return nil, errors.New("stok diubah melalui penyesuaian stok")
+	}
+
+	variant.SKU = request.SKU
+	variant.Size = request.Size
+	variant.Color = request.Color
+	variant.Price = request.Price
+	variant.Discount = request.Discount
+	variant.GramPlastic = request.GramPlastic
+	variant.UpdatedAt = time.Now()
+
+	updated, err := s.repo.UpdateVariant(variant)
 	if err != nil {
-		return nil, 0, err
+		return nil, errors.New("gagal memperbarui varian produk")
+	}
+	if err := s.repo.SyncVariantStock(productID); err != nil {
+		return nil, errors.New("gagal memperbarui stok produk")
+	}
+	return updated, nil
+}
+
+func (s *ProductService) DeleteVariant(productID, variantID uint64) error {
+	variant, err := s.repo.GetVariantByID(variantID)
+	if err != nil || variant.ProductID != productID {
+		return errors.New("varian produk tidak ditemukan")
+	}
+	if variant.Stock > 0 {
+		return errors.New("stok varian harus dikosongkan sebelum dihapus")
+	}
+	if err := s.repo.DeleteVariant(variant.ID); err != nil {
+		return errors.New("gagal menghapus varian produk")
 	}
+	if err := s.repo.SyncVariantStock(productID); err != nil {
+		return errors.New("gagal memperbarui stok produk")
+	}
+	return nil
+}
 
-	return products, totalItems, nil
+// indexProduct refreshes the product in the search index. The index only
+// serves search, so a failure is logged rather than undoing the change.
+func (s *ProductService) indexProduct(productID uint64) {
+	if s.searchService == nil {
+		return
+	}
+	products, err := s.repo.GetProductByID(productID)
+	if err != nil {
+		logrus.Error("Failed to load product for search index:", err)
+		return
+	}
+	if err := s.searchService.Index(productDocument(products)); err != nil {
+		logrus.Error("Failed to index product for search:", err)
+	}
 }
 
-func (s *ProductService) GetProductsByFilter(page, perPage int, filter string) ([]*entities.ProductModels, int64, error) {
-	var products []*entities.ProductModels
-	var totalItems int64
-	var err error
+// ReindexSearch rebuilds the product part of the search index from the
+// products table and returns how many products were indexed.
+func (s *ProductService) ReindexSearch() (int, error) {
+	if s.searchService == nil {
+		return 0, errors.New("pencarian tidak tersedia")
+	}
+	if err := s.searchService.Clear(search.DocumentProduct); err != nil {
+		return 0, err
+	}
 
-	if !isValidFilter(filter) {
-		products, totalItems, err = s.repo.GetProductByFilter(page, perPage, filter)
+	indexed := 0
+	for page := 1; ; page++ {
+		products, err := s.repo.FindAll(page, reindexBatchSize)
+		if err != nil {
+			return indexed, errors.New("gagal mendapatkan daftar produk")
+		}
+		for _, productData := range products {
+			if err := s.searchService.Index(productDocument(productData)); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+		if len(products) < reindexBatchSize {
+			return indexed, nil
+		}
 	}
+}
+
+// ReindexCategoryProducts refreshes the products of a category in the search
+// index after the category was renamed or deleted, since category names are
+// indexed as product keywords.
+func (s *ProductService) ReindexCategoryProducts(categoryID uint64) error {
+	if s.searchService == nil {
+		return nil
+	}
+	productIDs, err := s.repo.FindProductIDsByCategory(categoryID)
 	if err != nil {
-		return nil, 0, err
+		return errors.New("gagal mendapatkan produk kategori")
 	}
+	for _, productID := range productIDs {
+		s.indexProduct(productID)
+	}
+	return nil
+}
 
-	return products, totalItems, nil
+func productDocument(products *entities.ProductModels) *search.Document {
+	keywords := make([]string, 0, len(products.Categories))
+	for _, category := range products.Categories {
+		if category.DeletedAt != nil {
+			continue
+		}
+		keywords = append(keywords, category.Name)
+	}
+	return &search.Document{
+		Type:     search.DocumentProduct,
+		ID:       products.ID,
+		Title:    products.Name,
+		Body:     products.Description,
+		Keywords: keywords,
+	}
 }
 
-func isValidFilter(filter string) bool {
-	validFilters := map[string]bool{
-		"abjad":    true,
-		"termurah": true,
-		"termahal": true,
-		"terbaru":  true,
-		"promo":    true,
+func (s *ProductService) GetTotalProductSold() (uint64, error) {
+	totalSold, err := s.repo.GetTotalProductSold()
+	if err != nil {
+		return 0, err
+	}
+	return totalSold, nil
+}
+
+func (s *ProductService) GetTopRatedProducts() ([]*entities.ProductModels, error) {
+	result, err := s.repo.GetTopRatedProducts()
+	if err != nil {
+		return nil, err
 	}
-	return !validFilters[filter]
+	return result, nil
+}
+
+// ListProducts returns one page of products matching query together with the
+// total number of matches and a cursor for the next page, which is empty on the
+// last page. A cursor takes precedence over the page number.
+func (s *ProductService) ListProducts(query *product.ProductQuery) ([]*entities.ProductModels, int64, string, error) {
+	spec := *query
+	if spec.Sort == "" {
+		spec.Sort = product.DefaultSort
+	}
+	if _, ok := product.GetSortOrder(spec.Sort); !ok {
+		return nil, 0, "", errors.New("urutan produk tidak valid")
+	}
+	if spec.PerPage <= 0 {
+		spec.PerPage = product.DefaultPerPage
+	}
+	if spec.PerPage > product.MaxPerPage {
+		spec.PerPage = product.MaxPerPage
+	}
+
+	if spec.Rating != "" && spec.MinRating == nil && spec.MaxRating == nil {
+		lowerBound, upperBound, err := getRatingBounds(spec.Rating)
+		if err != nil {
+			return nil, 0, "", err
+		}
+		spec.MinRating, spec.MaxRating = &lowerBound, &upperBound
+	}
+	if spec.MinPrice != nil && spec.MaxPrice != nil && *spec.MinPrice > *spec.MaxPrice {
+		return nil, 0, "", errors.New("rentang harga tidak valid")
+	}
+	if spec.MinRating != nil && spec.MaxRating != nil && *spec.MinRating > *spec.MaxRating {
+		return nil, 0, "", errors.New("rentang rating tidak valid")
+	}
+
+	var cursor *product.Cursor
+	offset := 0
+	if spec.Cursor != "" {
+		var err error
+		cursor, err = product.DecodeCursor(spec.Cursor, spec.Sort)
+		if err != nil {
+			return nil, 0, "", err
+		}
+	} else if spec.Page > 1 {
+		offset = (spec.Page - 1) * spec.PerPage
+	}
+
+	products, err := s.repo.FindProducts(&spec, cursor, offset, spec.PerPage+1)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	totalItems, err := s.repo.CountProducts(&spec)
+	if err != nil {
+		return nil, 0, "", err
+	}
+
+	nextCursor := ""
+	if len(products) > spec.PerPage {
+		products = products[:spec.PerPage]
+		nextCursor = product.NewCursor(spec.Sort, products[len(products)-1]).Encode()
+	}
+
+	return products, totalItems, nextCursor, nil
 }
 
 func getRatingBounds(ratingParam string) (float64, float64, error) {
@@ -389,34 +822,6 @@ func getRatingBounds(ratingParam string) (float64, float64, error) {
 	return lowerBound, upperBound, nil
 }
 
-func (s *ProductService) GetRatedProductsInRange(page, perPage int, ratingParam string) ([]*entities.ProductModels, int64, error) {
-	lowerBound, upperBound, err := getRatingBounds(ratingParam)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	products, totalItems, err := s.repo.GetRatedProductsInRange(page, perPage, lowerBound, upperBound)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return products, totalItems, nil
-}
-
-func (s *ProductService) SearchByNameAndFilterByRating(page, perPage int, name, ratingParam string) ([]*entities.ProductModels, int64, error) {
-	lowerBound, upperBound, err := getRatingBounds(ratingParam)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	products, totalItems, err := s.repo.SearchByNameAndFilterByRating(page, perPage, name, ratingParam, lowerBound, upperBound)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return products, totalItems, nil
-}
-
 func (s *ProductService) GetProductRecommendation(userID uint64, page, perPage int) ([]*entities.ProductModels, int64, error) {
 	recommendations, err := s.botService.GenerateRecommendationProduct(userID)
 	if err != nil {
@@ -6,11 +6,19 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	repoAi "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/mocks"
 	serviceAi "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/service"
+	notificationDto "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/dto"
+	notificationMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/notification/mocks"
+	productFeature "github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
+	roleMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/role/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	searchMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/search/mocks"
+	databaseMocks "github.com/capstone-kelompok-7/backend-disappear/utils/database/mocks"
 	"github.com/sashabaranov/go-openai"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 	"testing"
 	"time"
 )
@@ -89,160 +97,13 @@ func TestProductService_GetPrevPage(t *testing.T) {
 	})
 }
 
-func TestProductService_GetAll(t *testing.T) {
-	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatBot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatBot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	products := []*entities.ProductModels{
-		{
-			ID:          1,
-			Name:        "Product test 1",
-			Description: "Description 1",
-			GramPlastic: 1,
-			Price:       100,
-			Stock:       10,
-			Discount:    2,
-			Exp:         3,
-			Rating:      4,
-			TotalReview: 1,
-		},
-		{
-			ID:          2,
-			Name:        "Product test 2",
-			Description: "Description 2",
-			GramPlastic: 1,
-			Price:       100,
-			Stock:       10,
-			Discount:    2,
-			Exp:         3,
-			Rating:      4,
-			TotalReview: 1,
-		},
-	}
-
-	t.Run("Succes case - Product Found", func(t *testing.T) {
-		expectedTotalItems := int64(8)
-		repo.On("FindAll", 1, 8).Return(products, nil).Once()
-		repo.On("GetTotalProductCount").Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := service.GetAll(1, 8)
-		assert.NoError(t, err)
-		assert.Equal(t, len(products), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed case - GetTotalProductCount Error", func(t *testing.T) {
-		expectedErr := errors.New("GetTotalProductCount Error")
-		repo.On("FindAll", 1, 8).Return(nil, nil).Once()
-		repo.On("GetTotalProductCount").Return(int64(0), expectedErr).Once()
-
-		product, totalItems, err := service.GetAll(1, 8)
-
-		assert.Nil(t, product)
-		assert.Error(t, err)
-		assert.Equal(t, int64(0), totalItems)
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - GetAll Error", func(t *testing.T) {
-		expectedErr := errors.New("FindAll Error")
-		repo.On("FindAll", 1, 8).Return(nil, expectedErr).Once()
-
-		product, totalItems, err := service.GetAll(1, 8)
-		assert.Error(t, err)
-		assert.Nil(t, product)
-		assert.Equal(t, int64(0), totalItems)
-		repo.AssertExpectations(t)
-
-	})
-}
-
-func TestProductService_GetProductsByName(t *testing.T) {
-	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatBot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatBot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	products := []*entities.ProductModels{
-		{
-			ID:          1,
-			Name:        "Product test 1",
-			Description: "Description 1",
-			GramPlastic: 1,
-			Price:       100,
-			Stock:       10,
-			Discount:    2,
-			Exp:         3,
-			Rating:      4,
-			TotalReview: 1,
-		},
-		{
-			ID:          2,
-			Name:        "Product test 2",
-			Description: "Description 2",
-			GramPlastic: 1,
-			Price:       100,
-			Stock:       10,
-			Discount:    2,
-			Exp:         3,
-			Rating:      4,
-			TotalReview: 1,
-		},
-	}
-	name := "Product Test"
-	t.Run("Succes Case - Product Found by Name", func(t *testing.T) {
-		expectedTotalItems := int64(8)
-		repo.On("FindByName", 1, 8, name).Return(products, nil).Once()
-		repo.On("GetTotalProductCountByName", name).Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := service.GetProductsByName(1, 8, name)
-		assert.NoError(t, err)
-		assert.Equal(t, len(products), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Finding Product by Name", func(t *testing.T) {
-		expectedErr := errors.New("failed to find product by name")
-		repo.On("FindByName", 1, 8, name).Return(nil, expectedErr).Once()
-
-		result, totalItems, err := service.GetProductsByName(1, 8, name)
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed Case - Error Getting Total Product Count by Name", func(t *testing.T) {
-		expectedErr := errors.New("failed to get total product count by name")
-		repo.On("FindByName", 1, 8, name).Return(products, nil).Once()
-		repo.On("GetTotalProductCountByName", name).Return(int64(0), expectedErr).Once()
-
-		result, totalItems, err := service.GetProductsByName(1, 8, name)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
-		repo.AssertExpectations(t)
-	})
-}
-
 func TestProductService_CreateProduct(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
 	repoChatBot := repoAi.NewRepositoryAssistantInterface(t)
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatBot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	request := &dto.CreateProductRequest{
 		Name:        "Product Test",
@@ -268,6 +129,10 @@ func TestProductService_CreateProduct(t *testing.T) {
 		}
 
 		repo.On("CreateProduct", mock.Anything, mock.Anything).Return(createdProduct, nil).Once()
+		repo.On("GetStockLevel", uint64(1), uint64(0)).Return(uint64(10), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "initial" && movement.Quantity == 10 && movement.StockAfter == 10
+		})).Return(nil).Once()
 
 		result, err := service.CreateProduct(request)
 
@@ -347,7 +212,7 @@ func TestProductService_GetProductByID(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatBot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	product := &entities.ProductModels{
 		ID:          1,
@@ -416,7 +281,7 @@ func TestProductService_CreateImageProduct(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	request := dto.CreateProductImage{
 		ProductID: 1,
@@ -488,7 +353,7 @@ func TestProductService_UpdateTotalReview(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 
@@ -547,7 +412,7 @@ func TestProductService_UpdateProductRating(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 	newRating := 4.5
@@ -575,81 +440,13 @@ func TestProductService_UpdateProductRating(t *testing.T) {
 	})
 }
 
-func TestProductService_GetProductReviews(t *testing.T) {
-	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	page := 1
-	perPage := 10
-
-	t.Run("Success case - Get Product Reviews", func(t *testing.T) {
-		products := []*entities.ProductModels{
-			{
-				ID:   1,
-				Name: "Product Test 1",
-			},
-			{
-				ID:   2,
-				Name: "Product Test 2",
-			},
-		}
-
-		expectedTotalItems := int64(20)
-
-		repo.On("GetProductReviews", page, perPage).Return(products, nil).Once()
-		repo.On("GetTotalProductCount").Return(expectedTotalItems, nil).Once()
-
-		result, totalItems, err := service.GetProductReviews(page, perPage)
-
-		assert.NoError(t, err)
-		assert.NotNil(t, result)
-		assert.Equal(t, len(products), len(result))
-		assert.Equal(t, expectedTotalItems, totalItems)
-
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed case - GetProductReviews Error", func(t *testing.T) {
-		expectedErr := errors.New("GetProductReviews Error")
-
-		repo.On("GetProductReviews", page, perPage).Return(nil, expectedErr).Once()
-
-		result, _, err := service.GetProductReviews(page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.EqualError(t, err, expectedErr.Error())
-
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Failed case - GetTotalProductCount Error", func(t *testing.T) {
-		expectedErr := errors.New("GetTotalProductCount Error")
-
-		repo.On("GetProductReviews", page, perPage).Return(nil, nil).Once()
-		repo.On("GetTotalProductCount").Return(int64(0), expectedErr).Once()
-
-		result, _, err := service.GetProductReviews(page, perPage)
-
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.EqualError(t, err, expectedErr.Error())
-
-		repo.AssertExpectations(t)
-	})
-}
-
 func TestProductService_UpdateProduct(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
 	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 
@@ -658,7 +455,6 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		Description: "Updated Description",
 		GramPlastic: 2,
 		Price:       200,
-		Stock:       20,
 		Discount:    5,
 		Exp:         6,
 		ImageURL:    "https://example.com/updated_image.jpg",
@@ -691,7 +487,6 @@ func TestProductService_UpdateProduct(t *testing.T) {
 			Description: "Updated Description",
 			GramPlastic: 2,
 			Price:       200,
-			Stock:       20,
 			Discount:    5,
 			Exp:         6,
 			ImageURL:    "https://example.com/updated_image.jpg",
@@ -710,7 +505,6 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		assert.Equal(t, updateRequest.Description, updatedProduct.Description)
 		assert.Equal(t, updateRequest.GramPlastic, updatedProduct.GramPlastic)
 		assert.Equal(t, updateRequest.Price, updatedProduct.Price)
-		assert.Equal(t, updateRequest.Stock, updatedProduct.Stock)
 		assert.Equal(t, updateRequest.Discount, updatedProduct.Discount)
 		assert.Equal(t, updateRequest.Exp, updatedProduct.Exp)
 		assert.Equal(t, updateRequest.ImageURL, updatedProduct.ProductPhotos[0].ImageURL)
@@ -719,6 +513,17 @@ func TestProductService_UpdateProduct(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Failed case - Stock Changed Outside Adjustment", func(t *testing.T) {
+		stock := uint64(20)
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID, Stock: 10}, nil).Once()
+
+		result, err := service.UpdateProduct(productID, &dto.UpdateProduct{Name: "Updated Product", Stock: &stock})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "stok diubah melalui penyesuaian stok")
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("Failed case - GetProductByID Error", func(t *testing.T) {
 		expectedErr := errors.New("GetProductByID Error")
 		repo.On("GetProductByID", productID).Return(nil, expectedErr).Once()
@@ -777,7 +582,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 
@@ -836,7 +641,7 @@ func TestProductService_DeleteImageProduct(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 	imageID := uint64(1)
@@ -933,7 +738,7 @@ func TestProductService_ReduceStockWhenPurchasing(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 	quantity := uint64(5)
@@ -946,8 +751,12 @@ func TestProductService_ReduceStockWhenPurchasing(t *testing.T) {
 
 		repo.On("GetProductByID", productID).Return(product, nil).Once()
 		repo.On("ReduceStockWhenPurchasing", productID, quantity).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(uint64(5), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "sale" && movement.Quantity == -5 && movement.StockAfter == 5 && movement.Reference == "ORDER-1"
+		})).Return(nil).Once()
 
-		err := service.ReduceStockWhenPurchasing(productID, quantity)
+		err := service.ReduceStockWhenPurchasing(productID, 0, quantity, "ORDER-1")
 
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
@@ -957,7 +766,7 @@ func TestProductService_ReduceStockWhenPurchasing(t *testing.T) {
 		expectedErr := errors.New("produk tidak ditemukan")
 		repo.On("GetProductByID", productID).Return(nil, expectedErr).Once()
 
-		err := service.ReduceStockWhenPurchasing(productID, quantity)
+		err := service.ReduceStockWhenPurchasing(productID, 0, quantity, "ORDER-1")
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, expectedErr.Error())
@@ -971,7 +780,7 @@ func TestProductService_ReduceStockWhenPurchasing(t *testing.T) {
 		}
 		repo.On("GetProductByID", productID).Return(product, nil).Once()
 
-		err := service.ReduceStockWhenPurchasing(productID, quantity)
+		err := service.ReduceStockWhenPurchasing(productID, 0, quantity, "ORDER-1")
 		assert.Error(t, err)
 		assert.EqualError(t, err, "stok tidak mencukupi untuk pesanan ini")
 		repo.AssertExpectations(t)
@@ -988,12 +797,46 @@ func TestProductService_ReduceStockWhenPurchasing(t *testing.T) {
 		repo.On("GetProductByID", productID).Return(product, nil).Once()
 		repo.On("ReduceStockWhenPurchasing", productID, quantity).Return(expectedErr).Once()
 
-		err := service.ReduceStockWhenPurchasing(productID, quantity)
+		err := service.ReduceStockWhenPurchasing(productID, 0, quantity, "ORDER-1")
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, expectedErr.Error())
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("Success case - Variant Stock Reduced", func(t *testing.T) {
+		product := &entities.ProductModels{
+			ID:       productID,
+			Stock:    12,
+			Variants: []entities.ProductVariantModels{{ID: 7, ProductID: productID, Stock: 6}},
+		}
+		repo.On("GetProductByID", productID).Return(product, nil).Once()
+		repo.On("ReduceVariantStock", uint64(7), quantity).Return(nil).Once()
+		repo.On("SyncVariantStock", productID).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(7)).Return(uint64(1), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return *movement.VariantID == 7 && movement.Quantity == -5 && movement.StockAfter == 1
+		})).Return(nil).Once()
+
+		err := service.ReduceStockWhenPurchasing(productID, 7, quantity, "ORDER-1")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Insufficient Variant Stock", func(t *testing.T) {
+		product := &entities.ProductModels{
+			ID:       productID,
+			Stock:    12,
+			Variants: []entities.ProductVariantModels{{ID: 7, ProductID: productID, Stock: 2}},
+		}
+		repo.On("GetProductByID", productID).Return(product, nil).Once()
+
+		err := service.ReduceStockWhenPurchasing(productID, 7, quantity, "ORDER-1")
+
+		assert.EqualError(t, err, "stok tidak mencukupi untuk pesanan ini")
+		repo.AssertExpectations(t)
+	})
 }
 
 func TestProductService_IncreaseStock(t *testing.T) {
@@ -1002,7 +845,7 @@ func TestProductService_IncreaseStock(t *testing.T) {
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
 	productID := uint64(1)
 	quantity := uint64(5)
@@ -1015,8 +858,12 @@ func TestProductService_IncreaseStock(t *testing.T) {
 
 		repo.On("GetProductByID", productID).Return(product, nil).Once()
 		repo.On("IncreaseStock", productID, quantity).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(uint64(15), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "cancellation" && movement.Quantity == 5 && movement.StockAfter == 15
+		})).Return(nil).Once()
 
-		err := service.IncreaseStock(productID, quantity)
+		err := service.IncreaseStock(productID, 0, quantity, "cancellation", "ORDER-1")
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
@@ -1025,7 +872,7 @@ func TestProductService_IncreaseStock(t *testing.T) {
 		expectedErr := errors.New("produk tidak ditemukan")
 
 		repo.On("GetProductByID", productID).Return(nil, expectedErr).Once()
-		err := service.IncreaseStock(productID, quantity)
+		err := service.IncreaseStock(productID, 0, quantity, "cancellation", "ORDER-1")
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, expectedErr.Error())
@@ -1042,328 +889,383 @@ func TestProductService_IncreaseStock(t *testing.T) {
 		repo.On("GetProductByID", productID).Return(product, nil).Once()
 		repo.On("IncreaseStock", productID, quantity).Return(expectedErr).Once()
 
-		err := service.IncreaseStock(productID, quantity)
+		err := service.IncreaseStock(productID, 0, quantity, "cancellation", "ORDER-1")
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, expectedErr.Error())
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("Success case - Variant Stock Increased", func(t *testing.T) {
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID}, nil).Once()
+		repo.On("IncreaseVariantStock", uint64(7), quantity).Return(nil).Once()
+		repo.On("SyncVariantStock", productID).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(7)).Return(uint64(5), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "return" && *movement.VariantID == 7
+		})).Return(nil).Once()
+
+		err := service.IncreaseStock(productID, 7, quantity, "return", "ORDER-1")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
 }
 
-func TestProductService_GetTotalProductSold(t *testing.T) {
+func TestProductService_UpdateProductStock(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
 	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	txManager := databaseMocks.NewTransactionManagerInterface(t)
+	txManager.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error {
+		return fn(nil)
+	}).Maybe()
+	repo.On("WithTx", mock.Anything).Return(repo).Maybe()
+	service := NewProductService(repo, serviceAI, nil, nil, nil, txManager, 0)
 
-	t.Run("Success case - Total Product Sold", func(t *testing.T) {
-		repo.On("GetTotalProductSold").Return(uint64(100), nil).Once()
+	productID := uint64(1)
+	stock := uint64(0)
 
-		totalSold, err := service.GetTotalProductSold()
+	t.Run("Success case - Stock Updated", func(t *testing.T) {
+		repo.On("LockProduct", productID).Return(&entities.ProductModels{ID: productID, Stock: 10}, nil).Once()
+		repo.On("UpdateProductStock", productID, stock).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(stock, nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "adjustment" && movement.Quantity == -10 && movement.ActorID == 1
+		})).Return(nil).Once()
 
+		err := service.UpdateProductStock(productID, stock, 1)
 		assert.NoError(t, err)
-		assert.Equal(t, uint64(100), totalSold)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Product Not Found", func(t *testing.T) {
+		repo.On("LockProduct", productID).Return(nil, errors.New("record not found")).Once()
 
+		err := service.UpdateProductStock(productID, stock, 1)
+		assert.EqualError(t, err, "produk tidak ditemukan")
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Failed case - GetTotalProductSold Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan total produk terjual")
+	t.Run("Failed case - UpdateProductStock Error", func(t *testing.T) {
+		repo.On("LockProduct", productID).Return(&entities.ProductModels{ID: productID, Stock: 10}, nil).Once()
+		repo.On("UpdateProductStock", productID, stock).Return(errors.New("database error")).Once()
 
-		repo.On("GetTotalProductSold").Return(uint64(0), expectedErr).Once()
+		err := service.UpdateProductStock(productID, stock, 1)
+		assert.EqualError(t, err, "gagal memperbarui stok produk")
+		repo.AssertExpectations(t)
+	})
 
-		totalSold, err := service.GetTotalProductSold()
+	t.Run("Failed case - Stock Kept Per Variant", func(t *testing.T) {
+		repo.On("LockProduct", productID).Return(&entities.ProductModels{
+			ID:       productID,
+			Variants: []entities.ProductVariantModels{{ID: 7, ProductID: productID}},
+		}, nil).Once()
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
-		assert.Equal(t, uint64(0), totalSold)
+		err := service.UpdateProductStock(productID, stock, 1)
+		assert.EqualError(t, err, "stok produk ini diatur per varian")
 		repo.AssertExpectations(t)
 	})
 }
 
-func TestProductService_GetTopRatedProducts(t *testing.T) {
+func TestProductService_GetProductForPurchase(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
-	repoAi := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoAi, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
 
-	t.Run("Success case - Top Rated Products", func(t *testing.T) {
-		topRatedProduct := &entities.ProductModels{
-			ID:          1,
-			Name:        "Top Rated Product",
-			Description: "Description",
-		}
+	product := &entities.ProductModels{
+		ID:          1,
+		Price:       5000,
+		Discount:    500,
+		Stock:       15,
+		GramPlastic: 10,
+		Exp:         20,
+		Variants: []entities.ProductVariantModels{
+			{ID: 7, ProductID: 1, SKU: "TOTE-L", Price: 8000, Discount: 1000, Stock: 5, GramPlastic: 15},
+		},
+	}
 
-		repo.On("GetTopRatedProducts").Return([]*entities.ProductModels{topRatedProduct}, nil).Once()
+	t.Run("Success case - Variant overrides price and stock", func(t *testing.T) {
+		repo.On("GetProductByID", uint64(1)).Return(product, nil).Once()
 
-		result, err := service.GetTopRatedProducts()
+		result, err := service.GetProductForPurchase(1, 7)
 
 		assert.NoError(t, err)
-		assert.Equal(t, []*entities.ProductModels{topRatedProduct}, result)
+		assert.Equal(t, uint64(8000), result.Price)
+		assert.Equal(t, uint64(1000), result.Discount)
+		assert.Equal(t, uint64(5), result.Stock)
+		assert.Equal(t, uint64(15), result.GramPlastic)
+		assert.Equal(t, uint64(20), result.Exp)
+		assert.Equal(t, uint64(5000), product.Price)
+	})
 
-		repo.AssertExpectations(t)
+	t.Run("Success case - Product without variants", func(t *testing.T) {
+		plain := &entities.ProductModels{ID: 2, Price: 5000}
+		repo.On("GetProductByID", uint64(2)).Return(plain, nil).Once()
+
+		result, err := service.GetProductForPurchase(2, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, plain, result)
 	})
 
-	t.Run("Failed case - GetTopRatedProducts Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan produk dengan rating tertinggi")
+	t.Run("Failed case - Variant required", func(t *testing.T) {
+		repo.On("GetProductByID", uint64(1)).Return(product, nil).Once()
 
-		repo.On("GetTopRatedProducts").Return(nil, expectedErr).Once()
+		result, err := service.GetProductForPurchase(1, 0)
 
-		result, err := service.GetTopRatedProducts()
+		assert.EqualError(t, err, "varian produk wajib dipilih")
+		assert.Nil(t, result)
+	})
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
+	t.Run("Failed case - Variant not found", func(t *testing.T) {
+		repo.On("GetProductByID", uint64(1)).Return(product, nil).Once()
+
+		result, err := service.GetProductForPurchase(1, 9)
+
+		assert.EqualError(t, err, "varian produk tidak ditemukan")
 		assert.Nil(t, result)
-		repo.AssertExpectations(t)
 	})
 }
 
-func TestProductService_GetProductsByCategoryAndName(t *testing.T) {
-	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	categoryName := "Electronics"
-	name := "Smartphone"
-	page := 1
-	perPage := 10
-
-	t.Run("Success case - Products Found", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:          1,
-				Name:        "Smartphone A",
-				Description: "Description A",
-			},
-			{
-				ID:          2,
-				Name:        "Smartphone B",
-				Description: "Description B",
-			},
-		}
+func TestProductService_CreateVariant(t *testing.T) {
+	stock := uint64(5)
+	request := &dto.VariantRequest{SKU: "TOTE-L", Size: "L", Color: "Hijau", Price: 8000, Stock: &stock, GramPlastic: 15}
+
+	t.Run("Success case - Variant created", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{ID: 1}, nil).Once()
+		repo.On("GetVariantBySKU", "TOTE-L").Return(nil, errors.New("record not found")).Once()
+		repo.On("CreateVariant", mock.MatchedBy(func(variant *entities.ProductVariantModels) bool {
+			return variant.ProductID == 1 && variant.SKU == "TOTE-L" && variant.Price == 8000 && variant.Stock == 5
+		})).Return(&entities.ProductVariantModels{ID: 7, ProductID: 1, SKU: "TOTE-L", Stock: 5}, nil).Once()
+		repo.On("SyncVariantStock", uint64(1)).Return(nil).Once()
+		repo.On("GetStockLevel", uint64(1), uint64(7)).Return(uint64(5), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "initial" && *movement.VariantID == 7 && movement.Quantity == 5
+		})).Return(nil).Once()
+
+		variant, err := service.CreateVariant(1, request)
 
-		repo.On("GetProductsByCategoryAndName", page, perPage, categoryName, name).Return(foundProducts, nil).Once()
-		repo.On("GetProductsCountByCategoryAndName", categoryName, name).Return(int64(len(foundProducts)), nil).Once()
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(7), variant.ID)
+	})
+
+	t.Run("Failed case - Product still holds its own stock", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{ID: 1, Stock: 12}, nil).Once()
+
+		variant, err := service.CreateVariant(1, request)
+
+		assert.EqualError(t, err, "stok produk harus dikosongkan sebelum menambahkan varian")
+		assert.Nil(t, variant)
+		repo.AssertNotCalled(t, "CreateVariant", mock.Anything)
+	})
+
+	t.Run("Success case - Further variant added to variant stock", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{
+			ID:       1,
+			Stock:    4,
+			Variants: []entities.ProductVariantModels{{ID: 6, ProductID: 1, Stock: 4}},
+		}, nil).Once()
+		repo.On("GetVariantBySKU", "TOTE-L").Return(nil, errors.New("record not found")).Once()
+		repo.On("CreateVariant", mock.Anything).Return(&entities.ProductVariantModels{ID: 7, ProductID: 1, SKU: "TOTE-L"}, nil).Once()
+		repo.On("SyncVariantStock", uint64(1)).Return(nil).Once()
 
-		result, totalItems, err := service.GetProductsByCategoryAndName(categoryName, name, page, perPage)
+		variant, err := service.CreateVariant(1, &dto.VariantRequest{SKU: "TOTE-L", Price: 8000})
 
 		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
-		repo.AssertExpectations(t)
+		assert.Equal(t, uint64(7), variant.ID)
 	})
 
-	t.Run("Failed case - GetProductsByCategoryAndName Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan produk sesuai pencarian")
+	t.Run("Failed case - Product not found", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(nil, errors.New("record not found")).Once()
 
-		repo.On("GetProductsByCategoryAndName", page, perPage, categoryName, name).Return(nil, expectedErr).Once()
+		variant, err := service.CreateVariant(1, request)
 
-		result, _, err := service.GetProductsByCategoryAndName(categoryName, name, page, perPage)
+		assert.EqualError(t, err, "produk tidak ditemukan")
+		assert.Nil(t, variant)
+	})
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
-		assert.Nil(t, result)
-		repo.AssertExpectations(t)
+	t.Run("Failed case - Discount above price", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{ID: 1}, nil).Once()
+
+		variant, err := service.CreateVariant(1, &dto.VariantRequest{SKU: "TOTE-L", Price: 1000, Discount: 2000})
+
+		assert.EqualError(t, err, "diskon varian melebihi harga")
+		assert.Nil(t, variant)
 	})
 
-	t.Run("Failed case - GetProductsCountByCategoryAndName Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan jumlah total produk sesuai pencarian")
-		repo.On("GetProductsByCategoryAndName", page, perPage, categoryName, name).Return([]*entities.ProductModels{}, nil).Once()
-		repo.On("GetProductsCountByCategoryAndName", categoryName, name).Return(int64(0), expectedErr).Once()
+	t.Run("Failed case - SKU taken", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{ID: 1}, nil).Once()
+		repo.On("GetVariantBySKU", "TOTE-L").Return(&entities.ProductVariantModels{ID: 3, SKU: "TOTE-L"}, nil).Once()
 
-		result, _, err := service.GetProductsByCategoryAndName(categoryName, name, page, perPage)
+		variant, err := service.CreateVariant(1, request)
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
-		assert.Nil(t, result)
-		repo.AssertExpectations(t)
+		assert.EqualError(t, err, "sku varian sudah digunakan")
+		assert.Nil(t, variant)
 	})
 }
 
-func TestProductService_GetProductsByCategoryName(t *testing.T) {
-	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	categoryName := "Electronics"
-	page := 1
-	perPage := 10
-
-	t.Run("Success case - Products Found", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:          1,
-				Name:        "Product A",
-				Description: "Description A",
-			},
-			{
-				ID:          2,
-				Name:        "Product B",
-				Description: "Description B",
-			},
-		}
-		repo.On("GetProductsByCategoryName", categoryName, page, perPage).Return(foundProducts, nil).Once()
-		repo.On("GetProductCountByCategoryName", categoryName).Return(int64(len(foundProducts)), nil).Once()
+func TestProductService_UpdateVariant(t *testing.T) {
+	request := &dto.VariantRequest{SKU: "TOTE-L", Size: "L", Price: 9000}
 
-		result, totalItems, err := service.GetProductsByCategoryName(categoryName, page, perPage)
+	t.Run("Success case - Variant updated", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		variant := &entities.ProductVariantModels{ID: 7, ProductID: 1, SKU: "TOTE-L", Price: 8000, Stock: 5}
+		repo.On("GetVariantByID", uint64(7)).Return(variant, nil).Once()
+		repo.On("GetVariantBySKU", "TOTE-L").Return(variant, nil).Once()
+		repo.On("UpdateVariant", variant).Return(variant, nil).Once()
+		repo.On("SyncVariantStock", uint64(1)).Return(nil).Once()
+
+		result, err := service.UpdateVariant(1, 7, request)
 
 		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
-		repo.AssertExpectations(t)
+		assert.Equal(t, uint64(9000), result.Price)
+		assert.Equal(t, uint64(5), result.Stock)
 	})
 
-	t.Run("Failed case - GetProductsByCategoryName Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan produk sesuai pencarian")
+	t.Run("Failed case - Stock Changed Outside Adjustment", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		stock := uint64(8)
+		variant := &entities.ProductVariantModels{ID: 7, ProductID: 1, SKU: "TOTE-L", Price: 8000, Stock: 5}
+		repo.On("GetVariantByID", uint64(7)).Return(variant, nil).Once()
+		repo.On("GetVariantBySKU", "TOTE-L").Return(variant, nil).Once()
 
-		repo.On("GetProductsByCategoryName", categoryName, page, perPage).Return(nil, expectedErr).Once()
+		result, err := service.UpdateVariant(1, 7, &dto.VariantRequest{SKU: "TOTE-L", Price: 9000, Stock: &stock})
 
-		result, _, err := service.GetProductsByCategoryName(categoryName, page, perPage)
+		assert.EqualError(t, err, "stok diubah melalui penyesuaian stok")
+		assert.Nil(t, result)
+	})
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
+	t.Run("Failed case - Variant of another product", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetVariantByID", uint64(7)).Return(&entities.ProductVariantModels{ID: 7, ProductID: 2}, nil).Once()
+
+		result, err := service.UpdateVariant(1, 7, request)
+
+		assert.EqualError(t, err, "varian produk tidak ditemukan")
 		assert.Nil(t, result)
-		repo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_DeleteVariant(t *testing.T) {
+	t.Run("Success case - Variant deleted", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetVariantByID", uint64(7)).Return(&entities.ProductVariantModels{ID: 7, ProductID: 1}, nil).Once()
+		repo.On("DeleteVariant", uint64(7)).Return(nil).Once()
+		repo.On("SyncVariantStock", uint64(1)).Return(nil).Once()
+
+		err := service.DeleteVariant(1, 7)
+
+		assert.NoError(t, err)
 	})
 
-	t.Run("Failed case - GetProductCountByCategoryName Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan jumlah total produk sesuai pencarian")
+	t.Run("Failed case - Variant not found", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetVariantByID", uint64(7)).Return(nil, errors.New("record not found")).Once()
 
-		repo.On("GetProductsByCategoryName", categoryName, page, perPage).Return([]*entities.ProductModels{}, nil).Once()
-		repo.On("GetProductCountByCategoryName", categoryName).Return(int64(0), expectedErr).Once()
+		err := service.DeleteVariant(1, 7)
 
-		result, _, err := service.GetProductsByCategoryName(categoryName, page, perPage)
+		assert.EqualError(t, err, "varian produk tidak ditemukan")
+	})
 
-		assert.Error(t, err)
-		assert.EqualError(t, err, expectedErr.Error())
-		assert.Nil(t, result)
-		repo.AssertExpectations(t)
+	t.Run("Failed case - Variant still has stock", func(t *testing.T) {
+		repo := mocks.NewRepositoryProductInterface(t)
+		service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+		repo.On("GetVariantByID", uint64(7)).Return(&entities.ProductVariantModels{ID: 7, ProductID: 1, Stock: 3}, nil).Once()
+
+		err := service.DeleteVariant(1, 7)
+
+		assert.EqualError(t, err, "stok varian harus dikosongkan sebelum dihapus")
+		repo.AssertNotCalled(t, "DeleteVariant", mock.Anything)
 	})
 }
 
-func TestProductService_GetProductsBySearchAndFilter(t *testing.T) {
+func TestProductService_GetTotalProductSold(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
 	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
 	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	page := 1
-	perPage := 8
-	filter := "abjad"
-	search := "smartphone"
-
-	t.Run("Success case - Products Found with Filter", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:          1,
-				Name:        "Smartphone A",
-				Description: "Description A",
-				Categories: []entities.CategoryModels{
-					{
-						ID:   1,
-						Name: "smartphone",
-					},
-				},
-			},
-			{
-				ID:          2,
-				Name:        "Smartphone B",
-				Description: "Description B",
-			},
-		}
-		repo.On("GetProductBySearchAndFilter", page, perPage, filter, search).Return(foundProducts, int64(len(foundProducts)), nil).Once()
-
-		result, totalItems, err := service.GetProductsBySearchAndFilter(page, perPage, filter, search)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
-		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
-		repo.AssertExpectations(t)
-	})
-
-	t.Run("Success case - Products Found without Filter", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:          3,
-				Name:        "Laptop C",
-				Description: "Description C",
-			},
-			{
-				ID:          4,
-				Name:        "Tablet D",
-				Description: "Description D",
-			},
-		}
-
-		repo.On("GetProductBySearchAndFilter", page, perPage, "", search).Return(foundProducts, int64(len(foundProducts)), nil).Once()
+	t.Run("Success case - Total Product Sold", func(t *testing.T) {
+		repo.On("GetTotalProductSold").Return(uint64(100), nil).Once()
 
-		result, totalItems, err := service.GetProductsBySearchAndFilter(page, perPage, "", search)
+		totalSold, err := service.GetTotalProductSold()
 
 		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
+		assert.Equal(t, uint64(100), totalSold)
+
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Failed case - GetProductBySearchAndFilter Error", func(t *testing.T) {
-		expectedErr := errors.New("gagal mendapatkan produk sesuai pencarian dan filter")
+	t.Run("Failed case - GetTotalProductSold Error", func(t *testing.T) {
+		expectedErr := errors.New("gagal mendapatkan total produk terjual")
 
-		repo.On("GetProductBySearchAndFilter", page, perPage, mock.Anything, search).Return(nil, int64(0), expectedErr).Once()
+		repo.On("GetTotalProductSold").Return(uint64(0), expectedErr).Once()
 
-		result, _, err := service.GetProductsBySearchAndFilter(page, perPage, filter, search)
+		totalSold, err := service.GetTotalProductSold()
 
 		assert.Error(t, err)
 		assert.EqualError(t, err, expectedErr.Error())
-		assert.Nil(t, result)
+		assert.Equal(t, uint64(0), totalSold)
 		repo.AssertExpectations(t)
 	})
 }
 
-func TestProductService_GetProductsByFilter(t *testing.T) {
+func TestProductService_GetTopRatedProducts(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatBot := repoAi.NewRepositoryAssistantInterface(t)
+	repoAi := repoAi.NewRepositoryAssistantInterface(t)
 	var initConfig = config.InitConfig()
 	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatBot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
+	serviceAI := serviceAi.NewAssistantService(repoAi, client, *initConfig)
+	service := NewProductService(repo, serviceAI, nil, nil, nil, nil, 0)
 
-	page := 1
-	perPage := 10
-	filter := "abjad"
+	t.Run("Success case - Top Rated Products", func(t *testing.T) {
+		topRatedProduct := &entities.ProductModels{
+			ID:          1,
+			Name:        "Top Rated Product",
+			Description: "Description",
+		}
+
+		repo.On("GetTopRatedProducts").Return([]*entities.ProductModels{topRatedProduct}, nil).Once()
 
-	t.Run("Success Case - Valid Filter", func(t *testing.T) {
-		expectedProducts := []*entities.ProductModels{}
-		expectedTotalItems := int64(len(expectedProducts))
-		repo.On("GetProductByFilter", page, perPage, filter).Return(expectedProducts, expectedTotalItems, nil).Once()
+		result, err := service.GetTopRatedProducts()
 
-		products, totalItems, err := service.GetProductsByFilter(page, perPage, filter)
 		assert.NoError(t, err)
-		assert.Equal(t, expectedProducts, products)
-		assert.Equal(t, expectedTotalItems, totalItems)
+		assert.Equal(t, []*entities.ProductModels{topRatedProduct}, result)
+
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Error Case - Repository Error", func(t *testing.T) {
-		expectedErr := errors.New("repository error")
-		repo.On("GetProductByFilter", page, perPage, filter).Return(nil, int64(0), expectedErr).Once()
+	t.Run("Failed case - GetTopRatedProducts Error", func(t *testing.T) {
+		expectedErr := errors.New("gagal mendapatkan produk dengan rating tertinggi")
+
+		repo.On("GetTopRatedProducts").Return(nil, expectedErr).Once()
+
+		result, err := service.GetTopRatedProducts()
 
-		products, totalItems, err := service.GetProductsByFilter(page, perPage, filter)
 		assert.Error(t, err)
-		assert.Nil(t, products)
-		assert.Zero(t, totalItems)
-		assert.Equal(t, expectedErr, err)
+		assert.EqualError(t, err, expectedErr.Error())
+		assert.Nil(t, result)
 		repo.AssertExpectations(t)
 	})
 }
@@ -1425,125 +1327,321 @@ func TestGetRatingBounds(t *testing.T) {
 	})
 }
 
-func TestProductService_GetRatedProductsInRange(t *testing.T) {
+func TestProductService_ListProducts(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	page := 1
-	perPage := 8
-	ratingParam := "baik"
-
-	t.Run("Success case - Rated Products Found", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:     1,
-				Name:   "Product A",
-				Rating: 4.5,
-			},
-			{
-				ID:     2,
-				Name:   "Product B",
-				Rating: 4.8,
-			},
+	service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+	createdAt := time.Date(2023, 11, 20, 8, 0, 0, 0, time.UTC)
+	products := []*entities.ProductModels{
+		{ID: 3, Name: "Botol Minum", Price: 50000, CreatedAt: createdAt},
+		{ID: 2, Name: "Sedotan Bambu", Price: 20000, CreatedAt: createdAt},
+		{ID: 1, Name: "Tas Belanja", Price: 35000, CreatedAt: createdAt},
+	}
+
+	t.Run("Success case - Filters combine and the next cursor is set", func(t *testing.T) {
+		minPrice, maxPrice := uint64(10000), uint64(60000)
+		query := &productFeature.ProductQuery{
+			Search:       "botol",
+			Category:     "Peralatan Makan",
+			MinPrice:     &minPrice,
+			MaxPrice:     &maxPrice,
+			Rating:       "baik",
+			DiscountOnly: true,
+			InStock:      true,
+			PerPage:      2,
 		}
+		matchesQuery := mock.MatchedBy(func(spec *productFeature.ProductQuery) bool {
+			return spec.Sort == productFeature.SortNewest && spec.Search == "botol" && spec.Category == "Peralatan Makan" &&
+				*spec.MinRating == 4 && *spec.MaxRating == 4.9 && spec.DiscountOnly && spec.InStock
+		})
+		repo.On("FindProducts", matchesQuery, (*productFeature.Cursor)(nil), 0, 3).Return(products, nil).Once()
+		repo.On("CountProducts", matchesQuery).Return(int64(5), nil).Once()
 
-		expectedLowerBound, expectedUpperBound, _ := getRatingBounds(ratingParam)
-		repo.On("GetRatedProductsInRange", page, perPage, expectedLowerBound, expectedUpperBound).Return(foundProducts, int64(len(foundProducts)), nil).Once()
+		result, totalItems, nextCursor, err := service.ListProducts(query)
 
-		result, totalItems, err := service.GetRatedProductsInRange(page, perPage, ratingParam)
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(5), totalItems)
+		cursor, err := productFeature.DecodeCursor(nextCursor, productFeature.SortNewest)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2), cursor.ID)
+		assert.True(t, cursor.CreatedAt.Equal(createdAt))
+		assert.Empty(t, query.Sort)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Cursor continues after the last product", func(t *testing.T) {
+		cursor := productFeature.NewCursor(productFeature.SortCheapest, products[1]).Encode()
+		query := &productFeature.ProductQuery{Sort: productFeature.SortCheapest, Cursor: cursor, Page: 4}
+		repo.On("FindProducts", mock.Anything, mock.MatchedBy(func(cursor *productFeature.Cursor) bool {
+			return cursor.ID == 2 && cursor.Value() == uint64(20000)
+		}), 0, 9).Return(products[2:], nil).Once()
+		repo.On("CountProducts", mock.Anything).Return(int64(3), nil).Once()
+
+		result, totalItems, nextCursor, err := service.ListProducts(query)
 
 		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
+		assert.Len(t, result, 1)
+		assert.Equal(t, int64(3), totalItems)
+		assert.Empty(t, nextCursor)
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Error case - Invalid Rating Param", func(t *testing.T) {
-		ratingParam := "invalid"
+	t.Run("Success case - Page number without cursor", func(t *testing.T) {
+		repo.On("FindProducts", mock.Anything, (*productFeature.Cursor)(nil), 16, 9).Return(nil, nil).Once()
+		repo.On("CountProducts", mock.Anything).Return(int64(3), nil).Once()
 
-		result, totalItems, err := service.GetRatedProductsInRange(page, perPage, ratingParam)
+		result, _, nextCursor, err := service.ListProducts(&productFeature.ProductQuery{Sort: productFeature.SortAlphabet, Page: 3})
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Empty(t, nextCursor)
+		repo.AssertExpectations(t)
 	})
 
-	t.Run("Error case - Repository error", func(t *testing.T) {
-		expectedLowerBound, expectedUpperBound, _ := getRatingBounds(ratingParam)
+	t.Run("Failed case - Invalid Sort", func(t *testing.T) {
+		_, _, _, err := service.ListProducts(&productFeature.ProductQuery{Sort: "populer"})
 
-		repo.On("GetRatedProductsInRange", page, perPage, expectedLowerBound, expectedUpperBound).Return(nil, int64(0), errors.New("some repository error")).Once()
+		assert.EqualError(t, err, "urutan produk tidak valid")
+	})
 
-		result, totalItems, err := service.GetRatedProductsInRange(page, perPage, ratingParam)
+	t.Run("Failed case - Invalid Price Range", func(t *testing.T) {
+		minPrice, maxPrice := uint64(50000), uint64(10000)
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
+		_, _, _, err := service.ListProducts(&productFeature.ProductQuery{MinPrice: &minPrice, MaxPrice: &maxPrice})
+
+		assert.EqualError(t, err, "rentang harga tidak valid")
+	})
+
+	t.Run("Failed case - Cursor From Another Sort", func(t *testing.T) {
+		cursor := productFeature.NewCursor(productFeature.SortCheapest, products[0]).Encode()
+
+		_, _, _, err := service.ListProducts(&productFeature.ProductQuery{Sort: productFeature.SortPriciest, Cursor: cursor})
+
+		assert.EqualError(t, err, "cursor tidak valid")
+	})
+
+	t.Run("Failed case - Invalid Rating Label", func(t *testing.T) {
+		_, _, _, err := service.ListProducts(&productFeature.ProductQuery{Rating: "luar biasa"})
+
+		assert.EqualError(t, err, "tipe filter tidak valid")
+	})
+
+	t.Run("Failed case - Find Products Error", func(t *testing.T) {
+		expectedErr := errors.New("database error")
+		repo.On("FindProducts", mock.Anything, (*productFeature.Cursor)(nil), 0, 9).Return(nil, expectedErr).Once()
+
+		_, _, _, err := service.ListProducts(&productFeature.ProductQuery{})
+
+		assert.Equal(t, expectedErr, err)
 		repo.AssertExpectations(t)
 	})
 }
 
-func TestProductService_SearchByNameAndFilterByRating(t *testing.T) {
+func TestProductService_AdjustStock(t *testing.T) {
 	repo := mocks.NewRepositoryProductInterface(t)
-	repoChatbot := repoAi.NewRepositoryAssistantInterface(t)
-	var initConfig = config.InitConfig()
-	var client = openai.NewClient(initConfig.OpenAiApiKey)
-	serviceAI := serviceAi.NewAssistantService(repoChatbot, client, *initConfig)
-	service := NewProductService(repo, serviceAI)
-
-	page := 1
-	perPage := 8
-	name := "Product"
-	ratingParam := "baik"
-
-	t.Run("Success case - Products Found", func(t *testing.T) {
-		foundProducts := []*entities.ProductModels{
-			{
-				ID:     1,
-				Name:   "Product A",
-				Rating: 4.5,
-			},
-			{
-				ID:     2,
-				Name:   "Product B",
-				Rating: 4.8,
-			},
-		}
+	notificationService := notificationMocks.NewServiceNotificationInterface(t)
+	txManager := databaseMocks.NewTransactionManagerInterface(t)
+	txManager.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error {
+		return fn(nil)
+	}).Maybe()
+	repo.On("WithTx", mock.Anything).Return(repo).Maybe()
+	notificationService.On("WithTx", mock.Anything).Return(notificationService).Maybe()
+	roleService := roleMocks.NewServiceRoleInterface(t)
+	service := NewProductService(repo, nil, notificationService, roleService, nil, txManager, 5)
+
+	productID := uint64(1)
+	actorID := uint64(9)
 
-		expectedLowerBound, expectedUpperBound, _ := getRatingBounds(ratingParam)
-		repo.On("SearchByNameAndFilterByRating", page, perPage, name, ratingParam, expectedLowerBound, expectedUpperBound).Return(foundProducts, int64(len(foundProducts)), nil).Once()
+	t.Run("Success case - Stock Added", func(t *testing.T) {
+		request := &dto.AdjustStockRequest{Quantity: 10, Reason: "restock gudang"}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID, Stock: 3}, nil).Once()
+		repo.On("IncreaseStock", productID, uint64(10)).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(uint64(13), nil).Once()
+		repo.On("CreateInventoryMovement", mock.MatchedBy(func(movement *entities.InventoryMovementModels) bool {
+			return movement.Type == "adjustment" && movement.Quantity == 10 && movement.StockAfter == 13 &&
+				movement.Reason == "restock gudang" && movement.ActorID == actorID
+		})).Return(nil).Once()
 
-		result, totalItems, err := service.SearchByNameAndFilterByRating(page, perPage, name, ratingParam)
+		movement, err := service.AdjustStock(productID, request, actorID)
 
 		assert.NoError(t, err)
-		assert.Equal(t, foundProducts, result)
-		assert.Equal(t, int64(len(foundProducts)), totalItems)
+		assert.Equal(t, uint64(13), movement.StockAfter)
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Error case - Repository error", func(t *testing.T) {
-		expectedLowerBound, expectedUpperBound, _ := getRatingBounds(ratingParam)
+	t.Run("Success case - Removal Crossing Threshold Alerts Staff", func(t *testing.T) {
+		request := &dto.AdjustStockRequest{Quantity: -6, Reason: "barang rusak"}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID, Name: "Tote Bag", Stock: 10}, nil).Once()
+		repo.On("ReduceStockWhenPurchasing", productID, uint64(6)).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(uint64(4), nil).Once()
+		repo.On("CreateInventoryMovement", mock.AnythingOfType("*entities.InventoryMovementModels")).Return(nil).Once()
+		roleService.On("GetUserIDsWithPermission", "products.stock").Return([]uint64{2, 3}, nil).Once()
+		notificationService.On("Notify", mock.MatchedBy(func(request *notificationDto.NotifyRequest) bool {
+			return request.Type == "stock_alert" && request.Body == "Stok Tote Bag tinggal 4. Segera lakukan restock, yaa!"
+		})).Return(nil, nil).Twice()
 
-		repo.On("SearchByNameAndFilterByRating", page, perPage, name, ratingParam, expectedLowerBound, expectedUpperBound).Return(nil, int64(0), errors.New("some repository error")).Once()
+		_, err := service.AdjustStock(productID, request, actorID)
 
-		result, totalItems, err := service.SearchByNameAndFilterByRating(page, perPage, name, ratingParam)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		notificationService.AssertExpectations(t)
+	})
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
+	t.Run("Success case - Already Low Stock Does Not Alert Again", func(t *testing.T) {
+		request := &dto.AdjustStockRequest{Quantity: -1, Reason: "barang rusak"}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID, Stock: 4}, nil).Once()
+		repo.On("ReduceStockWhenPurchasing", productID, uint64(1)).Return(nil).Once()
+		repo.On("GetStockLevel", productID, uint64(0)).Return(uint64(3), nil).Once()
+		repo.On("CreateInventoryMovement", mock.AnythingOfType("*entities.InventoryMovementModels")).Return(nil).Once()
+
+		_, err := service.AdjustStock(productID, request, actorID)
+
+		assert.NoError(t, err)
 		repo.AssertExpectations(t)
 	})
 
-	t.Run("Error case - Invalid Rating Param", func(t *testing.T) {
-		ratingParam := "invalid"
+	t.Run("Failed case - Zero Quantity", func(t *testing.T) {
+		_, err := service.AdjustStock(productID, &dto.AdjustStockRequest{Reason: "cek"}, actorID)
+
+		assert.EqualError(t, err, "jumlah penyesuaian stok tidak boleh nol")
+	})
 
-		result, totalItems, err := service.SearchByNameAndFilterByRating(page, perPage, name, ratingParam)
+	t.Run("Failed case - Stock Would Go Below Zero", func(t *testing.T) {
+		request := &dto.AdjustStockRequest{Quantity: -5, Reason: "barang hilang"}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID, Stock: 2}, nil).Once()
 
-		assert.Error(t, err)
-		assert.Nil(t, result)
-		assert.Zero(t, totalItems)
+		_, err := service.AdjustStock(productID, request, actorID)
+
+		assert.EqualError(t, err, "stok tidak boleh kurang dari nol")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Variant Not Picked", func(t *testing.T) {
+		request := &dto.AdjustStockRequest{Quantity: 2, Reason: "restock"}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{
+			ID:       productID,
+			Variants: []entities.ProductVariantModels{{ID: 7, ProductID: productID}},
+		}, nil).Once()
+
+		_, err := service.AdjustStock(productID, request, actorID)
+
+		assert.EqualError(t, err, "varian produk wajib dipilih")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_GetInventoryMovements(t *testing.T) {
+	repo := mocks.NewRepositoryProductInterface(t)
+	service := NewProductService(repo, nil, nil, nil, nil, nil, 0)
+
+	productID := uint64(1)
+
+	t.Run("Success case - Movements Found", func(t *testing.T) {
+		movements := []*entities.InventoryMovementModels{{ID: 2, ProductID: productID, Type: "sale", Quantity: -1}}
+		repo.On("GetProductByID", productID).Return(&entities.ProductModels{ID: productID}, nil).Once()
+		repo.On("FindInventoryMovements", productID, 1, 8).Return(movements, nil).Once()
+		repo.On("CountInventoryMovements", productID).Return(int64(1), nil).Once()
+
+		result, totalItems, err := service.GetInventoryMovements(productID, 1, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, movements, result)
+		assert.Equal(t, int64(1), totalItems)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Product Not Found", func(t *testing.T) {
+		repo.On("GetProductByID", productID).Return(nil, errors.New("record not found")).Once()
+
+		_, _, err := service.GetInventoryMovements(productID, 1, 8)
+
+		assert.EqualError(t, err, "produk tidak ditemukan")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestProductService_SearchIndex(t *testing.T) {
+	repo := mocks.NewRepositoryProductInterface(t)
+	searchService := searchMocks.NewServiceSearchInterface(t)
+	service := NewProductService(repo, nil, nil, nil, searchService, nil, 0)
+
+	indexed := &entities.ProductModels{
+		ID:          1,
+		Name:        "Botol Minum",
+		Description: "Botol stainless",
+		Categories:  []entities.CategoryModels{{ID: 2, Name: "Peralatan Makan"}},
+	}
+	isProductDocument := mock.MatchedBy(func(document *search.Document) bool {
+		return document.Type == search.DocumentProduct && document.ID == 1 && document.Title == "Botol Minum" &&
+			len(document.Keywords) == 1 && document.Keywords[0] == "Peralatan Makan"
+	})
+
+	t.Run("Success case - Created Product Is Indexed", func(t *testing.T) {
+		repo.On("CreateProduct", mock.Anything, mock.Anything).Return(&entities.ProductModels{ID: 1}, nil).Once()
+		repo.On("GetProductByID", uint64(1)).Return(indexed, nil).Once()
+		searchService.On("Index", isProductDocument).Return(nil).Once()
+
+		_, err := service.CreateProduct(&dto.CreateProductRequest{Name: "Botol Minum"})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Index Failure Does Not Fail The Change", func(t *testing.T) {
+		repo.On("CreateProduct", mock.Anything, mock.Anything).Return(&entities.ProductModels{ID: 1}, nil).Once()
+		repo.On("GetProductByID", uint64(1)).Return(indexed, nil).Once()
+		searchService.On("Index", isProductDocument).Return(errors.New("gagal memperbarui indeks pencarian")).Once()
+
+		_, err := service.CreateProduct(&dto.CreateProductRequest{Name: "Botol Minum"})
+
+		assert.NoError(t, err)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Deleted Product Is Removed", func(t *testing.T) {
+		repo.On("GetProductByID", uint64(1)).Return(indexed, nil).Once()
+		repo.On("DeleteProduct", uint64(1)).Return(nil).Once()
+		searchService.On("Remove", search.DocumentProduct, uint64(1)).Return(nil).Once()
+
+		err := service.DeleteProduct(1)
+
+		assert.NoError(t, err)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Reindex Pages Through Products", func(t *testing.T) {
+		searchService.On("Clear", search.DocumentProduct).Return(nil).Once()
+		repo.On("FindAll", 1, reindexBatchSize).Return([]*entities.ProductModels{indexed}, nil).Once()
+		searchService.On("Index", isProductDocument).Return(nil).Once()
+
+		count, err := service.ReindexSearch()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		repo.AssertExpectations(t)
+		searchService.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Category Change Reindexes Its Products", func(t *testing.T) {
+		deletedAt := time.Now()
+		withDeletedCategory := &entities.ProductModels{
+			ID:          1,
+			Name:        "Botol Minum",
+			Description: "Botol stainless",
+			Categories: []entities.CategoryModels{
+				{ID: 2, Name: "Peralatan Makan"},
+				{ID: 3, Name: "Dapur", DeletedAt: &deletedAt},
+			},
+		}
+		repo.On("FindProductIDsByCategory", uint64(3)).Return([]uint64{1}, nil).Once()
+		repo.On("GetProductByID", uint64(1)).Return(withDeletedCategory, nil).Once()
+		searchService.On("Index", isProductDocument).Return(nil).Once()
+
+		err := service.ReindexCategoryProducts(3)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		searchService.AssertExpectations(t)
 	})
 }
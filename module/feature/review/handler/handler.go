@@ -25,9 +25,6 @@ func NewReviewHandler(service review.ServiceReviewInterface) review.HandlerRevie
 func (h *ReviewHandler) CreateReview() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		reviewRequest := new(dto.CreateReviewRequest)
 		if err := c.Bind(reviewRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
@@ -52,10 +49,6 @@ func (h *ReviewHandler) CreateReview() echo.HandlerFunc {
 
 func (h *ReviewHandler) CreateReviewImages() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		reviewRequest := new(dto.CreatePhotoReviewRequest)
 		file, err := c.FormFile("photo")
 		var uploadedURL string
@@ -25,7 +25,7 @@ func setupTestService(t *testing.T) (*mocks.RepositoryReviewInterface, review.Se
 	repoAssistant := assistantMocks.NewRepositoryAssistantInterface(t)
 
 	assistantService := assistants.NewAssistantService(repoAssistant, nil, config.Config{})
-	productService := products.NewProductService(repoProduct, assistantService)
+	productService := products.NewProductService(repoProduct, assistantService, nil, nil, nil, nil, 0)
 	reviewService := NewReviewService(repo, productService)
 
 	return repo, reviewService, productService, assistantService
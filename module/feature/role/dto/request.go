@@ -0,0 +1,16 @@
+package dto
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required"`
+}
+
+type UpdateRoleRequest struct {
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions" validate:"required"`
+}
+
+type AssignUserRoleRequest struct {
+	Role string `json:"role" validate:"required"`
+}
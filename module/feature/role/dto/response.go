@@ -0,0 +1,54 @@
+package dto
+
+import "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+
+type RoleFormatter struct {
+	ID          uint64   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system"`
+	Permissions []string `json:"permissions"`
+}
+
+func FormatRole(role *entities.RoleModels) *RoleFormatter {
+	permissions := make([]string, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		permissions = append(permissions, permission.Name)
+	}
+
+	return &RoleFormatter{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		IsSystem:    role.IsSystem,
+		Permissions: permissions,
+	}
+}
+
+func FormatterRole(roles []*entities.RoleModels) []*RoleFormatter {
+	roleFormatters := make([]*RoleFormatter, 0)
+
+	for _, role := range roles {
+		roleFormatters = append(roleFormatters, FormatRole(role))
+	}
+
+	return roleFormatters
+}
+
+type PermissionFormatter struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func FormatterPermission(permissions []*entities.PermissionModels) []*PermissionFormatter {
+	permissionFormatters := make([]*PermissionFormatter, 0)
+
+	for _, permission := range permissions {
+		permissionFormatters = append(permissionFormatters, &PermissionFormatter{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return permissionFormatters
+}
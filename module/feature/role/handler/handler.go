@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+)
+
+type RoleHandler struct {
+	service role.ServiceRoleInterface
+}
+
+func NewRoleHandler(service role.ServiceRoleInterface) role.HandlerRoleInterface {
+	return &RoleHandler{
+		service: service,
+	}
+}
+
+func (h *RoleHandler) GetRoles() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roles, err := h.service.GetRoles()
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar peran: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan daftar peran", dto.FormatterRole(roles))
+	}
+}
+
+func (h *RoleHandler) GetRoleById() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+		result, err := h.service.GetRoleById(roleID)
+		if err != nil {
+			return response.SendStatusNotFoundResponse(c, "Gagal mendapatkan peran: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan peran", dto.FormatRole(result))
+	}
+}
+
+func (h *RoleHandler) GetPermissions() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		permissions, err := h.service.GetPermissions()
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar izin: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan daftar izin", dto.FormatterPermission(permissions))
+	}
+}
+
+func (h *RoleHandler) CreateRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := new(dto.CreateRoleRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, err := h.service.CreateRole(req)
+		if err != nil {
+			if err.Error() == "nama peran sudah digunakan" {
+				return response.SendStatusConflictResponse(c, "Gagal menambahkan peran: "+err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Gagal menambahkan peran: "+err.Error())
+		}
+		return response.SendStatusCreatedResponse(c, "Peran berhasil ditambahkan", dto.FormatRole(result))
+	}
+}
+
+func (h *RoleHandler) UpdateRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+		req := new(dto.UpdateRoleRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, err := h.service.UpdateRole(roleID, req)
+		if err != nil {
+			if err.Error() == "peran tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Gagal memperbarui peran: "+err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Gagal memperbarui peran: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil memperbarui peran", dto.FormatRole(result))
+	}
+}
+
+func (h *RoleHandler) DeleteRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		roleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+		if err := h.service.DeleteRole(roleID); err != nil {
+			if err.Error() == "peran tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, "Gagal menghapus peran: "+err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Gagal menghapus peran: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil menghapus peran")
+	}
+}
+
+func (h *RoleHandler) AssignUserRole() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai")
+		}
+		req := new(dto.AssignUserRoleRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		if err := h.service.AssignUserRole(currentUser.ID, userID, req.Role); err != nil {
+			switch err.Error() {
+			case "pengguna tidak ditemukan", "peran tidak ditemukan":
+				return response.SendStatusNotFoundResponse(c, "Gagal mengubah peran pengguna: "+err.Error())
+			case "tidak dapat mengubah peran akun sendiri":
+				return response.SendStatusForbiddenResponse(c, "Gagal mengubah peran pengguna: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mengubah peran pengguna: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Berhasil mengubah peran pengguna")
+	}
+}
@@ -0,0 +1,45 @@
+package role
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role/dto"
+	"github.com/labstack/echo/v4"
+)
+
+type RepositoryRoleInterface interface {
+	FindAllRoles() ([]*entities.RoleModels, error)
+	GetRoleById(roleID uint64) (*entities.RoleModels, error)
+	GetRoleByName(name string) (*entities.RoleModels, error)
+	CreateRole(newRole *entities.RoleModels) (*entities.RoleModels, error)
+	UpdateRole(updatedRole *entities.RoleModels, permissions []entities.PermissionModels) error
+	DeleteRole(roleID uint64) error
+	FindAllPermissions() ([]*entities.PermissionModels, error)
+	GetPermissionsByNames(names []string) ([]entities.PermissionModels, error)
+	CreatePermission(permission *entities.PermissionModels) error
+	CountUsersByRole(name string) (int64, error)
+	UpdateUserRole(userID uint64, roleName string) error
+	FindUserIDsWithPermission(permission string) ([]uint64, error)
+}
+
+type ServiceRoleInterface interface {
+	SeedDefaults() error
+	GetRoles() ([]*entities.RoleModels, error)
+	GetRoleById(roleID uint64) (*entities.RoleModels, error)
+	GetPermissions() ([]*entities.PermissionModels, error)
+	CreateRole(request *dto.CreateRoleRequest) (*entities.RoleModels, error)
+	UpdateRole(roleID uint64, request *dto.UpdateRoleRequest) (*entities.RoleModels, error)
+	DeleteRole(roleID uint64) error
+	AssignUserRole(actorID, userID uint64, roleName string) error
+	GetRolePermissions(roleName string) (map[string]bool, error)
+	GetUserIDsWithPermission(permission string) ([]uint64, error)
+}
+
+type HandlerRoleInterface interface {
+	GetRoles() echo.HandlerFunc
+	GetRoleById() echo.HandlerFunc
+	GetPermissions() echo.HandlerFunc
+	CreateRole() echo.HandlerFunc
+	UpdateRole() echo.HandlerFunc
+	DeleteRole() echo.HandlerFunc
+	AssignUserRole() echo.HandlerFunc
+}
@@ -0,0 +1,136 @@
+package role
+
+import "github.com/labstack/echo/v4"
+
+const (
+	RoleAdmin         = "admin"
+	RoleCustomer      = "customer"
+	RoleContentEditor = "content_editor"
+	RoleWarehouse     = "warehouse"
+)
+
+const (
+	PermissionManageArticles   = "articles.manage"
+	PermissionManageCarousels  = "carousels.manage"
+	PermissionManageCategories = "categories.manage"
+	PermissionManageChallenges = "challenges.manage"
+	PermissionManageProducts   = "products.manage"
+	PermissionManageStock      = "products.stock"
+	PermissionManageOrders     = "orders.manage"
+	PermissionManageVouchers   = "vouchers.manage"
+	PermissionManageUsers      = "users.manage"
+	PermissionViewDashboard    = "dashboard.view"
+	PermissionManageRoles      = "roles.manage"
+	PermissionManageSearch     = "search.manage"
+
+	PermissionOwnAddresses     = "addresses.own"
+	PermissionOwnCart          = "cart.own"
+	PermissionOwnOrders        = "orders.own"
+	PermissionOwnProfile       = "profile.own"
+	PermissionOwnNotifications = "notifications.own"
+	PermissionClaimVouchers    = "vouchers.claim"
+	PermissionBookmarkArticles = "articles.bookmark"
+	PermissionUseAssistant     = "assistant.use"
+	PermissionJoinChallenges   = "challenges.participate"
+	PermissionCreateReviews    = "reviews.create"
+)
+
+// Permissions is the catalog seeded into the database. Roles can only be given
+// permissions from this list because routes are wired against these names.
+var Permissions = map[string]string{
+	PermissionManageArticles:   "Mengelola artikel",
+	PermissionManageCarousels:  "Mengelola carousel",
+	PermissionManageCategories: "Mengelola kategori produk",
+	PermissionManageChallenges: "Mengelola tantangan dan peserta",
+	PermissionManageProducts:   "Mengelola produk",
+	PermissionManageStock:      "Mengubah stok produk",
+	PermissionManageOrders:     "Mengelola pesanan, pembayaran dan pengembalian",
+	PermissionManageVouchers:   "Mengelola kupon",
+	PermissionManageUsers:      "Mengelola pengguna",
+	PermissionViewDashboard:    "Melihat dashboard",
+	PermissionManageRoles:      "Mengelola peran dan izin",
+	PermissionManageSearch:     "Membangun ulang indeks pencarian",
+
+	PermissionOwnAddresses:     "Mengelola alamat sendiri",
+	PermissionOwnCart:          "Mengelola keranjang sendiri",
+	PermissionOwnOrders:        "Membuat dan melihat pesanan sendiri",
+	PermissionOwnProfile:       "Mengelola profil sendiri",
+	PermissionOwnNotifications: "Melihat notifikasi sendiri",
+	PermissionClaimVouchers:    "Klaim dan menggunakan kupon",
+	PermissionBookmarkArticles: "Membaca dan menyimpan artikel",
+	PermissionUseAssistant:     "Menggunakan asisten",
+	PermissionJoinChallenges:   "Mengikuti tantangan",
+	PermissionCreateReviews:    "Memberikan ulasan produk",
+}
+
+// DefaultRoles are created on startup when missing. The admin role is synced
+// back to its defaults on every startup so it can never lose access to the
+// role API and new staff permissions reach it without a manual step.
+var DefaultRoles = map[string][]string{
+	RoleAdmin: {
+		PermissionManageArticles,
+		PermissionManageCarousels,
+		PermissionManageCategories,
+		PermissionManageChallenges,
+		PermissionManageProducts,
+		PermissionManageStock,
+		PermissionManageOrders,
+		PermissionManageVouchers,
+		PermissionManageUsers,
+		PermissionViewDashboard,
+		PermissionManageRoles,
+		PermissionManageSearch,
+	},
+	RoleCustomer: {
+		PermissionOwnAddresses,
+		PermissionOwnCart,
+		PermissionOwnOrders,
+		PermissionOwnProfile,
+		PermissionOwnNotifications,
+		PermissionClaimVouchers,
+		PermissionBookmarkArticles,
+		PermissionUseAssistant,
+		PermissionJoinChallenges,
+		PermissionCreateReviews,
+	},
+	RoleContentEditor: {
+		PermissionManageArticles,
+		PermissionManageCarousels,
+	},
+	RoleWarehouse: {
+		PermissionManageOrders,
+		PermissionManageStock,
+	},
+}
+
+var defaultRoleDescriptions = map[string]string{
+	RoleAdmin:         "Administrator dengan semua izin",
+	RoleCustomer:      "Pelanggan",
+	RoleContentEditor: "Editor konten untuk artikel dan carousel",
+	RoleWarehouse:     "Gudang untuk pesanan dan stok",
+}
+
+func DefaultRoleDescription(name string) string {
+	return defaultRoleDescriptions[name]
+}
+
+// HasManagementPermission reports whether the permission set grants any of the
+// staff permissions the admin role holds by default.
+func HasManagementPermission(permissions map[string]bool) bool {
+	for _, permission := range DefaultRoles[RoleAdmin] {
+		if permissions[permission] {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission reports whether the permission set loaded by the permission
+// middleware for the current request contains the given permission.
+func HasPermission(c echo.Context, permission string) bool {
+	permissions, ok := c.Get("Permissions").(map[string]bool)
+	if !ok {
+		return false
+	}
+	return permissions[permission]
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"gorm.io/gorm"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) role.RepositoryRoleInterface {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+func (r *RoleRepository) FindAllRoles() ([]*entities.RoleModels, error) {
+	var roles []*entities.RoleModels
+	if err := r.db.Preload("Permissions").Order("id ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *RoleRepository) GetRoleById(roleID uint64) (*entities.RoleModels, error) {
+	var roles entities.RoleModels
+	if err := r.db.Preload("Permissions").Where("id = ?", roleID).First(&roles).Error; err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+func (r *RoleRepository) GetRoleByName(name string) (*entities.RoleModels, error) {
+	var roles entities.RoleModels
+	if err := r.db.Preload("Permissions").Where("name = ?", name).First(&roles).Error; err != nil {
+		return nil, err
+	}
+	return &roles, nil
+}
+
+func (r *RoleRepository) CreateRole(newRole *entities.RoleModels) (*entities.RoleModels, error) {
+	if err := r.db.Create(newRole).Error; err != nil {
+		return nil, err
+	}
+	return newRole, nil
+}
+
+func (r *RoleRepository) UpdateRole(updatedRole *entities.RoleModels, permissions []entities.PermissionModels) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(updatedRole).Omit("Permissions").Updates(map[string]interface{}{
+			"description": updatedRole.Description,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Model(updatedRole).Association("Permissions").Replace(permissions)
+	})
+}
+
+func (r *RoleRepository) DeleteRole(roleID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		roles := &entities.RoleModels{ID: roleID}
+		if err := tx.Model(roles).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(roles).Error
+	})
+}
+
+func (r *RoleRepository) FindAllPermissions() ([]*entities.PermissionModels, error) {
+	var permissions []*entities.PermissionModels
+	if err := r.db.Order("name ASC").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *RoleRepository) GetPermissionsByNames(names []string) ([]entities.PermissionModels, error) {
+	var permissions []entities.PermissionModels
+	if err := r.db.Where("name IN ?", names).Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (r *RoleRepository) CreatePermission(permission *entities.PermissionModels) error {
+	return r.db.Create(permission).Error
+}
+
+func (r *RoleRepository) CountUsersByRole(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.UserModels{}).Where("role = ? AND deleted_at IS NULL", name).Count(&count).Error
+	return count, err
+}
+
+func (r *RoleRepository) UpdateUserRole(userID uint64, roleName string) error {
+	return r.db.Model(&entities.UserModels{}).Where("id = ?", userID).Update("role", roleName).Error
+}
+
+// FindUserIDsWithPermission lists the active users whose role grants the
+// permission.
+func (r *RoleRepository) FindUserIDsWithPermission(permission string) ([]uint64, error) {
+	var userIDs []uint64
+	if err := r.db.Table("users").
+		Joins("JOIN roles ON roles.name = users.role").
+		Joins("JOIN role_permissions ON role_permissions.role_id = roles.id").
+		Joins("JOIN permissions ON permissions.id = role_permissions.permission_id").
+		Where("permissions.name = ? AND users.deleted_at IS NULL", permission).
+		Distinct().
+		Pluck("users.id", &userIDs).Error; err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
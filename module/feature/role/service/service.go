@@ -0,0 +1,287 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const permissionCacheTTL = 5 * time.Minute
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type RoleService struct {
+	repo        role.RepositoryRoleInterface
+	userService users.ServiceUserInterface
+	cache       caching.CacheRepository
+}
+
+func NewRoleService(repo role.RepositoryRoleInterface, userService users.ServiceUserInterface, cache caching.CacheRepository) role.ServiceRoleInterface {
+	return &RoleService{
+		repo:        repo,
+		userService: userService,
+		cache:       cache,
+	}
+}
+
+// SeedDefaults creates the permission catalog and the default roles. Existing
+// roles keep whatever permissions admins gave them, except the admin role.
+func (s *RoleService) SeedDefaults() error {
+	names := make([]string, 0, len(role.Permissions))
+	for name := range role.Permissions {
+		names = append(names, name)
+	}
+	existing, err := s.repo.GetPermissionsByNames(names)
+	if err != nil {
+		return err
+	}
+	seeded := make(map[string]bool, len(existing))
+	for _, permission := range existing {
+		seeded[permission.Name] = true
+	}
+	for name, description := range role.Permissions {
+		if seeded[name] {
+			continue
+		}
+		if err := s.repo.CreatePermission(&entities.PermissionModels{Name: name, Description: description}); err != nil {
+			return err
+		}
+	}
+
+	for name, permissionNames := range role.DefaultRoles {
+		permissions, err := s.repo.GetPermissionsByNames(permissionNames)
+		if err != nil {
+			return err
+		}
+
+		existingRole, err := s.repo.GetRoleByName(name)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			newRole := &entities.RoleModels{
+				Name:        name,
+				Description: role.DefaultRoleDescription(name),
+				IsSystem:    name == role.RoleAdmin || name == role.RoleCustomer,
+				Permissions: permissions,
+			}
+			if _, err := s.repo.CreateRole(newRole); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if name == role.RoleAdmin {
+			if err := s.repo.UpdateRole(existingRole, permissions); err != nil {
+				return err
+			}
+			s.cachePermissions(name, permissions)
+		}
+	}
+	return nil
+}
+
+func (s *RoleService) GetRoles() ([]*entities.RoleModels, error) {
+	roles, err := s.repo.FindAllRoles()
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (s *RoleService) GetRoleById(roleID uint64) (*entities.RoleModels, error) {
+	result, err := s.repo.GetRoleById(roleID)
+	if err != nil {
+		return nil, errors.New("peran tidak ditemukan")
+	}
+	return result, nil
+}
+
+func (s *RoleService) GetPermissions() ([]*entities.PermissionModels, error) {
+	permissions, err := s.repo.FindAllPermissions()
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+func (s *RoleService) CreateRole(request *dto.CreateRoleRequest) (*entities.RoleModels, error) {
+	name := strings.ToLower(strings.TrimSpace(request.Name))
+	if !roleNamePattern.MatchString(name) {
+		return nil, errors.New("nama peran hanya boleh berisi huruf kecil, angka dan garis bawah")
+	}
+	if existingRole, _ := s.repo.GetRoleByName(name); existingRole != nil {
+		return nil, errors.New("nama peran sudah digunakan")
+	}
+
+	permissions, err := s.resolvePermissions(request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	newRole := &entities.RoleModels{
+		Name:        name,
+		Description: request.Description,
+		Permissions: permissions,
+	}
+	result, err := s.repo.CreateRole(newRole)
+	if err != nil {
+		return nil, errors.New("gagal menambahkan peran")
+	}
+	s.cachePermissions(name, permissions)
+
+	return result, nil
+}
+
+func (s *RoleService) UpdateRole(roleID uint64, request *dto.UpdateRoleRequest) (*entities.RoleModels, error) {
+	existingRole, err := s.repo.GetRoleById(roleID)
+	if err != nil {
+		return nil, errors.New("peran tidak ditemukan")
+	}
+	if existingRole.Name == role.RoleAdmin {
+		return nil, errors.New("peran admin tidak dapat diubah")
+	}
+
+	permissions, err := s.resolvePermissions(request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	existingRole.Description = request.Description
+	if err := s.repo.UpdateRole(existingRole, permissions); err != nil {
+		return nil, errors.New("gagal memperbarui peran")
+	}
+	existingRole.Permissions = permissions
+	s.cachePermissions(existingRole.Name, permissions)
+
+	return existingRole, nil
+}
+
+func (s *RoleService) DeleteRole(roleID uint64) error {
+	existingRole, err := s.repo.GetRoleById(roleID)
+	if err != nil {
+		return errors.New("peran tidak ditemukan")
+	}
+	if existingRole.IsSystem {
+		return errors.New("peran bawaan tidak dapat dihapus")
+	}
+
+	totalUsers, err := s.repo.CountUsersByRole(existingRole.Name)
+	if err != nil {
+		return err
+	}
+	if totalUsers > 0 {
+		return errors.New("peran masih digunakan oleh pengguna")
+	}
+
+	if err := s.repo.DeleteRole(existingRole.ID); err != nil {
+		return errors.New("gagal menghapus peran")
+	}
+	s.cachePermissions(existingRole.Name, nil)
+
+	return nil
+}
+
+func (s *RoleService) AssignUserRole(actorID, userID uint64, roleName string) error {
+	if actorID == userID {
+		return errors.New("tidak dapat mengubah peran akun sendiri")
+	}
+	if _, err := s.userService.GetUsersById(userID); err != nil {
+		return errors.New("pengguna tidak ditemukan")
+	}
+	if _, err := s.repo.GetRoleByName(roleName); err != nil {
+		return errors.New("peran tidak ditemukan")
+	}
+
+	if err := s.repo.UpdateUserRole(userID, roleName); err != nil {
+		return errors.New("gagal mengubah peran pengguna")
+	}
+	return nil
+}
+
+func (s *RoleService) GetUserIDsWithPermission(permission string) ([]uint64, error) {
+	userIDs, err := s.repo.FindUserIDsWithPermission(permission)
+	if err != nil {
+		return nil, errors.New("gagal mendapatkan pengguna dengan izin tersebut")
+	}
+	return userIDs, nil
+}
+
+// GetRolePermissions returns the permission set of a role, served from the cache
+// when possible since it is read on every protected request. An unknown role
+// has no permissions.
+func (s *RoleService) GetRolePermissions(roleName string) (map[string]bool, error) {
+	cacheKey := permissionCacheKey(roleName)
+	if cached, err := s.cache.Get(cacheKey); err == nil {
+		var names []string
+		if err := json.Unmarshal(cached, &names); err == nil {
+			return toPermissionSet(names), nil
+		}
+	}
+
+	existingRole, err := s.repo.GetRoleByName(roleName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.cachePermissions(roleName, existingRole.Permissions)
+	names := make([]string, 0, len(existingRole.Permissions))
+	for _, permission := range existingRole.Permissions {
+		names = append(names, permission.Name)
+	}
+	return toPermissionSet(names), nil
+}
+
+func (s *RoleService) resolvePermissions(names []string) ([]entities.PermissionModels, error) {
+	for _, name := range names {
+		if _, ok := role.Permissions[name]; !ok {
+			return nil, fmt.Errorf("izin %s tidak dikenal", name)
+		}
+	}
+	if len(names) == 0 {
+		return []entities.PermissionModels{}, nil
+	}
+	return s.repo.GetPermissionsByNames(names)
+}
+
+// cachePermissions overwrites the cached set instead of deleting it, which also
+// keeps other instances from serving a stale set for longer than the TTL.
+func (s *RoleService) cachePermissions(roleName string, permissions []entities.PermissionModels) {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.Name)
+	}
+	data, err := json.Marshal(names)
+	if err != nil {
+		return
+	}
+	if err := s.cache.Set(permissionCacheKey(roleName), data, permissionCacheTTL); err != nil {
+		logrus.Error("Gagal menyimpan cache izin peran: ", err)
+	}
+}
+
+func permissionCacheKey(roleName string) string {
+	return "role:permissions:" + roleName
+}
+
+func toPermissionSet(names []string) map[string]bool {
+	permissions := make(map[string]bool, len(names))
+	for _, name := range names {
+		permissions[name] = true
+	}
+	return permissions
+}
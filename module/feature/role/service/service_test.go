@@ -0,0 +1,271 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role/mocks"
+	userMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
+	mocks_caching "github.com/capstone-kelompok-7/backend-disappear/utils/caching/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupRoleService(t *testing.T) (*mocks.RepositoryRoleInterface, *userMocks.ServiceUserInterface, *mocks_caching.CacheRepository, role.ServiceRoleInterface) {
+	repo := mocks.NewRepositoryRoleInterface(t)
+	userService := userMocks.NewServiceUserInterface(t)
+	cache := mocks_caching.NewCacheRepository(t)
+	service := NewRoleService(repo, userService, cache)
+	return repo, userService, cache, service
+}
+
+func TestRoleService_GetRolePermissions(t *testing.T) {
+	t.Run("Success Case - Cache Hit", func(t *testing.T) {
+		_, _, cache, service := setupRoleService(t)
+		cache.On("Get", "role:permissions:warehouse").Return([]byte(`["orders.manage","products.stock"]`), nil)
+
+		result, err := service.GetRolePermissions(role.RoleWarehouse)
+
+		assert.NoError(t, err)
+		assert.True(t, result[role.PermissionManageOrders])
+		assert.True(t, result[role.PermissionManageStock])
+		assert.False(t, result[role.PermissionManageProducts])
+	})
+
+	t.Run("Success Case - Cache Miss", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		cache.On("Get", "role:permissions:content_editor").Return(nil, errors.New("redis: nil"))
+		repo.On("GetRoleByName", role.RoleContentEditor).Return(&entities.RoleModels{
+			Name:        role.RoleContentEditor,
+			Permissions: []entities.PermissionModels{{Name: role.PermissionManageArticles}},
+		}, nil)
+		cache.On("Set", "role:permissions:content_editor", []byte(`["articles.manage"]`), permissionCacheTTL).Return(nil)
+
+		result, err := service.GetRolePermissions(role.RoleContentEditor)
+
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]bool{role.PermissionManageArticles: true}, result)
+	})
+
+	t.Run("Success Case - Unknown Role", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		cache.On("Get", "role:permissions:ghost").Return(nil, errors.New("redis: nil"))
+		repo.On("GetRoleByName", "ghost").Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.GetRolePermissions("ghost")
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("Failed Case - Repository Error", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		cache.On("Get", "role:permissions:customer").Return(nil, errors.New("redis: nil"))
+		repo.On("GetRoleByName", role.RoleCustomer).Return(nil, errors.New("database error"))
+
+		result, err := service.GetRolePermissions(role.RoleCustomer)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		permissions := []entities.PermissionModels{{ID: 1, Name: role.PermissionManageVouchers}}
+		repo.On("GetRoleByName", "marketing").Return(nil, gorm.ErrRecordNotFound)
+		repo.On("GetPermissionsByNames", []string{role.PermissionManageVouchers}).Return(permissions, nil)
+		repo.On("CreateRole", mock.MatchedBy(func(r *entities.RoleModels) bool {
+			return r.Name == "marketing" && !r.IsSystem && len(r.Permissions) == 1
+		})).Return(&entities.RoleModels{ID: 5, Name: "marketing", Permissions: permissions}, nil)
+		cache.On("Set", "role:permissions:marketing", []byte(`["vouchers.manage"]`), permissionCacheTTL).Return(nil)
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: " Marketing ", Permissions: []string{role.PermissionManageVouchers}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(5), result.ID)
+	})
+
+	t.Run("Failed Case - Invalid Name", func(t *testing.T) {
+		_, _, _, service := setupRoleService(t)
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "tim gudang", Permissions: []string{}})
+
+		assert.EqualError(t, err, "nama peran hanya boleh berisi huruf kecil, angka dan garis bawah")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Failed Case - Name Taken", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleByName", role.RoleWarehouse).Return(&entities.RoleModels{ID: 4, Name: role.RoleWarehouse}, nil)
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: role.RoleWarehouse, Permissions: []string{}})
+
+		assert.EqualError(t, err, "nama peran sudah digunakan")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Failed Case - Unknown Permission", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleByName", "marketing").Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.CreateRole(&dto.CreateRoleRequest{Name: "marketing", Permissions: []string{"orders.delete"}})
+
+		assert.EqualError(t, err, "izin orders.delete tidak dikenal")
+		assert.Nil(t, result)
+	})
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		existing := &entities.RoleModels{ID: 4, Name: role.RoleWarehouse}
+		permissions := []entities.PermissionModels{{ID: 7, Name: role.PermissionManageStock}}
+		repo.On("GetRoleById", uint64(4)).Return(existing, nil)
+		repo.On("GetPermissionsByNames", []string{role.PermissionManageStock}).Return(permissions, nil)
+		repo.On("UpdateRole", existing, permissions).Return(nil)
+		cache.On("Set", "role:permissions:warehouse", []byte(`["products.stock"]`), permissionCacheTTL).Return(nil)
+
+		result, err := service.UpdateRole(4, &dto.UpdateRoleRequest{Description: "Stok saja", Permissions: []string{role.PermissionManageStock}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Stok saja", result.Description)
+		assert.Equal(t, permissions, result.Permissions)
+	})
+
+	t.Run("Failed Case - Admin Role", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleById", uint64(1)).Return(&entities.RoleModels{ID: 1, Name: role.RoleAdmin, IsSystem: true}, nil)
+
+		result, err := service.UpdateRole(1, &dto.UpdateRoleRequest{Permissions: []string{}})
+
+		assert.EqualError(t, err, "peran admin tidak dapat diubah")
+		assert.Nil(t, result)
+	})
+
+	t.Run("Failed Case - Not Found", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleById", uint64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		result, err := service.UpdateRole(9, &dto.UpdateRoleRequest{Permissions: []string{}})
+
+		assert.EqualError(t, err, "peran tidak ditemukan")
+		assert.Nil(t, result)
+	})
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		repo.On("GetRoleById", uint64(5)).Return(&entities.RoleModels{ID: 5, Name: "marketing"}, nil)
+		repo.On("CountUsersByRole", "marketing").Return(int64(0), nil)
+		repo.On("DeleteRole", uint64(5)).Return(nil)
+		cache.On("Set", "role:permissions:marketing", []byte(`[]`), permissionCacheTTL).Return(nil)
+
+		err := service.DeleteRole(5)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed Case - System Role", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleById", uint64(2)).Return(&entities.RoleModels{ID: 2, Name: role.RoleCustomer, IsSystem: true}, nil)
+
+		err := service.DeleteRole(2)
+
+		assert.EqualError(t, err, "peran bawaan tidak dapat dihapus")
+	})
+
+	t.Run("Failed Case - Role In Use", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("GetRoleById", uint64(4)).Return(&entities.RoleModels{ID: 4, Name: role.RoleWarehouse}, nil)
+		repo.On("CountUsersByRole", role.RoleWarehouse).Return(int64(3), nil)
+
+		err := service.DeleteRole(4)
+
+		assert.EqualError(t, err, "peran masih digunakan oleh pengguna")
+		repo.AssertNotCalled(t, "DeleteRole", mock.Anything)
+	})
+}
+
+func TestRoleService_AssignUserRole(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		repo, userService, _, service := setupRoleService(t)
+		userService.On("GetUsersById", uint64(2)).Return(&entities.UserModels{ID: 2, Role: role.RoleCustomer}, nil)
+		repo.On("GetRoleByName", role.RoleWarehouse).Return(&entities.RoleModels{ID: 4, Name: role.RoleWarehouse}, nil)
+		repo.On("UpdateUserRole", uint64(2), role.RoleWarehouse).Return(nil)
+
+		err := service.AssignUserRole(1, 2, role.RoleWarehouse)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Failed Case - Own Account", func(t *testing.T) {
+		_, _, _, service := setupRoleService(t)
+
+		err := service.AssignUserRole(1, 1, role.RoleCustomer)
+
+		assert.EqualError(t, err, "tidak dapat mengubah peran akun sendiri")
+	})
+
+	t.Run("Failed Case - Unknown Role", func(t *testing.T) {
+		repo, userService, _, service := setupRoleService(t)
+		userService.On("GetUsersById", uint64(2)).Return(&entities.UserModels{ID: 2}, nil)
+		repo.On("GetRoleByName", "ghost").Return(nil, gorm.ErrRecordNotFound)
+
+		err := service.AssignUserRole(1, 2, "ghost")
+
+		assert.EqualError(t, err, "peran tidak ditemukan")
+	})
+}
+
+func TestRoleService_SeedDefaults(t *testing.T) {
+	t.Run("Success Case - Existing Roles Keep Their Permissions", func(t *testing.T) {
+		repo, _, cache, service := setupRoleService(t)
+		catalog := make([]entities.PermissionModels, 0, len(role.Permissions))
+		for name := range role.Permissions {
+			catalog = append(catalog, entities.PermissionModels{Name: name})
+		}
+		repo.On("GetPermissionsByNames", mock.Anything).Return(catalog, nil)
+		for name := range role.DefaultRoles {
+			repo.On("GetRoleByName", name).Return(&entities.RoleModels{Name: name}, nil)
+		}
+		repo.On("UpdateRole", mock.MatchedBy(func(r *entities.RoleModels) bool {
+			return r.Name == role.RoleAdmin
+		}), catalog).Return(nil).Once()
+		cache.On("Set", "role:permissions:admin", mock.Anything, permissionCacheTTL).Return(nil)
+
+		err := service.SeedDefaults()
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "CreatePermission", mock.Anything)
+		repo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+}
+
+func TestRoleService_GetUserIDsWithPermission(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("FindUserIDsWithPermission", role.PermissionManageStock).Return([]uint64{2, 3}, nil).Once()
+
+		result, err := service.GetUserIDsWithPermission(role.PermissionManageStock)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{2, 3}, result)
+	})
+
+	t.Run("Failed Case - Repository Error", func(t *testing.T) {
+		repo, _, _, service := setupRoleService(t)
+		repo.On("FindUserIDsWithPermission", role.PermissionManageStock).Return(nil, errors.New("db error")).Once()
+
+		result, err := service.GetUserIDsWithPermission(role.PermissionManageStock)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "gagal mendapatkan pengguna dengan izin tersebut")
+	})
+}
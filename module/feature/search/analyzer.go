@@ -0,0 +1,190 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxTermLength = 50
+
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// stopwords are Indonesian function words that would match nearly every
+// document and say nothing about what it is about.
+var stopwords = map[string]bool{
+	"ada": true, "adalah": true, "agar": true, "akan": true, "aku": true, "anda": true,
+	"antara": true, "apa": true, "atau": true, "bagi": true, "bahwa": true, "banyak": true,
+	"bisa": true, "dalam": true, "dan": true, "dapat": true, "dari": true, "dengan": true,
+	"di": true, "dia": true, "hal": true, "hanya": true, "harus": true, "ia": true,
+	"ini": true, "itu": true, "jika": true, "juga": true, "kami": true, "kamu": true,
+	"karena": true, "ke": true, "kita": true, "lagi": true, "lebih": true, "maka": true,
+	"masih": true, "mereka": true, "namun": true, "oleh": true, "pada": true, "para": true,
+	"saat": true, "saja": true, "sangat": true, "saya": true, "secara": true, "sehingga": true,
+	"sejak": true, "seperti": true, "serta": true, "sudah": true, "tanpa": true, "telah": true,
+	"tentang": true, "tersebut": true, "tetapi": true, "tidak": true, "untuk": true, "yaitu": true,
+	"yang": true, "nya": true, "pun": true, "lah": true, "kah": true, "si": true,
+}
+
+var (
+	particles  = []string{"kah", "pun"}
+	possessive = []string{"nya", "ku", "mu"}
+	suffixes   = []string{"kan", "an"}
+)
+
+// Analyze turns text into index terms: lowercased words with markup, stopwords
+// and Indonesian affixes removed. Repeated words stay repeated so callers can
+// count them.
+func Analyze(text string) []string {
+	var terms []string
+	for _, word := range Words(text) {
+		if term := Term(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Words splits text on anything that is not a letter or a digit, so both
+// punctuation and reduplication such as "botol-botol" separate words.
+func Words(text string) []string {
+	return strings.FieldsFunc(StripTags(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Term is the index term for a single word, or "" when the word is too short,
+// too long or a stopword.
+func Term(word string) string {
+	word = strings.ToLower(word)
+	length := utf8.RuneCountInString(word)
+	if length < 2 || length > maxTermLength || stopwords[word] {
+		return ""
+	}
+	return Stem(word)
+}
+
+func StripTags(text string) string {
+	return tagPattern.ReplaceAllString(text, " ")
+}
+
+// Stem strips Indonesian affixes without a dictionary: the particles -kah and
+// -pun, then possessive pronouns, then up to two prefixes and finally one
+// derivational suffix. It over-stems some words, but queries go through the
+// same steps so both sides still meet on the same term.
+func Stem(word string) string {
+	if strings.IndexFunc(word, unicode.IsDigit) >= 0 {
+		return word
+	}
+
+	word = trimSuffix(word, particles, 3)
+	word = trimSuffix(word, possessive, 3)
+	for i := 0; i < 2; i++ {
+		stripped := trimPrefix(word)
+		if stripped == word {
+			break
+		}
+		word = stripped
+	}
+	return trimSuffix(word, suffixes, 4)
+}
+
+func trimSuffix(word string, candidates []string, minStem int) string {
+	for _, suffix := range candidates {
+		if strings.HasSuffix(word, suffix) && len(word)-len(suffix) >= minStem {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+// trimPrefix removes one prefix. The nasal prefixes swallow the first letter
+// of the root (menulis from tulis, memakai from pakai, menyapu from sapu), so
+// that letter is put back when a vowel follows. Native roots do not start with
+// two consonants, so a prefix that would leave one (kertas, memproduksi) is
+// part of the word.
+func trimPrefix(word string) string {
+	rules := []struct {
+		prefix  string
+		restore string
+	}{
+		{"meng", ""}, {"peng", ""},
+		{"meny", "s"}, {"peny", "s"},
+		{"mem", "p"}, {"pem", "p"},
+		{"men", "t"}, {"pen", "t"},
+		{"ber", ""}, {"ter", ""}, {"per", ""},
+		{"me", ""}, {"pe", ""},
+		{"di", ""}, {"ke", ""}, {"se", ""},
+	}
+	for _, rule := range rules {
+		if !strings.HasPrefix(word, rule.prefix) {
+			continue
+		}
+		root := strings.TrimPrefix(word, rule.prefix)
+		if len(root) < 3 {
+			continue
+		}
+		first := rune(root[0])
+		if (rule.prefix == "me" || rule.prefix == "pe") && !strings.ContainsRune("lrwymn", first) {
+			continue
+		}
+		if !isVowel(first) && !isVowel(rune(root[1])) {
+			continue
+		}
+		if rule.restore != "" && isVowel(first) {
+			root = rule.restore + root
+		}
+		return root
+	}
+	return word
+}
+
+func isVowel(r rune) bool {
+	return strings.ContainsRune("aiueo", r)
+}
+
+// MaxTypos is how many edits a query term may be away from an index term and
+// still match it. Short terms must match exactly.
+func MaxTypos(term string) int {
+	switch length := utf8.RuneCountInString(term); {
+	case length < 4:
+		return 0
+	case length < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Distance is the Levenshtein edit distance between two terms.
+func Distance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+func min(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"menulis", "tulis"},
+		{"memakai", "pakai"},
+		{"menyapu", "sapu"},
+		{"mengolah", "olah"},
+		{"digunakan", "guna"},
+		{"peralatan", "alat"},
+		{"botolnya", "botol"},
+		{"bisakah", "bisa"},
+		{"kertas", "kertas"},
+		{"memproduksi", "memproduksi"},
+		{"plastik500", "plastik500"},
+	}
+	for _, test := range tests {
+		t.Run(test.word, func(t *testing.T) {
+			assert.Equal(t, test.expected, Stem(test.word))
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"Stopwords and markup are dropped", "<p>Botol yang <b>digunakan</b> untuk minum</p>", []string{"botol", "guna", "minum"}},
+		{"Reduplication splits words", "botol-botol", []string{"botol", "botol"}},
+		{"Single letters are dropped", "a b botol", []string{"botol"}},
+		{"Empty text", "", nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Analyze(test.text))
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"botol", "botol", 0},
+		{"botol", "botl", 1},
+		{"botol", "bottol", 1},
+		{"botol", "butol", 1},
+		{"kertas", "ketras", 2},
+		{"", "botol", 5},
+		{"kopi", "", 4},
+		{"café", "cafe", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.a+"-"+test.b, func(t *testing.T) {
+			assert.Equal(t, test.expected, Distance(test.a, test.b))
+			assert.Equal(t, test.expected, Distance(test.b, test.a))
+		})
+	}
+}
+
+func TestMaxTypos(t *testing.T) {
+	tests := []struct {
+		term     string
+		expected int
+	}{
+		{"tas", 0},
+		{"kopi", 1},
+		{"plastik", 1},
+		{"kemasanku", 2},
+		{"éééé", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.term, func(t *testing.T) {
+			assert.Equal(t, test.expected, MaxTypos(test.term))
+		})
+	}
+}
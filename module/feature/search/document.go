@@ -0,0 +1,45 @@
+package search
+
+const (
+	DocumentProduct = "product"
+	DocumentArticle = "article"
+)
+
+// Field boosts: a word in the title counts three times, a category twice and
+// a word in the description or content once.
+const (
+	TitleWeight   = 3.0
+	KeywordWeight = 2.0
+	BodyWeight    = 1.0
+)
+
+// Document is what a feature hands to the index. Keywords are short labels
+// such as category names that rank above the body but are not shown.
+type Document struct {
+	Type     string
+	ID       uint64
+	Title    string
+	Body     string
+	Keywords []string
+}
+
+// Posting is an index entry joined with the length of its document, which is
+// all ranking needs.
+type Posting struct {
+	DocumentID uint64
+	Term       string
+	Weight     float64
+	Length     float64
+}
+
+type Result struct {
+	Type    string
+	ID      uint64
+	Title   string
+	Snippet string
+	Score   float64
+}
+
+func IsValidDocumentType(documentType string) bool {
+	return documentType == DocumentProduct || documentType == DocumentArticle
+}
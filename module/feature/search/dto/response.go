@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+
+type SearchResultFormatter struct {
+	Type    string  `json:"type"`
+	ID      uint64  `json:"id"`
+	Title   string  `json:"title"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+func FormatSearchResult(result *search.Result) *SearchResultFormatter {
+	return &SearchResultFormatter{
+		Type:    result.Type,
+		ID:      result.ID,
+		Title:   result.Title,
+		Snippet: result.Snippet,
+		Score:   result.Score,
+	}
+}
+
+func FormatSearchResults(results []*search.Result) []*SearchResultFormatter {
+	resultFormatter := make([]*SearchResultFormatter, 0, len(results))
+
+	for _, result := range results {
+		resultFormatter = append(resultFormatter, FormatSearchResult(result))
+	}
+
+	return resultFormatter
+}
+
+type ReindexFormatter struct {
+	Products int `json:"products"`
+	Articles int `json:"articles"`
+}
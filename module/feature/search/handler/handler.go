@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/article"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+)
+
+type SearchHandler struct {
+	service        search.ServiceSearchInterface
+	productService product.ServiceProductInterface
+	articleService article.ServiceArticleInterface
+}
+
+func NewSearchHandler(service search.ServiceSearchInterface, productService product.ServiceProductInterface, articleService article.ServiceArticleInterface) search.HandlerSearchInterface {
+	return &SearchHandler{
+		service:        service,
+		productService: productService,
+		articleService: articleService,
+	}
+}
+
+func (h *SearchHandler) Search() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		perPage := 8
+
+		results, totalItems, err := h.service.Search(c.QueryParam("q"), c.QueryParam("type"), page, perPage)
+		if err != nil {
+			switch err.Error() {
+			case "kata kunci pencarian wajib diisi", "jenis pencarian tidak valid":
+				return response.SendBadRequestResponse(c, "Gagal melakukan pencarian: "+err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal melakukan pencarian: "+err.Error())
+		}
+
+		currentPage, totalPages := h.service.CalculatePaginationValues(page, int(totalItems), perPage)
+		nextPage := h.service.GetNextPage(currentPage, totalPages)
+		prevPage := h.service.GetPrevPage(currentPage)
+
+		return response.SendPaginationResponse(c, dto.FormatSearchResults(results), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil melakukan pencarian")
+	}
+}
+
+func (h *SearchHandler) Reindex() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		products, err := h.productService.ReindexSearch()
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal membangun ulang indeks pencarian: "+err.Error())
+		}
+		articles, err := h.articleService.ReindexSearch()
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal membangun ulang indeks pencarian: "+err.Error())
+		}
+		return response.SendSuccessResponse(c, "Berhasil membangun ulang indeks pencarian", dto.ReindexFormatter{
+			Products: products,
+			Articles: articles,
+		})
+	}
+}
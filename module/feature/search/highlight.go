@@ -0,0 +1,103 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	HighlightOpen  = "<mark>"
+	HighlightClose = "</mark>"
+)
+
+// segment is a run of text that is either a whole word or the gap between two
+// words, so highlighting can keep the original spacing and punctuation.
+type segment struct {
+	text   string
+	isWord bool
+}
+
+func segments(text string) []segment {
+	var result []segment
+	var current strings.Builder
+	inWord := false
+	for _, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if current.Len() > 0 && isWord != inWord {
+			result = append(result, segment{text: current.String(), isWord: inWord})
+			current.Reset()
+		}
+		inWord = isWord
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		result = append(result, segment{text: current.String(), isWord: inWord})
+	}
+	return result
+}
+
+// Highlight escapes text for HTML and wraps every word whose term is in terms
+// in a mark tag.
+func Highlight(text string, terms map[string]bool) string {
+	return render(segments(collapse(text)), terms)
+}
+
+// Snippet is a highlighted excerpt of about size words starting a little
+// before the first matching word. Text without a match is cut from the start.
+func Snippet(text string, terms map[string]bool, size int) string {
+	parts := segments(collapse(text))
+
+	var words []int
+	first := -1
+	for i, part := range parts {
+		if !part.isWord {
+			continue
+		}
+		if first < 0 && terms[Term(part.text)] {
+			first = len(words)
+		}
+		words = append(words, i)
+	}
+	if len(words) == 0 {
+		return ""
+	}
+
+	start := 0
+	if first > size/4 {
+		start = first - size/4
+	}
+	end := start + size
+	if end > len(words) {
+		end = len(words)
+	}
+
+	last := len(parts)
+	if end < len(words) {
+		last = words[end-1] + 1
+	}
+	snippet := render(parts[words[start]:last], terms)
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(words) {
+		snippet += "..."
+	}
+	return snippet
+}
+
+func render(parts []segment, terms map[string]bool) string {
+	var builder strings.Builder
+	for _, part := range parts {
+		if part.isWord && terms[Term(part.text)] {
+			builder.WriteString(HighlightOpen + html.EscapeString(part.text) + HighlightClose)
+			continue
+		}
+		builder.WriteString(html.EscapeString(part.text))
+	}
+	return builder.String()
+}
+
+func collapse(text string) string {
+	return strings.Join(strings.Fields(StripTags(text)), " ")
+}
@@ -0,0 +1,47 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	terms := map[string]bool{"botol": true, "guna": true}
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Matches stemmed words", "Botol yang digunakan", "<mark>Botol</mark> yang <mark>digunakan</mark>"},
+		{"Keeps punctuation", "botol, gelas.", "<mark>botol</mark>, gelas."},
+		{"Escapes HTML", "botol & <script>gelas</script>", "<mark>botol</mark> &amp; gelas"},
+		{"Collapses whitespace", "  botol \n\t gelas ", "<mark>botol</mark> gelas"},
+		{"No match", "gelas kaca", "gelas kaca"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Highlight(test.text, terms))
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	terms := map[string]bool{"botol": true}
+	tests := []struct {
+		name     string
+		text     string
+		size     int
+		expected string
+	}{
+		{"Short text is kept whole", "satu botol dua", 8, "satu <mark>botol</mark> dua"},
+		{"Cut around first match", "satu dua tiga empat lima enam botol tujuh delapan sembilan sepuluh", 4, "...enam <mark>botol</mark> tujuh delapan..."},
+		{"No match cuts from start", "satu dua tiga empat lima", 3, "satu dua tiga..."},
+		{"Empty text", "", 3, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Snippet(test.text, terms, test.size))
+		})
+	}
+}
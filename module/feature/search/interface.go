@@ -0,0 +1,31 @@
+package search
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/labstack/echo/v4"
+)
+
+type RepositorySearchInterface interface {
+	SaveDocument(document *entities.SearchDocumentModels, terms []*entities.SearchTermModels) error
+	DeleteDocument(documentType string, sourceID uint64) error
+	ClearDocuments(documentType string) error
+	FindPostings(terms []string, documentType string) ([]*Posting, error)
+	FindSimilarTerms(term string, minLength, maxLength int) ([]string, error)
+	GetIndexStats(documentType string) (int64, float64, error)
+	FindDocumentsByIDs(ids []uint64) ([]*entities.SearchDocumentModels, error)
+}
+
+type ServiceSearchInterface interface {
+	Index(document *Document) error
+	Remove(documentType string, id uint64) error
+	Clear(documentType string) error
+	Search(query, documentType string, page, perPage int) ([]*Result, int64, error)
+	CalculatePaginationValues(page int, totalItems int, perPage int) (int, int)
+	GetNextPage(currentPage, totalPages int) int
+	GetPrevPage(currentPage int) int
+}
+
+type HandlerSearchInterface interface {
+	Search() echo.HandlerFunc
+	Reindex() echo.HandlerFunc
+}
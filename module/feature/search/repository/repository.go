@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"gorm.io/gorm"
+)
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) search.RepositorySearchInterface {
+	return &SearchRepository{
+		db: db,
+	}
+}
+
+// SaveDocument replaces the document and all of its postings in one
+// transaction, so a search never sees half of an update.
+func (r *SearchRepository) SaveDocument(document *entities.SearchDocumentModels, terms []*entities.SearchTermModels) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing entities.SearchDocumentModels
+		err := tx.Where("type = ? AND source_id = ?", document.Type, document.SourceID).First(&existing).Error
+		switch {
+		case err == nil:
+			document.ID = existing.ID
+		case err != gorm.ErrRecordNotFound:
+			return err
+		}
+
+		document.UpdatedAt = time.Now()
+		if err := tx.Save(document).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("document_id = ?", document.ID).Delete(&entities.SearchTermModels{}).Error; err != nil {
+			return err
+		}
+		if len(terms) == 0 {
+			return nil
+		}
+		for _, term := range terms {
+			term.DocumentID = document.ID
+		}
+		return tx.CreateInBatches(terms, 500).Error
+	})
+}
+
+func (r *SearchRepository) DeleteDocument(documentType string, sourceID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		documentIDs := tx.Model(&entities.SearchDocumentModels{}).Select("id").Where("type = ? AND source_id = ?", documentType, sourceID)
+		if err := tx.Where("document_id IN (?)", documentIDs).Delete(&entities.SearchTermModels{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("type = ? AND source_id = ?", documentType, sourceID).Delete(&entities.SearchDocumentModels{}).Error
+	})
+}
+
+func (r *SearchRepository) ClearDocuments(documentType string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		documentIDs := tx.Model(&entities.SearchDocumentModels{}).Select("id").Where("type = ?", documentType)
+		if err := tx.Where("document_id IN (?)", documentIDs).Delete(&entities.SearchTermModels{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("type = ?", documentType).Delete(&entities.SearchDocumentModels{}).Error
+	})
+}
+
+// FindPostings loads every posting for the terms, limited to one document
+// type unless documentType is empty.
+func (r *SearchRepository) FindPostings(terms []string, documentType string) ([]*search.Posting, error) {
+	var postings []*search.Posting
+	query := r.db.Table("search_terms").
+		Select("search_terms.document_id, search_terms.term, search_terms.weight, search_documents.length").
+		Joins("JOIN search_documents ON search_documents.id = search_terms.document_id").
+		Where("search_terms.term IN ?", terms)
+	if documentType != "" {
+		query = query.Where("search_documents.type = ?", documentType)
+	}
+	if err := query.Scan(&postings).Error; err != nil {
+		return nil, err
+	}
+	return postings, nil
+}
+
+// FindSimilarTerms lists indexed terms that could be a typo of term: same
+// first letter and a length within the allowed number of edits.
+func (r *SearchRepository) FindSimilarTerms(term string, minLength, maxLength int) ([]string, error) {
+	var terms []string
+	if err := r.db.Model(&entities.SearchTermModels{}).
+		Distinct("term").
+		Where("term LIKE ? AND CHAR_LENGTH(term) BETWEEN ? AND ?", string([]rune(term)[:1])+"%", minLength, maxLength).
+		Pluck("term", &terms).Error; err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// GetIndexStats returns how many documents are indexed and their average
+// length, over one document type unless documentType is empty.
+func (r *SearchRepository) GetIndexStats(documentType string) (int64, float64, error) {
+	var stats struct {
+		Total         int64
+		AverageLength float64
+	}
+	query := r.db.Model(&entities.SearchDocumentModels{}).Select("COUNT(*) AS total, COALESCE(AVG(length), 0) AS average_length")
+	if documentType != "" {
+		query = query.Where("type = ?", documentType)
+	}
+	if err := query.Scan(&stats).Error; err != nil {
+		return 0, 0, err
+	}
+	return stats.Total, stats.AverageLength, nil
+}
+
+func (r *SearchRepository) FindDocumentsByIDs(ids []uint64) ([]*entities.SearchDocumentModels, error) {
+	var documents []*entities.SearchDocumentModels
+	if err := r.db.Where("id IN ?", ids).Find(&documents).Error; err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
@@ -0,0 +1,290 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+)
+
+// BM25 parameters: k1 limits how much repeating a word keeps helping and b how
+// strongly long documents are penalised.
+const (
+	bm25K1       = 1.2
+	bm25B        = 0.75
+	snippetWords = 30
+)
+
+type SearchService struct {
+	repo search.RepositorySearchInterface
+}
+
+func NewSearchService(repo search.RepositorySearchInterface) search.ServiceSearchInterface {
+	return &SearchService{
+		repo: repo,
+	}
+}
+
+// Index adds the document or replaces what was indexed for it before.
+func (s *SearchService) Index(document *search.Document) error {
+	if !search.IsValidDocumentType(document.Type) {
+		return errors.New("jenis pencarian tidak valid")
+	}
+
+	weights := map[string]float64{}
+	var length float64
+	add := func(text string, weight float64) {
+		for _, term := range search.Analyze(text) {
+			weights[term] += weight
+			length += weight
+		}
+	}
+	add(document.Title, search.TitleWeight)
+	for _, keyword := range document.Keywords {
+		add(keyword, search.KeywordWeight)
+	}
+	add(document.Body, search.BodyWeight)
+
+	terms := make([]*entities.SearchTermModels, 0, len(weights))
+	for term, weight := range weights {
+		terms = append(terms, &entities.SearchTermModels{Term: term, Weight: weight})
+	}
+	sort.Slice(terms, func(i, j int) bool { return terms[i].Term < terms[j].Term })
+
+	model := &entities.SearchDocumentModels{
+		Type:     document.Type,
+		SourceID: document.ID,
+		Title:    document.Title,
+		Body:     document.Body,
+		Length:   length,
+	}
+	if err := s.repo.SaveDocument(model, terms); err != nil {
+		return errors.New("gagal memperbarui indeks pencarian")
+	}
+	return nil
+}
+
+func (s *SearchService) Remove(documentType string, id uint64) error {
+	if err := s.repo.DeleteDocument(documentType, id); err != nil {
+		return errors.New("gagal memperbarui indeks pencarian")
+	}
+	return nil
+}
+
+func (s *SearchService) Clear(documentType string) error {
+	if err := s.repo.ClearDocuments(documentType); err != nil {
+		return errors.New("gagal memperbarui indeks pencarian")
+	}
+	return nil
+}
+
+// Search ranks documents with BM25 over the field-weighted postings. Each
+// query term may also match indexed terms a few typos away, scored lower than
+// an exact match, and a document only counts the best match per query term.
+func (s *SearchService) Search(query, documentType string, page, perPage int) ([]*search.Result, int64, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.New("kata kunci pencarian wajib diisi")
+	}
+	if documentType != "" && !search.IsValidDocumentType(documentType) {
+		return nil, 0, errors.New("jenis pencarian tidak valid")
+	}
+
+	queryTerms := unique(search.Analyze(query))
+	if len(queryTerms) == 0 {
+		return nil, 0, nil
+	}
+
+	expansions, err := s.expand(queryTerms)
+	if err != nil {
+		return nil, 0, errors.New("gagal melakukan pencarian")
+	}
+	highlights := map[string]bool{}
+	var indexTerms []string
+	for _, variants := range expansions {
+		for term := range variants {
+			if !highlights[term] {
+				highlights[term] = true
+				indexTerms = append(indexTerms, term)
+			}
+		}
+	}
+	sort.Strings(indexTerms)
+
+	postings, err := s.repo.FindPostings(indexTerms, documentType)
+	if err != nil {
+		return nil, 0, errors.New("gagal melakukan pencarian")
+	}
+	totalDocuments, averageLength, err := s.repo.GetIndexStats(documentType)
+	if err != nil {
+		return nil, 0, errors.New("gagal melakukan pencarian")
+	}
+
+	ranked := rank(queryTerms, expansions, postings, totalDocuments, averageLength)
+	totalItems := int64(len(ranked))
+
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * perPage
+	if start >= len(ranked) {
+		return []*search.Result{}, totalItems, nil
+	}
+	end := start + perPage
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	ranked = ranked[start:end]
+
+	ids := make([]uint64, len(ranked))
+	for i, hit := range ranked {
+		ids[i] = hit.documentID
+	}
+	documents, err := s.repo.FindDocumentsByIDs(ids)
+	if err != nil {
+		return nil, 0, errors.New("gagal melakukan pencarian")
+	}
+	byID := make(map[uint64]*entities.SearchDocumentModels, len(documents))
+	for _, document := range documents {
+		byID[document.ID] = document
+	}
+
+	results := make([]*search.Result, 0, len(ranked))
+	for _, hit := range ranked {
+		document, ok := byID[hit.documentID]
+		if !ok {
+			continue
+		}
+		results = append(results, &search.Result{
+			Type:    document.Type,
+			ID:      document.SourceID,
+			Title:   search.Highlight(document.Title, highlights),
+			Snippet: search.Snippet(document.Body, highlights, snippetWords),
+			Score:   hit.score,
+		})
+	}
+	return results, totalItems, nil
+}
+
+// expand maps every query term to the indexed terms it matches and how much
+// each match is worth: 1 for the term itself, less the more edits away.
+func (s *SearchService) expand(queryTerms []string) (map[string]map[string]float64, error) {
+	expansions := make(map[string]map[string]float64, len(queryTerms))
+	for _, term := range queryTerms {
+		variants := map[string]float64{term: 1}
+		expansions[term] = variants
+
+		typos := search.MaxTypos(term)
+		if typos == 0 {
+			continue
+		}
+		length := utf8.RuneCountInString(term)
+		candidates, err := s.repo.FindSimilarTerms(term, length-typos, length+typos)
+		if err != nil {
+			return nil, err
+		}
+		for _, candidate := range candidates {
+			if candidate == term {
+				continue
+			}
+			if distance := search.Distance(term, candidate); distance <= typos {
+				variants[candidate] = 1 / float64(1+distance)
+			}
+		}
+	}
+	return expansions, nil
+}
+
+type hit struct {
+	documentID uint64
+	score      float64
+}
+
+func rank(queryTerms []string, expansions map[string]map[string]float64, postings []*search.Posting, totalDocuments int64, averageLength float64) []hit {
+	frequency := map[string]int{}
+	byDocument := map[uint64]map[string]*search.Posting{}
+	for _, posting := range postings {
+		frequency[posting.Term]++
+		if byDocument[posting.DocumentID] == nil {
+			byDocument[posting.DocumentID] = map[string]*search.Posting{}
+		}
+		byDocument[posting.DocumentID][posting.Term] = posting
+	}
+	if averageLength <= 0 {
+		averageLength = 1
+	}
+
+	hits := make([]hit, 0, len(byDocument))
+	for documentID, terms := range byDocument {
+		var score float64
+		for _, queryTerm := range queryTerms {
+			var best float64
+			for term, factor := range expansions[queryTerm] {
+				posting, ok := terms[term]
+				if !ok {
+					continue
+				}
+				documentFrequency := float64(frequency[term])
+				idf := math.Log(1 + (float64(totalDocuments)-documentFrequency+0.5)/(documentFrequency+0.5))
+				norm := bm25K1 * (1 - bm25B + bm25B*posting.Length/averageLength)
+				if value := factor * idf * posting.Weight * (bm25K1 + 1) / (posting.Weight + norm); value > best {
+					best = value
+				}
+			}
+			score += best
+		}
+		hits = append(hits, hit{documentID: documentID, score: score})
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].documentID < hits[j].documentID
+	})
+	return hits
+}
+
+func unique(terms []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, term := range terms {
+		if !seen[term] {
+			seen[term] = true
+			result = append(result, term)
+		}
+	}
+	return result
+}
+
+func (s *SearchService) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
+	pageInt := page
+	if pageInt <= 0 {
+		pageInt = 1
+	}
+
+	totalPages := int(math.Ceil(float64(totalItems) / float64(perPage)))
+
+	if pageInt > totalPages {
+		pageInt = totalPages
+	}
+
+	return pageInt, totalPages
+}
+
+func (s *SearchService) GetNextPage(currentPage, totalPages int) int {
+	if currentPage < totalPages {
+		return currentPage + 1
+	}
+	return totalPages
+}
+
+func (s *SearchService) GetPrevPage(currentPage int) int {
+	if currentPage > 1 {
+		return currentPage - 1
+	}
+	return 1
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSearchService_Index(t *testing.T) {
+	t.Run("Success case - Terms are stemmed and weighted by field", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		document := &search.Document{
+			Type:     search.DocumentProduct,
+			ID:       4,
+			Title:    "Botol Minum",
+			Body:     "Botol yang digunakan untuk minuman",
+			Keywords: []string{"Peralatan Makan"},
+		}
+
+		repo.On("SaveDocument", mock.MatchedBy(func(document *entities.SearchDocumentModels) bool {
+			return document.Type == search.DocumentProduct && document.SourceID == 4 && document.Length == 13
+		}), mock.MatchedBy(func(terms []*entities.SearchTermModels) bool {
+			weights := map[string]float64{}
+			for _, term := range terms {
+				weights[term.Term] = term.Weight
+			}
+			return len(terms) == 5 && weights["botol"] == 4 && weights["minum"] == 4 &&
+				weights["guna"] == 1 && weights["alat"] == 2 && weights["makan"] == 2 && weights["yang"] == 0
+		})).Return(nil).Once()
+
+		err := service.Index(document)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Invalid Document Type", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+
+		err := service.Index(&search.Document{Type: "voucher", ID: 1, Title: "Diskon"})
+
+		assert.EqualError(t, err, "jenis pencarian tidak valid")
+	})
+
+	t.Run("Failed case - Save Error", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		repo.On("SaveDocument", mock.Anything, mock.Anything).Return(errors.New("database error")).Once()
+
+		err := service.Index(&search.Document{Type: search.DocumentArticle, ID: 1, Title: "Daur Ulang"})
+
+		assert.EqualError(t, err, "gagal memperbarui indeks pencarian")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestSearchService_Remove(t *testing.T) {
+	repo := mocks.NewRepositorySearchInterface(t)
+	service := NewSearchService(repo)
+
+	t.Run("Success case - Document Removed", func(t *testing.T) {
+		repo.On("DeleteDocument", search.DocumentArticle, uint64(3)).Return(nil).Once()
+
+		err := service.Remove(search.DocumentArticle, 3)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed case - Delete Error", func(t *testing.T) {
+		repo.On("DeleteDocument", search.DocumentArticle, uint64(3)).Return(errors.New("database error")).Once()
+
+		err := service.Remove(search.DocumentArticle, 3)
+
+		assert.EqualError(t, err, "gagal memperbarui indeks pencarian")
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestSearchService_Search(t *testing.T) {
+	documents := []*entities.SearchDocumentModels{
+		{ID: 1, Type: search.DocumentProduct, SourceID: 10, Title: "Botol Minum", Body: "Botol stainless untuk minum setiap hari."},
+		{ID: 2, Type: search.DocumentArticle, SourceID: 20, Title: "Mengurangi Sampah", Body: "Bawa botol sendiri supaya tidak membeli air kemasan."},
+	}
+
+	t.Run("Success case - Title match ranks above body match", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		repo.On("FindSimilarTerms", "botol", 4, 6).Return([]string{"botol"}, nil).Once()
+		repo.On("FindPostings", []string{"botol"}, "").Return([]*search.Posting{
+			{DocumentID: 1, Term: "botol", Weight: 4, Length: 10},
+			{DocumentID: 2, Term: "botol", Weight: 1, Length: 12},
+		}, nil).Once()
+		repo.On("GetIndexStats", "").Return(int64(5), 11.0, nil).Once()
+		repo.On("FindDocumentsByIDs", []uint64{1, 2}).Return(documents, nil).Once()
+
+		results, totalItems, err := service.Search("botol", "", 1, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), totalItems)
+		assert.Len(t, results, 2)
+		assert.Equal(t, uint64(10), results[0].ID)
+		assert.Equal(t, search.DocumentProduct, results[0].Type)
+		assert.Greater(t, results[0].Score, results[1].Score)
+		assert.Equal(t, "<mark>Botol</mark> Minum", results[0].Title)
+		assert.Equal(t, "Bawa <mark>botol</mark> sendiri supaya tidak membeli air kemasan.", results[1].Snippet)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Typo still matches", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		repo.On("FindSimilarTerms", "botl", 3, 5).Return([]string{"botol", "bantal"}, nil).Once()
+		repo.On("FindPostings", []string{"botl", "botol"}, search.DocumentProduct).Return([]*search.Posting{
+			{DocumentID: 1, Term: "botol", Weight: 4, Length: 10},
+		}, nil).Once()
+		repo.On("GetIndexStats", search.DocumentProduct).Return(int64(3), 10.0, nil).Once()
+		repo.On("FindDocumentsByIDs", []uint64{1}).Return(documents[:1], nil).Once()
+
+		results, totalItems, err := service.Search("botl", search.DocumentProduct, 1, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), totalItems)
+		assert.Equal(t, "<mark>Botol</mark> Minum", results[0].Title)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Page past the last result", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		repo.On("FindSimilarTerms", "botol", 4, 6).Return(nil, nil).Once()
+		repo.On("FindPostings", []string{"botol"}, "").Return([]*search.Posting{
+			{DocumentID: 1, Term: "botol", Weight: 4, Length: 10},
+		}, nil).Once()
+		repo.On("GetIndexStats", "").Return(int64(1), 10.0, nil).Once()
+
+		results, totalItems, err := service.Search("botol", "", 3, 8)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Equal(t, int64(1), totalItems)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success case - Only stopwords", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+
+		results, totalItems, err := service.Search("yang dan", "", 1, 8)
+
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+		assert.Zero(t, totalItems)
+	})
+
+	t.Run("Failed case - Empty Query", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+
+		_, _, err := service.Search("  ", "", 1, 8)
+
+		assert.EqualError(t, err, "kata kunci pencarian wajib diisi")
+	})
+
+	t.Run("Failed case - Invalid Type", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+
+		_, _, err := service.Search("botol", "voucher", 1, 8)
+
+		assert.EqualError(t, err, "jenis pencarian tidak valid")
+	})
+
+	t.Run("Failed case - Postings Error", func(t *testing.T) {
+		repo := mocks.NewRepositorySearchInterface(t)
+		service := NewSearchService(repo)
+		repo.On("FindSimilarTerms", "botol", 4, 6).Return(nil, nil).Once()
+		repo.On("FindPostings", []string{"botol"}, "").Return(nil, errors.New("database error")).Once()
+
+		_, _, err := service.Search("botol", "", 1, 8)
+
+		assert.EqualError(t, err, "gagal melakukan pencarian")
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,12 @@
+package dto
+
+type ShippingQuoteRequest struct {
+	AddressID uint64              `json:"address_id" validate:"required"`
+	Courier   string              `json:"courier"`
+	Items     []ShippingQuoteItem `json:"items" validate:"required,dive"`
+}
+
+type ShippingQuoteItem struct {
+	ProductID uint64 `json:"product_id" validate:"required"`
+	Quantity  uint64 `json:"quantity" validate:"required"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+
+type ShippingRateResponse struct {
+	Courier     string `json:"courier"`
+	Service     string `json:"service"`
+	Description string `json:"description"`
+	Cost        uint64 `json:"cost"`
+	Etd         string `json:"etd"`
+}
+
+func FormatShippingRate(rate shippingrate.Rate) *ShippingRateResponse {
+	return &ShippingRateResponse{
+		Courier:     rate.Courier,
+		Service:     rate.Service,
+		Description: rate.Description,
+		Cost:        rate.Cost,
+		Etd:         rate.Etd,
+	}
+}
+
+func FormatterShippingRate(rates []shippingrate.Rate) []*ShippingRateResponse {
+	rateFormatters := make([]*ShippingRateResponse, 0)
+
+	for _, rate := range rates {
+		rateFormatters = append(rateFormatters, FormatShippingRate(rate))
+	}
+
+	return rateFormatters
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+)
+
+type ShippingHandler struct {
+	service shipping.ServiceShippingInterface
+}
+
+func NewShippingHandler(service shipping.ServiceShippingInterface) shipping.HandlerShippingInterface {
+	return &ShippingHandler{
+		service: service,
+	}
+}
+
+func (h *ShippingHandler) GetQuote() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		req := new(dto.ShippingQuoteRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		rates, err := h.service.GetQuote(currentUser.ID, req)
+		if err != nil {
+			if err.Error() == "alamat tidak ditemukan" || err.Error() == "produk tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Gagal menghitung ongkos kirim: "+err.Error())
+		}
+
+		return response.SendSuccessResponse(c, "Berhasil mendapatkan ongkos kirim", dto.FormatterShippingRate(rates))
+	}
+}
@@ -0,0 +1,16 @@
+package shipping
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+	"github.com/labstack/echo/v4"
+)
+
+type ServiceShippingInterface interface {
+	GetQuote(userID uint64, request *dto.ShippingQuoteRequest) ([]shippingrate.Rate, error)
+	CalculateFee(destination string, weight uint64, courier, service string) (*shippingrate.Rate, error)
+}
+
+type HandlerShippingInterface interface {
+	GetQuote() echo.HandlerFunc
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/address"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+	"strings"
+)
+
+type ShippingService struct {
+	rateProvider   shippingrate.RateProvider
+	originCity     string
+	addressService address.ServiceAddressInterface
+	productService product.ServiceProductInterface
+}
+
+func NewShippingService(
+	rateProvider shippingrate.RateProvider,
+	originCity string,
+	addressService address.ServiceAddressInterface,
+	productService product.ServiceProductInterface,
+) shipping.ServiceShippingInterface {
+	return &ShippingService{
+		rateProvider:   rateProvider,
+		originCity:     originCity,
+		addressService: addressService,
+		productService: productService,
+	}
+}
+
+func (s *ShippingService) GetQuote(userID uint64, request *dto.ShippingQuoteRequest) ([]shippingrate.Rate, error) {
+	addresses, err := s.addressService.GetAddressByID(request.AddressID)
+	if err != nil || addresses.UserID != userID {
+		return nil, errors.New("alamat tidak ditemukan")
+	}
+
+	var totalWeight uint64
+	for _, item := range request.Items {
+		products, err := s.productService.GetProductByID(item.ProductID)
+		if err != nil {
+			return nil, errors.New("produk tidak ditemukan")
+		}
+		totalWeight += products.Weight * item.Quantity
+	}
+
+	return s.rateProvider.GetRates(shippingrate.RateRequest{
+		Origin:      s.originCity,
+		Destination: addresses.City,
+		Weight:      totalWeight,
+		Courier:     request.Courier,
+	})
+}
+
+func (s *ShippingService) CalculateFee(destination string, weight uint64, courier, service string) (*shippingrate.Rate, error) {
+	rates, err := s.rateProvider.GetRates(shippingrate.RateRequest{
+		Origin:      s.originCity,
+		Destination: destination,
+		Weight:      weight,
+		Courier:     courier,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rate := range rates {
+		if strings.EqualFold(rate.Service, service) {
+			return &rate, nil
+		}
+	}
+	return nil, errors.New("layanan pengiriman tidak tersedia")
+}
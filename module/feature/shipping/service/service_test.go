@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	addressMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/address/mocks"
+	address "github.com/capstone-kelompok-7/backend-disappear/module/feature/address/service"
+	assistant "github.com/capstone-kelompok-7/backend-disappear/module/feature/assistant/service"
+	productMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
+	product "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/service"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/shippingrate/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupShippingService(t *testing.T, rateProvider shippingrate.RateProvider) (
+	*ShippingService,
+	*addressMocks.RepositoryAddressInterface,
+	*productMocks.RepositoryProductInterface,
+) {
+	addressRepo := addressMocks.NewRepositoryAddressInterface(t)
+	productRepo := productMocks.NewRepositoryProductInterface(t)
+	assistantService := assistant.NewAssistantService(nil, nil, config.Config{})
+	productService := product.NewProductService(productRepo, assistantService, nil, nil, nil, nil, 0)
+	addressService := address.NewAddressService(addressRepo)
+
+	shippingService := NewShippingService(rateProvider, "Jakarta", addressService, productService)
+	return shippingService.(*ShippingService), addressRepo, productRepo
+}
+
+func TestShippingService_GetQuote(t *testing.T) {
+	request := &dto.ShippingQuoteRequest{
+		AddressID: 1,
+		Courier:   "jne",
+		Items: []dto.ShippingQuoteItem{
+			{ProductID: 1, Quantity: 2},
+			{ProductID: 2, Quantity: 1},
+		},
+	}
+
+	t.Run("Success Case", func(t *testing.T) {
+		service, addressRepo, productRepo := setupShippingService(t, shippingrate.NewLocalRateTable())
+		addressRepo.On("GetAddressByID", uint64(1)).Return(&entities.AddressModels{ID: 1, UserID: 1, City: "Medan"}, nil).Once()
+		productRepo.On("GetProductByID", uint64(1)).Return(&entities.ProductModels{ID: 1, Weight: 800}, nil).Once()
+		productRepo.On("GetProductByID", uint64(2)).Return(&entities.ProductModels{ID: 2, Weight: 500}, nil).Once()
+
+		rates, err := service.GetQuote(1, request)
+
+		assert.NoError(t, err)
+		assert.Len(t, rates, 2)
+		assert.Equal(t, "REG", rates[0].Service)
+		assert.Equal(t, uint64(75000), rates[0].Cost)
+		addressRepo.AssertExpectations(t)
+		productRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Address Of Another User", func(t *testing.T) {
+		service, addressRepo, _ := setupShippingService(t, shippingrate.NewLocalRateTable())
+		addressRepo.On("GetAddressByID", uint64(1)).Return(&entities.AddressModels{ID: 1, UserID: 2, City: "Medan"}, nil).Once()
+
+		rates, err := service.GetQuote(1, request)
+
+		assert.Nil(t, rates)
+		assert.EqualError(t, err, "alamat tidak ditemukan")
+	})
+
+	t.Run("Failed Case - Product Not Found", func(t *testing.T) {
+		service, addressRepo, productRepo := setupShippingService(t, shippingrate.NewLocalRateTable())
+		addressRepo.On("GetAddressByID", uint64(1)).Return(&entities.AddressModels{ID: 1, UserID: 1, City: "Medan"}, nil).Once()
+		productRepo.On("GetProductByID", uint64(1)).Return(nil, errors.New("record not found")).Once()
+
+		rates, err := service.GetQuote(1, request)
+
+		assert.Nil(t, rates)
+		assert.EqualError(t, err, "produk tidak ditemukan")
+	})
+}
+
+func TestShippingService_CalculateFee(t *testing.T) {
+	t.Run("Success Case", func(t *testing.T) {
+		rateProvider := mocks.NewRateProvider(t)
+		service, _, _ := setupShippingService(t, rateProvider)
+		rateProvider.On("GetRates", mock.MatchedBy(func(r shippingrate.RateRequest) bool {
+			return r.Origin == "Jakarta" && r.Destination == "Bandung" && r.Weight == 1200 && r.Courier == "jne"
+		})).Return([]shippingrate.Rate{
+			{Courier: "jne", Service: "REG", Cost: 24000},
+			{Courier: "jne", Service: "YES", Cost: 48000},
+		}, nil).Once()
+
+		rate, err := service.CalculateFee("Bandung", 1200, "jne", "yes")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(48000), rate.Cost)
+	})
+
+	t.Run("Failed Case - Service Unavailable", func(t *testing.T) {
+		rateProvider := mocks.NewRateProvider(t)
+		service, _, _ := setupShippingService(t, rateProvider)
+		rateProvider.On("GetRates", mock.Anything).Return([]shippingrate.Rate{{Courier: "jne", Service: "REG"}}, nil).Once()
+
+		rate, err := service.CalculateFee("Bandung", 1200, "jne", "OKE")
+
+		assert.Nil(t, rate)
+		assert.EqualError(t, err, "layanan pengiriman tidak tersedia")
+	})
+
+	t.Run("Failed Case - Provider Error", func(t *testing.T) {
+		rateProvider := mocks.NewRateProvider(t)
+		service, _, _ := setupShippingService(t, rateProvider)
+		rateProvider.On("GetRates", mock.Anything).Return(nil, errors.New("kurir tidak tersedia")).Once()
+
+		rate, err := service.CalculateFee("Bandung", 1200, "pos", "REG")
+
+		assert.Nil(t, rate)
+		assert.EqualError(t, err, "kurir tidak tersedia")
+	})
+}
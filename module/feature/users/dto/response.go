@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"time"
+
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 )
 
@@ -181,3 +183,197 @@ func FormatUserProfileResponse(user *entities.UserModels) *UserProfileResponse {
 	}
 	return userFormatter
 }
+
+// The Export* types are what ends up in the data export archive. They leave
+// out secrets such as the password hash and device token.
+type ExportProfile struct {
+	ID             uint64    `json:"id"`
+	Email          string    `json:"email"`
+	Name           string    `json:"name"`
+	Phone          string    `json:"phone"`
+	PhotoProfile   string    `json:"photo_profile"`
+	Provider       string    `json:"provider"`
+	Role           string    `json:"role"`
+	Level          string    `json:"level"`
+	Exp            uint64    `json:"exp"`
+	TotalGram      uint64    `json:"total_gram"`
+	TotalChallenge uint64    `json:"total_challenge"`
+	IsVerified     bool      `json:"is_verified"`
+	LastLogin      time.Time `json:"last_login"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ExportAddress struct {
+	ID           uint64    `json:"id"`
+	AcceptedName string    `json:"accepted_name"`
+	Phone        string    `json:"phone"`
+	Address      string    `json:"address"`
+	City         string    `json:"city"`
+	IsPrimary    bool      `json:"is_primary"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ExportOrder struct {
+	ID                    string            `json:"id"`
+	IdOrder               string            `json:"id_order"`
+	Note                  string            `json:"note"`
+	OrderStatus           string            `json:"order_status"`
+	PaymentStatus         string            `json:"payment_status"`
+	PaymentMethod         string            `json:"payment_method"`
+	Courier               string            `json:"courier"`
+	CourierService        string            `json:"courier_service"`
+	GrandTotalGramPlastic uint64            `json:"grand_total_gram_plastic"`
+	TotalAmountPaid       uint64            `json:"total_amount_paid"`
+	Address               ExportAddress     `json:"address"`
+	Items                 []ExportOrderItem `json:"items"`
+	CreatedAt             time.Time         `json:"created_at"`
+}
+
+type ExportOrderItem struct {
+	ProductID   uint64 `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    uint64 `json:"quantity"`
+	TotalPrice  uint64 `json:"total_price"`
+}
+
+type ExportReview struct {
+	ID          uint64    `json:"id"`
+	ProductID   uint64    `json:"product_id"`
+	Rating      uint64    `json:"rating"`
+	Description string    `json:"description"`
+	Photos      []string  `json:"photos"`
+	Date        time.Time `json:"date"`
+}
+
+type ExportChallengeForm struct {
+	ID          uint64    `json:"id"`
+	ChallengeID uint64    `json:"challenge_id"`
+	Username    string    `json:"username"`
+	Photo       string    `json:"photo"`
+	Status      string    `json:"status"`
+	Exp         uint64    `json:"exp"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ExportChat struct {
+	Role      string    `json:"role"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func FormatExportProfile(user *entities.UserModels) *ExportProfile {
+	return &ExportProfile{
+		ID:             user.ID,
+		Email:          user.Email,
+		Name:           user.Name,
+		Phone:          user.Phone,
+		PhotoProfile:   user.PhotoProfile,
+		Provider:       user.Provider,
+		Role:           user.Role,
+		Level:          user.Level,
+		Exp:            user.Exp,
+		TotalGram:      user.TotalGram,
+		TotalChallenge: user.TotalChallenge,
+		IsVerified:     user.IsVerified,
+		LastLogin:      user.LastLogin,
+		CreatedAt:      user.CreatedAt,
+	}
+}
+
+func FormatExportAddress(address entities.AddressModels) ExportAddress {
+	return ExportAddress{
+		ID:           address.ID,
+		AcceptedName: address.AcceptedName,
+		Phone:        address.Phone,
+		Address:      address.Address,
+		City:         address.City,
+		IsPrimary:    address.IsPrimary,
+		CreatedAt:    address.CreatedAt,
+	}
+}
+
+func FormatExportAddresses(addresses []*entities.AddressModels) []ExportAddress {
+	result := make([]ExportAddress, 0, len(addresses))
+	for _, address := range addresses {
+		result = append(result, FormatExportAddress(*address))
+	}
+	return result
+}
+
+func FormatExportOrders(orders []*entities.OrderModels) []ExportOrder {
+	result := make([]ExportOrder, 0, len(orders))
+	for _, order := range orders {
+		items := make([]ExportOrderItem, 0, len(order.OrderDetails))
+		for _, detail := range order.OrderDetails {
+			items = append(items, ExportOrderItem{
+				ProductID:   detail.ProductID,
+				ProductName: detail.Product.Name,
+				Quantity:    detail.Quantity,
+				TotalPrice:  detail.TotalPrice,
+			})
+		}
+		result = append(result, ExportOrder{
+			ID:                    order.ID,
+			IdOrder:               order.IdOrder,
+			Note:                  order.Note,
+			OrderStatus:           order.OrderStatus,
+			PaymentStatus:         order.PaymentStatus,
+			PaymentMethod:         order.PaymentMethod,
+			Courier:               order.Courier,
+			CourierService:        order.CourierService,
+			GrandTotalGramPlastic: order.GrandTotalGramPlastic,
+			TotalAmountPaid:       order.TotalAmountPaid,
+			Address:               FormatExportAddress(order.Address),
+			Items:                 items,
+			CreatedAt:             order.CreatedAt,
+		})
+	}
+	return result
+}
+
+func FormatExportReviews(reviews []*entities.ReviewModels) []ExportReview {
+	result := make([]ExportReview, 0, len(reviews))
+	for _, review := range reviews {
+		photos := make([]string, 0, len(review.Photos))
+		for _, photo := range review.Photos {
+			photos = append(photos, photo.ImageURL)
+		}
+		result = append(result, ExportReview{
+			ID:          review.ID,
+			ProductID:   review.ProductID,
+			Rating:      review.Rating,
+			Description: review.Description,
+			Photos:      photos,
+			Date:        review.Date,
+		})
+	}
+	return result
+}
+
+func FormatExportChallengeForms(forms []*entities.ChallengeFormModels) []ExportChallengeForm {
+	result := make([]ExportChallengeForm, 0, len(forms))
+	for _, form := range forms {
+		result = append(result, ExportChallengeForm{
+			ID:          form.ID,
+			ChallengeID: form.ChallengeID,
+			Username:    form.Username,
+			Photo:       form.Photo,
+			Status:      form.Status,
+			Exp:         form.Exp,
+			CreatedAt:   form.CreatedAt,
+		})
+	}
+	return result
+}
+
+func FormatExportChats(chats []entities.ChatModel) []ExportChat {
+	result := make([]ExportChat, 0, len(chats))
+	for _, chat := range chats {
+		result = append(result, ExportChat{
+			Role:      chat.Role,
+			Text:      chat.Text,
+			CreatedAt: chat.CreatedAt,
+		})
+	}
+	return result
+}
@@ -1,8 +1,11 @@
 package handler
 
 import (
+	"fmt"
 	"mime/multipart"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
@@ -25,10 +28,6 @@ func NewUserHandler(service users.ServiceUserInterface) users.HandlerUserInterfa
 
 func (h *UserHandler) GetUsersByEmail() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		email := c.QueryParam("email")
 		if email == "" {
 			return response.SendBadRequestResponse(c, "Format email yang Anda masukkan tidak sesuai.")
@@ -87,10 +86,6 @@ func (h *UserHandler) GetUsersById() echo.HandlerFunc {
 
 func (h *UserHandler) GetAllUsers() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
 		perPage := 8
@@ -127,9 +122,6 @@ func (h *UserHandler) GetAllUsers() echo.HandlerFunc {
 func (h *UserHandler) EditProfile() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		var editProfileRequest dto.EditProfileRequest
 		if err := c.Bind(&editProfileRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
@@ -164,10 +156,6 @@ func (h *UserHandler) EditProfile() echo.HandlerFunc {
 
 func (h *UserHandler) DeleteAccount() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
@@ -193,10 +181,6 @@ func (h *UserHandler) GetLeaderboard() echo.HandlerFunc {
 
 func (h *UserHandler) GetUserTransactionActivity() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		id := c.Param("id")
 		if id == "" {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
@@ -228,9 +212,6 @@ func (h *UserHandler) GetUserTransactionActivity() echo.HandlerFunc {
 func (h *UserHandler) GetUserProfile() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		user, err := h.service.GetUsersById(currentUser.ID)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan detail pengguna: "+err.Error())
@@ -239,3 +220,27 @@ func (h *UserHandler) GetUserProfile() echo.HandlerFunc {
 		return response.SendSuccessResponse(c, "Berhasil mendapat detail pengguna", dto.FormatUserProfileResponse(user))
 	}
 }
+
+func (h *UserHandler) ExportData() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		archive, err := h.service.ExportUserData(currentUser.ID)
+		if err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal mengekspor data pengguna: "+err.Error())
+		}
+
+		filename := fmt.Sprintf("data-pengguna-%d-%s.zip", currentUser.ID, time.Now().Format("20060102"))
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+		return c.Blob(http.StatusOK, "application/zip", archive)
+	}
+}
+
+func (h *UserHandler) DeleteOwnAccount() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		if err := h.service.DeleteAccount(currentUser.ID); err != nil {
+			return response.SendStatusInternalServerResponse(c, "Gagal menghapus akun: "+err.Error())
+		}
+		return response.SendStatusOkResponse(c, "Akun Anda berhasil dihapus")
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type RepositoryUserInterface interface {
+	WithTx(tx *gorm.DB) RepositoryUserInterface
 	GetUsersByEmail(email string) (*entities.UserModels, error)
 	GetUsersById(userId uint64) (*entities.UserModels, error)
 	GetUsersPassword(userID uint64) (string, error)
@@ -20,6 +22,8 @@ type RepositoryUserInterface interface {
 	UpdateUserExp(userID uint64, exp uint64) (*entities.UserModels, error)
 	UpdateUserChallengeFollow(userID uint64, totalChallenge uint64) (*entities.UserModels, error)
 	UpdateUserContribution(userID uint64, gramPlastic uint64) (*entities.UserModels, error)
+	AddUserRewards(userID uint64, exp, gramPlastic uint64) error
+	DeductUserRewards(userID uint64, exp, gramPlastic uint64) error
 	UpdateUserLevel(userID uint64, level string) error
 	GetUserLevel(userID uint64) (string, error)
 	GetFilterLevel(page, perPage int, level string) ([]*entities.UserModels, int64, error)
@@ -27,9 +31,18 @@ type RepositoryUserInterface interface {
 	GetUserTransactionActivity(userID uint64) (int, int, int, error)
 	GetUserChallengeActivity(userID uint64) (int, int, int, error)
 	GetAllUsersBySearchAndFilter(page, perPage int, search, levelFilter string) ([]*entities.UserModels, int64, error)
+	DeleteChatsByUserID(userID uint64) error
+	GetUserIDsPendingChatPurge(limit int) ([]uint64, error)
+	MarkChatsPurged(userID uint64) error
+	GetAddressesByUserID(userID uint64) ([]*entities.AddressModels, error)
+	GetOrdersByUserID(userID uint64) ([]*entities.OrderModels, error)
+	GetReviewsByUserID(userID uint64) ([]*entities.ReviewModels, error)
+	GetChallengeFormsByUserID(userID uint64) ([]*entities.ChallengeFormModels, error)
+	GetChatsByUserID(userID uint64) ([]entities.ChatModel, error)
 }
 
 type ServiceUserInterface interface {
+	WithTx(tx *gorm.DB) ServiceUserInterface
 	GetUsersByEmail(email string) (*entities.UserModels, error)
 	GetUsersById(userId uint64) (*entities.UserModels, error)
 	ValidatePassword(userID uint64, oldPassword, newPassword, confirmPassword string) error
@@ -44,6 +57,8 @@ type ServiceUserInterface interface {
 	UpdateUserExp(userID uint64, exp uint64) (*entities.UserModels, error)
 	UpdateUserChallengeFollow(userID uint64, totalChallenge uint64) (*entities.UserModels, error)
 	UpdateUserContribution(userID uint64, gramPlastic uint64) (*entities.UserModels, error)
+	AwardUserRewards(userID uint64, exp, gramPlastic uint64) error
+	ReverseUserRewards(userID uint64, exp, gramPlastic uint64) error
 	GetUserLevel(userID uint64) (string, error)
 	GetLeaderboardByExp(limit int) ([]*entities.UserModels, error)
 	GetUserTransactionActivity(userID uint64) (int, int, int, error)
@@ -51,6 +66,8 @@ type ServiceUserInterface interface {
 	GetUserProfile(userID uint64) (*entities.UserModels, error)
 	GetUsersBySearchAndFilter(page, perPage int, search, levelFilter string) ([]*entities.UserModels, int64, error)
 	GetUsersByLevel(page, perPage int, level string) ([]*entities.UserModels, int64, error)
+	ExportUserData(userID uint64) ([]byte, error)
+	PurgeDeletedChats() (int, error)
 }
 
 type HandlerUserInterface interface {
@@ -63,4 +80,6 @@ type HandlerUserInterface interface {
 	GetLeaderboard() echo.HandlerFunc
 	GetUserTransactionActivity() echo.HandlerFunc
 	GetUserProfile() echo.HandlerFunc
+	ExportData() echo.HandlerFunc
+	DeleteOwnAccount() echo.HandlerFunc
 }
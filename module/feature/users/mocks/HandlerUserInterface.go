@@ -44,6 +44,22 @@ func (_m *HandlerUserInterface) DeleteAccount() echo.HandlerFunc {
 	return r0
 }
 
+// DeleteOwnAccount provides a mock function with given fields:
+func (_m *HandlerUserInterface) DeleteOwnAccount() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // EditProfile provides a mock function with given fields:
 func (_m *HandlerUserInterface) EditProfile() echo.HandlerFunc {
 	ret := _m.Called()
@@ -60,6 +76,22 @@ func (_m *HandlerUserInterface) EditProfile() echo.HandlerFunc {
 	return r0
 }
 
+// ExportData provides a mock function with given fields:
+func (_m *HandlerUserInterface) ExportData() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetAllUsers provides a mock function with given fields:
 func (_m *HandlerUserInterface) GetAllUsers() echo.HandlerFunc {
 	ret := _m.Called()
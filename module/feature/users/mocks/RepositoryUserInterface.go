@@ -4,9 +4,10 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	users "github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
-
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // RepositoryUserInterface is an autogenerated mock type for the RepositoryUserInterface type
@@ -14,6 +15,20 @@ type RepositoryUserInterface struct {
 	mock.Mock
 }
 
+// AddUserRewards provides a mock function with given fields: userID, exp, gramPlastic
+func (_m *RepositoryUserInterface) AddUserRewards(userID uint64, exp uint64, gramPlastic uint64) error {
+	ret := _m.Called(userID, exp, gramPlastic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, exp, gramPlastic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ChangePassword provides a mock function with given fields: userID, newPasswordHash
 func (_m *RepositoryUserInterface) ChangePassword(userID uint64, newPasswordHash string) error {
 	ret := _m.Called(userID, newPasswordHash)
@@ -28,6 +43,20 @@ func (_m *RepositoryUserInterface) ChangePassword(userID uint64, newPasswordHash
 	return r0
 }
 
+// DeductUserRewards provides a mock function with given fields: userID, exp, gramPlastic
+func (_m *RepositoryUserInterface) DeductUserRewards(userID uint64, exp uint64, gramPlastic uint64) error {
+	ret := _m.Called(userID, exp, gramPlastic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, exp, gramPlastic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteAccount provides a mock function with given fields: userID
 func (_m *RepositoryUserInterface) DeleteAccount(userID uint64) error {
 	ret := _m.Called(userID)
@@ -42,6 +71,20 @@ func (_m *RepositoryUserInterface) DeleteAccount(userID uint64) error {
 	return r0
 }
 
+// DeleteChatsByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) DeleteChatsByUserID(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditProfile provides a mock function with given fields: userID, updatedData
 func (_m *RepositoryUserInterface) EditProfile(userID uint64, updatedData dto.EditProfileRequest) (*entities.UserModels, error) {
 	ret := _m.Called(userID, updatedData)
@@ -120,6 +163,32 @@ func (_m *RepositoryUserInterface) FindByName(page int, perPage int, name string
 	return r0, r1
 }
 
+// GetAddressesByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) GetAddressesByUserID(userID uint64) ([]*entities.AddressModels, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*entities.AddressModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.AddressModels, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.AddressModels); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.AddressModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllUsersBySearchAndFilter provides a mock function with given fields: page, perPage, search, levelFilter
 func (_m *RepositoryUserInterface) GetAllUsersBySearchAndFilter(page int, perPage int, search string, levelFilter string) ([]*entities.UserModels, int64, error) {
 	ret := _m.Called(page, perPage, search, levelFilter)
@@ -153,6 +222,58 @@ func (_m *RepositoryUserInterface) GetAllUsersBySearchAndFilter(page int, perPag
 	return r0, r1, r2
 }
 
+// GetChallengeFormsByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) GetChallengeFormsByUserID(userID uint64) ([]*entities.ChallengeFormModels, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*entities.ChallengeFormModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.ChallengeFormModels, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.ChallengeFormModels); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ChallengeFormModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChatsByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) GetChatsByUserID(userID uint64) ([]entities.ChatModel, error) {
+	ret := _m.Called(userID)
+
+	var r0 []entities.ChatModel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]entities.ChatModel, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []entities.ChatModel); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entities.ChatModel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFilterLevel provides a mock function with given fields: page, perPage, level
 func (_m *RepositoryUserInterface) GetFilterLevel(page int, perPage int, level string) ([]*entities.UserModels, int64, error) {
 	ret := _m.Called(page, perPage, level)
@@ -212,6 +333,58 @@ func (_m *RepositoryUserInterface) GetLeaderboardByExp(limit int) ([]*entities.U
 	return r0, r1
 }
 
+// GetOrdersByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) GetOrdersByUserID(userID uint64) ([]*entities.OrderModels, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*entities.OrderModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.OrderModels, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.OrderModels); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.OrderModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviewsByUserID provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) GetReviewsByUserID(userID uint64) ([]*entities.ReviewModels, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*entities.ReviewModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]*entities.ReviewModels, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []*entities.ReviewModels); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.ReviewModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalUserCount provides a mock function with given fields:
 func (_m *RepositoryUserInterface) GetTotalUserCount() (int64, error) {
 	ret := _m.Called()
@@ -298,6 +471,32 @@ func (_m *RepositoryUserInterface) GetUserChallengeActivity(userID uint64) (int,
 	return r0, r1, r2, r3
 }
 
+// GetUserIDsPendingChatPurge provides a mock function with given fields: limit
+func (_m *RepositoryUserInterface) GetUserIDsPendingChatPurge(limit int) ([]uint64, error) {
+	ret := _m.Called(limit)
+
+	var r0 []uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int) ([]uint64, error)); ok {
+		return rf(limit)
+	}
+	if rf, ok := ret.Get(0).(func(int) []uint64); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserLevel provides a mock function with given fields: userID
 func (_m *RepositoryUserInterface) GetUserLevel(userID uint64) (string, error) {
 	ret := _m.Called(userID)
@@ -436,6 +635,20 @@ func (_m *RepositoryUserInterface) GetUsersPassword(userID uint64) (string, erro
 	return r0, r1
 }
 
+// MarkChatsPurged provides a mock function with given fields: userID
+func (_m *RepositoryUserInterface) MarkChatsPurged(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserChallengeFollow provides a mock function with given fields: userID, totalChallenge
 func (_m *RepositoryUserInterface) UpdateUserChallengeFollow(userID uint64, totalChallenge uint64) (*entities.UserModels, error) {
 	ret := _m.Called(userID, totalChallenge)
@@ -528,6 +741,22 @@ func (_m *RepositoryUserInterface) UpdateUserLevel(userID uint64, level string)
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *RepositoryUserInterface) WithTx(tx *gorm.DB) users.RepositoryUserInterface {
+	ret := _m.Called(tx)
+
+	var r0 users.RepositoryUserInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) users.RepositoryUserInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(users.RepositoryUserInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewRepositoryUserInterface creates a new instance of RepositoryUserInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryUserInterface(t interface {
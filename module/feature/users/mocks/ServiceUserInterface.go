@@ -4,9 +4,10 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	users "github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
-
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // ServiceUserInterface is an autogenerated mock type for the ServiceUserInterface type
@@ -14,6 +15,20 @@ type ServiceUserInterface struct {
 	mock.Mock
 }
 
+// AwardUserRewards provides a mock function with given fields: userID, exp, gramPlastic
+func (_m *ServiceUserInterface) AwardUserRewards(userID uint64, exp uint64, gramPlastic uint64) error {
+	ret := _m.Called(userID, exp, gramPlastic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, exp, gramPlastic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CalculatePaginationValues provides a mock function with given fields: page, totalItems, perPage
 func (_m *ServiceUserInterface) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
 	ret := _m.Called(page, totalItems, perPage)
@@ -92,6 +107,32 @@ func (_m *ServiceUserInterface) EditProfile(userID uint64, updatedData dto.EditP
 	return r0, r1
 }
 
+// ExportUserData provides a mock function with given fields: userID
+func (_m *ServiceUserInterface) ExportUserData(userID uint64) ([]byte, error) {
+	ret := _m.Called(userID)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) ([]byte, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) []byte); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllUsers provides a mock function with given fields: page, perPage
 func (_m *ServiceUserInterface) GetAllUsers(page int, perPage int) ([]*entities.UserModels, int64, error) {
 	ret := _m.Called(page, perPage)
@@ -456,6 +497,44 @@ func (_m *ServiceUserInterface) GetUsersBySearchAndFilter(page int, perPage int,
 	return r0, r1, r2
 }
 
+// PurgeDeletedChats provides a mock function with given fields:
+func (_m *ServiceUserInterface) PurgeDeletedChats() (int, error) {
+	ret := _m.Called()
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReverseUserRewards provides a mock function with given fields: userID, exp, gramPlastic
+func (_m *ServiceUserInterface) ReverseUserRewards(userID uint64, exp uint64, gramPlastic uint64) error {
+	ret := _m.Called(userID, exp, gramPlastic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, uint64) error); ok {
+		r0 = rf(userID, exp, gramPlastic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserChallengeFollow provides a mock function with given fields: userID, totalChallenge
 func (_m *ServiceUserInterface) UpdateUserChallengeFollow(userID uint64, totalChallenge uint64) (*entities.UserModels, error) {
 	ret := _m.Called(userID, totalChallenge)
@@ -548,6 +627,22 @@ func (_m *ServiceUserInterface) ValidatePassword(userID uint64, oldPassword stri
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *ServiceUserInterface) WithTx(tx *gorm.DB) users.ServiceUserInterface {
+	ret := _m.Called(tx)
+
+	var r0 users.ServiceUserInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) users.ServiceUserInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(users.ServiceUserInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewServiceUserInterface creates a new instance of ServiceUserInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceUserInterface(t interface {
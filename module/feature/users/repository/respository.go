@@ -1,22 +1,37 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 )
 
+const anonymizedName = "Pengguna Terhapus"
+
 type UserRepository struct {
-	db *gorm.DB
+	db    *gorm.DB
+	chats *mongo.Collection
 }
 
-func NewUserRepository(db *gorm.DB) users.RepositoryUserInterface {
+func NewUserRepository(db *gorm.DB, mgo *mongo.Client) users.RepositoryUserInterface {
 	return &UserRepository{
-		db: db,
+		db:    db,
+		chats: mgo.Database("assistant").Collection("chats"),
+	}
+}
+
+func (r *UserRepository) WithTx(tx *gorm.DB) users.RepositoryUserInterface {
+	return &UserRepository{
+		db:    tx,
+		chats: r.chats,
 	}
 }
 
@@ -109,17 +124,151 @@ func (r *UserRepository) EditProfile(userID uint64, updatedData dto.EditProfileR
 	return user, nil
 }
 
+// DeleteAccount soft-deletes the user and strips personal data from every
+// table that refers to them. Totals such as TotalGram, Exp and the order
+// amounts are kept so statistics and leaderboards stay correct.
 func (r *UserRepository) DeleteAccount(userID uint64) error {
 	user := &entities.UserModels{}
 	if err := r.db.First(user, userID).Error; err != nil {
 		return err
 	}
 
-	if err := r.db.Model(user).Update("deleted_at", time.Now()).Error; err != nil {
-		return err
+	now := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entities.UserModels{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"social_id":     "",
+			"provider":      "",
+			"email":         fmt.Sprintf("deleted-%d@anonymized.invalid", userID),
+			"password":      "",
+			"phone":         "",
+			"name":          anonymizedName,
+			"photo_profile": "",
+			"deleted_at":    now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.AddressModels{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+			"accepted_name": anonymizedName,
+			"phone":         "",
+			"address":       "",
+			"deleted_at":    gorm.Expr("COALESCE(deleted_at, ?)", now),
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.OrderModels{}).Where("user_id = ?", userID).Update("note", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.ChallengeFormModels{}).Where("user_id = ?", userID).Updates(map[string]interface{}{
+			"username": anonymizedName,
+			"photo":    "",
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&entities.OrderReturnModels{}).Where("user_id = ?", userID).Update("reason", "").Error; err != nil {
+			return err
+		}
+		if err := tx.Where("return_id IN (?)", tx.Model(&entities.OrderReturnModels{}).Select("id").Where("user_id = ?", userID)).
+			Delete(&entities.OrderReturnPhotoModels{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("cart_id IN (?)", tx.Model(&entities.CartModels{}).Select("id").Where("user_id = ?", userID)).
+			Delete(&entities.CartItemModels{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&entities.CartModels{},
+			&entities.ArticleBookmarkModels{},
+			&entities.FcmModels{},
+			&entities.UserDeviceModels{},
+			&entities.NotificationOutboxModels{},
+			&entities.NotificationPreferenceModels{},
+			&entities.RefreshTokenModels{},
+			&entities.TwoFactorModels{},
+			&entities.RecoveryCodeModels{},
+		} {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entities.OTPModels{}).Error
+	})
+}
+
+func (r *UserRepository) DeleteChatsByUserID(userID uint64) error {
+	_, err := r.chats.DeleteMany(context.Background(), bson.M{"userid": userID})
+	return err
+}
+
+// GetUserIDsPendingChatPurge lists deleted accounts whose chat history in
+// MongoDB has not been removed yet.
+func (r *UserRepository) GetUserIDsPendingChatPurge(limit int) ([]uint64, error) {
+	var userIDs []uint64
+	if err := r.db.Model(&entities.UserModels{}).
+		Where("deleted_at IS NOT NULL AND chats_purged_at IS NULL").
+		Order("id ASC").
+		Limit(limit).
+		Pluck("id", &userIDs).Error; err != nil {
+		return nil, err
 	}
+	return userIDs, nil
+}
 
-	return nil
+func (r *UserRepository) MarkChatsPurged(userID uint64) error {
+	return r.db.Model(&entities.UserModels{}).Where("id = ?", userID).Update("chats_purged_at", time.Now()).Error
+}
+
+func (r *UserRepository) GetAddressesByUserID(userID uint64) ([]*entities.AddressModels, error) {
+	var addresses []*entities.AddressModels
+	if err := r.db.Where("user_id = ? AND deleted_at IS NULL", userID).Find(&addresses).Error; err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (r *UserRepository) GetOrdersByUserID(userID uint64) ([]*entities.OrderModels, error) {
+	var orders []*entities.OrderModels
+	if err := r.db.
+		Preload("Address").
+		Preload("OrderDetails").
+		Preload("OrderDetails.Product").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (r *UserRepository) GetReviewsByUserID(userID uint64) ([]*entities.ReviewModels, error) {
+	var reviews []*entities.ReviewModels
+	if err := r.db.Preload("Photos").Where("user_id = ? AND deleted_at IS NULL", userID).Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *UserRepository) GetChallengeFormsByUserID(userID uint64) ([]*entities.ChallengeFormModels, error) {
+	var forms []*entities.ChallengeFormModels
+	if err := r.db.Where("user_id = ? AND deleted_at IS NULL", userID).Find(&forms).Error; err != nil {
+		return nil, err
+	}
+	return forms, nil
+}
+
+func (r *UserRepository) GetChatsByUserID(userID uint64) ([]entities.ChatModel, error) {
+	ctx := context.Background()
+	cursor, err := r.chats.Find(ctx, bson.M{"userid": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var chats []entities.ChatModel
+	if err := cursor.All(ctx, &chats); err != nil {
+		return nil, err
+	}
+	return chats, nil
 }
 
 func (r *UserRepository) UpdateUserExp(userID uint64, exp uint64) (*entities.UserModels, error) {
@@ -149,6 +298,24 @@ func (r *UserRepository) UpdateUserContribution(userID uint64, gramPlastic uint6
 	return user, nil
 }
 
+// AddUserRewards adds exp and gram in one statement, so concurrent updates to
+// the same user are never lost.
+func (r *UserRepository) AddUserRewards(userID uint64, exp, gramPlastic uint64) error {
+	return r.db.Model(&entities.UserModels{}).Where("id = ?", userID).UpdateColumns(map[string]interface{}{
+		"exp":        gorm.Expr("exp + ?", exp),
+		"total_gram": gorm.Expr("total_gram + ?", gramPlastic),
+	}).Error
+}
+
+// DeductUserRewards takes exp and gram off in one statement, stopping at zero,
+// so concurrent updates to the same user are never lost.
+func (r *UserRepository) DeductUserRewards(userID uint64, exp, gramPlastic uint64) error {
+	return r.db.Model(&entities.UserModels{}).Where("id = ?", userID).UpdateColumns(map[string]interface{}{
+		"exp":        gorm.Expr("GREATEST(CAST(exp AS SIGNED) - ?, 0)", exp),
+		"total_gram": gorm.Expr("GREATEST(CAST(total_gram AS SIGNED) - ?, 0)", gramPlastic),
+	}).Error
+}
+
 func (r *UserRepository) UpdateUserLevel(userID uint64, level string) error {
 	var user entities.UserModels
 	if err := r.db.Model(&user).Where("id = ?", userID).Update("level", level).Error; err != nil {
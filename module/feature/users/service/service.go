@@ -1,6 +1,9 @@
 package service
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
 	"errors"
 	"math"
 
@@ -8,17 +11,33 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type UserService struct {
-	repo users.RepositoryUserInterface
-	hash utils.HashInterface
+	repo   users.RepositoryUserInterface
+	hash   utils.HashInterface
+	jwt    utils.JWTInterface
+	mailer email.EmailSenderInterface
 }
 
-func NewUserService(repo users.RepositoryUserInterface, hash utils.HashInterface) users.ServiceUserInterface {
+func NewUserService(repo users.RepositoryUserInterface, hash utils.HashInterface, jwt utils.JWTInterface, mailer email.EmailSenderInterface) users.ServiceUserInterface {
 	return &UserService{
-		repo: repo,
-		hash: hash,
+		repo:   repo,
+		hash:   hash,
+		jwt:    jwt,
+		mailer: mailer,
+	}
+}
+
+func (s *UserService) WithTx(tx *gorm.DB) users.ServiceUserInterface {
+	return &UserService{
+		repo:   s.repo.WithTx(tx),
+		hash:   s.hash,
+		jwt:    s.jwt,
+		mailer: s.mailer,
 	}
 }
 
@@ -74,6 +93,12 @@ func (s *UserService) ChangePassword(userID uint64, updateRequest dto.UpdatePass
 		return err
 	}
 
+	if err := s.jwt.RevokeUserTokens(user.ID); err != nil {
+		return errors.New("gagal mencabut sesi pengguna")
+	}
+
+	email.SendPasswordChanged(s.mailer, user.ID, user.Name, user.Email)
+
 	return nil
 }
 
@@ -154,9 +179,46 @@ func (s *UserService) DeleteAccount(userID uint64) error {
 	if err := s.repo.DeleteAccount(userID); err != nil {
 		return err
 	}
+	// The account is already anonymized in MySQL, so a chat history that
+	// cannot be removed now is left to PurgeDeletedChats instead of failing
+	// the request.
+	if err := s.purgeChats(userID); err != nil {
+		logrus.Warnf("Riwayat chat pengguna %d gagal dihapus, akan dicoba lagi: %v", userID, err)
+	}
+	if err := s.jwt.RevokeUserTokens(userID); err != nil {
+		return errors.New("gagal mencabut sesi pengguna")
+	}
 	return nil
 }
 
+const purgeDeletedChatsBatchSize = 100
+
+// PurgeDeletedChats removes the chat history of deleted accounts that
+// DeleteAccount could not clean up, and returns how many were done.
+func (s *UserService) PurgeDeletedChats() (int, error) {
+	userIDs, err := s.repo.GetUserIDsPendingChatPurge(purgeDeletedChatsBatchSize)
+	if err != nil {
+		return 0, errors.New("gagal mendapatkan akun yang riwayat chatnya belum dihapus")
+	}
+
+	purged := 0
+	for _, userID := range userIDs {
+		if err := s.purgeChats(userID); err != nil {
+			logrus.Errorf("Gagal menghapus riwayat chat pengguna %d: %v", userID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (s *UserService) purgeChats(userID uint64) error {
+	if err := s.repo.DeleteChatsByUserID(userID); err != nil {
+		return err
+	}
+	return s.repo.MarkChatsPurged(userID)
+}
+
 func (s *UserService) UpdateUserExp(userID uint64, exp uint64) (*entities.UserModels, error) {
 	user, err := s.repo.GetUsersById(userID)
 	if err != nil {
@@ -194,6 +256,37 @@ func (s *UserService) UpdateUserContribution(userID uint64, gramPlastic uint64)
 	return updatedUser, nil
 }
 
+// AwardUserRewards grants the exp and gram earned with an order and moves the
+// user up a level when the new exp calls for it.
+func (s *UserService) AwardUserRewards(userID uint64, exp, gramPlastic uint64) error {
+	if err := s.repo.AddUserRewards(userID, exp, gramPlastic); err != nil {
+		return errors.New("gagal menambahkan exp pengguna")
+	}
+	return s.syncLevel(userID)
+}
+
+// ReverseUserRewards takes back exp and gram granted for an order and moves
+// the user down a level when the remaining exp calls for it.
+func (s *UserService) ReverseUserRewards(userID uint64, exp, gramPlastic uint64) error {
+	if err := s.repo.DeductUserRewards(userID, exp, gramPlastic); err != nil {
+		return errors.New("gagal mengurangi exp pengguna")
+	}
+	return s.syncLevel(userID)
+}
+
+func (s *UserService) syncLevel(userID uint64) error {
+	user, err := s.repo.GetUsersById(userID)
+	if err != nil {
+		return errors.New("pengguna tidak ditemukan")
+	}
+	if level := determineLevel(user.Exp); level != user.Level {
+		if err := s.repo.UpdateUserLevel(userID, level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func determineLevel(exp uint64) string {
 	if exp <= 500 {
 		return "Bronze"
@@ -276,3 +369,62 @@ func (s *UserService) GetUsersBySearchAndFilter(page, perPage int, search, level
 func (s *UserService) GetUsersByLevel(page, perPage int, level string) ([]*entities.UserModels, int64, error) {
 	return s.repo.GetFilterLevel(page, perPage, level)
 }
+
+// ExportUserData collects everything stored about the user into a zip archive
+// with one JSON file per kind of data.
+func (s *UserService) ExportUserData(userID uint64) ([]byte, error) {
+	user, err := s.repo.GetUsersById(userID)
+	if err != nil {
+		return nil, errors.New("pengguna tidak ditemukan")
+	}
+	addresses, err := s.repo.GetAddressesByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil data alamat")
+	}
+	orders, err := s.repo.GetOrdersByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil data pesanan")
+	}
+	reviews, err := s.repo.GetReviewsByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil data ulasan")
+	}
+	challengeForms, err := s.repo.GetChallengeFormsByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil data tantangan")
+	}
+	chats, err := s.repo.GetChatsByUserID(userID)
+	if err != nil {
+		return nil, errors.New("gagal mengambil riwayat chat")
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", dto.FormatExportProfile(user)},
+		{"addresses.json", dto.FormatExportAddresses(addresses)},
+		{"orders.json", dto.FormatExportOrders(orders)},
+		{"reviews.json", dto.FormatExportReviews(reviews)},
+		{"challenges.json", dto.FormatExportChallengeForms(challengeForms)},
+		{"chats.json", dto.FormatExportChats(chats)},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		writer, err := archive.Create(file.name)
+		if err != nil {
+			return nil, errors.New("gagal membuat arsip data")
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, errors.New("gagal membuat arsip data")
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, errors.New("gagal membuat arsip data")
+	}
+	return buf.Bytes(), nil
+}
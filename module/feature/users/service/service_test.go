@@ -1,26 +1,33 @@
 package service
 
 import (
+	"archive/zip"
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users/dto"
 	userMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/email"
 	utils "github.com/capstone-kelompok-7/backend-disappear/utils/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
 	"testing"
 )
 
 func setupTestService(t *testing.T) (
 	*UserService,
 	*userMocks.RepositoryUserInterface,
-	*utils.HashInterface) {
+	*utils.HashInterface,
+	*utils.JWTInterface) {
 
 	repo := userMocks.NewRepositoryUserInterface(t)
 	hash := utils.NewHashInterface(t)
-	service := NewUserService(repo, hash)
+	jwt := utils.NewJWTInterface(t)
+	service := NewUserService(repo, hash, jwt, email.NewSender("", email.NewMailbox()))
 
-	return service.(*UserService), repo, hash
+	return service.(*UserService), repo, hash, jwt
 }
 
 func TestUserService_PaginationFunctions(t *testing.T) {
@@ -79,7 +86,7 @@ func TestUserService_GetUsersById(t *testing.T) {
 		Name: "User 1",
 		Role: "customer",
 	}
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
 		repo.On("GetUsersById", userID).Return(nil, expectedErr).Once()
@@ -113,7 +120,7 @@ func TestUserService_GetUsersByEmail(t *testing.T) {
 		Name:  "User 1",
 		Role:  "customer",
 	}
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
 		repo.On("GetUsersByEmail", userEmail).Return(nil, expectedErr).Once()
@@ -141,9 +148,10 @@ func TestUserService_GetUsersByEmail(t *testing.T) {
 func TestUserService_ChangePassword(t *testing.T) {
 	userID := uint64(1)
 	user := &entities.UserModels{
-		ID:   userID,
-		Name: "User 1",
-		Role: "customer",
+		ID:    userID,
+		Name:  "User 1",
+		Email: "user1@example.com",
+		Role:  "customer",
 	}
 	request := dto.UpdatePasswordRequest{
 		OldPassword:     "pass123",
@@ -151,7 +159,9 @@ func TestUserService_ChangePassword(t *testing.T) {
 		ConfirmPassword: "pass1234",
 	}
 
-	service, repo, hash := setupTestService(t)
+	service, repo, hash, jwt := setupTestService(t)
+	mailbox := email.NewMailbox()
+	service.mailer = email.NewSender("no-reply@disappear.id", mailbox)
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
 		repo.On("GetUsersById", userID).Return(nil, expectedErr).Once()
@@ -198,12 +208,32 @@ func TestUserService_ChangePassword(t *testing.T) {
 
 		hash.On("GenerateHash", request.NewPassword).Return(hashedPassword, nil).Once()
 		repo.On("ChangePassword", userID, hashedPassword).Return(nil).Once()
+		jwt.On("RevokeUserTokens", userID).Return(nil).Once()
 
 		err := service.ChangePassword(userID, request)
 
 		assert.Nil(t, err)
 		repo.AssertExpectations(t)
 		hash.AssertExpectations(t)
+		jwt.AssertExpectations(t)
+
+		sent := mailbox.To(user.Email)
+		assert.Len(t, sent, 1)
+		assert.Equal(t, "Password Akun Disappear Diubah", sent[0].Subject)
+		assert.Contains(t, sent[0].Text, "Hi User 1")
+		assert.Contains(t, sent[0].HTML, "user1@example.com")
+	})
+
+	t.Run("Failed Case - Error Revoking Sessions", func(t *testing.T) {
+		hashedPassword := "hashedpassword"
+		repo.On("GetUsersById", userID).Return(user, nil).Once()
+		hash.On("GenerateHash", request.NewPassword).Return(hashedPassword, nil).Once()
+		repo.On("ChangePassword", userID, hashedPassword).Return(nil).Once()
+		jwt.On("RevokeUserTokens", userID).Return(errors.New("redis down")).Once()
+
+		err := service.ChangePassword(userID, request)
+
+		assert.EqualError(t, err, "gagal mencabut sesi pengguna")
 	})
 }
 
@@ -227,7 +257,7 @@ func TestUserService_EditProfile(t *testing.T) {
 		PhotoProfile: "photos.jpg",
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
 		repo.On("GetUsersById", userID).Return(nil, expectedErr).Once()
@@ -274,7 +304,7 @@ func TestUserService_DeleteAccount(t *testing.T) {
 		Role: "customer",
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, jwt := setupTestService(t)
 
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
@@ -289,11 +319,15 @@ func TestUserService_DeleteAccount(t *testing.T) {
 	t.Run("Success Case - Account Deleted", func(t *testing.T) {
 		repo.On("GetUsersById", userID).Return(user, nil).Once()
 		repo.On("DeleteAccount", userID).Return(nil).Once()
+		repo.On("DeleteChatsByUserID", userID).Return(nil).Once()
+		repo.On("MarkChatsPurged", userID).Return(nil).Once()
+		jwt.On("RevokeUserTokens", userID).Return(nil).Once()
 
 		err := service.DeleteAccount(userID)
 
 		assert.Nil(t, err)
 		repo.AssertExpectations(t)
+		jwt.AssertExpectations(t)
 	})
 
 	t.Run("Failed Case - Error Deleting Account", func(t *testing.T) {
@@ -307,6 +341,105 @@ func TestUserService_DeleteAccount(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Success Case - Chat Deletion Left For Retry", func(t *testing.T) {
+		repo.On("GetUsersById", userID).Return(user, nil).Once()
+		repo.On("DeleteAccount", userID).Return(nil).Once()
+		repo.On("DeleteChatsByUserID", userID).Return(errors.New("mongo down")).Once()
+		jwt.On("RevokeUserTokens", userID).Return(nil).Once()
+
+		err := service.DeleteAccount(userID)
+
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+		jwt.AssertExpectations(t)
+	})
+}
+
+func TestUserService_PurgeDeletedChats(t *testing.T) {
+	t.Run("Success Case - Purges Pending Accounts", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("GetUserIDsPendingChatPurge", purgeDeletedChatsBatchSize).Return([]uint64{1, 2, 3}, nil).Once()
+		repo.On("DeleteChatsByUserID", uint64(1)).Return(nil).Once()
+		repo.On("MarkChatsPurged", uint64(1)).Return(nil).Once()
+		repo.On("DeleteChatsByUserID", uint64(2)).Return(errors.New("mongo down")).Once()
+		repo.On("DeleteChatsByUserID", uint64(3)).Return(nil).Once()
+		repo.On("MarkChatsPurged", uint64(3)).Return(nil).Once()
+
+		purged, err := service.PurgeDeletedChats()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, purged)
+		repo.AssertNotCalled(t, "MarkChatsPurged", uint64(2))
+	})
+
+	t.Run("Failed Case - Error Listing Accounts", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("GetUserIDsPendingChatPurge", purgeDeletedChatsBatchSize).Return(nil, errors.New("db down")).Once()
+
+		purged, err := service.PurgeDeletedChats()
+
+		assert.EqualError(t, err, "gagal mendapatkan akun yang riwayat chatnya belum dihapus")
+		assert.Equal(t, 0, purged)
+	})
+}
+
+func TestUserService_ExportUserData(t *testing.T) {
+	userID := uint64(1)
+	user := &entities.UserModels{
+		ID:        userID,
+		Email:     "user@example.com",
+		Name:      "User 1",
+		Password:  "hashedPassword",
+		TotalGram: 120,
+	}
+
+	t.Run("Success Case - Archive Contains Every Section", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("GetUsersById", userID).Return(user, nil).Once()
+		repo.On("GetAddressesByUserID", userID).Return([]*entities.AddressModels{{ID: 1, UserID: userID, City: "Bandung"}}, nil).Once()
+		repo.On("GetOrdersByUserID", userID).Return([]*entities.OrderModels{{
+			ID:           "order-1",
+			OrderDetails: []entities.OrderDetailsModels{{ProductID: 3, Quantity: 2, Product: entities.ProductModels{Name: "Tumbler"}}},
+		}}, nil).Once()
+		repo.On("GetReviewsByUserID", userID).Return([]*entities.ReviewModels{{ID: 1, ProductID: 3, Rating: 5}}, nil).Once()
+		repo.On("GetChallengeFormsByUserID", userID).Return([]*entities.ChallengeFormModels{{ID: 1, ChallengeID: 2}}, nil).Once()
+		repo.On("GetChatsByUserID", userID).Return([]entities.ChatModel{{UserID: userID, Role: "user", Text: "halo"}}, nil).Once()
+
+		result, err := service.ExportUserData(userID)
+
+		assert.NoError(t, err)
+		archive, err := zip.NewReader(bytes.NewReader(result), int64(len(result)))
+		assert.NoError(t, err)
+		contents := map[string]string{}
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			assert.NoError(t, err)
+			data, _ := io.ReadAll(reader)
+			reader.Close()
+			contents[file.Name] = string(data)
+		}
+		assert.Len(t, contents, 6)
+		assert.Contains(t, contents["profile.json"], "user@example.com")
+		assert.NotContains(t, contents["profile.json"], "hashedPassword")
+		assert.Contains(t, contents["orders.json"], "Tumbler")
+		assert.Contains(t, contents["chats.json"], "halo")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Error Getting Chats", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("GetUsersById", userID).Return(user, nil).Once()
+		repo.On("GetAddressesByUserID", userID).Return(nil, nil).Once()
+		repo.On("GetOrdersByUserID", userID).Return(nil, nil).Once()
+		repo.On("GetReviewsByUserID", userID).Return(nil, nil).Once()
+		repo.On("GetChallengeFormsByUserID", userID).Return(nil, nil).Once()
+		repo.On("GetChatsByUserID", userID).Return(nil, errors.New("mongo down")).Once()
+
+		result, err := service.ExportUserData(userID)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "gagal mengambil riwayat chat")
+	})
 }
 
 func TestDetermineLevel(t *testing.T) {
@@ -337,7 +470,7 @@ func TestUserService_UpdateUserExp(t *testing.T) {
 	}
 
 	t.Run("Failed Case - Error Updating Level", func(t *testing.T) {
-		service, repo, _ := setupTestService(t)
+		service, repo, _, _ := setupTestService(t)
 		expectedErr := errors.New("gagal memperbarui Level")
 		repo.On("GetUsersById", userID).Return(user, nil).Once()
 		repo.On("UpdateUserExp", userID, exp).Return(user, nil).Once()
@@ -351,7 +484,7 @@ func TestUserService_UpdateUserExp(t *testing.T) {
 	})
 
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
-		service, repo, _ := setupTestService(t)
+		service, repo, _, _ := setupTestService(t)
 		expectedErr := errors.New("pengguna tidak ditemukan")
 		repo.On("GetUsersById", userID).Return(nil, expectedErr).Once()
 
@@ -363,7 +496,7 @@ func TestUserService_UpdateUserExp(t *testing.T) {
 	})
 
 	t.Run("Failed Case - Error Updating Exp", func(t *testing.T) {
-		service, repo, _ := setupTestService(t)
+		service, repo, _, _ := setupTestService(t)
 		expectedErr := errors.New("gagal memperbarui Exp")
 		repo.On("GetUsersById", userID).Return(user, nil).Once()
 		repo.On("UpdateUserExp", userID, exp).Return(nil, expectedErr).Once()
@@ -376,7 +509,7 @@ func TestUserService_UpdateUserExp(t *testing.T) {
 	})
 
 	t.Run("Success Case - Exp Updated", func(t *testing.T) {
-		service, repo, _ := setupTestService(t)
+		service, repo, _, _ := setupTestService(t)
 		user.Exp = exp
 		repo.On("GetUsersById", userID).Return(user, nil).Once()
 		repo.On("UpdateUserExp", userID, exp).Return(user, nil).Once()
@@ -401,7 +534,7 @@ func TestUserService_UpdateUserContribution(t *testing.T) {
 		TotalGram: 0,
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Failed Case - User Not Found", func(t *testing.T) {
 		expectedErr := errors.New("pengguna tidak ditemukan")
@@ -449,7 +582,7 @@ func TestUserService_GetUserLevel(t *testing.T) {
 		Level: expectedLevel,
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get User Level", func(t *testing.T) {
 		repo.On("GetUsersById", userID).Return(user, nil).Once()
@@ -495,7 +628,7 @@ func TestUserService_UpdateUserChallengeFollow(t *testing.T) {
 		TotalChallenge: expectedChallenge,
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Update User Challenge Follow", func(t *testing.T) {
 		repo.On("UpdateUserChallengeFollow", userID, expectedChallenge).Return(user, nil).Once()
@@ -532,7 +665,7 @@ func TestUserService_GetLeaderboardByExp(t *testing.T) {
 		},
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get Leaderboard by Exp", func(t *testing.T) {
 		repo.On("GetLeaderboardByExp", limit).Return(expectedUsers, nil).Once()
@@ -565,7 +698,7 @@ func TestUserService_GetUserTransactionActivity(t *testing.T) {
 	expectedFailedOrder := 2
 	expectedTotalOrder := 7
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get User Transaction Activity", func(t *testing.T) {
 		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID}, nil).Once()
@@ -614,7 +747,7 @@ func TestUserService_GetUserChallengeActivity(t *testing.T) {
 	expectedFailedChallenge := 3
 	expectedTotalChallenge := 11
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get User Challenge Activity", func(t *testing.T) {
 		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID}, nil).Once()
@@ -667,7 +800,7 @@ func TestUserService_GetUserProfile(t *testing.T) {
 		Role:  "user",
 	}
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get User Profile", func(t *testing.T) {
 		repo.On("GetUsersById", userID).Return(expectedUser, nil).Once()
@@ -704,7 +837,7 @@ func TestUserService_GetUsersBySearchAndFilter(t *testing.T) {
 
 	expectedTotalUsers := int64(len(expectedUsers))
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get Users By Search And Filter", func(t *testing.T) {
 		repo.On("GetAllUsersBySearchAndFilter", page, perPage, search, levelFilter).Return(expectedUsers, expectedTotalUsers, nil).Once()
@@ -742,7 +875,7 @@ func TestUserService_GetUsersByLevel(t *testing.T) {
 
 	expectedTotalUsers := int64(len(expectedUsers))
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get Users By Level", func(t *testing.T) {
 		repo.On("GetFilterLevel", page, perPage, level).Return(expectedUsers, expectedTotalUsers, nil).Once()
@@ -780,7 +913,7 @@ func TestUserService_GetUsersByName(t *testing.T) {
 
 	expectedTotalItems := int64(len(expectedUsers))
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get Users By Name", func(t *testing.T) {
 		repo.On("FindByName", page, perPage, name).Return(expectedUsers, nil).Once()
@@ -831,7 +964,7 @@ func TestUserService_GetAllUsers(t *testing.T) {
 
 	expectedTotalItems := int64(len(expectedUsers))
 
-	service, repo, _ := setupTestService(t)
+	service, repo, _, _ := setupTestService(t)
 
 	t.Run("Success Case - Get All Users", func(t *testing.T) {
 		repo.On("FindAll", page, perPage).Return(expectedUsers, nil).Once()
@@ -877,7 +1010,7 @@ func TestUserService_ValidatePassword(t *testing.T) {
 	newPassword := "newPass123"
 	confirmPassword := "newPass123"
 
-	service, repo, hash := setupTestService(t)
+	service, repo, hash, _ := setupTestService(t)
 
 	t.Run("Success Case - Password Validation", func(t *testing.T) {
 		storedPassword := "$2a$10$12345678901234567890123456789012345678901234567890"
@@ -929,3 +1062,75 @@ func TestUserService_ValidatePassword(t *testing.T) {
 		assert.EqualError(t, err, expectedErr.Error())
 	})
 }
+
+func TestUserService_AwardUserRewards(t *testing.T) {
+	userID := uint64(1)
+
+	t.Run("Success Case - Level Rises With Exp", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("AddUserRewards", userID, uint64(600), uint64(20)).Return(nil).Once()
+		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID, Exp: 700, Level: "Bronze"}, nil).Once()
+		repo.On("UpdateUserLevel", userID, "Silver").Return(nil).Once()
+
+		err := service.AwardUserRewards(userID, 600, 20)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Level Unchanged", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("AddUserRewards", userID, uint64(30), uint64(20)).Return(nil).Once()
+		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID, Exp: 130, Level: "Bronze"}, nil).Once()
+
+		err := service.AwardUserRewards(userID, 30, 20)
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "UpdateUserLevel", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Add Error", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("AddUserRewards", userID, uint64(30), uint64(20)).Return(errors.New("db error")).Once()
+
+		err := service.AwardUserRewards(userID, 30, 20)
+
+		assert.EqualError(t, err, "gagal menambahkan exp pengguna")
+	})
+}
+
+func TestUserService_ReverseUserRewards(t *testing.T) {
+	userID := uint64(1)
+
+	t.Run("Success Case - Level Drops With Exp", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("DeductUserRewards", userID, uint64(600), uint64(20)).Return(nil).Once()
+		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID, Exp: 400, Level: "Silver"}, nil).Once()
+		repo.On("UpdateUserLevel", userID, "Bronze").Return(nil).Once()
+
+		err := service.ReverseUserRewards(userID, 600, 20)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Level Unchanged", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("DeductUserRewards", userID, uint64(30), uint64(20)).Return(nil).Once()
+		repo.On("GetUsersById", userID).Return(&entities.UserModels{ID: userID, Exp: 70, Level: "Bronze"}, nil).Once()
+
+		err := service.ReverseUserRewards(userID, 30, 20)
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "UpdateUserLevel", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed Case - Deduct Error", func(t *testing.T) {
+		service, repo, _, _ := setupTestService(t)
+		repo.On("DeductUserRewards", userID, uint64(30), uint64(20)).Return(errors.New("db error")).Once()
+
+		err := service.ReverseUserRewards(userID, 30, 20)
+
+		assert.EqualError(t, err, "gagal mengurangi exp pengguna")
+	})
+}
@@ -0,0 +1,34 @@
+package voucher
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+const (
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	codeLength   = 8
+)
+
+// NormalizeCode makes voucher codes case-insensitive by storing and looking
+// them up in upper case.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateCode returns a random code with the given prefix. The alphabet drops
+// 0, O, 1 and I so codes can be read out loud or typed from a print.
+func GenerateCode(prefix string) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(NormalizeCode(prefix))
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < codeLength; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(codeAlphabet[n.Int64()])
+	}
+	return builder.String(), nil
+}
@@ -3,32 +3,64 @@ package dto
 import "time"
 
 type CreateVoucherRequest struct {
-	Name        string    `form:"name" json:"name" validate:"required"`
-	Code        string    `form:"code" json:"code" validate:"required"`
-	Category    string    `form:"category" json:"category" validate:"required"`
-	Description string    `form:"description" json:"description" validate:"required"`
-	Discount    uint64    `form:"discount" json:"discount" validate:"required"`
-	StartDate   time.Time `form:"start_date" json:"start_date" validate:"required"`
-	EndDate     time.Time `form:"end_date" json:"end_date" validate:"required"`
-	MinPurchase uint64    `form:"min_purchase" json:"min_purchase" validate:"required"`
-	Stock       uint64    `form:"stock" json:"stock" validate:"required"`
-	Status      string    `form:"status" json:"status"`
+	Name         string    `form:"name" json:"name" validate:"required"`
+	Code         string    `form:"code" json:"code" validate:"required"`
+	Category     string    `form:"category" json:"category" validate:"required"`
+	Description  string    `form:"description" json:"description" validate:"required"`
+	DiscountType string    `form:"discount_type" json:"discount_type"`
+	Discount     uint64    `form:"discount" json:"discount"`
+	MaxDiscount  uint64    `form:"max_discount" json:"max_discount"`
+	StartDate    time.Time `form:"start_date" json:"start_date" validate:"required"`
+	EndDate      time.Time `form:"end_date" json:"end_date" validate:"required"`
+	MinPurchase  uint64    `form:"min_purchase" json:"min_purchase" validate:"required"`
+	Stock        uint64    `form:"stock" json:"stock" validate:"required"`
+	UsageLimit   uint64    `form:"usage_limit" json:"usage_limit"`
+	UsagePerUser uint64    `form:"usage_per_user" json:"usage_per_user"`
+	ProductIDs   []uint64  `form:"product_ids" json:"product_ids"`
+	CategoryIDs  []uint64  `form:"category_ids" json:"category_ids"`
+	Status       string    `form:"status" json:"status"`
 }
 
 type UpdateVoucherRequest struct {
-	Name        string    `form:"name" json:"name"`
-	Code        string    `form:"code" json:"code"`
-	Category    string    `form:"category" json:"category"`
-	Description string    `form:"description" json:"description"`
-	Discount    uint64    `form:"discount" json:"discount"`
-	StartDate   time.Time `form:"start_date" json:"start_date"`
-	EndDate     time.Time `form:"end_date" json:"end_date"`
-	MinPurchase uint64    `form:"min_purchase" json:"min_purchase"`
-	Stock       uint64    `form:"stock" json:"stock"`
-	Status      string    `form:"status" json:"status"`
+	Name         string    `form:"name" json:"name"`
+	Code         string    `form:"code" json:"code"`
+	Category     string    `form:"category" json:"category"`
+	Description  string    `form:"description" json:"description"`
+	DiscountType string    `form:"discount_type" json:"discount_type"`
+	Discount     uint64    `form:"discount" json:"discount"`
+	MaxDiscount  uint64    `form:"max_discount" json:"max_discount"`
+	StartDate    time.Time `form:"start_date" json:"start_date"`
+	EndDate      time.Time `form:"end_date" json:"end_date"`
+	MinPurchase  uint64    `form:"min_purchase" json:"min_purchase"`
+	Stock        uint64    `form:"stock" json:"stock"`
+	UsageLimit   uint64    `form:"usage_limit" json:"usage_limit"`
+	UsagePerUser uint64    `form:"usage_per_user" json:"usage_per_user"`
+	ProductIDs   []uint64  `form:"product_ids" json:"product_ids"`
+	CategoryIDs  []uint64  `form:"category_ids" json:"category_ids"`
+	Status       string    `form:"status" json:"status"`
 }
 
 type ClaimsVoucherRequest struct {
 	UserID    uint64 `form:"user_id" json:"user_id"`
 	VoucherID uint64 `form:"voucher_id" json:"voucher_id" validate:"required"`
 }
+
+type ValidateVoucherRequest struct {
+	VoucherID   uint64                `json:"voucher_id" validate:"required"`
+	ShipmentFee uint64                `json:"shipment_fee"`
+	Items       []ValidateVoucherItem `json:"items" validate:"required,dive"`
+}
+
+type ValidateVoucherItem struct {
+	ProductID uint64 `json:"product_id" validate:"required"`
+	Quantity  uint64 `json:"quantity" validate:"required"`
+}
+
+type GenerateVoucherCodesRequest struct {
+	Quantity int    `json:"quantity" validate:"required,min=1,max=1000"`
+	Prefix   string `json:"prefix" validate:"omitempty,alphanum,max=10"`
+}
+
+type RedeemVoucherCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
@@ -8,17 +8,24 @@ import (
 )
 
 type VoucherFormatter struct {
-	ID          uint64    `json:"id"`
-	Name        string    `json:"name"`
-	Code        string    `json:"code"`
-	Category    string    `json:"category"`
-	Description string    `json:"description"`
-	Discount    uint64    `json:"discount"`
-	StartDate   time.Time `json:"start_date"`
-	EndDate     time.Time `json:"end-date"`
-	MinPurchase uint64    `json:"min_purchase" `
-	Stock       uint64    `json:"stock" `
-	Status      string    `json:"status" `
+	ID           uint64    `json:"id"`
+	Name         string    `json:"name"`
+	Code         string    `json:"code"`
+	Category     string    `json:"category"`
+	Description  string    `json:"description"`
+	DiscountType string    `json:"discount_type"`
+	Discount     uint64    `json:"discount"`
+	MaxDiscount  uint64    `json:"max_discount"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end-date"`
+	MinPurchase  uint64    `json:"min_purchase" `
+	Stock        uint64    `json:"stock" `
+	UsageLimit   uint64    `json:"usage_limit"`
+	UsagePerUser uint64    `json:"usage_per_user"`
+	UsedCount    uint64    `json:"used_count"`
+	ProductIDs   []uint64  `json:"product_ids"`
+	CategoryIDs  []uint64  `json:"category_ids"`
+	Status       string    `json:"status" `
 }
 
 func FormatVoucher(voucher *entities.VoucherModels) *VoucherFormatter {
@@ -28,13 +35,30 @@ func FormatVoucher(voucher *entities.VoucherModels) *VoucherFormatter {
 	voucherFormatter.Code = voucher.Code
 	voucherFormatter.Category = voucher.Category
 	voucherFormatter.Description = voucher.Description
+	voucherFormatter.DiscountType = voucher.DiscountType
 	voucherFormatter.Discount = voucher.Discount
+	voucherFormatter.MaxDiscount = voucher.MaxDiscount
 	voucherFormatter.StartDate = voucher.StartDate
 	voucherFormatter.EndDate = voucher.EndDate
 	voucherFormatter.MinPurchase = voucher.MinPurchase
 	voucherFormatter.Stock = voucher.Stock
+	voucherFormatter.UsageLimit = voucher.UsageLimit
+	voucherFormatter.UsagePerUser = voucher.UsagePerUser
+	voucherFormatter.UsedCount = voucher.UsedCount
 	voucherFormatter.Status = voucher.Status
 
+	productIDs := make([]uint64, 0, len(voucher.Products))
+	for _, product := range voucher.Products {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	voucherFormatter.ProductIDs = productIDs
+
+	categoryIDs := make([]uint64, 0, len(voucher.Categories))
+	for _, category := range voucher.Categories {
+		categoryIDs = append(categoryIDs, category.CategoryID)
+	}
+	voucherFormatter.CategoryIDs = categoryIDs
+
 	return voucherFormatter
 }
 
@@ -133,3 +157,49 @@ func FormatterVoucherToClaims(vouchers []*entities.VoucherModels) []*VoucherToCl
 
 	return voucherToClaimsFormatters
 }
+
+type ValidateVoucherResponse struct {
+	VoucherID    uint64 `json:"voucher_id"`
+	Code         string `json:"code"`
+	DiscountType string `json:"discount_type"`
+	Discount     uint64 `json:"discount"`
+}
+
+func FormatValidateVoucher(voucher *entities.VoucherModels, discount uint64) *ValidateVoucherResponse {
+	return &ValidateVoucherResponse{
+		VoucherID:    voucher.ID,
+		Code:         voucher.Code,
+		DiscountType: voucher.DiscountType,
+		Discount:     discount,
+	}
+}
+
+type VoucherCodeFormatter struct {
+	ID         uint64     `json:"id"`
+	VoucherID  uint64     `json:"voucher_id"`
+	Code       string     `json:"code"`
+	UserID     *uint64    `json:"user_id"`
+	OrderID    *string    `json:"order_id"`
+	RedeemedAt *time.Time `json:"redeemed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func FormatVoucherCode(code *entities.VoucherCodeModels) *VoucherCodeFormatter {
+	return &VoucherCodeFormatter{
+		ID:         code.ID,
+		VoucherID:  code.VoucherID,
+		Code:       code.Code,
+		UserID:     code.UserID,
+		OrderID:    code.OrderID,
+		RedeemedAt: code.RedeemedAt,
+		CreatedAt:  code.CreatedAt,
+	}
+}
+
+func FormatterVoucherCode(codes []*entities.VoucherCodeModels) []*VoucherCodeFormatter {
+	voucherCodeFormatters := make([]*VoucherCodeFormatter, 0, len(codes))
+	for _, code := range codes {
+		voucherCodeFormatters = append(voucherCodeFormatters, FormatVoucherCode(code))
+	}
+	return voucherCodeFormatters
+}
@@ -23,10 +23,6 @@ func NewVoucherHandler(service voucher.ServiceVoucherInterface) voucher.HandlerV
 
 func (h *VoucherHandler) CreateVoucher() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		voucherRequest := new(dto.CreateVoucherRequest)
 		if err := c.Bind(voucherRequest); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
@@ -37,15 +33,21 @@ func (h *VoucherHandler) CreateVoucher() echo.HandlerFunc {
 		}
 
 		newVoucher := &entities.VoucherModels{
-			Name:        voucherRequest.Name,
-			Code:        voucherRequest.Code,
-			Category:    voucherRequest.Category,
-			Description: voucherRequest.Description,
-			Discount:    voucherRequest.Discount,
-			StartDate:   voucherRequest.StartDate,
-			EndDate:     voucherRequest.EndDate,
-			MinPurchase: voucherRequest.MinPurchase,
-			Stock:       voucherRequest.Stock,
+			Name:         voucherRequest.Name,
+			Code:         voucherRequest.Code,
+			Category:     voucherRequest.Category,
+			Description:  voucherRequest.Description,
+			DiscountType: voucherRequest.DiscountType,
+			Discount:     voucherRequest.Discount,
+			MaxDiscount:  voucherRequest.MaxDiscount,
+			StartDate:    voucherRequest.StartDate,
+			EndDate:      voucherRequest.EndDate,
+			MinPurchase:  voucherRequest.MinPurchase,
+			Stock:        voucherRequest.Stock,
+			UsageLimit:   voucherRequest.UsageLimit,
+			UsagePerUser: voucherRequest.UsagePerUser,
+			Products:     voucherProducts(voucherRequest.ProductIDs),
+			Categories:   voucherCategories(voucherRequest.CategoryIDs),
 		}
 
 		result, err := h.service.CreateVoucher(newVoucher)
@@ -61,10 +63,6 @@ func (h *VoucherHandler) CreateVoucher() echo.HandlerFunc {
 
 func (h *VoucherHandler) GetAllVouchers() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		page, _ := strconv.Atoi(c.QueryParam("page"))
 		pageConv, _ := strconv.Atoi(strconv.Itoa(page))
 		perPage := 8
@@ -100,10 +98,6 @@ func (h *VoucherHandler) GetAllVouchers() echo.HandlerFunc {
 
 func (h *VoucherHandler) UpdateVouchers() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.UpdateVoucherRequest)
 		voucherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
@@ -117,17 +111,23 @@ func (h *VoucherHandler) UpdateVouchers() echo.HandlerFunc {
 			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
 		}
 		updatedVoucher := &entities.VoucherModels{
-			ID:          voucherID,
-			Name:        req.Name,
-			Code:        req.Code,
-			Category:    req.Category,
-			Description: req.Description,
-			Discount:    req.Discount,
-			StartDate:   req.StartDate,
-			EndDate:     req.EndDate,
-			MinPurchase: req.MinPurchase,
-			Stock:       req.Stock,
-			Status:      req.Status,
+			ID:           voucherID,
+			Name:         req.Name,
+			Code:         req.Code,
+			Category:     req.Category,
+			Description:  req.Description,
+			DiscountType: req.DiscountType,
+			Discount:     req.Discount,
+			MaxDiscount:  req.MaxDiscount,
+			StartDate:    req.StartDate,
+			EndDate:      req.EndDate,
+			MinPurchase:  req.MinPurchase,
+			Stock:        req.Stock,
+			UsageLimit:   req.UsageLimit,
+			UsagePerUser: req.UsagePerUser,
+			Products:     voucherProducts(req.ProductIDs),
+			Categories:   voucherCategories(req.CategoryIDs),
+			Status:       req.Status,
 		}
 		err = h.service.UpdateVoucher(voucherID, updatedVoucher)
 		if err != nil {
@@ -140,10 +140,6 @@ func (h *VoucherHandler) UpdateVouchers() echo.HandlerFunc {
 
 func (h *VoucherHandler) DeleteVoucherById() echo.HandlerFunc {
 	return func(c echo.Context) error {
-		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "admin" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		voucherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
 		if err != nil {
 			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
@@ -173,9 +169,6 @@ func (h *VoucherHandler) GetVoucherById() echo.HandlerFunc {
 func (h *VoucherHandler) ClaimVoucher() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		req := new(dto.ClaimsVoucherRequest)
 		if err := c.Bind(req); err != nil {
 			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
@@ -201,9 +194,6 @@ func (h *VoucherHandler) ClaimVoucher() echo.HandlerFunc {
 func (h *VoucherHandler) GetVoucherUser() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 		result, err := h.service.GetUserVouchers(currentUser.ID)
 		if err != nil {
 			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan kupon user: "+err.Error())
@@ -221,9 +211,6 @@ func (h *VoucherHandler) GetVoucherUser() echo.HandlerFunc {
 func (h *VoucherHandler) GetAllVouchersToClaims() echo.HandlerFunc {
 	return func(c echo.Context) error {
 		currentUser := c.Get("CurrentUser").(*entities.UserModels)
-		if currentUser.Role != "customer" {
-			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
-		}
 
 		limit := 7
 		result, err := h.service.GetAllVoucherToClaims(limit, currentUser.ID)
@@ -233,3 +220,131 @@ func (h *VoucherHandler) GetAllVouchersToClaims() echo.HandlerFunc {
 		return response.SendSuccessResponse(c, "Berhasil mendapatkan daftar kupon", dto.FormatterVoucherToClaims(result))
 	}
 }
+
+func (h *VoucherHandler) ValidateVoucher() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		req := new(dto.ValidateVoucherRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, discount, err := h.service.ValidateVoucher(currentUser.ID, req)
+		if err != nil {
+			if err.Error() == "kupon tidak ditemukan" || err.Error() == "produk tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Kupon tidak dapat digunakan: "+err.Error())
+		}
+
+		return response.SendSuccessResponse(c, "Kupon dapat digunakan", dto.FormatValidateVoucher(result, discount))
+	}
+}
+
+// voucherProducts keeps a nil slice nil so an update without product_ids
+// leaves the existing scope untouched, while an empty list clears it.
+func voucherProducts(productIDs []uint64) []entities.VoucherProductModels {
+	if productIDs == nil {
+		return nil
+	}
+	products := make([]entities.VoucherProductModels, 0, len(productIDs))
+	for _, productID := range productIDs {
+		products = append(products, entities.VoucherProductModels{ProductID: productID})
+	}
+	return products
+}
+
+func voucherCategories(categoryIDs []uint64) []entities.VoucherCategoryModels {
+	if categoryIDs == nil {
+		return nil
+	}
+	categories := make([]entities.VoucherCategoryModels, 0, len(categoryIDs))
+	for _, categoryID := range categoryIDs {
+		categories = append(categories, entities.VoucherCategoryModels{CategoryID: categoryID})
+	}
+	return categories
+}
+
+func (h *VoucherHandler) RedeemVoucherCode() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		currentUser := c.Get("CurrentUser").(*entities.UserModels)
+		req := new(dto.RedeemVoucherCodeRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, err := h.service.RedeemCode(currentUser.ID, req.Code)
+		if err != nil {
+			switch err.Error() {
+			case "terlalu banyak percobaan kode kupon, silakan coba lagi nanti":
+				return response.SendStatusTooManyRequestsResponse(c, err.Error())
+			case "kode kupon tidak valid":
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			case "kode kupon sudah digunakan", "kupon telah diklaim":
+				return response.SendStatusConflictResponse(c, err.Error())
+			}
+			return response.SendBadRequestResponse(c, "Gagal klaim kupon: "+err.Error())
+		}
+
+		return response.SendSuccessResponse(c, "Berhasil klaim kupon", dto.FormatVoucher(result))
+	}
+}
+
+func (h *VoucherHandler) GenerateVoucherCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		voucherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		req := new(dto.GenerateVoucherCodesRequest)
+		if err := c.Bind(req); err != nil {
+			return response.SendBadRequestResponse(c, "Format input yang Anda masukkan tidak sesuai.")
+		}
+		if err := utils.ValidateStruct(req); err != nil {
+			return response.SendBadRequestResponse(c, "Validasi gagal: "+err.Error())
+		}
+
+		result, err := h.service.GenerateCodes(voucherID, req)
+		if err != nil {
+			if err.Error() == "kupon tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal membuat kode kupon: "+err.Error())
+		}
+
+		return response.SendStatusCreatedResponse(c, "Kode kupon berhasil dibuat", dto.FormatterVoucherCode(result))
+	}
+}
+
+func (h *VoucherHandler) GetVoucherCodes() echo.HandlerFunc {
+	return func(c echo.Context) error {
+		voucherID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+		if err != nil {
+			return response.SendBadRequestResponse(c, "Format ID yang Anda masukkan tidak sesuai.")
+		}
+		page, _ := strconv.Atoi(c.QueryParam("page"))
+		if page <= 0 {
+			page = 1
+		}
+		perPage := 50
+
+		codes, totalItems, err := h.service.GetVoucherCodes(voucherID, page, perPage)
+		if err != nil {
+			if err.Error() == "kupon tidak ditemukan" {
+				return response.SendStatusNotFoundResponse(c, err.Error())
+			}
+			return response.SendStatusInternalServerResponse(c, "Gagal mendapatkan daftar kode kupon: "+err.Error())
+		}
+		currentPage, totalPages := h.service.CalculatePaginationValues(page, int(totalItems), perPage)
+		nextPage := h.service.GetNextPage(currentPage, totalPages)
+		prevPage := h.service.GetPrevPage(currentPage)
+
+		return response.SendPaginationResponse(c, dto.FormatterVoucherCode(codes), currentPage, totalPages, int(totalItems), nextPage, prevPage, "Berhasil mendapatkan daftar kode kupon")
+	}
+}
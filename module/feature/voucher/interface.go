@@ -2,10 +2,13 @@ package voucher
 
 import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/dto"
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
 type RepositoryVoucherInterface interface {
+	WithTx(tx *gorm.DB) RepositoryVoucherInterface
 	CreateVoucher(newData *entities.VoucherModels) (*entities.VoucherModels, error)
 	FindAllVoucher(page, perPage int) ([]*entities.VoucherModels, error)
 	GetTotalVoucherCount() (int64, error)
@@ -19,6 +22,7 @@ type RepositoryVoucherInterface interface {
 	DeleteUserVoucherClaims(userID, voucherID uint64) error
 	GetUserVoucherClaims(userID uint64) ([]*entities.VoucherClaimModels, error)
 	GetVoucherByCode(code string) (*entities.VoucherModels, error)
+	IsVoucherCodeUsed(code string) (bool, error)
 	FindByStatus(page, perPage int, status string) ([]*entities.VoucherModels, error)
 	GetTotalVoucherCountByStatus(status string) (int64, error)
 	FindByCategory(page, perPage int, category string) ([]*entities.VoucherModels, error)
@@ -26,9 +30,24 @@ type RepositoryVoucherInterface interface {
 	FindByStatusCategory(page, perPage int, status, category string) ([]*entities.VoucherModels, error)
 	GetTotalVoucherCountByStatusCategory(status, category string) (int64, error)
 	FindAllVoucherToClaims(limit int, userID uint64) ([]*entities.VoucherModels, error)
+	LockVoucher(voucherID uint64) (*entities.VoucherModels, error)
+	CountUserVoucherUsage(userID, voucherID uint64) (int64, error)
+	IncreaseVoucherUsage(voucherID uint64) (bool, error)
+	CreateVoucherUsage(usage *entities.VoucherUsageModels) error
+	GetVoucherUsageByOrderID(orderID string) (*entities.VoucherUsageModels, error)
+	DeleteVoucherUsage(usage *entities.VoucherUsageModels) error
+	GetVoucherCodeByCode(code string) (*entities.VoucherCodeModels, error)
+	GetExistingVoucherCodes(codes []string) ([]string, error)
+	CreateVoucherCodes(codes []*entities.VoucherCodeModels) error
+	FindVoucherCodes(voucherID uint64, page, perPage int) ([]*entities.VoucherCodeModels, error)
+	GetTotalVoucherCodeCount(voucherID uint64) (int64, error)
+	RedeemVoucherCode(codeID, userID uint64, orderID *string) (bool, error)
+	AttachVoucherCodeOrder(voucherID, userID uint64, orderID string) error
+	ReleaseVoucherCode(orderID string) error
 }
 
 type ServiceVoucherInterface interface {
+	WithTx(tx *gorm.DB) ServiceVoucherInterface
 	CreateVoucher(newData *entities.VoucherModels) (*entities.VoucherModels, error)
 	DeleteVoucher(voucherID uint64) error
 	GetVoucherById(voucherID uint64) (*entities.VoucherModels, error)
@@ -45,6 +64,14 @@ type ServiceVoucherInterface interface {
 	GetVoucherByCategory(page, perPage int, category string) ([]*entities.VoucherModels, int64, error)
 	GetVoucherByStatusCategory(page, perPage int, status, category string) ([]*entities.VoucherModels, int64, error)
 	GetAllVoucherToClaims(limit int, userID uint64) ([]*entities.VoucherModels, error)
+	CalculateDiscount(userID uint64, vouchers *entities.VoucherModels, cart Cart) (uint64, error)
+	ValidateVoucher(userID uint64, request *dto.ValidateVoucherRequest) (*entities.VoucherModels, uint64, error)
+	RecordUsage(userID, voucherID uint64, orderID, code string, discount uint64) error
+	ReleaseUsage(orderID string) error
+	ResolveCode(userID uint64, code string) (*entities.VoucherModels, error)
+	RedeemCode(userID uint64, code string) (*entities.VoucherModels, error)
+	GenerateCodes(voucherID uint64, request *dto.GenerateVoucherCodesRequest) ([]*entities.VoucherCodeModels, error)
+	GetVoucherCodes(voucherID uint64, page, perPage int) ([]*entities.VoucherCodeModels, int64, error)
 }
 
 type HandlerVoucherInterface interface {
@@ -56,4 +83,8 @@ type HandlerVoucherInterface interface {
 	ClaimVoucher() echo.HandlerFunc
 	GetVoucherUser() echo.HandlerFunc
 	GetAllVouchersToClaims() echo.HandlerFunc
+	ValidateVoucher() echo.HandlerFunc
+	RedeemVoucherCode() echo.HandlerFunc
+	GenerateVoucherCodes() echo.HandlerFunc
+	GetVoucherCodes() echo.HandlerFunc
 }
@@ -60,6 +60,22 @@ func (_m *HandlerVoucherInterface) DeleteVoucherById() echo.HandlerFunc {
 	return r0
 }
 
+// GenerateVoucherCodes provides a mock function with given fields:
+func (_m *HandlerVoucherInterface) GenerateVoucherCodes() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetAllVouchers provides a mock function with given fields:
 func (_m *HandlerVoucherInterface) GetAllVouchers() echo.HandlerFunc {
 	ret := _m.Called()
@@ -108,6 +124,22 @@ func (_m *HandlerVoucherInterface) GetVoucherById() echo.HandlerFunc {
 	return r0
 }
 
+// GetVoucherCodes provides a mock function with given fields:
+func (_m *HandlerVoucherInterface) GetVoucherCodes() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // GetVoucherUser provides a mock function with given fields:
 func (_m *HandlerVoucherInterface) GetVoucherUser() echo.HandlerFunc {
 	ret := _m.Called()
@@ -124,6 +156,22 @@ func (_m *HandlerVoucherInterface) GetVoucherUser() echo.HandlerFunc {
 	return r0
 }
 
+// RedeemVoucherCode provides a mock function with given fields:
+func (_m *HandlerVoucherInterface) RedeemVoucherCode() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // UpdateVouchers provides a mock function with given fields:
 func (_m *HandlerVoucherInterface) UpdateVouchers() echo.HandlerFunc {
 	ret := _m.Called()
@@ -140,6 +188,22 @@ func (_m *HandlerVoucherInterface) UpdateVouchers() echo.HandlerFunc {
 	return r0
 }
 
+// ValidateVoucher provides a mock function with given fields:
+func (_m *HandlerVoucherInterface) ValidateVoucher() echo.HandlerFunc {
+	ret := _m.Called()
+
+	var r0 echo.HandlerFunc
+	if rf, ok := ret.Get(0).(func() echo.HandlerFunc); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(echo.HandlerFunc)
+		}
+	}
+
+	return r0
+}
+
 // NewHandlerVoucherInterface creates a new instance of HandlerVoucherInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewHandlerVoucherInterface(t interface {
@@ -4,7 +4,9 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	voucher "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // RepositoryVoucherInterface is an autogenerated mock type for the RepositoryVoucherInterface type
@@ -12,6 +14,20 @@ type RepositoryVoucherInterface struct {
 	mock.Mock
 }
 
+// AttachVoucherCodeOrder provides a mock function with given fields: voucherID, userID, orderID
+func (_m *RepositoryVoucherInterface) AttachVoucherCodeOrder(voucherID uint64, userID uint64, orderID string) error {
+	ret := _m.Called(voucherID, userID, orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, string) error); ok {
+		r0 = rf(voucherID, userID, orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ClaimVoucher provides a mock function with given fields: claimVoucher
 func (_m *RepositoryVoucherInterface) ClaimVoucher(claimVoucher *entities.VoucherClaimModels) error {
 	ret := _m.Called(claimVoucher)
@@ -26,6 +42,30 @@ func (_m *RepositoryVoucherInterface) ClaimVoucher(claimVoucher *entities.Vouche
 	return r0
 }
 
+// CountUserVoucherUsage provides a mock function with given fields: userID, voucherID
+func (_m *RepositoryVoucherInterface) CountUserVoucherUsage(userID uint64, voucherID uint64) (int64, error) {
+	ret := _m.Called(userID, voucherID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (int64, error)); ok {
+		return rf(userID, voucherID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) int64); ok {
+		r0 = rf(userID, voucherID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(userID, voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateVoucher provides a mock function with given fields: newData
 func (_m *RepositoryVoucherInterface) CreateVoucher(newData *entities.VoucherModels) (*entities.VoucherModels, error) {
 	ret := _m.Called(newData)
@@ -52,6 +92,34 @@ func (_m *RepositoryVoucherInterface) CreateVoucher(newData *entities.VoucherMod
 	return r0, r1
 }
 
+// CreateVoucherCodes provides a mock function with given fields: codes
+func (_m *RepositoryVoucherInterface) CreateVoucherCodes(codes []*entities.VoucherCodeModels) error {
+	ret := _m.Called(codes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*entities.VoucherCodeModels) error); ok {
+		r0 = rf(codes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateVoucherUsage provides a mock function with given fields: usage
+func (_m *RepositoryVoucherInterface) CreateVoucherUsage(usage *entities.VoucherUsageModels) error {
+	ret := _m.Called(usage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.VoucherUsageModels) error); ok {
+		r0 = rf(usage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteUserVoucherClaims provides a mock function with given fields: userID, voucherID
 func (_m *RepositoryVoucherInterface) DeleteUserVoucherClaims(userID uint64, voucherID uint64) error {
 	ret := _m.Called(userID, voucherID)
@@ -80,6 +148,20 @@ func (_m *RepositoryVoucherInterface) DeleteVoucher(voucherID uint64) error {
 	return r0
 }
 
+// DeleteVoucherUsage provides a mock function with given fields: usage
+func (_m *RepositoryVoucherInterface) DeleteVoucherUsage(usage *entities.VoucherUsageModels) error {
+	ret := _m.Called(usage)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.VoucherUsageModels) error); ok {
+		r0 = rf(usage)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindAllVoucher provides a mock function with given fields: page, perPage
 func (_m *RepositoryVoucherInterface) FindAllVoucher(page int, perPage int) ([]*entities.VoucherModels, error) {
 	ret := _m.Called(page, perPage)
@@ -210,6 +292,82 @@ func (_m *RepositoryVoucherInterface) FindByStatusCategory(page int, perPage int
 	return r0, r1
 }
 
+// FindVoucherCodes provides a mock function with given fields: voucherID, page, perPage
+func (_m *RepositoryVoucherInterface) FindVoucherCodes(voucherID uint64, page int, perPage int) ([]*entities.VoucherCodeModels, error) {
+	ret := _m.Called(voucherID, page, perPage)
+
+	var r0 []*entities.VoucherCodeModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]*entities.VoucherCodeModels, error)); ok {
+		return rf(voucherID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, int, int) []*entities.VoucherCodeModels); ok {
+		r0 = rf(voucherID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.VoucherCodeModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, int, int) error); ok {
+		r1 = rf(voucherID, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExistingVoucherCodes provides a mock function with given fields: codes
+func (_m *RepositoryVoucherInterface) GetExistingVoucherCodes(codes []string) ([]string, error) {
+	ret := _m.Called(codes)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) ([]string, error)); ok {
+		return rf(codes)
+	}
+	if rf, ok := ret.Get(0).(func([]string) []string); ok {
+		r0 = rf(codes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(codes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTotalVoucherCodeCount provides a mock function with given fields: voucherID
+func (_m *RepositoryVoucherInterface) GetTotalVoucherCodeCount(voucherID uint64) (int64, error) {
+	ret := _m.Called(voucherID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (int64, error)); ok {
+		return rf(voucherID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
+		r0 = rf(voucherID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTotalVoucherCount provides a mock function with given fields:
 func (_m *RepositoryVoucherInterface) GetTotalVoucherCount() (int64, error) {
 	ret := _m.Called()
@@ -408,6 +566,82 @@ func (_m *RepositoryVoucherInterface) GetVoucherCategory(voucherID uint64) (stri
 	return r0, r1
 }
 
+// GetVoucherCodeByCode provides a mock function with given fields: code
+func (_m *RepositoryVoucherInterface) GetVoucherCodeByCode(code string) (*entities.VoucherCodeModels, error) {
+	ret := _m.Called(code)
+
+	var r0 *entities.VoucherCodeModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.VoucherCodeModels, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entities.VoucherCodeModels); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherCodeModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVoucherUsageByOrderID provides a mock function with given fields: orderID
+func (_m *RepositoryVoucherInterface) GetVoucherUsageByOrderID(orderID string) (*entities.VoucherUsageModels, error) {
+	ret := _m.Called(orderID)
+
+	var r0 *entities.VoucherUsageModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*entities.VoucherUsageModels, error)); ok {
+		return rf(orderID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *entities.VoucherUsageModels); ok {
+		r0 = rf(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherUsageModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncreaseVoucherUsage provides a mock function with given fields: voucherID
+func (_m *RepositoryVoucherInterface) IncreaseVoucherUsage(voucherID uint64) (bool, error) {
+	ret := _m.Called(voucherID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (bool, error)); ok {
+		return rf(voucherID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) bool); ok {
+		r0 = rf(voucherID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsVoucherAlreadyClaimed provides a mock function with given fields: userID, voucherID
 func (_m *RepositoryVoucherInterface) IsVoucherAlreadyClaimed(userID uint64, voucherID uint64) (bool, error) {
 	ret := _m.Called(userID, voucherID)
@@ -432,6 +666,80 @@ func (_m *RepositoryVoucherInterface) IsVoucherAlreadyClaimed(userID uint64, vou
 	return r0, r1
 }
 
+// IsVoucherCodeUsed provides a mock function with given fields: code
+func (_m *RepositoryVoucherInterface) IsVoucherCodeUsed(code string) (bool, error) {
+	ret := _m.Called(code)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(code)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockVoucher provides a mock function with given fields: voucherID
+func (_m *RepositoryVoucherInterface) LockVoucher(voucherID uint64) (*entities.VoucherModels, error) {
+	ret := _m.Called(voucherID)
+
+	var r0 *entities.VoucherModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (*entities.VoucherModels, error)); ok {
+		return rf(voucherID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) *entities.VoucherModels); ok {
+		r0 = rf(voucherID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(voucherID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RedeemVoucherCode provides a mock function with given fields: codeID, userID, orderID
+func (_m *RepositoryVoucherInterface) RedeemVoucherCode(codeID uint64, userID uint64, orderID *string) (bool, error) {
+	ret := _m.Called(codeID, userID, orderID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, *string) (bool, error)); ok {
+		return rf(codeID, userID, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64, *string) bool); ok {
+		r0 = rf(codeID, userID, orderID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64, *string) error); ok {
+		r1 = rf(codeID, userID, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ReduceStockWhenClaimed provides a mock function with given fields: voucherID, quantity
 func (_m *RepositoryVoucherInterface) ReduceStockWhenClaimed(voucherID uint64, quantity uint64) error {
 	ret := _m.Called(voucherID, quantity)
@@ -446,6 +754,20 @@ func (_m *RepositoryVoucherInterface) ReduceStockWhenClaimed(voucherID uint64, q
 	return r0
 }
 
+// ReleaseVoucherCode provides a mock function with given fields: orderID
+func (_m *RepositoryVoucherInterface) ReleaseVoucherCode(orderID string) error {
+	ret := _m.Called(orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateVoucher provides a mock function with given fields: voucherID, updatedVoucher
 func (_m *RepositoryVoucherInterface) UpdateVoucher(voucherID uint64, updatedVoucher *entities.VoucherModels) error {
 	ret := _m.Called(voucherID, updatedVoucher)
@@ -460,6 +782,22 @@ func (_m *RepositoryVoucherInterface) UpdateVoucher(voucherID uint64, updatedVou
 	return r0
 }
 
+// WithTx provides a mock function with given fields: tx
+func (_m *RepositoryVoucherInterface) WithTx(tx *gorm.DB) voucher.RepositoryVoucherInterface {
+	ret := _m.Called(tx)
+
+	var r0 voucher.RepositoryVoucherInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) voucher.RepositoryVoucherInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(voucher.RepositoryVoucherInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewRepositoryVoucherInterface creates a new instance of RepositoryVoucherInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepositoryVoucherInterface(t interface {
@@ -4,7 +4,10 @@ package mocks
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	voucher "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
+	dto "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/dto"
 	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // ServiceVoucherInterface is an autogenerated mock type for the ServiceVoucherInterface type
@@ -12,6 +15,30 @@ type ServiceVoucherInterface struct {
 	mock.Mock
 }
 
+// CalculateDiscount provides a mock function with given fields: userID, vouchers, cart
+func (_m *ServiceVoucherInterface) CalculateDiscount(userID uint64, vouchers *entities.VoucherModels, cart voucher.Cart) (uint64, error) {
+	ret := _m.Called(userID, vouchers, cart)
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *entities.VoucherModels, voucher.Cart) (uint64, error)); ok {
+		return rf(userID, vouchers, cart)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *entities.VoucherModels, voucher.Cart) uint64); ok {
+		r0 = rf(userID, vouchers, cart)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *entities.VoucherModels, voucher.Cart) error); ok {
+		r1 = rf(userID, vouchers, cart)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CalculatePaginationValues provides a mock function with given fields: page, totalItems, perPage
 func (_m *ServiceVoucherInterface) CalculatePaginationValues(page int, totalItems int, perPage int) (int, int) {
 	ret := _m.Called(page, totalItems, perPage)
@@ -128,6 +155,32 @@ func (_m *ServiceVoucherInterface) DeleteVoucherClaims(userID uint64, voucherID
 	return r0
 }
 
+// GenerateCodes provides a mock function with given fields: voucherID, request
+func (_m *ServiceVoucherInterface) GenerateCodes(voucherID uint64, request *dto.GenerateVoucherCodesRequest) ([]*entities.VoucherCodeModels, error) {
+	ret := _m.Called(voucherID, request)
+
+	var r0 []*entities.VoucherCodeModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, *dto.GenerateVoucherCodesRequest) ([]*entities.VoucherCodeModels, error)); ok {
+		return rf(voucherID, request)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *dto.GenerateVoucherCodesRequest) []*entities.VoucherCodeModels); ok {
+		r0 = rf(voucherID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.VoucherCodeModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *dto.GenerateVoucherCodesRequest) error); ok {
+		r1 = rf(voucherID, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllVoucher provides a mock function with given fields: page, perPage
 func (_m *ServiceVoucherInterface) GetAllVoucher(page int, perPage int) ([]*entities.VoucherModels, int64, error) {
 	ret := _m.Called(page, perPage)
@@ -366,6 +419,119 @@ func (_m *ServiceVoucherInterface) GetVoucherByStatusCategory(page int, perPage
 	return r0, r1, r2
 }
 
+// GetVoucherCodes provides a mock function with given fields: voucherID, page, perPage
+func (_m *ServiceVoucherInterface) GetVoucherCodes(voucherID uint64, page int, perPage int) ([]*entities.VoucherCodeModels, int64, error) {
+	ret := _m.Called(voucherID, page, perPage)
+
+	var r0 []*entities.VoucherCodeModels
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint64, int, int) ([]*entities.VoucherCodeModels, int64, error)); ok {
+		return rf(voucherID, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, int, int) []*entities.VoucherCodeModels); ok {
+		r0 = rf(voucherID, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entities.VoucherCodeModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, int, int) int64); ok {
+		r1 = rf(voucherID, page, perPage)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint64, int, int) error); ok {
+		r2 = rf(voucherID, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RecordUsage provides a mock function with given fields: userID, voucherID, orderID, code, discount
+func (_m *ServiceVoucherInterface) RecordUsage(userID uint64, voucherID uint64, orderID string, code string, discount uint64) error {
+	ret := _m.Called(userID, voucherID, orderID, code, discount)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64, string, string, uint64) error); ok {
+		r0 = rf(userID, voucherID, orderID, code, discount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedeemCode provides a mock function with given fields: userID, code
+func (_m *ServiceVoucherInterface) RedeemCode(userID uint64, code string) (*entities.VoucherModels, error) {
+	ret := _m.Called(userID, code)
+
+	var r0 *entities.VoucherModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string) (*entities.VoucherModels, error)); ok {
+		return rf(userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string) *entities.VoucherModels); ok {
+		r0 = rf(userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
+		r1 = rf(userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseUsage provides a mock function with given fields: orderID
+func (_m *ServiceVoucherInterface) ReleaseUsage(orderID string) error {
+	ret := _m.Called(orderID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(orderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveCode provides a mock function with given fields: userID, code
+func (_m *ServiceVoucherInterface) ResolveCode(userID uint64, code string) (*entities.VoucherModels, error) {
+	ret := _m.Called(userID, code)
+
+	var r0 *entities.VoucherModels
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string) (*entities.VoucherModels, error)); ok {
+		return rf(userID, code)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string) *entities.VoucherModels); ok {
+		r0 = rf(userID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string) error); ok {
+		r1 = rf(userID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateVoucher provides a mock function with given fields: voucherID, req
 func (_m *ServiceVoucherInterface) UpdateVoucher(voucherID uint64, req *entities.VoucherModels) error {
 	ret := _m.Called(voucherID, req)
@@ -380,6 +546,55 @@ func (_m *ServiceVoucherInterface) UpdateVoucher(voucherID uint64, req *entities
 	return r0
 }
 
+// ValidateVoucher provides a mock function with given fields: userID, request
+func (_m *ServiceVoucherInterface) ValidateVoucher(userID uint64, request *dto.ValidateVoucherRequest) (*entities.VoucherModels, uint64, error) {
+	ret := _m.Called(userID, request)
+
+	var r0 *entities.VoucherModels
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint64, *dto.ValidateVoucherRequest) (*entities.VoucherModels, uint64, error)); ok {
+		return rf(userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, *dto.ValidateVoucherRequest) *entities.VoucherModels); ok {
+		r0 = rf(userID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entities.VoucherModels)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, *dto.ValidateVoucherRequest) uint64); ok {
+		r1 = rf(userID, request)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint64, *dto.ValidateVoucherRequest) error); ok {
+		r2 = rf(userID, request)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WithTx provides a mock function with given fields: tx
+func (_m *ServiceVoucherInterface) WithTx(tx *gorm.DB) voucher.ServiceVoucherInterface {
+	ret := _m.Called(tx)
+
+	var r0 voucher.ServiceVoucherInterface
+	if rf, ok := ret.Get(0).(func(*gorm.DB) voucher.ServiceVoucherInterface); ok {
+		r0 = rf(tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(voucher.ServiceVoucherInterface)
+		}
+	}
+
+	return r0
+}
+
 // NewServiceVoucherInterface creates a new instance of ServiceVoucherInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewServiceVoucherInterface(t interface {
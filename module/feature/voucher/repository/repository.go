@@ -6,6 +6,7 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type VoucherRepository struct {
@@ -18,6 +19,12 @@ func NewVoucherRepository(db *gorm.DB) voucher.RepositoryVoucherInterface {
 	}
 }
 
+func (r *VoucherRepository) WithTx(tx *gorm.DB) voucher.RepositoryVoucherInterface {
+	return &VoucherRepository{
+		db: tx,
+	}
+}
+
 func (r *VoucherRepository) CreateVoucher(newData *entities.VoucherModels) (*entities.VoucherModels, error) {
 	if err := r.db.Create(&newData).Error; err != nil {
 		return newData, err
@@ -26,15 +33,45 @@ func (r *VoucherRepository) CreateVoucher(newData *entities.VoucherModels) (*ent
 	return newData, nil
 }
 
+// UpdateVoucher replaces the product and category scope only when the new value
+// is not nil, so an update that leaves them out keeps the current scope.
 func (r *VoucherRepository) UpdateVoucher(voucherID uint64, updatedVoucher *entities.VoucherModels) error {
 	var vouchers *entities.VoucherModels
 	if err := r.db.Where("id = ? AND deleted_at IS NULL", voucherID).First(&vouchers).Error; err != nil {
 		return err
 	}
-	if err := r.db.Updates(&updatedVoucher).Error; err != nil {
-		return err
-	}
-	return nil
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Updates(&updatedVoucher).Error; err != nil {
+			return err
+		}
+		if updatedVoucher.Products != nil {
+			if err := tx.Where("voucher_id = ?", voucherID).Delete(&entities.VoucherProductModels{}).Error; err != nil {
+				return err
+			}
+			for i := range updatedVoucher.Products {
+				updatedVoucher.Products[i].VoucherID = voucherID
+			}
+			if len(updatedVoucher.Products) > 0 {
+				if err := tx.Create(&updatedVoucher.Products).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if updatedVoucher.Categories != nil {
+			if err := tx.Where("voucher_id = ?", voucherID).Delete(&entities.VoucherCategoryModels{}).Error; err != nil {
+				return err
+			}
+			for i := range updatedVoucher.Categories {
+				updatedVoucher.Categories[i].VoucherID = voucherID
+			}
+			if len(updatedVoucher.Categories) > 0 {
+				if err := tx.Create(&updatedVoucher.Categories).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
 }
 
 func (r *VoucherRepository) DeleteVoucher(voucherID uint64) error {
@@ -53,7 +90,8 @@ func (r *VoucherRepository) DeleteVoucher(voucherID uint64) error {
 
 func (r *VoucherRepository) GetVoucherById(voucherID uint64) (*entities.VoucherModels, error) {
 	var vouchers *entities.VoucherModels
-	if err := r.db.Where("id = ? AND deleted_at IS NULL", voucherID).First(&vouchers).Error; err != nil {
+	if err := r.db.Preload("Products").Preload("Categories").
+		Where("id = ? AND deleted_at IS NULL", voucherID).First(&vouchers).Error; err != nil {
 		return nil, err
 	}
 	return vouchers, nil
@@ -126,12 +164,22 @@ func (r *VoucherRepository) GetUserVoucherClaims(userID uint64) ([]*entities.Vou
 
 func (r *VoucherRepository) GetVoucherByCode(code string) (*entities.VoucherModels, error) {
 	var vouchers entities.VoucherModels
-	if err := r.db.Where("code = ? AND deleted_at IS NULL", code).First(&vouchers).Error; err != nil {
+	if err := r.db.Where("UPPER(code) = UPPER(?) AND deleted_at IS NULL", code).First(&vouchers).Error; err != nil {
 		return nil, err
 	}
 	return &vouchers, nil
 }
 
+// IsVoucherCodeUsed also looks at deleted vouchers, because the unique index on
+// code keeps their codes reserved.
+func (r *VoucherRepository) IsVoucherCodeUsed(code string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entities.VoucherModels{}).Where("code = ?", code).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (r *VoucherRepository) FindByStatus(page, perPage int, status string) ([]*entities.VoucherModels, error) {
 	var vouchers []*entities.VoucherModels
 	offset := (page - 1) * perPage
@@ -220,3 +268,133 @@ func (r *VoucherRepository) FindAllVoucherToClaims(limit int, userID uint64) ([]
 
 	return vouchers, nil
 }
+
+// LockVoucher reads the voucher and holds its row until the transaction ends,
+// so usages of one voucher are recorded one at a time.
+func (r *VoucherRepository) LockVoucher(voucherID uint64) (*entities.VoucherModels, error) {
+	var vouchers entities.VoucherModels
+	if err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND deleted_at IS NULL", voucherID).
+		First(&vouchers).Error; err != nil {
+		return nil, err
+	}
+	return &vouchers, nil
+}
+
+func (r *VoucherRepository) CountUserVoucherUsage(userID, voucherID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.VoucherUsageModels{}).
+		Where("user_id = ? AND voucher_id = ?", userID, voucherID).
+		Count(&count).Error
+	return count, err
+}
+
+// IncreaseVoucherUsage counts one more use of the voucher unless its global
+// usage limit is already reached, in which case it returns false.
+func (r *VoucherRepository) IncreaseVoucherUsage(voucherID uint64) (bool, error) {
+	result := r.db.Model(&entities.VoucherModels{}).
+		Where("id = ? AND (usage_limit = 0 OR used_count < usage_limit)", voucherID).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *VoucherRepository) CreateVoucherUsage(usage *entities.VoucherUsageModels) error {
+	if err := r.db.Create(usage).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *VoucherRepository) GetVoucherUsageByOrderID(orderID string) (*entities.VoucherUsageModels, error) {
+	var usage entities.VoucherUsageModels
+	if err := r.db.Where("order_id = ?", orderID).First(&usage).Error; err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+func (r *VoucherRepository) DeleteVoucherUsage(usage *entities.VoucherUsageModels) error {
+	if err := r.db.Delete(usage).Error; err != nil {
+		return err
+	}
+	if err := r.db.Model(&entities.VoucherModels{}).
+		Where("id = ? AND used_count > 0", usage.VoucherID).
+		Update("used_count", gorm.Expr("used_count - 1")).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *VoucherRepository) GetVoucherCodeByCode(code string) (*entities.VoucherCodeModels, error) {
+	var voucherCode entities.VoucherCodeModels
+	if err := r.db.Where("code = ?", code).First(&voucherCode).Error; err != nil {
+		return nil, err
+	}
+	return &voucherCode, nil
+}
+
+func (r *VoucherRepository) GetExistingVoucherCodes(codes []string) ([]string, error) {
+	var existing []string
+	err := r.db.Model(&entities.VoucherCodeModels{}).Where("code IN ?", codes).Pluck("code", &existing).Error
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (r *VoucherRepository) CreateVoucherCodes(codes []*entities.VoucherCodeModels) error {
+	return r.db.CreateInBatches(codes, 100).Error
+}
+
+func (r *VoucherRepository) FindVoucherCodes(voucherID uint64, page, perPage int) ([]*entities.VoucherCodeModels, error) {
+	var codes []*entities.VoucherCodeModels
+	offset := (page - 1) * perPage
+	err := r.db.Where("voucher_id = ?", voucherID).Order("id ASC").
+		Offset(offset).Limit(perPage).Find(&codes).Error
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *VoucherRepository) GetTotalVoucherCodeCount(voucherID uint64) (int64, error) {
+	var count int64
+	err := r.db.Model(&entities.VoucherCodeModels{}).Where("voucher_id = ?", voucherID).Count(&count).Error
+	return count, err
+}
+
+// RedeemVoucherCode hands a single-use code to a user. The update only matches a
+// code nobody has redeemed, or one the same user redeemed but has not used on an
+// order yet, so two users typing the same code cannot both get it.
+func (r *VoucherRepository) RedeemVoucherCode(codeID, userID uint64, orderID *string) (bool, error) {
+	updates := map[string]interface{}{
+		"user_id":     userID,
+		"redeemed_at": gorm.Expr("COALESCE(redeemed_at, ?)", time.Now()),
+	}
+	if orderID != nil {
+		updates["order_id"] = *orderID
+	}
+	result := r.db.Model(&entities.VoucherCodeModels{}).
+		Where("id = ? AND (redeemed_at IS NULL OR (user_id = ? AND order_id IS NULL))", codeID, userID).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *VoucherRepository) AttachVoucherCodeOrder(voucherID, userID uint64, orderID string) error {
+	return r.db.Model(&entities.VoucherCodeModels{}).
+		Where("voucher_id = ? AND user_id = ? AND order_id IS NULL", voucherID, userID).
+		Limit(1).
+		Update("order_id", orderID).Error
+}
+
+func (r *VoucherRepository) ReleaseVoucherCode(orderID string) error {
+	return r.db.Model(&entities.VoucherCodeModels{}).
+		Where("order_id = ?", orderID).
+		Update("order_id", nil).Error
+}
@@ -0,0 +1,130 @@
+package voucher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+)
+
+const (
+	DiscountTypeFixed        = "fixed"
+	DiscountTypePercentage   = "percentage"
+	DiscountTypeFreeShipping = "free_shipping"
+)
+
+// CartItem is one line of a cart as the rules see it. Subtotal is the price the
+// customer pays for the line before any voucher is applied.
+type CartItem struct {
+	ProductID   uint64
+	CategoryIDs []uint64
+	Subtotal    uint64
+}
+
+// NewCartItem turns a product and quantity into the line the voucher rules
+// work on, priced after the product's own discount.
+func NewCartItem(products *entities.ProductModels, quantity uint64) CartItem {
+	categoryIDs := make([]uint64, 0, len(products.Categories))
+	for _, category := range products.Categories {
+		categoryIDs = append(categoryIDs, category.ID)
+	}
+	return CartItem{
+		ProductID:   products.ID,
+		CategoryIDs: categoryIDs,
+		Subtotal:    quantity * (products.Price - products.Discount),
+	}
+}
+
+type Cart struct {
+	Items       []CartItem
+	ShipmentFee uint64
+}
+
+func ValidateDiscountRule(voucher *entities.VoucherModels) error {
+	switch voucher.DiscountType {
+	case DiscountTypeFixed:
+		if voucher.Discount == 0 {
+			return errors.New("nilai diskon kupon wajib diisi")
+		}
+	case DiscountTypePercentage:
+		if voucher.Discount == 0 || voucher.Discount > 100 {
+			return errors.New("persentase diskon kupon harus antara 1 dan 100")
+		}
+	case DiscountTypeFreeShipping:
+	default:
+		return errors.New("jenis diskon kupon tidak valid")
+	}
+	return nil
+}
+
+// CalculateDiscount applies the voucher rules to a cart and returns the discount
+// in rupiah. Usage limits are not checked here because they need the database.
+// The discount never exceeds what it applies to, so the order total cannot
+// underflow.
+func CalculateDiscount(voucher *entities.VoucherModels, cart Cart, now time.Time) (uint64, error) {
+	if now.Before(voucher.StartDate) {
+		return 0, errors.New("kupon belum dapat digunakan")
+	}
+	if now.After(voucher.EndDate) {
+		return 0, errors.New("kupon sudah kedaluwarsa")
+	}
+
+	eligibleSubtotal := EligibleSubtotal(voucher, cart.Items)
+	if eligibleSubtotal == 0 {
+		return 0, errors.New("kupon tidak berlaku untuk produk yang dipilih")
+	}
+	if eligibleSubtotal < voucher.MinPurchase {
+		return 0, errors.New("total belanja belum memenuhi minimal pembelian kupon")
+	}
+
+	var discount, limit uint64
+	switch voucher.DiscountType {
+	case DiscountTypePercentage:
+		discount = eligibleSubtotal * voucher.Discount / 100
+		limit = eligibleSubtotal
+	case DiscountTypeFreeShipping:
+		discount = cart.ShipmentFee
+		limit = cart.ShipmentFee
+	default:
+		discount = voucher.Discount
+		limit = eligibleSubtotal
+	}
+
+	if voucher.MaxDiscount > 0 && discount > voucher.MaxDiscount {
+		discount = voucher.MaxDiscount
+	}
+	if discount > limit {
+		discount = limit
+	}
+	return discount, nil
+}
+
+// EligibleSubtotal sums the items the voucher is scoped to. A voucher without
+// product or category scope applies to the whole cart.
+func EligibleSubtotal(voucher *entities.VoucherModels, items []CartItem) uint64 {
+	scoped := len(voucher.Products) > 0 || len(voucher.Categories) > 0
+
+	var subtotal uint64
+	for _, item := range items {
+		if !scoped || isItemInScope(voucher, item) {
+			subtotal += item.Subtotal
+		}
+	}
+	return subtotal
+}
+
+func isItemInScope(voucher *entities.VoucherModels, item CartItem) bool {
+	for _, product := range voucher.Products {
+		if product.ProductID == item.ProductID {
+			return true
+		}
+	}
+	for _, category := range voucher.Categories {
+		for _, categoryID := range item.CategoryIDs {
+			if category.CategoryID == categoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
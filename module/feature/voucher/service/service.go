@@ -2,41 +2,78 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"strconv"
 	"time"
 
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/dto"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/database"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 type VoucherService struct {
-	repo        voucher.RepositoryVoucherInterface
-	userService users.ServiceUserInterface
+	repo           voucher.RepositoryVoucherInterface
+	userService    users.ServiceUserInterface
+	productService product.ServiceProductInterface
+	txManager      database.TransactionManagerInterface
+	cache          caching.CacheRepository
 }
 
-func NewVoucherService(repo voucher.RepositoryVoucherInterface, userService users.ServiceUserInterface) voucher.ServiceVoucherInterface {
+func NewVoucherService(repo voucher.RepositoryVoucherInterface, userService users.ServiceUserInterface, productService product.ServiceProductInterface, txManager database.TransactionManagerInterface, cache caching.CacheRepository) voucher.ServiceVoucherInterface {
 	return &VoucherService{
-		repo:        repo,
-		userService: userService,
+		repo:           repo,
+		userService:    userService,
+		productService: productService,
+		txManager:      txManager,
+		cache:          cache,
+	}
+}
+
+func (s *VoucherService) WithTx(tx *gorm.DB) voucher.ServiceVoucherInterface {
+	return &VoucherService{
+		repo:           s.repo.WithTx(tx),
+		userService:    s.userService,
+		productService: s.productService,
+		txManager:      s.txManager,
+		cache:          s.cache,
 	}
 }
 
 func (s *VoucherService) CreateVoucher(newData *entities.VoucherModels) (*entities.VoucherModels, error) {
-	if existingVoucher, _ := s.repo.GetVoucherByCode(newData.Code); existingVoucher != nil {
+	code := voucher.NormalizeCode(newData.Code)
+	if s.isCodeTaken(code) {
 		return nil, errors.New("kode kupon sudah digunakan")
 	}
 
 	newVoucher := &entities.VoucherModels{
-		Name:        newData.Name,
-		Code:        newData.Code,
-		Category:    newData.Category,
-		Description: newData.Description,
-		Discount:    newData.Discount,
-		StartDate:   newData.StartDate,
-		EndDate:     newData.EndDate,
-		MinPurchase: newData.MinPurchase,
-		Stock:       newData.Stock,
+		Name:         newData.Name,
+		Code:         code,
+		Category:     newData.Category,
+		Description:  newData.Description,
+		DiscountType: newData.DiscountType,
+		Discount:     newData.Discount,
+		MaxDiscount:  newData.MaxDiscount,
+		StartDate:    newData.StartDate,
+		EndDate:      newData.EndDate,
+		MinPurchase:  newData.MinPurchase,
+		Stock:        newData.Stock,
+		UsageLimit:   newData.UsageLimit,
+		UsagePerUser: newData.UsagePerUser,
+		Products:     newData.Products,
+		Categories:   newData.Categories,
+	}
+	if newVoucher.DiscountType == "" {
+		newVoucher.DiscountType = voucher.DiscountTypeFixed
+	}
+	if err := voucher.ValidateDiscountRule(newVoucher); err != nil {
+		return nil, err
 	}
 	currentTime := time.Now()
 	if currentTime.After(newVoucher.EndDate) {
@@ -56,22 +93,43 @@ func (s *VoucherService) UpdateVoucher(voucherID uint64, req *entities.VoucherMo
 	if err != nil {
 		return errors.New("kupon tidak ditemukan")
 	}
-	if existingVoucher, _ := s.repo.GetVoucherByCode(req.Code); existingVoucher != nil {
+	code := voucher.NormalizeCode(req.Code)
+	if code != "" && code != vouchers.Code && s.isCodeTaken(code) {
 		return errors.New("kode kupon sudah digunakan")
 	}
 	updatedVoucher := &entities.VoucherModels{
-		ID:          voucherID,
-		Name:        req.Name,
-		Code:        req.Code,
-		Category:    req.Category,
-		Description: req.Description,
-		Discount:    req.Discount,
-		StartDate:   req.StartDate,
-		EndDate:     req.EndDate,
-		MinPurchase: req.MinPurchase,
-		Stock:       req.Stock,
-		Status:      req.Status,
-		UpdatedAt:   time.Now(),
+		ID:           voucherID,
+		Name:         req.Name,
+		Code:         code,
+		Category:     req.Category,
+		Description:  req.Description,
+		DiscountType: req.DiscountType,
+		Discount:     req.Discount,
+		MaxDiscount:  req.MaxDiscount,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		MinPurchase:  req.MinPurchase,
+		Stock:        req.Stock,
+		UsageLimit:   req.UsageLimit,
+		UsagePerUser: req.UsagePerUser,
+		Products:     req.Products,
+		Categories:   req.Categories,
+		Status:       req.Status,
+		UpdatedAt:    time.Now(),
+	}
+
+	rule := *vouchers
+	if rule.DiscountType == "" {
+		rule.DiscountType = voucher.DiscountTypeFixed
+	}
+	if updatedVoucher.DiscountType != "" {
+		rule.DiscountType = updatedVoucher.DiscountType
+	}
+	if updatedVoucher.Discount != 0 {
+		rule.Discount = updatedVoucher.Discount
+	}
+	if err := voucher.ValidateDiscountRule(&rule); err != nil {
+		return err
 	}
 	currentTime := time.Now()
 	if currentTime.After(updatedVoucher.EndDate) {
@@ -86,6 +144,20 @@ func (s *VoucherService) UpdateVoucher(voucherID uint64, req *entities.VoucherMo
 	return nil
 }
 
+// isCodeTaken checks the code against both voucher codes and generated campaign
+// codes, since a code can only resolve to one voucher. A failed lookup counts
+// as taken; the unique index would reject the write anyway.
+func (s *VoucherService) isCodeTaken(code string) bool {
+	if used, err := s.repo.IsVoucherCodeUsed(code); err != nil || used {
+		return true
+	}
+	if code == "" {
+		return false
+	}
+	existingCode, _ := s.repo.GetVoucherCodeByCode(code)
+	return existingCode != nil
+}
+
 func (s *VoucherService) DeleteVoucher(voucherID uint64) error {
 	vouchers, err := s.repo.GetVoucherById(voucherID)
 	if err != nil {
@@ -299,3 +371,300 @@ func (s *VoucherService) GetAllVoucherToClaims(limit int, userID uint64) ([]*ent
 
 	return filteredVouchers, nil
 }
+
+func (s *VoucherService) CalculateDiscount(userID uint64, vouchers *entities.VoucherModels, cart voucher.Cart) (uint64, error) {
+	if vouchers.UsageLimit > 0 && vouchers.UsedCount >= vouchers.UsageLimit {
+		return 0, errors.New("kuota penggunaan kupon sudah habis")
+	}
+
+	if vouchers.UsagePerUser > 0 {
+		used, err := s.repo.CountUserVoucherUsage(userID, vouchers.ID)
+		if err != nil {
+			return 0, err
+		}
+		if uint64(used) >= vouchers.UsagePerUser {
+			return 0, errors.New("batas penggunaan kupon untuk akun ini sudah tercapai")
+		}
+	}
+
+	return voucher.CalculateDiscount(vouchers, cart, time.Now())
+}
+
+func (s *VoucherService) ValidateVoucher(userID uint64, request *dto.ValidateVoucherRequest) (*entities.VoucherModels, uint64, error) {
+	vouchers, err := s.repo.GetVoucherById(request.VoucherID)
+	if err != nil {
+		return nil, 0, errors.New("kupon tidak ditemukan")
+	}
+
+	cart := voucher.Cart{ShipmentFee: request.ShipmentFee}
+	for _, item := range request.Items {
+		products, err := s.productService.GetProductByID(item.ProductID)
+		if err != nil {
+			return nil, 0, errors.New("produk tidak ditemukan")
+		}
+		cart.Items = append(cart.Items, voucher.NewCartItem(products, item.Quantity))
+	}
+
+	discount, err := s.CalculateDiscount(userID, vouchers, cart)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return vouchers, discount, nil
+}
+
+// RecordUsage counts the voucher as used by the order. It runs inside the
+// order's transaction and locks the voucher row first, so the per-user limit
+// checked at checkout is checked again where concurrent orders cannot both
+// slip under it. The usage increment is conditional in the database, so two
+// checkouts racing for the last slot cannot both succeed.
+func (s *VoucherService) RecordUsage(userID, voucherID uint64, orderID, code string, discount uint64) error {
+	vouchers, err := s.repo.LockVoucher(voucherID)
+	if err != nil {
+		return errors.New("kupon tidak ditemukan")
+	}
+	if vouchers.UsagePerUser > 0 {
+		used, err := s.repo.CountUserVoucherUsage(userID, vouchers.ID)
+		if err != nil {
+			return err
+		}
+		if uint64(used) >= vouchers.UsagePerUser {
+			return errors.New("batas penggunaan kupon untuk akun ini sudah tercapai")
+		}
+	}
+
+	increased, err := s.repo.IncreaseVoucherUsage(voucherID)
+	if err != nil {
+		return err
+	}
+	if !increased {
+		return errors.New("kuota penggunaan kupon sudah habis")
+	}
+
+	code = voucher.NormalizeCode(code)
+	if err := s.redeemCodeForOrder(userID, voucherID, orderID, code); err != nil {
+		return err
+	}
+
+	usage := &entities.VoucherUsageModels{
+		VoucherID: voucherID,
+		UserID:    userID,
+		OrderID:   orderID,
+		Code:      code,
+		Discount:  discount,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateVoucherUsage(usage); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *VoucherService) ReleaseUsage(orderID string) error {
+	usage, err := s.repo.GetVoucherUsageByOrderID(orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := s.repo.ReleaseVoucherCode(orderID); err != nil {
+		return err
+	}
+	return s.repo.DeleteVoucherUsage(usage)
+}
+
+// redeemCodeForOrder ties a single-use campaign code to the order it was spent
+// on. Without a code, the order is attached to a campaign code the user
+// redeemed earlier for the same voucher, if there is one.
+func (s *VoucherService) redeemCodeForOrder(userID, voucherID uint64, orderID, code string) error {
+	if code == "" {
+		return s.repo.AttachVoucherCodeOrder(voucherID, userID, orderID)
+	}
+
+	voucherCode, err := s.repo.GetVoucherCodeByCode(code)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	redeemed, err := s.repo.RedeemVoucherCode(voucherCode.ID, userID, &orderID)
+	if err != nil {
+		return err
+	}
+	if !redeemed {
+		return errors.New("kode kupon sudah digunakan")
+	}
+	return nil
+}
+
+const (
+	codeAttemptLimit     = 10
+	codeAttemptWindow    = 15 * time.Minute
+	generateCodeAttempts = 5
+)
+
+// ResolveCode finds the voucher behind a typed code, which is either the
+// voucher's own code or a generated campaign code. Failed lookups are counted
+// per user so codes cannot be guessed by brute force.
+func (s *VoucherService) ResolveCode(userID uint64, code string) (*entities.VoucherModels, error) {
+	code = voucher.NormalizeCode(code)
+	if code == "" {
+		return nil, errors.New("kode kupon wajib diisi")
+	}
+
+	attemptKey := fmt.Sprintf("voucher:code-attempts:%d", userID)
+	if s.isCodeAttemptLimited(attemptKey) {
+		return nil, errors.New("terlalu banyak percobaan kode kupon, silakan coba lagi nanti")
+	}
+
+	vouchers, err := s.findVoucherByCode(userID, code)
+	if err != nil {
+		if _, incrErr := s.cache.Incr(attemptKey, codeAttemptWindow); incrErr != nil {
+			logrus.Error("Gagal mencatat percobaan kode kupon: ", incrErr)
+		}
+		return nil, err
+	}
+	return vouchers, nil
+}
+
+func (s *VoucherService) isCodeAttemptLimited(attemptKey string) bool {
+	cached, err := s.cache.Get(attemptKey)
+	if err != nil {
+		return false
+	}
+	attempts, err := strconv.ParseInt(string(cached), 10, 64)
+	return err == nil && attempts >= codeAttemptLimit
+}
+
+func (s *VoucherService) findVoucherByCode(userID uint64, code string) (*entities.VoucherModels, error) {
+	voucherID := uint64(0)
+	if existingVoucher, err := s.repo.GetVoucherByCode(code); err == nil && existingVoucher != nil {
+		voucherID = existingVoucher.ID
+	} else {
+		voucherCode, err := s.repo.GetVoucherCodeByCode(code)
+		if err != nil {
+			return nil, errors.New("kode kupon tidak valid")
+		}
+		if voucherCode.RedeemedAt != nil && (voucherCode.UserID == nil || *voucherCode.UserID != userID || voucherCode.OrderID != nil) {
+			return nil, errors.New("kode kupon sudah digunakan")
+		}
+		voucherID = voucherCode.VoucherID
+	}
+
+	vouchers, err := s.repo.GetVoucherById(voucherID)
+	if err != nil {
+		return nil, errors.New("kode kupon tidak valid")
+	}
+	return vouchers, nil
+}
+
+func (s *VoucherService) RedeemCode(userID uint64, code string) (*entities.VoucherModels, error) {
+	vouchers, err := s.ResolveCode(userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	code = voucher.NormalizeCode(code)
+	err = s.txManager.WithTransaction(func(tx *gorm.DB) error {
+		repo := s.repo.WithTx(tx)
+		voucherCode, err := repo.GetVoucherCodeByCode(code)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if voucherCode != nil {
+			redeemed, err := repo.RedeemVoucherCode(voucherCode.ID, userID, nil)
+			if err != nil {
+				return err
+			}
+			if !redeemed {
+				return errors.New("kode kupon sudah digunakan")
+			}
+		}
+
+		return s.WithTx(tx).ClaimVoucher(&entities.VoucherClaimModels{
+			UserID:    userID,
+			VoucherID: vouchers.ID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return vouchers, nil
+}
+
+func (s *VoucherService) GenerateCodes(voucherID uint64, request *dto.GenerateVoucherCodesRequest) ([]*entities.VoucherCodeModels, error) {
+	vouchers, err := s.repo.GetVoucherById(voucherID)
+	if err != nil {
+		return nil, errors.New("kupon tidak ditemukan")
+	}
+
+	codes := make([]string, 0, request.Quantity)
+	seen := make(map[string]bool, request.Quantity)
+	for attempt := 0; attempt < generateCodeAttempts && len(codes) < request.Quantity; attempt++ {
+		var batch []string
+		for len(codes)+len(batch) < request.Quantity {
+			code, err := voucher.GenerateCode(request.Prefix)
+			if err != nil {
+				return nil, errors.New("gagal membuat kode kupon")
+			}
+			if seen[code] {
+				continue
+			}
+			seen[code] = true
+			batch = append(batch, code)
+		}
+
+		existing, err := s.repo.GetExistingVoucherCodes(batch)
+		if err != nil {
+			return nil, err
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, code := range existing {
+			taken[code] = true
+		}
+		for _, code := range batch {
+			if !taken[code] {
+				codes = append(codes, code)
+			}
+		}
+	}
+	if len(codes) < request.Quantity {
+		return nil, errors.New("gagal membuat kode kupon yang unik")
+	}
+
+	voucherCodes := make([]*entities.VoucherCodeModels, 0, len(codes))
+	for _, code := range codes {
+		voucherCodes = append(voucherCodes, &entities.VoucherCodeModels{
+			VoucherID: vouchers.ID,
+			Code:      code,
+			CreatedAt: time.Now(),
+		})
+	}
+	if err := s.repo.CreateVoucherCodes(voucherCodes); err != nil {
+		return nil, errors.New("gagal menyimpan kode kupon")
+	}
+
+	return voucherCodes, nil
+}
+
+func (s *VoucherService) GetVoucherCodes(voucherID uint64, page, perPage int) ([]*entities.VoucherCodeModels, int64, error) {
+	if _, err := s.repo.GetVoucherById(voucherID); err != nil {
+		return nil, 0, errors.New("kupon tidak ditemukan")
+	}
+
+	codes, err := s.repo.FindVoucherCodes(voucherID, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalItems, err := s.repo.GetTotalVoucherCodeCount(voucherID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return codes, totalItems, nil
+}
@@ -9,14 +9,20 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	_ "github.com/capstone-kelompok-7/backend-disappear/module/entities"
 	_ "github.com/capstone-kelompok-7/backend-disappear/module/feature/challenge/dto"
+	productMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/product/mocks"
 	user_mock "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/mocks"
 	user_service "github.com/capstone-kelompok-7/backend-disappear/module/feature/users/service"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/dto"
 	voucherMocks "github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher/mocks"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
 	_ "github.com/capstone-kelompok-7/backend-disappear/utils"
+	databaseMocks "github.com/capstone-kelompok-7/backend-disappear/utils/database/mocks"
+	utilsMocks "github.com/capstone-kelompok-7/backend-disappear/utils/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	_ "github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
 )
 
 func TestVocucherService_calculatepaginations(t *testing.T) {
@@ -96,9 +102,9 @@ func TestVoucherlService_GetPrevPage(t *testing.T) {
 func TestVoucherService_GetAll(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
 
-	service := NewVoucherService(repo, userService)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	vouchers := []*entities.VoucherModels{
 		{
@@ -194,13 +200,13 @@ func TestVoucherService_GetAll(t *testing.T) {
 func TestVoucherService_Create(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	existingvouchers := &entities.VoucherModels{
 		Name:        "voucher a",
 		ID:          1,
-		Code:        "abc",
+		Code:        "ABC",
 		Category:    "abc",
 		Description: "abc",
 		Discount:    1000,
@@ -217,7 +223,7 @@ func TestVoucherService_Create(t *testing.T) {
 	vouchers := &entities.VoucherModels{
 		Name:        "voucher b",
 		ID:          2,
-		Code:        "abc",
+		Code:        "ABC",
 		Category:    "abc",
 		Description: "abc",
 		Discount:    1000,
@@ -232,7 +238,8 @@ func TestVoucherService_Create(t *testing.T) {
 	}
 
 	t.Run("Succes Case", func(t *testing.T) {
-		repo.On("GetVoucherByCode", existingvouchers.Code).Return(nil, nil).Once()
+		repo.On("IsVoucherCodeUsed", existingvouchers.Code).Return(false, nil).Once()
+		repo.On("GetVoucherCodeByCode", existingvouchers.Code).Return(nil, gorm.ErrRecordNotFound).Once()
 		repo.On("CreateVoucher", mock.AnythingOfType("*entities.VoucherModels")).Return(nil, nil).Once()
 		result, err := service.CreateVoucher(vouchers)
 		assert.Nil(t, err)
@@ -244,7 +251,8 @@ func TestVoucherService_Create(t *testing.T) {
 
 	t.Run("Failed Case - Error Creating Challenge", func(t *testing.T) {
 		expectedErr := errors.New("failed to create challenge")
-		repo.On("GetVoucherByCode", existingvouchers.Code).Return(nil, nil).Once()
+		repo.On("IsVoucherCodeUsed", existingvouchers.Code).Return(false, nil).Once()
+		repo.On("GetVoucherCodeByCode", existingvouchers.Code).Return(nil, gorm.ErrRecordNotFound).Once()
 		repo.On("CreateVoucher", mock.AnythingOfType("*entities.VoucherModels")).Return(nil, expectedErr).Once()
 		result, err := service.CreateVoucher(vouchers)
 		assert.Error(t, err)
@@ -256,7 +264,7 @@ func TestVoucherService_Create(t *testing.T) {
 
 	t.Run("Failed Case - kupon sudah digunakan ", func(t *testing.T) {
 		expectedErr := errors.New("failed to get voucher by code")
-		repo.On("GetVoucherByCode", existingvouchers.Code).Return(existingvouchers, expectedErr).Once()
+		repo.On("IsVoucherCodeUsed", existingvouchers.Code).Return(false, expectedErr).Once()
 		result, err := service.CreateVoucher(vouchers)
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -269,7 +277,7 @@ func TestVoucherService_Create(t *testing.T) {
 		existingVoucher := &entities.VoucherModels{
 			Name:        "voucher a",
 			ID:          1,
-			Code:        "abc",
+			Code:        "ABC",
 			Category:    "abc",
 			Description: "abc",
 			Discount:    1000,
@@ -281,7 +289,7 @@ func TestVoucherService_Create(t *testing.T) {
 		newVoucher := &entities.VoucherModels{
 			Name:        "voucher b",
 			ID:          2,
-			Code:        "def",
+			Code:        "DEF",
 			Category:    "def",
 			Description: "def",
 			Discount:    1500,
@@ -289,7 +297,8 @@ func TestVoucherService_Create(t *testing.T) {
 			EndDate:     time.Now().Add(time.Hour * 48),
 		}
 
-		repo.On("GetVoucherByCode", newVoucher.Code).Return(nil, nil).Once()
+		repo.On("IsVoucherCodeUsed", newVoucher.Code).Return(false, nil).Once()
+		repo.On("GetVoucherCodeByCode", newVoucher.Code).Return(nil, gorm.ErrRecordNotFound).Once()
 		repo.On("CreateVoucher", mock.AnythingOfType("*entities.VoucherModels")).Return(existingVoucher, nil).Once()
 
 		result, err := service.CreateVoucher(newVoucher)
@@ -305,13 +314,13 @@ func TestVoucherService_Create(t *testing.T) {
 func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	existingVoucher := &entities.VoucherModels{
 		Name:        "voucher a",
 		ID:          1,
-		Code:        "abc",
+		Code:        "ABC",
 		Category:    "abc",
 		Description: "abc",
 		Discount:    1000,
@@ -328,7 +337,7 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 	updatedVoucher := &entities.VoucherModels{
 		ID:          1,
 		Name:        "voucher b",
-		Code:        "def",
+		Code:        "DEF",
 		Category:    "def",
 		Description: "def",
 		Discount:    1500,
@@ -342,7 +351,8 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 
 	t.Run("Success Case - Update Voucher", func(t *testing.T) {
 		repo.On("GetVoucherById", updatedVoucher.ID).Return(existingVoucher, nil).Once()
-		repo.On("GetVoucherByCode", updatedVoucher.Code).Return(nil, nil).Once()
+		repo.On("IsVoucherCodeUsed", updatedVoucher.Code).Return(false, nil).Once()
+		repo.On("GetVoucherCodeByCode", updatedVoucher.Code).Return(nil, gorm.ErrRecordNotFound).Once()
 		repo.On("UpdateVoucher", updatedVoucher.ID, mock.AnythingOfType("*entities.VoucherModels")).Return(nil).Once()
 
 		err := service.UpdateVoucher(updatedVoucher.ID, updatedVoucher)
@@ -364,7 +374,7 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 
 	t.Run("Failed Case - Voucher Code Already Used", func(t *testing.T) {
 		repo.On("GetVoucherById", updatedVoucher.ID).Return(existingVoucher, nil).Once()
-		repo.On("GetVoucherByCode", updatedVoucher.Code).Return(existingVoucher, nil).Once()
+		repo.On("IsVoucherCodeUsed", updatedVoucher.Code).Return(true, nil).Once()
 
 		err := service.UpdateVoucher(updatedVoucher.ID, updatedVoucher)
 
@@ -374,9 +384,29 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Success Case - Keeps Own Code", func(t *testing.T) {
+		sameCode := &entities.VoucherModels{
+			ID:        1,
+			Name:      "voucher a",
+			Code:      " abc ",
+			StartDate: time.Now(),
+			EndDate:   time.Now().Add(time.Hour * 48),
+		}
+		repo.On("GetVoucherById", sameCode.ID).Return(existingVoucher, nil).Once()
+		repo.On("UpdateVoucher", sameCode.ID, mock.MatchedBy(func(voucher *entities.VoucherModels) bool {
+			return voucher.Code == "ABC"
+		})).Return(nil).Once()
+
+		err := service.UpdateVoucher(sameCode.ID, sameCode)
+
+		assert.Nil(t, err)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("Failed Case - Update Failure", func(t *testing.T) {
 		repo.On("GetVoucherById", updatedVoucher.ID).Return(existingVoucher, nil).Once()
-		repo.On("GetVoucherByCode", updatedVoucher.Code).Return(nil, nil).Once()
+		repo.On("IsVoucherCodeUsed", updatedVoucher.Code).Return(false, nil).Once()
+		repo.On("GetVoucherCodeByCode", updatedVoucher.Code).Return(nil, gorm.ErrRecordNotFound).Once()
 		repo.On("UpdateVoucher", updatedVoucher.ID, mock.AnythingOfType("*entities.VoucherModels")).Return(errors.New("gagal memperbarui kupon")).Once()
 
 		err := service.UpdateVoucher(updatedVoucher.ID, updatedVoucher)
@@ -400,7 +430,6 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 		updatedVoucher.EndDate = time.Now().Add(-time.Hour * 48)
 
 		repo.On("GetVoucherById", updatedVoucher.ID).Return(existingVoucher, nil).Once()
-		repo.On("GetVoucherByCode", updatedVoucher.Code).Return(nil, nil).Once()
 		repo.On("UpdateVoucher", updatedVoucher.ID, mock.AnythingOfType("*entities.VoucherModels")).Return(nil).Once()
 
 		err := service.UpdateVoucher(updatedVoucher.ID, updatedVoucher)
@@ -415,8 +444,8 @@ func TestVoucherService_UpdateVoucher_Success(t *testing.T) {
 func TestVoucherService_DeleteVoucher(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	t.Run("Success Delete", func(t *testing.T) {
 		deletedVoucher := &entities.VoucherModels{
@@ -462,8 +491,8 @@ func TestVoucherService_DeleteVoucher(t *testing.T) {
 func TestVoucher_GetVoucherById(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	t.Run("Success - Voucher Found", func(t *testing.T) {
 
@@ -506,8 +535,8 @@ func TestVoucher_GetVoucherById(t *testing.T) {
 func TestVoucher_DeleteVoucherClaims(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 
 	t.Run("Success - Delete User Voucher Claims", func(t *testing.T) {
 		// Mocked user and voucher IDs
@@ -554,8 +583,8 @@ func TestVoucher_DeleteVoucherClaims(t *testing.T) {
 func TestVoucher_TestGetUserVouchers(t *testing.T) {
 	repo := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repo, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repo, userService, nil, nil, nil)
 	t.Run("Success - Get User Vouchers", func(t *testing.T) {
 		// Mocked user ID
 		userID := uint64(123)
@@ -613,8 +642,8 @@ func TestVoucher_TestGetUserVouchers(t *testing.T) {
 func TestVoucher_TestGetVoucherByStatus(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 
 	t.Run("Success - Get Voucher By Status", func(t *testing.T) {
 		page := 1
@@ -681,8 +710,8 @@ func TestVoucher_TestGetVoucherByStatus(t *testing.T) {
 func TestVoucher_TestGetVoucherByCategory(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 
 	page := 1
 	perPage := 10
@@ -745,8 +774,8 @@ func TestVoucher_TestGetVoucherByCategory(t *testing.T) {
 func TestVoucher_TestGetVoucherByStatusCategory(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 
 	page := 1
 	perPage := 10
@@ -808,8 +837,8 @@ func TestVoucher_TestGetVoucherByStatusCategory(t *testing.T) {
 func TestVoucher_TestGetAllVoucherToClaims(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	repoUser := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(repoUser, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(repoUser, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 	t.Run("Success - Get All Vouchers to Claims", func(t *testing.T) {
 		limit := 5
 		userID := uint64(123)
@@ -854,8 +883,8 @@ func TestVoucher_TestGetAllVoucherToClaims(t *testing.T) {
 func TestVoucher_TestCanClaimsVoucher(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	userMock := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(userMock, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(userMock, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 
 	t.Run("Success - User can claim voucher", func(t *testing.T) {
 		userID := uint64(1)
@@ -990,8 +1019,8 @@ func TestVoucher_TestCanClaimsVoucher(t *testing.T) {
 func TestVoucher_TestClaimVoucher(t *testing.T) {
 	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
 	userMock := user_mock.NewRepositoryUserInterface(t)
-	userService := user_service.NewUserService(userMock, utils.NewHash())
-	service := NewVoucherService(repoMock, userService)
+	userService := user_service.NewUserService(userMock, utils.NewHash(), nil, nil)
+	service := NewVoucherService(repoMock, userService, nil, nil, nil)
 
 	userID := uint64(1)
 	voucherID := uint64(100)
@@ -1149,3 +1178,425 @@ func TestVoucher_TestClaimVoucher(t *testing.T) {
 		userMock.AssertExpectations(t)
 	})
 }
+
+func TestVoucherService_CalculateDiscount(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	service := NewVoucherService(repoMock, nil, nil, nil, nil)
+
+	userID := uint64(1)
+	cart := voucher.Cart{
+		Items: []voucher.CartItem{
+			{ProductID: 1, CategoryIDs: []uint64{10}, Subtotal: 80000},
+			{ProductID: 2, CategoryIDs: []uint64{20}, Subtotal: 20000},
+		},
+		ShipmentFee: 15000,
+	}
+	newVoucher := func() *entities.VoucherModels {
+		return &entities.VoucherModels{
+			ID:           1,
+			DiscountType: voucher.DiscountTypeFixed,
+			Discount:     10000,
+			StartDate:    time.Now().Add(-24 * time.Hour),
+			EndDate:      time.Now().Add(24 * time.Hour),
+		}
+	}
+
+	t.Run("Success Case - Fixed Discount", func(t *testing.T) {
+		discount, err := service.CalculateDiscount(userID, newVoucher(), cart)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10000), discount)
+	})
+
+	t.Run("Success Case - Percentage Discount Capped", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.DiscountType = voucher.DiscountTypePercentage
+		vouchers.Discount = 50
+		vouchers.MaxDiscount = 30000
+
+		discount, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(30000), discount)
+	})
+
+	t.Run("Success Case - Free Shipping", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.DiscountType = voucher.DiscountTypeFreeShipping
+		vouchers.MaxDiscount = 10000
+
+		discount, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(10000), discount)
+	})
+
+	t.Run("Success Case - Category Scope", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.DiscountType = voucher.DiscountTypePercentage
+		vouchers.Discount = 10
+		vouchers.Categories = []entities.VoucherCategoryModels{{CategoryID: 20}}
+
+		discount, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(2000), discount)
+	})
+
+	t.Run("Failed Case - Product Out Of Scope", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.Products = []entities.VoucherProductModels{{ProductID: 3}}
+
+		discount, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.EqualError(t, err, "kupon tidak berlaku untuk produk yang dipilih")
+		assert.Equal(t, uint64(0), discount)
+	})
+
+	t.Run("Failed Case - Minimum Purchase", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.MinPurchase = 200000
+
+		_, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.EqualError(t, err, "total belanja belum memenuhi minimal pembelian kupon")
+	})
+
+	t.Run("Failed Case - Expired", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.EndDate = time.Now().Add(-time.Hour)
+
+		_, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.EqualError(t, err, "kupon sudah kedaluwarsa")
+	})
+
+	t.Run("Failed Case - Usage Limit Reached", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.UsageLimit = 5
+		vouchers.UsedCount = 5
+
+		_, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.EqualError(t, err, "kuota penggunaan kupon sudah habis")
+	})
+
+	t.Run("Failed Case - Per User Limit Reached", func(t *testing.T) {
+		vouchers := newVoucher()
+		vouchers.UsagePerUser = 1
+		repoMock.On("CountUserVoucherUsage", userID, vouchers.ID).Return(int64(1), nil).Once()
+
+		_, err := service.CalculateDiscount(userID, vouchers, cart)
+
+		assert.EqualError(t, err, "batas penggunaan kupon untuk akun ini sudah tercapai")
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_ValidateVoucher(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	productService := productMocks.NewServiceProductInterface(t)
+	service := NewVoucherService(repoMock, nil, productService, nil, nil)
+
+	userID := uint64(1)
+	request := &dto.ValidateVoucherRequest{
+		VoucherID: 1,
+		Items:     []dto.ValidateVoucherItem{{ProductID: 1, Quantity: 2}},
+	}
+	mockVoucher := &entities.VoucherModels{
+		ID:           1,
+		DiscountType: voucher.DiscountTypePercentage,
+		Discount:     10,
+		StartDate:    time.Now().Add(-24 * time.Hour),
+		EndDate:      time.Now().Add(24 * time.Hour),
+	}
+	mockProduct := &entities.ProductModels{
+		ID:       1,
+		Price:    30000,
+		Discount: 5000,
+	}
+
+	t.Run("Success Case - Validate Voucher", func(t *testing.T) {
+		repoMock.On("GetVoucherById", request.VoucherID).Return(mockVoucher, nil).Once()
+		productService.On("GetProductByID", uint64(1)).Return(mockProduct, nil).Once()
+
+		result, discount, err := service.ValidateVoucher(userID, request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockVoucher, result)
+		assert.Equal(t, uint64(5000), discount)
+		repoMock.AssertExpectations(t)
+		productService.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Voucher Not Found", func(t *testing.T) {
+		repoMock.On("GetVoucherById", request.VoucherID).Return(nil, errors.New("record not found")).Once()
+
+		result, _, err := service.ValidateVoucher(userID, request)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kupon tidak ditemukan")
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Product Not Found", func(t *testing.T) {
+		repoMock.On("GetVoucherById", request.VoucherID).Return(mockVoucher, nil).Once()
+		productService.On("GetProductByID", uint64(1)).Return(nil, errors.New("record not found")).Once()
+
+		result, _, err := service.ValidateVoucher(userID, request)
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "produk tidak ditemukan")
+		repoMock.AssertExpectations(t)
+		productService.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_RecordUsage(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	service := NewVoucherService(repoMock, nil, nil, nil, nil)
+
+	t.Run("Success Case - Record Usage", func(t *testing.T) {
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1}, nil).Once()
+		repoMock.On("IncreaseVoucherUsage", uint64(1)).Return(true, nil).Once()
+		repoMock.On("AttachVoucherCodeOrder", uint64(1), uint64(2), "order-1").Return(nil).Once()
+		repoMock.On("CreateVoucherUsage", mock.MatchedBy(func(u *entities.VoucherUsageModels) bool {
+			return u.VoucherID == 1 && u.UserID == 2 && u.OrderID == "order-1" && u.Discount == 5000
+		})).Return(nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "", 5000)
+
+		assert.NoError(t, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Quota Exhausted", func(t *testing.T) {
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1}, nil).Once()
+		repoMock.On("IncreaseVoucherUsage", uint64(1)).Return(false, nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "", 5000)
+
+		assert.EqualError(t, err, "kuota penggunaan kupon sudah habis")
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Record Usage With Campaign Code", func(t *testing.T) {
+		voucherCode := &entities.VoucherCodeModels{ID: 7, VoucherID: 1, Code: "PROMOABCD2345"}
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1}, nil).Once()
+		repoMock.On("IncreaseVoucherUsage", uint64(1)).Return(true, nil).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Once()
+		repoMock.On("RedeemVoucherCode", uint64(7), uint64(2), mock.MatchedBy(func(orderID *string) bool {
+			return orderID != nil && *orderID == "order-1"
+		})).Return(true, nil).Once()
+		repoMock.On("CreateVoucherUsage", mock.MatchedBy(func(u *entities.VoucherUsageModels) bool {
+			return u.Code == "PROMOABCD2345"
+		})).Return(nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "promoabcd2345", 5000)
+
+		assert.NoError(t, err)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Campaign Code Already Used", func(t *testing.T) {
+		voucherCode := &entities.VoucherCodeModels{ID: 7, VoucherID: 1, Code: "PROMOABCD2345"}
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1}, nil).Once()
+		repoMock.On("IncreaseVoucherUsage", uint64(1)).Return(true, nil).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Once()
+		repoMock.On("RedeemVoucherCode", uint64(7), uint64(2), mock.Anything).Return(false, nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "PROMOABCD2345", 5000)
+
+		assert.EqualError(t, err, "kode kupon sudah digunakan")
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Per User Limit Reached", func(t *testing.T) {
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1, UsagePerUser: 1}, nil).Once()
+		repoMock.On("CountUserVoucherUsage", uint64(2), uint64(1)).Return(int64(1), nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "", 5000)
+
+		assert.EqualError(t, err, "batas penggunaan kupon untuk akun ini sudah tercapai")
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Under Per User Limit", func(t *testing.T) {
+		repoMock.On("LockVoucher", uint64(1)).Return(&entities.VoucherModels{ID: 1, UsagePerUser: 2}, nil).Once()
+		repoMock.On("CountUserVoucherUsage", uint64(2), uint64(1)).Return(int64(1), nil).Once()
+		repoMock.On("IncreaseVoucherUsage", uint64(1)).Return(true, nil).Once()
+		repoMock.On("AttachVoucherCodeOrder", uint64(1), uint64(2), "order-1").Return(nil).Once()
+		repoMock.On("CreateVoucherUsage", mock.Anything).Return(nil).Once()
+
+		err := service.RecordUsage(2, 1, "order-1", "", 5000)
+
+		assert.NoError(t, err)
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_ResolveCode(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	cacheMock := utilsMocks.NewCacheRepository(t)
+	service := NewVoucherService(repoMock, nil, nil, nil, cacheMock)
+
+	userID := uint64(2)
+	attemptKey := "voucher:code-attempts:2"
+	mockVoucher := &entities.VoucherModels{ID: 1, Code: "HEMAT10"}
+
+	t.Run("Success Case - Voucher Code Is Case Insensitive", func(t *testing.T) {
+		cacheMock.On("Get", attemptKey).Return(nil, errors.New("redis: nil")).Once()
+		repoMock.On("GetVoucherByCode", "HEMAT10").Return(mockVoucher, nil).Once()
+		repoMock.On("GetVoucherById", uint64(1)).Return(mockVoucher, nil).Once()
+
+		result, err := service.ResolveCode(userID, " hemat10 ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockVoucher, result)
+		repoMock.AssertExpectations(t)
+		cacheMock.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - Campaign Code", func(t *testing.T) {
+		voucherCode := &entities.VoucherCodeModels{ID: 7, VoucherID: 1, Code: "PROMOABCD2345"}
+		cacheMock.On("Get", attemptKey).Return([]byte("3"), nil).Once()
+		repoMock.On("GetVoucherByCode", "PROMOABCD2345").Return(nil, gorm.ErrRecordNotFound).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Once()
+		repoMock.On("GetVoucherById", uint64(1)).Return(mockVoucher, nil).Once()
+
+		result, err := service.ResolveCode(userID, "PROMOABCD2345")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockVoucher, result)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Campaign Code Redeemed By Another User", func(t *testing.T) {
+		otherUserID := uint64(9)
+		redeemedAt := time.Now()
+		voucherCode := &entities.VoucherCodeModels{ID: 7, VoucherID: 1, Code: "PROMOABCD2345", UserID: &otherUserID, RedeemedAt: &redeemedAt}
+		cacheMock.On("Get", attemptKey).Return(nil, errors.New("redis: nil")).Once()
+		repoMock.On("GetVoucherByCode", "PROMOABCD2345").Return(nil, gorm.ErrRecordNotFound).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Once()
+		cacheMock.On("Incr", attemptKey, codeAttemptWindow).Return(int64(1), nil).Once()
+
+		result, err := service.ResolveCode(userID, "PROMOABCD2345")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kode kupon sudah digunakan")
+		repoMock.AssertExpectations(t)
+		cacheMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Unknown Code Counts As Attempt", func(t *testing.T) {
+		cacheMock.On("Get", attemptKey).Return([]byte("4"), nil).Once()
+		repoMock.On("GetVoucherByCode", "TEBAK123").Return(nil, gorm.ErrRecordNotFound).Once()
+		repoMock.On("GetVoucherCodeByCode", "TEBAK123").Return(nil, gorm.ErrRecordNotFound).Once()
+		cacheMock.On("Incr", attemptKey, codeAttemptWindow).Return(int64(5), nil).Once()
+
+		result, err := service.ResolveCode(userID, "tebak123")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kode kupon tidak valid")
+		repoMock.AssertExpectations(t)
+		cacheMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Too Many Attempts", func(t *testing.T) {
+		cacheMock.On("Get", attemptKey).Return([]byte("10"), nil).Once()
+
+		result, err := service.ResolveCode(userID, "HEMAT10")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "terlalu banyak percobaan kode kupon, silakan coba lagi nanti")
+		cacheMock.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_RedeemCode(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	userMock := user_mock.NewServiceUserInterface(t)
+	cacheMock := utilsMocks.NewCacheRepository(t)
+	txManager := databaseMocks.NewTransactionManagerInterface(t)
+	txManager.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error {
+		return fn(nil)
+	}).Maybe()
+	repoMock.On("WithTx", mock.Anything).Return(repoMock).Maybe()
+	service := NewVoucherService(repoMock, userMock, nil, txManager, cacheMock)
+
+	userID := uint64(2)
+	voucherCode := &entities.VoucherCodeModels{ID: 7, VoucherID: 1, Code: "PROMOABCD2345"}
+	mockVoucher := &entities.VoucherModels{ID: 1, Stock: 10}
+
+	t.Run("Success Case - Redeem Campaign Code", func(t *testing.T) {
+		cacheMock.On("Get", mock.Anything).Return(nil, errors.New("redis: nil")).Once()
+		repoMock.On("GetVoucherByCode", "PROMOABCD2345").Return(nil, gorm.ErrRecordNotFound).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Twice()
+		repoMock.On("GetVoucherById", uint64(1)).Return(mockVoucher, nil).Twice()
+		repoMock.On("RedeemVoucherCode", uint64(7), userID, (*string)(nil)).Return(true, nil).Once()
+		userMock.On("GetUserLevel", userID).Return("Bronze", nil).Once()
+		repoMock.On("GetVoucherCategory", uint64(1)).Return("All Customer", nil).Once()
+		repoMock.On("IsVoucherAlreadyClaimed", userID, uint64(1)).Return(false, nil).Once()
+		repoMock.On("ClaimVoucher", mock.Anything).Return(nil).Once()
+		repoMock.On("ReduceStockWhenClaimed", uint64(1), uint64(1)).Return(nil).Once()
+
+		result, err := service.RedeemCode(userID, "promoabcd2345")
+
+		assert.NoError(t, err)
+		assert.Equal(t, mockVoucher, result)
+		repoMock.AssertExpectations(t)
+		userMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Code Taken Concurrently", func(t *testing.T) {
+		cacheMock.On("Get", mock.Anything).Return(nil, errors.New("redis: nil")).Once()
+		repoMock.On("GetVoucherByCode", "PROMOABCD2345").Return(nil, gorm.ErrRecordNotFound).Once()
+		repoMock.On("GetVoucherCodeByCode", "PROMOABCD2345").Return(voucherCode, nil).Twice()
+		repoMock.On("GetVoucherById", uint64(1)).Return(mockVoucher, nil).Once()
+		repoMock.On("RedeemVoucherCode", uint64(7), userID, (*string)(nil)).Return(false, nil).Once()
+
+		result, err := service.RedeemCode(userID, "PROMOABCD2345")
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kode kupon sudah digunakan")
+		repoMock.AssertExpectations(t)
+	})
+}
+
+func TestVoucherService_GenerateCodes(t *testing.T) {
+	repoMock := voucherMocks.NewRepositoryVoucherInterface(t)
+	service := NewVoucherService(repoMock, nil, nil, nil, nil)
+
+	mockVoucher := &entities.VoucherModels{ID: 1}
+
+	t.Run("Success Case - Generate Unique Codes", func(t *testing.T) {
+		repoMock.On("GetVoucherById", uint64(1)).Return(mockVoucher, nil).Once()
+		repoMock.On("GetExistingVoucherCodes", mock.AnythingOfType("[]string")).Return([]string{}, nil).Once()
+		repoMock.On("CreateVoucherCodes", mock.MatchedBy(func(codes []*entities.VoucherCodeModels) bool {
+			seen := map[string]bool{}
+			for _, code := range codes {
+				if code.VoucherID != 1 || len(code.Code) != len("PROMO")+8 || code.Code[:5] != "PROMO" || seen[code.Code] {
+					return false
+				}
+				seen[code.Code] = true
+			}
+			return len(codes) == 50
+		})).Return(nil).Once()
+
+		result, err := service.GenerateCodes(1, &dto.GenerateVoucherCodesRequest{Quantity: 50, Prefix: "promo"})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 50)
+		repoMock.AssertExpectations(t)
+	})
+
+	t.Run("Failed Case - Voucher Not Found", func(t *testing.T) {
+		repoMock.On("GetVoucherById", uint64(2)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		result, err := service.GenerateCodes(2, &dto.GenerateVoucherCodesRequest{Quantity: 1})
+
+		assert.Nil(t, result)
+		assert.EqualError(t, err, "kupon tidak ditemukan")
+		repoMock.AssertExpectations(t)
+	})
+}
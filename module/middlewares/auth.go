@@ -1,6 +1,7 @@
 package middlewares
 
 import (
+	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
 	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
@@ -21,30 +22,90 @@ func AuthMiddleware(jwtService utils.JWTInterface, userService users.ServiceUser
 			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
 			token, err := jwtService.ValidateToken(tokenString)
+			if errors.Is(err, utils.ErrTokenRevoked) {
+				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token telah dicabut, silakan masuk kembali")
+			}
 			if err != nil {
 				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token tidak valid "+err.Error())
 			}
 
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok || !token.Valid {
-				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token tidak valid atau telah kadaluarsa "+err.Error())
-			}
+			return setCurrentUser(c, next, token, userService)
+		}
+	}
+}
 
-			userIDFloat, ok := claims["user_id"].(float64)
-			if !ok {
-				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: ID Pengguna tidak valid "+err.Error())
+// TwoFactorSetupMiddleware lets through either a regular access token or the
+// partial token from the password step, so a user who must enroll in 2FA can
+// do so before logging in fully. The partial token is kept in the context as
+// "TwoFactorToken".
+func TwoFactorSetupMiddleware(jwtService utils.JWTInterface, userService users.ServiceUserInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
+
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token Bearer hilang atau tidak valid")
 			}
 
-			userID := uint64(userIDFloat)
+			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-			user, err := userService.GetUsersById(userID)
+			token, err := jwtService.ValidateToken(tokenString)
 			if err != nil {
-				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Pengguna tidak ditemukan "+err.Error())
+				token, err = jwtService.ValidateTwoFactorToken(tokenString)
+				if err != nil {
+					return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token tidak valid atau telah kadaluarsa")
+				}
+				c.Set("TwoFactorToken", tokenString)
 			}
 
-			c.Set("CurrentUser", user)
+			return setCurrentUser(c, next, token, userService)
+		}
+	}
+}
+
+// PasswordResetMiddleware only lets through the reset token from the
+// forgot-password flow. Access tokens are refused, so a reset token cannot be
+// used anywhere else and an access token cannot skip the OTP.
+func PasswordResetMiddleware(jwtService utils.JWTInterface, userService users.ServiceUserInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authHeader := c.Request().Header.Get("Authorization")
 
-			return next(c)
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token Bearer hilang atau tidak valid")
+			}
+
+			tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+			token, err := jwtService.ValidatePasswordResetToken(tokenString)
+			if err != nil {
+				return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token reset password tidak valid atau telah kadaluarsa")
+			}
+
+			return setCurrentUser(c, next, token, userService)
 		}
 	}
 }
+
+func setCurrentUser(c echo.Context, next echo.HandlerFunc, token *jwt.Token, userService users.ServiceUserInterface) error {
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Token tidak valid atau telah kadaluarsa")
+	}
+
+	userIDFloat, ok := claims["user_id"].(float64)
+	if !ok {
+		return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: ID Pengguna tidak valid")
+	}
+
+	userID := uint64(userIDFloat)
+
+	user, err := userService.GetUsersById(userID)
+	if err != nil {
+		return response.SendStatusUnauthorizedResponse(c, "Tidak diizinkan: Pengguna tidak ditemukan "+err.Error())
+	}
+
+	c.Set("CurrentUser", user)
+
+	return next(c)
+}
@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"errors"
+
+	"github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+)
+
+// LoadPermissions must run after AuthMiddleware. It puts the current user's
+// permission set in the context for handlers that only adjust their behaviour
+// with role.HasPermission, without rejecting the request.
+func LoadPermissions(roleService role.ServiceRoleInterface) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if _, err := loadPermissions(c, roleService); err != nil {
+				return response.SendStatusInternalServerResponse(c, "Gagal memeriksa izin: "+err.Error())
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequirePermission must run after AuthMiddleware. The request passes when the
+// current user's role holds at least one of the given permissions.
+func RequirePermission(roleService role.ServiceRoleInterface, permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			granted, err := loadPermissions(c, roleService)
+			if err != nil {
+				return response.SendStatusInternalServerResponse(c, "Gagal memeriksa izin: "+err.Error())
+			}
+
+			for _, permission := range permissions {
+				if granted[permission] {
+					return next(c)
+				}
+			}
+			return response.SendStatusForbiddenResponse(c, "Tidak diizinkan: Anda tidak memiliki izin")
+		}
+	}
+}
+
+func loadPermissions(c echo.Context, roleService role.ServiceRoleInterface) (map[string]bool, error) {
+	currentUser, ok := c.Get("CurrentUser").(*entities.UserModels)
+	if !ok {
+		return nil, errors.New("pengguna tidak ditemukan")
+	}
+
+	granted, err := roleService.GetRolePermissions(currentUser.Role)
+	if err != nil {
+		return nil, err
+	}
+	c.Set("Permissions", granted)
+
+	return granted, nil
+}
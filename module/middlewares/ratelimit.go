@@ -0,0 +1,102 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/response"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// RateLimitKeyFunc picks what a limit is counted against. An empty key skips
+// the limit for that request.
+type RateLimitKeyFunc func(c echo.Context) string
+
+// RateLimit allows at most limit requests per key in a fixed window. The
+// counters live in the cache so every instance shares them. When the cache is
+// unavailable requests are let through rather than locking everyone out.
+func RateLimit(cache caching.CacheRepository, name string, limit int64, window time.Duration, keyFunc RateLimitKeyFunc) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := keyFunc(c)
+			if key == "" {
+				return next(c)
+			}
+
+			count, err := cache.Incr(fmt.Sprintf("ratelimit:%s:%s", name, key), window)
+			if err != nil {
+				logrus.Error("Gagal memeriksa batas permintaan: ", err)
+				return next(c)
+			}
+			if count > limit {
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(window.Seconds())))
+				return response.SendStatusTooManyRequestsResponse(c, "Terlalu banyak permintaan, silakan coba lagi nanti")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RateLimitByIP keys on c.RealIP, so it is only as trustworthy as the
+// server's IPExtractor; see IPExtractor.
+func RateLimitByIP(c echo.Context) string {
+	return c.RealIP()
+}
+
+// IPExtractor decides where the client address comes from. Without trusted
+// proxies it is the socket address, since X-Forwarded-For and X-Real-IP can be
+// set by anyone. Behind a proxy the header is read, but only the hops added by
+// the listed proxies are skipped.
+func IPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, network := range trustedProxies {
+		options = append(options, echo.TrustIPRange(network))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+// RateLimitByEmail reads the email field from a JSON or form body and puts the
+// body back so the handler can still bind it.
+func RateLimitByEmail(c echo.Context) string {
+	req := c.Request()
+	if req.Body == nil {
+		return ""
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return ""
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	email := ""
+	if strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		var payload struct {
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(body, &payload); err == nil {
+			email = payload.Email
+		}
+	} else {
+		email = c.FormValue("email")
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return strings.ToLower(strings.TrimSpace(email))
+}
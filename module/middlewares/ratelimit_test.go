@@ -0,0 +1,167 @@
+package middlewares
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveRateLimited(middleware echo.MiddlewareFunc, req *http.Request) *httptest.ResponseRecorder {
+	e := echo.New()
+	e.IPExtractor = IPExtractor(nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	_ = middleware(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(c)
+	return rec
+}
+
+func TestRateLimit(t *testing.T) {
+	window := time.Minute
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/login", nil)
+		req.RemoteAddr = "10.0.0.1:5000"
+		return req
+	}
+
+	t.Run("Success Case - Within Limit", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Incr", "ratelimit:login:10.0.0.1", window).Return(int64(3), nil).Once()
+
+		rec := serveRateLimited(RateLimit(cache, "login", 3, window, RateLimitByIP), newRequest())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("Failed Case - Over Limit", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Incr", "ratelimit:login:10.0.0.1", window).Return(int64(4), nil).Once()
+
+		rec := serveRateLimited(RateLimit(cache, "login", 3, window, RateLimitByIP), newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	})
+
+	t.Run("Success Case - New Window Resets Count", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Incr", "ratelimit:login:10.0.0.1", window).Return(int64(4), nil).Once()
+		cache.On("Incr", "ratelimit:login:10.0.0.1", window).Return(int64(1), nil).Once()
+		middleware := RateLimit(cache, "login", 3, window, RateLimitByIP)
+
+		blocked := serveRateLimited(middleware, newRequest())
+		allowed := serveRateLimited(middleware, newRequest())
+
+		assert.Equal(t, http.StatusTooManyRequests, blocked.Code)
+		assert.Equal(t, http.StatusOK, allowed.Code)
+	})
+
+	t.Run("Success Case - Empty Key Skips Limit", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+
+		rec := serveRateLimited(RateLimit(cache, "login", 3, window, func(c echo.Context) string { return "" }), newRequest())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		cache.AssertNotCalled(t, "Incr")
+	})
+
+	t.Run("Success Case - Cache Unavailable Lets Request Through", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Incr", "ratelimit:login:10.0.0.1", window).Return(int64(0), errors.New("connection refused")).Once()
+
+		rec := serveRateLimited(RateLimit(cache, "login", 3, window, RateLimitByIP), newRequest())
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestRateLimitByIP(t *testing.T) {
+	_, proxy, _ := net.ParseCIDR("192.0.2.0/24")
+	tests := []struct {
+		name           string
+		trustedProxies []*net.IPNet
+		remoteAddr     string
+		forwardedFor   string
+		realIP         string
+		expected       string
+	}{
+		{"Direct - Forged Forwarded For Ignored", nil, "203.0.113.7:5000", "10.0.0.2", "", "203.0.113.7"},
+		{"Direct - Forged Real IP Ignored", nil, "203.0.113.7:5000", "", "10.0.0.3", "203.0.113.7"},
+		{"Proxy - Client From Trusted Proxy", []*net.IPNet{proxy}, "192.0.2.10:5000", "198.51.100.4", "", "198.51.100.4"},
+		{"Proxy - Forged Hop Before Client Ignored", []*net.IPNet{proxy}, "192.0.2.10:5000", "10.0.0.2, 198.51.100.4", "", "198.51.100.4"},
+		{"Proxy - Untrusted Peer Ignored", []*net.IPNet{proxy}, "203.0.113.7:5000", "10.0.0.2", "", "203.0.113.7"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := echo.New()
+			e.IPExtractor = IPExtractor(test.trustedProxies)
+			req := httptest.NewRequest(http.MethodPost, "/login", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.forwardedFor != "" {
+				req.Header.Set(echo.HeaderXForwardedFor, test.forwardedFor)
+			}
+			if test.realIP != "" {
+				req.Header.Set(echo.HeaderXRealIP, test.realIP)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			assert.Equal(t, test.expected, RateLimitByIP(c))
+		})
+	}
+}
+
+func TestRateLimitByEmail(t *testing.T) {
+	t.Run("JSON Body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"email":" User@Example.COM "}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		assert.Equal(t, "user@example.com", RateLimitByEmail(c))
+
+		var payload struct {
+			Email string `json:"email"`
+		}
+		assert.NoError(t, c.Bind(&payload))
+		assert.Equal(t, " User@Example.COM ", payload.Email)
+	})
+
+	t.Run("Form Body", func(t *testing.T) {
+		form := url.Values{"email": {"User@Example.com"}}
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(form.Encode()))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		assert.Equal(t, "user@example.com", RateLimitByEmail(c))
+	})
+
+	t.Run("Missing Email", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+
+		assert.Equal(t, "", RateLimitByEmail(c))
+	})
+
+	t.Run("Same Limit For Different Casing", func(t *testing.T) {
+		window := time.Minute
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Incr", "ratelimit:otp:user@example.com", window).Return(int64(1), nil).Twice()
+		middleware := RateLimit(cache, "otp", 3, window, RateLimitByEmail)
+
+		for _, email := range []string{"User@Example.com", "user@example.com"} {
+			req := httptest.NewRequest(http.MethodPost, "/otp", strings.NewReader(`{"email":"`+email+`"}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			assert.Equal(t, http.StatusOK, serveRateLimited(middleware, req).Code)
+		}
+	})
+}
@@ -12,171 +12,220 @@ import (
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/dashboard"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/fcm"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/homepage"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/notification"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/product"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/review"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/role"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/search"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/shipping"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/users"
 	"github.com/capstone-kelompok-7/backend-disappear/module/feature/voucher"
 	"github.com/capstone-kelompok-7/backend-disappear/module/middlewares"
 	"github.com/capstone-kelompok-7/backend-disappear/utils"
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
 	"github.com/labstack/echo/v4"
+	"time"
 )
 
-func RouteAuth(e *echo.Echo, h auth.HandlerAuthInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteAuth(e *echo.Echo, h auth.HandlerAuthInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, cache caching.CacheRepository) {
+	loginLimits := []echo.MiddlewareFunc{
+		middlewares.RateLimit(cache, "login-ip", 20, 15*time.Minute, middlewares.RateLimitByIP),
+		middlewares.RateLimit(cache, "login-email", 10, 15*time.Minute, middlewares.RateLimitByEmail),
+	}
+	verifyLimits := []echo.MiddlewareFunc{
+		middlewares.RateLimit(cache, "otp-verify-ip", 20, 15*time.Minute, middlewares.RateLimitByIP),
+		middlewares.RateLimit(cache, "otp-verify-email", 10, 15*time.Minute, middlewares.RateLimitByEmail),
+	}
+	twoFactorLimits := []echo.MiddlewareFunc{
+		middlewares.RateLimit(cache, "2fa-verify-ip", 20, 15*time.Minute, middlewares.RateLimitByIP),
+	}
+	sendOTPLimits := []echo.MiddlewareFunc{
+		middlewares.RateLimit(cache, "otp-send-ip", 10, 15*time.Minute, middlewares.RateLimitByIP),
+		middlewares.RateLimit(cache, "otp-send-email", 3, 15*time.Minute, middlewares.RateLimitByEmail),
+	}
+
 	authGroup := e.Group("api/v1/auth")
-	authGroup.POST("/register", h.Register())
-	authGroup.POST("/login", h.Login())
-	authGroup.POST("/verify", h.VerifyEmail())
-	authGroup.POST("/resend-otp", h.ResendOTP())
-	authGroup.POST("/forgot-password", h.ForgotPassword())
-	authGroup.POST("/forgot-password/verify", h.VerifyOTP())
-	authGroup.POST("/forgot-password/reset", h.ResetPassword(), middlewares.AuthMiddleware(jwtService, userService))
+	authGroup.POST("/register", h.Register(), sendOTPLimits...)
+	authGroup.POST("/login", h.Login(), loginLimits...)
+	authGroup.POST("/verify", h.VerifyEmail(), verifyLimits...)
+	authGroup.POST("/resend-otp", h.ResendOTP(), sendOTPLimits...)
+	authGroup.POST("/forgot-password", h.ForgotPassword(), sendOTPLimits...)
+	authGroup.POST("/forgot-password/verify", h.VerifyOTP(), verifyLimits...)
+	authGroup.POST("/forgot-password/2fa", h.VerifyPasswordResetTwoFactor(), twoFactorLimits...)
+	authGroup.POST("/forgot-password/reset", h.ResetPassword(), middlewares.PasswordResetMiddleware(jwtService, userService))
 	authGroup.POST("/register-social", h.RegisterSocial())
 	authGroup.POST("/login-social", h.LoginSocial())
+	authGroup.POST("/refresh", h.RefreshToken())
+	authGroup.POST("/logout", h.Logout(), middlewares.AuthMiddleware(jwtService, userService))
+	authGroup.POST("/logout-all", h.LogoutAll(), middlewares.AuthMiddleware(jwtService, userService))
+	authGroup.POST("/2fa/setup", h.SetupTwoFactor(), middlewares.TwoFactorSetupMiddleware(jwtService, userService))
+	authGroup.POST("/2fa/enable", h.EnableTwoFactor(), middlewares.TwoFactorSetupMiddleware(jwtService, userService))
+	authGroup.POST("/2fa/verify", h.VerifyTwoFactor(), twoFactorLimits...)
+	authGroup.POST("/2fa/disable", h.DisableTwoFactor(), middlewares.AuthMiddleware(jwtService, userService))
+	authGroup.POST("/2fa/recovery-codes", h.RegenerateRecoveryCodes(), middlewares.AuthMiddleware(jwtService, userService))
 }
 
-func RouteUser(e *echo.Echo, h users.HandlerUserInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteUser(e *echo.Echo, h users.HandlerUserInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	usersGroup := e.Group("api/v1/users")
-	usersGroup.GET("", h.GetAllUsers(), middlewares.AuthMiddleware(jwtService, userService))
-	usersGroup.GET("/by-email", h.GetUsersByEmail(), middlewares.AuthMiddleware(jwtService, userService))
+	usersGroup.GET("", h.GetAllUsers(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageUsers))
+	usersGroup.GET("/by-email", h.GetUsersByEmail(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageUsers))
 	usersGroup.POST("/change-password", h.ChangePassword(), middlewares.AuthMiddleware(jwtService, userService))
 	usersGroup.GET("/:id", h.GetUsersById(), middlewares.AuthMiddleware(jwtService, userService))
-	usersGroup.POST("/edit-profile", h.EditProfile(), middlewares.AuthMiddleware(jwtService, userService))
-	usersGroup.DELETE("/:id", h.DeleteAccount(), middlewares.AuthMiddleware(jwtService, userService))
+	usersGroup.POST("/edit-profile", h.EditProfile(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnProfile))
+	usersGroup.GET("/export", h.ExportData(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnProfile))
+	usersGroup.DELETE("/me", h.DeleteOwnAccount(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnProfile))
+	usersGroup.DELETE("/:id", h.DeleteAccount(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageUsers))
 	usersGroup.GET("/leaderboard", h.GetLeaderboard(), middlewares.AuthMiddleware(jwtService, userService))
-	usersGroup.GET("/get-activities/:id", h.GetUserTransactionActivity(), middlewares.AuthMiddleware(jwtService, userService))
-	usersGroup.GET("/get-profile", h.GetUserProfile(), middlewares.AuthMiddleware(jwtService, userService))
+	usersGroup.GET("/get-activities/:id", h.GetUserTransactionActivity(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageUsers))
+	usersGroup.GET("/get-profile", h.GetUserProfile(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnProfile))
 }
 
-func RouteVoucher(e *echo.Echo, h voucher.HandlerVoucherInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteVoucher(e *echo.Echo, h voucher.HandlerVoucherInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	voucherGroup := e.Group("api/v1/vouchers")
-	voucherGroup.POST("", h.CreateVoucher(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.GET("", h.GetAllVouchers(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.PUT("/:id", h.UpdateVouchers(), middlewares.AuthMiddleware(jwtService, userService))
+	voucherGroup.POST("", h.CreateVoucher(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
+	voucherGroup.GET("", h.GetAllVouchers(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
+	voucherGroup.PUT("/:id", h.UpdateVouchers(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
 	voucherGroup.GET("/:id", h.GetVoucherById(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.DELETE("/:id", h.DeleteVoucherById(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.POST("/claims", h.ClaimVoucher(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.GET("/users", h.GetVoucherUser(), middlewares.AuthMiddleware(jwtService, userService))
-	voucherGroup.GET("/to-claims", h.GetAllVouchersToClaims(), middlewares.AuthMiddleware(jwtService, userService))
+	voucherGroup.DELETE("/:id", h.DeleteVoucherById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
+	voucherGroup.POST("/claims", h.ClaimVoucher(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionClaimVouchers))
+	voucherGroup.GET("/users", h.GetVoucherUser(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionClaimVouchers))
+	voucherGroup.GET("/to-claims", h.GetAllVouchersToClaims(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionClaimVouchers))
+	voucherGroup.POST("/validate", h.ValidateVoucher(), middlewares.AuthMiddleware(jwtService, userService))
+	voucherGroup.POST("/redeem", h.RedeemVoucherCode(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionClaimVouchers))
+	voucherGroup.POST("/:id/codes", h.GenerateVoucherCodes(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
+	voucherGroup.GET("/:id/codes", h.GetVoucherCodes(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageVouchers))
 }
 
-func RouteProduct(e *echo.Echo, h product.HandlerProductInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteProduct(e *echo.Echo, h product.HandlerProductInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	productsGroup := e.Group("api/v1/products")
 	productsGroup.GET("", h.GetAllProducts())
-	productsGroup.POST("", h.CreateProduct(), middlewares.AuthMiddleware(jwtService, userService))
+	productsGroup.POST("", h.CreateProduct(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
 	productsGroup.GET("/:id", h.GetProductById())
-	productsGroup.POST("/images", h.CreateProductImage(), middlewares.AuthMiddleware(jwtService, userService))
-	productsGroup.GET("/reviews", h.GetAllProductsReview(), middlewares.AuthMiddleware(jwtService, userService))
-	productsGroup.PUT("/:id", h.UpdateProduct(), middlewares.AuthMiddleware(jwtService, userService))
-	productsGroup.DELETE("/:id", h.DeleteProduct(), middlewares.AuthMiddleware(jwtService, userService))
-	productsGroup.DELETE("/:idProduct/image/:idImage", h.DeleteProductImageById(), middlewares.AuthMiddleware(jwtService, userService))
+	productsGroup.POST("/images", h.CreateProductImage(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.GET("/reviews", h.GetAllProductsReview(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.PUT("/:id", h.UpdateProduct(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.DELETE("/:id", h.DeleteProduct(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.PUT("/:id/stock", h.UpdateProductStock(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageStock))
+	productsGroup.POST("/:id/stock/adjustments", h.AdjustStock(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageStock))
+	productsGroup.GET("/:id/stock/movements", h.GetInventoryMovements(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageStock))
+	productsGroup.POST("/:id/variants", h.CreateVariant(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.PUT("/:id/variants/:variant_id", h.UpdateVariant(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.DELETE("/:id/variants/:variant_id", h.DeleteVariant(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
+	productsGroup.DELETE("/:idProduct/image/:idImage", h.DeleteProductImageById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageProducts))
 	productsGroup.GET("/preferences", h.GetAllProductsPreferences(), middlewares.AuthMiddleware(jwtService, userService))
 	productsGroup.GET("/other-products", h.GetTopRatedProducts(), middlewares.AuthMiddleware(jwtService, userService))
 }
 
-func RouteArticle(e *echo.Echo, h article.HandlerArticleInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteArticle(e *echo.Echo, h article.HandlerArticleInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	articlesGroup := e.Group("api/v1/articles")
-	articlesGroup.POST("", h.CreateArticle(), middlewares.AuthMiddleware(jwtService, userService))
+	articlesGroup.POST("", h.CreateArticle(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageArticles))
 	articlesGroup.GET("", h.GetAllArticles(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.GET("/:id", h.GetArticleById(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.PUT("/:id", h.UpdateArticleById(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.DELETE("/:id", h.DeleteArticleById(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.POST("/bookmark", h.BookmarkArticle(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.DELETE("/bookmark/:id", h.DeleteBookmarkedArticle(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.GET("/bookmark", h.GetUsersBookmark(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.GET("/preferences", h.GetAllArticleUser(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.GET("/other-article", h.GetOtherArticle(), middlewares.AuthMiddleware(jwtService, userService))
-	articlesGroup.GET("/latest-article", h.GetLatestArticle(), middlewares.AuthMiddleware(jwtService, userService))
-}
-
-func RouteChallenge(e *echo.Echo, h challenge.HandlerChallengeInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+	articlesGroup.GET("/:id", h.GetArticleById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.LoadPermissions(roleService))
+	articlesGroup.PUT("/:id", h.UpdateArticleById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageArticles))
+	articlesGroup.DELETE("/:id", h.DeleteArticleById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageArticles))
+	articlesGroup.POST("/bookmark", h.BookmarkArticle(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+	articlesGroup.DELETE("/bookmark/:id", h.DeleteBookmarkedArticle(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+	articlesGroup.GET("/bookmark", h.GetUsersBookmark(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+	articlesGroup.GET("/preferences", h.GetAllArticleUser(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+	articlesGroup.GET("/other-article", h.GetOtherArticle(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+	articlesGroup.GET("/latest-article", h.GetLatestArticle(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionBookmarkArticles))
+}
+
+func RouteChallenge(e *echo.Echo, h challenge.HandlerChallengeInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	challengesGroup := e.Group("api/v1/challenges")
 	challengesGroup.GET("", h.GetAllChallenges(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.POST("", h.CreateChallenge(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.PUT("/:id", h.UpdateChallenge(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.DELETE("/:id", h.DeleteChallengeById(), middlewares.AuthMiddleware(jwtService, userService))
+	challengesGroup.POST("", h.CreateChallenge(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
+	challengesGroup.PUT("/:id", h.UpdateChallenge(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
+	challengesGroup.DELETE("/:id", h.DeleteChallengeById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
 	challengesGroup.GET("/:id", h.GetChallengeById(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.POST("/submit", h.CreateSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.PUT("/participants/status/:id", h.UpdateSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.GET("/participants", h.GetAllSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService))
-	challengesGroup.GET("/participants/:id", h.GetSubmitChallengeFormById(), middlewares.AuthMiddleware(jwtService, userService))
+	challengesGroup.POST("/submit", h.CreateSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionJoinChallenges))
+	challengesGroup.PUT("/participants/status/:id", h.UpdateSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
+	challengesGroup.GET("/participants", h.GetAllSubmitChallengeForm(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
+	challengesGroup.GET("/participants/:id", h.GetSubmitChallengeFormById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageChallenges))
 }
 
-func RouteCategory(e *echo.Echo, h category.HandlerCategoryInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteCategory(e *echo.Echo, h category.HandlerCategoryInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	categoriesGroup := e.Group("/api/v1/categories")
 	categoriesGroup.GET("", h.GetAllCategory(), middlewares.AuthMiddleware(jwtService, userService))
-	categoriesGroup.GET("/:id", h.GetCategoryById(), middlewares.AuthMiddleware(jwtService, userService))
-	categoriesGroup.POST("", h.CreateCategory(), middlewares.AuthMiddleware(jwtService, userService))
-	categoriesGroup.PUT("/:id", h.UpdateCategoryById(), middlewares.AuthMiddleware(jwtService, userService))
-	categoriesGroup.DELETE("/:id", h.DeleteCategoryById(), middlewares.AuthMiddleware(jwtService, userService))
+	categoriesGroup.GET("/:id", h.GetCategoryById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCategories))
+	categoriesGroup.POST("", h.CreateCategory(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCategories))
+	categoriesGroup.PUT("/:id", h.UpdateCategoryById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCategories))
+	categoriesGroup.DELETE("/:id", h.DeleteCategoryById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCategories))
 }
 
-func RouteCarousel(e *echo.Echo, h carousel.HandlerCarouselInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteCarousel(e *echo.Echo, h carousel.HandlerCarouselInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	carouselsGroup := e.Group("/api/v1/carousel")
-	carouselsGroup.GET("", h.GetAllCarousels(), middlewares.AuthMiddleware(jwtService, userService))
-	carouselsGroup.GET("/:id", h.GetCarouselById(), middlewares.AuthMiddleware(jwtService, userService))
-	carouselsGroup.POST("", h.CreateCarousel(), middlewares.AuthMiddleware(jwtService, userService))
-	carouselsGroup.PUT("/:id", h.UpdateCarousel(), middlewares.AuthMiddleware(jwtService, userService))
-	carouselsGroup.DELETE("/:id", h.DeleteCarousel(), middlewares.AuthMiddleware(jwtService, userService))
+	carouselsGroup.GET("", h.GetAllCarousels(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCarousels))
+	carouselsGroup.GET("/:id", h.GetCarouselById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCarousels))
+	carouselsGroup.POST("", h.CreateCarousel(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCarousels))
+	carouselsGroup.PUT("/:id", h.UpdateCarousel(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCarousels))
+	carouselsGroup.DELETE("/:id", h.DeleteCarousel(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageCarousels))
 
 }
 
-func RouteAddress(e *echo.Echo, h address.HandlerAddressInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteAddress(e *echo.Echo, h address.HandlerAddressInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	addressesGroup := e.Group("/api/v1/address")
-	addressesGroup.GET("", h.GetAllAddress(), middlewares.AuthMiddleware(jwtService, userService))
-	addressesGroup.POST("", h.CreateAddress(), middlewares.AuthMiddleware(jwtService, userService))
-	addressesGroup.PUT("/:id", h.UpdateAddress(), middlewares.AuthMiddleware(jwtService, userService))
-	addressesGroup.DELETE("/:id", h.DeleteAddress(), middlewares.AuthMiddleware(jwtService, userService))
+	addressesGroup.GET("", h.GetAllAddress(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnAddresses))
+	addressesGroup.POST("", h.CreateAddress(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnAddresses))
+	addressesGroup.PUT("/:id", h.UpdateAddress(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnAddresses))
+	addressesGroup.DELETE("/:id", h.DeleteAddress(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnAddresses))
 }
 
-func RouteReview(e *echo.Echo, h review.HandlerReviewInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteReview(e *echo.Echo, h review.HandlerReviewInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	reviewsGroup := e.Group("/api/v1/reviews")
-	reviewsGroup.POST("", h.CreateReview(), middlewares.AuthMiddleware(jwtService, userService))
-	reviewsGroup.POST("/photos", h.CreateReviewImages(), middlewares.AuthMiddleware(jwtService, userService))
+	reviewsGroup.POST("", h.CreateReview(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionCreateReviews))
+	reviewsGroup.POST("/photos", h.CreateReviewImages(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionCreateReviews))
 	reviewsGroup.GET("/:id", h.GetReviewById(), middlewares.AuthMiddleware(jwtService, userService))
 	reviewsGroup.GET("/detail/:id", h.GetDetailReviewProduct(), middlewares.AuthMiddleware(jwtService, userService))
 }
 
-func RouteCart(e *echo.Echo, h cart.HandlerCartInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteCart(e *echo.Echo, h cart.HandlerCartInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	cartGroup := e.Group("/api/v1/carts")
-	cartGroup.POST("", h.AddCartItem(), middlewares.AuthMiddleware(jwtService, userService))
-	cartGroup.GET("", h.GetCart(), middlewares.AuthMiddleware(jwtService, userService))
-	cartGroup.PUT("/reduce/quantity", h.ReduceQuantity(), middlewares.AuthMiddleware(jwtService, userService))
-	cartGroup.DELETE("/cart-items/:id", h.DeleteCartItems(), middlewares.AuthMiddleware(jwtService, userService))
+	cartGroup.POST("", h.AddCartItem(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnCart))
+	cartGroup.GET("", h.GetCart(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnCart))
+	cartGroup.PUT("/reduce/quantity", h.ReduceQuantity(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnCart))
+	cartGroup.DELETE("/cart-items/:id", h.DeleteCartItems(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnCart))
 }
 
-func RouteOrder(e *echo.Echo, h order.HandlerOrderInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteOrder(e *echo.Echo, h order.HandlerOrderInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	orderGroup := e.Group("/api/v1/order")
-	orderGroup.GET("", h.GetAllOrders(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.GET("/payment", h.GetAllPayment(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.GET("/:id", h.GetOrderById(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.POST("", h.CreateOrder(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.POST("/confirm/:id", h.ConfirmPayment(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.POST("/carts", h.CreateOrderFromCart(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.POST("/cancel/:id", h.CancelPayment(), middlewares.AuthMiddleware(jwtService, userService))
+	orderGroup.GET("", h.GetAllOrders(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
+	orderGroup.GET("/payment", h.GetAllPayment(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
+	orderGroup.GET("/:id", h.GetOrderById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders, role.PermissionManageOrders))
+	orderGroup.POST("", h.CreateOrder(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders))
+	orderGroup.POST("/confirm/:id", h.ConfirmPayment(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
+	orderGroup.POST("/carts", h.CreateOrderFromCart(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders))
+	orderGroup.POST("/cancel/:id", h.CancelPayment(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
 	orderGroup.POST("/callback", h.Callback())
-	orderGroup.PUT("/update-order", h.UpdateOrderStatus(), middlewares.AuthMiddleware(jwtService, userService))
+	orderGroup.PUT("/update-order", h.UpdateOrderStatus(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
 	orderGroup.GET("/by-users", h.GetAllOrderByUserID(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.PUT("/accept-order/:id", h.AcceptOrder(), middlewares.AuthMiddleware(jwtService, userService))
-	orderGroup.GET("/track", h.Tracking(), middlewares.AuthMiddleware(jwtService, userService))
+	orderGroup.PUT("/accept-order/:id", h.AcceptOrder(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders))
+	orderGroup.GET("/track", h.Tracking(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders, role.PermissionManageOrders))
+	orderGroup.GET("/timeline/:id", h.GetOrderTimeline(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders, role.PermissionManageOrders))
+	orderGroup.POST("/returns", h.CreateReturn(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders))
+	orderGroup.GET("/returns", h.GetReturns(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders, role.PermissionManageOrders))
+	orderGroup.GET("/returns/:id", h.GetReturnById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnOrders, role.PermissionManageOrders))
+	orderGroup.PUT("/returns/:id/approve", h.ApproveReturn(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
+	orderGroup.PUT("/returns/:id/reject", h.RejectReturn(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageOrders))
 }
 
-func RouteAssistant(e *echo.Echo, h assistant.HandlerAssistantInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteAssistant(e *echo.Echo, h assistant.HandlerAssistantInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	assistantGroup := e.Group("/api/v1/assistant")
-	assistantGroup.POST("/question", h.CreateQuestion(), middlewares.AuthMiddleware(jwtService, userService))
-	assistantGroup.POST("/answer", h.CreateAnswer(), middlewares.AuthMiddleware(jwtService, userService))
-	assistantGroup.GET("", h.GetChatByIdUser(), middlewares.AuthMiddleware(jwtService, userService))
+	assistantGroup.POST("/question", h.CreateQuestion(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionUseAssistant))
+	assistantGroup.POST("/answer", h.CreateAnswer(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionUseAssistant))
+	assistantGroup.GET("", h.GetChatByIdUser(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionUseAssistant))
 	assistantGroup.POST("/generate-article", h.GenerateArticle(), middlewares.AuthMiddleware(jwtService, userService))
-	assistantGroup.GET("/product", h.GetProductByIdUser(), middlewares.AuthMiddleware(jwtService, userService))
+	assistantGroup.GET("/product", h.GetProductByIdUser(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionUseAssistant))
 }
 
-func RouteDashboard(e *echo.Echo, h dashboard.HandlerDashboardInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteDashboard(e *echo.Echo, h dashboard.HandlerDashboardInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	dashboardGroup := e.Group("/api/v1/dashboards")
-	dashboardGroup.GET("/card", h.GetCardDashboard(), middlewares.AuthMiddleware(jwtService, userService))
+	dashboardGroup.GET("/card", h.GetCardDashboard(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionViewDashboard))
 	dashboardGroup.GET("/landing-page", h.GetLandingPage())
 	dashboardGroup.GET("/reviews", h.GetReview())
-	dashboardGroup.GET("/chart", h.GetGramPlasticStat(), middlewares.AuthMiddleware(jwtService, userService))
-	dashboardGroup.GET("/transactions", h.GetLastTransactions(), middlewares.AuthMiddleware(jwtService, userService))
+	dashboardGroup.GET("/chart", h.GetGramPlasticStat(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionViewDashboard))
+	dashboardGroup.GET("/transactions", h.GetLastTransactions(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionViewDashboard))
 
 }
 
@@ -186,10 +235,43 @@ func RouteHomepage(e *echo.Echo, h homepage.HandlerHomepageInterface, jwtService
 	homeGroup.GET("/content", h.GetHomepageContent(), middlewares.AuthMiddleware(jwtService, userService))
 }
 
-func RouteFcm(e *echo.Echo, h fcm.HandlerFcmInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+func RouteFcm(e *echo.Echo, h fcm.HandlerFcmInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
 	fcmGroup := e.Group("/api/v1/fcm")
-	fcmGroup.GET("/:id", h.GetFcmById(), middlewares.AuthMiddleware(jwtService, userService))
-	fcmGroup.GET("/users", h.GetFcmByIdUser(), middlewares.AuthMiddleware(jwtService, userService))
-	fcmGroup.POST("", h.CreateFcm(), middlewares.AuthMiddleware(jwtService, userService))
-	fcmGroup.PUT("/:id", h.DeleteFcmById(), middlewares.AuthMiddleware(jwtService, userService))
+	fcmGroup.GET("/devices", h.GetDevices(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	fcmGroup.POST("/devices", h.RegisterDevice(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	fcmGroup.DELETE("/devices", h.UnregisterDevice(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+}
+
+func RouteNotification(e *echo.Echo, h notification.HandlerNotificationInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
+	notificationGroup := e.Group("/api/v1/notifications")
+	notificationGroup.GET("", h.GetNotifications(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.GET("/unread-count", h.GetUnreadCount(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.PUT("/read-all", h.MarkAllRead(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.GET("/preferences", h.GetPreferences(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.PUT("/preferences", h.UpdatePreferences(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.PUT("/:id/read", h.MarkRead(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.DELETE("/:id", h.DeleteNotification(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionOwnNotifications))
+	notificationGroup.POST("", h.SendNotification(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageUsers))
+}
+
+func RouteShipping(e *echo.Echo, h shipping.HandlerShippingInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface) {
+	shippingGroup := e.Group("/api/v1/shipping")
+	shippingGroup.POST("/quote", h.GetQuote(), middlewares.AuthMiddleware(jwtService, userService))
+}
+
+func RouteRole(e *echo.Echo, h role.HandlerRoleInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
+	roleGroup := e.Group("/api/v1/roles")
+	roleGroup.GET("", h.GetRoles(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.GET("/permissions", h.GetPermissions(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.GET("/:id", h.GetRoleById(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.POST("", h.CreateRole(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.PUT("/:id", h.UpdateRole(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.DELETE("/:id", h.DeleteRole(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+	roleGroup.PUT("/users/:id", h.AssignUserRole(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageRoles))
+}
+
+func RouteSearch(e *echo.Echo, h search.HandlerSearchInterface, jwtService utils.JWTInterface, userService users.ServiceUserInterface, roleService role.ServiceRoleInterface) {
+	searchGroup := e.Group("/api/v1/search")
+	searchGroup.GET("", h.Search(), middlewares.AuthMiddleware(jwtService, userService))
+	searchGroup.POST("/reindex", h.Reindex(), middlewares.AuthMiddleware(jwtService, userService), middlewares.RequirePermission(roleService, role.PermissionManageSearch))
 }
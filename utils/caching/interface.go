@@ -5,4 +5,7 @@ import "time"
 type CacheRepository interface {
 	Get(key string) ([]byte, error)
 	Set(key string, entry []byte, expiration time.Duration) error
+	SetNX(key string, entry []byte, expiration time.Duration) (bool, error)
+	Incr(key string, expiration time.Duration) (int64, error)
+	Del(key string) error
 }
@@ -13,6 +13,20 @@ type CacheRepository struct {
 	mock.Mock
 }
 
+// Del provides a mock function with given fields: key
+func (_m *CacheRepository) Del(key string) error {
+	ret := _m.Called(key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: key
 func (_m *CacheRepository) Get(key string) ([]byte, error) {
 	ret := _m.Called(key)
@@ -39,6 +53,30 @@ func (_m *CacheRepository) Get(key string) ([]byte, error) {
 	return r0, r1
 }
 
+// Incr provides a mock function with given fields: key, expiration
+func (_m *CacheRepository) Incr(key string, expiration time.Duration) (int64, error) {
+	ret := _m.Called(key, expiration)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) (int64, error)); ok {
+		return rf(key, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(string, time.Duration) int64); ok {
+		r0 = rf(key, expiration)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = rf(key, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Set provides a mock function with given fields: key, entry, expiration
 func (_m *CacheRepository) Set(key string, entry []byte, expiration time.Duration) error {
 	ret := _m.Called(key, entry, expiration)
@@ -53,6 +91,30 @@ func (_m *CacheRepository) Set(key string, entry []byte, expiration time.Duratio
 	return r0
 }
 
+// SetNX provides a mock function with given fields: key, entry, expiration
+func (_m *CacheRepository) SetNX(key string, entry []byte, expiration time.Duration) (bool, error) {
+	ret := _m.Called(key, entry, expiration)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Duration) (bool, error)); ok {
+		return rf(key, entry, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(string, []byte, time.Duration) bool); ok {
+		r0 = rf(key, entry, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []byte, time.Duration) error); ok {
+		r1 = rf(key, entry, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewCacheRepository creates a new instance of CacheRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCacheRepository(t interface {
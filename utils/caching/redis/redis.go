@@ -43,3 +43,28 @@ func (r redisCacheRepository) Get(key string) ([]byte, error) {
 func (r redisCacheRepository) Set(key string, entry []byte, expiration time.Duration) error {
 	return r.rdb.Set(context.Background(), key, entry, expiration).Err()
 }
+
+func (r redisCacheRepository) SetNX(key string, entry []byte, expiration time.Duration) (bool, error) {
+	return r.rdb.SetNX(context.Background(), key, entry, expiration).Result()
+}
+
+// incrScript increments and sets the expiry in one step, so a failure between
+// the two can never leave a counter without a TTL. A counter found without one
+// gets it too, which heals keys left behind by the old two-step version.
+var incrScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if tonumber(ARGV[1]) > 0 and redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// Incr bumps a counter and starts its expiry on the first hit, which gives a
+// fixed window counter for rate limiting. A zero expiration keeps the counter.
+func (r redisCacheRepository) Incr(key string, expiration time.Duration) (int64, error) {
+	return incrScript.Run(context.Background(), r.rdb, []string{key}, expiration.Milliseconds()).Int64()
+}
+
+func (r redisCacheRepository) Del(key string) error {
+	return r.rdb.Del(context.Background(), key).Err()
+}
@@ -2,21 +2,31 @@ package database
 
 import (
 	entities "github.com/capstone-kelompok-7/backend-disappear/module/entities"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 func Migrate(db *gorm.DB) {
+	normalizeVoucherCodes(db)
+
 	err := db.AutoMigrate(
 		entities.VoucherModels{},
 		entities.UserModels{},
+		entities.RoleModels{},
+		entities.PermissionModels{},
 		entities.ArticleBookmarkModels{},
 		entities.AddressModels{},
 		entities.CategoryModels{},
 		entities.ProductModels{},
 		entities.ProductPhotosModels{},
+		entities.ProductVariantModels{},
+		entities.InventoryMovementModels{},
 		entities.ReviewModels{},
 		entities.ArticleModels{},
 		entities.OTPModels{},
+		entities.RefreshTokenModels{},
+		entities.TwoFactorModels{},
+		entities.RecoveryCodeModels{},
 		entities.ChallengeModels{},
 		entities.CarouselModels{},
 		entities.ReviewPhotoModels{},
@@ -25,12 +35,72 @@ func Migrate(db *gorm.DB) {
 		entities.ChallengeFormModels{},
 		entities.OrderModels{},
 		entities.OrderDetailsModels{},
+		entities.OrderStatusHistoryModels{},
+		entities.PaymentWebhookLogModels{},
+		entities.OrderReturnModels{},
+		entities.OrderReturnPhotoModels{},
+		entities.OrderRefundModels{},
+		entities.ShipmentModels{},
+		entities.ShipmentEventModels{},
 		entities.VoucherClaimModels{},
+		entities.VoucherProductModels{},
+		entities.VoucherCategoryModels{},
+		entities.VoucherUsageModels{},
+		entities.VoucherCodeModels{},
 		entities.EnvironmentIssuesModels{},
 		entities.FcmModels{},
+		entities.UserDeviceModels{},
+		entities.NotificationOutboxModels{},
+		entities.NotificationPreferenceModels{},
+		entities.SearchDocumentModels{},
+		entities.SearchTermModels{},
 	)
 
 	if err != nil {
+		logrus.Error("Database : cannot migrate database ", err.Error())
+		return
+	}
+
+	migrateDeviceTokens(db)
+	dropInboxOrderConstraint(db)
+}
+
+// normalizeVoucherCodes upper-cases and trims codes saved before lookups were
+// case-insensitive, so the unique index on vouchers.code sees them the way the
+// service compares them. Codes that collide after this have to be renamed by
+// hand before the index can be created.
+func normalizeVoucherCodes(db *gorm.DB) {
+	if !db.Migrator().HasTable(&entities.VoucherModels{}) {
+		return
+	}
+	if err := db.Exec("UPDATE vouchers SET code = UPPER(TRIM(code))").Error; err != nil {
+		logrus.Error("Database : cannot normalize voucher codes ", err.Error())
+	}
+}
+
+// migrateDeviceTokens moves the old single users.device_token column into
+// user_devices and drops it, so existing users keep getting notifications.
+func migrateDeviceTokens(db *gorm.DB) {
+	if !db.Migrator().HasColumn(&entities.UserModels{}, "device_token") {
+		return
+	}
+
+	err := db.Exec("INSERT IGNORE INTO user_devices (user_id, token, last_seen_at, created_at) " +
+		"SELECT id, device_token, last_login, NOW() FROM users WHERE device_token <> '' AND deleted_at IS NULL").Error
+	if err != nil {
+		logrus.Error("Database : cannot move device tokens to user_devices ", err.Error())
 		return
 	}
+
+	if err := db.Migrator().DropColumn(&entities.UserModels{}, "device_token"); err != nil {
+		logrus.Error("Database : cannot drop users.device_token ", err.Error())
+	}
+}
+
+// dropInboxOrderConstraint removes the old foreign key from the inbox to orders,
+// which made notifications that are not about an order impossible.
+func dropInboxOrderConstraint(db *gorm.DB) {
+	if db.Migrator().HasConstraint(&entities.FcmModels{}, "fk_fcms_order") {
+		_ = db.Migrator().DropConstraint(&entities.FcmModels{}, "fk_fcms_order")
+	}
 }
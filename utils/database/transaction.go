@@ -0,0 +1,23 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+type TransactionManagerInterface interface {
+	WithTransaction(fn func(tx *gorm.DB) error) error
+}
+
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) TransactionManagerInterface {
+	return &TransactionManager{
+		db: db,
+	}
+}
+
+func (m *TransactionManager) WithTransaction(fn func(tx *gorm.DB) error) error {
+	return m.db.Transaction(fn)
+}
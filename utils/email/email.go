@@ -1,79 +1,110 @@
 package email
 
 import (
-	"errors"
-	"github.com/wneessen/go-mail"
-	"html/template"
-	"os"
-	"path/filepath"
-	"runtime"
-	"strconv"
-	"strings"
+	"log"
+
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"github.com/sirupsen/logrus"
 )
 
-type EmailSenderInterface interface {
-	EmailService(email, otp string) error
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+
+	defaultFrom = "no-reply@disappear.id"
+)
+
+// Message is one rendered email. HTML is optional; Text is always sent.
+type Message struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
 }
 
-type Sender struct{}
+// Transport hands a message over for delivery.
+type Transport interface {
+	Send(message *Message) error
+}
 
-func NewEmailService() EmailSenderInterface {
-	return &Sender{}
+type EmailSenderInterface interface {
+	EmailService(email, otp string) error
+	SendEmail(email, subject, body string) error
+	SendTemplate(email, name string, data map[string]string) error
 }
 
-func (s *Sender) EmailService(email, otp string) error {
-	secretUser := os.Getenv("SMTP_USER")
-	secretPass := os.Getenv("SMTP_PASS")
-	secretPort := os.Getenv("SMTP_PORT")
+type Sender struct {
+	from      string
+	transport Transport
+}
 
-	convPort, err := strconv.Atoi(secretPort)
-	if err != nil {
-		return err
+func NewEmailService(config config.Config) EmailSenderInterface {
+	switch config.Mail.Driver {
+	case "", DriverSMTP:
+		return NewSender(config.Mail.From, NewSMTPTransport(config.Mail))
+	case DriverFile:
+		return NewSender(config.Mail.From, NewFileTransport(config.Mail.SinkDir))
+	default:
+		log.Fatal("Config : invalid mail driver ", config.Mail.Driver)
+		return nil
 	}
+}
 
-	m := mail.NewMsg()
-	if err := m.From(secretUser); err != nil {
-		return err
-	}
-	if err := m.To(email); err != nil {
-		return err
+func NewSender(from string, transport Transport) *Sender {
+	if from == "" {
+		from = defaultFrom
 	}
-
-	m.Subject("Verifikasi Email - Disappear Organization")
-	emailTemplate := struct {
-		OTP   string
-		Email string
-	}{
-		OTP:   otp,
-		Email: email,
+	return &Sender{
+		from:      from,
+		transport: transport,
 	}
+}
 
-	_, filename, _, ok := runtime.Caller(0)
-	if !ok {
-		return errors.New("Failed to get the current file path")
-	}
+// EmailService sends the one-time password used to verify an address.
+func (s *Sender) EmailService(email, otp string) error {
+	return s.SendTemplate(email, TemplateOTP, map[string]string{
+		"Email": email,
+		"OTP":   otp,
+	})
+}
 
-	templatePath := filepath.Join(filepath.Dir(filename), "template.html")
+// SendEmail sends a plain text message, used for notifications.
+func (s *Sender) SendEmail(email, subject, body string) error {
+	return s.transport.Send(&Message{
+		From:    s.from,
+		To:      email,
+		Subject: subject,
+		Text:    body,
+	})
+}
 
-	tmpl, err := template.New("emailTemplate").ParseFiles(templatePath)
+// SendTemplate renders the named template with data and sends it with both a
+// text and an HTML part.
+func (s *Sender) SendTemplate(email, name string, data map[string]string) error {
+	subject, text, html, err := Render(name, data)
 	if err != nil {
 		return err
 	}
 
-	var bodyContent strings.Builder
-	if err := tmpl.ExecuteTemplate(&bodyContent, "template.html", emailTemplate); err != nil {
-		return err
-	}
-
-	m.SetBodyString(mail.TypeTextHTML, bodyContent.String())
+	return s.transport.Send(&Message{
+		From:    s.from,
+		To:      email,
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	})
+}
 
-	c, err := mail.NewClient("smtp.gmail.com", mail.WithPort(convPort), mail.WithSMTPAuth(mail.SMTPAuthPlain), mail.WithUsername(secretUser), mail.WithPassword(secretPass))
-	if err != nil {
-		return err
-	}
-	if err := c.DialAndSend(m); err != nil {
-		return err
+// SendPasswordChanged warns the owner of an account that its password was
+// changed. It is sent straight away rather than through the notification
+// outbox, since a security notice must not depend on the user's preferences.
+// The change already happened, so a failure is only logged.
+func SendPasswordChanged(sender EmailSenderInterface, userID uint64, name, email string) {
+	if err := sender.SendTemplate(email, TemplatePasswordChanged, map[string]string{
+		"Name":  name,
+		"Email": email,
+	}); err != nil {
+		logrus.Warnf("Email perubahan password untuk pengguna %d gagal dikirim: %v", userID, err)
 	}
-
-	return nil
 }
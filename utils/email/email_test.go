@@ -0,0 +1,177 @@
+package email
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		subject string
+		text    string
+		html    string
+	}{
+		{
+			name:    TemplateOTP,
+			data:    map[string]string{"Email": "user@example.com", "OTP": "123456"},
+			subject: "Verifikasi Email - Disappear Organization",
+			text:    "123456",
+			html:    "123456",
+		},
+		{
+			name:    TemplateOrderReceipt,
+			data:    map[string]string{"Name": "Budi", "OrderID": "ORD-1", "Total": "Rp 50.000"},
+			subject: "Pesanan ORD-1 Berhasil Dibuat",
+			text:    "Total pembayaran: Rp 50.000",
+			html:    "ORD-1",
+		},
+		{
+			name:    TemplatePasswordChanged,
+			data:    map[string]string{"Name": "Budi", "Email": "budi@example.com"},
+			subject: "Password Akun Disappear Diubah",
+			text:    "Hi Budi,",
+			html:    "<b>budi@example.com</b>",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			subject, text, html, err := Render(test.name, test.data)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.subject, subject)
+			assert.Contains(t, text, test.text)
+			assert.Contains(t, html, test.html)
+			assert.Contains(t, html, "<title>"+test.subject+"</title>")
+		})
+	}
+
+	t.Run("Escapes HTML but not text", func(t *testing.T) {
+		_, text, html, err := Render(TemplatePasswordChanged, map[string]string{"Name": "<script>", "Email": "budi@example.com"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, text, "Hi <script>,")
+		assert.Contains(t, html, "Hi &lt;script&gt;,")
+		assert.NotContains(t, html, "<script>")
+	})
+
+	t.Run("Missing key renders empty", func(t *testing.T) {
+		subject, text, html, err := Render(TemplateShipped, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Pesanan  Sedang Dikirim", subject)
+		assert.NotContains(t, text, "no value")
+		assert.NotContains(t, html, "no value")
+	})
+
+	t.Run("Unknown template", func(t *testing.T) {
+		_, _, _, err := Render("unknown", nil)
+
+		assert.EqualError(t, err, `template email "unknown" tidak ditemukan`)
+		assert.False(t, IsValidTemplate("unknown"))
+	})
+}
+
+func TestFileTransport_Send(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	sender := NewSender("", NewFileTransport(dir))
+
+	err := sender.SendTemplate("budi@example.com", TemplatePasswordChanged, map[string]string{
+		"Name":  "Budi",
+		"Email": "budi@example.com",
+	})
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		assert.True(t, strings.HasSuffix(files[0].Name(), "-1-budi_at_example.com.eml"))
+
+		content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+		assert.NoError(t, err)
+		eml := string(content)
+		assert.Contains(t, eml, "Subject: Password Akun Disappear Diubah")
+		assert.Contains(t, eml, "<"+defaultFrom+">")
+		assert.Contains(t, eml, "<budi@example.com>")
+		assert.Contains(t, eml, "multipart/alternative")
+		assert.Contains(t, eml, "Content-Type: text/plain")
+		assert.Contains(t, eml, "Content-Type: text/html")
+		assert.Contains(t, eml, "Hi Budi,")
+	}
+}
+
+func TestFileTransport_SendPlainText(t *testing.T) {
+	dir := t.TempDir()
+
+	err := NewSender("toko@disappear.id", NewFileTransport(dir)).SendEmail("budi@example.com", "Halo", "Isi pesan")
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	if assert.Len(t, files, 1) {
+		content, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+		assert.NoError(t, err)
+		eml := string(content)
+		assert.Contains(t, eml, "Subject: Halo")
+		assert.Contains(t, eml, "<toko@disappear.id>")
+		assert.Contains(t, eml, "Isi pesan")
+		assert.NotContains(t, eml, "text/html")
+	}
+}
+
+func TestMailbox_Send(t *testing.T) {
+	t.Run("Keeps messages per recipient", func(t *testing.T) {
+		mailbox := NewMailbox()
+		sender := NewSender("", mailbox)
+
+		assert.NoError(t, sender.EmailService("budi@example.com", "123456"))
+		assert.NoError(t, sender.SendEmail("ani@example.com", "Halo", "Isi pesan"))
+
+		assert.Len(t, mailbox.Messages(), 2)
+		if messages := mailbox.To("budi@example.com"); assert.Len(t, messages, 1) {
+			assert.Equal(t, "Verifikasi Email - Disappear Organization", messages[0].Subject)
+			assert.Contains(t, messages[0].Text, "123456")
+			assert.Contains(t, messages[0].HTML, "123456")
+		}
+	})
+
+	t.Run("Rejects invalid address", func(t *testing.T) {
+		mailbox := NewMailbox()
+
+		err := NewSender("", mailbox).SendEmail("bukan email", "Halo", "Isi pesan")
+
+		assert.Error(t, err)
+		assert.Empty(t, mailbox.Messages())
+	})
+}
+
+type failingTransport struct{}
+
+func (failingTransport) Send(*Message) error {
+	return errors.New("smtp down")
+}
+
+func TestSendPasswordChanged(t *testing.T) {
+	t.Run("Sends the notice", func(t *testing.T) {
+		mailbox := NewMailbox()
+
+		SendPasswordChanged(NewSender("", mailbox), 1, "Budi", "budi@example.com")
+
+		if messages := mailbox.To("budi@example.com"); assert.Len(t, messages, 1) {
+			assert.Equal(t, "Password Akun Disappear Diubah", messages[0].Subject)
+			assert.Contains(t, messages[0].Text, "budi@example.com")
+		}
+	})
+
+	t.Run("Transport failure is only logged", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			SendPasswordChanged(NewSender("", failingTransport{}), 1, "Budi", "budi@example.com")
+		})
+	})
+}
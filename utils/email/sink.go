@@ -0,0 +1,80 @@
+package email
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FileTransport writes every message as an .eml file into a directory instead
+// of sending it, for local development without an SMTP server.
+type FileTransport struct {
+	dir string
+	seq uint64
+}
+
+func NewFileTransport(dir string) *FileTransport {
+	return &FileTransport{
+		dir: dir,
+	}
+}
+
+func (t *FileTransport) Send(message *Message) error {
+	m, err := buildMsg(message)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient := strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(message.To)
+	name := fmt.Sprintf("%s-%d-%s.eml", time.Now().Format("20060102T150405"), atomic.AddUint64(&t.seq, 1), recipient)
+	return m.WriteToFile(filepath.Join(t.dir, name))
+}
+
+// Mailbox keeps sent messages in memory so tests can assert on them.
+type Mailbox struct {
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func NewMailbox() *Mailbox {
+	return &Mailbox{}
+}
+
+func (b *Mailbox) Send(message *Message) error {
+	if _, err := buildMsg(message); err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sent := *message
+	b.messages = append(b.messages, &sent)
+	return nil
+}
+
+// Messages returns what was sent so far, oldest first.
+func (b *Mailbox) Messages() []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Message(nil), b.messages...)
+}
+
+// To returns the messages sent to one address.
+func (b *Mailbox) To(email string) []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var messages []*Message
+	for _, message := range b.messages {
+		if message.To == email {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,59 @@
+package email
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"github.com/wneessen/go-mail"
+)
+
+type SMTPTransport struct {
+	host string
+	port int
+	user string
+	pass string
+}
+
+func NewSMTPTransport(config config.Mail) *SMTPTransport {
+	return &SMTPTransport{
+		host: config.Host,
+		port: config.Port,
+		user: config.User,
+		pass: config.Pass,
+	}
+}
+
+func (t *SMTPTransport) Send(message *Message) error {
+	m, err := buildMsg(message)
+	if err != nil {
+		return err
+	}
+
+	c, err := mail.NewClient(t.host, mail.WithPort(t.port), mail.WithSMTPAuth(mail.SMTPAuthPlain), mail.WithUsername(t.user), mail.WithPassword(t.pass))
+	if err != nil {
+		return err
+	}
+	if err := c.DialAndSend(m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// buildMsg turns a message into MIME: text/plain, with text/html as its
+// alternative when there is one.
+func buildMsg(message *Message) (*mail.Msg, error) {
+	m := mail.NewMsg()
+	if err := m.From(message.From); err != nil {
+		return nil, err
+	}
+	if err := m.To(message.To); err != nil {
+		return nil, err
+	}
+	m.Subject(message.Subject)
+	m.SetDate()
+	m.SetMessageID()
+	m.SetBodyString(mail.TypeTextPlain, message.Text)
+	if message.HTML != "" {
+		m.AddAlternativeString(mail.TypeTextHTML, message.HTML)
+	}
+	return m, nil
+}
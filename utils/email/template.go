@@ -0,0 +1,90 @@
+package email
+
+import (
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	TemplateOTP               = "otp"
+	TemplateOrderReceipt      = "order_receipt"
+	TemplatePaymentConfirmed  = "payment_confirmed"
+	TemplateShipped           = "shipped"
+	TemplateChallengeApproved = "challenge_approved"
+	TemplatePasswordChanged   = "password_changed"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+// subjects holds the subject line of every named template; it may use the
+// same data as the body.
+var subjects = map[string]string{
+	TemplateOTP:               "Verifikasi Email - Disappear Organization",
+	TemplateOrderReceipt:      "Pesanan {{.OrderID}} Berhasil Dibuat",
+	TemplatePaymentConfirmed:  "Pembayaran Pesanan {{.OrderID}} Diterima",
+	TemplateShipped:           "Pesanan {{.OrderID}} Sedang Dikirim",
+	TemplateChallengeApproved: "Tantangan {{.Challenge}} Disetujui",
+	TemplatePasswordChanged:   "Password Akun Disappear Diubah",
+}
+
+type mailTemplate struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+var mailTemplates = parseTemplates()
+
+func parseTemplates() map[string]*mailTemplate {
+	parsed := make(map[string]*mailTemplate, len(subjects))
+	for name, subject := range subjects {
+		parsed[name] = &mailTemplate{
+			subject: texttemplate.Must(texttemplate.New(name).Option("missingkey=zero").Parse(subject)),
+			text:    texttemplate.Must(texttemplate.New(name+".txt").Option("missingkey=zero").ParseFS(templateFS, "templates/"+name+".txt")),
+			html:    htmltemplate.Must(htmltemplate.New("layout.html").Option("missingkey=zero").ParseFS(templateFS, "templates/layout.html", "templates/"+name+".html")),
+		}
+	}
+	return parsed
+}
+
+// IsValidTemplate reports whether name is one of the named templates.
+func IsValidTemplate(name string) bool {
+	_, ok := mailTemplates[name]
+	return ok
+}
+
+// Render fills the named template with data and returns its subject, text
+// body and HTML body. Missing keys render as empty text.
+func Render(name string, data map[string]string) (string, string, string, error) {
+	tmpl, ok := mailTemplates[name]
+	if !ok {
+		return "", "", "", fmt.Errorf("template email %q tidak ditemukan", name)
+	}
+
+	values := make(map[string]string, len(data)+1)
+	for key, value := range data {
+		values[key] = value
+	}
+
+	var subject strings.Builder
+	if err := tmpl.subject.Execute(&subject, values); err != nil {
+		return "", "", "", err
+	}
+	values["Subject"] = subject.String()
+
+	var text strings.Builder
+	if err := tmpl.text.Execute(&text, values); err != nil {
+		return "", "", "", err
+	}
+
+	var html strings.Builder
+	if err := tmpl.html.ExecuteTemplate(&html, "layout.html", values); err != nil {
+		return "", "", "", err
+	}
+
+	return subject.String(), text.String(), html.String(), nil
+}
@@ -0,0 +1,194 @@
+package identity
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	defaultKeysTTL     = time.Hour
+	minRefreshInterval = time.Minute
+)
+
+// JWKSVerifier checks RS256 ID tokens against the provider's published keys.
+// Keys are cached for as long as the provider's Cache-Control allows and are
+// refetched early when a token names a key id that is not cached yet, which is
+// how key rotation shows up.
+type JWKSVerifier struct {
+	provider string
+	jwksURL  string
+	audience string
+	issuers  []string
+	client   *http.Client
+
+	mu          sync.RWMutex
+	keys        map[string]*rsa.PublicKey
+	expiresAt   time.Time
+	lastFetched time.Time
+}
+
+func NewJWKSVerifier(provider, jwksURL, audience string, issuers ...string) *JWKSVerifier {
+	return &JWKSVerifier{
+		provider: provider,
+		jwksURL:  jwksURL,
+		audience: audience,
+		issuers:  issuers,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type jwks struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+func (v *JWKSVerifier) Verify(idToken string) (*Identity, error) {
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok || token.Method.Alg() != "RS256" {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		kid, _ := token.Header["kid"].(string)
+		return v.key(kid)
+	})
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) || !claims.VerifyAudience(v.audience, true) {
+		return nil, ErrInvalidToken
+	}
+	if !v.verifyIssuer(claims) {
+		return nil, ErrInvalidToken
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, ErrInvalidToken
+	}
+	result := &Identity{
+		Provider: v.provider,
+		SocialID: subject,
+	}
+	result.Email, _ = claims["email"].(string)
+	result.EmailVerified, _ = claims["email_verified"].(bool)
+	result.Name, _ = claims["name"].(string)
+	result.Picture, _ = claims["picture"].(string)
+	return result, nil
+}
+
+func (v *JWKSVerifier) verifyIssuer(claims jwt.MapClaims) bool {
+	for _, issuer := range v.issuers {
+		if claims.VerifyIssuer(issuer, true) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *JWKSVerifier) key(kid string) (*rsa.PublicKey, error) {
+	v.mu.RLock()
+	key, found := v.keys[kid]
+	fresh := time.Now().Before(v.expiresAt)
+	v.mu.RUnlock()
+	if found && fresh {
+		return key, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if key, found := v.keys[kid]; found && time.Now().Before(v.expiresAt) {
+		return key, nil
+	}
+	if time.Since(v.lastFetched) >= minRefreshInterval || time.Now().After(v.expiresAt) {
+		if err := v.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	if key, found := v.keys[kid]; found {
+		return key, nil
+	}
+	return nil, errors.New("kunci token identitas tidak dikenal")
+}
+
+// refresh must be called with mu held.
+func (v *JWKSVerifier) refresh() error {
+	v.lastFetched = time.Now()
+
+	res, err := v.client.Get(v.jwksURL)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("gagal mengambil JWKS: status %d", res.StatusCode)
+	}
+
+	var body jwks
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(body.Keys))
+	for _, jwk := range body.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+		key, err := parseRSAKey(jwk.N, jwk.E)
+		if err != nil {
+			return err
+		}
+		keys[jwk.Kid] = key
+	}
+
+	v.keys = keys
+	v.expiresAt = time.Now().Add(cacheMaxAge(res.Header.Get("Cache-Control")))
+	return nil
+}
+
+func parseRSAKey(n, e string) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
+
+func cacheMaxAge(header string) time.Duration {
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.TrimSpace(directive)
+		if !strings.HasPrefix(directive, "max-age=") {
+			continue
+		}
+		seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+		if err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultKeysTTL
+}
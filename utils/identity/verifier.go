@@ -0,0 +1,46 @@
+package identity
+
+import (
+	"errors"
+	"log"
+
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+)
+
+const (
+	ProviderGoogle   = "google"
+	ProviderFirebase = "firebase"
+
+	googleJWKSURL   = "https://www.googleapis.com/oauth2/v3/certs"
+	firebaseJWKSURL = "https://www.googleapis.com/service_accounts/v1/jwk/securetoken@system.gserviceaccount.com"
+)
+
+var ErrInvalidToken = errors.New("token identitas tidak valid")
+
+// Identity is what the provider vouches for in a verified ID token.
+type Identity struct {
+	Provider      string
+	SocialID      string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+type Verifier interface {
+	Verify(idToken string) (*Identity, error)
+}
+
+func NewVerifier(config config.Config) Verifier {
+	switch config.Social.Provider {
+	case "", ProviderGoogle:
+		return NewJWKSVerifier(ProviderGoogle, googleJWKSURL, config.Social.Audience,
+			"accounts.google.com", "https://accounts.google.com")
+	case ProviderFirebase:
+		return NewJWKSVerifier(ProviderFirebase, firebaseJWKSURL, config.Social.Audience,
+			"https://securetoken.google.com/"+config.Social.Audience)
+	default:
+		log.Fatal("Config : invalid social login provider ", config.Social.Provider)
+		return nil
+	}
+}
@@ -1,51 +1,231 @@
 package utils
 
 import (
-	"github.com/golang-jwt/jwt"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
+	"github.com/golang-jwt/jwt"
+	"github.com/redis/go-redis/v9"
 )
 
+const (
+	AccessTokenTTL        = 15 * time.Minute
+	RefreshTokenTTL       = 30 * 24 * time.Hour
+	TwoFactorTokenTTL     = 5 * time.Minute
+	PasswordResetTokenTTL = 10 * time.Minute
+
+	tokenPurposeTwoFactor              = "2fa"
+	tokenPurposePasswordReset          = "password_reset"
+	tokenPurposePasswordResetTwoFactor = "password_reset_2fa"
+)
+
+var ErrTokenRevoked = errors.New("token telah dicabut")
+
 type JWTInterface interface {
 	GenerateJWT(userID uint64, email, role string) (string, error)
 	ValidateToken(tokenString string) (*jwt.Token, error)
+	GenerateTwoFactorToken(userID uint64, email, role string) (string, error)
+	ValidateTwoFactorToken(tokenString string) (*jwt.Token, error)
+	GeneratePasswordResetTwoFactorToken(userID uint64, email, role string) (string, error)
+	ValidatePasswordResetTwoFactorToken(tokenString string) (*jwt.Token, error)
+	GeneratePasswordResetToken(userID uint64, email, role string) (string, error)
+	ValidatePasswordResetToken(tokenString string) (*jwt.Token, error)
+	TokenVersion(userID uint64) (int64, error)
+	RevokeToken(tokenString string) error
+	RevokeUserTokens(userID uint64) error
 }
 
+// JWT issues short-lived access tokens. Every token carries a jti and the
+// user's token version, so a single token can be put on the denylist on logout
+// and all tokens of a user can be dropped by bumping the version.
+//
+// Two-factor tokens are the partial tokens handed out after the password step.
+// They carry a purpose claim and are only accepted by ValidateTwoFactorToken.
+// The forgot-password flow has its own purposes: a partial token for the TOTP
+// step of accounts with 2FA and a reset token that can only set a new password.
 type JWT struct {
 	Secret string
+	cache  caching.CacheRepository
 }
 
-func NewJWT(secret string) JWTInterface {
+func NewJWT(secret string, cache caching.CacheRepository) JWTInterface {
 	return &JWT{
 		Secret: secret,
+		cache:  cache,
 	}
 }
 
 func (j *JWT) GenerateJWT(userID uint64, email, role string) (string, error) {
+	return j.generate(userID, email, role, "", AccessTokenTTL)
+}
+
+func (j *JWT) ValidateToken(tokenString string) (*jwt.Token, error) {
+	return j.validate(tokenString, "")
+}
+
+func (j *JWT) GenerateTwoFactorToken(userID uint64, email, role string) (string, error) {
+	return j.generate(userID, email, role, tokenPurposeTwoFactor, TwoFactorTokenTTL)
+}
+
+func (j *JWT) ValidateTwoFactorToken(tokenString string) (*jwt.Token, error) {
+	return j.validate(tokenString, tokenPurposeTwoFactor)
+}
+
+func (j *JWT) GeneratePasswordResetTwoFactorToken(userID uint64, email, role string) (string, error) {
+	return j.generate(userID, email, role, tokenPurposePasswordResetTwoFactor, TwoFactorTokenTTL)
+}
+
+func (j *JWT) ValidatePasswordResetTwoFactorToken(tokenString string) (*jwt.Token, error) {
+	return j.validate(tokenString, tokenPurposePasswordResetTwoFactor)
+}
+
+func (j *JWT) GeneratePasswordResetToken(userID uint64, email, role string) (string, error) {
+	return j.generate(userID, email, role, tokenPurposePasswordReset, PasswordResetTokenTTL)
+}
+
+func (j *JWT) ValidatePasswordResetToken(tokenString string) (*jwt.Token, error) {
+	return j.validate(tokenString, tokenPurposePasswordReset)
+}
+
+func (j *JWT) generate(userID uint64, email, role, purpose string, ttl time.Duration) (string, error) {
+	version, err := j.TokenVersion(userID)
+	if err != nil {
+		return "", err
+	}
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	claims := jwt.MapClaims{
+		"jti":     tokenID,
 		"user_id": userID,
 		"email":   email,
 		"role":    role,
+		"ver":     version,
 		"iat":     time.Now().Unix(),
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
+		"exp":     time.Now().Add(ttl).Unix(),
+	}
+	if purpose != "" {
+		claims["purpose"] = purpose
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	accessToken, err := token.SignedString([]byte(j.Secret))
+	signedToken, err := token.SignedString([]byte(j.Secret))
 	if err != nil {
 		return "", err
 	}
 
-	return accessToken, nil
+	return signedToken, nil
 }
 
-func (j *JWT) ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
-		return []byte(j.Secret), nil
-	})
+func (j *JWT) validate(tokenString, purpose string) (*jwt.Token, error) {
+	token, err := j.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
 
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("klaim token tidak valid")
+	}
+	if tokenPurpose, _ := claims["purpose"].(string); tokenPurpose != purpose {
+		return nil, errors.New("token tidak dapat digunakan untuk permintaan ini")
+	}
+	if tokenID, _ := claims["jti"].(string); tokenID != "" {
+		_, err := j.cache.Get(revokedTokenKey(tokenID))
+		if err == nil {
+			return nil, ErrTokenRevoked
+		}
+		if !errors.Is(err, redis.Nil) {
+			return nil, errors.New("gagal memeriksa status token")
+		}
+	}
+	userID, _ := claims["user_id"].(float64)
+	tokenVersion, _ := claims["ver"].(float64)
+	version, err := j.TokenVersion(uint64(userID))
 	if err != nil {
 		return nil, err
 	}
+	if int64(tokenVersion) != version {
+		return nil, ErrTokenRevoked
+	}
 
 	return token, nil
 }
+
+// TokenVersion returns zero for users that never had their tokens revoked.
+// Any other cache failure is an error, so callers never treat a revoked
+// session as valid just because the cache is unreachable.
+func (j *JWT) TokenVersion(userID uint64) (int64, error) {
+	value, err := j.cache.Get(tokenVersionKey(userID))
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.New("gagal memeriksa versi token")
+	}
+	version, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, errors.New("versi token tidak valid")
+	}
+	return version, nil
+}
+
+// RevokeToken keeps the token's jti on the denylist until the token would have
+// expired anyway.
+func (j *JWT) RevokeToken(tokenString string) error {
+	token, err := j.parse(tokenString)
+	if err != nil {
+		return err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return errors.New("klaim token tidak valid")
+	}
+	tokenID, _ := claims["jti"].(string)
+	if tokenID == "" {
+		return errors.New("token tidak memiliki ID")
+	}
+	expiresAt, _ := claims["exp"].(float64)
+	ttl := time.Until(time.Unix(int64(expiresAt), 0))
+	if ttl <= 0 {
+		return nil
+	}
+	return j.cache.Set(revokedTokenKey(tokenID), []byte("1"), ttl)
+}
+
+func (j *JWT) RevokeUserTokens(userID uint64) error {
+	_, err := j.cache.Incr(tokenVersionKey(userID), 0)
+	return err
+}
+
+func (j *JWT) parse(tokenString string) (*jwt.Token, error) {
+	return jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("metode tanda tangan tidak didukung: %v", t.Header["alg"])
+		}
+		return []byte(j.Secret), nil
+	})
+}
+
+func generateTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func tokenVersionKey(userID uint64) string {
+	return fmt.Sprintf("auth:token-version:%d", userID)
+}
+
+func revokedTokenKey(tokenID string) string {
+	return "auth:revoked:" + tokenID
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching/mocks"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJWT_TokenVersion(t *testing.T) {
+	t.Run("Success Case - Missing Key Is Version Zero", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", tokenVersionKey(1)).Return(nil, redis.Nil).Once()
+
+		version, err := NewJWT("secret", cache).TokenVersion(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), version)
+	})
+
+	t.Run("Success Case - Stored Version", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", tokenVersionKey(1)).Return([]byte("3"), nil).Once()
+
+		version, err := NewJWT("secret", cache).TokenVersion(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), version)
+	})
+
+	t.Run("Failed Case - Cache Unavailable", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", tokenVersionKey(1)).Return(nil, errors.New("connection refused")).Once()
+
+		version, err := NewJWT("secret", cache).TokenVersion(1)
+
+		assert.EqualError(t, err, "gagal memeriksa versi token")
+		assert.Equal(t, int64(0), version)
+	})
+}
+
+func TestJWT_ValidateToken(t *testing.T) {
+	generate := func(t *testing.T) string {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", tokenVersionKey(1)).Return(nil, redis.Nil).Once()
+		token, err := NewJWT("secret", cache).GenerateJWT(1, "test@example.com", "customer")
+		assert.NoError(t, err)
+		return token
+	}
+
+	t.Run("Success Case - Valid Token", func(t *testing.T) {
+		token := generate(t)
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", mock.MatchedBy(func(key string) bool { return key != tokenVersionKey(1) })).Return(nil, redis.Nil).Once()
+		cache.On("Get", tokenVersionKey(1)).Return(nil, redis.Nil).Once()
+
+		parsed, err := NewJWT("secret", cache).ValidateToken(token)
+
+		assert.NoError(t, err)
+		assert.True(t, parsed.Valid)
+	})
+
+	t.Run("Failed Case - Revoked Token", func(t *testing.T) {
+		token := generate(t)
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", mock.AnythingOfType("string")).Return([]byte("1"), nil).Once()
+
+		parsed, err := NewJWT("secret", cache).ValidateToken(token)
+
+		assert.ErrorIs(t, err, ErrTokenRevoked)
+		assert.Nil(t, parsed)
+	})
+
+	t.Run("Failed Case - Denylist Unavailable", func(t *testing.T) {
+		token := generate(t)
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", mock.AnythingOfType("string")).Return(nil, errors.New("connection refused")).Once()
+
+		parsed, err := NewJWT("secret", cache).ValidateToken(token)
+
+		assert.EqualError(t, err, "gagal memeriksa status token")
+		assert.Nil(t, parsed)
+	})
+
+	t.Run("Failed Case - Token Version Unavailable", func(t *testing.T) {
+		token := generate(t)
+		cache := mocks.NewCacheRepository(t)
+		cache.On("Get", mock.MatchedBy(func(key string) bool { return key != tokenVersionKey(1) })).Return(nil, redis.Nil).Once()
+		cache.On("Get", tokenVersionKey(1)).Return(nil, errors.New("connection refused")).Once()
+
+		parsed, err := NewJWT("secret", cache).ValidateToken(token)
+
+		assert.EqualError(t, err, "gagal memeriksa versi token")
+		assert.Nil(t, parsed)
+	})
+}
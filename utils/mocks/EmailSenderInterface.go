@@ -23,6 +23,34 @@ func (_m *EmailSenderInterface) EmailService(_a0 string, otp string) error {
 	return r0
 }
 
+// SendEmail provides a mock function with given fields: _a0, subject, body
+func (_m *EmailSenderInterface) SendEmail(_a0 string, subject string, body string) error {
+	ret := _m.Called(_a0, subject, body)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(_a0, subject, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendTemplate provides a mock function with given fields: _a0, name, data
+func (_m *EmailSenderInterface) SendTemplate(_a0 string, name string, data map[string]string) error {
+	ret := _m.Called(_a0, name, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, map[string]string) error); ok {
+		r0 = rf(_a0, name, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEmailSenderInterface creates a new instance of EmailSenderInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEmailSenderInterface(t interface {
@@ -36,6 +36,182 @@ func (_m *JWTInterface) GenerateJWT(userID uint64, email string, role string) (s
 	return r0, r1
 }
 
+// GeneratePasswordResetToken provides a mock function with given fields: userID, email, role
+func (_m *JWTInterface) GeneratePasswordResetToken(userID uint64, email string, role string) (string, error) {
+	ret := _m.Called(userID, email, role)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string) (string, error)); ok {
+		return rf(userID, email, role)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string, string) string); ok {
+		r0 = rf(userID, email, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
+		r1 = rf(userID, email, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GeneratePasswordResetTwoFactorToken provides a mock function with given fields: userID, email, role
+func (_m *JWTInterface) GeneratePasswordResetTwoFactorToken(userID uint64, email string, role string) (string, error) {
+	ret := _m.Called(userID, email, role)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string) (string, error)); ok {
+		return rf(userID, email, role)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string, string) string); ok {
+		r0 = rf(userID, email, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
+		r1 = rf(userID, email, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateTwoFactorToken provides a mock function with given fields: userID, email, role
+func (_m *JWTInterface) GenerateTwoFactorToken(userID uint64, email string, role string) (string, error) {
+	ret := _m.Called(userID, email, role)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, string, string) (string, error)); ok {
+		return rf(userID, email, role)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, string, string) string); ok {
+		r0 = rf(userID, email, role)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, string, string) error); ok {
+		r1 = rf(userID, email, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeToken provides a mock function with given fields: tokenString
+func (_m *JWTInterface) RevokeToken(tokenString string) error {
+	ret := _m.Called(tokenString)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tokenString)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeUserTokens provides a mock function with given fields: userID
+func (_m *JWTInterface) RevokeUserTokens(userID uint64) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TokenVersion provides a mock function with given fields: userID
+func (_m *JWTInterface) TokenVersion(userID uint64) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uint64) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidatePasswordResetToken provides a mock function with given fields: tokenString
+func (_m *JWTInterface) ValidatePasswordResetToken(tokenString string) (*jwt.Token, error) {
+	ret := _m.Called(tokenString)
+
+	var r0 *jwt.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*jwt.Token, error)); ok {
+		return rf(tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *jwt.Token); ok {
+		r0 = rf(tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidatePasswordResetTwoFactorToken provides a mock function with given fields: tokenString
+func (_m *JWTInterface) ValidatePasswordResetTwoFactorToken(tokenString string) (*jwt.Token, error) {
+	ret := _m.Called(tokenString)
+
+	var r0 *jwt.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*jwt.Token, error)); ok {
+		return rf(tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *jwt.Token); ok {
+		r0 = rf(tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ValidateToken provides a mock function with given fields: tokenString
 func (_m *JWTInterface) ValidateToken(tokenString string) (*jwt.Token, error) {
 	ret := _m.Called(tokenString)
@@ -62,6 +238,32 @@ func (_m *JWTInterface) ValidateToken(tokenString string) (*jwt.Token, error) {
 	return r0, r1
 }
 
+// ValidateTwoFactorToken provides a mock function with given fields: tokenString
+func (_m *JWTInterface) ValidateTwoFactorToken(tokenString string) (*jwt.Token, error) {
+	ret := _m.Called(tokenString)
+
+	var r0 *jwt.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*jwt.Token, error)); ok {
+		return rf(tokenString)
+	}
+	if rf, ok := ret.Get(0).(func(string) *jwt.Token); ok {
+		r0 = rf(tokenString)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jwt.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewJWTInterface creates a new instance of JWTInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewJWTInterface(t interface {
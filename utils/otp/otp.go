@@ -1,20 +1,23 @@
 package otp
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"math/big"
 )
 
-func GenerateRandomOTP(otpLent int) string {
-	src := rand.NewSource(time.Now().UnixNano())
-	r := rand.New(src)
-
+// GenerateRandomOTP draws every digit from crypto/rand so codes cannot be
+// predicted from the time they were generated.
+func GenerateRandomOTP(otpLent int) (string, error) {
 	const n = "0123456789"
 
 	otp := make([]byte, otpLent)
 	for i := range otp {
-		otp[i] = n[r.Intn(len(n))]
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(n))))
+		if err != nil {
+			return "", err
+		}
+		otp[i] = n[index.Int64()]
 	}
 
-	return string(otp)
+	return string(otp), nil
 }
@@ -0,0 +1,102 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
+	"github.com/midtrans/midtrans-go/coreapi"
+	"sync"
+	"time"
+)
+
+const (
+	TransactionPending    = "pending"
+	TransactionSettlement = "settlement"
+	TransactionExpire     = "expire"
+	TransactionDeny       = "deny"
+)
+
+// FakeGateway is an in-memory PaymentGateway for local development and tests.
+// Every charge starts in the configured status, and Settle, Expire and Deny move
+// a transaction the same way a Midtrans notification would. Notifications are
+// signed with serverKey exactly like Midtrans signs them.
+type FakeGateway struct {
+	mu            sync.Mutex
+	defaultStatus string
+	serverKey     string
+	transactions  map[string]string
+}
+
+func NewFakeGateway(defaultStatus, serverKey string) *FakeGateway {
+	if defaultStatus == "" {
+		defaultStatus = TransactionPending
+	}
+	return &FakeGateway{
+		defaultStatus: defaultStatus,
+		serverKey:     serverKey,
+		transactions:  make(map[string]string),
+	}
+}
+
+func (g *FakeGateway) Charge(request ChargeRequest) (interface{}, error) {
+	switch request.PaymentMethod {
+	case "qris", "bank_transfer", "gopay":
+	default:
+		return nil, errors.New("gagal membuat permintaan pembayaran")
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.transactions[request.OrderID]; exists {
+		return nil, errors.New("gagal membuat permintaan pembayaran")
+	}
+	g.transactions[request.OrderID] = g.defaultStatus
+
+	return &coreapi.ChargeResponse{
+		TransactionID:     "fake-" + request.OrderID,
+		OrderID:           request.OrderID,
+		GrossAmount:       fmt.Sprintf("%d.00", request.Amount),
+		PaymentType:       request.PaymentMethod,
+		TransactionTime:   time.Now().Format("2006-01-02 15:04:05"),
+		TransactionStatus: g.defaultStatus,
+		FraudStatus:       "accept",
+		StatusCode:        "201",
+		StatusMessage:     "Transaksi simulasi berhasil dibuat",
+	}, nil
+}
+
+func (g *FakeGateway) CheckTransaction(orderID string) (dto.Status, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	transactionStatus, exists := g.transactions[orderID]
+	if !exists {
+		return dto.Status{}, errors.New("transaksi tidak ditemukan")
+	}
+	return MapTransactionStatus(transactionStatus, "accept"), nil
+}
+
+func (g *FakeGateway) VerifyNotification(payload map[string]any) error {
+	return verifyNotificationSignature(payload, g.serverKey)
+}
+
+func (g *FakeGateway) Settle(orderID string) error {
+	return g.setStatus(orderID, TransactionSettlement)
+}
+
+func (g *FakeGateway) Expire(orderID string) error {
+	return g.setStatus(orderID, TransactionExpire)
+}
+
+func (g *FakeGateway) Deny(orderID string) error {
+	return g.setStatus(orderID, TransactionDeny)
+}
+
+func (g *FakeGateway) setStatus(orderID, transactionStatus string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, exists := g.transactions[orderID]; !exists {
+		return errors.New("transaksi tidak ditemukan")
+	}
+	g.transactions[orderID] = transactionStatus
+	return nil
+}
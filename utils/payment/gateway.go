@@ -0,0 +1,38 @@
+package payment
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
+	"log"
+)
+
+const (
+	GatewayMidtrans = "midtrans"
+	GatewayFake     = "fake"
+)
+
+type ChargeRequest struct {
+	OrderID       string
+	Amount        int64
+	PaymentMethod string
+	Name          string
+	Email         string
+}
+
+type PaymentGateway interface {
+	Charge(request ChargeRequest) (interface{}, error)
+	CheckTransaction(orderID string) (dto.Status, error)
+	VerifyNotification(payload map[string]any) error
+}
+
+func NewPaymentGateway(config config.Config) PaymentGateway {
+	switch config.Payment.Gateway {
+	case "", GatewayMidtrans:
+		return NewMidtransGateway(config)
+	case GatewayFake:
+		return NewFakeGateway(config.Payment.FakeStatus, config.ServerKey)
+	default:
+		log.Fatal("Config : invalid payment gateway ", config.Payment.Gateway)
+		return nil
+	}
+}
@@ -1,13 +1,69 @@
 package payment
 
 import (
+	"errors"
 	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"github.com/capstone-kelompok-7/backend-disappear/module/feature/order/dto"
 	"github.com/midtrans/midtrans-go"
 	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/sirupsen/logrus"
 )
 
+type MidtransGateway struct {
+	coreClient coreapi.Client
+	serverKey  string
+}
+
+func NewMidtransGateway(config config.Config) PaymentGateway {
+	return &MidtransGateway{
+		coreClient: InitSnapMidtrans(config),
+		serverKey:  config.ServerKey,
+	}
+}
+
 func InitSnapMidtrans(config config.Config) coreapi.Client {
+	environment := midtrans.Sandbox
+	if config.Payment.Environment == "production" {
+		environment = midtrans.Production
+	}
+
 	var coreClient coreapi.Client
-	coreClient.New(config.ServerKey, midtrans.Sandbox)
+	coreClient.New(config.ServerKey, environment)
 	return coreClient
 }
+
+func (g *MidtransGateway) Charge(request ChargeRequest) (interface{}, error) {
+	var paymentType coreapi.CoreapiPaymentType
+
+	switch request.PaymentMethod {
+	case "qris":
+		paymentType = coreapi.PaymentTypeQris
+	case "bank_transfer":
+		paymentType = coreapi.PaymentTypeBankTransfer
+	case "gopay":
+		paymentType = coreapi.PaymentTypeGopay
+	}
+
+	resp, err := CreateCoreAPIPaymentRequest(g.coreClient, request.OrderID, request.Amount, paymentType, request.Name, request.Email)
+	if err != nil {
+		logrus.Error(err)
+		return nil, errors.New("gagal membuat permintaan pembayaran")
+	}
+
+	return resp, nil
+}
+
+func (g *MidtransGateway) CheckTransaction(orderID string) (dto.Status, error) {
+	transactionStatusResp, err := g.coreClient.CheckTransaction(orderID)
+	if err != nil {
+		return dto.Status{}, err
+	}
+	if transactionStatusResp == nil {
+		return dto.Status{}, nil
+	}
+	return TransactionStatus(transactionStatusResp), nil
+}
+
+func (g *MidtransGateway) VerifyNotification(payload map[string]any) error {
+	return verifyNotificationSignature(payload, g.serverKey)
+}
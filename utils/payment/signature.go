@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidSignature is returned when a notification is unsigned or its
+// signature_key does not match the configured server key.
+var ErrInvalidSignature = errors.New("signature notifikasi tidak valid")
+
+// NotificationSignature computes the signature_key Midtrans attaches to every
+// HTTP notification: SHA512(order_id + status_code + gross_amount + server key).
+func NotificationSignature(orderID, statusCode, grossAmount, serverKey string) string {
+	hash := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(hash[:])
+}
+
+func verifyNotificationSignature(payload map[string]any, serverKey string) error {
+	orderID, _ := payload["order_id"].(string)
+	statusCode := payloadString(payload["status_code"])
+	grossAmount := payloadString(payload["gross_amount"])
+	signatureKey, _ := payload["signature_key"].(string)
+	if orderID == "" || signatureKey == "" {
+		return ErrInvalidSignature
+	}
+
+	expected := NotificationSignature(orderID, statusCode, grossAmount, serverKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signatureKey)) != 1 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func payloadString(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -6,29 +6,33 @@ import (
 )
 
 func TransactionStatus(transactionStatusResp *coreapi.TransactionStatusResponse) dto.Status {
+	return MapTransactionStatus(transactionStatusResp.TransactionStatus, transactionStatusResp.FraudStatus)
+}
+
+func MapTransactionStatus(transactionStatus, fraudStatus string) dto.Status {
 
 	var status dto.Status
-	if transactionStatusResp.TransactionStatus == "capture" {
-		if transactionStatusResp.FraudStatus == "challenge" {
+	if transactionStatus == "capture" {
+		if fraudStatus == "challenge" {
 			status.PaymentStatus = "challenge"
 			status.OrderStatus = "challenge"
-		} else if transactionStatusResp.FraudStatus == "accept" {
+		} else if fraudStatus == "accept" {
 			// TODO set transaction status on your database to 'success'
 			status.PaymentStatus = "Konfirmasi"
 			status.OrderStatus = "Proses"
 		}
-	} else if transactionStatusResp.TransactionStatus == "settlement" {
+	} else if transactionStatus == "settlement" {
 		// TODO set transaction status on your databaase to 'success'
 		status.PaymentStatus = "Konfirmasi"
 		status.OrderStatus = "Proses"
-	} else if transactionStatusResp.TransactionStatus == "deny" {
+	} else if transactionStatus == "deny" {
 		// TODO you can ignore 'deny', because most of the time it allows payment retries
 		// and later can become success
-	} else if transactionStatusResp.TransactionStatus == "cancel" || transactionStatusResp.TransactionStatus == "expire" {
+	} else if transactionStatus == "cancel" || transactionStatus == "expire" {
 		// TODO set transaction status on your databaase to 'failure'
 		status.PaymentStatus = "Gagal"
 		status.OrderStatus = "Gagal"
-	} else if transactionStatusResp.TransactionStatus == "pending" {
+	} else if transactionStatus == "pending" {
 		// TODO set transaction status on your databaase to 'pending' / waiting payment
 		status.PaymentStatus = "Menunggu Konfirmasi"
 		status.OrderStatus = "Menunggu Konfirmasi"
@@ -21,11 +21,12 @@ type SuccessResponses struct {
 }
 
 type PaginationMeta struct {
-	CurrentPage int `json:"current_page"`
-	TotalPage   int `json:"total_page"`
-	TotalItems  int `json:"total_items"`
-	NextPage    int `json:"next_page"`
-	PrevPage    int `json:"prev_page"`
+	CurrentPage int    `json:"current_page"`
+	TotalPage   int    `json:"total_page"`
+	TotalItems  int    `json:"total_items"`
+	NextPage    int    `json:"next_page"`
+	PrevPage    int    `json:"prev_page"`
+	NextCursor  string `json:"next_cursor,omitempty"`
 }
 
 type PaginationRes struct {
@@ -34,6 +35,17 @@ type PaginationRes struct {
 	Meta    PaginationMeta `json:"meta"`
 }
 
+type CursorPaginationMeta struct {
+	TotalItems int    `json:"total_items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type CursorPaginationRes struct {
+	Message string               `json:"message"`
+	Data    interface{}          `json:"data"`
+	Meta    CursorPaginationMeta `json:"meta"`
+}
+
 func SendStatusForbiddenResponse(c echo.Context, message string) error {
 	return c.JSON(http.StatusForbidden, ErrorResponse{
 		Message: message,
@@ -83,6 +95,33 @@ func SendPaginationResponse(c echo.Context, data interface{}, currentPage, total
 	})
 }
 
+func SendPaginationWithCursorResponse(c echo.Context, data interface{}, currentPage, totalPages, totalItems, nextPage, prevPage int, nextCursor, message string) error {
+	pagination := PaginationMeta{
+		CurrentPage: currentPage,
+		TotalPage:   totalPages,
+		TotalItems:  totalItems,
+		NextPage:    nextPage,
+		PrevPage:    prevPage,
+		NextCursor:  nextCursor,
+	}
+	return c.JSON(http.StatusOK, PaginationRes{
+		Message: message,
+		Data:    data,
+		Meta:    pagination,
+	})
+}
+
+func SendCursorPaginationResponse(c echo.Context, data interface{}, totalItems int, nextCursor, message string) error {
+	return c.JSON(http.StatusOK, CursorPaginationRes{
+		Message: message,
+		Data:    data,
+		Meta: CursorPaginationMeta{
+			TotalItems: totalItems,
+			NextCursor: nextCursor,
+		},
+	})
+}
+
 func SendStatusInternalServerResponse(c echo.Context, message string) error {
 	return c.JSON(http.StatusInternalServerError, ErrorResponse{
 		Message: message,
@@ -119,3 +158,9 @@ func SendStatusUnauthorizedResponse(c echo.Context, message string) error {
 		Message: message,
 	})
 }
+
+func SendStatusTooManyRequestsResponse(c echo.Context, message string) error {
+	return c.JSON(http.StatusTooManyRequests, ErrorResponse{
+		Message: message,
+	})
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching"
+	"github.com/sirupsen/logrus"
+)
+
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() error
+}
+
+type Scheduler struct {
+	cache caching.CacheRepository
+	jobs  []Job
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+func NewScheduler(cache caching.CacheRepository) *Scheduler {
+	return &Scheduler{
+		cache: cache,
+		stop:  make(chan struct{}),
+	}
+}
+
+func (s *Scheduler) AddJob(name string, interval time.Duration, run func() error) {
+	s.jobs = append(s.jobs, Job{
+		Name:     name,
+		Interval: interval,
+		Run:      run,
+	})
+}
+
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(job)
+	}
+}
+
+func (s *Scheduler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(job Job) {
+	defer s.wg.Done()
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.RunOnce(job, now)
+		}
+	}
+}
+
+// RunOnce runs job for the interval slot that contains now. The slot is claimed
+// in the cache first, so with several replicas only one of them runs each slot.
+func (s *Scheduler) RunOnce(job Job, now time.Time) bool {
+	slot := now.Truncate(job.Interval).Unix()
+	key := fmt.Sprintf("scheduler:%s:%d", job.Name, slot)
+	acquired, err := s.cache.SetNX(key, []byte("1"), job.Interval)
+	if err != nil {
+		logrus.Errorf("Scheduler : gagal mengambil lock %s: %s", job.Name, err.Error())
+		return false
+	}
+	if !acquired {
+		return false
+	}
+
+	if err := job.Run(); err != nil {
+		logrus.Errorf("Scheduler : job %s gagal: %s", job.Name, err.Error())
+	}
+	return true
+}
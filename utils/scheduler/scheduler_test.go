@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/capstone-kelompok-7/backend-disappear/utils/caching/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduler_RunOnce(t *testing.T) {
+	now := time.Date(2023, 12, 1, 10, 7, 30, 0, time.UTC)
+	slotKey := "scheduler:order-expiry:1701425100"
+
+	t.Run("Success Case - Lock Acquired", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(true, nil).Once()
+		runs := 0
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			runs++
+			return nil
+		}}
+
+		ran := NewScheduler(cache).RunOnce(job, now)
+
+		assert.True(t, ran)
+		assert.Equal(t, 1, runs)
+	})
+
+	t.Run("Success Case - Same Slot For Every Tick In Interval", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(true, nil).Once()
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(false, nil).Once()
+		runs := 0
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			runs++
+			return nil
+		}}
+		scheduler := NewScheduler(cache)
+
+		first := scheduler.RunOnce(job, now)
+		second := scheduler.RunOnce(job, now.Add(2*time.Minute))
+
+		assert.True(t, first)
+		assert.False(t, second)
+		assert.Equal(t, 1, runs)
+	})
+
+	t.Run("Success Case - Next Slot Runs Again", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(true, nil).Once()
+		cache.On("SetNX", "scheduler:order-expiry:1701425400", []byte("1"), 5*time.Minute).Return(true, nil).Once()
+		runs := 0
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			runs++
+			return nil
+		}}
+		scheduler := NewScheduler(cache)
+
+		scheduler.RunOnce(job, now)
+		scheduler.RunOnce(job, now.Add(5*time.Minute))
+
+		assert.Equal(t, 2, runs)
+	})
+
+	t.Run("Failed Case - Lock Held By Another Replica", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(false, nil).Once()
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			t.Fatal("job must not run without the lock")
+			return nil
+		}}
+
+		assert.False(t, NewScheduler(cache).RunOnce(job, now))
+	})
+
+	t.Run("Failed Case - Cache Unavailable", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(false, errors.New("connection refused")).Once()
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			t.Fatal("job must not run without the lock")
+			return nil
+		}}
+
+		assert.False(t, NewScheduler(cache).RunOnce(job, now))
+	})
+
+	t.Run("Success Case - Job Error Still Uses The Slot", func(t *testing.T) {
+		cache := mocks.NewCacheRepository(t)
+		cache.On("SetNX", slotKey, []byte("1"), 5*time.Minute).Return(true, nil).Once()
+		job := Job{Name: "order-expiry", Interval: 5 * time.Minute, Run: func() error {
+			return errors.New("database down")
+		}}
+
+		assert.True(t, NewScheduler(cache).RunOnce(job, now))
+	})
+}
+
+func TestScheduler_StartStop(t *testing.T) {
+	cache := mocks.NewCacheRepository(t)
+	cache.On("SetNX", mock.AnythingOfType("string"), []byte("1"), 10*time.Millisecond).Return(true, nil)
+	var runs int32
+	scheduler := NewScheduler(cache)
+	scheduler.AddJob("tick", 10*time.Millisecond, func() error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+
+	scheduler.Start()
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 2 }, time.Second, 5*time.Millisecond)
+	scheduler.Stop()
+
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
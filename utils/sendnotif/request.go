@@ -2,6 +2,7 @@ package sendnotif
 
 type SendNotificationRequest struct {
 	OrderID string `json:"order_id"`
+	Type    string `json:"type"`
 	UserID  uint64 `json:"user_id"`
 	Title   string `json:"title"`
 	Body    string `json:"body"`
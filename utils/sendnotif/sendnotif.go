@@ -3,44 +3,44 @@ package sendnotif
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"firebase.google.com/go"
 	"firebase.google.com/go/messaging"
 	"github.com/capstone-kelompok-7/backend-disappear/config"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/api/option"
+	"sync"
 )
 
+// ErrTokenUnregistered means FCM no longer knows the token, usually because the
+// app was uninstalled. The token should be removed.
+var ErrTokenUnregistered = errors.New("token perangkat tidak terdaftar")
+
 type FcmServiceInterface interface {
 	SendNotification(request SendNotificationRequest) (string, error)
 }
 
-type FcmService struct{}
+// FcmService keeps one Messaging client for the life of the process. It is
+// created on the first send and created again only if that failed.
+type FcmService struct {
+	mu     sync.Mutex
+	client *messaging.Client
+}
 
 func NewFcmService() *FcmService {
 	return &FcmService{}
 }
 
 func (f *FcmService) SendNotification(request SendNotificationRequest) (string, error) {
-	decodedKey, err := f.getDecodedFireBaseKey()
+	client, err := f.messagingClient()
 	if err != nil {
 		return "", err
 	}
 
-	opt := []option.ClientOption{option.WithCredentialsJSON(decodedKey)}
-	app, err := firebase.NewApp(context.Background(), nil, opt...)
-
-	if err != nil {
-		logrus.Error("Error initializing Firebase app", err)
-	}
-
-	client, err := app.Messaging(context.Background())
-	if err != nil {
-		logrus.Error("Error creating Firebase Messaging client", err)
-	}
-
 	message := &messaging.Message{
 		Data: map[string]string{
 			"order_id": request.OrderID,
+			"type":     request.Type,
 			"title":    request.Title,
 			"body":     request.Body,
 		},
@@ -50,16 +50,46 @@ func (f *FcmService) SendNotification(request SendNotificationRequest) (string,
 	response, err := client.Send(context.Background(), message)
 	if err != nil {
 		if messaging.IsRegistrationTokenNotRegistered(err) {
-			logrus.Error("Registration token is not valid. Remove it from your database.", err)
-		} else {
-			logrus.Error("Error sending message: ", err)
+			return "", ErrTokenUnregistered
 		}
+		logrus.Error("Error sending message: ", err)
+		return "", err
 	}
 
 	logrus.Info("Successfully sent message:", response)
 	return response, nil
 }
 
+func (f *FcmService) messagingClient() (*messaging.Client, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.client != nil {
+		return f.client, nil
+	}
+
+	decodedKey, err := f.getDecodedFireBaseKey()
+	if err != nil {
+		return nil, err
+	}
+
+	opt := []option.ClientOption{option.WithCredentialsJSON(decodedKey)}
+	app, err := firebase.NewApp(context.Background(), nil, opt...)
+	if err != nil {
+		logrus.Error("Error initializing Firebase app", err)
+		return nil, err
+	}
+
+	client, err := app.Messaging(context.Background())
+	if err != nil {
+		logrus.Error("Error creating Firebase Messaging client", err)
+		return nil, err
+	}
+
+	f.client = client
+	return client, nil
+}
+
 func (f *FcmService) getDecodedFireBaseKey() ([]byte, error) {
 	fireBaseAuthKey := config.InitConfig().FirebaseKey
 	decodedKey, err := base64.StdEncoding.DecodeString(fireBaseAuthKey)
@@ -0,0 +1,105 @@
+package shippingrate
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	zoneSameCity = iota
+	zoneRegional
+	zoneNational
+)
+
+type localService struct {
+	Courier     string
+	Service     string
+	Description string
+	Etd         [3]string
+	PricePerKg  [3]uint64
+}
+
+// LocalRateTable prices shipments from a static table. Destinations are grouped
+// into same city, the origin's region, and everywhere else; every started
+// kilogram is charged at the zone's price.
+type LocalRateTable struct {
+	regions  map[string]string
+	services []localService
+}
+
+func NewLocalRateTable() *LocalRateTable {
+	return &LocalRateTable{
+		regions: map[string]string{
+			"jakarta":    "jawa",
+			"bogor":      "jawa",
+			"depok":      "jawa",
+			"tangerang":  "jawa",
+			"bekasi":     "jawa",
+			"bandung":    "jawa",
+			"semarang":   "jawa",
+			"yogyakarta": "jawa",
+			"surabaya":   "jawa",
+			"malang":     "jawa",
+			"medan":      "sumatera",
+			"padang":     "sumatera",
+			"palembang":  "sumatera",
+			"pekanbaru":  "sumatera",
+			"denpasar":   "bali",
+			"makassar":   "sulawesi",
+			"manado":     "sulawesi",
+			"balikpapan": "kalimantan",
+			"pontianak":  "kalimantan",
+		},
+		services: []localService{
+			{Courier: "jne", Service: "REG", Description: "Layanan Reguler", Etd: [3]string{"1-2", "2-3", "3-5"}, PricePerKg: [3]uint64{9000, 12000, 25000}},
+			{Courier: "jne", Service: "YES", Description: "Yakin Esok Sampai", Etd: [3]string{"1", "1", "1-2"}, PricePerKg: [3]uint64{18000, 24000, 45000}},
+			{Courier: "jnt", Service: "EZ", Description: "Reguler", Etd: [3]string{"1-2", "2-3", "3-6"}, PricePerKg: [3]uint64{8000, 11000, 24000}},
+			{Courier: "sicepat", Service: "REG", Description: "Reguler", Etd: [3]string{"1-2", "2-3", "3-5"}, PricePerKg: [3]uint64{8500, 11500, 24500}},
+			{Courier: "sicepat", Service: "BEST", Description: "Besok Sampai Tujuan", Etd: [3]string{"1", "1", "1-2"}, PricePerKg: [3]uint64{16000, 22000, 42000}},
+		},
+	}
+}
+
+func (t *LocalRateTable) GetRates(request RateRequest) ([]Rate, error) {
+	if request.Destination == "" {
+		return nil, errors.New("kota tujuan tidak boleh kosong")
+	}
+
+	zone := t.zone(request.Origin, request.Destination)
+	kilograms := (request.Weight + 999) / 1000
+	if kilograms == 0 {
+		kilograms = 1
+	}
+
+	var rates []Rate
+	for _, service := range t.services {
+		if request.Courier != "" && !strings.EqualFold(request.Courier, service.Courier) {
+			continue
+		}
+		rates = append(rates, Rate{
+			Courier:     service.Courier,
+			Service:     service.Service,
+			Description: service.Description,
+			Cost:        service.PricePerKg[zone] * kilograms,
+			Etd:         service.Etd[zone],
+		})
+	}
+	if len(rates) == 0 {
+		return nil, errors.New("kurir tidak tersedia")
+	}
+	return rates, nil
+}
+
+func (t *LocalRateTable) zone(origin, destination string) int {
+	origin = strings.ToLower(strings.TrimSpace(origin))
+	destination = strings.ToLower(strings.TrimSpace(destination))
+	if origin == destination {
+		return zoneSameCity
+	}
+	originRegion, originFound := t.regions[origin]
+	destinationRegion, destinationFound := t.regions[destination]
+	if originFound && destinationFound && originRegion == destinationRegion {
+		return zoneRegional
+	}
+	return zoneNational
+}
@@ -0,0 +1,39 @@
+package shippingrate
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"log"
+)
+
+const (
+	ProviderLocal = "local"
+)
+
+type RateRequest struct {
+	Origin      string
+	Destination string
+	Weight      uint64
+	Courier     string
+}
+
+type Rate struct {
+	Courier     string `json:"courier"`
+	Service     string `json:"service"`
+	Description string `json:"description"`
+	Cost        uint64 `json:"cost"`
+	Etd         string `json:"etd"`
+}
+
+type RateProvider interface {
+	GetRates(request RateRequest) ([]Rate, error)
+}
+
+func NewRateProvider(config config.Config) RateProvider {
+	switch config.Shipping.Provider {
+	case "", ProviderLocal:
+		return NewLocalRateTable()
+	default:
+		log.Fatal("Config : invalid shipping rate provider ", config.Shipping.Provider)
+		return nil
+	}
+}
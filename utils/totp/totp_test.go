@@ -0,0 +1,94 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 seed "12345678901234567890" from RFC 6238
+// Appendix B, base32 encoded.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	// The RFC lists 8 digit codes; a 6 digit code is their last six digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, test := range tests {
+		code, err := GenerateCode(rfc6238Secret, Step(time.Unix(test.unix, 0)))
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.code, code, "time %d", test.unix)
+	}
+}
+
+func TestGenerateCode_LowercaseSecret(t *testing.T) {
+	code, err := GenerateCode("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", Step(time.Unix(59, 0)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "287082", code)
+}
+
+func TestGenerateCode_InvalidSecret(t *testing.T) {
+	_, err := GenerateCode("not base32!", 1)
+
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	t.Run("Accepts the current step", func(t *testing.T) {
+		step, ok := Validate(rfc6238Secret, "050471", now)
+
+		assert.True(t, ok)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("Accepts the adjacent steps for clock drift", func(t *testing.T) {
+		previous, _ := GenerateCode(rfc6238Secret, Step(now)-1)
+		next, _ := GenerateCode(rfc6238Secret, Step(now)+1)
+
+		previousStep, previousOK := Validate(rfc6238Secret, previous, now)
+		nextStep, nextOK := Validate(rfc6238Secret, next, now)
+
+		assert.True(t, previousOK)
+		assert.Equal(t, Step(now)-1, previousStep)
+		assert.True(t, nextOK)
+		assert.Equal(t, Step(now)+1, nextStep)
+	})
+
+	t.Run("Rejects codes outside the skew", func(t *testing.T) {
+		old, _ := GenerateCode(rfc6238Secret, Step(now)-2)
+
+		_, ok := Validate(rfc6238Secret, old, now)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Rejects codes of the wrong length", func(t *testing.T) {
+		_, ok := Validate(rfc6238Secret, "07081804", now)
+
+		assert.False(t, ok)
+	})
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	key, err := encoding.DecodeString(secret)
+	assert.NoError(t, err)
+	assert.Len(t, key, 20)
+}
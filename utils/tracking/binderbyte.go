@@ -0,0 +1,81 @@
+package tracking
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const binderbyteTrackURL = "https://api.binderbyte.com/v1/track"
+
+type BinderbyteTracker struct {
+	apiKey string
+	client *http.Client
+}
+
+func NewBinderbyteTracker(apiKey string) *BinderbyteTracker {
+	return &BinderbyteTracker{
+		apiKey: apiKey,
+		client: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type binderbyteResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	Data    struct {
+		Summary struct {
+			Status string `json:"status"`
+		} `json:"summary"`
+		History []struct {
+			Date     string `json:"date"`
+			Desc     string `json:"desc"`
+			Location string `json:"location"`
+		} `json:"history"`
+	} `json:"data"`
+}
+
+func (t *BinderbyteTracker) Track(courier, awb string) (*Result, error) {
+	req, err := http.NewRequest("GET", binderbyteTrackURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	query := req.URL.Query()
+	query.Add("courier", courier)
+	query.Add("awb", awb)
+	query.Add("api_key", t.apiKey)
+	req.URL.RawQuery = query.Encode()
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var trackingInfo binderbyteResponse
+	if err := json.NewDecoder(res.Body).Decode(&trackingInfo); err != nil {
+		return nil, err
+	}
+	if trackingInfo.Status != http.StatusOK {
+		return nil, errors.New("gagal melacak paket: " + trackingInfo.Message)
+	}
+
+	result := &Result{
+		Status:    trackingInfo.Data.Summary.Status,
+		Delivered: strings.EqualFold(trackingInfo.Data.Summary.Status, "DELIVERED"),
+	}
+	for _, history := range trackingInfo.Data.History {
+		date, _ := time.ParseInLocation("2006-01-02 15:04:05", history.Date, time.Local)
+		result.Events = append(result.Events, Event{
+			Date:        date,
+			Description: history.Desc,
+			Location:    history.Location,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package tracking
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOnProcess = "ON PROCESS"
+	StatusDelivered = "DELIVERED"
+)
+
+// FakeTracker is an in-memory Tracker for local development and tests. Every
+// airway bill it has not seen yet is reported as on process until Deliver is
+// called for it.
+type FakeTracker struct {
+	mu        sync.Mutex
+	shipments map[string]*Result
+}
+
+func NewFakeTracker() *FakeTracker {
+	return &FakeTracker{
+		shipments: make(map[string]*Result),
+	}
+}
+
+func (t *FakeTracker) Track(courier, awb string) (*Result, error) {
+	if awb == "" {
+		return nil, errors.New("nomor resi tidak boleh kosong")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := t.shipment(courier, awb)
+	return &Result{
+		Status:    result.Status,
+		Delivered: result.Delivered,
+		Events:    append([]Event(nil), result.Events...),
+	}, nil
+}
+
+func (t *FakeTracker) Deliver(courier, awb string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := t.shipment(courier, awb)
+	result.Status = StatusDelivered
+	result.Delivered = true
+	result.Events = append([]Event{{Date: time.Now(), Description: "Paket telah diterima"}}, result.Events...)
+}
+
+func (t *FakeTracker) shipment(courier, awb string) *Result {
+	key := courier + ":" + awb
+	result, exists := t.shipments[key]
+	if !exists {
+		result = &Result{
+			Status: StatusOnProcess,
+			Events: []Event{{Date: time.Now(), Description: "Paket telah diserahkan ke kurir"}},
+		}
+		t.shipments[key] = result
+	}
+	return result
+}
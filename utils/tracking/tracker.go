@@ -0,0 +1,40 @@
+package tracking
+
+import (
+	"github.com/capstone-kelompok-7/backend-disappear/config"
+	"log"
+	"time"
+)
+
+const (
+	TrackerBinderbyte = "binderbyte"
+	TrackerFake       = "fake"
+)
+
+type Event struct {
+	Date        time.Time
+	Description string
+	Location    string
+}
+
+type Result struct {
+	Status    string
+	Delivered bool
+	Events    []Event
+}
+
+type Tracker interface {
+	Track(courier, awb string) (*Result, error)
+}
+
+func NewTracker(config config.Config) Tracker {
+	switch config.Tracking.Provider {
+	case "", TrackerBinderbyte:
+		return NewBinderbyteTracker(config.ResiKey)
+	case TrackerFake:
+		return NewFakeTracker()
+	default:
+		log.Fatal("Config : invalid shipment tracker ", config.Tracking.Provider)
+		return nil
+	}
+}